@@ -41,6 +41,7 @@ const (
 	MinPaginationLimit     = 1
 	DefaultPaginationLimit = 100
 	MaxPaginationLimit     = 100
+	MaxAuditLogLimit       = 1000
 	OpRead                 = OperationType("read")
 	OpCreate               = OperationType("create")
 	OpUpdate               = OperationType("update")
@@ -516,6 +517,35 @@ var _ = Service("stream", func() {
 		})
 	})
 
+	Method("CancelTask", func() {
+		Meta("openapi:summary", "Cancel task")
+		Description("Request cancellation of a running task. The task is cancelled asynchronously, poll the task to get the final state.")
+		Result(Task)
+		Payload(GetTaskRequest)
+		HTTP(func() {
+			DELETE("/tasks/{*taskId}")
+			Meta("openapi:tag:configuration")
+			Response(StatusAccepted)
+			TaskNotFoundError()
+			TaskFinishedError()
+		})
+	})
+
+	// Audit endpoints -------------------------------------------------------------------------------------------------
+
+	Method("ListAuditLog", func() {
+		Meta("openapi:summary", "List audit log")
+		Description("List mutating API calls in the project, from the newest to the oldest.")
+		Result(AuditLog)
+		Payload(ListAuditLogRequest)
+		HTTP(func() {
+			GET("/audit")
+			Meta("openapi:tag:configuration")
+			Param("limit")
+			Response(StatusOK)
+		})
+	})
+
 	// Aggregation endpoints -------------------------------------------------------------------------------------------
 
 	Method("AggregationSources", func() {
@@ -1337,16 +1367,43 @@ var Task = Type("Task", func() {
 		Format(FormatDateTime)
 		Example("2022-04-28T14:20:04.000Z")
 	})
+	Attribute("cancelledAt", String, func() {
+		Description("Date and time of the task cancellation.")
+		Format(FormatDateTime)
+		Example("2022-04-28T14:20:04.000Z")
+	})
 	Attribute("duration", Int64, func() {
 		Description("Duration of the task in milliseconds.")
 		Example(123456789)
 	})
+	Attribute("progress", TaskProgress)
 	Attribute("result", String)
 	Attribute("error", String)
 	Attribute("outputs", TaskOutputs)
 	Required("taskId", "type", "url", "status", "isFinished", "createdAt")
 })
 
+var TaskProgress = Type("TaskProgress", func() {
+	Description("Progress of the task, reported by the running operation.")
+	Attribute("percent", Int, "Progress in percent.", func() {
+		Minimum(0)
+		Maximum(100)
+		Example(50)
+	})
+	Attribute("step", String, "Current step of the operation.", func() {
+		Example("create")
+	})
+	Attribute("message", String, "Message describing the current state.", func() {
+		Example("Creating the sink.")
+	})
+	Attribute("updatedAt", String, func() {
+		Description("Date and time of the last progress update.")
+		Format(FormatDateTime)
+		Example("2022-04-28T14:20:04.000Z")
+	})
+	Required("percent", "updatedAt")
+})
+
 var TaskOutputs = Type("TaskOutputs", func() {
 	Description("Outputs generated by the task.")
 	Attribute("url", String, "Absolute URL of the entity.")
@@ -1361,6 +1418,71 @@ var GetTaskRequest = Type("GetTaskRequest", func() {
 	Required("taskId")
 })
 
+// Audit ---------------------------------------------------------------------------------------------------------------
+
+var ListAuditLogRequest = Type("ListAuditLogRequest", func() {
+	Attribute("limit", Int, "Maximum number of returned entries.", func() {
+		Default(DefaultPaginationLimit)
+		Example(DefaultPaginationLimit)
+		Minimum(MinPaginationLimit)
+		Maximum(MaxAuditLogLimit)
+	})
+})
+
+var AuditLog = Type("AuditLog", func() {
+	Description("Audit log entries, from the newest to the oldest.")
+	Attribute("entries", ArrayOf(AuditEntry))
+	Required("entries")
+})
+
+var AuditEntry = Type("AuditEntry", func() {
+	Description("A mutating API call.")
+	Attribute("id", String, "Unique ID of the entry.", func() {
+		Example("2024-01-01T01:00:00.000Z_a1b2c3d4e5f6g7h")
+	})
+	Attribute("created", String, func() {
+		Description("Date and time of the API call.")
+		Format(FormatDateTime)
+		Example("2024-01-01T01:00:00.000Z")
+	})
+	Attribute("requestId", String, "ID of the API request.", func() {
+		Example("a1b2c3d4e5f6g7h")
+	})
+	Attribute("endpoint", String, "Name of the API endpoint.", func() {
+		Example("CreateSource")
+	})
+	Attribute("httpMethod", String, "HTTP method of the API request.", func() {
+		Example("POST")
+	})
+	Attribute("httpPath", String, "HTTP path of the API request.", func() {
+		Example("/v1/branches/default/sources")
+	})
+	Attribute("by", By)
+	Attribute("objectKey", String, "Key of the modified object.", func() {
+		Example("123/456/my-source")
+	})
+	Attribute("taskId", TaskID, "ID of the asynchronous task started by the API call.")
+	Attribute("before", MapOf(String, Any), "Summary of the object state before the API call.")
+	Attribute("after", MapOf(String, Any), "Summary of the expected object state after the API call.")
+	Attribute("result", AuditResult)
+	Required("id", "created", "endpoint", "httpMethod", "httpPath", "by", "result")
+})
+
+var AuditResult = Type("AuditResult", func() {
+	Description("Result of the API call, the result of an asynchronous operation is available in the task.")
+	Attribute("success", Boolean, "True, if the API call succeeded.")
+	Attribute("statusCode", Int, "HTTP status code of the failed API call.", func() {
+		Example(400)
+	})
+	Attribute("errorName", String, "Name of the error.", func() {
+		Example("stream.api.sourceNotFound")
+	})
+	Attribute("error", String, "Error message.", func() {
+		Example(`Source "my-source" not found in the branch.`)
+	})
+	Required("success")
+})
+
 // Aggregation ---------------------------------------------------------------------------------------------------------
 
 var AggregatedSourcesRequest = Type("AggregatedSourcesRequest", func() {
@@ -1470,6 +1592,10 @@ func TaskNotFoundError() {
 	GenericError(StatusNotFound, "taskNotFound", "Task not found error.", `Task "001" not found.`)
 }
 
+func TaskFinishedError() {
+	GenericError(StatusBadRequest, "taskFinished", "Task already finished error.", `Task "001" is already finished.`)
+}
+
 func ForbiddenProtectedSettingError() {
 	GenericError(StatusNotFound, "forbidden", "Modification of protected settings is forbidden.", `Cannot modify protected keys: "storage.level.local.encoding.compression.gzip.blockSize".`)
 }
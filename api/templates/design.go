@@ -29,6 +29,8 @@ const (
 	TaskStatusProcessing = "processing"
 	TaskStatusSuccess    = "success"
 	TaskStatusError      = "error"
+	DefaultAuditLogLimit = 100
+	MaxAuditLogLimit     = 1000
 )
 
 // API definition ------------------------------------------------------------------------------------------------------
@@ -177,6 +179,36 @@ var _ = Service("templates", func() {
 		})
 	})
 
+	Method("RefreshRepository", func() {
+		Meta("openapi:summary", "Refresh template repository")
+		Description("Pull the repository immediately, without waiting for the periodic update. The pull runs as an asynchronous task.")
+		Result(Task)
+		Payload(RepositoryRequest)
+		HTTP(func() {
+			POST("/repositories/{repository}/refresh")
+			Meta("openapi:tag:template")
+			Response(StatusAccepted)
+			RepositoryNotFoundError()
+		})
+	})
+
+	Method("RepositoryWebhook", func() {
+		Meta("openapi:summary", "Template repository webhook")
+		Description("Webhook target for a git host, for example GitHub \"push\" event. " +
+			"The request body must be signed by the configured secret, the signature is read from the \"X-Hub-Signature-256\" header. " +
+			"All instances of the default repository are pulled by an asynchronous task.")
+		NoSecurity()
+		Payload(RepositoryWebhookRequest)
+		HTTP(func() {
+			POST("/webhooks/repositories/{repository}")
+			Meta("openapi:tag:template")
+			Header("signature:X-Hub-Signature-256")
+			Response(StatusAccepted)
+			RepositoryNotFoundError()
+			SkipRequestBodyEncodeDecode()
+		})
+	})
+
 	Method("TemplatesIndex", func() {
 		Meta("openapi:summary", "List templates in the repository")
 		Description("List all templates  defined in the repository.")
@@ -221,12 +253,13 @@ var _ = Service("templates", func() {
 
 	Method("InputsIndex", func() {
 		Meta("openapi:summary", "Get inputs")
-		Description("Get inputs for the \"use\" API call.")
+		Description("Get inputs for the \"use\" API call. Use the \"jsonSchema\" format to get the inputs also as a JSON Schema for external UIs.")
 		Result(Inputs)
-		Payload(TemplateVersionRequest)
+		Payload(InputsIndexRequest)
 		HTTP(func() {
 			GET("/repositories/{repository}/templates/{template}/{version}/inputs")
 			Meta("openapi:tag:template")
+			Param("format")
 			Response(StatusOK)
 			RepositoryNotFoundError()
 			TemplateNotFoundError()
@@ -267,6 +300,19 @@ var _ = Service("templates", func() {
 		})
 	})
 
+	Method("InputLookup", func() {
+		Meta("openapi:summary", "Lookup input options")
+		Description("List existing tables, buckets or workspaces in the branch.\nThey are options for inputs of the \"table\", \"bucket\" and \"connection\" kinds.")
+		Result(InputLookupResult)
+		Payload(InputLookupRequest)
+		HTTP(func() {
+			GET("/project/{branch}/input-lookup/{kind}")
+			Meta("openapi:tag:template")
+			Response(StatusOK)
+			BranchNotFoundError()
+		})
+	})
+
 	// Instance endpoints ----------------------------------------------------------------------------------------------
 
 	Method("InstancesIndex", func() {
@@ -311,10 +357,11 @@ var _ = Service("templates", func() {
 	Method("DeleteInstance", func() {
 		Meta("openapi:summary", "Delete instance")
 		Result(Task)
-		Payload(InstanceRequest)
+		Payload(DeleteInstanceRequest)
 		HTTP(func() {
 			DELETE("/project/{branch}/instances/{instanceId}")
 			Meta("openapi:tag:instance")
+			Param("keepConfigs")
 			Response(StatusAccepted)
 			BranchNotFoundError()
 			InstanceNotFoundError()
@@ -322,6 +369,21 @@ var _ = Service("templates", func() {
 		})
 	})
 
+	Method("DeleteInstancePreview", func() {
+		Meta("openapi:summary", "Preview of the instance deletion")
+		Description("List configurations, rows and schedulers to be deleted, and storage tables they write. The tables are not deleted.")
+		Result(InstanceDeletePreview)
+		Payload(DeleteInstanceRequest)
+		HTTP(func() {
+			GET("/project/{branch}/instances/{instanceId}/delete-preview")
+			Meta("openapi:tag:instance")
+			Param("keepConfigs")
+			Response(StatusOK)
+			BranchNotFoundError()
+			InstanceNotFoundError()
+		})
+	})
+
 	Method("UpgradeInstance", func() {
 		Meta("openapi:summary", "Re-generate the instance in the same or different version")
 		Result(Task)
@@ -370,6 +432,69 @@ var _ = Service("templates", func() {
 		})
 	})
 
+	Method("InstanceHistoryIndex", func() {
+		Meta("openapi:summary", "Get history of the instance")
+		Description("List operations which modified the instance, from the newest. Values of the hidden inputs, for example passwords, are not included.")
+		Result(InstanceHistory)
+		Payload(InstanceRequest)
+		HTTP(func() {
+			GET("/project/{branch}/instances/{instanceId}/history")
+			Meta("openapi:tag:instance")
+			Response(StatusOK)
+			BranchNotFoundError()
+			InstanceNotFoundError()
+		})
+	})
+
+	Method("RollbackInstancePreview", func() {
+		Meta("openapi:summary", "Preview of the instance rollback")
+		Description("Show the previous state of the instance from the history and changes of the configurations caused by the rollback. Nothing is modified.")
+		Result(InstanceRollbackPreview)
+		Payload(InstanceRequest)
+		HTTP(func() {
+			GET("/project/{branch}/instances/{instanceId}/rollback")
+			Meta("openapi:tag:instance")
+			Response(StatusOK)
+			TemplateNotFoundError()
+			BranchNotFoundError()
+			InstanceNotFoundError()
+			VersionNotFoundError()
+			RollbackNotAvailableError()
+		})
+	})
+
+	Method("RollbackInstance", func() {
+		Meta("openapi:summary", "Rollback the instance to the previous state")
+		Description("Re-generate the instance in the previous version with the previous inputs, see the instance history. " +
+			"It can be used also to clean up a failed upgrade. Values of the hidden inputs are loaded from the current configurations.")
+		Result(Task)
+		Payload(InstanceRequest)
+		HTTP(func() {
+			POST("/project/{branch}/instances/{instanceId}/rollback")
+			Meta("openapi:tag:instance")
+			Response(StatusAccepted)
+			TemplateNotFoundError()
+			BranchNotFoundError()
+			InstanceNotFoundError()
+			VersionNotFoundError()
+			RollbackNotAvailableError()
+			ProjectLockedError()
+		})
+	})
+
+	Method("ListAuditLog", func() {
+		Meta("openapi:summary", "List audit log")
+		Description("List mutating API calls in the project, from the newest to the oldest.")
+		Result(AuditLog)
+		Payload(ListAuditLogRequest)
+		HTTP(func() {
+			GET("/audit")
+			Meta("openapi:tag:configuration")
+			Param("limit")
+			Response(StatusOK)
+		})
+	})
+
 	Method("GetTask", func() {
 		Meta("openapi:summary", "Get task")
 		Description("Get details of a task.")
@@ -382,6 +507,111 @@ var _ = Service("templates", func() {
 			TaskNotFoundError()
 		})
 	})
+
+	Method("CancelTask", func() {
+		Meta("openapi:summary", "Cancel task")
+		Description("Request cancellation of a running task. The task is cancelled asynchronously, poll the task to get the final state.")
+		Result(Task)
+		Payload(GetTaskRequest)
+		HTTP(func() {
+			DELETE("/tasks/{*taskId}")
+			Meta("openapi:tag:configuration")
+			Response(StatusAccepted)
+			TaskNotFoundError()
+			TaskFinishedError()
+		})
+	})
+})
+
+// Audit -------------------------------------------------------------------------------------------------------------
+
+var ListAuditLogRequest = Type("ListAuditLogRequest", func() {
+	Attribute("limit", Int, "Maximum number of returned entries.", func() {
+		Default(DefaultAuditLogLimit)
+		Example(DefaultAuditLogLimit)
+		Minimum(1)
+		Maximum(MaxAuditLogLimit)
+	})
+})
+
+var AuditLog = Type("AuditLog", func() {
+	Description("Audit log entries, from the newest to the oldest.")
+	Attribute("entries", ArrayOf(AuditEntry))
+	Required("entries")
+})
+
+var AuditEntry = Type("AuditEntry", func() {
+	Description("A mutating API call.")
+	Attribute("id", String, "Unique ID of the entry.", func() {
+		Example("2024-01-01T01:00:00.000Z_a1b2c3d4e5f6g7h")
+	})
+	Attribute("created", String, func() {
+		Description("Date and time of the API call.")
+		Format(FormatDateTime)
+		Example("2024-01-01T01:00:00.000Z")
+	})
+	Attribute("requestId", String, "ID of the API request.", func() {
+		Example("a1b2c3d4e5f6g7h")
+	})
+	Attribute("endpoint", String, "Name of the API endpoint.", func() {
+		Example("UpgradeInstance")
+	})
+	Attribute("httpMethod", String, "HTTP method of the API request.", func() {
+		Example("POST")
+	})
+	Attribute("httpPath", String, "HTTP path of the API request.", func() {
+		Example("/v1/project/default/instances/V1StGXR8IZ5jdHi6BAmyT/upgrade/1.2.3")
+	})
+	Attribute("by", AuditBy)
+	Attribute("objectKey", String, "Key of the modified object.", func() {
+		Example("123/456/V1StGXR8IZ5jdHi6BAmyT")
+	})
+	Attribute("taskId", TaskID, "ID of the asynchronous task started by the API call.")
+	Attribute("before", MapOf(String, Any), "Summary of the object state before the API call.")
+	Attribute("after", MapOf(String, Any), "Summary of the expected object state after the API call.")
+	Attribute("result", AuditResult)
+	Required("id", "created", "endpoint", "httpMethod", "httpPath", "by", "result")
+})
+
+var AuditBy = Type("AuditBy", func() {
+	Description("Information about the API call actor.")
+	Attribute("type", String, func() {
+		Description("Type of the actor.")
+		Enum("user")
+		Example("user")
+	})
+	Attribute("tokenId", String, func() {
+		Description(`ID of the token.`)
+		Example("896455")
+	})
+	Attribute("tokenDesc", String, func() {
+		Description(`Description of the token.`)
+		Example("john.green@company.com")
+	})
+	Attribute("userId", String, func() {
+		Description(`ID of the user.`)
+		Example("578621")
+	})
+	Attribute("userName", String, func() {
+		Description(`Name of the user.`)
+		Example("John Green")
+	})
+	Required("type")
+})
+
+var AuditResult = Type("AuditResult", func() {
+	Description("Result of the API call, the result of an asynchronous operation is available in the task.")
+	Attribute("success", Boolean, "True, if the API call succeeded.")
+	Attribute("statusCode", Int, "HTTP status code of the failed API call.", func() {
+		Example(400)
+	})
+	Attribute("errorName", String, "Name of the error.", func() {
+		Example("templates.instanceNotFound")
+	})
+	Attribute("error", String, "Error message.", func() {
+		Example(`Instance "V1StGXR8IZ5jdHi6BAmyT" not found.`)
+	})
+	Required("success")
 })
 
 // Task --------------------------------------------------------------------------------------------------------------
@@ -417,10 +647,16 @@ var Task = Type("Task", func() {
 		Format(FormatDateTime)
 		Example("2022-04-28T14:20:04.000Z")
 	})
+	Attribute("cancelledAt", String, func() {
+		Description("Date and time of the task cancellation.")
+		Format(FormatDateTime)
+		Example("2022-04-28T14:20:04.000Z")
+	})
 	Attribute("duration", Int64, func() {
 		Description("Duration of the task in milliseconds.")
 		Example(123456789)
 	})
+	Attribute("progress", TaskProgress)
 	Attribute("result", String)
 	Attribute("error", String)
 	Attribute("outputs", TaskOutputs)
@@ -428,6 +664,27 @@ var Task = Type("Task", func() {
 	Example(ExampleTask())
 })
 
+var TaskProgress = Type("TaskProgress", func() {
+	Description("Progress of the task, reported by the running operation.")
+	Attribute("percent", Int, "Progress in percent.", func() {
+		Minimum(0)
+		Maximum(100)
+		Example(50)
+	})
+	Attribute("step", String, "Current step of the operation.", func() {
+		Example("push")
+	})
+	Attribute("message", String, "Message describing the current state.", func() {
+		Example("Pushing changes to the project.")
+	})
+	Attribute("updatedAt", String, func() {
+		Description("Date and time of the last progress update.")
+		Format(FormatDateTime)
+		Example("2022-04-28T14:20:04.000Z")
+	})
+	Required("percent", "updatedAt")
+})
+
 var TaskOutputs = Type("TaskOutputs", func() {
 	Description("Outputs generated by the task.")
 	Attribute("instanceId", InstanceID, "ID of the created/updated template instance.")
@@ -543,10 +800,18 @@ func InstanceNotFoundError() {
 	GenericError(StatusNotFound, "templates.instanceNotFound", "Instance not found error.", `Instance "V1StGXR8IZ5jdHi6BAmyT" not found.`)
 }
 
+func RollbackNotAvailableError() {
+	GenericError(StatusBadRequest, "templates.rollbackNotAvailable", "Rollback not available error.", `Instance "V1StGXR8IZ5jdHi6BAmyT" has no previous state in the history.`)
+}
+
 func TaskNotFoundError() {
 	GenericError(StatusNotFound, "templates.taskNotFound", "Task not found error.", `Task "001" not found.`)
 }
 
+func TaskFinishedError() {
+	GenericError(StatusBadRequest, "templates.taskFinished", "Task already finished error.", `Task "001" is already finished.`)
+}
+
 // Common attributes----------------------------------------------------------------------------------------------------
 
 var tokenSecurity = APIKeySecurity("storage-api-token", func() {
@@ -561,6 +826,14 @@ var RepositoryRequest = Type("RepositoryRequest", func() {
 	Required("repository")
 })
 
+var RepositoryWebhookRequest = Type("RepositoryWebhookRequest", func() {
+	Extend(RepositoryRequest)
+	Attribute("signature", String, func() {
+		Description("HMAC SHA256 signature of the request body, in the \"sha256=<hex>\" format.")
+		Example("sha256=757107ea0eb2509fc211221cce984b8a37570b6d7586c22c46f4379c8b043e17")
+	})
+})
+
 var TemplateRequest = Type("TemplateRequest", func() {
 	Extend(RepositoryRequest)
 	Attribute("template", TemplateID)
@@ -572,6 +845,16 @@ var TemplateVersionRequest = Type("TemplateVersionRequest", func() {
 	templateVersionAttr()
 })
 
+var InputsIndexRequest = Type("InputsIndexRequest", func() {
+	Extend(TemplateVersionRequest)
+	Attribute("format", String, func() {
+		Description("Format of the response. The \"jsonSchema\" format adds the inputs as a JSON Schema with UI hints.")
+		Enum("default", "jsonSchema")
+		Default("default")
+		Example("jsonSchema")
+	})
+})
+
 var BranchRequest = Type("BranchRequest", func() {
 	Attribute("branch", String, func() {
 		Example("default")
@@ -586,6 +869,14 @@ var InstanceRequest = Type("InstanceRequest", func() {
 	Required("instanceId")
 })
 
+var DeleteInstanceRequest = Type("DeleteInstanceRequest", func() {
+	Extend(InstanceRequest)
+	Attribute("keepConfigs", ArrayOf(String), func() {
+		Description("Configurations to keep, in the \"<componentId>:<configId>\" format. They are converted to regular configurations, the template metadata are removed.")
+		Example([]string{"keboola.ex-db-mysql:7954825835"})
+	})
+})
+
 var InputsPayload = Type("InputsPayload", func() {
 	Attribute("steps", ArrayOf(StepPayload), "Steps with input values filled in by user.", func() {
 		Example([]ExampleStepPayloadData{ExampleStepPayload()})
@@ -722,10 +1013,30 @@ var Repository = Type("Repository", func() {
 	Attribute("author", Author, func() {
 		Example(ExampleAuthor())
 	})
+	Attribute("status", RepositoryStatus, "Result of the last repository pull. It is set only in the repository detail.")
 	Required("name", "url", "ref", "author")
 	Example(ExampleRepository())
 })
 
+var RepositoryStatus = Type("RepositoryStatus", func() {
+	Description("Result of the last repository pull.")
+	Attribute("commitHash", String, "Hash of the checked out commit.", func() {
+		Example("2c4ff6ac8e6b3ac3d4e30c4e0e4cbc1e1e1f9b3c")
+	})
+	Attribute("lastUpdate", String, "Date and time of the last successful pull.", func() {
+		Format(FormatDateTime)
+		Example("2022-04-28T14:20:04.000Z")
+	})
+	Attribute("lastError", String, "Error of the last failed pull. It is cleared by the next successful pull.", func() {
+		Example("cannot pull the repository: authentication required")
+	})
+	Attribute("lastErrorAt", String, "Date and time of the last failed pull.", func() {
+		Format(FormatDateTime)
+		Example("2022-04-28T14:20:04.000Z")
+	})
+	Required("commitHash")
+})
+
 var Templates = Type("Templates", func() {
 	Description("List of the templates.")
 	Attribute("repository", Repository, "Information about the repository.")
@@ -938,6 +1249,7 @@ var Inputs = Type("Inputs", func() {
 	Attribute("initialState", ValidationResult, "Initial state - same structure as the validation result.", func() {
 		Example(ExampleValidationResult())
 	})
+	Attribute("jsonSchema", Any, "JSON Schema of the inputs values with the \"x-keboola-*\" UI hints. Present only in the \"jsonSchema\" format.")
 	Required("stepGroups", "initialState")
 })
 
@@ -1017,7 +1329,7 @@ var Input = Type("input", func() {
 		Example("string")
 	})
 	Attribute("kind", String, "Kind of the input.", func() {
-		Enum("input", "hidden", "textarea", "confirm", "select", "multiselect", "oauth", "oauthAccounts")
+		Enum("input", "hidden", "textarea", "confirm", "select", "multiselect", "oauth", "oauthAccounts", "table", "bucket", "connection")
 		Example("input")
 	})
 	Attribute("default", Any, "Default value, match defined type.", func() {
@@ -1044,6 +1356,34 @@ var Input = Type("input", func() {
 	Example(ExampleInput())
 })
 
+var InputLookupRequest = Type("InputLookupRequest", func() {
+	Extend(BranchRequest)
+	Attribute("kind", String, "Kind of the input.", func() {
+		Enum("table", "bucket", "connection")
+		Example("table")
+	})
+	Required("kind")
+})
+
+var InputLookupResult = Type("InputLookupResult", func() {
+	Description("Existing objects in the branch, options for an input of the kind.")
+	Attribute("kind", String, "Kind of the input.", func() {
+		Example("table")
+	})
+	Attribute("options", ArrayOf(InputLookupOption), "Existing objects. Value is a table ID, a bucket ID or a workspace ID.")
+	Required("kind", "options")
+})
+
+var InputLookupOption = Type("InputLookupOption", func() {
+	Attribute("value", String, "Value of the option.", func() {
+		Example("in.c-bucket.table")
+	})
+	Attribute("label", String, "Visible label of the option.", func() {
+		Example("table (in.c-bucket.table)")
+	})
+	Required("value", "label")
+})
+
 var InputOption = Type("inputOption", func() {
 	Description("Input option for type = select OR multiselect.")
 	Attribute("label", String, "Visible label of the option.", func() {
@@ -1156,6 +1496,116 @@ var Config = Type("Config", func() {
 	Required("componentId", "configId", "name")
 })
 
+var InstanceHistory = Type("InstanceHistory", func() {
+	Description("History of the template instance.")
+	Attribute("records", ArrayOf(InstanceHistoryRecord), "Operations which modified the instance, from the newest.")
+	Required("records")
+})
+
+var InstanceHistoryRecord = Type("InstanceHistoryRecord", func() {
+	Description("An operation which modified the template instance.")
+	Attribute("operation", String, "Operation type.", func() {
+		Enum("use", "upgrade", "update", "rollback")
+		Example("upgrade")
+	})
+	Attribute("changed", ChangeInfo, "Date of the operation and who made it.")
+	Attribute("repositoryName", String, func() {
+		Example("keboola")
+		Description("Name of the template repository.")
+	})
+	Attribute("templateId", TemplateID)
+	Attribute("version", String, func() {
+		Example("v1.1.0")
+		Description("Semantic version of the template.")
+	})
+	Attribute("name", String, "Name of the instance.", func() {
+		Example("My Instance")
+	})
+	Attribute("inputs", ArrayOf(InstanceHistoryInput), "Values of the template inputs, hidden inputs are not included.")
+	Attribute("error", String, "Error of the operation. If it is set, the instance may be partially modified.", func() {
+		Example("cannot push changes")
+	})
+	Required("operation", "changed", "repositoryName", "templateId", "version", "name", "inputs")
+})
+
+var InstanceHistoryInput = Type("InstanceHistoryInput", func() {
+	Attribute("id", String, "Input ID.", func() {
+		Example("api-token")
+	})
+	Attribute("value", Any, "Input value.", func() {
+		Example("foo")
+	})
+	Attribute("skipped", Boolean, "The input was not visible or the step was skipped, the default value was used.")
+	Required("id", "skipped")
+})
+
+var InstanceRollbackPreview = Type("InstanceRollbackPreview", func() {
+	Attribute("target", InstanceHistoryRecord, "The previous state of the instance to be restored.")
+	Attribute("changes", ArrayOf(RollbackChange), "Configurations and rows modified by the rollback.")
+	Required("target", "changes")
+})
+
+var RollbackChange = Type("RollbackChange", func() {
+	Description("The configuration or row modified by the rollback.")
+	Attribute("componentId", String, "Component ID.", func() {
+		Example("keboola.ex-db-mysql")
+	})
+	Attribute("configId", String, "Configuration ID.", func() {
+		Example("7954825835")
+	})
+	Attribute("rowId", String, "Row ID, it is set if the change is in a configuration row.", func() {
+		Example("7954825836")
+	})
+	Attribute("name", String, "Name of the configuration or row.", func() {
+		Example("My Extractor")
+	})
+	Attribute("action", String, "The object is created, updated or deleted by the rollback.", func() {
+		Enum("create", "update", "delete")
+		Example("update")
+	})
+	Attribute("changedFields", ArrayOf(String), "Changed fields, it is set if the object is updated.", func() {
+		Example([]string{"configuration", "name"})
+	})
+	Required("componentId", "configId", "name", "action", "changedFields")
+})
+
+var InstanceDeletePreview = Type("InstanceDeletePreview", func() {
+	Attribute("configurations", ArrayOf(DeletePreviewConfig), "Configurations of the instance and their schedulers.")
+	Required("configurations")
+})
+
+var DeletePreviewConfig = Type("DeletePreviewConfig", func() {
+	Description("The configuration affected by the instance deletion.")
+	Attribute("componentId", String, "Component ID.", func() {
+		Example("keboola.ex-db-mysql")
+	})
+	Attribute("configId", String, "Configuration ID.", func() {
+		Example("7954825835")
+	})
+	Attribute("name", String, "Name of the configuration.", func() {
+		Example("My Extractor")
+	})
+	Attribute("action", String, "The configuration is deleted or kept as a regular configuration.", func() {
+		Enum("delete", "keep")
+		Example("delete")
+	})
+	Attribute("rows", ArrayOf(DeletePreviewRow), "Rows of the configuration.")
+	Attribute("outputTables", ArrayOf(String), "Storage tables written by the configuration or its rows.", func() {
+		Example([]string{"in.c-bucket.table"})
+	})
+	Required("componentId", "configId", "name", "action", "rows", "outputTables")
+})
+
+var DeletePreviewRow = Type("DeletePreviewRow", func() {
+	Attribute("rowId", String, "Row ID.", func() {
+		Example("7954825836")
+	})
+	Attribute("name", String, "Name of the row.", func() {
+		Example("My Table")
+	})
+	Required("rowId", "name")
+})
+
 var ChangeInfo = Type("ChangeInfo", func() {
 	Description("Date of change and who made it.")
 	Attribute("date", String, func() {
@@ -5,6 +5,7 @@ import (
 	"context"
 	"net/http"
 	"os"
+	"sync"
 
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
@@ -21,6 +22,7 @@ import (
 	"github.com/keboola/keboola-as-code/internal/pkg/telemetry"
 	"github.com/keboola/keboola-as-code/internal/pkg/telemetry/datadog"
 	"github.com/keboola/keboola-as-code/internal/pkg/telemetry/metric/prometheus"
+	"github.com/keboola/keboola-as-code/internal/pkg/telemetry/otlp"
 	"github.com/keboola/keboola-as-code/internal/pkg/telemetry/pprof"
 	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
 )
@@ -31,12 +33,18 @@ const (
 )
 
 func main() {
-	entrypoint.Run(run, config.New(), entrypoint.Config{ENVPrefix: ENVPrefix})
+	entrypoint.RunWithReload(run, config.New, entrypoint.Config{ENVPrefix: ENVPrefix})
 }
 
-func run(ctx context.Context, cfg config.Config, _ []string) error {
-	// Create logger
-	logger := log.NewServiceLogger(os.Stdout, cfg.DebugLog) // nolint:forbidigo
+func run(ctx context.Context, configReloader *configmap.Reloader[config.Config], _ []string) error {
+	cfg := configReloader.Current()
+
+	// Create logger, the verbosity can be changed by the configuration reload
+	verbosity := log.NewVerbosity(cfg.DebugLog)
+	logger := log.NewServiceLoggerWithVerbosity(os.Stdout, verbosity) // nolint:forbidigo
+	configReloader.OnReload(func(ctx context.Context, cfg config.Config) {
+		verbosity.SetVerbose(cfg.DebugLog)
+	})
 
 	// Dump configuration, sensitive values are masked
 	dump, err := configmap.NewDumper().Dump(cfg).AsJSON(false)
@@ -80,6 +88,9 @@ func run(ctx context.Context, cfg config.Config, _ []string) error {
 	// Setup telemetry
 	tel, err := telemetry.New(
 		func() (trace.TracerProvider, error) {
+			if cfg.OTLP.TracesEnabled() {
+				return otlp.NewTracerProvider(ctx, cfg.OTLP, logger, proc, ServiceName)
+			}
 			if cfg.Datadog.Enabled {
 				return datadog.NewTracerProvider(
 					logger, proc,
@@ -92,6 +103,9 @@ func run(ctx context.Context, cfg config.Config, _ []string) error {
 			return nil, nil
 		},
 		func() (metric.MeterProvider, error) {
+			if cfg.OTLP.MetricsEnabled() {
+				return otlp.NewMeterProvider(ctx, cfg.OTLP, logger, proc, ServiceName)
+			}
 			return prometheus.ServeMetrics(ctx, cfg.Metrics, logger, proc, ServiceName)
 		},
 	)
@@ -100,7 +114,7 @@ func run(ctx context.Context, cfg config.Config, _ []string) error {
 	}
 
 	// Create dependencies
-	d, err := dependencies.NewServiceScope(ctx, cfg, proc, logger, tel, os.Stdout, os.Stderr) // nolint:forbidigo
+	d, err := dependencies.NewServiceScope(ctx, configReloader, proc, logger, tel, os.Stdout, os.Stderr) // nolint:forbidigo
 	if err != nil {
 		return err
 	}
@@ -112,7 +126,17 @@ func run(ctx context.Context, cfg config.Config, _ []string) error {
 		return err
 	}
 
+	// Reload configuration on SIGHUP or on a configuration file change
+	reloadCtx, reloadCancel := context.WithCancel(ctx)
+	reloadWg := &sync.WaitGroup{}
+	if err := configReloader.Watch(reloadCtx, reloadWg, logger); err != nil {
+		reloadCancel()
+		return err
+	}
+
 	// Wait for the service shutdown
 	proc.WaitForShutdown()
+	reloadCancel()
+	reloadWg.Wait()
 	return nil
 }
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"os"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/entrypoint"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/servicectx"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/admin"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/dependencies"
+	"github.com/keboola/keboola-as-code/internal/pkg/telemetry"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+// ENVPrefix is the same as in the Stream service, so the admin command can be run with the service configuration.
+const ENVPrefix = "STREAM_"
+
+func main() {
+	entrypoint.Run(run, admin.NewConfig(), entrypoint.Config{ENVPrefix: ENVPrefix})
+}
+
+func run(ctx context.Context, cfg admin.Config, posArgs []string) error {
+	// Logs are written to STDERR, the STDOUT contains only the command output
+	logger := log.NewServiceLogger(os.Stderr, cfg.DebugLog) // nolint:forbidigo
+
+	// Create process abstraction
+	proc := servicectx.New(servicectx.WithLogger(logger))
+	defer func() {
+		proc.Shutdown(ctx, errors.New("command finished"))
+		proc.WaitForShutdown()
+	}()
+
+	// Create dependencies
+	d, err := dependencies.NewAdminScope(ctx, cfg.Config, proc, logger, telemetry.NewNop(), os.Stderr, os.Stderr) //nolint:forbidigo
+	if err != nil {
+		return err
+	}
+
+	return admin.New(d, os.Stdout, cfg).Run(ctx, posArgs) //nolint:forbidigo
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"os"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/configmap"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/entrypoint"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/servicectx"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/devmode"
+	"github.com/keboola/keboola-as-code/internal/pkg/telemetry"
+)
+
+// ENVPrefix differs from the Stream service, so the dev mode cannot be accidentally connected to a real cluster.
+const ENVPrefix = "STREAM_DEV_"
+
+func main() {
+	entrypoint.Run(run, devmode.NewConfig(), entrypoint.Config{ENVPrefix: ENVPrefix})
+}
+
+func run(ctx context.Context, cfg devmode.Config, _ []string) error {
+	logger := log.NewServiceLogger(os.Stdout, cfg.DebugLog) // nolint:forbidigo
+
+	// Dump configuration, sensitive values are masked
+	dump, err := configmap.NewDumper().Dump(cfg).AsJSON(false)
+	if err == nil {
+		logger.Infof(ctx, "configuration: %s", string(dump))
+	} else {
+		return err
+	}
+
+	// Create process abstraction
+	proc := servicectx.New(servicectx.WithLogger(logger))
+
+	// Start embedded etcd and all components, telemetry is not exported
+	if _, err := devmode.Start(ctx, cfg, logger, proc, telemetry.NewNop()); err != nil {
+		proc.Shutdown(ctx, err)
+		proc.WaitForShutdown()
+		return err
+	}
+
+	// Wait for the service shutdown
+	proc.WaitForShutdown()
+	return nil
+}
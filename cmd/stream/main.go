@@ -4,8 +4,10 @@ import (
 	"context"
 	"net/http"
 	"os"
+	"sync"
 	"syscall"
 
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
 	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
@@ -21,6 +23,7 @@ import (
 	"github.com/keboola/keboola-as-code/internal/pkg/telemetry"
 	"github.com/keboola/keboola-as-code/internal/pkg/telemetry/datadog"
 	"github.com/keboola/keboola-as-code/internal/pkg/telemetry/metric/prometheus"
+	"github.com/keboola/keboola-as-code/internal/pkg/telemetry/otlp"
 	"github.com/keboola/keboola-as-code/internal/pkg/telemetry/pprof"
 	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
 )
@@ -31,12 +34,18 @@ const (
 )
 
 func main() {
-	entrypoint.Run(run, config.New(), entrypoint.Config{ENVPrefix: ENVPrefix})
+	entrypoint.RunWithReload(run, config.New, entrypoint.Config{ENVPrefix: ENVPrefix})
 }
 
-func run(ctx context.Context, cfg config.Config, posArgs []string) error {
-	// Create logger
-	logger := log.NewServiceLogger(os.Stdout, cfg.DebugLog) // nolint:forbidigo
+func run(ctx context.Context, configReloader *configmap.Reloader[config.Config], posArgs []string) error {
+	cfg := configReloader.Current()
+
+	// Create logger, the verbosity can be changed by the configuration reload
+	verbosity := log.NewVerbosity(cfg.DebugLog)
+	logger := log.NewServiceLoggerWithVerbosity(os.Stdout, verbosity) // nolint:forbidigo
+	configReloader.OnReload(func(ctx context.Context, cfg config.Config) {
+		verbosity.SetVerbose(cfg.DebugLog)
+	})
 
 	// Get list of enabled components
 	components, err := stream.ParseComponentsList(posArgs)
@@ -85,6 +94,9 @@ func run(ctx context.Context, cfg config.Config, posArgs []string) error {
 	// Setup telemetry
 	tel, err := telemetry.New(
 		func() (trace.TracerProvider, error) {
+			if cfg.OTLP.TracesEnabled() {
+				return otlp.NewTracerProvider(ctx, cfg.OTLP, logger, proc, ServiceName, attribute.String("stream.components", components.String()))
+			}
 			if cfg.Datadog.Enabled {
 				return datadog.NewTracerProvider(
 					logger, proc,
@@ -98,6 +110,9 @@ func run(ctx context.Context, cfg config.Config, posArgs []string) error {
 			return nil, nil
 		},
 		func() (metric.MeterProvider, error) {
+			if cfg.OTLP.MetricsEnabled() {
+				return otlp.NewMeterProvider(ctx, cfg.OTLP, logger, proc, ServiceName)
+			}
 			return prometheus.ServeMetrics(ctx, cfg.Metrics, logger, proc, ServiceName)
 		},
 	)
@@ -114,7 +129,7 @@ func run(ctx context.Context, cfg config.Config, posArgs []string) error {
 	}
 
 	// Create dependencies
-	d, err := dependencies.NewServiceScope(ctx, cfg, proc, logger, tel, os.Stdout, os.Stderr) //nolint:forbidigo
+	d, err := dependencies.NewServiceScope(ctx, configReloader, proc, logger, tel, os.Stdout, os.Stderr) //nolint:forbidigo
 	if err != nil {
 		return err
 	}
@@ -124,7 +139,17 @@ func run(ctx context.Context, cfg config.Config, posArgs []string) error {
 		return err
 	}
 
+	// Reload configuration on SIGHUP or on a configuration file change
+	reloadCtx, reloadCancel := context.WithCancel(ctx)
+	reloadWg := &sync.WaitGroup{}
+	if err := configReloader.Watch(reloadCtx, reloadWg, logger); err != nil {
+		reloadCancel()
+		return err
+	}
+
 	// Wait for the service shutdown
 	proc.WaitForShutdown()
+	reloadCancel()
+	reloadWg.Wait()
 	return nil
 }
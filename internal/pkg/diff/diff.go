@@ -96,18 +96,7 @@ func (d *Differ) Diff() (*Results, error) {
 		if err != nil {
 			d.errors.Append(err)
 		} else {
-			if result.State != ResultEqual {
-				results.Equal = false
-			}
-			if result.State == ResultNotEqual {
-				results.HasNotEqualResult = true
-			}
-			if result.State != ResultOnlyInRemote {
-				results.HasOnlyInRemoteResult = true
-			}
-			if result.State != ResultOnlyInLocal {
-				results.HasOnlyInLocalResult = true
-			}
+			results.updateFlags(result)
 			d.results = append(d.results, result)
 		}
 	}
@@ -281,6 +270,33 @@ func (d *Differ) getDiffFields(t reflect.Type) []*reflecthelper.StructField {
 	}
 }
 
+// Subtree returns results of the object and all its children, for example a config and its rows.
+func (r *Results) Subtree(key model.Key) *Results {
+	out := &Results{Equal: true, Objects: r.Objects}
+	for _, result := range r.Results {
+		if isInSubtree(result.Key(), key) {
+			out.updateFlags(result)
+			out.Results = append(out.Results, result)
+		}
+	}
+	return out
+}
+
+func (r *Results) updateFlags(result *Result) {
+	if result.State != ResultEqual {
+		r.Equal = false
+	}
+	if result.State == ResultNotEqual {
+		r.HasNotEqualResult = true
+	}
+	if result.State != ResultOnlyInRemote {
+		r.HasOnlyInRemoteResult = true
+	}
+	if result.State != ResultOnlyInLocal {
+		r.HasOnlyInLocalResult = true
+	}
+}
+
 func (r *Results) Format(details bool) []string {
 	var out []string
 	for _, result := range r.Results {
@@ -322,3 +338,18 @@ func (r *Result) Mark() string {
 		panic(errors.Errorf("unexpected type %T", r.State))
 	}
 }
+
+// isInSubtree returns true if the key is the root key or one of its parents is the root key.
+func isInSubtree(key, root model.Key) bool {
+	for key != nil {
+		if key.String() == root.String() {
+			return true
+		}
+		parent, err := key.ParentKey()
+		if err != nil {
+			return false
+		}
+		key = parent
+	}
+	return false
+}
@@ -802,6 +802,59 @@ func TestResults_Format(t *testing.T) {
 	assert.Equal(t, expected, output)
 }
 
+func TestResults_Subtree(t *testing.T) {
+	t.Parallel()
+	projectState := newProjectState(t)
+
+	branchKey := model.BranchKey{ID: 123}
+	assert.NoError(t, projectState.Set(&model.BranchState{
+		BranchManifest: &model.BranchManifest{BranchKey: branchKey},
+		Local:          &model.Branch{BranchKey: branchKey, Name: "name"},
+		Remote:         &model.Branch{BranchKey: branchKey, Name: "name"},
+	}))
+	config1Key := model.ConfigKey{BranchID: 123, ComponentID: "foo.bar", ID: "456"}
+	assert.NoError(t, projectState.Set(&model.ConfigState{
+		ConfigManifest: &model.ConfigManifest{ConfigKey: config1Key},
+		Local:          &model.Config{ConfigKey: config1Key, Name: "config 1"},
+		Remote:         &model.Config{ConfigKey: config1Key, Name: "config 1"},
+	}))
+	rowKey := model.ConfigRowKey{BranchID: 123, ComponentID: "foo.bar", ConfigID: "456", ID: "789"}
+	assert.NoError(t, projectState.Set(&model.ConfigRowState{
+		ConfigRowManifest: &model.ConfigRowManifest{ConfigRowKey: rowKey},
+		Local:             &model.ConfigRow{ConfigRowKey: rowKey, Name: "row"},
+	}))
+	config2Key := model.ConfigKey{BranchID: 123, ComponentID: "foo.bar", ID: "999"}
+	assert.NoError(t, projectState.Set(&model.ConfigState{
+		ConfigManifest: &model.ConfigManifest{ConfigKey: config2Key},
+		Local:          &model.Config{ConfigKey: config2Key, Name: "config 2"},
+		Remote:         &model.Config{ConfigKey: config2Key, Name: "changed"},
+	}))
+
+	results, err := NewDiffer(projectState).Diff()
+	assert.NoError(t, err)
+	assert.Len(t, results.Results, 4)
+	assert.False(t, results.Equal)
+
+	// Config and its row
+	subtree := results.Subtree(config1Key)
+	if assert.Len(t, subtree.Results, 2) {
+		assert.Equal(t, config1Key.String(), subtree.Results[0].Key().String())
+		assert.Equal(t, rowKey.String(), subtree.Results[1].Key().String())
+	}
+	assert.False(t, subtree.Equal)
+	assert.False(t, subtree.HasNotEqualResult)
+
+	// Only the row
+	subtree = results.Subtree(rowKey)
+	assert.Len(t, subtree.Results, 1)
+	assert.Equal(t, ResultOnlyInLocal, subtree.Results[0].State)
+
+	// Whole branch
+	subtree = results.Subtree(branchKey)
+	assert.Len(t, subtree.Results, 4)
+	assert.True(t, subtree.HasNotEqualResult)
+}
+
 func newProjectState(t *testing.T) *state.State {
 	t.Helper()
 	d := dependencies.NewMocked(t, context.Background())
@@ -0,0 +1,109 @@
+package lineage
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/encoding/json"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+type Format string
+
+const (
+	FormatDOT     = Format("dot")
+	FormatMermaid = Format("mermaid")
+	FormatJSON    = Format("json")
+)
+
+func Formats() []string {
+	return []string{string(FormatDOT), string(FormatMermaid), string(FormatJSON)}
+}
+
+// Export encodes the graph to the format.
+func (g *Graph) Export(format Format) (string, error) {
+	switch format {
+	case FormatDOT:
+		return g.DOT(), nil
+	case FormatMermaid:
+		return g.Mermaid(), nil
+	case FormatJSON:
+		return g.JSON()
+	default:
+		return "", errors.Errorf(`format "%s" is not supported, please use one of: %s`, format, strings.Join(Formats(), ", "))
+	}
+}
+
+// DOT encodes the graph to the Graphviz DOT language.
+func (g *Graph) DOT() string {
+	var b strings.Builder
+	b.WriteString("digraph lineage {\n")
+	b.WriteString("  rankdir=LR;\n")
+	for _, node := range g.Nodes() {
+		fmt.Fprintf(&b, "  %s [label=%s, shape=%s];\n", dotQuote(node.ID), dotQuote(node.Label), dotShape(node.Kind))
+	}
+	for _, edge := range g.Edges() {
+		fmt.Fprintf(&b, "  %s -> %s [label=%s];\n", dotQuote(edge.From), dotQuote(edge.To), dotQuote(string(edge.Type)))
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// Mermaid encodes the graph to the Mermaid flowchart.
+// Node IDs are replaced by short identifiers, because Mermaid doesn't allow special characters in them.
+func (g *Graph) Mermaid() string {
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	ids := make(map[string]string)
+	for i, node := range g.Nodes() {
+		ids[node.ID] = fmt.Sprintf("n%d", i+1)
+		fmt.Fprintf(&b, "  %s%s\n", ids[node.ID], mermaidShape(node.Kind, mermaidQuote(node.Label)))
+	}
+	for _, edge := range g.Edges() {
+		fmt.Fprintf(&b, "  %s -->|%s| %s\n", ids[edge.From], edge.Type, ids[edge.To])
+	}
+	return b.String()
+}
+
+// JSON encodes the graph to JSON with "nodes" and "edges" keys.
+func (g *Graph) JSON() (string, error) {
+	nodes := g.Nodes()
+	edges := g.Edges()
+	if nodes == nil {
+		nodes = []*Node{}
+	}
+	if edges == nil {
+		edges = []*Edge{}
+	}
+	return json.EncodeString(map[string]any{"nodes": nodes, "edges": edges}, true)
+}
+
+func dotQuote(str string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(str) + `"`
+}
+
+func dotShape(kind NodeKind) string {
+	switch kind {
+	case NodeTable:
+		return "cylinder"
+	case NodeOrchestration:
+		return "hexagon"
+	default:
+		return "box"
+	}
+}
+
+func mermaidQuote(str string) string {
+	return `"` + strings.ReplaceAll(str, `"`, "#quot;") + `"`
+}
+
+func mermaidShape(kind NodeKind, label string) string {
+	switch kind {
+	case NodeTable:
+		return "[(" + label + ")]"
+	case NodeOrchestration:
+		return "{{" + label + "}}"
+	default:
+		return "[" + label + "]"
+	}
+}
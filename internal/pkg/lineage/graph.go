@@ -0,0 +1,239 @@
+// Package lineage builds a data lineage graph of configs, tables and orchestrations from the local project state.
+//
+// Edges follow the data flow:
+//   - config -> table, the config writes the table (output mapping),
+//   - table -> config, the config reads the table (input mapping),
+//   - config -> orchestration, the config is run by a task of the orchestration.
+//
+// Orchestration tasks are taken from the model.UsedInOrchestratorRelation relations created by the orchestrator mapper.
+// Tables are taken from the input and output mapping, see model.InputMappingTables and model.OutputMappingTables.
+package lineage
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/keboola/go-client/pkg/keboola"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/state"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+type NodeKind string
+
+type EdgeType string
+
+type Direction string
+
+const (
+	NodeConfig        = NodeKind("config")
+	NodeTable         = NodeKind("table")
+	NodeOrchestration = NodeKind("orchestration")
+	EdgeOutput        = EdgeType("output")
+	EdgeInput         = EdgeType("input")
+	EdgeTask          = EdgeType("task")
+	Upstream          = Direction("upstream")
+	Downstream        = Direction("downstream")
+	Both              = Direction("both")
+)
+
+type Node struct {
+	ID    string   `json:"id"`
+	Kind  NodeKind `json:"kind"`
+	Label string   `json:"label"`
+	Path  string   `json:"path,omitempty"`
+}
+
+type Edge struct {
+	From string   `json:"from"`
+	To   string   `json:"to"`
+	Type EdgeType `json:"type"`
+}
+
+type Graph struct {
+	nodes map[string]*Node
+	edges map[string]*Edge
+}
+
+func Directions() []string {
+	return []string{string(Upstream), string(Downstream), string(Both)}
+}
+
+// TableNodeID returns ID of the table node.
+func TableNodeID(tableID string) string {
+	return string(NodeTable) + ":" + tableID
+}
+
+// ConfigNodeID returns ID of the config or orchestration node.
+func ConfigNodeID(key model.ConfigKey) string {
+	kind := NodeConfig
+	if key.ComponentID == keboola.OrchestratorComponentID {
+		kind = NodeOrchestration
+	}
+	return string(kind) + ":" + key.ComponentID.String() + "/" + key.ID.String()
+}
+
+func NewGraph() *Graph {
+	return &Graph{nodes: make(map[string]*Node), edges: make(map[string]*Edge)}
+}
+
+// Build creates the graph from local configs and rows of the branch.
+// Mappings of config rows are assigned to the parent config.
+func Build(s *state.State, branch model.BranchKey) *Graph {
+	g := NewGraph()
+	for _, config := range s.ConfigsFrom(branch) {
+		if !config.HasLocalState() {
+			continue
+		}
+
+		configNode := g.AddNode(&Node{ID: ConfigNodeID(config.ConfigKey), Kind: NodeConfig, Label: config.Local.Name, Path: config.Path()})
+		if config.ComponentID == keboola.OrchestratorComponentID {
+			configNode.Kind = NodeOrchestration
+			continue
+		}
+
+		// Orchestrations using the config
+		for _, relation := range config.Local.Relations.GetByType(model.UsedInOrchestratorRelType) {
+			orchestration := model.ConfigKey{BranchID: config.BranchID, ComponentID: keboola.OrchestratorComponentID, ID: relation.(*model.UsedInOrchestratorRelation).ConfigID}
+			g.AddEdge(configNode.ID, ConfigNodeID(orchestration), EdgeTask)
+		}
+
+		// Input and output mapping of the config and its rows
+		g.addMapping(configNode, config.Local)
+		for _, row := range s.ConfigRowsFrom(config.ConfigKey) {
+			if row.HasLocalState() {
+				g.addMapping(configNode, row.Local)
+			}
+		}
+	}
+
+	// Remove edges to configs, which are not present in the local state, for example in orchestration tasks
+	for key, edge := range g.edges {
+		if g.nodes[edge.From] == nil || g.nodes[edge.To] == nil {
+			delete(g.edges, key)
+		}
+	}
+
+	return g
+}
+
+func (g *Graph) addMapping(configNode *Node, object model.ObjectWithContent) {
+	for _, tableID := range model.InputMappingTables(object.GetContent()) {
+		g.AddNode(&Node{ID: TableNodeID(tableID), Kind: NodeTable, Label: tableID})
+		g.AddEdge(TableNodeID(tableID), configNode.ID, EdgeInput)
+	}
+	for _, tableID := range model.OutputMappingTables(object.GetContent()) {
+		g.AddNode(&Node{ID: TableNodeID(tableID), Kind: NodeTable, Label: tableID})
+		g.AddEdge(configNode.ID, TableNodeID(tableID), EdgeOutput)
+	}
+}
+
+// AddNode adds the node, if a node with the same ID already exists, then the existing node is returned.
+func (g *Graph) AddNode(node *Node) *Node {
+	if existing, found := g.nodes[node.ID]; found {
+		return existing
+	}
+	g.nodes[node.ID] = node
+	return node
+}
+
+func (g *Graph) AddEdge(from, to string, t EdgeType) {
+	edge := &Edge{From: from, To: to, Type: t}
+	g.edges[from+" -> "+to] = edge
+}
+
+func (g *Graph) Node(id string) (*Node, bool) {
+	node, found := g.nodes[id]
+	return node, found
+}
+
+// Nodes returns all nodes sorted by ID.
+func (g *Graph) Nodes() (out []*Node) {
+	for _, node := range g.nodes {
+		out = append(out, node)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].ID < out[j].ID
+	})
+	return out
+}
+
+// Edges returns all edges sorted by the source and target node.
+func (g *Graph) Edges() (out []*Edge) {
+	for _, edge := range g.edges {
+		out = append(out, edge)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].From != out[j].From {
+			return out[i].From < out[j].From
+		}
+		return out[i].To < out[j].To
+	})
+	return out
+}
+
+// Focus returns a sub-graph with the node and all nodes upstream and/or downstream of it.
+// If the ID has no kind prefix, it is considered to be a table ID.
+func (g *Graph) Focus(id string, direction Direction) (*Graph, error) {
+	if !strings.Contains(id, ":") {
+		id = TableNodeID(id)
+	}
+	if _, found := g.nodes[id]; !found {
+		return nil, errors.Errorf(`node "%s" not found in the graph`, id)
+	}
+
+	visited := map[string]bool{id: true}
+	switch direction {
+	case Upstream:
+		g.walk(id, visited, true)
+	case Downstream:
+		g.walk(id, visited, false)
+	case Both:
+		// Directions are walked separately, a node upstream of the focused node is not expanded downstream
+		g.walk(id, visited, true)
+		downstream := map[string]bool{id: true}
+		g.walk(id, downstream, false)
+		for nodeID := range downstream {
+			visited[nodeID] = true
+		}
+	default:
+		return nil, errors.Errorf(`direction "%s" is not supported, please use one of: %s`, direction, strings.Join(Directions(), ", "))
+	}
+
+	out := NewGraph()
+	for nodeID := range visited {
+		out.AddNode(g.nodes[nodeID])
+	}
+	for key, edge := range g.edges {
+		if visited[edge.From] && visited[edge.To] {
+			out.edges[key] = edge
+		}
+	}
+	return out, nil
+}
+
+// walk marks all nodes reachable from the node, it is a breadth-first search over the adjacency lists.
+func (g *Graph) walk(id string, visited map[string]bool, upstream bool) {
+	// Index edges by the source node
+	adjacency := make(map[string][]string)
+	for _, edge := range g.edges {
+		from, to := edge.From, edge.To
+		if upstream {
+			from, to = to, from
+		}
+		adjacency[from] = append(adjacency[from], to)
+	}
+
+	queue := []string{id}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, next := range adjacency[current] {
+			if !visited[next] {
+				visited[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+}
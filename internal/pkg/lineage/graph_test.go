@@ -0,0 +1,179 @@
+package lineage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/keboola/go-client/pkg/keboola"
+	"github.com/keboola/go-utils/pkg/orderedmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/dependencies"
+	"github.com/keboola/keboola-as-code/internal/pkg/state"
+)
+
+func TestBuild(t *testing.T) {
+	t.Parallel()
+
+	g := Build(createTestState(t), model.BranchKey{ID: 123})
+	assert.Equal(t, []string{
+		"config:keboola.ex-db-mysql/1 -> table:in.c-db.users (output)",
+		"config:keboola.snowflake-transformation/2 -> orchestration:keboola.orchestrator/4 (task)",
+		"config:keboola.snowflake-transformation/2 -> table:out.c-report.summary (output)",
+		"config:keboola.wr-db-mysql/3 -> orchestration:keboola.orchestrator/4 (task)",
+		"config:keboola.wr-db-mysql/5 -> orchestration:keboola.orchestrator/4 (task)",
+		"table:in.c-db.users -> config:keboola.snowflake-transformation/2 (input)",
+		"table:in.c-other.table -> config:keboola.wr-db-mysql/5 (input)",
+		"table:out.c-report.summary -> config:keboola.wr-db-mysql/3 (input)",
+	}, edgesStr(g))
+
+	node, found := g.Node("orchestration:keboola.orchestrator/4")
+	require.True(t, found)
+	assert.Equal(t, NodeOrchestration, node.Kind)
+	assert.Equal(t, "Daily", node.Label)
+	assert.Equal(t, "main/other/keboola.orchestrator/daily", node.Path)
+}
+
+func TestGraph_Focus(t *testing.T) {
+	t.Parallel()
+
+	g := Build(createTestState(t), model.BranchKey{ID: 123})
+
+	// Everything upstream of the table
+	upstream, err := g.Focus("out.c-report.summary", Upstream)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"config:keboola.ex-db-mysql/1 -> table:in.c-db.users (output)",
+		"config:keboola.snowflake-transformation/2 -> table:out.c-report.summary (output)",
+		"table:in.c-db.users -> config:keboola.snowflake-transformation/2 (input)",
+	}, edgesStr(upstream))
+
+	// Everything downstream of the table
+	downstream, err := g.Focus("table:out.c-report.summary", Downstream)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"config:keboola.wr-db-mysql/3 -> orchestration:keboola.orchestrator/4 (task)",
+		"table:out.c-report.summary -> config:keboola.wr-db-mysql/3 (input)",
+	}, edgesStr(downstream))
+
+	// Both directions, other configs of the orchestration are not included
+	both, err := g.Focus("config:keboola.wr-db-mysql/5", Both)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"config:keboola.wr-db-mysql/5 -> orchestration:keboola.orchestrator/4 (task)",
+		"table:in.c-other.table -> config:keboola.wr-db-mysql/5 (input)",
+	}, edgesStr(both))
+
+	// Errors
+	_, err = g.Focus("in.c-missing.table", Upstream)
+	assert.EqualError(t, err, `node "table:in.c-missing.table" not found in the graph`)
+	_, err = g.Focus("in.c-db.users", Direction("foo"))
+	assert.EqualError(t, err, `direction "foo" is not supported, please use one of: upstream, downstream, both`)
+}
+
+func TestGraph_Export(t *testing.T) {
+	t.Parallel()
+
+	g, err := Build(createTestState(t), model.BranchKey{ID: 123}).Focus("out.c-report.summary", Downstream)
+	require.NoError(t, err)
+
+	dot, err := g.Export(FormatDOT)
+	require.NoError(t, err)
+	assert.Equal(t, `digraph lineage {
+  rankdir=LR;
+  "config:keboola.wr-db-mysql/3" [label="Writer \"Report\"", shape=box];
+  "orchestration:keboola.orchestrator/4" [label="Daily", shape=hexagon];
+  "table:out.c-report.summary" [label="out.c-report.summary", shape=cylinder];
+  "config:keboola.wr-db-mysql/3" -> "orchestration:keboola.orchestrator/4" [label="task"];
+  "table:out.c-report.summary" -> "config:keboola.wr-db-mysql/3" [label="input"];
+}
+`, dot)
+
+	mermaid, err := g.Export(FormatMermaid)
+	require.NoError(t, err)
+	assert.Equal(t, `flowchart LR
+  n1["Writer #quot;Report#quot;"]
+  n2{{"Daily"}}
+  n3[("out.c-report.summary")]
+  n1 -->|task| n2
+  n3 -->|input| n1
+`, mermaid)
+
+	jsonOut, err := g.Export(FormatJSON)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+  "nodes": [
+    {"id": "config:keboola.wr-db-mysql/3", "kind": "config", "label": "Writer \"Report\"", "path": "main/writer/keboola.wr-db-mysql/report"},
+    {"id": "orchestration:keboola.orchestrator/4", "kind": "orchestration", "label": "Daily", "path": "main/other/keboola.orchestrator/daily"},
+    {"id": "table:out.c-report.summary", "kind": "table", "label": "out.c-report.summary"}
+  ],
+  "edges": [
+    {"from": "config:keboola.wr-db-mysql/3", "to": "orchestration:keboola.orchestrator/4", "type": "task"},
+    {"from": "table:out.c-report.summary", "to": "config:keboola.wr-db-mysql/3", "type": "input"}
+  ]
+}`, jsonOut)
+
+	_, err = g.Export(Format("svg"))
+	assert.EqualError(t, err, `format "svg" is not supported, please use one of: dot, mermaid, json`)
+}
+
+func createTestState(t *testing.T) *state.State {
+	t.Helper()
+
+	s := dependencies.NewMocked(t, context.Background()).MockedState()
+	branchKey := model.BranchKey{ID: 123}
+	require.NoError(t, s.Set(&model.BranchState{
+		BranchManifest: &model.BranchManifest{BranchKey: branchKey, Paths: model.Paths{AbsPath: model.NewAbsPath("", "main")}},
+		Local:          &model.Branch{BranchKey: branchKey, Name: "Main", IsDefault: true},
+	}))
+
+	addConfig := func(componentID keboola.ComponentID, id keboola.ConfigID, name, path string, inputs, outputs []string) *model.Config {
+		key := model.ConfigKey{BranchID: 123, ComponentID: componentID, ID: id}
+		content := orderedmap.New()
+		for _, tableID := range inputs {
+			tables, _ := content.GetNestedOrNil("storage.input.tables").([]any)
+			require.NoError(t, content.SetNested("storage.input.tables", append(tables, orderedmap.FromPairs([]orderedmap.Pair{{Key: "source", Value: tableID}}))))
+		}
+		for _, tableID := range outputs {
+			tables, _ := content.GetNestedOrNil("storage.output.tables").([]any)
+			require.NoError(t, content.SetNested("storage.output.tables", append(tables, orderedmap.FromPairs([]orderedmap.Pair{{Key: "destination", Value: tableID}}))))
+		}
+		config := &model.Config{ConfigKey: key, Name: name, Content: content}
+		require.NoError(t, s.Set(&model.ConfigState{
+			ConfigManifest: &model.ConfigManifest{ConfigKey: key, Paths: model.Paths{AbsPath: model.NewAbsPath("main", path)}},
+			Local:          config,
+		}))
+		return config
+	}
+
+	addConfig("keboola.ex-db-mysql", "1", "Users", "extractor/keboola.ex-db-mysql/users", nil, []string{"in.c-db.users"})
+	transformation := addConfig("keboola.snowflake-transformation", "2", "Summary", "transformation/keboola.snowflake-transformation/summary", []string{"in.c-db.users"}, nil)
+	writer := addConfig("keboola.wr-db-mysql", "3", `Writer "Report"`, "writer/keboola.wr-db-mysql/report", []string{"out.c-report.summary"}, nil)
+	otherWriter := addConfig("keboola.wr-db-mysql", "5", "Other", "writer/keboola.wr-db-mysql/other", []string{"in.c-other.table"}, nil)
+
+	// Output of the transformation is defined in a row
+	rowKey := model.ConfigRowKey{BranchID: 123, ComponentID: "keboola.snowflake-transformation", ConfigID: "2", ID: "21"}
+	rowContent := orderedmap.New()
+	require.NoError(t, rowContent.SetNested("storage.output.tables", []any{orderedmap.FromPairs([]orderedmap.Pair{{Key: "destination", Value: "out.c-report.summary"}})}))
+	require.NoError(t, s.Set(&model.ConfigRowState{
+		ConfigRowManifest: &model.ConfigRowManifest{ConfigRowKey: rowKey, Paths: model.Paths{AbsPath: model.NewAbsPath("main/transformation/keboola.snowflake-transformation/summary", "rows/row")}},
+		Local:             &model.ConfigRow{ConfigRowKey: rowKey, Name: "Row", Content: rowContent},
+	}))
+
+	// Orchestration uses the transformation and both writers, relations are created by the orchestrator mapper
+	addConfig(keboola.OrchestratorComponentID, "4", "Daily", "other/keboola.orchestrator/daily", nil, nil)
+	for _, target := range []*model.Config{transformation, writer, otherWriter} {
+		target.Relations.Add(&model.UsedInOrchestratorRelation{ConfigID: "4"})
+	}
+
+	return s
+}
+
+func edgesStr(g *Graph) (out []string) {
+	for _, edge := range g.Edges() {
+		out = append(out, edge.From+" -> "+edge.To+" ("+string(edge.Type)+")")
+	}
+	return out
+}
@@ -0,0 +1,71 @@
+package lint
+
+import (
+	"context"
+	"sort"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/filesystem"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+const ConfigFileName = "lint.json"
+
+// Config is loaded from the ".keboola/lint.json" file, for example:
+//
+//	{
+//	  "rules": {
+//	    "unused-variables": "off",
+//	    "plain-text-secret": "error"
+//	  }
+//	}
+type Config struct {
+	// Rules modify the severity of the rules, the "off" value disables the rule.
+	Rules map[string]Severity `json:"rules"`
+}
+
+func ConfigPath() string {
+	return filesystem.Join(filesystem.MetadataDir, ConfigFileName)
+}
+
+func NewConfig() *Config {
+	return &Config{Rules: make(map[string]Severity)}
+}
+
+// ConfigExists returns true if the lint config file is present in the project.
+func ConfigExists(ctx context.Context, fs filesystem.Fs) bool {
+	return fs.IsFile(ctx, ConfigPath())
+}
+
+// LoadConfig loads the config, if the file doesn't exist, an empty config is returned.
+func LoadConfig(ctx context.Context, fs filesystem.Fs) (*Config, error) {
+	config := NewConfig()
+
+	path := ConfigPath()
+	if fs.IsFile(ctx, path) {
+		if _, err := fs.FileLoader().ReadJSONFileTo(ctx, filesystem.NewFileDef(path).SetDescription("lint config"), config); err != nil {
+			return nil, err
+		}
+	}
+
+	// Validate severities
+	errs := errors.NewMultiError()
+	for _, id := range config.RuleIDs() {
+		if err := config.Rules[id].Validate(); err != nil {
+			errs.Append(errors.PrefixErrorf(err, `invalid rule "%s"`, id))
+		}
+	}
+	if err := errs.ErrorOrNil(); err != nil {
+		return nil, errors.PrefixErrorf(err, `invalid lint config "%s"`, path)
+	}
+
+	return config, nil
+}
+
+// RuleIDs returns sorted IDs of the configured rules.
+func (c *Config) RuleIDs() (out []string) {
+	for id := range c.Rules {
+		out = append(out, id)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,139 @@
+// Package lint provides a pluggable rules engine, which checks the local project state for common mistakes.
+//
+// Built-in rules are listed by DefaultRules.
+// Rules can be disabled or their severity can be changed in the ".keboola/lint.json" file, see Config.
+package lint
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/state"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+type Severity string
+
+const (
+	SeverityOff     = Severity("off")
+	SeverityNote    = Severity("note")
+	SeverityWarning = Severity("warning")
+	SeverityError   = Severity("error")
+)
+
+// Rule checks the project state and reports found issues.
+type Rule struct {
+	ID          string
+	Description string
+	Severity    Severity // default severity, it can be modified by Config
+	Check       CheckFunc
+}
+
+// CheckFunc reports an issue for each problem found in the state.
+type CheckFunc func(ctx context.Context, s *state.State, report ReportFunc)
+
+// ReportFunc reports an issue found in the object, the path points to the affected file.
+type ReportFunc func(key model.Key, path string, message string)
+
+type Issue struct {
+	RuleID   string
+	Severity Severity
+	Key      model.Key
+	Path     string // path to the affected file, relative to the project directory
+	Message  string
+}
+
+type Issues []*Issue
+
+// Engine runs enabled rules with the configured severity.
+type Engine struct {
+	rules []Rule
+}
+
+func Severities() []Severity {
+	return []Severity{SeverityOff, SeverityNote, SeverityWarning, SeverityError}
+}
+
+func (v Severity) Validate() error {
+	for _, s := range Severities() {
+		if v == s {
+			return nil
+		}
+	}
+	return errors.Errorf(`severity "%s" is not valid, allowed values: %s`, v, severitiesStr())
+}
+
+// NewEngine creates the engine with the rules modified by the config.
+// The config is optional, an error is returned if it references an unknown rule.
+func NewEngine(config *Config, rules ...Rule) (*Engine, error) {
+	e := &Engine{}
+	known := make(map[string]bool)
+	for _, rule := range rules {
+		known[rule.ID] = true
+		if config != nil {
+			if severity, found := config.Rules[rule.ID]; found {
+				rule.Severity = severity
+			}
+		}
+		if rule.Severity != SeverityOff {
+			e.rules = append(e.rules, rule)
+		}
+	}
+
+	// Check the config
+	if config != nil {
+		errs := errors.NewMultiError()
+		for _, id := range config.RuleIDs() {
+			if !known[id] {
+				errs.Append(errors.Errorf(`rule "%s" is not defined`, id))
+			}
+		}
+		if err := errs.ErrorOrNil(); err != nil {
+			return nil, errors.PrefixErrorf(err, `invalid lint config "%s"`, ConfigPath())
+		}
+	}
+
+	return e, nil
+}
+
+// Rules returns enabled rules with the configured severity.
+func (e *Engine) Rules() []Rule {
+	return e.rules
+}
+
+// Run all enabled rules, issues are sorted by path.
+func (e *Engine) Run(ctx context.Context, s *state.State) (issues Issues) {
+	for _, rule := range e.rules {
+		rule.Check(ctx, s, func(key model.Key, path string, message string) {
+			issues = append(issues, &Issue{RuleID: rule.ID, Severity: rule.Severity, Key: key, Path: path, Message: message})
+		})
+	}
+	sort.SliceStable(issues, func(i, j int) bool {
+		return issues[i].Path < issues[j].Path
+	})
+	return issues
+}
+
+// Errors returns issues with the error severity.
+func (v Issues) Errors() (out Issues) {
+	for _, issue := range v {
+		if issue.Severity == SeverityError {
+			out = append(out, issue)
+		}
+	}
+	return out
+}
+
+func (v *Issue) String() string {
+	return v.Path + ": " + v.Message + " [" + v.RuleID + "]"
+}
+
+func severitiesStr() string {
+	var out []string
+	for _, s := range Severities() {
+		out = append(out, string(s))
+	}
+	return strings.Join(out, ", ")
+}
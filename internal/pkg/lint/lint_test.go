@@ -0,0 +1,248 @@
+package lint
+
+import (
+	"context"
+	"testing"
+
+	"github.com/keboola/go-client/pkg/keboola"
+	"github.com/keboola/go-utils/pkg/orderedmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/encoding/json"
+	"github.com/keboola/keboola-as-code/internal/pkg/filesystem"
+	"github.com/keboola/keboola-as-code/internal/pkg/filesystem/aferofs"
+	"github.com/keboola/keboola-as-code/internal/pkg/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/dependencies"
+	"github.com/keboola/keboola-as-code/internal/pkg/state"
+)
+
+func TestEngine_DefaultRules(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	engine, err := NewEngine(nil, DefaultRules()...)
+	require.NoError(t, err)
+
+	var issues []string
+	for _, issue := range engine.Run(ctx, createTestState(t)) {
+		issues = append(issues, string(issue.Severity)+" "+issue.String())
+	}
+	assert.Equal(t, []string{
+		`note main/extractor/ex-generic-v2/old-name/meta.json: path "main/extractor/ex-generic-v2/old-name" does not match the naming template, expected "main/extractor/ex-generic-v2/wrong-name", use "kbc local fix-paths" to fix it [naming-convention]`,
+		`warning main/extractor/keboola.ex-db-mysql/my-extractor/config.json: value of "parameters.password" looks like a secret, prefix the key with "#" to encrypt it [plain-text-secret]`,
+		`warning main/other/keboola.orchestrator/my-orchestration/phases/001-phase/001-task/task.json: task "Task" in phase "Phase" runs disabled config "Disabled Writer" [disabled-task-target]`,
+		`warning main/transformation/keboola.snowflake-transformation/my-transformation/blocks/001-block/001-code/code.sql: code "Code" in block "Block" has 3 SQL statements, but 2 statements are loaded back from the file [sql-split]`,
+		`note main/transformation/keboola.snowflake-transformation/my-transformation/config.json: input table "in.c-db.orders" is not written by any configuration in the branch [unknown-input-table]`,
+		`warning main/transformation/keboola.snowflake-transformation/my-transformation/variables/config.json: variable "unused" is not used in config "My Transformation" [unused-variables]`,
+	}, issues)
+	assert.Empty(t, Issues(nil).Errors())
+}
+
+func TestEngine_Config(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	fs := aferofs.NewMemoryFs()
+	require.NoError(t, fs.WriteFile(ctx, filesystem.NewRawFile(ConfigPath(), `{"rules": {"naming-convention": "off", "plain-text-secret": "error"}}`)))
+	assert.True(t, ConfigExists(ctx, fs))
+
+	config, err := LoadConfig(ctx, fs)
+	require.NoError(t, err)
+	engine, err := NewEngine(config, DefaultRules()...)
+	require.NoError(t, err)
+
+	var ids []string
+	for _, rule := range engine.Rules() {
+		ids = append(ids, rule.ID+":"+string(rule.Severity))
+	}
+	assert.Equal(t, []string{
+		"unused-variables:warning",
+		"disabled-task-target:warning",
+		"unknown-input-table:note",
+		"sql-split:warning",
+		"plain-text-secret:error",
+	}, ids)
+
+	issues := engine.Run(ctx, createTestState(t))
+	assert.Len(t, issues, 5)
+	if assert.Len(t, issues.Errors(), 1) {
+		assert.Equal(t, RulePlainTextSecret, issues.Errors()[0].RuleID)
+	}
+}
+
+func TestEngine_InvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	fs := aferofs.NewMemoryFs()
+	assert.False(t, ConfigExists(ctx, fs))
+
+	// Invalid severity
+	require.NoError(t, fs.WriteFile(ctx, filesystem.NewRawFile(ConfigPath(), `{"rules": {"sql-split": "fatal"}}`)))
+	_, err := LoadConfig(ctx, fs)
+	if assert.Error(t, err) {
+		assert.Equal(t, "invalid lint config \".keboola/lint.json\":\n- invalid rule \"sql-split\":\n  - severity \"fatal\" is not valid, allowed values: off, note, warning, error", err.Error())
+	}
+
+	// Unknown rule
+	_, err = NewEngine(&Config{Rules: map[string]Severity{"foo": SeverityOff}}, DefaultRules()...)
+	if assert.Error(t, err) {
+		assert.Equal(t, "invalid lint config \".keboola/lint.json\":\n- rule \"foo\" is not defined", err.Error())
+	}
+}
+
+func TestEngine_SARIF(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	engine, err := NewEngine(&Config{Rules: map[string]Severity{
+		RuleUnusedVariables:    SeverityOff,
+		RuleDisabledTaskTarget: SeverityOff,
+		RuleUnknownInputTable:  SeverityOff,
+		RuleSQLSplit:           SeverityOff,
+		RuleNamingConvention:   SeverityOff,
+	}}, DefaultRules()...)
+	require.NoError(t, err)
+
+	out, err := engine.SARIF(engine.Run(ctx, createTestState(t)))
+	require.NoError(t, err)
+
+	sarif := orderedmap.New()
+	require.NoError(t, json.DecodeString(out, sarif))
+	assert.Equal(t, "2.1.0", sarif.GetOrNil("version"))
+	assert.Equal(t, "kbc", sarif.GetNestedOrNil("runs[0].tool.driver.name"))
+	assert.Equal(t, RulePlainTextSecret, sarif.GetNestedOrNil("runs[0].tool.driver.rules[0].id"))
+	assert.Equal(t, RulePlainTextSecret, sarif.GetNestedOrNil("runs[0].results[0].ruleId"))
+	assert.Equal(t, "warning", sarif.GetNestedOrNil("runs[0].results[0].level"))
+	assert.Equal(t,
+		"main/extractor/keboola.ex-db-mysql/my-extractor/config.json",
+		sarif.GetNestedOrNil("runs[0].results[0].locations[0].physicalLocation.artifactLocation.uri"),
+	)
+}
+
+func createTestState(t *testing.T) *state.State {
+	t.Helper()
+
+	s := dependencies.NewMocked(t, context.Background()).MockedState()
+	branchKey := model.BranchKey{ID: 123}
+	addObject := func(objectState model.ObjectState) {
+		require.NoError(t, s.Set(objectState))
+	}
+
+	// Branch
+	addObject(&model.BranchState{
+		BranchManifest: &model.BranchManifest{BranchKey: branchKey, Paths: model.Paths{AbsPath: model.NewAbsPath("", "main")}},
+		Local:          &model.Branch{BranchKey: branchKey, Name: "Main", IsDefault: true},
+	})
+
+	// Extractor with a plain-text secret
+	extractorKey := model.ConfigKey{BranchID: 123, ComponentID: "keboola.ex-db-mysql", ID: "456"}
+	extractorContent := orderedmap.FromPairs([]orderedmap.Pair{
+		{Key: "parameters", Value: orderedmap.FromPairs([]orderedmap.Pair{
+			{Key: "password", Value: "my-password"},
+			{Key: "#token", Value: "KBC::ProjectSecure::abc"},
+			{Key: "tokenFromVariable", Value: "{{token}}"},
+		})},
+	})
+	require.NoError(t, extractorContent.SetNested("storage.output.tables", []any{
+		orderedmap.FromPairs([]orderedmap.Pair{{Key: "destination", Value: "in.c-db.users"}}),
+	}))
+	addObject(&model.ConfigState{
+		ConfigManifest: &model.ConfigManifest{ConfigKey: extractorKey, Paths: model.Paths{AbsPath: model.NewAbsPath("main", "extractor/keboola.ex-db-mysql/my-extractor")}},
+		Local:          &model.Config{ConfigKey: extractorKey, Name: "My Extractor", Content: extractorContent},
+	})
+
+	// Config with a path which doesn't match the naming
+	otherKey := model.ConfigKey{BranchID: 123, ComponentID: "ex-generic-v2", ID: "444"}
+	addObject(&model.ConfigState{
+		ConfigManifest: &model.ConfigManifest{ConfigKey: otherKey, Paths: model.Paths{AbsPath: model.NewAbsPath("main", "extractor/ex-generic-v2/old-name")}},
+		Local:          &model.Config{ConfigKey: otherKey, Name: "Wrong Name", Content: orderedmap.New()},
+	})
+
+	// Transformation reading an unknown table, with SQL which cannot be split back
+	transformationKey := model.ConfigKey{BranchID: 123, ComponentID: "keboola.snowflake-transformation", ID: "789"}
+	transformationPath := "main/transformation/keboola.snowflake-transformation/my-transformation"
+	transformationContent := orderedmap.New()
+	require.NoError(t, transformationContent.SetNested("storage.input.tables", []any{
+		orderedmap.FromPairs([]orderedmap.Pair{{Key: "source", Value: "in.c-db.users"}}),
+		orderedmap.FromPairs([]orderedmap.Pair{{Key: "source", Value: "in.c-db.orders"}}),
+	}))
+	addObject(&model.ConfigState{
+		ConfigManifest: &model.ConfigManifest{ConfigKey: transformationKey, Paths: model.Paths{AbsPath: model.NewAbsPath("main", "transformation/keboola.snowflake-transformation/my-transformation")}},
+		Local: &model.Config{
+			ConfigKey: transformationKey,
+			Name:      "My Transformation",
+			Content:   transformationContent,
+			Transformation: &model.Transformation{Blocks: []*model.Block{{
+				Name:    "Block",
+				AbsPath: model.NewAbsPath(transformationPath+"/blocks", "001-block"),
+				Codes: model.Codes{{
+					Name:         "Code",
+					AbsPath:      model.NewAbsPath(transformationPath+"/blocks/001-block", "001-code"),
+					CodeFileName: "code.sql",
+					Scripts: model.Scripts{
+						model.StaticScript{Value: "SELECT '{{ used }}';"},
+						model.StaticScript{Value: "-- comment"},
+						model.StaticScript{Value: "SELECT 2;"},
+					},
+				}},
+			}}},
+		},
+	})
+
+	// Variables for the transformation
+	variablesKey := model.ConfigKey{BranchID: 123, ComponentID: keboola.VariablesComponentID, ID: "111"}
+	variablesContent := orderedmap.FromPairs([]orderedmap.Pair{
+		{Key: "variables", Value: []any{
+			orderedmap.FromPairs([]orderedmap.Pair{{Key: "name", Value: "used"}, {Key: "type", Value: "string"}}),
+			orderedmap.FromPairs([]orderedmap.Pair{{Key: "name", Value: "unused"}, {Key: "type", Value: "string"}}),
+		}},
+	})
+	variablesRelations := model.Relations{&model.VariablesForRelation{ComponentID: transformationKey.ComponentID, ConfigID: transformationKey.ID}}
+	addObject(&model.ConfigState{
+		ConfigManifest: &model.ConfigManifest{ConfigKey: variablesKey, Relations: variablesRelations, Paths: model.Paths{AbsPath: model.NewAbsPath(transformationPath, "variables")}},
+		Local:          &model.Config{ConfigKey: variablesKey, Name: "Variables", Content: variablesContent, Relations: variablesRelations},
+	})
+
+	// Disabled writer
+	writerKey := model.ConfigKey{BranchID: 123, ComponentID: "keboola.wr-db-mysql", ID: "222"}
+	addObject(&model.ConfigState{
+		ConfigManifest: &model.ConfigManifest{ConfigKey: writerKey, Paths: model.Paths{AbsPath: model.NewAbsPath("main", "writer/keboola.wr-db-mysql/disabled-writer")}},
+		Local:          &model.Config{ConfigKey: writerKey, Name: "Disabled Writer", IsDisabled: true, Content: orderedmap.New()},
+	})
+
+	// Orchestrator running the disabled writer
+	orchestratorKey := model.ConfigKey{BranchID: 123, ComponentID: keboola.OrchestratorComponentID, ID: "333"}
+	orchestratorPath := "main/other/keboola.orchestrator/my-orchestration"
+	addObject(&model.ConfigState{
+		ConfigManifest: &model.ConfigManifest{ConfigKey: orchestratorKey, Paths: model.Paths{AbsPath: model.NewAbsPath("main", "other/keboola.orchestrator/my-orchestration")}},
+		Local: &model.Config{
+			ConfigKey: orchestratorKey,
+			Name:      "My Orchestration",
+			Content:   orderedmap.New(),
+			Orchestration: &model.Orchestration{Phases: []*model.Phase{{
+				Name:    "Phase",
+				AbsPath: model.NewAbsPath(orchestratorPath+"/phases", "001-phase"),
+				Tasks: []*model.Task{
+					{
+						Name:        "Task",
+						Enabled:     true,
+						ComponentID: writerKey.ComponentID,
+						ConfigID:    writerKey.ID,
+						AbsPath:     model.NewAbsPath(orchestratorPath+"/phases/001-phase", "001-task"),
+					},
+					{
+						Name:        "Disabled Task",
+						Enabled:     false,
+						ComponentID: writerKey.ComponentID,
+						ConfigID:    writerKey.ID,
+						AbsPath:     model.NewAbsPath(orchestratorPath+"/phases/001-phase", "002-disabled-task"),
+					},
+				},
+			}}},
+		},
+	})
+
+	return s
+}
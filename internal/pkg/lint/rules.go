@@ -0,0 +1,334 @@
+package lint
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/keboola/go-client/pkg/keboola"
+	"github.com/keboola/go-utils/pkg/orderedmap"
+	"github.com/umisama/go-regexpcache"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/encoding/json"
+	"github.com/keboola/keboola-as-code/internal/pkg/filesystem"
+	"github.com/keboola/keboola-as-code/internal/pkg/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/naming"
+	"github.com/keboola/keboola-as-code/internal/pkg/state"
+)
+
+const (
+	RuleUnusedVariables     = "unused-variables"
+	RuleDisabledTaskTarget  = "disabled-task-target"
+	RuleUnknownInputTable   = "unknown-input-table"
+	RuleSQLSplit            = "sql-split"
+	RulePlainTextSecret     = "plain-text-secret"
+	RuleNamingConvention    = "naming-convention"
+	secretKeyRegexp         = `(?i)(password|passwd|secret|token|api_?key|private_?key|access_?key)$`
+	variablePlaceholderExpr = `^\{\{[^}]+\}\}$`
+)
+
+// DefaultRules returns built-in rules with the default severity.
+func DefaultRules() []Rule {
+	return []Rule{
+		{
+			ID:          RuleUnusedVariables,
+			Description: "Variables defined for a configuration should be used in the configuration.",
+			Severity:    SeverityWarning,
+			Check:       checkUnusedVariables,
+		},
+		{
+			ID:          RuleDisabledTaskTarget,
+			Description: "Enabled orchestrator tasks should not run disabled configurations.",
+			Severity:    SeverityWarning,
+			Check:       checkDisabledTaskTarget,
+		},
+		{
+			ID:          RuleUnknownInputTable,
+			Description: "Tables read by transformations should be written by a configuration in the same branch.",
+			Severity:    SeverityNote,
+			Check:       checkUnknownInputTable,
+		},
+		{
+			ID:          RuleSQLSplit,
+			Description: "SQL code must be split back to the same statements after it is saved to a file.",
+			Severity:    SeverityWarning,
+			Check:       checkSQLSplit,
+		},
+		{
+			ID:          RulePlainTextSecret,
+			Description: `Secrets should be stored under keys prefixed with "#", so they are encrypted.`,
+			Severity:    SeverityWarning,
+			Check:       checkPlainTextSecret,
+		},
+		{
+			ID:          RuleNamingConvention,
+			Description: "Paths of objects should match the naming template from the manifest.",
+			Severity:    SeverityNote,
+			Check:       checkNamingConvention,
+		},
+	}
+}
+
+func checkUnusedVariables(_ context.Context, s *state.State, report ReportFunc) {
+	for _, config := range localConfigs(s) {
+		if config.ComponentID != keboola.VariablesComponentID {
+			continue
+		}
+
+		for _, relation := range config.Local.Relations {
+			if relation.Type() != model.VariablesForRelType && relation.Type() != model.SharedCodeVariablesForRelType {
+				continue
+			}
+
+			// Get the configuration or the shared code row, which uses the variables
+			targetKey, err := relation.ParentKey(config.Key())
+			if err != nil {
+				continue
+			}
+			target, found := s.Get(targetKey)
+			if !found || !target.HasLocalState() {
+				continue
+			}
+
+			text := usageText(s, target)
+			for _, name := range variablesNames(config.Local.Content) {
+				if !regexpcache.MustCompile(`\{\{\s*` + regexp.QuoteMeta(name) + `\s*\}\}`).MatchString(text) {
+					report(config.Key(), configFilePath(config), fmt.Sprintf(`variable "%s" is not used in %s "%s"`, name, target.Kind().Name, target.ObjectName()))
+				}
+			}
+		}
+	}
+}
+
+func checkDisabledTaskTarget(_ context.Context, s *state.State, report ReportFunc) {
+	for _, config := range localConfigs(s) {
+		if config.ComponentID != keboola.OrchestratorComponentID || config.Local.Orchestration == nil {
+			continue
+		}
+
+		for _, phase := range config.Local.Orchestration.Phases {
+			for _, task := range phase.Tasks {
+				if !task.Enabled || task.ConfigID == "" {
+					continue
+				}
+
+				targetKey := model.ConfigKey{BranchID: config.BranchID, ComponentID: task.ComponentID, ID: task.ConfigID}
+				target, found := s.Get(targetKey)
+				if !found || !target.HasLocalState() {
+					continue
+				}
+
+				if targetConfig := target.LocalState().(*model.Config); targetConfig.IsDisabled {
+					report(config.Key(), filesystem.Join(task.Path(), naming.TaskFile), fmt.Sprintf(`task "%s" in phase "%s" runs disabled config "%s"`, task.Name, phase.Name, targetConfig.Name))
+				}
+			}
+		}
+	}
+}
+
+func checkUnknownInputTable(_ context.Context, s *state.State, report ReportFunc) {
+	for _, branch := range s.Branches() {
+		if !branch.HasLocalState() {
+			continue
+		}
+
+		// Collect tables written by all configs and rows in the branch
+		written := make(map[string]bool)
+		var configs []*model.ConfigState
+		for _, config := range s.ConfigsFrom(branch.BranchKey) {
+			if !config.HasLocalState() {
+				continue
+			}
+			configs = append(configs, config)
+			for _, table := range model.OutputMappingTables(config.Local.Content) {
+				written[table] = true
+			}
+			for _, row := range s.ConfigRowsFrom(config.ConfigKey) {
+				if row.HasLocalState() {
+					for _, table := range model.OutputMappingTables(row.Local.Content) {
+						written[table] = true
+					}
+				}
+			}
+		}
+
+		// Check tables read by transformations
+		for _, config := range configs {
+			component, err := s.Components().GetOrErr(config.ComponentID)
+			if err != nil || !component.IsTransformation() {
+				continue
+			}
+			for _, table := range model.InputMappingTables(config.Local.Content) {
+				if !written[table] {
+					report(config.Key(), configFilePath(config), fmt.Sprintf(`input table "%s" is not written by any configuration in the branch`, table))
+				}
+			}
+		}
+	}
+}
+
+func checkSQLSplit(_ context.Context, s *state.State, report ReportFunc) {
+	for _, config := range localConfigs(s) {
+		if config.Local.Transformation == nil || naming.CodeFileExt(config.ComponentID) != naming.SQLExt {
+			continue
+		}
+
+		for _, block := range config.Local.Transformation.Blocks {
+			for _, code := range block.Codes {
+				// Links to shared codes are not saved to the code file
+				if slices.ContainsFunc(code.Scripts, func(script model.Script) bool {
+					_, ok := script.(model.StaticScript)
+					return !ok
+				}) {
+					continue
+				}
+
+				// Simulate saving to the code file and loading back
+				expected := normalizedScripts(code.Scripts)
+				actual := normalizedScripts(model.ScriptsFromStr(code.Scripts.String(config.ComponentID), config.ComponentID))
+				if !slices.Equal(expected, actual) {
+					report(config.Key(), filesystem.Join(code.Path(), code.CodeFileName), fmt.Sprintf(
+						`code "%s" in block "%s" has %d SQL statements, but %d statements are loaded back from the file`,
+						code.Name, block.Name, len(expected), len(actual),
+					))
+				}
+			}
+		}
+	}
+}
+
+func checkPlainTextSecret(_ context.Context, s *state.State, report ReportFunc) {
+	for _, objectState := range s.All() {
+		if !objectState.HasLocalState() {
+			continue
+		}
+		object, ok := objectState.LocalState().(model.ObjectWithContent)
+		if !ok || object.GetContent() == nil {
+			continue
+		}
+
+		object.GetContent().VisitAllRecursive(func(path orderedmap.Path, value any, _ any) {
+			str, ok := value.(string)
+			if !ok || str == "" || keboola.IsEncrypted(str) || regexpcache.MustCompile(variablePlaceholderExpr).MatchString(str) {
+				return
+			}
+			step, ok := path.Last().(orderedmap.MapStep)
+			if !ok || keboola.IsKeyToEncrypt(step.Key()) {
+				return
+			}
+			if regexpcache.MustCompile(secretKeyRegexp).MatchString(strings.ReplaceAll(step.Key(), "-", "_")) {
+				report(objectState.Key(), configFilePath(objectState), fmt.Sprintf(`value of "%s" looks like a secret, prefix the key with "#" to encrypt it`, path.String()))
+			}
+		})
+	}
+}
+
+func checkNamingConvention(_ context.Context, s *state.State, report ReportFunc) {
+	// A new registry is used, so the paths of the state are not modified
+	generator := naming.NewGenerator(s.Manifest().NamingTemplate(), naming.NewRegistry())
+	for _, objectState := range s.All() {
+		if !objectState.HasLocalState() {
+			continue
+		}
+
+		var expected model.AbsPath
+		switch v := objectState.(type) {
+		case *model.BranchState:
+			expected = generator.BranchPath(v.Local)
+		case *model.ConfigState:
+			component, err := s.Components().GetOrErr(v.ComponentID)
+			if err != nil {
+				continue
+			}
+			expected = generator.ConfigPath(v.GetParentPath(), component, v.Local)
+		case *model.ConfigRowState:
+			component, err := s.Components().GetOrErr(v.ComponentID)
+			if err != nil {
+				continue
+			}
+			expected = generator.ConfigRowPath(v.GetParentPath(), component, v.Local)
+		default:
+			continue
+		}
+
+		if actual := objectState.Path(); expected.Path() != actual {
+			report(objectState.Key(), filesystem.Join(actual, naming.MetaFile), fmt.Sprintf(`path "%s" does not match the naming template, expected "%s", use "kbc local fix-paths" to fix it`, actual, expected.Path()))
+		}
+	}
+}
+
+func localConfigs(s *state.State) (out []*model.ConfigState) {
+	for _, config := range s.Configs() {
+		if config.HasLocalState() {
+			out = append(out, config)
+		}
+	}
+	return out
+}
+
+func configFilePath(objectState model.ObjectState) string {
+	return filesystem.Join(objectState.Path(), naming.ConfigFile)
+}
+
+// usageText returns all content of the object, where a variable can be used.
+func usageText(s *state.State, objectState model.ObjectState) string {
+	var b strings.Builder
+	writeContent := func(content *orderedmap.OrderedMap) {
+		if content != nil {
+			b.WriteString(json.MustEncodeString(content, false))
+		}
+	}
+	writeScripts := func(scripts model.Scripts) {
+		for _, script := range scripts {
+			b.WriteString(script.Content())
+			b.WriteString("\n")
+		}
+	}
+
+	switch v := objectState.LocalState().(type) {
+	case *model.Config:
+		writeContent(v.Content)
+		if v.Transformation != nil {
+			for _, block := range v.Transformation.Blocks {
+				for _, code := range block.Codes {
+					writeScripts(code.Scripts)
+				}
+			}
+		}
+		for _, row := range s.ConfigRowsFrom(v.ConfigKey) {
+			if row.HasLocalState() {
+				writeContent(row.Local.Content)
+			}
+		}
+	case *model.ConfigRow:
+		writeContent(v.Content)
+		if v.SharedCode != nil {
+			writeScripts(v.SharedCode.Scripts)
+		}
+	}
+	return b.String()
+}
+
+func variablesNames(content *orderedmap.OrderedMap) (out []string) {
+	if content == nil {
+		return nil
+	}
+	items, _ := content.GetOrNil("variables").([]any)
+	for _, item := range items {
+		if m, ok := item.(*orderedmap.OrderedMap); ok {
+			if name, ok := m.GetOrNil("name").(string); ok && name != "" {
+				out = append(out, name)
+			}
+		}
+	}
+	return out
+}
+
+func normalizedScripts(scripts model.Scripts) (out []string) {
+	for _, script := range scripts {
+		out = append(out, strings.TrimSpace(script.Content()))
+	}
+	return out
+}
@@ -4,15 +4,46 @@ package log
 import (
 	"io"
 
+	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
+// Verbosity is the minimal log level of a service logger, it can be changed at runtime, for example on a configuration reload.
+type Verbosity struct {
+	level zap.AtomicLevel
+}
+
+func NewVerbosity(verbose bool) *Verbosity {
+	v := &Verbosity{level: zap.NewAtomicLevel()}
+	v.SetVerbose(verbose)
+	return v
+}
+
+// SetVerbose enables or disables logging at DEBUG level.
+func (v *Verbosity) SetVerbose(verbose bool) {
+	if verbose {
+		v.level.SetLevel(zapcore.DebugLevel)
+	} else {
+		v.level.SetLevel(zapcore.InfoLevel)
+	}
+}
+
+// Verbose returns true, if logging at DEBUG level is enabled.
+func (v *Verbosity) Verbose() bool {
+	return v.level.Enabled(zapcore.DebugLevel)
+}
+
 // NewServiceLogger new production zapLogger for an API or worker node.
 func NewServiceLogger(writer io.Writer, verbose bool) Logger {
+	return NewServiceLoggerWithVerbosity(writer, NewVerbosity(verbose))
+}
+
+// NewServiceLoggerWithVerbosity is similar to the NewServiceLogger, but the verbosity can be changed at runtime.
+func NewServiceLoggerWithVerbosity(writer io.Writer, verbosity *Verbosity) Logger {
 	var cores []zapcore.Core
 
 	// Log to the standard logger
-	cores = append(cores, writerCore(writer, verbose))
+	cores = append(cores, writerCore(writer, verbosity.level))
 
 	// Create zapLogger
 	return newLoggerFromZapCore(zapcore.NewTee(cores...))
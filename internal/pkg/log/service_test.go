@@ -4,6 +4,8 @@ import (
 	"context"
 	"strings"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestServiceLogger_VerboseFalse(t *testing.T) {
@@ -69,3 +71,35 @@ func TestServiceLogger_VerboseTrue(t *testing.T) {
 `
 	AssertJSONMessages(t, expected, out.String())
 }
+
+func TestServiceLogger_Verbosity(t *testing.T) {
+	t.Parallel()
+
+	var out strings.Builder
+	verbosity := NewVerbosity(false)
+	logger := NewServiceLoggerWithVerbosity(&out, verbosity)
+
+	logger.Debug(context.Background(), "Debug msg 1")
+	logger.Info(context.Background(), "Info msg 1")
+
+	// Enable debug messages
+	verbosity.SetVerbose(true)
+	assert.True(t, verbosity.Verbose())
+	logger.Debug(context.Background(), "Debug msg 2")
+	logger.Info(context.Background(), "Info msg 2")
+
+	// Disable debug messages
+	verbosity.SetVerbose(false)
+	assert.False(t, verbosity.Verbose())
+	logger.Debug(context.Background(), "Debug msg 3")
+	logger.Info(context.Background(), "Info msg 3")
+
+	// Assert
+	expected := `
+{"level":"info","message":"Info msg 1"}
+{"level":"debug","message":"Debug msg 2"}
+{"level":"info","message":"Info msg 2"}
+{"level":"info","message":"Info msg 3"}
+`
+	AssertJSONMessages(t, expected, out.String())
+}
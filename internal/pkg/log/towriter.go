@@ -8,11 +8,6 @@ import (
 )
 
 // writerCore writes to a writer.
-func writerCore(stderr io.Writer, verbose bool) zapcore.Core {
-	minLevel := zapcore.InfoLevel
-	if verbose {
-		minLevel = zapcore.DebugLevel
-	}
-
+func writerCore(stderr io.Writer, minLevel zapcore.LevelEnabler) zapcore.Core {
 	return zapcore.NewCore(newJSONEncoder(), zapcore.AddSync(stderr), minLevel)
 }
@@ -458,6 +458,14 @@ func (m ConfigMetadata) SetTemplateInstance(repo string, tmpl string, inst strin
 	m[instanceIDMetadataKey] = inst
 }
 
+// ClearTemplateInstance detaches the config from the template instance, so it becomes a regular config.
+// Removed keys are deleted from the remote config on push.
+func (m ConfigMetadata) ClearTemplateInstance() {
+	for _, key := range []string{repositoryMetadataKey, templateIDMetadataKey, instanceIDMetadataKey, configIDMetadataKey, rowsIdsMetadataKey, configInputsUsageMetadataKey, rowsInputsUsageMetadataKey} {
+		delete(m, key)
+	}
+}
+
 func (m ConfigMetadata) Repository() string {
 	return m[repositoryMetadataKey]
 }
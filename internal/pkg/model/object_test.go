@@ -139,3 +139,17 @@ func TestBranchMetadata_DeleteTemplateUsage(t *testing.T) {
 	assert.NoError(t, err)
 	assert.False(t, found)
 }
+
+func TestConfigMetadata_ClearTemplateInstance(t *testing.T) {
+	t.Parallel()
+
+	m := ConfigMetadata{"foo": "bar"}
+	m.SetTemplateInstance("repo", "tmpl", "inst")
+	m.SetConfigTemplateID("my-config")
+	assert.Equal(t, "inst", m.InstanceID())
+
+	m.ClearTemplateInstance()
+	assert.Equal(t, ConfigMetadata{"foo": "bar"}, m)
+	assert.Empty(t, m.InstanceID())
+	assert.Nil(t, m.ConfigTemplateID())
+}
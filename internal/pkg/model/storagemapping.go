@@ -0,0 +1,30 @@
+package model
+
+import (
+	"github.com/keboola/go-utils/pkg/orderedmap"
+)
+
+// InputMappingTables returns IDs of the tables read by the config or row, according to the input mapping.
+func InputMappingTables(content *orderedmap.OrderedMap) []string {
+	return storageMappingTables(content, "storage.input.tables", "source")
+}
+
+// OutputMappingTables returns IDs of the tables written by the config or row, according to the output mapping.
+func OutputMappingTables(content *orderedmap.OrderedMap) []string {
+	return storageMappingTables(content, "storage.output.tables", "destination")
+}
+
+func storageMappingTables(content *orderedmap.OrderedMap, path, field string) (out []string) {
+	if content == nil {
+		return nil
+	}
+	items, _ := content.GetNestedOrNil(path).([]any)
+	for _, item := range items {
+		if m, ok := item.(*orderedmap.OrderedMap); ok {
+			if tableID, ok := m.GetOrNil(field).(string); ok && tableID != "" {
+				out = append(out, tableID)
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,41 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/keboola/go-utils/pkg/orderedmap"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/encoding/json"
+	. "github.com/keboola/keboola-as-code/internal/pkg/model"
+)
+
+func TestStorageMappingTables(t *testing.T) {
+	t.Parallel()
+
+	content := orderedmap.New()
+	json.MustDecodeString(`
+{
+  "storage": {
+    "input": {
+      "tables": [
+        {"source": "in.c-bucket.a", "destination": "a.csv"},
+        {"source": ""},
+        "invalid",
+        {"source": "in.c-bucket.b"}
+      ]
+    },
+    "output": {
+      "tables": [
+        {"source": "c.csv", "destination": "out.c-bucket.c"}
+      ]
+    }
+  }
+}
+`, content)
+
+	assert.Equal(t, []string{"in.c-bucket.a", "in.c-bucket.b"}, InputMappingTables(content))
+	assert.Equal(t, []string{"out.c-bucket.c"}, OutputMappingTables(content))
+	assert.Empty(t, InputMappingTables(orderedmap.New()))
+	assert.Empty(t, OutputMappingTables(nil))
+}
@@ -10,8 +10,9 @@ import (
 type TemplateRepositoryType string
 
 const (
-	RepositoryTypeDir = `dir`
-	RepositoryTypeGit = `git`
+	RepositoryTypeDir     = `dir`
+	RepositoryTypeGit     = `git`
+	RepositoryTypeArchive = `archive`
 )
 
 type TemplateRepositories struct {
@@ -19,24 +20,36 @@ type TemplateRepositories struct {
 	asMap   map[string]TemplateRepository
 }
 
+// TemplateRepository definition.
+// Repository of the "archive" type is a tar.gz file downloaded from an HTTPS URL or an OCI registry ("oci://registry/repository:tag").
+// The archive must be verified by the Checksum and/or by the ed25519 signature of the archive digest and the PublicKey, at least one of them is required.
 type TemplateRepository struct {
-	Type TemplateRepositoryType `json:"type" validate:"oneof=dir git"`
-	Name string                 `json:"name" validate:"required,max=40"`
-	URL  string                 `json:"url" validate:"required"`
-	Ref  string                 `json:"ref,omitempty" validate:"required_if=Type git"`
+	Type      TemplateRepositoryType `json:"type" validate:"oneof=dir git archive"`
+	Name      string                 `json:"name" validate:"required,max=40"`
+	URL       string                 `json:"url" validate:"required"`
+	Ref       string                 `json:"ref,omitempty" validate:"required_if=Type git"`
+	Checksum  string                 `json:"checksum,omitempty" validate:"required_if=Type archive PublicKey '',omitempty,startswith=sha256:,len=71"`
+	PublicKey string                 `json:"publicKey,omitempty" validate:"omitempty,base64"`
 }
 
 // String returns human-readable name of the repository.
 func (r TemplateRepository) String() string {
-	if r.Type == RepositoryTypeDir {
+	switch r.Type {
+	case RepositoryTypeDir:
 		return fmt.Sprintf("dir:%s", r.URL)
+	case RepositoryTypeArchive:
+		return fmt.Sprintf("archive:%s", r.URL)
+	default:
+		return fmt.Sprintf("%s:%s", r.URL, r.Ref)
 	}
-	return fmt.Sprintf("%s:%s", r.URL, r.Ref)
 }
 
 // Hash returns unique identifier of the repository.
 func (r TemplateRepository) Hash() string {
 	hash := fmt.Sprintf("%s:%s:%s", r.Type, r.URL, r.Ref)
+	if r.Checksum != "" {
+		hash += ":" + r.Checksum
+	}
 	sha := sha256.Sum256([]byte(hash))
 	return string(sha[:])
 }
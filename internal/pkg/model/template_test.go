@@ -0,0 +1,39 @@
+package model_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/keboola/keboola-as-code/internal/pkg/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/validator"
+)
+
+func TestTemplateRepository_Validate_ArchiveVerification(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	val := validator.New()
+	repo := TemplateRepository{Type: RepositoryTypeArchive, Name: "archive", URL: "https://example.com/templates.tar.gz"}
+
+	// Archive without a checksum and a public key cannot be verified
+	err := val.Validate(ctx, repo)
+	if assert.Error(t, err) {
+		assert.Equal(t, `"checksum" is a required field`, err.Error())
+	}
+
+	// Checksum is enough
+	withChecksum := repo
+	withChecksum.Checksum = "sha256:" + strings.Repeat("a", 64)
+	assert.NoError(t, val.Validate(ctx, withChecksum))
+
+	// Public key is enough
+	withPublicKey := repo
+	withPublicKey.PublicKey = "a2V5"
+	assert.NoError(t, val.Validate(ctx, withPublicKey))
+
+	// Other repository types don't use the verification
+	assert.NoError(t, val.Validate(ctx, TemplateRepository{Type: RepositoryTypeGit, Name: "git", URL: "https://example.com/repo.git", Ref: "main"}))
+}
@@ -1,52 +1,87 @@
 package delete_template
 
 import (
+	"sort"
+
 	"github.com/keboola/go-client/pkg/keboola"
 
 	"github.com/keboola/keboola-as-code/internal/pkg/model"
 	"github.com/keboola/keboola-as-code/internal/pkg/search"
 	"github.com/keboola/keboola-as-code/internal/pkg/state"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
 )
 
-// NewPlan creates a plan for renaming objects that do not match the naming.
-func NewPlan(projectState *state.State, branchKey model.BranchKey, instanceID string) (*Plan, error) {
+// NewPlan creates a plan for deleting configs of the template instance.
+// Configs from the keepConfigs list are not deleted, they are converted to regular configs.
+func NewPlan(projectState *state.State, branchKey model.BranchKey, instanceID string, keepConfigs []model.ConfigKey) (*Plan, error) {
 	builder := &planBuilder{State: projectState}
-	actions, err := builder.build(branchKey, instanceID)
-	if err != nil {
+	if err := builder.build(branchKey, instanceID, keepConfigs); err != nil {
 		return nil, err
 	}
-	return &Plan{actions: actions, projectState: projectState, branchKey: branchKey, instanceID: instanceID}, nil
+	return &Plan{actions: builder.actions, keepActions: builder.keepActions, projectState: projectState, branchKey: branchKey, instanceID: instanceID}, nil
 }
 
 type planBuilder struct {
 	*state.State
-	actions []DeleteAction
+	actions     []DeleteAction
+	keepActions []KeepAction
 }
 
-func (b *planBuilder) build(branchKey model.BranchKey, instanceID string) ([]DeleteAction, error) {
+func (b *planBuilder) build(branchKey model.BranchKey, instanceID string, keepConfigs []model.ConfigKey) error {
+	keepMap := make(map[model.ConfigKey]bool)
+	for _, key := range keepConfigs {
+		keepMap[key] = false
+	}
+
 	configsMap := map[keboola.ConfigID]bool{}
 	for _, config := range search.ConfigsForTemplateInstance(b.State.LocalObjects().ConfigsWithRowsFrom(branchKey), instanceID) {
 		configState := b.MustGet(config.Key())
+		rows, tables := b.rowsAndTables(config)
+
+		// Keep config
+		if _, found := keepMap[config.ConfigKey]; found {
+			keepMap[config.ConfigKey] = true
+			b.keepActions = append(b.keepActions, KeepAction{
+				State:  configState.(*model.ConfigState),
+				Rows:   rows,
+				Tables: tables,
+			})
+			continue
+		}
+
 		action := DeleteAction{
 			State:    configState,
 			Manifest: configState.Manifest(),
+			Rows:     rows,
+			Tables:   tables,
 		}
 		b.actions = append(b.actions, action)
 
 		configsMap[config.ID] = true
 	}
 
+	// All configs to keep must be part of the instance
+	errs := errors.NewMultiError()
+	for key, found := range keepMap {
+		if !found {
+			errs.Append(errors.Errorf(`config "%s:%s" is not part of the template instance "%s"`, key.ComponentID, key.ID, instanceID))
+		}
+	}
+	if err := errs.ErrorOrNil(); err != nil {
+		return err
+	}
+
 	// Search for schedules and delete those belonging to the deleted configs
 	for _, config := range b.ConfigsFrom(branchKey) {
 		component, err := b.Components().GetOrErr(config.ComponentID)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		if component.IsScheduler() {
 			rel, err := config.Relations.GetOneByType(model.SchedulerForRelType)
 			if err != nil {
-				return nil, err
+				return err
 			}
 			if rel != nil {
 				schedulerRel := rel.(*model.SchedulerForRelation)
@@ -61,5 +96,26 @@ func (b *planBuilder) build(branchKey model.BranchKey, instanceID string) ([]Del
 		}
 	}
 
-	return b.actions, nil
+	return nil
+}
+
+// rowsAndTables returns rows of the config and storage tables written by the config or its rows.
+func (b *planBuilder) rowsAndTables(config *model.ConfigWithRows) (rows []model.ObjectState, tables []string) {
+	tablesMap := make(map[string]bool)
+	for _, tableID := range model.OutputMappingTables(config.Content) {
+		tablesMap[tableID] = true
+	}
+	for _, row := range config.Rows {
+		if rowState, found := b.Get(row.Key()); found {
+			rows = append(rows, rowState)
+		}
+		for _, tableID := range model.OutputMappingTables(row.Content) {
+			tablesMap[tableID] = true
+		}
+	}
+	for tableID := range tablesMap {
+		tables = append(tables, tableID)
+	}
+	sort.Strings(tables)
+	return rows, tables
 }
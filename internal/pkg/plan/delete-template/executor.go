@@ -22,6 +22,11 @@ func (e *executor) invoke() error {
 		uow.DeleteObject(action.State, action.Manifest)
 	}
 
+	for _, action := range e.keepActions {
+		action.State.Local.Metadata.ClearTemplateInstance()
+		uow.SaveObject(action.State, action.State.LocalState(), model.NewChangedFields("metadata"))
+	}
+
 	branchState := e.Plan.projectState.MustGet(e.Plan.branchKey).(*model.BranchState)
 	if err := branchState.Local.Metadata.DeleteTemplateUsage(e.Plan.instanceID); err != nil {
 		return errors.PrefixError(err, "cannot remove template instance metadata")
@@ -13,36 +13,73 @@ import (
 type DeleteAction struct {
 	State    model.ObjectState
 	Manifest model.ObjectManifest
+	Rows     []model.ObjectState // rows are deleted with the config
+	Tables   []string            // storage tables written by the config or its rows, the tables are not deleted
+}
+
+// KeepAction converts the config to a regular config, the template metadata are removed.
+type KeepAction struct {
+	State  *model.ConfigState
+	Rows   []model.ObjectState
+	Tables []string
 }
 
 type Plan struct {
 	actions      []DeleteAction
+	keepActions  []KeepAction
 	projectState *state.State
 	branchKey    model.BranchKey
 	instanceID   string
 }
 
 func (p *Plan) Empty() bool {
-	return len(p.actions) == 0
+	return len(p.actions) == 0 && len(p.keepActions) == 0
 }
 
 func (p *Plan) Name() string {
 	return "delete template instance"
 }
 
+// DeleteActions returns configs to be deleted, including schedulers.
+func (p *Plan) DeleteActions() []DeleteAction {
+	return p.actions
+}
+
+// KeepActions returns configs to be converted to regular configs.
+func (p *Plan) KeepActions() []KeepAction {
+	return p.keepActions
+}
+
 func (p *Plan) Log(w io.Writer) {
 	fmt.Fprintf(w, `Plan for "%s" operation:`, p.Name())
 	fmt.Fprintln(w)
-	if len(p.actions) == 0 {
+	if p.Empty() {
 		fmt.Fprintln(w, "  nothing to delete")
-	} else {
-		for _, action := range p.actions {
-			fmt.Fprintf(w, "  %s %s %s", diff.DeleteMark, model.ConfigAbbr, action.State.Path())
-			fmt.Fprintln(w)
-		}
+		return
+	}
+	for _, action := range p.actions {
+		fmt.Fprintf(w, "  %s %s %s", diff.DeleteMark, model.ConfigAbbr, action.State.Path())
+		fmt.Fprintln(w)
+		logRowsAndTables(w, diff.DeleteMark, action.Rows, action.Tables)
+	}
+	for _, action := range p.keepActions {
+		fmt.Fprintf(w, "  %s %s %s (kept, template metadata removed)", diff.ChangeMark, model.ConfigAbbr, action.State.Path())
+		fmt.Fprintln(w)
+		logRowsAndTables(w, diff.EqualMark, action.Rows, action.Tables)
 	}
 }
 
 func (p *Plan) Invoke(ctx context.Context) error {
 	return newExecutor(ctx, p).invoke()
 }
+
+func logRowsAndTables(w io.Writer, rowMark string, rows []model.ObjectState, tables []string) {
+	for _, row := range rows {
+		fmt.Fprintf(w, "    %s %s %s", rowMark, model.RowAbbr, row.Path())
+		fmt.Fprintln(w)
+	}
+	for _, tableID := range tables {
+		fmt.Fprintf(w, "    writes table \"%s\"", tableID)
+		fmt.Fprintln(w)
+	}
+}
@@ -0,0 +1,96 @@
+package delete_template
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/env"
+	"github.com/keboola/keboola-as-code/internal/pkg/filesystem"
+	"github.com/keboola/keboola-as-code/internal/pkg/filesystem/aferofs"
+	"github.com/keboola/keboola-as-code/internal/pkg/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/project"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/dependencies"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/testhelper"
+	loadState "github.com/keboola/keboola-as-code/pkg/lib/operation/state/load"
+)
+
+func TestDeletePlan_KeepConfigs(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	projectState := loadTestState(t)
+
+	branchKey := model.BranchKey{ID: 12345}
+	deletedKey := model.ConfigKey{BranchID: 12345, ComponentID: "ex-generic-v2", ID: "101"}
+	keptKey := model.ConfigKey{BranchID: 12345, ComponentID: "ex-generic-v2", ID: "103"}
+
+	plan, err := NewPlan(projectState.State(), branchKey, "inst123", []model.ConfigKey{keptKey})
+	require.NoError(t, err)
+	require.Len(t, plan.DeleteActions(), 1)
+	require.Len(t, plan.KeepActions(), 1)
+	assert.Equal(t, deletedKey, plan.DeleteActions()[0].State.Key())
+	assert.Equal(t, keptKey, plan.KeepActions()[0].State.Key())
+
+	// Preview
+	out := &strings.Builder{}
+	plan.Log(out)
+	assert.Equal(t, strings.TrimLeft(`
+Plan for "delete template instance" operation:
+  × C main/extractor/ex-generic-v2/empty
+  * C main/extractor/ex-generic-v2/without-rows (kept, template metadata removed)
+`, "\n"), out.String())
+
+	// Invoke
+	require.NoError(t, plan.Invoke(ctx))
+
+	// The deleted config is removed
+	assert.False(t, projectState.MustGet(deletedKey).HasLocalState())
+
+	// The kept config is detached from the instance, the template metadata keys are deleted
+	kept := projectState.MustGet(keptKey).(*model.ConfigState)
+	assert.Equal(t, model.ConfigMetadata{}, kept.Local.Metadata)
+
+	// The instance is removed from the branch
+	branch := projectState.MustGet(branchKey).(*model.BranchState)
+	_, found, err := branch.Local.Metadata.TemplateInstance("inst123")
+	require.NoError(t, err)
+	assert.False(t, found)
+	_, found, err = branch.Local.Metadata.TemplateInstance("inst456")
+	require.NoError(t, err)
+	assert.True(t, found)
+}
+
+func TestDeletePlan_KeepConfigs_NotInInstance(t *testing.T) {
+	t.Parallel()
+	projectState := loadTestState(t)
+
+	// Config belongs to a different instance
+	keepConfigs := []model.ConfigKey{{BranchID: 12345, ComponentID: "keboola.ex-aws-s3", ID: "104"}}
+	_, err := NewPlan(projectState.State(), model.BranchKey{ID: 12345}, "inst123", keepConfigs)
+	if assert.Error(t, err) {
+		assert.Equal(t, `config "keboola.ex-aws-s3:104" is not part of the template instance "inst123"`, err.Error())
+	}
+}
+
+func loadTestState(t *testing.T) *project.State {
+	t.Helper()
+
+	_, testFile, _, _ := runtime.Caller(0)
+	testDir := filesystem.Dir(testFile)
+	fs := aferofs.NewMemoryFsFrom(filesystem.Join(testDir, "..", "..", "fixtures", "local", "template-instance"))
+
+	// Replace ENVs
+	envs := env.Empty()
+	envs.Set("TEST_KBC_PROJECT_ID", "12345")
+	envs.Set("TEST_KBC_STORAGE_API_HOST", "foo.bar")
+	require.NoError(t, testhelper.ReplaceEnvsDir(context.Background(), fs, `/`, envs))
+
+	d := dependencies.NewMocked(t, context.Background())
+	projectState, err := d.MockedProject(fs).LoadState(loadState.Options{LoadLocalState: true}, d)
+	require.NoError(t, err)
+	return projectState
+}
@@ -0,0 +1,60 @@
+package search
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	fuzzyMatchScore       = 1
+	fuzzyConsecutiveBonus = 4
+	fuzzyWordStartBonus   = 3
+)
+
+// Fuzzy returns true if all characters of the pattern are present in the str in the same order, case-insensitive.
+// The score is higher for consecutive characters and characters at the start of a word.
+// An empty pattern matches everything with zero score.
+func Fuzzy(str, pattern string) (score int, ok bool) {
+	if pattern == "" {
+		return 0, true
+	}
+
+	patternRunes := []rune(pattern)
+	p := 0
+	prev := rune(0)
+	prevMatched := false
+	for _, r := range str {
+		if p < len(patternRunes) && unicode.ToLower(r) == unicode.ToLower(patternRunes[p]) {
+			score += fuzzyMatchScore
+			if prevMatched {
+				score += fuzzyConsecutiveBonus
+			}
+			if prev == 0 || isWordSeparator(prev) || (unicode.IsLower(prev) && unicode.IsUpper(r)) {
+				score += fuzzyWordStartBonus
+			}
+			p++
+			prevMatched = true
+		} else {
+			prevMatched = false
+		}
+		prev = r
+	}
+
+	if p < len(patternRunes) {
+		return 0, false
+	}
+	return score, true
+}
+
+// FuzzyMatch returns true if the str matches the pattern, see Fuzzy.
+func FuzzyMatch(str, pattern string) bool {
+	if utf8.RuneCountInString(pattern) > utf8.RuneCountInString(str) {
+		return false
+	}
+	_, ok := Fuzzy(str, pattern)
+	return ok
+}
+
+func isWordSeparator(r rune) bool {
+	return unicode.IsSpace(r) || unicode.IsPunct(r) || r == '/'
+}
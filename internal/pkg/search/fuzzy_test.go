@@ -0,0 +1,49 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFuzzy(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		str     string
+		pattern string
+		match   bool
+	}{
+		{str: "Foo Bar", pattern: "", match: true},
+		{str: "Foo Bar", pattern: "fb", match: true},
+		{str: "Foo Bar", pattern: "FOOBAR", match: true},
+		{str: "Foo Bar", pattern: "bf", match: false},
+		{str: "Foo Bar", pattern: "foo bar baz", match: false},
+		{str: "main/extractor/ex-generic-v2/my-config", pattern: "exgenmy", match: true},
+		{str: "Příliš žluťoučký kůň", pattern: "žlk", match: true},
+	}
+
+	for _, tc := range cases {
+		_, ok := Fuzzy(tc.str, tc.pattern)
+		assert.Equal(t, tc.match, ok, tc.str+" | "+tc.pattern)
+		assert.Equal(t, tc.match, FuzzyMatch(tc.str, tc.pattern), tc.str+" | "+tc.pattern)
+	}
+}
+
+func TestFuzzy_Score(t *testing.T) {
+	t.Parallel()
+
+	// Consecutive characters are preferred
+	consecutive, ok := Fuzzy("my config", "conf")
+	assert.True(t, ok)
+	scattered, ok := Fuzzy("cars on fire", "conf")
+	assert.True(t, ok)
+	assert.Greater(t, consecutive, scattered)
+
+	// Word start is preferred
+	wordStart, ok := Fuzzy("foo bar", "b")
+	assert.True(t, ok)
+	inWord, ok := Fuzzy("foobar", "b")
+	assert.True(t, ok)
+	assert.Greater(t, wordStart, inWord)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/keboola/keboola-as-code/internal/pkg/telemetry/datadog"
 	"github.com/keboola/keboola-as-code/internal/pkg/telemetry/metric/prometheus"
+	"github.com/keboola/keboola-as-code/internal/pkg/telemetry/otlp"
 	"github.com/keboola/keboola-as-code/internal/pkg/telemetry/pprof"
 	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
 	"github.com/keboola/keboola-as-code/internal/pkg/utils/strhelper"
@@ -14,15 +15,16 @@ import (
 // Config of the Apps Proxy.
 // See "cliconfig" package for more information.
 type Config struct {
-	DebugLog         bool              `configKey:"debugLog" configUsage:"Enable debug log level."`
+	DebugLog         bool              `configKey:"debugLog" configUsage:"Enable debug log level." configReload:"true"`
 	DebugHTTPClient  bool              `configKey:"debugHTTPClient" configUsage:"Log HTTP client requests and responses as debug messages."`
 	PProf            pprof.Config      `configKey:"pprof"`
 	Datadog          datadog.Config    `configKey:"datadog"`
 	Metrics          prometheus.Config `configKey:"metrics"`
+	OTLP             otlp.Config       `configKey:"otlp"`
 	DNSServer        string            `configKey:"dnsServer" configUsage:"DNS server for proxy. If empty, the /etc/resolv.conf is used."`
 	API              API               `configKey:"api"`
 	CookieSecretSalt string            `configKey:"cookieSecretSalt" configUsage:"Cookie secret needed by OAuth 2 Proxy." validate:"required" sensitive:"true"`
-	Upstream         Upstream          `configKey:"-" configUsage:"Configuration options for upstream"`
+	Upstream         Upstream          `configKey:"upstream" configUsage:"Configuration options for upstream"`
 	SandboxesAPI     SandboxesAPI      `configKey:"sandboxesAPI"`
 	CsrfTokenSalt    string            `configKey:"csrfTokenSalt" configUsage:"Salt used for generating CSRF tokens" validate:"required" sensitive:"true"`
 }
@@ -38,8 +40,8 @@ type SandboxesAPI struct {
 }
 
 type Upstream struct {
-	HTTPTimeout time.Duration `configKey:"httpTimeout" configUsage:"Timeout for HTTP request on upstream"`
-	WsTimeout   time.Duration `configKey:"wsTimeout" configUsage:"Timeout for websocket request on upstream"`
+	HTTPTimeout time.Duration `configKey:"httpTimeout" configUsage:"Timeout for HTTP request on upstream" configReload:"true"`
+	WsTimeout   time.Duration `configKey:"wsTimeout" configUsage:"Timeout for websocket request on upstream" configReload:"true"`
 }
 
 func New() Config {
@@ -49,6 +51,7 @@ func New() Config {
 		PProf:           pprof.NewConfig(),
 		Datadog:         datadog.NewConfig(),
 		Metrics:         prometheus.NewConfig(),
+		OTLP:            otlp.NewConfig(),
 		Upstream: Upstream{
 			HTTPTimeout: 30 * time.Second,
 			WsTimeout:   6 * time.Hour,
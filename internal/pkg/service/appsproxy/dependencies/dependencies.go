@@ -33,6 +33,7 @@ import (
 	"github.com/keboola/keboola-as-code/internal/pkg/service/appsproxy/proxy/pagewriter"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/appsproxy/proxy/transport"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/appsproxy/proxy/transport/dns/dnsmock"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/configmap"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/dependencies"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/httpclient"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/servicectx"
@@ -48,6 +49,7 @@ const (
 type ServiceScope interface {
 	dependencies.BaseScope
 	Config() config.Config
+	ConfigReloader() *configmap.Reloader[config.Config]
 	AppsAPI() *api.API
 	AppHandlers() *apphandler.Manager
 	AppConfigLoader() *appconfig.Loader
@@ -68,7 +70,7 @@ type Mocked interface {
 // serviceScope implements APIScope interface.
 type serviceScope struct {
 	parentScopes
-	config            config.Config
+	configReloader    *configmap.Reloader[config.Config]
 	appsAPI           *api.API
 	appHandlers       *apphandler.Manager
 	upstreamTransport http.RoundTripper
@@ -90,15 +92,15 @@ type parentScopesImpl struct {
 
 func NewServiceScope(
 	ctx context.Context,
-	cfg config.Config,
+	configReloader *configmap.Reloader[config.Config],
 	proc *servicectx.Process,
 	logger log.Logger,
 	tel telemetry.Telemetry,
 	stdout io.Writer,
 	stderr io.Writer,
 ) (v ServiceScope, err error) {
-	parentScp := newParentScopes(ctx, cfg, proc, logger, tel, stdout, stderr)
-	return newServiceScope(ctx, parentScp, cfg)
+	parentScp := newParentScopes(ctx, configReloader.Current(), proc, logger, tel, stdout, stderr)
+	return newServiceScope(ctx, parentScp, configReloader)
 }
 
 func newParentScopes(
@@ -132,13 +134,15 @@ func newParentScopes(
 	return d
 }
 
-func newServiceScope(ctx context.Context, parentScp parentScopes, cfg config.Config) (v *serviceScope, err error) {
+func newServiceScope(ctx context.Context, parentScp parentScopes, configReloader *configmap.Reloader[config.Config]) (v *serviceScope, err error) {
 	ctx, span := parentScp.Telemetry().Tracer().Start(ctx, "keboola.go.appsproxy.dependencies.newServiceScope")
 	defer span.End(&err)
 
+	cfg := configReloader.Current()
+
 	d := &serviceScope{}
 	d.parentScopes = parentScp
-	d.config = cfg
+	d.configReloader = configReloader
 
 	d.upstreamTransport, err = transport.New(d, cfg.DNSServer)
 	if err != nil {
@@ -161,8 +165,13 @@ func newServiceScope(ctx context.Context, parentScp parentScopes, cfg config.Con
 	return d, nil
 }
 
+// Config returns the current configuration, fields with the configReload tag can be changed by a reload.
 func (v *serviceScope) Config() config.Config {
-	return v.config
+	return v.configReloader.Current()
+}
+
+func (v *serviceScope) ConfigReloader() *configmap.Reloader[config.Config] {
+	return v.configReloader
 }
 
 func (v *serviceScope) AppsAPI() *api.API {
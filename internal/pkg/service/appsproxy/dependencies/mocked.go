@@ -68,7 +68,7 @@ func NewMockedServiceScope(tb testing.TB, ctx context.Context, cfg config.Config
 
 	mock := &mocked{Mocked: commonMock, config: cfg, dnsServer: dnsServer}
 
-	scope, err := newServiceScope(ctx, mock, cfg)
+	scope, err := newServiceScope(ctx, mock, configmap.NewReloader(cfg, nil))
 	require.NoError(tb, err)
 
 	mock.DebugLogger().Truncate()
@@ -9,6 +9,7 @@ import (
 	"net/url"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.opentelemetry.io/otel/attribute"
@@ -21,6 +22,7 @@ import (
 	"github.com/keboola/keboola-as-code/internal/pkg/service/appsproxy/dataapps/wakeup"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/appsproxy/proxy/apphandler/chain"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/appsproxy/proxy/pagewriter"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/configmap"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/ctxattr"
 	svcErrors "github.com/keboola/keboola-as-code/internal/pkg/service/common/errors"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/servicectx"
@@ -44,7 +46,9 @@ type Manager struct {
 	configLoader *appconfig.Loader
 	notify       *notify.Manager
 	wakeup       *wakeup.Manager
-	config       config.Config
+	// httpTimeout and wsTimeout can be changed by the configuration reload
+	httpTimeout atomic.Int64
+	wsTimeout   atomic.Int64
 }
 
 type AppUpstream struct {
@@ -64,7 +68,7 @@ type dependencies interface {
 	AppConfigLoader() *appconfig.Loader
 	NotifyManager() *notify.Manager
 	WakeupManager() *wakeup.Manager
-	Config() config.Config
+	ConfigReloader() *configmap.Reloader[config.Config]
 }
 
 func NewManager(d dependencies) *Manager {
@@ -77,9 +81,14 @@ func NewManager(d dependencies) *Manager {
 		configLoader: d.AppConfigLoader(),
 		notify:       d.NotifyManager(),
 		wakeup:       d.WakeupManager(),
-		config:       d.Config(),
 	}
 
+	// Apply reloaded timeouts to new requests
+	m.setTimeouts(d.ConfigReloader().Current().Upstream)
+	d.ConfigReloader().OnReload(func(ctx context.Context, cfg config.Config) {
+		m.setTimeouts(cfg.Upstream)
+	})
+
 	d.Process().OnShutdown(func(ctx context.Context) {
 		m.Shutdown(ctx)
 	})
@@ -92,6 +101,11 @@ func (m *Manager) Shutdown(ctx context.Context) {
 	m.wg.Wait()
 }
 
+func (m *Manager) setTimeouts(cfg config.Upstream) {
+	m.httpTimeout.Store(int64(cfg.HTTPTimeout))
+	m.wsTimeout.Store(int64(cfg.WsTimeout))
+}
+
 func (m *Manager) NewUpstream(ctx context.Context, app api.AppConfig) (upstream *AppUpstream, err error) {
 	ctx, span := m.telemetry.Tracer().Start(ctx, "keboola.go.apps-proxy.upstream.NewUpstream")
 	defer span.End(&err)
@@ -106,8 +120,8 @@ func (m *Manager) NewUpstream(ctx context.Context, app api.AppConfig) (upstream
 
 	// Create reverse proxy
 	upstream = &AppUpstream{manager: m, app: app, target: target}
-	upstream.handler = upstream.newProxy(m.config.Upstream.HTTPTimeout)
-	upstream.wsHandler = upstream.newWebsocketProxy(m.config.Upstream.WsTimeout)
+	upstream.handler = upstream.newProxy(&m.httpTimeout)
+	upstream.wsHandler = upstream.newWebsocketProxy(&m.wsTimeout)
 	return upstream, nil
 }
 
@@ -119,7 +133,7 @@ func (u *AppUpstream) ServeHTTPOrError(rw http.ResponseWriter, req *http.Request
 	return u.handler.ServeHTTPOrError(rw, req)
 }
 
-func (u *AppUpstream) newProxy(timeout time.Duration) *chain.Chain {
+func (u *AppUpstream) newProxy(timeout *atomic.Int64) *chain.Chain {
 	proxy := httputil.NewSingleHostReverseProxy(u.target)
 	proxy.Transport = u.manager.transport
 	proxy.ErrorHandler = u.manager.pageWriter.ProxyErrorHandlerFor(u.app)
@@ -127,7 +141,7 @@ func (u *AppUpstream) newProxy(timeout time.Duration) *chain.Chain {
 	return chain.
 		New(chain.HandlerFunc(func(w http.ResponseWriter, req *http.Request) error {
 			ctx := ctxattr.ContextWith(req.Context(), attribute.Bool(attrWebsocket, false))
-			ctx, cancel := context.WithTimeout(ctx, timeout)
+			ctx, cancel := context.WithTimeout(ctx, time.Duration(timeout.Load()))
 			defer cancel()
 			proxy.ServeHTTP(w, req.WithContext(ctx))
 			return nil
@@ -138,7 +152,7 @@ func (u *AppUpstream) newProxy(timeout time.Duration) *chain.Chain {
 		)
 }
 
-func (u *AppUpstream) newWebsocketProxy(timeout time.Duration) *chain.Chain {
+func (u *AppUpstream) newWebsocketProxy(timeout *atomic.Int64) *chain.Chain {
 	proxy := httputil.NewSingleHostReverseProxy(u.target)
 	proxy.Transport = u.manager.transport
 	proxy.ErrorHandler = u.manager.pageWriter.ProxyErrorHandlerFor(u.app)
@@ -146,7 +160,7 @@ func (u *AppUpstream) newWebsocketProxy(timeout time.Duration) *chain.Chain {
 	return chain.
 		New(chain.HandlerFunc(func(w http.ResponseWriter, req *http.Request) error {
 			ctx := ctxattr.ContextWith(req.Context(), attribute.Bool(attrWebsocket, true))
-			ctx, cancel := context.WithTimeout(ctx, timeout)
+			ctx, cancel := context.WithTimeout(ctx, time.Duration(timeout.Load()))
 			defer cancel()
 			proxy.ServeHTTP(w, req.WithContext(ctx))
 			return nil
@@ -0,0 +1,161 @@
+package browser
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/AlecAivazis/survey/v2/terminal"
+	"golang.org/x/term"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+const (
+	enterScreen   = "\x1b[?1049h\x1b[?25l" // alternate screen, hidden cursor
+	leaveScreen   = "\x1b[?25h\x1b[?1049l"
+	clearScreen   = "\x1b[H\x1b[2J"
+	defaultWidth  = 80
+	defaultHeight = 24
+)
+
+// Actions are executed in the normal terminal mode, so they can log and ask questions.
+type Actions interface {
+	Diff(ctx context.Context, key model.Key) error
+	Pull(ctx context.Context, key model.Key) error
+	Push(ctx context.Context, key model.Key) error
+	// Items returns the current items, it is called after each action to reflect changes.
+	Items(ctx context.Context) ([]*Item, error)
+}
+
+type Browser struct {
+	stdin   terminal.FileReader
+	stdout  terminal.FileWriter
+	reader  *bufio.Reader
+	actions Actions
+	model   *Model
+}
+
+func New(stdin terminal.FileReader, stdout terminal.FileWriter, items []*Item, actions Actions) *Browser {
+	return &Browser{
+		stdin:   stdin,
+		stdout:  stdout,
+		reader:  bufio.NewReader(stdin),
+		actions: actions,
+		model:   NewModel(items),
+	}
+}
+
+// Run starts the browser, it blocks until the user quits it.
+func (b *Browser) Run(ctx context.Context) (err error) {
+	fd := int(b.stdin.Fd())
+	oldState, err := term.MakeRaw(fd)
+	if err != nil {
+		return errors.PrefixError(err, "cannot switch the terminal to the raw mode")
+	}
+	b.write(enterScreen)
+	defer func() {
+		b.write(leaveScreen)
+		if restoreErr := term.Restore(fd, oldState); restoreErr != nil && err == nil {
+			err = restoreErr
+		}
+	}()
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		b.draw()
+		key, err := readKey(b.reader)
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		switch cmd := b.model.Update(key); cmd {
+		case cmdQuit:
+			return nil
+		case cmdDiff, cmdPull, cmdPush:
+			if err := b.suspend(ctx, fd, oldState, cmd); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// suspend leaves the browser screen, runs the action in the normal terminal mode and returns back.
+func (b *Browser) suspend(ctx context.Context, fd int, oldState *term.State, cmd command) error {
+	b.write(leaveScreen)
+	if err := term.Restore(fd, oldState); err != nil {
+		return err
+	}
+
+	item := b.model.Selected()
+	b.write(fmt.Sprintf("\n%s %s \"%s\" ...\n\n", commandTitle(cmd), item.Key.Kind().Name, item.Name))
+	if err := b.execute(ctx, cmd, item.Key); err == nil {
+		b.model.SetMessage(fmt.Sprintf(`%s of %s "%s" done.`, commandTitle(cmd), item.Key.Kind().Name, item.Name))
+	} else {
+		b.write(fmt.Sprintf("\n%s\n", errors.Format(err, errors.FormatAsSentences())))
+		b.model.SetMessage(fmt.Sprintf(`%s of %s "%s" failed.`, commandTitle(cmd), item.Key.Kind().Name, item.Name))
+	}
+
+	// Reload items, the action may have modified the state
+	if items, err := b.actions.Items(ctx); err == nil {
+		b.model.SetItems(items)
+	} else {
+		b.write(fmt.Sprintf("\n%s\n", errors.Format(err, errors.FormatAsSentences())))
+	}
+
+	b.write("\nPress any key to return to the browser.")
+	if _, err := term.MakeRaw(fd); err != nil {
+		return err
+	}
+	if _, err := readKey(b.reader); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	b.write(enterScreen)
+	return nil
+}
+
+func (b *Browser) execute(ctx context.Context, cmd command, key model.Key) error {
+	switch cmd {
+	case cmdDiff:
+		return b.actions.Diff(ctx, key)
+	case cmdPull:
+		return b.actions.Pull(ctx, key)
+	case cmdPush:
+		return b.actions.Push(ctx, key)
+	default:
+		panic(errors.Errorf(`unexpected command "%d"`, cmd))
+	}
+}
+
+func (b *Browser) draw() {
+	width, height, err := term.GetSize(int(b.stdout.Fd()))
+	if err != nil {
+		width, height = defaultWidth, defaultHeight
+	}
+	b.write(clearScreen + b.model.Render(width, height))
+}
+
+func (b *Browser) write(str string) {
+	// The error can occur mainly in tests, if stdout of the virtual terminal is closed on test failure.
+	_, _ = io.WriteString(b.stdout, str)
+}
+
+func commandTitle(cmd command) string {
+	switch cmd {
+	case cmdDiff:
+		return "Diff"
+	case cmdPull:
+		return "Pull"
+	case cmdPush:
+		return "Push"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,129 @@
+// Package browser provides an interactive terminal UI to browse the local project state.
+package browser
+
+import (
+	"strings"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/diff"
+	"github.com/keboola/keboola-as-code/internal/pkg/model"
+)
+
+type SyncStatus int
+
+const (
+	// SyncUnknown - the remote state is not loaded, the browser works offline.
+	SyncUnknown SyncStatus = iota
+	SyncEqual
+	SyncChanged
+	SyncOnlyInLocal
+	SyncOnlyInRemote
+)
+
+// Item is one row in the browser list, a branch, config or config row.
+type Item struct {
+	Key           model.Key
+	Level         int // 0 branch, 1 config, 2 row
+	Name          string
+	Path          string
+	Search        string // fuzzy search text, it contains names of the object and its parents
+	Status        SyncStatus
+	ChangedFields string
+	State         model.ObjectState
+}
+
+// Objects is a subset of the project state, used to build the browser items.
+type Objects interface {
+	Branches() []*model.BranchState
+	ConfigsFrom(branch model.BranchKey) []*model.ConfigState
+	ConfigRowsFrom(config model.ConfigKey) []*model.ConfigRowState
+}
+
+func (s SyncStatus) Mark() string {
+	switch s {
+	case SyncEqual:
+		return diff.EqualMark
+	case SyncChanged:
+		return diff.ChangeMark
+	case SyncOnlyInLocal:
+		return diff.OnlyInLocalMark
+	case SyncOnlyInRemote:
+		return diff.OnlyInRemoteMark
+	default:
+		return "?"
+	}
+}
+
+func (s SyncStatus) String() string {
+	switch s {
+	case SyncEqual:
+		return "synchronized"
+	case SyncChanged:
+		return "changed"
+	case SyncOnlyInLocal:
+		return "only in the local state"
+	case SyncOnlyInRemote:
+		return "only in the remote state"
+	default:
+		return "unknown, the remote state is not loaded"
+	}
+}
+
+// ItemsFromState creates items for all branches, configs and rows, in the tree order.
+// The results are optional, if they are nil, then the sync status of all items is unknown.
+func ItemsFromState(objects Objects, results *diff.Results) []*Item {
+	// Index diff results
+	resultsMap := make(map[string]*diff.Result)
+	if results != nil {
+		for _, result := range results.Results {
+			resultsMap[result.Key().String()] = result
+		}
+	}
+
+	newItem := func(objectState model.ObjectState, level int, parentSearch string) *Item {
+		item := &Item{
+			Key:    objectState.Key(),
+			Level:  level,
+			Name:   objectState.ObjectName(),
+			Path:   objectState.Path(),
+			State:  objectState,
+			Status: SyncUnknown,
+		}
+		item.Search = strings.TrimPrefix(parentSearch+" / "+item.Name+" "+item.Key.ObjectID(), " / ")
+		if result, found := resultsMap[item.Key.String()]; found {
+			item.Status = statusFromResult(result)
+			if !result.ChangedFields.IsEmpty() {
+				item.ChangedFields = result.ChangedFields.String()
+			}
+		}
+		return item
+	}
+
+	var items []*Item
+	for _, branch := range objects.Branches() {
+		branchItem := newItem(branch, 0, "")
+		items = append(items, branchItem)
+		for _, config := range objects.ConfigsFrom(branch.BranchKey) {
+			configItem := newItem(config, 1, branchItem.Search)
+			items = append(items, configItem)
+			for _, row := range objects.ConfigRowsFrom(config.ConfigKey) {
+				items = append(items, newItem(row, 2, configItem.Search))
+			}
+		}
+	}
+	return items
+}
+
+func statusFromResult(result *diff.Result) SyncStatus {
+	switch result.State {
+	case diff.ResultEqual:
+		return SyncEqual
+	case diff.ResultNotEqual:
+		return SyncChanged
+	case diff.ResultOnlyInLocal:
+		return SyncOnlyInLocal
+	case diff.ResultOnlyInRemote:
+		return SyncOnlyInRemote
+	default:
+		return SyncUnknown
+	}
+}
@@ -0,0 +1,105 @@
+package browser
+
+import (
+	"bufio"
+	"unicode/utf8"
+)
+
+type KeyCode int
+
+const (
+	KeyUnknown KeyCode = iota
+	KeyRune
+	KeyUp
+	KeyDown
+	KeyPageUp
+	KeyPageDown
+	KeyHome
+	KeyEnd
+	KeyEnter
+	KeyEscape
+	KeyBackspace
+	KeyCtrlC
+)
+
+// Key is a key pressed in the raw mode terminal.
+type Key struct {
+	Code KeyCode
+	Rune rune
+}
+
+// readKey reads one key press, including escape sequences of special keys.
+func readKey(r *bufio.Reader) (Key, error) {
+	b, err := r.ReadByte()
+	if err != nil {
+		return Key{}, err
+	}
+
+	switch b {
+	case 0x03:
+		return Key{Code: KeyCtrlC}, nil
+	case '\r', '\n':
+		return Key{Code: KeyEnter}, nil
+	case 0x7f, 0x08:
+		return Key{Code: KeyBackspace}, nil
+	case 0x1b:
+		// A standalone escape key is not followed by other bytes
+		if r.Buffered() == 0 {
+			return Key{Code: KeyEscape}, nil
+		}
+		return readEscapeSequence(r)
+	}
+
+	// UTF-8 character
+	if b < utf8.RuneSelf {
+		return Key{Code: KeyRune, Rune: rune(b)}, nil
+	}
+	if err := r.UnreadByte(); err != nil {
+		return Key{}, err
+	}
+	ch, _, err := r.ReadRune()
+	if err != nil {
+		return Key{}, err
+	}
+	return Key{Code: KeyRune, Rune: ch}, nil
+}
+
+func readEscapeSequence(r *bufio.Reader) (Key, error) {
+	b, err := r.ReadByte()
+	if err != nil {
+		return Key{}, err
+	}
+	if b != '[' && b != 'O' {
+		return Key{Code: KeyUnknown}, nil
+	}
+
+	// Read parameters and the final byte of the sequence
+	var seq []byte
+	for {
+		b, err = r.ReadByte()
+		if err != nil {
+			return Key{}, err
+		}
+		seq = append(seq, b)
+		if b >= 0x40 && b <= 0x7e {
+			break
+		}
+	}
+
+	switch string(seq) {
+	case "A":
+		return Key{Code: KeyUp}, nil
+	case "B":
+		return Key{Code: KeyDown}, nil
+	case "H", "1~":
+		return Key{Code: KeyHome}, nil
+	case "F", "4~":
+		return Key{Code: KeyEnd}, nil
+	case "5~":
+		return Key{Code: KeyPageUp}, nil
+	case "6~":
+		return Key{Code: KeyPageDown}, nil
+	default:
+		return Key{Code: KeyUnknown}, nil
+	}
+}
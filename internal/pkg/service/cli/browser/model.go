@@ -0,0 +1,380 @@
+package browser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/encoding/json"
+	"github.com/keboola/keboola-as-code/internal/pkg/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/search"
+)
+
+const (
+	styleReset   = "\x1b[0m"
+	styleBold    = "\x1b[1m"
+	styleDim     = "\x1b[2m"
+	styleReverse = "\x1b[7m"
+	listRatio    = 0.45
+	minListWidth = 20
+	separator    = " │ "
+	helpText     = "↑/↓ move  / search  d diff  p pull  u push  [/] scroll preview  q quit"
+)
+
+type command int
+
+const (
+	cmdNone command = iota
+	cmdQuit
+	cmdDiff
+	cmdPull
+	cmdPush
+)
+
+// Model holds the browser state, it is independent of the terminal.
+type Model struct {
+	items     []*Item
+	visible   []*Item
+	matched   map[*Item]bool // items matched by the query, their parents are visible too
+	cursor    int
+	offset    int // first visible row of the list
+	preview   int // first visible row of the preview
+	query     string
+	searching bool
+	message   string
+}
+
+func NewModel(items []*Item) *Model {
+	m := &Model{}
+	m.SetItems(items)
+	return m
+}
+
+// SetItems replaces all items, for example after a pull, the selected object is kept.
+func (m *Model) SetItems(items []*Item) {
+	var selected model.Key
+	if item := m.Selected(); item != nil {
+		selected = item.Key
+	}
+
+	m.items = items
+	m.filter()
+
+	if selected != nil {
+		for i, item := range m.visible {
+			if item.Key.String() == selected.String() {
+				m.cursor = i
+				break
+			}
+		}
+	}
+}
+
+// Selected returns the item under the cursor or nil, if there is no visible item.
+func (m *Model) Selected() *Item {
+	if m.cursor < 0 || m.cursor >= len(m.visible) {
+		return nil
+	}
+	return m.visible[m.cursor]
+}
+
+// Visible returns items matching the search query and their parents.
+func (m *Model) Visible() []*Item {
+	return m.visible
+}
+
+func (m *Model) SetMessage(msg string) {
+	m.message = msg
+}
+
+// Update processes the pressed key and returns a command to execute.
+func (m *Model) Update(k Key) command {
+	m.message = ""
+
+	// Common keys
+	switch k.Code {
+	case KeyCtrlC:
+		return cmdQuit
+	case KeyUp:
+		m.move(-1)
+		return cmdNone
+	case KeyDown:
+		m.move(1)
+		return cmdNone
+	case KeyPageUp:
+		m.move(-10)
+		return cmdNone
+	case KeyPageDown:
+		m.move(10)
+		return cmdNone
+	case KeyHome:
+		m.move(-len(m.visible))
+		return cmdNone
+	case KeyEnd:
+		m.move(len(m.visible))
+		return cmdNone
+	}
+
+	// Search mode
+	if m.searching {
+		switch k.Code {
+		case KeyEnter:
+			m.searching = false
+		case KeyEscape:
+			m.searching = false
+			m.setQuery("")
+		case KeyBackspace:
+			if runes := []rune(m.query); len(runes) > 0 {
+				m.setQuery(string(runes[:len(runes)-1]))
+			}
+		case KeyRune:
+			m.setQuery(m.query + string(k.Rune))
+		}
+		return cmdNone
+	}
+
+	// Normal mode
+	switch k.Code {
+	case KeyEscape:
+		m.setQuery("")
+	case KeyRune:
+		switch k.Rune {
+		case 'q':
+			return cmdQuit
+		case '/':
+			m.searching = true
+		case 'k':
+			m.move(-1)
+		case 'j':
+			m.move(1)
+		case 'g':
+			m.move(-len(m.visible))
+		case 'G':
+			m.move(len(m.visible))
+		case '[':
+			m.preview = max(0, m.preview-5)
+		case ']':
+			m.preview += 5
+		case 'd':
+			return m.objectCommand(cmdDiff)
+		case 'p':
+			return m.objectCommand(cmdPull)
+		case 'u':
+			return m.objectCommand(cmdPush)
+		}
+	}
+	return cmdNone
+}
+
+// Render returns the whole screen, lines are separated by CRLF, because the terminal is in the raw mode.
+func (m *Model) Render(width, height int) string {
+	if width <= 0 || height <= 2 {
+		return ""
+	}
+
+	listWidth := max(minListWidth, int(float64(width)*listRatio))
+	previewWidth := max(0, width-listWidth-len([]rune(separator)))
+	bodyHeight := height - 2
+	m.scrollTo(bodyHeight)
+
+	// Header
+	header := "Keboola project browser"
+	if m.searching || m.query != "" {
+		header += "  search: " + m.query
+		if m.searching {
+			header += "_"
+		}
+	}
+	lines := []string{styleBold + fit(header, width) + styleReset}
+
+	// List and preview
+	previewLines := m.previewLines()
+	if m.preview > max(0, len(previewLines)-bodyHeight) {
+		m.preview = max(0, len(previewLines)-bodyHeight)
+	}
+	for row := 0; row < bodyHeight; row++ {
+		var line strings.Builder
+		line.WriteString(m.renderListRow(m.offset+row, listWidth))
+		line.WriteString(separator)
+		if i := m.preview + row; i < len(previewLines) {
+			line.WriteString(fit(previewLines[i], previewWidth))
+		}
+		lines = append(lines, line.String())
+	}
+
+	// Status bar
+	status := helpText
+	if m.message != "" {
+		status = m.message
+	}
+	lines = append(lines, styleReverse+fit(status, width)+styleReset)
+
+	return strings.Join(lines, "\r\n")
+}
+
+func (m *Model) renderListRow(index, width int) string {
+	if index >= len(m.visible) {
+		return strings.Repeat(" ", width)
+	}
+
+	item := m.visible[index]
+	text := fit(fmt.Sprintf("%s%s %s %s", strings.Repeat("  ", item.Level), item.Status.Mark(), item.Key.Kind().Abbr, item.Name), width)
+	switch {
+	case index == m.cursor:
+		return styleReverse + text + styleReset
+	case m.matched != nil && !m.matched[item]:
+		return styleDim + text + styleReset
+	default:
+		return text
+	}
+}
+
+func (m *Model) previewLines() (out []string) {
+	item := m.Selected()
+	if item == nil {
+		if m.query != "" {
+			return []string{fmt.Sprintf(`No object matches "%s".`, m.query)}
+		}
+		return []string{"The project is empty."}
+	}
+
+	field := func(name, value string) {
+		out = append(out, fmt.Sprintf("%-12s %s", name+":", value))
+	}
+	status := item.Status.Mark() + " " + item.Status.String()
+	if item.ChangedFields != "" {
+		status += " | changed: " + item.ChangedFields
+	}
+
+	var description string
+	var content any
+	switch v := item.State.LocalOrRemoteState().(type) {
+	case *model.Branch:
+		out = append(out, fmt.Sprintf(`Branch "%s"`, v.Name))
+		field("ID", v.ObjectID())
+		field("Default", fmt.Sprintf("%t", v.IsDefault))
+		description = v.Description
+	case *model.Config:
+		out = append(out, fmt.Sprintf(`Config "%s"`, v.Name))
+		field("ID", v.ObjectID())
+		field("Component", v.ComponentID.String())
+		if v.IsDisabled {
+			field("Disabled", "true")
+		}
+		if instanceID := v.Metadata.InstanceID(); instanceID != "" {
+			field("Template", fmt.Sprintf("%s, instance %s", v.Metadata.TemplateID(), instanceID))
+		}
+		description = v.Description
+		if v.Content != nil {
+			content = v.Content
+		}
+	case *model.ConfigRow:
+		out = append(out, fmt.Sprintf(`Row "%s"`, v.Name))
+		field("ID", v.ObjectID())
+		if v.IsDisabled {
+			field("Disabled", "true")
+		}
+		description = v.Description
+		if v.Content != nil {
+			content = v.Content
+		}
+	}
+	field("Path", item.Path)
+	field("Status", status)
+
+	if description = strings.TrimSpace(description); description != "" {
+		out = append(out, "", "Description:")
+		for _, line := range strings.Split(description, "\n") {
+			out = append(out, "  "+line)
+		}
+	}
+
+	if content != nil {
+		if str, err := json.EncodeString(content, true); err == nil {
+			out = append(out, "", "Configuration:")
+			for _, line := range strings.Split(strings.TrimRight(str, "\n"), "\n") {
+				out = append(out, "  "+line)
+			}
+		}
+	}
+
+	return out
+}
+
+func (m *Model) objectCommand(cmd command) command {
+	if m.Selected() == nil {
+		m.message = "No object selected."
+		return cmdNone
+	}
+	return cmd
+}
+
+func (m *Model) move(delta int) {
+	m.cursor = min(max(0, m.cursor+delta), max(0, len(m.visible)-1))
+	m.preview = 0
+}
+
+// scrollTo keeps the cursor in the visible part of the list.
+func (m *Model) scrollTo(height int) {
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+height {
+		m.offset = m.cursor - height + 1
+	}
+}
+
+func (m *Model) setQuery(query string) {
+	m.query = query
+	m.filter()
+	m.cursor = 0
+	m.offset = 0
+	m.preview = 0
+}
+
+// filter updates visible items according to the query.
+// Parents of the matched items are visible too, to keep the tree structure.
+func (m *Model) filter() {
+	if m.query == "" {
+		m.visible = m.items
+		m.matched = nil
+		m.cursor = min(m.cursor, max(0, len(m.visible)-1))
+		return
+	}
+
+	m.visible = nil
+	m.matched = make(map[*Item]bool)
+	var parents [3]*Item
+	var parentVisible [3]bool
+	for _, item := range m.items {
+		if item.Level < len(parents) {
+			parents[item.Level] = item
+			parentVisible[item.Level] = false
+		}
+		if !search.FuzzyMatch(item.Search, m.query) {
+			continue
+		}
+		for level := 0; level < item.Level && level < len(parents); level++ {
+			if parent := parents[level]; parent != nil && !parentVisible[level] {
+				parentVisible[level] = true
+				m.visible = append(m.visible, parent)
+			}
+		}
+		if item.Level < len(parents) {
+			parentVisible[item.Level] = true
+		}
+		m.matched[item] = true
+		m.visible = append(m.visible, item)
+	}
+}
+
+// fit truncates or pads the text to the width.
+func fit(text string, width int) string {
+	runes := []rune(strings.ReplaceAll(text, "\t", "  "))
+	if len(runes) > width {
+		if width <= 1 {
+			return string(runes[:width])
+		}
+		return string(runes[:width-1]) + "…"
+	}
+	return string(runes) + strings.Repeat(" ", width-len(runes))
+}
@@ -0,0 +1,206 @@
+package browser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/acarl005/stripansi"
+	"github.com/keboola/go-utils/pkg/orderedmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/diff"
+	"github.com/keboola/keboola-as-code/internal/pkg/model"
+)
+
+type testObjects struct {
+	branches []*model.BranchState
+	configs  []*model.ConfigState
+	rows     []*model.ConfigRowState
+}
+
+func (o *testObjects) Branches() []*model.BranchState {
+	return o.branches
+}
+
+func (o *testObjects) ConfigsFrom(branch model.BranchKey) (out []*model.ConfigState) {
+	for _, config := range o.configs {
+		if config.BranchKey() == branch {
+			out = append(out, config)
+		}
+	}
+	return out
+}
+
+func (o *testObjects) ConfigRowsFrom(config model.ConfigKey) (out []*model.ConfigRowState) {
+	for _, row := range o.rows {
+		if row.ConfigKey() == config {
+			out = append(out, row)
+		}
+	}
+	return out
+}
+
+func TestItemsFromState(t *testing.T) {
+	t.Parallel()
+
+	objects := newTestObjects()
+	items := ItemsFromState(objects, nil)
+	assert.Equal(t, []string{
+		"Main 123",
+		"Main 123 / My Extractor 456",
+		"Main 123 / My Extractor 456 / Row One 789",
+		"Main 123 / Other Writer 999",
+	}, itemsSearch(items))
+	for _, item := range items {
+		assert.Equal(t, SyncUnknown, item.Status)
+	}
+
+	// Sync status from the diff results
+	results := &diff.Results{Results: []*diff.Result{
+		{ObjectState: objects.branches[0], State: diff.ResultEqual},
+		{ObjectState: objects.configs[0], State: diff.ResultNotEqual, ChangedFields: model.NewChangedFields("name")},
+		{ObjectState: objects.rows[0], State: diff.ResultOnlyInLocal},
+	}}
+	items = ItemsFromState(objects, results)
+	assert.Equal(t, SyncEqual, items[0].Status)
+	assert.Equal(t, SyncChanged, items[1].Status)
+	assert.Equal(t, "name", items[1].ChangedFields)
+	assert.Equal(t, SyncOnlyInLocal, items[2].Status)
+	assert.Equal(t, SyncUnknown, items[3].Status)
+}
+
+func TestModel_Navigation(t *testing.T) {
+	t.Parallel()
+
+	m := NewModel(ItemsFromState(newTestObjects(), nil))
+	assert.Equal(t, "Main", m.Selected().Name)
+
+	assert.Equal(t, cmdNone, m.Update(Key{Code: KeyDown}))
+	assert.Equal(t, "My Extractor", m.Selected().Name)
+	m.Update(Key{Code: KeyRune, Rune: 'j'})
+	assert.Equal(t, "Row One", m.Selected().Name)
+	m.Update(Key{Code: KeyRune, Rune: 'G'})
+	assert.Equal(t, "Other Writer", m.Selected().Name)
+	m.Update(Key{Code: KeyDown})
+	assert.Equal(t, "Other Writer", m.Selected().Name)
+	m.Update(Key{Code: KeyRune, Rune: 'k'})
+	assert.Equal(t, "Row One", m.Selected().Name)
+	m.Update(Key{Code: KeyHome})
+	assert.Equal(t, "Main", m.Selected().Name)
+
+	// Selection is kept on reload
+	m.Update(Key{Code: KeyEnd})
+	m.SetItems(ItemsFromState(newTestObjects(), nil))
+	assert.Equal(t, "Other Writer", m.Selected().Name)
+}
+
+func TestModel_Search(t *testing.T) {
+	t.Parallel()
+
+	m := NewModel(ItemsFromState(newTestObjects(), nil))
+	m.Update(Key{Code: KeyRune, Rune: '/'})
+	for _, r := range "rowone" {
+		m.Update(Key{Code: KeyRune, Rune: r})
+	}
+
+	// Parents of the matched row are visible
+	assert.Equal(t, []string{"Main", "My Extractor", "Row One"}, itemsNames(m.Visible()))
+
+	// Keys are part of the query in the search mode
+	assert.Equal(t, cmdNone, m.Update(Key{Code: KeyRune, Rune: 'q'}))
+	assert.Empty(t, m.Visible())
+	m.Update(Key{Code: KeyBackspace})
+	assert.Len(t, m.Visible(), 3)
+
+	// Enter ends the search mode, the filter is kept
+	m.Update(Key{Code: KeyEnter})
+	assert.Len(t, m.Visible(), 3)
+
+	// Escape clears the filter
+	m.Update(Key{Code: KeyEscape})
+	assert.Len(t, m.Visible(), 4)
+}
+
+func TestModel_Commands(t *testing.T) {
+	t.Parallel()
+
+	m := NewModel(ItemsFromState(newTestObjects(), nil))
+	assert.Equal(t, cmdDiff, m.Update(Key{Code: KeyRune, Rune: 'd'}))
+	assert.Equal(t, cmdPull, m.Update(Key{Code: KeyRune, Rune: 'p'}))
+	assert.Equal(t, cmdPush, m.Update(Key{Code: KeyRune, Rune: 'u'}))
+	assert.Equal(t, cmdQuit, m.Update(Key{Code: KeyRune, Rune: 'q'}))
+	assert.Equal(t, cmdQuit, m.Update(Key{Code: KeyCtrlC}))
+
+	// No object is selected in an empty project
+	m = NewModel(nil)
+	assert.Equal(t, cmdNone, m.Update(Key{Code: KeyRune, Rune: 'p'}))
+	assert.Contains(t, stripansi.Strip(m.Render(80, 5)), "No object selected.")
+}
+
+func TestModel_Render(t *testing.T) {
+	t.Parallel()
+
+	m := NewModel(ItemsFromState(newTestObjects(), nil))
+	m.Update(Key{Code: KeyDown})
+
+	lines := strings.Split(stripansi.Strip(m.Render(120, 12)), "\r\n")
+	require.Len(t, lines, 12)
+	for _, line := range lines {
+		assert.Len(t, []rune(line), 120)
+	}
+	assert.Equal(t, "Keboola project browser", strings.TrimSpace(lines[0]))
+	assert.Contains(t, lines[1], `? B Main`)
+	assert.Contains(t, lines[1], `Config "My Extractor"`)
+	assert.Contains(t, lines[2], `  ? C My Extractor`)
+	assert.Contains(t, lines[2], `ID:          456`)
+	assert.Contains(t, lines[3], `    ? R Row One`)
+	assert.Contains(t, lines[3], `Component:   keboola.ex-db-mysql`)
+	assert.Contains(t, lines[4], `Path:        main/extractor/keboola.ex-db-mysql/my-extractor`)
+	assert.Contains(t, strings.Join(lines, "\n"), `"foo": "bar"`)
+	assert.Equal(t, helpText, strings.TrimSpace(lines[11]))
+}
+
+func newTestObjects() *testObjects {
+	branchKey := model.BranchKey{ID: 123}
+	configKey := model.ConfigKey{BranchID: 123, ComponentID: "keboola.ex-db-mysql", ID: "456"}
+	otherConfigKey := model.ConfigKey{BranchID: 123, ComponentID: "keboola.wr-db-mysql", ID: "999"}
+	rowKey := model.ConfigRowKey{BranchID: 123, ComponentID: "keboola.ex-db-mysql", ConfigID: "456", ID: "789"}
+	content := orderedmap.New()
+	content.Set("foo", "bar")
+
+	return &testObjects{
+		branches: []*model.BranchState{{
+			BranchManifest: &model.BranchManifest{BranchKey: branchKey, Paths: model.Paths{AbsPath: model.NewAbsPath("", "main")}},
+			Local:          &model.Branch{BranchKey: branchKey, Name: "Main", IsDefault: true},
+		}},
+		configs: []*model.ConfigState{
+			{
+				ConfigManifest: &model.ConfigManifest{ConfigKey: configKey, Paths: model.Paths{AbsPath: model.NewAbsPath("main", "extractor/keboola.ex-db-mysql/my-extractor")}},
+				Local:          &model.Config{ConfigKey: configKey, Name: "My Extractor", Content: content},
+			},
+			{
+				ConfigManifest: &model.ConfigManifest{ConfigKey: otherConfigKey, Paths: model.Paths{AbsPath: model.NewAbsPath("main", "writer/keboola.wr-db-mysql/other-writer")}},
+				Local:          &model.Config{ConfigKey: otherConfigKey, Name: "Other Writer"},
+			},
+		},
+		rows: []*model.ConfigRowState{{
+			ConfigRowManifest: &model.ConfigRowManifest{ConfigRowKey: rowKey, Paths: model.Paths{AbsPath: model.NewAbsPath("main/extractor/keboola.ex-db-mysql/my-extractor", "rows/row-one")}},
+			Local:             &model.ConfigRow{ConfigRowKey: rowKey, Name: "Row One"},
+		}},
+	}
+}
+
+func itemsNames(items []*Item) (out []string) {
+	for _, item := range items {
+		out = append(out, item.Name)
+	}
+	return out
+}
+
+func itemsSearch(items []*Item) (out []string) {
+	for _, item := range items {
+		out = append(out, item.Search)
+	}
+	return out
+}
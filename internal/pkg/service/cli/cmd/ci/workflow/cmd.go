@@ -7,12 +7,14 @@ import (
 	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/dependencies"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/helpmsg"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/configmap"
+	"github.com/keboola/keboola-as-code/internal/pkg/workflows"
 	workflowsGen "github.com/keboola/keboola-as-code/pkg/lib/operation/project/local/workflows/generate"
 )
 
 type Flags struct {
 	StorageAPIHost configmap.Value[string] `configKey:"storage-api-host" configShorthand:"H" configUsage:"storage API host, eg. \"connection.keboola.com\""`
 	CI             configmap.Value[bool]   `configKey:"ci" configUsage:"generate workflows"`
+	CIProvider     configmap.Value[string] `configKey:"ci-provider" configUsage:"CI provider: github, gitlab, bitbucket or azure"`
 	CIValidate     configmap.Value[bool]   `configKey:"ci-validate" configUsage:"create workflow to validate all branches on change"`
 	CIPull         configmap.Value[bool]   `configKey:"ci-pull" configUsage:"create workflow to sync main branch each hour"`
 	CIMainBranch   configmap.Value[string] `configKey:"ci-main-branch" configUsage:"name of the main branch for push/pull workflows"`
@@ -22,6 +24,7 @@ type Flags struct {
 func DefaultFlags() Flags {
 	return Flags{
 		CI:           configmap.NewValue(true),
+		CIProvider:   configmap.NewValue(workflows.DefaultProvider),
 		CIValidate:   configmap.NewValue(true),
 		CIPush:       configmap.NewValue(true),
 		CIPull:       configmap.NewValue(true),
@@ -41,6 +44,11 @@ func Command(p dependencies.Provider) *cobra.Command {
 				return err
 			}
 
+			// Validate the CI provider before any work is done
+			if err := workflows.ValidateProvider(f.CIProvider.Value); err != nil {
+				return err
+			}
+
 			// Get dependencies
 			d, err := p.LocalCommandScope(cmd.Context(), f.StorageAPIHost)
 			if err != nil {
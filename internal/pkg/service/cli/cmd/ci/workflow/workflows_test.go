@@ -24,6 +24,12 @@ func TestAskWorkflowsOptionsInteractive(t *testing.T) {
 	go func() {
 		defer wg.Done()
 
+		assert.NoError(t, console.ExpectString(`Please select the CI provider:`))
+
+		assert.NoError(t, console.SendDownArrow()) // gitlab
+
+		assert.NoError(t, console.SendEnter()) // enter
+
 		assert.NoError(t, console.ExpectString(`Generate "validate" workflow?`))
 
 		assert.NoError(t, console.SendLine(`n`)) // no
@@ -36,7 +42,7 @@ func TestAskWorkflowsOptionsInteractive(t *testing.T) {
 
 		assert.NoError(t, console.SendLine(`n`)) // no
 
-		assert.NoError(t, console.ExpectString(`Please select the main GitHub branch name:`))
+		assert.NoError(t, console.ExpectString(`Please select the main branch name:`))
 
 		assert.NoError(t, console.SendEnter()) // enter - main
 
@@ -46,6 +52,7 @@ func TestAskWorkflowsOptionsInteractive(t *testing.T) {
 	// Run
 	out := AskWorkflowsOptions(f, d)
 	assert.Equal(t, genWorkflows.Options{
+		Provider:   `gitlab`,
 		Validate:   false,
 		Push:       true,
 		Pull:       false,
@@ -68,10 +75,12 @@ func TestAskWorkflowsOptionsByFlag(t *testing.T) {
 	f.CIPull = configmap.NewValueWithOrigin(false, configmap.SetByFlag)
 	f.CIMainBranch = configmap.NewValueWithOrigin("main", configmap.SetByFlag)
 	f.CIPush = configmap.NewValueWithOrigin(true, configmap.SetByFlag)
+	f.CIProvider = configmap.NewValueWithOrigin("bitbucket", configmap.SetByFlag)
 
 	// Run
 	out := AskWorkflowsOptions(f, d)
 	assert.Equal(t, genWorkflows.Options{
+		Provider:   `bitbucket`,
 		Validate:   false,
 		Push:       true,
 		Pull:       false,
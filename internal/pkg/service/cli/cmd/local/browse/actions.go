@@ -0,0 +1,109 @@
+package browse
+
+import (
+	"context"
+
+	"github.com/keboola/go-client/pkg/keboola"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/diff"
+	"github.com/keboola/keboola-as-code/internal/pkg/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/project"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/browser"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/dependencies"
+	createDiff "github.com/keboola/keboola-as-code/pkg/lib/operation/project/sync/diff/create"
+	"github.com/keboola/keboola-as-code/pkg/lib/operation/project/sync/diff/printdiff"
+	"github.com/keboola/keboola-as-code/pkg/lib/operation/project/sync/pull"
+	"github.com/keboola/keboola-as-code/pkg/lib/operation/project/sync/push"
+	loadState "github.com/keboola/keboola-as-code/pkg/lib/operation/state/load"
+)
+
+// localStateScope loads the local state without the Storage API token.
+// The Storage API is used only by the remote state, which is not loaded.
+type localStateScope struct {
+	dependencies.LocalCommandScope
+}
+
+func (localStateScope) KeboolaProjectAPI() *keboola.AuthorizedAPI {
+	return nil
+}
+
+// actions run sync operations limited to the selected object.
+// The authenticated scope and the remote state are created by the first action and kept for the later actions.
+// Pull and push operations update the loaded state, so it reflects changes made by the browser.
+type actions struct {
+	p          dependencies.Provider
+	flags      Flags
+	prj        *project.Project
+	localState *project.State
+
+	d     dependencies.RemoteCommandScope
+	state *project.State
+}
+
+func newActions(p dependencies.Provider, flags Flags, prj *project.Project, localState *project.State) *actions {
+	return &actions{p: p, flags: flags, prj: prj, localState: localState}
+}
+
+func (a *actions) Diff(ctx context.Context, key model.Key) error {
+	projectState, err := a.remoteState(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = printdiff.Run(ctx, projectState, printdiff.Options{PrintDetails: true, Object: key}, a.d)
+	return err
+}
+
+func (a *actions) Pull(ctx context.Context, key model.Key) error {
+	projectState, err := a.remoteState(ctx)
+	if err != nil {
+		return err
+	}
+
+	return pull.Run(ctx, projectState, pull.Options{Object: key}, a.d)
+}
+
+func (a *actions) Push(ctx context.Context, key model.Key) error {
+	projectState, err := a.remoteState(ctx)
+	if err != nil {
+		return err
+	}
+
+	return push.Run(ctx, projectState, push.Options{Object: key, ChangeDescription: "Updated from #KeboolaCLI"}, a.d)
+}
+
+// Items are called after an action, so the remote state is loaded and the sync status of items is known.
+// If the remote state has not been loaded, for example the authentication failed, only the local state is used.
+func (a *actions) Items(ctx context.Context) ([]*browser.Item, error) {
+	if a.state == nil {
+		return browser.ItemsFromState(a.localState, nil), nil
+	}
+
+	results, err := createDiff.Run(ctx, createDiff.Options{Objects: a.state}, a.d, diff.WithIgnoreBranchName(a.state.ProjectManifest().AllowTargetENV()))
+	if err != nil {
+		return nil, err
+	}
+
+	return browser.ItemsFromState(a.state, results), nil
+}
+
+// remoteState authenticates and loads the local and the remote state on the first call.
+func (a *actions) remoteState(ctx context.Context) (*project.State, error) {
+	if a.state != nil {
+		return a.state, nil
+	}
+
+	d, err := a.p.RemoteCommandScope(ctx, a.flags.StorageAPIHost, a.flags.StorageAPIToken)
+	if err != nil {
+		return nil, err
+	}
+
+	projectState, err := a.prj.LoadState(loadState.DiffOptions(), d)
+	if err != nil {
+		return nil, err
+	}
+
+	a.d = d
+	a.state = projectState
+	return projectState, nil
+}
@@ -0,0 +1,66 @@
+package browse
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/browser"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/dependencies"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/helpmsg"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/configmap"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+	loadState "github.com/keboola/keboola-as-code/pkg/lib/operation/state/load"
+)
+
+type Flags struct {
+	StorageAPIHost  configmap.Value[string] `configKey:"storage-api-host" configShorthand:"H" configUsage:"storage API host, eg. \"connection.keboola.com\""`
+	StorageAPIToken configmap.Value[string] `configKey:"storage-api-token" configShorthand:"t" configUsage:"storage API token from your project"`
+}
+
+func DefaultFlags() Flags {
+	return Flags{}
+}
+
+func Command(p dependencies.Provider) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "browse",
+		Short: helpmsg.Read(`local/browse/short`),
+		Long:  helpmsg.Read(`local/browse/long`),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			f := Flags{}
+			if err := p.BaseScope().ConfigBinder().Bind(cmd.Context(), cmd.Flags(), args, &f); err != nil {
+				return err
+			}
+
+			// Command must be used in project directory, the authentication is performed by the first sync action.
+			// The cached index with components is used, if the Storage API is not reachable.
+			d, err := p.LocalCommandScope(cmd.Context(), f.StorageAPIHost, dependencies.WithIndexCache())
+			if err != nil {
+				return err
+			}
+			prj, _, err := d.LocalProject(cmd.Context(), false)
+			if err != nil {
+				return err
+			}
+
+			// The browser requires an interactive terminal
+			stdin, stdout, ok := d.Dialogs().Terminal()
+			if !ok {
+				return errors.New(`command "local browse" requires an interactive terminal`)
+			}
+
+			// Load only the local state, the remote state is loaded on demand by sync actions
+			localState, err := prj.LoadState(loadState.LocalOperationOptions(), localStateScope{LocalCommandScope: d})
+			if err != nil {
+				return err
+			}
+
+			// Browse
+			items := browser.ItemsFromState(localState, nil)
+			return browser.New(stdin, stdout, items, newActions(p, f, prj, localState)).Run(cmd.Context())
+		},
+	}
+
+	configmap.MustGenerateFlags(cmd.Flags(), DefaultFlags())
+
+	return cmd
+}
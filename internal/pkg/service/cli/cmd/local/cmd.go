@@ -3,9 +3,11 @@ package local
 import (
 	"github.com/spf13/cobra"
 
+	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/cmd/local/browse"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/cmd/local/create"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/cmd/local/encrypt"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/cmd/local/fixpath"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/cmd/local/graph"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/cmd/local/persist"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/cmd/local/template"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/cmd/local/validate"
@@ -26,6 +28,8 @@ func Commands(p dependencies.Provider) *cobra.Command {
 		validate.Command(p),
 		fixpath.Command(p),
 		template.Commands(p),
+		browse.Command(p),
+		graph.Command(p),
 	)
 
 	return cmd
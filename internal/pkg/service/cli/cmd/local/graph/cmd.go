@@ -0,0 +1,84 @@
+package graph
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/lineage"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/dependencies"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/helpmsg"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/configmap"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+	graphOp "github.com/keboola/keboola-as-code/pkg/lib/operation/project/local/graph"
+	loadState "github.com/keboola/keboola-as-code/pkg/lib/operation/state/load"
+)
+
+type Flags struct {
+	StorageAPIHost  configmap.Value[string] `configKey:"storage-api-host" configShorthand:"H" configUsage:"storage API host, eg. \"connection.keboola.com\""`
+	StorageAPIToken configmap.Value[string] `configKey:"storage-api-token" configShorthand:"t" configUsage:"storage API token from your project"`
+	Branch          configmap.Value[string] `configKey:"branch" configShorthand:"b" configUsage:"branch ID or name, the main branch by default"`
+	Format          configmap.Value[string] `configKey:"format" configUsage:"output format (dot/mermaid/json)"`
+	Focus           configmap.Value[string] `configKey:"focus" configUsage:"export only nodes connected to the node or table ID"`
+	Direction       configmap.Value[string] `configKey:"direction" configUsage:"direction of the focus (upstream/downstream/both)"`
+}
+
+func DefaultFlags() Flags {
+	return Flags{
+		Format:    configmap.NewValue(string(lineage.FormatDOT)),
+		Direction: configmap.NewValue(string(lineage.Upstream)),
+	}
+}
+
+func Command(p dependencies.Provider) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "graph",
+		Short: helpmsg.Read(`local/graph/short`),
+		Long:  helpmsg.Read(`local/graph/long`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			f := Flags{}
+			if err := p.BaseScope().ConfigBinder().Bind(cmd.Context(), cmd.Flags(), args, &f); err != nil {
+				return err
+			}
+
+			// Command must be used in project directory
+			prj, d, err := p.LocalProject(cmd.Context(), false, f.StorageAPIHost, f.StorageAPIToken)
+			if err != nil {
+				return err
+			}
+
+			// Load project state
+			projectState, err := prj.LoadState(loadState.LocalOperationOptions(), d)
+			if err != nil {
+				return err
+			}
+
+			// Select branch, the main branch by default
+			mainBranch := projectState.MainBranch()
+			if mainBranch == nil {
+				return errors.New("main branch not found in the local state")
+			}
+			branchKey := mainBranch.BranchKey
+			if f.Branch.IsSet() {
+				branch, err := d.Dialogs().SelectBranch(projectState.LocalObjects().Branches(), `Select branch`, f.Branch)
+				if err != nil {
+					return err
+				}
+				branchKey = branch.BranchKey
+			}
+
+			// Options
+			options := graphOp.Options{
+				Branch:    branchKey,
+				Format:    lineage.Format(f.Format.Value),
+				Focus:     f.Focus.Value,
+				Direction: lineage.Direction(f.Direction.Value),
+			}
+
+			// Export graph
+			return graphOp.Run(cmd.Context(), projectState, options, d)
+		},
+	}
+
+	configmap.MustGenerateFlags(cmd.Flags(), DefaultFlags())
+
+	return cmd
+}
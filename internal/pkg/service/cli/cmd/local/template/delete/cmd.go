@@ -1,6 +1,8 @@
 package templatedelete
 
 import (
+	"strings"
+
 	"github.com/spf13/cobra"
 
 	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/dependencies"
@@ -15,6 +17,7 @@ type Flags struct {
 	StorageAPIToken configmap.Value[string] `configKey:"storage-api-token" configShorthand:"t" configUsage:"storage API token from your project"`
 	Branch          configmap.Value[string] `configKey:"branch" configShorthand:"b" configUsage:"branch ID or name"`
 	Instance        configmap.Value[string] `configKey:"instance" configShorthand:"i" configUsage:"instance ID of the template to delete"`
+	KeepConfigs     configmap.Value[string] `configKey:"keep-configs" configUsage:"comma separated list of configs to keep as regular configs, in format \"[componentId]:[configId]\""`
 	DryRun          configmap.Value[bool]   `configKey:"dry-run" configUsage:"print what needs to be done"`
 }
 
@@ -52,8 +55,14 @@ func Command(p dependencies.Provider) *cobra.Command {
 				return err
 			}
 
+			// Configs to keep
+			keepConfigs, err := deleteOp.ParseConfigKeys(branchKey, strings.Split(f.KeepConfigs.Value, ","))
+			if err != nil {
+				return err
+			}
+
 			// Delete template
-			options := deleteOp.Options{Branch: branchKey, Instance: instance.InstanceID, DryRun: f.DryRun.Value}
+			options := deleteOp.Options{Branch: branchKey, Instance: instance.InstanceID, DryRun: f.DryRun.Value, KeepConfigs: keepConfigs}
 			return deleteOp.Run(cmd.Context(), projectState, options, d)
 		},
 	}
@@ -3,6 +3,8 @@ package upgrade
 import (
 	"context"
 
+	"github.com/keboola/go-client/pkg/keboola"
+
 	"github.com/keboola/keboola-as-code/internal/pkg/log"
 	"github.com/keboola/keboola-as-code/internal/pkg/model"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/dialog"
@@ -19,16 +21,22 @@ type upgradeTmplDialog struct {
 	groups     input.StepsGroupsExt
 	out        upgradeTemplate.Options
 	inputsFile configmap.Value[string]
+	storage    *input.StorageObjects
 }
 
 type upgradeTmplDeps interface {
+	KeboolaProjectAPI() *keboola.AuthorizedAPI
 	Logger() log.Logger
 }
 
 // AskUpgradeTemplateOptions - dialog for updating a template to a new version.
 func AskUpgradeTemplateOptions(ctx context.Context, d *dialog.Dialogs, deps upgradeTmplDeps, projectState *state.State, branchKey model.BranchKey, instance model.TemplateInstance, groups template.StepsGroups, inputsFile configmap.Value[string]) (upgradeTemplate.Options, error) {
 	groupsExt := upgrade.ExportInputsValues(ctx, deps.Logger().Debugf, projectState, branchKey, instance.InstanceID, groups)
-	dialog := &upgradeTmplDialog{Dialogs: d, groups: groupsExt, inputsFile: inputsFile}
+	storage, err := input.LoadStorageObjects(ctx, deps.KeboolaProjectAPI(), branchKey.ID, input.StorageKindsOf(groupsExt.InputsMap()))
+	if err != nil {
+		return upgradeTemplate.Options{}, err
+	}
+	dialog := &upgradeTmplDialog{Dialogs: d, groups: groupsExt, inputsFile: inputsFile, storage: storage}
 	dialog.out.Branch = branchKey
 	dialog.out.Instance = instance
 	return dialog.ask(ctx)
@@ -36,7 +44,7 @@ func AskUpgradeTemplateOptions(ctx context.Context, d *dialog.Dialogs, deps upgr
 
 func (d *upgradeTmplDialog) ask(ctx context.Context) (upgradeTemplate.Options, error) {
 	// User inputs
-	if v, _, err := d.Dialogs.AskUseTemplateInputs(ctx, d.groups, false, d.inputsFile); err != nil {
+	if v, _, err := d.Dialogs.AskUseTemplateInputs(ctx, d.groups, false, d.inputsFile, d.storage); err != nil {
 		return d.out, err
 	} else {
 		d.out.Inputs = v
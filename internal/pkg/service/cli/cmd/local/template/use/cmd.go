@@ -1,6 +1,7 @@
 package use
 
 import (
+	"context"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -9,7 +10,9 @@ import (
 	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/dependencies"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/helpmsg"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/configmap"
+	"github.com/keboola/keboola-as-code/internal/pkg/template/input"
 	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+	prerequisitesOp "github.com/keboola/keboola-as-code/pkg/lib/operation/project/local/template/prerequisites"
 	useOp "github.com/keboola/keboola-as-code/pkg/lib/operation/project/local/template/use"
 	loadState "github.com/keboola/keboola-as-code/pkg/lib/operation/state/load"
 )
@@ -69,12 +72,37 @@ func Command(p dependencies.Provider) *cobra.Command {
 				return err
 			}
 
+			// Required templates are resolved when the target branch is known
+			var prerequisites prerequisitesOp.Prerequisites
+			resolvePrerequisites := func(ctx context.Context, branch model.BranchKey) (map[string]any, error) {
+				resolved, err := prerequisitesOp.Resolve(ctx, projectState, branch, template, askPrerequisiteInputs(d.Dialogs()), d)
+				if err != nil {
+					return nil, err
+				}
+				prerequisites = resolved
+				return prerequisites.InputsFor(template.TemplateRecord().Requirements.Templates)
+			}
+
+			// Existing tables, buckets and workspaces for the storage inputs
+			loadStorageObjects := func(ctx context.Context, branch model.BranchKey, kinds input.Kinds) (*input.StorageObjects, error) {
+				return input.LoadStorageObjects(ctx, d.KeboolaProjectAPI(), branch.ID, kinds)
+			}
+
 			// Options
-			options, err := AskUseTemplateOptions(cmd.Context(), d.Dialogs(), projectState, template.Inputs(), f)
+			options, err := AskUseTemplateOptions(cmd.Context(), d.Dialogs(), projectState, template.Inputs(), resolvePrerequisites, loadStorageObjects, f)
 			if err != nil {
 				return err
 			}
 
+			// Install or upgrade required templates
+			warnings, err := prerequisitesOp.Install(cmd.Context(), projectState, options.TargetBranch, prerequisites, d)
+			if err != nil {
+				return err
+			}
+			for _, w := range warnings {
+				d.Logger().Warn(cmd.Context(), w)
+			}
+
 			// Use template
 			opResult, err := useOp.Run(cmd.Context(), projectState, template, options, d)
 			if err != nil {
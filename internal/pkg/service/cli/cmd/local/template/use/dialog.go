@@ -3,29 +3,45 @@ package use
 import (
 	"context"
 
+	"github.com/keboola/keboola-as-code/internal/pkg/model"
 	"github.com/keboola/keboola-as-code/internal/pkg/project"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/dialog"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/prompt"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/configmap"
 	"github.com/keboola/keboola-as-code/internal/pkg/template"
+	"github.com/keboola/keboola-as-code/internal/pkg/template/input"
 	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+	"github.com/keboola/keboola-as-code/pkg/lib/operation/project/local/template/prerequisites"
 	useTemplate "github.com/keboola/keboola-as-code/pkg/lib/operation/project/local/template/use"
 )
 
 type useTmplDialog struct {
 	*dialog.Dialogs
 	Flags
-	projectState *project.State
-	inputs       template.StepsGroups
-	out          useTemplate.Options
+	projectState  *project.State
+	inputs        template.StepsGroups
+	prerequisites PrerequisitesFn
+	storage       StorageObjectsFn
+	out           useTemplate.Options
 }
 
+// PrerequisitesFn resolves templates required by the template in the target branch.
+// It returns values passed from the required templates to the template inputs.
+type PrerequisitesFn func(ctx context.Context, branch model.BranchKey) (map[string]any, error)
+
+// StorageObjectsFn loads existing objects from the target branch, to select values of the storage inputs.
+type StorageObjectsFn func(ctx context.Context, branch model.BranchKey, kinds input.Kinds) (*input.StorageObjects, error)
+
 // AskUseTemplateOptions - dialog for using the template in the project.
-func AskUseTemplateOptions(ctx context.Context, d *dialog.Dialogs, projectState *project.State, inputs template.StepsGroups, f Flags) (useTemplate.Options, error) {
+// The prerequisites and storage callbacks are optional.
+func AskUseTemplateOptions(ctx context.Context, d *dialog.Dialogs, projectState *project.State, inputs template.StepsGroups, prerequisites PrerequisitesFn, storage StorageObjectsFn, f Flags) (useTemplate.Options, error) {
 	dialog := &useTmplDialog{
-		Dialogs:      d,
-		projectState: projectState,
-		inputs:       inputs,
-		Flags:        f,
+		Dialogs:       d,
+		projectState:  projectState,
+		inputs:        inputs,
+		prerequisites: prerequisites,
+		storage:       storage,
+		Flags:         f,
 	}
 	return dialog.ask(ctx)
 }
@@ -38,6 +54,22 @@ func (d *useTmplDialog) ask(ctx context.Context) (useTemplate.Options, error) {
 	}
 	d.out.TargetBranch = targetBranch.BranchKey
 
+	// Values passed from the required templates are used as default values
+	groups := d.inputs.ToExtended()
+	if d.prerequisites != nil {
+		values, err := d.prerequisites(ctx, d.out.TargetBranch)
+		if err != nil {
+			return d.out, err
+		}
+		_ = groups.VisitInputs(func(group *input.StepsGroupExt, step *input.StepExt, inputDef *input.Input) error {
+			if v, found := values[inputDef.ID]; found {
+				inputDef.Default = v
+				step.Show = true
+			}
+			return nil
+		})
+	}
+
 	// Instance name
 	if v, err := d.askInstanceName(); err != nil {
 		return d.out, err
@@ -45,8 +77,17 @@ func (d *useTmplDialog) ask(ctx context.Context) (useTemplate.Options, error) {
 		d.out.InstanceName = v
 	}
 
+	// Existing objects for the storage inputs
+	var storage *input.StorageObjects
+	if kinds := input.StorageKindsOf(groups.InputsMap()); d.storage != nil && len(kinds) > 0 {
+		storage, err = d.storage(ctx, d.out.TargetBranch, kinds)
+		if err != nil {
+			return d.out, err
+		}
+	}
+
 	// User inputs
-	if v, _, err := d.AskUseTemplateInputs(ctx, d.inputs.ToExtended(), false, d.InputsFile); err != nil {
+	if v, _, err := d.AskUseTemplateInputs(ctx, groups, false, d.InputsFile, storage); err != nil {
 		return d.out, err
 	} else {
 		d.out.Inputs = v
@@ -75,3 +116,12 @@ func (d *useTmplDialog) askInstanceName() (string, error) {
 	}
 	return v, nil
 }
+
+// askPrerequisiteInputs - dialog to enter inputs of a required template, which will be installed or upgraded.
+func askPrerequisiteInputs(d *dialog.Dialogs) prerequisites.InputsFn {
+	return func(ctx context.Context, tmpl *template.Template, groups input.StepsGroupsExt) (template.InputsValues, error) {
+		d.Printf("Template \"%s\" is required, please enter its inputs.\n", tmpl.FullName())
+		values, _, err := d.AskUseTemplateInputs(ctx, groups, false, configmap.Value[string]{}, nil)
+		return values, err
+	}
+}
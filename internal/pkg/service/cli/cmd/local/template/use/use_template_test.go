@@ -195,7 +195,7 @@ func TestAskUseTemplate_ShowIfMet(t *testing.T) {
 		InstanceName: configmap.NewValue("My Instance"),
 	}
 
-	output, err := AskUseTemplateOptions(context.Background(), d, projectState, stepsGroups, f)
+	output, err := AskUseTemplateOptions(context.Background(), d, projectState, stepsGroups, nil, nil, f)
 	assert.NoError(t, err)
 
 	assert.NoError(t, console.Tty().Close())
@@ -322,7 +322,7 @@ func TestAskUseTemplate_ShowIfNotMet(t *testing.T) {
 		InputsFile:   configmap.Value[string]{},
 	}
 
-	output, err := AskUseTemplateOptions(context.Background(), d, projectState, stepsGroups, f)
+	output, err := AskUseTemplateOptions(context.Background(), d, projectState, stepsGroups, nil, nil, f)
 	assert.NoError(t, err)
 
 	assert.NoError(t, console.Tty().Close())
@@ -475,7 +475,7 @@ func TestAskUseTemplate_OptionalSteps(t *testing.T) {
 		InputsFile:   configmap.Value[string]{},
 	}
 
-	output, err := AskUseTemplateOptions(context.Background(), d, projectState, stepsGroups, f)
+	output, err := AskUseTemplateOptions(context.Background(), d, projectState, stepsGroups, nil, nil, f)
 	assert.NoError(t, err)
 
 	assert.NoError(t, console.Tty().Close())
@@ -576,7 +576,7 @@ func TestAskUseTemplate_InputsFromFile(t *testing.T) {
 		},
 	}
 
-	output, err := AskUseTemplateOptions(context.Background(), d, projectState, stepsGroups, f)
+	output, err := AskUseTemplateOptions(context.Background(), d, projectState, stepsGroups, nil, nil, f)
 	assert.NoError(t, err)
 
 	// Assert
@@ -672,7 +672,7 @@ func TestAskUseTemplate_InputsFromFile_InvalidStepsCount(t *testing.T) {
 		},
 	}
 
-	_, err = AskUseTemplateOptions(context.Background(), d, projectState, stepsGroups, f)
+	_, err = AskUseTemplateOptions(context.Background(), d, projectState, stepsGroups, nil, nil, f)
 	expectedErr := `
 steps group 1 "Please select which steps you want to fill." is invalid:
 - all steps (3) must be selected
@@ -4,6 +4,7 @@ import (
 	"github.com/keboola/go-client/pkg/keboola"
 	"github.com/spf13/cobra"
 
+	"github.com/keboola/keboola-as-code/internal/pkg/lint"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/dependencies"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/helpmsg"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/configmap"
@@ -18,12 +19,24 @@ import (
 type Flags struct {
 	StorageAPIHost  configmap.Value[string] `configKey:"storage-api-host" configShorthand:"H" configUsage:"storage API host, eg. \"connection.keboola.com\""`
 	StorageAPIToken configmap.Value[string] `configKey:"storage-api-token" configShorthand:"t" configUsage:"storage API token from your project"`
+	Lint            configmap.Value[bool]   `configKey:"lint" configUsage:"run lint rules, enabled by default if \".keboola/lint.json\" exists"`
+	SARIFOutput     configmap.Value[string] `configKey:"sarif-output" configUsage:"write lint results in the SARIF format to the file"`
+}
+
+// SubcommandFlags are used by the "config", "row" and "schema" subcommands, lint rules are not applied there.
+type SubcommandFlags struct {
+	StorageAPIHost  configmap.Value[string] `configKey:"storage-api-host" configShorthand:"H" configUsage:"storage API host, eg. \"connection.keboola.com\""`
+	StorageAPIToken configmap.Value[string] `configKey:"storage-api-token" configShorthand:"t" configUsage:"storage API token from your project"`
 }
 
 func DefaultFlags() Flags {
 	return Flags{}
 }
 
+func DefaultSubcommandFlags() SubcommandFlags {
+	return SubcommandFlags{}
+}
+
 func Command(p dependencies.Provider) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "validate",
@@ -35,6 +48,11 @@ func Command(p dependencies.Provider) *cobra.Command {
 				return err
 			}
 
+			// SARIF report is produced by the lint rules
+			if f.Lint.IsSet() && !f.Lint.Value && f.SARIFOutput.Value != "" {
+				return errors.New(`flag "--sarif-output" cannot be used with "--lint=false"`)
+			}
+
 			// Command must be used in project directory
 			prj, d, err := p.LocalProject(cmd.Context(), false, f.StorageAPIHost, f.StorageAPIToken)
 			if err != nil {
@@ -47,10 +65,18 @@ func Command(p dependencies.Provider) *cobra.Command {
 				return err
 			}
 
+			// Lint rules are enabled by default, if the lint config exists, the explicit flag has priority
+			lintEnabled := f.Lint.Value
+			if !f.Lint.IsSet() {
+				lintEnabled = f.SARIFOutput.Value != "" || lint.ConfigExists(cmd.Context(), prj.Fs())
+			}
+
 			// Options
 			options := validate.Options{
 				ValidateSecrets:    true,
 				ValidateJSONSchema: true,
+				Lint:               lintEnabled,
+				SARIFOutput:        f.SARIFOutput.Value,
 			}
 
 			// Validate
@@ -85,7 +111,7 @@ func ValidateConfigCommand(p dependencies.Provider) *cobra.Command {
 			}
 
 			// flags
-			f := Flags{}
+			f := SubcommandFlags{}
 			if err := p.BaseScope().ConfigBinder().Bind(cmd.Context(), cmd.Flags(), args, &f); err != nil {
 				return err
 			}
@@ -100,7 +126,7 @@ func ValidateConfigCommand(p dependencies.Provider) *cobra.Command {
 		},
 	}
 
-	configmap.MustGenerateFlags(cmd.Flags(), DefaultFlags())
+	configmap.MustGenerateFlags(cmd.Flags(), DefaultSubcommandFlags())
 
 	return cmd
 }
@@ -115,7 +141,7 @@ func ValidateRowCommand(p dependencies.Provider) *cobra.Command {
 				return errors.New("please enter two arguments: component ID and JSON file path")
 			}
 
-			f := Flags{}
+			f := SubcommandFlags{}
 			err = p.BaseScope().ConfigBinder().Bind(cmd.Context(), cmd.Flags(), args, &f)
 			if err != nil {
 				return err
@@ -131,7 +157,7 @@ func ValidateRowCommand(p dependencies.Provider) *cobra.Command {
 		},
 	}
 
-	configmap.MustGenerateFlags(cmd.Flags(), DefaultFlags())
+	configmap.MustGenerateFlags(cmd.Flags(), DefaultSubcommandFlags())
 
 	return cmd
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/dependencies"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/helpmsg"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/configmap"
+	"github.com/keboola/keboola-as-code/internal/pkg/workflows"
 	createEnvFiles "github.com/keboola/keboola-as-code/pkg/lib/operation/project/local/envfiles/create"
 	initOp "github.com/keboola/keboola-as-code/pkg/lib/operation/project/sync/init"
 )
@@ -17,6 +18,7 @@ type Flags struct {
 	StorageAPIToken configmap.Value[string] `configKey:"storage-api-token" configShorthand:"t" configUsage:"storage API token from your project"`
 	Branches        configmap.Value[string] `configKey:"branches" configShorthand:"b" configUsage:"comma separated IDs or name globs, use \"*\" for all"`
 	CI              configmap.Value[bool]   `configKey:"ci" configUsage:"generate workflows"`
+	CIProvider      configmap.Value[string] `configKey:"ci-provider" configUsage:"CI provider: github, gitlab, bitbucket or azure"`
 	CIValidate      configmap.Value[bool]   `configKey:"ci-validate" configUsage:"create workflow to validate all branches on change"`
 	CIPush          configmap.Value[bool]   `configKey:"ci-push" configUsage:"create workflow to push change in main branch to the project"`
 	CIPull          configmap.Value[bool]   `configKey:"ci-pull" configUsage:"create workflow to sync main branch each hour"`
@@ -28,6 +30,7 @@ func DefaultFlags() Flags {
 	return Flags{
 		Branches:       configmap.NewValue("main"),
 		CI:             configmap.NewValue(true),
+		CIProvider:     configmap.NewValue(workflows.DefaultProvider),
 		CIValidate:     configmap.NewValue(true),
 		CIPull:         configmap.NewValue(true),
 		CIPush:         configmap.NewValue(true),
@@ -52,6 +55,11 @@ func Command(p dependencies.Provider) *cobra.Command {
 				return err
 			}
 
+			// Validate the CI provider before any work is done
+			if err := workflows.ValidateProvider(f.CIProvider.Value); err != nil {
+				return err
+			}
+
 			// Get dependencies
 			projectDeps, err := p.RemoteCommandScope(cmd.Context(), f.StorageAPIHost, f.StorageAPIToken)
 			if err != nil {
@@ -56,14 +56,16 @@ func AskInitOptions(ctx context.Context, d *dialog.Dialogs, dep initDeps, f Flag
 		}
 
 		out.Workflows = workflowsGen.Options{
+			Provider:   f.CIProvider.Value,
 			Validate:   f.CI.Value,
 			Push:       f.CI.Value,
 			Pull:       f.CI.Value,
 			MainBranch: f.CIMainBranch.Value,
 		}
-	} else if d.Confirm(&prompt.Confirm{Label: "Generate workflows files for GitHub Actions?", Default: true}) {
+	} else if d.Confirm(&prompt.Confirm{Label: "Generate CI workflows files?", Default: true}) {
 		out.Workflows = workflow.AskWorkflowsOptions(workflow.Flags{
 			CI:           f.CI,
+			CIProvider:   f.CIProvider,
 			CIPush:       f.CIPush,
 			CIPull:       f.CIPull,
 			CIMainBranch: f.CIMainBranch,
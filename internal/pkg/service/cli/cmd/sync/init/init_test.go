@@ -41,10 +41,14 @@ func TestDialogs_AskInitOptions(t *testing.T) {
 
 		assert.NoError(t, console.SendEnter()) // enter, first option "only main branch"
 
-		assert.NoError(t, console.ExpectString(`Generate workflows files for GitHub Actions?`))
+		assert.NoError(t, console.ExpectString(`Generate CI workflows files?`))
 
 		assert.NoError(t, console.SendEnter()) // enter - yes
 
+		assert.NoError(t, console.ExpectString(`Please select the CI provider:`))
+
+		assert.NoError(t, console.SendEnter()) // enter - github
+
 		assert.NoError(t, console.ExpectString(`Generate "validate" workflow?`))
 
 		assert.NoError(t, console.SendEnter()) // enter - yes
@@ -57,7 +61,7 @@ func TestDialogs_AskInitOptions(t *testing.T) {
 
 		assert.NoError(t, console.SendLine(`n`))
 
-		assert.NoError(t, console.ExpectString(`Please select the main GitHub branch name:`))
+		assert.NoError(t, console.ExpectString(`Please select the main branch name:`))
 
 		assert.NoError(t, console.SendEnter()) // enter - main
 
@@ -79,6 +83,7 @@ func TestDialogs_AskInitOptions(t *testing.T) {
 			AllowedBranches: model.AllowedBranches{model.MainBranchDef},
 		},
 		Workflows: genWorkflows.Options{
+			Provider:   `github`,
 			Validate:   true,
 			Push:       true,
 			Pull:       false,
@@ -117,6 +122,6 @@ func TestDialogs_AskInitOptions_No_CI(t *testing.T) {
 			Naming:          naming.TemplateWithoutIds(),
 			AllowedBranches: model.AllowedBranches{model.MainBranchDef},
 		},
-		Workflows: genWorkflows.Options{MainBranch: "main"},
+		Workflows: genWorkflows.Options{Provider: "github", MainBranch: "main"},
 	}, opts)
 }
@@ -5,8 +5,10 @@ import (
 
 	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/cmd/template/create"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/cmd/template/describe"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/cmd/template/diff"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/cmd/template/list"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/cmd/template/repository"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/cmd/template/schema"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/cmd/template/test"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/dependencies"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/helpmsg"
@@ -21,6 +23,8 @@ func Commands(p dependencies.Provider) *cobra.Command {
 	cmd.AddCommand(
 		list.Command(p),
 		describe.Command(p),
+		diff.Command(p),
+		schema.Command(p),
 		create.Command(p),
 		repository.Commands(p),
 		test.Commands(p),
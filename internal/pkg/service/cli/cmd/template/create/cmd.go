@@ -1,6 +1,8 @@
 package create
 
 import (
+	"context"
+
 	"github.com/spf13/cobra"
 
 	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/dependencies"
@@ -20,6 +22,8 @@ type Flags struct {
 	UsedComponents  configmap.Value[string] `configKey:"used-components" configShorthand:"u" configUsage:"comma separated list of component ids"`
 	AllConfigs      configmap.Value[bool]   `configKey:"all-configs" configShorthand:"a" configUsage:"use all configs from the branch"`
 	AllInputs       configmap.Value[bool]   `configKey:"all-inputs" configUsage:"use all found config/row fields as user inputs"`
+	RulesFile       configmap.Value[string] `configKey:"rules-file" configUsage:"JSON file with rules for non-interactive creation"`
+	ProjectDir      configmap.Value[string] `configKey:"project-dir" configUsage:"path to a local project, configs are read from its local state instead of the API"`
 }
 
 func DefaultFlags() Flags {
@@ -37,6 +41,11 @@ func Command(p dependencies.Provider) *cobra.Command {
 				return err
 			}
 
+			// Create template from the local project
+			if f.ProjectDir.IsSet() {
+				return createFromLocalProject(cmd.Context(), p, f)
+			}
+
 			// Command must be used in template repository
 			dep, err := p.RemoteCommandScope(cmd.Context(), f.StorageAPIHost, f.StorageAPIToken)
 			if err != nil {
@@ -58,3 +67,40 @@ func Command(p dependencies.Provider) *cobra.Command {
 
 	return cmd
 }
+
+// createFromLocalProject creates the template from the local state of the project, the Storage API token is not needed.
+func createFromLocalProject(ctx context.Context, p dependencies.Provider, f Flags) error {
+	baseScp := p.BaseScope()
+	prj, err := loadProjectManifest(ctx, baseScp.Logger(), baseScp.Environment(), f.ProjectDir.Value)
+	if err != nil {
+		return err
+	}
+
+	// The API host is taken from the project manifest, if it is not set by the flag
+	host := f.StorageAPIHost
+	if !host.IsSet() {
+		host = configmap.NewValueWithOrigin(prj.ProjectManifest().APIHost(), configmap.SetByFlag)
+	}
+
+	// Command must be used in template repository
+	localScp, err := p.LocalCommandScope(ctx, host)
+	if err != nil {
+		return err
+	}
+	dep := localSourceScope{LocalCommandScope: localScp}
+
+	// Load objects from the local project
+	source, err := loadLocalSource(ctx, dep, prj)
+	if err != nil {
+		return err
+	}
+
+	// Options
+	options, err := askCreateTemplateOpts(ctx, dep.Dialogs(), dep, f, source)
+	if err != nil {
+		return err
+	}
+
+	// Create template
+	return createOp.Run(ctx, options, dep)
+}
@@ -2,6 +2,9 @@ package create
 
 import (
 	"context"
+	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
 
@@ -15,6 +18,7 @@ import (
 	"github.com/keboola/keboola-as-code/internal/pkg/template/input"
 	createTemplate "github.com/keboola/keboola-as-code/pkg/lib/operation/template/local/create"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/keboola/keboola-as-code/internal/pkg/model"
 	nopPrompt "github.com/keboola/keboola-as-code/internal/pkg/service/cli/prompt/nop"
@@ -447,3 +451,145 @@ func TestAskCreateTemplateAllConfigs(t *testing.T) {
 		Components: []string{},
 	}, opts)
 }
+
+func TestAskCreateTemplateRulesFile(t *testing.T) {
+	t.Parallel()
+
+	d, _ := dialog.NewForTest(t, false)
+
+	deps := dependencies.NewMocked(t, context.Background())
+	templatehelper.AddMockedObjectsResponses(deps.MockedHTTPTransport())
+
+	// Rules file
+	rulesFile := filepath.Join(t.TempDir(), "rules.json")
+	rulesJSON := `
+{
+  "name": "My Super Template",
+  "description": "Full workflow to ...",
+  "configs": ["keboola.my-component:1", "keboola.my-component:3"],
+  "ids": {
+    "keboola.my-component:1": "extractor",
+    "keboola.my-component:1:456": "extractor-row"
+  },
+  "inputs": [
+    {"path": "parameters.string", "inputId": "my-string", "name": "My String", "description": "Some string."},
+    {"path": "parameters.int", "componentId": "keboola.my-component"}
+  ]
+}
+`
+	require.NoError(t, os.WriteFile(rulesFile, []byte(rulesJSON), 0o600))
+
+	// Flags take precedence over the rules file, secrets are always inputs
+	f := Flags{
+		StorageAPIHost: configmap.NewValueWithOrigin("connection.keboola.com", configmap.SetByFlag),
+		ID:             configmap.NewValueWithOrigin("my-template-id", configmap.SetByFlag),
+		RulesFile:      configmap.NewValueWithOrigin(rulesFile, configmap.SetByFlag),
+	}
+
+	// Run
+	opts, err := AskCreateTemplateOpts(context.Background(), d, deps, f)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, createTemplate.Options{
+		ID:           `my-template-id`,
+		Name:         `My Super Template`,
+		Description:  `Full workflow to ...`,
+		SourceBranch: model.BranchKey{ID: 123},
+		Configs: []create.ConfigDef{
+			{
+				Key:        model.ConfigKey{BranchID: 123, ComponentID: `keboola.my-component`, ID: `1`},
+				TemplateID: `extractor`,
+				Inputs: []create.InputDef{
+					{InputID: "my-component-password", Path: orderedmap.PathFromStr("parameters.#password")},
+					{InputID: "my-component-int", Path: orderedmap.PathFromStr("parameters.int")},
+					{InputID: "my-string", Path: orderedmap.PathFromStr("parameters.string")},
+				},
+				Rows: []create.ConfigRowDef{
+					{
+						Key:        model.ConfigRowKey{BranchID: 123, ComponentID: `keboola.my-component`, ConfigID: `1`, ID: `456`},
+						TemplateID: `extractor-row`,
+					},
+				},
+			},
+			{
+				Key:        model.ConfigKey{BranchID: 123, ComponentID: `keboola.my-component`, ID: `3`},
+				TemplateID: `config-3`,
+			},
+		},
+		StepsGroups: input.StepsGroups{
+			{
+				Description: "Default Group",
+				Required:    "all",
+				Steps: []input.Step{
+					{
+						Icon:        "common:settings",
+						Name:        "Default Step",
+						Description: "Default Step",
+						Inputs: input.Inputs{
+							{
+								ID:   "my-component-password",
+								Name: "Password",
+								Type: input.TypeString,
+								Kind: input.KindHidden,
+							},
+							{
+								ID:      "my-component-int",
+								Name:    "Int",
+								Type:    input.TypeInt,
+								Kind:    input.KindInput,
+								Default: 123,
+							},
+							{
+								ID:          "my-string",
+								Name:        "My String",
+								Description: "Some string.",
+								Type:        input.TypeString,
+								Kind:        input.KindInput,
+								Default:     "my string",
+							},
+						},
+					},
+				},
+			},
+		},
+		Components: []string{},
+	}, opts)
+}
+
+func TestAskCreateTemplateRulesFile_Invalid(t *testing.T) {
+	t.Parallel()
+
+	d, _ := dialog.NewForTest(t, false)
+
+	deps := dependencies.NewMocked(t, context.Background())
+	templatehelper.AddMockedObjectsResponses(deps.MockedHTTPTransport())
+
+	// Rules file
+	rulesFile := filepath.Join(t.TempDir(), "rules.json")
+	rulesJSON := `
+{
+  "name": "My Super Template",
+  "description": "Full workflow to ...",
+  "configs": ["keboola.my-component:1"],
+  "inputs": [
+    {"path": "parameters.missing"},
+    {"path": "parameters.string", "componentId": "keboola.other"}
+  ]
+}
+`
+	require.NoError(t, os.WriteFile(rulesFile, []byte(rulesJSON), 0o600))
+
+	f := Flags{
+		StorageAPIHost: configmap.NewValueWithOrigin("connection.keboola.com", configmap.SetByFlag),
+		RulesFile:      configmap.NewValueWithOrigin(rulesFile, configmap.SetByFlag),
+	}
+
+	// Run
+	_, err := AskCreateTemplateOpts(context.Background(), d, deps, f)
+	require.Error(t, err)
+	assert.Equal(t, strings.TrimSpace(`
+- input rule "parameters.missing" does not match any field in the selected configurations
+- input rule "parameters.string" does not match any field in the selected configurations
+`), err.Error())
+}
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/keboola/keboola-as-code/internal/pkg/template"
@@ -18,6 +19,7 @@ import (
 	"github.com/keboola/keboola-as-code/internal/pkg/utils/strhelper"
 	createTemplate "github.com/keboola/keboola-as-code/pkg/lib/operation/template/local/create"
 	"github.com/umisama/go-regexpcache"
+	"golang.org/x/exp/maps"
 )
 
 type createTmplDialogDeps interface {
@@ -34,37 +36,50 @@ type createTmplDialog struct {
 	selectedBranch  *model.Branch
 	allConfigs      []*model.ConfigWithRows
 	selectedConfigs []*model.ConfigWithRows
+	rules           *Rules
+	source          *localSource
 	out             createTemplate.Options
 }
 
 // AskCreateTemplateOpts - dialog for creating a template from an existing project.
+// If the rules file is specified, no question is asked, see Rules.
 func AskCreateTemplateOpts(ctx context.Context, d *dialog.Dialogs, deps createTmplDialogDeps, f Flags) (createTemplate.Options, error) {
-	return (&createTmplDialog{
+	return askCreateTemplateOpts(ctx, d, deps, f, nil)
+}
+
+// askCreateTemplateOpts - if the source is set, branches and configs are read from the local project, not from the API.
+func askCreateTemplateOpts(ctx context.Context, d *dialog.Dialogs, deps createTmplDialogDeps, f Flags, source *localSource) (createTemplate.Options, error) {
+	dlg := &createTmplDialog{
 		Dialogs: d,
 		prompt:  d.Prompt,
 		deps:    deps,
 		Flags:   f,
-	}).ask(ctx)
+		source:  source,
+	}
+
+	if f.RulesFile.IsSet() {
+		rules, err := LoadRules(f.RulesFile.Value)
+		if err != nil {
+			return createTemplate.Options{}, err
+		}
+		rules.applyTo(&dlg.Flags)
+		dlg.rules = rules
+	}
+
+	return dlg.ask(ctx)
 }
 
 func (d *createTmplDialog) ask(ctx context.Context) (createTemplate.Options, error) {
-	// Get Storage API
-	api := d.deps.KeboolaProjectAPI()
-
 	// Load branches
-	var allBranches []*model.Branch
-	if result, err := api.ListBranchesRequest().Send(ctx); err == nil {
-		for _, apiBranch := range *result {
-			allBranches = append(allBranches, model.NewBranch(apiBranch))
-		}
-	} else {
+	allBranches, err := d.loadBranches(ctx)
+	if err != nil {
 		return d.out, err
 	}
 
 	// Name
 	if d.Name.IsSet() {
 		d.out.Name = d.Name.Value
-	} else {
+	} else if d.rules == nil {
 		d.out.Name = d.askName()
 	}
 	if err := validateTemplateName(d.out.Name); err != nil {
@@ -72,9 +87,12 @@ func (d *createTmplDialog) ask(ctx context.Context) (createTemplate.Options, err
 	}
 
 	// ID
-	if d.ID.IsSet() {
+	switch {
+	case d.ID.IsSet():
 		d.out.ID = d.ID.Value
-	} else {
+	case d.rules != nil:
+		d.out.ID = strhelper.NormalizeName(d.out.Name)
+	default:
 		d.out.ID = d.askID()
 	}
 	if err := validateID(d.out.ID); err != nil {
@@ -84,36 +102,35 @@ func (d *createTmplDialog) ask(ctx context.Context) (createTemplate.Options, err
 	// Description
 	if d.Description.IsSet() {
 		d.out.Description = d.Description.Value
-	} else {
+	} else if d.rules == nil {
 		d.out.Description = d.askDescription()
 	}
 	if err := validateTemplateDescription(d.out.Description); err != nil {
 		return d.out, err
 	}
 
-	// Branch
-	var err error
-	d.selectedBranch, err = d.SelectBranch(allBranches, `Select the source branch`, d.Branch)
+	// Branch, the default branch is used with the rules file
+	if d.rules != nil && !d.Branch.IsSet() {
+		d.selectedBranch, err = defaultBranch(allBranches)
+	} else {
+		d.selectedBranch, err = d.SelectBranch(allBranches, `Select the source branch`, d.Branch)
+	}
 	if err != nil {
 		return d.out, err
 	}
 	d.out.SourceBranch = d.selectedBranch.BranchKey
 
 	// Load configs
-	branchKey := keboola.BranchKey{ID: d.selectedBranch.ID}
-	if result, err := api.ListConfigsAndRowsFrom(branchKey).Send(ctx); err == nil {
-		for _, component := range *result {
-			for _, apiConfig := range component.Configs {
-				d.allConfigs = append(d.allConfigs, model.NewConfigWithRows(apiConfig))
-			}
-		}
-	} else {
+	d.allConfigs, err = d.loadConfigs(ctx, d.selectedBranch.BranchKey)
+	if err != nil {
 		return d.out, err
 	}
 
 	// Select configs
 	if d.AllConfigs.Value {
 		d.selectedConfigs = d.allConfigs
+	} else if d.rules != nil && !d.Configs.IsSet() {
+		return d.out, errors.New(`please specify configs in the rules file or use the "--configs" or "--all-configs" flag`)
 	} else {
 		configs, err := d.SelectConfigs(d.allConfigs, `Select the configurations to include in the template`, d.Configs)
 		if err != nil {
@@ -122,30 +139,88 @@ func (d *createTmplDialog) ask(ctx context.Context) (createTemplate.Options, err
 		d.selectedConfigs = configs
 	}
 
-	// Ask for new ID for each config and row
-	d.out.Configs, err = askTemplateObjectsIds(d.selectedBranch, d.selectedConfigs, d.Dialogs)
-	if err != nil {
-		return d.out, err
-	}
+	if d.rules != nil {
+		// Generate new ID for each config and row, IDs can be overridden by the rules
+		d.out.Configs, err = templateObjectsIdsFromRules(d.selectedBranch, d.selectedConfigs, d.rules.IDs, d.Dialogs)
+		if err != nil {
+			return d.out, err
+		}
 
-	// Ask for user inputs
-	objectInputs, stepsGroups, err := d.AskNewTemplateInputs(ctx, d.deps, d.selectedBranch, d.selectedConfigs, d.AllInputs)
-	if err != nil {
-		return d.out, err
+		// Select user inputs by the rules
+		objectInputs, stepsGroups, err := d.NewTemplateInputsFromRules(ctx, d.deps, d.selectedBranch, d.selectedConfigs, d.rules.Inputs)
+		if err != nil {
+			return d.out, err
+		}
+		objectInputs.SetTo(d.out.Configs)
+		d.out.StepsGroups = stepsGroups
+	} else {
+		// Ask for new ID for each config and row
+		d.out.Configs, err = askTemplateObjectsIds(d.selectedBranch, d.selectedConfigs, d.Dialogs)
+		if err != nil {
+			return d.out, err
+		}
+
+		// Ask for user inputs
+		objectInputs, stepsGroups, err := d.AskNewTemplateInputs(ctx, d.deps, d.selectedBranch, d.selectedConfigs, d.AllInputs)
+		if err != nil {
+			return d.out, err
+		}
+		objectInputs.SetTo(d.out.Configs)
+		d.out.StepsGroups = stepsGroups
 	}
-	objectInputs.SetTo(d.out.Configs)
-	d.out.StepsGroups = stepsGroups
 
 	// Ask for list of used components
-	if d.UsedComponents.IsSet() {
+	switch {
+	case d.UsedComponents.IsSet():
 		d.out.Components = strings.Split(d.UsedComponents.Value, `,`)
-	} else {
+	case d.rules != nil:
+		d.out.Components = []string{}
+	default:
 		d.out.Components = d.askComponents(d.deps.Components().Used())
 	}
 
+	// Objects of the local project are used instead of the remote project
+	if d.source != nil {
+		d.out.SourceObjects = d.source.objects
+	}
+
 	return d.out, nil
 }
 
+func (d *createTmplDialog) loadBranches(ctx context.Context) ([]*model.Branch, error) {
+	if d.source != nil {
+		return d.source.branches, nil
+	}
+
+	var out []*model.Branch
+	result, err := d.deps.KeboolaProjectAPI().ListBranchesRequest().Send(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, apiBranch := range *result {
+		out = append(out, model.NewBranch(apiBranch))
+	}
+	return out, nil
+}
+
+func (d *createTmplDialog) loadConfigs(ctx context.Context, branch model.BranchKey) ([]*model.ConfigWithRows, error) {
+	if d.source != nil {
+		return d.source.configs[branch], nil
+	}
+
+	var out []*model.ConfigWithRows
+	result, err := d.deps.KeboolaProjectAPI().ListConfigsAndRowsFrom(keboola.BranchKey{ID: branch.ID}).Send(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, component := range *result {
+		for _, apiConfig := range component.Configs {
+			out = append(out, model.NewConfigWithRows(apiConfig))
+		}
+	}
+	return out, nil
+}
+
 func (d *createTmplDialog) askComponents(all []*keboola.Component) []string {
 	opts := make([]string, 0)
 	for _, c := range all {
@@ -211,9 +286,10 @@ func validateTemplateDescription(val any) error {
 
 type templateIdsDialog struct {
 	*dialog.Dialogs
-	prompt  prompt.Prompt
-	branch  *model.Branch
-	configs []*model.ConfigWithRows
+	prompt    prompt.Prompt
+	branch    *model.Branch
+	configs   []*model.ConfigWithRows
+	overrides map[string]string // template ID by "componentId:configId" or "componentId:configId:rowId"
 }
 
 // askTemplateObjectsIds - dialog to define human-readable ID for each config and config row.
@@ -222,6 +298,34 @@ func askTemplateObjectsIds(branch *model.Branch, configs []*model.ConfigWithRows
 	return (&templateIdsDialog{Dialogs: d, prompt: d.Prompt, branch: branch, configs: configs}).ask()
 }
 
+// templateObjectsIdsFromRules - generates human-readable ID for each config and config row without prompts.
+// Used in AskCreateTemplateOpts with the rules file.
+func templateObjectsIdsFromRules(branch *model.Branch, configs []*model.ConfigWithRows, overrides map[string]string, d *dialog.Dialogs) ([]create.ConfigDef, error) {
+	dlg := &templateIdsDialog{Dialogs: d, prompt: d.Prompt, branch: branch, configs: configs, overrides: overrides}
+
+	// Each override must match a selected object
+	refs := make(map[string]bool)
+	for _, c := range configs {
+		refs[objectRef(c)] = true
+		for _, r := range c.Rows {
+			refs[objectRef(r)] = true
+		}
+	}
+	errs := errors.NewMultiError()
+	keys := maps.Keys(overrides)
+	sort.Strings(keys)
+	for _, ref := range keys {
+		if !refs[ref] {
+			errs.Append(errors.Errorf(`ID is defined for "%s", but the object is not selected`, ref))
+		}
+	}
+	if errs.Len() > 0 {
+		return nil, errs.ErrorOrNil()
+	}
+
+	return dlg.parse(dlg.defaultValue())
+}
+
 func (d *templateIdsDialog) ask() ([]create.ConfigDef, error) {
 	result, _ := d.prompt.Editor("md", &prompt.Question{
 		Description: `Please enter a human readable ID for each config and config row.`,
@@ -336,10 +440,13 @@ func (d *templateIdsDialog) defaultValue() string {
 	// Generate default IDs for configs and rows
 	idByKey := make(map[string]string)
 	ids := make(map[string]bool)
+	for _, id := range d.overrides {
+		ids[id] = true
+	}
 	for _, c := range d.configs {
-		makeUniqueID(c, idByKey, ids)
+		d.makeID(c, idByKey, ids)
 		for _, r := range c.Rows {
-			makeUniqueID(r, idByKey, ids)
+			d.makeID(r, idByKey, ids)
 		}
 	}
 
@@ -369,6 +476,37 @@ Do not edit lines starting with "#"!
 	return lines.String()
 }
 
+func (d *templateIdsDialog) makeID(object model.Object, idByKey map[string]string, ids map[string]bool) {
+	if id, found := d.overrides[objectRef(object)]; found {
+		idByKey[object.Key().String()] = id
+		return
+	}
+	makeUniqueID(object, idByKey, ids)
+}
+
+// objectRef returns "componentId:configId" for a config and "componentId:configId:rowId" for a row.
+func objectRef(object model.Object) string {
+	switch v := object.(type) {
+	case *model.ConfigWithRows:
+		return fmt.Sprintf("%s:%s", v.ComponentID, v.ID)
+	case *model.Config:
+		return fmt.Sprintf("%s:%s", v.ComponentID, v.ID)
+	case *model.ConfigRow:
+		return fmt.Sprintf("%s:%s:%s", v.ComponentID, v.ConfigID, v.ID)
+	default:
+		return object.Key().String()
+	}
+}
+
+func defaultBranch(all []*model.Branch) (*model.Branch, error) {
+	for _, b := range all {
+		if b.IsDefault {
+			return b, nil
+		}
+	}
+	return nil, errors.New(`default branch not found`)
+}
+
 func makeUniqueID(object model.Object, idByKey map[string]string, ids map[string]bool) {
 	name := object.ObjectName()
 	id := strhelper.NormalizeName(name)
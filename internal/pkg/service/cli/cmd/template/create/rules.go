@@ -0,0 +1,52 @@
+package create
+
+import (
+	"os"
+	"strings"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/encoding/json"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/dialog"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/configmap"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+// Rules for the non-interactive creation of a template, loaded from the "--rules-file".
+// Values set by flags take precedence over the rules.
+type Rules struct {
+	ID          string             `json:"id"`
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	Branch      string             `json:"branch"`
+	Configs     []string           `json:"configs"`
+	IDs         map[string]string  `json:"ids"`
+	Inputs      []dialog.InputRule `json:"inputs"`
+	Components  []string           `json:"components"`
+}
+
+// LoadRules from the JSON file.
+func LoadRules(path string) (*Rules, error) {
+	content, err := os.ReadFile(path) // nolint:forbidigo // file may be outside the repository, so the OS package is used
+	if err != nil {
+		return nil, errors.Errorf(`cannot read rules file "%s": %w`, path, err)
+	}
+	rules := &Rules{}
+	if err := json.Decode(content, rules); err != nil {
+		return nil, errors.Errorf(`cannot decode rules file "%s": %w`, path, err)
+	}
+	return rules, nil
+}
+
+// applyTo sets flags not set by the user from the rules.
+func (r *Rules) applyTo(f *Flags) {
+	setString := func(v *configmap.Value[string], value string) {
+		if !v.IsSet() && value != "" {
+			*v = configmap.NewValueWithOrigin(value, configmap.SetByConfig)
+		}
+	}
+	setString(&f.ID, r.ID)
+	setString(&f.Name, r.Name)
+	setString(&f.Description, r.Description)
+	setString(&f.Branch, r.Branch)
+	setString(&f.Configs, strings.Join(r.Configs, ","))
+	setString(&f.UsedComponents, strings.Join(r.Components, ","))
+}
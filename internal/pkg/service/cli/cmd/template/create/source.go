@@ -0,0 +1,106 @@
+package create
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+
+	"github.com/keboola/go-client/pkg/keboola"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/env"
+	"github.com/keboola/keboola-as-code/internal/pkg/filesystem/aferofs"
+	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/project"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/dependencies"
+	"github.com/keboola/keboola-as-code/internal/pkg/telemetry"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+	loadState "github.com/keboola/keboola-as-code/pkg/lib/operation/state/load"
+)
+
+// localSourceScope creates the template without the Storage API token.
+// The Storage API is used only to load the remote state, which is replaced by the local project objects.
+type localSourceScope struct {
+	dependencies.LocalCommandScope
+}
+
+func (localSourceScope) KeboolaProjectAPI() *keboola.AuthorizedAPI {
+	return nil
+}
+
+type localSourceDeps interface {
+	Components() *model.ComponentsMap
+	KeboolaProjectAPI() *keboola.AuthorizedAPI
+	Logger() log.Logger
+	Telemetry() telemetry.Telemetry
+}
+
+// localSource provides branches and configs from the local state of a project, see the "--project-dir" flag.
+// All objects are converted to the form returned by the Storage API, so the template is created as from the remote project.
+type localSource struct {
+	branches []*model.Branch
+	configs  map[model.BranchKey][]*model.ConfigWithRows
+	objects  []model.Object
+}
+
+// loadProjectManifest from the directory, the API host is used if it is not set by the flag.
+func loadProjectManifest(ctx context.Context, logger log.Logger, envs env.Provider, dir string) (*project.Project, error) {
+	absDir, err := filepath.Abs(dir) // nolint: forbidigo // the project may be outside the repository directory
+	if err != nil {
+		return nil, err
+	}
+
+	fs, err := aferofs.NewLocalFs(absDir)
+	if err != nil {
+		return nil, err
+	}
+
+	prj, err := project.New(ctx, logger, fs, envs, false)
+	if err != nil {
+		return nil, errors.PrefixErrorf(err, `cannot load project from "%s"`, dir)
+	}
+	return prj, nil
+}
+
+// loadLocalSource loads the local state of the project.
+func loadLocalSource(ctx context.Context, d localSourceDeps, prj *project.Project) (*localSource, error) {
+	projectState, err := prj.LoadState(loadState.LocalOperationOptions(), d)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parents are processed before children
+	objectStates := projectState.All()
+	sort.SliceStable(objectStates, func(i, j int) bool {
+		return objectStates[i].Level() < objectStates[j].Level()
+	})
+
+	src := &localSource{configs: make(map[model.BranchKey][]*model.ConfigWithRows)}
+	configs := make(map[model.ConfigKey]*model.ConfigWithRows)
+	for _, objectState := range objectStates {
+		if !objectState.HasLocalState() {
+			continue
+		}
+
+		object, err := projectState.RemoteManager().ToAPIForm(ctx, objectState)
+		if err != nil {
+			return nil, err
+		}
+		src.objects = append(src.objects, object)
+
+		switch v := object.(type) {
+		case *model.Branch:
+			src.branches = append(src.branches, v)
+		case *model.Config:
+			config := &model.ConfigWithRows{Config: v}
+			configs[v.ConfigKey] = config
+			src.configs[v.BranchKey()] = append(src.configs[v.BranchKey()], config)
+		case *model.ConfigRow:
+			if config, found := configs[v.ConfigKey()]; found {
+				config.Rows = append(config.Rows, v)
+			}
+		}
+	}
+
+	return src, nil
+}
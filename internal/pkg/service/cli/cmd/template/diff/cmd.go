@@ -0,0 +1,76 @@
+package diff
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/dependencies"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/helpmsg"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/configmap"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+	diffOp "github.com/keboola/keboola-as-code/pkg/lib/operation/template/local/diff"
+)
+
+type Flags struct {
+	StorageAPIHost configmap.Value[string] `configKey:"storage-api-host" configShorthand:"H" configUsage:"storage API host, eg. \"connection.keboola.com\""`
+	TestName       string                  `configKey:"test-name" configUsage:"name of the test with inputs used to render both versions"`
+	Details        bool                    `configKey:"details" configUsage:"print changed lines, not only changed fields"`
+	OutputJSON     string                  `configKey:"output-json" configUsage:"write the changelog to a JSON file"`
+}
+
+func DefaultFlags() Flags {
+	return Flags{}
+}
+
+func Command(p dependencies.Provider) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff <template> <old-version> <new-version>",
+		Short: helpmsg.Read(`template/diff/short`),
+		Long:  helpmsg.Read(`template/diff/long`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 3 {
+				return errors.New(`please enter arguments with the template ID and the old and the new version`)
+			}
+
+			f := Flags{}
+			if err := p.BaseScope().ConfigBinder().Bind(cmd.Context(), cmd.Flags(), args, &f); err != nil {
+				return err
+			}
+
+			// Get dependencies
+			d, err := p.LocalCommandScope(cmd.Context(), f.StorageAPIHost, dependencies.WithDefaultStorageAPIHost())
+			if err != nil {
+				return err
+			}
+
+			// Command must be used in template repository
+			repo, _, err := d.LocalTemplateRepository(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			// Load both versions
+			oldTmpl, err := d.Template(cmd.Context(), model.NewTemplateRef(repo.Definition(), args[0], args[1]))
+			if err != nil {
+				return err
+			}
+			newTmpl, err := d.Template(cmd.Context(), model.NewTemplateRef(repo.Definition(), args[0], args[2]))
+			if err != nil {
+				return err
+			}
+
+			// Diff
+			options := diffOp.Options{
+				TestName:     f.TestName,
+				PrintDetails: f.Details,
+				OutputJSON:   f.OutputJSON,
+			}
+			_, err = diffOp.Run(cmd.Context(), oldTmpl, newTmpl, options, d)
+			return err
+		},
+	}
+
+	configmap.MustGenerateFlags(cmd.Flags(), DefaultFlags())
+
+	return cmd
+}
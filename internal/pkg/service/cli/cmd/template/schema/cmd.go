@@ -0,0 +1,70 @@
+package schema
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/dependencies"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/helpmsg"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/configmap"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+	schemaOp "github.com/keboola/keboola-as-code/pkg/lib/operation/template/local/repository/schema"
+)
+
+type Flags struct {
+	StorageAPIHost configmap.Value[string] `configKey:"storage-api-host" configShorthand:"H" configUsage:"storage API host, eg. \"connection.keboola.com\""`
+	OutputFile     string                  `configKey:"output-file" configShorthand:"o" configUsage:"write the JSON Schema to a file instead of stdout"`
+}
+
+func DefaultFlags() Flags {
+	return Flags{}
+}
+
+func Command(p dependencies.Provider) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schema <template> [version]",
+		Short: helpmsg.Read(`template/schema/short`),
+		Long:  helpmsg.Read(`template/schema/long`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New(`please enter argument with the template ID and optionally its version`)
+			}
+
+			f := Flags{}
+			if err := p.BaseScope().ConfigBinder().Bind(cmd.Context(), cmd.Flags(), args, &f); err != nil {
+				return err
+			}
+
+			// Get dependencies
+			d, err := p.LocalCommandScope(cmd.Context(), f.StorageAPIHost, dependencies.WithDefaultStorageAPIHost())
+			if err != nil {
+				return err
+			}
+
+			// Command must be used in template repository
+			repo, _, err := d.LocalTemplateRepository(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			// Optional version argument
+			var versionArg string
+			if len(args) > 1 {
+				versionArg = args[1]
+			}
+
+			// Load template
+			template, err := d.Template(cmd.Context(), model.NewTemplateRef(repo.Definition(), args[0], versionArg))
+			if err != nil {
+				return err
+			}
+
+			// Export schema
+			return schemaOp.Run(cmd.Context(), template, schemaOp.Options{OutputFile: f.OutputFile}, d)
+		},
+	}
+
+	configmap.MustGenerateFlags(cmd.Flags(), DefaultFlags())
+
+	return cmd
+}
@@ -39,7 +39,7 @@ func (d *createTmplTestDialog) ask(ctx context.Context) (createOp.Options, []str
 	}
 
 	// User inputs
-	v, warnings, err := d.Dialogs.AskUseTemplateInputs(ctx, d.template.Inputs().ToExtended(), true, d.InputsFile)
+	v, warnings, err := d.Dialogs.AskUseTemplateInputs(ctx, d.template.Inputs().ToExtended(), true, d.InputsFile, nil)
 	if err != nil {
 		return d.out, nil, err
 	} else {
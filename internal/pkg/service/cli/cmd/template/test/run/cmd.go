@@ -17,6 +17,7 @@ type Flags struct {
 	TestName         string                  `configKey:"test-name" configUsage:"name of a single test to be run"`
 	LocalOnly        bool                    `configKey:"local-only" configUsage:"run a local test only"`
 	RemoteOnly       bool                    `configKey:"remote-only" configUsage:"run a remote test only"`
+	Offline          bool                    `configKey:"offline" configUsage:"use a fake Storage API instead of a test project, jobs are not run, requires the Storage API index cached by a previous online run"`
 	Verbose          bool                    `configKey:"verbose" configUsage:"show details about running tests"`
 	TestProjectsFile configmap.Value[string] `configKey:"test-projects-file" configUsage:"file containing projects that could be used for templates"`
 }
@@ -40,12 +41,17 @@ func Command(p dependencies.Provider) *cobra.Command {
 			options := testOp.Options{
 				LocalOnly:  f.LocalOnly,
 				RemoteOnly: f.RemoteOnly,
+				Offline:    f.Offline,
 				TestName:   f.TestName,
 				Verbose:    f.Verbose,
 			}
 
-			// Get dependencies
-			d, err := p.LocalCommandScope(cmd.Context(), f.StorageAPIHost, dependencies.WithDefaultStorageAPIHost())
+			// Get dependencies, in the offline mode, components are loaded from the cached Storage API index
+			opts := []dependencies.Option{dependencies.WithDefaultStorageAPIHost(), dependencies.WithIndexCache()}
+			if f.Offline {
+				opts = append(opts, dependencies.WithOfflineIndex())
+			}
+			d, err := p.LocalCommandScope(cmd.Context(), f.StorageAPIHost, opts...)
 			if err != nil {
 				return err
 			}
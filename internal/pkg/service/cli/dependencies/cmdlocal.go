@@ -74,10 +74,11 @@ func newLocalCommandScope(ctx context.Context, baseScp BaseScope, hostByFlag con
 	}
 
 	// Create common local dependencies
-	pubScp, err := dependencies.NewPublicScope(
-		ctx, baseScp, host,
-		dependencies.WithPreloadComponents(true),
-	)
+	pubScpOpts := []dependencies.PublicScopeOption{dependencies.WithPreloadComponents(true)}
+	if cfg.indexCache {
+		pubScpOpts = append(pubScpOpts, dependencies.WithIndexCache(newIndexCache(baseScp)), dependencies.WithOfflineIndex(cfg.offlineIndex))
+	}
+	pubScp, err := dependencies.NewPublicScope(ctx, baseScp, host, pubScpOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -164,6 +165,10 @@ func (v *localCommandScope) LocalTemplateRepository(ctx context.Context) (*repos
 	return value.value, value.found, err
 }
 
+func (v *localCommandScope) TemplateRepository(ctx context.Context, reference model.TemplateRepository) (*repository.Repository, error) {
+	return v.templateRepository(ctx, reference)
+}
+
 func (v *localCommandScope) LocalTemplate(ctx context.Context) (*template.Template, bool, error) {
 	value, err := v.localTemplate.InitAndGet(func() (localTemplateValue, error) {
 		// Get template path from current working dir
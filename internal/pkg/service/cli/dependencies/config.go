@@ -3,6 +3,8 @@ package dependencies
 type config struct {
 	defaultStorageAPIHost string
 	withoutMasterToken    bool
+	indexCache            bool
+	offlineIndex          bool
 }
 
 type Option func(*config)
@@ -30,3 +32,20 @@ func WithoutMasterToken() Option {
 		c.withoutMasterToken = true
 	}
 }
+
+// WithIndexCache stores the Storage API index with components to the CLI cache directory.
+// The cached index is used if the Storage API is not reachable.
+func WithIndexCache() Option {
+	return func(c *config) {
+		c.indexCache = true
+	}
+}
+
+// WithOfflineIndex loads the Storage API index with components only from the cache, no request is sent.
+// This is useful for commands that can work without network access, it implies WithIndexCache.
+func WithOfflineIndex() Option {
+	return func(c *config) {
+		c.indexCache = true
+		c.offlineIndex = true
+	}
+}
@@ -68,6 +68,7 @@ type LocalCommandScope interface {
 	LocalProject(ctx context.Context, ignoreErrors bool) (*projectPkg.Project, bool, error)
 	LocalTemplate(ctx context.Context) (*template.Template, bool, error)
 	LocalTemplateRepository(ctx context.Context) (*repository.Repository, bool, error)
+	TemplateRepository(ctx context.Context, reference model.TemplateRepository) (*repository.Repository, error)
 }
 
 // RemoteCommandScope interface provides dependencies for commands that modify remote project.
@@ -0,0 +1,90 @@
+package dependencies
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/keboola/go-client/pkg/keboola"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/encoding/json"
+	"github.com/keboola/keboola-as-code/internal/pkg/filesystem"
+	"github.com/keboola/keboola-as-code/internal/pkg/filesystem/aferofs"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/dependencies"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+const (
+	// CacheDirEnv overrides the CLI cache directory, by default it is "keboola-cli" in the user cache directory.
+	CacheDirEnv  = "KBC_CACHE_DIR"
+	cacheDirName = "keboola-cli"
+)
+
+var indexCacheFileNameRegexp = regexp.MustCompile(`[^a-zA-Z0-9.\-]+`)
+
+// indexCache stores the Storage API index with components to the CLI cache directory, see dependencies.IndexCache.
+// It allows local commands to work without network access.
+type indexCache struct {
+	baseScp BaseScope
+}
+
+func newIndexCache(baseScp BaseScope) dependencies.IndexCache {
+	return &indexCache{baseScp: baseScp}
+}
+
+func (c *indexCache) LoadIndex(ctx context.Context, storageAPIHost string) (*keboola.IndexComponents, error) {
+	fs, err := c.fs()
+	if err != nil {
+		return nil, err
+	}
+
+	path := indexCacheFileName(storageAPIHost)
+	if !fs.IsFile(ctx, path) {
+		return nil, nil
+	}
+
+	index := &keboola.IndexComponents{}
+	if _, err := fs.FileLoader().ReadJSONFileTo(ctx, filesystem.NewFileDef(path).SetDescription("cached Storage API index"), index); err != nil {
+		return nil, err
+	}
+	return index, nil
+}
+
+func (c *indexCache) StoreIndex(ctx context.Context, storageAPIHost string, index *keboola.IndexComponents) error {
+	fs, err := c.fs()
+	if err != nil {
+		return err
+	}
+
+	content, err := json.EncodeString(index, false)
+	if err != nil {
+		return errors.PrefixError(err, "cannot encode Storage API index")
+	}
+
+	return fs.WriteFile(ctx, filesystem.NewRawFile(indexCacheFileName(storageAPIHost), content))
+}
+
+func (c *indexCache) fs() (filesystem.Fs, error) {
+	dir := c.baseScp.Environment().Get(CacheDirEnv)
+	if dir == "" {
+		userDir, err := os.UserCacheDir()
+		if err != nil {
+			return nil, err
+		}
+		dir = filepath.Join(userDir, cacheDirName) // nolint: forbidigo
+	}
+
+	dir, err := filepath.Abs(dir) // nolint: forbidigo
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil { // nolint: forbidigo
+		return nil, err
+	}
+	return aferofs.NewLocalFs(dir)
+}
+
+func indexCacheFileName(storageAPIHost string) string {
+	return "index-" + indexCacheFileNameRegexp.ReplaceAllString(storageAPIHost, "_") + ".json"
+}
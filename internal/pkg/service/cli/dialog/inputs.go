@@ -53,6 +53,51 @@ func (p *Dialogs) AskNewTemplateInputs(ctx context.Context, deps inputsDialogDep
 	return objectInputs, stepsGroups.ToValue(), nil
 }
 
+// InputRule selects config/row fields, which will be replaced by user input, without the dialog.
+// Used in the non-interactive template creation, see "kbc template create --rules-file".
+type InputRule struct {
+	Path        string `json:"path"`
+	ComponentID string `json:"componentId,omitempty"`
+	InputID     string `json:"inputId,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// NewTemplateInputsFromRules - defines user inputs for a new template without prompts.
+// Fields are selected by the rules, steps and details of the inputs have default values of the dialogs.
+func (p *Dialogs) NewTemplateInputsFromRules(ctx context.Context, deps inputsDialogDeps, branch *model.Branch, configs []*model.ConfigWithRows, rules []InputRule) (objectInputsMap, template.StepsGroups, error) {
+	// Create empty inputs map
+	inputs := input.NewInputsMap()
+
+	// Select config/row fields by the rules
+	selectDialog, err := newInputsSelectDialog(p.Prompt, false, deps.Components(), branch, configs, inputs)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := selectDialog.applyRules(rules); err != nil {
+		return nil, nil, err
+	}
+	if err := selectDialog.parse(selectDialog.defaultValue()); err != nil {
+		return nil, nil, err
+	}
+
+	// Use the default step and steps group
+	stepsDialog := newStepsDialog(p.Prompt)
+	stepsGroups, err := stepsDialog.parse(ctx, stepsDialog.defaultValue())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Add inputs to the default step
+	detailsDialog := newInputsDetailsDialog(p.Prompt, inputs, stepsGroups)
+	stepsGroups, err = detailsDialog.parse(ctx, detailsDialog.defaultValue())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return selectDialog.objectInputs, stepsGroups.ToValue(), nil
+}
+
 type inputFields map[string]input.ObjectField
 
 func (f inputFields) Write(out *strings.Builder) {
@@ -222,6 +222,49 @@ Allowed characters: a-z, A-Z, 0-9, "-".
 	return lines.String()
 }
 
+// applyRules selects fields matching the rules, a rule can modify ID, name and description of the input.
+func (d *inputsSelectDialog) applyRules(rules []InputRule) error {
+	errs := errors.NewMultiError()
+	for _, rule := range rules {
+		matched := false
+		for objectKey, fields := range d.objectFields {
+			field, found := fields[rule.Path]
+			if !found || (rule.ComponentID != "" && rule.ComponentID != objectComponentID(objectKey).String()) {
+				continue
+			}
+
+			matched = true
+			field.Selected = true
+			if rule.InputID != "" {
+				field.Input.ID = rule.InputID
+			}
+			if rule.Name != "" {
+				field.Input.Name = rule.Name
+			}
+			if rule.Description != "" {
+				field.Input.Description = rule.Description
+			}
+			fields[rule.Path] = field
+		}
+
+		if !matched {
+			errs.Append(errors.Errorf(`input rule "%s" does not match any field in the selected configurations`, rule.Path))
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+func objectComponentID(key model.Key) keboola.ComponentID {
+	switch v := key.(type) {
+	case model.ConfigKey:
+		return v.ComponentID
+	case model.ConfigRowKey:
+		return v.ComponentID
+	default:
+		return ""
+	}
+}
+
 // detectInputs - detects potential inputs in each config and config row.
 func (d *inputsSelectDialog) detectInputs() error {
 	d.objectFields = make(map[model.Key]inputFields)
@@ -26,17 +26,20 @@ type useTmplInputsDialog struct {
 	inputs        map[string]*input.Input
 	inputsFile    map[string]any // inputs values loaded from a file specified by inputsFileFlag
 	useInputsFile bool
+	storage       *input.StorageObjects // existing objects for storage inputs, it may be nil
 	out           template.InputsValues
 	context       context.Context // for input.ValidateUserInput
 	inputsValues  map[string]any  // for input.Available
 }
 
 // AskUseTemplateInputs - dialog to enter template inputs.
-func (p *Dialogs) AskUseTemplateInputs(ctx context.Context, groups input.StepsGroupsExt, isForTest bool, inputsFileFlag configmap.Value[string]) (template.InputsValues, []string, error) {
+// The storage objects are optional, if they are not set, the storage inputs are entered as a text.
+func (p *Dialogs) AskUseTemplateInputs(ctx context.Context, groups input.StepsGroupsExt, isForTest bool, inputsFileFlag configmap.Value[string], storage *input.StorageObjects) (template.InputsValues, []string, error) {
 	dialog := &useTmplInputsDialog{
 		Dialogs:      p,
 		groups:       groups,
 		inputs:       groups.InputsMap(),
+		storage:      storage,
 		context:      context.Background(),
 		inputsValues: make(map[string]any),
 	}
@@ -343,6 +346,8 @@ func (d *useTmplInputsDialog) askInput(ctx context.Context, inputDef *input.Inpu
 		}
 		// Save value
 		return "", d.addInputValue(ctx, selectedValues, inputDef, true)
+	case input.KindTable, input.KindBucket, input.KindConnection:
+		return "", d.askStorageInput(ctx, inputDef)
 	case input.KindOAuth:
 		// OAuth is not supported in CLI dialog.
 		return "", d.addInputValue(ctx, d.defaultOrEmptyValueFor(inputDef), inputDef, true)
@@ -354,6 +359,42 @@ func (d *useTmplInputsDialog) askInput(ctx context.Context, inputDef *input.Inpu
 	return "", nil
 }
 
+// askStorageInput - select an existing table, bucket or workspace from the project.
+func (d *useTmplInputsDialog) askStorageInput(ctx context.Context, inputDef *input.Input) error {
+	var options input.Options
+	if d.storage != nil {
+		options = d.storage.Options(inputDef.Kind)
+	}
+
+	// Objects are not loaded, or there is none, the value is validated when the template is used
+	if len(options) == 0 {
+		value, _ := d.Ask(&prompt.Question{
+			Label:       inputDef.Name,
+			Description: inputDef.Description,
+			Validator: func(raw any) error {
+				return inputDef.ValidateUserInput(ctx, raw)
+			},
+			Default: cast.ToString(inputDef.Default),
+		})
+		return d.addInputValue(ctx, value, inputDef, true)
+	}
+
+	selectPrompt := &prompt.SelectIndex{
+		Label:       inputDef.Name,
+		Description: inputDef.Description,
+		Options:     options.Names(),
+		UseDefault:  true,
+		Validator: func(answerRaw any) error {
+			return inputDef.ValidateUserInput(ctx, options[answerRaw.(survey.OptionAnswer).Index].Value)
+		},
+	}
+	if _, index, found := options.GetByID(cast.ToString(inputDef.Default)); found {
+		selectPrompt.Default = index
+	}
+	selectedIndex, _ := d.SelectIndex(selectPrompt)
+	return d.addInputValue(ctx, options[selectedIndex].Value, inputDef, true)
+}
+
 // addInputValue from CLI dialog or inputs file.
 func (d *useTmplInputsDialog) addInputValue(ctx context.Context, value any, inputDef *input.Input, isFilled bool) error {
 	inputValue, err := template.ParseInputValue(ctx, value, inputDef, isFilled)
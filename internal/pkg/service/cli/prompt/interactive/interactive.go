@@ -44,6 +44,10 @@ func (p *Prompt) IsInteractive() bool {
 	return true
 }
 
+func (p *Prompt) Terminal() (stdin terminal.FileReader, stdout terminal.FileWriter, ok bool) {
+	return p.stdin, p.stdout, true
+}
+
 func (p *Prompt) Printf(format string, a ...any) {
 	// The error can occur mainly in tests, if stdout of the virtual terminal is closed on test failure.
 	_, _ = fmt.Fprintf(p.stdout, format, a...)
@@ -1,6 +1,8 @@
 package nop
 
 import (
+	"github.com/AlecAivazis/survey/v2/terminal"
+
 	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/prompt"
 )
 
@@ -14,6 +16,10 @@ func (p *Prompt) IsInteractive() bool {
 	return false
 }
 
+func (p *Prompt) Terminal() (stdin terminal.FileReader, stdout terminal.FileWriter, ok bool) {
+	return nil, nil, false
+}
+
 func (p *Prompt) Printf(_ string, _ ...any) {
 	// nop
 }
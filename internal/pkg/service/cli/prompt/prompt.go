@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/AlecAivazis/survey/v2/core"
+	"github.com/AlecAivazis/survey/v2/terminal"
 
 	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
 )
@@ -75,6 +76,8 @@ type Prompt interface {
 	// Editor allows you to edit text using the editor specified via env EDITOR.
 	// fileExt is the extension of the temporary file, for syntax highlighting.
 	Editor(fileExt string, q *Question) (result string, ok bool)
+	// Terminal returns streams of the interactive terminal, for full-screen UIs.
+	Terminal() (stdin terminal.FileReader, stdout terminal.FileWriter, ok bool)
 }
 
 func ValueRequired(val any) error {
@@ -0,0 +1,118 @@
+// Package audit provides a durable log of mutating API calls.
+//
+// Each mutating API call is recorded by the middleware.Audit Goa middleware:
+//   - The middleware creates a Record and stores it to the context.
+//   - The service fills in the identity, the modified object and the before/after summaries, see FromContext.
+//   - After the endpoint returns, the Entry with the result is written to the Backend.
+//
+// Entries are stored per project, they can be listed by the Log.List method.
+// Two backends are available: an etcd prefix with the TTL and a JSON lines file.
+// The file backend is intended only for a single node deployment, each node lists only its local file.
+package audit
+
+import (
+	"context"
+
+	"github.com/benbjohnson/clock"
+	"github.com/keboola/go-client/pkg/keboola"
+	etcd "go.etcd.io/etcd/client/v3"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/idgenerator"
+	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	svcErrors "github.com/keboola/keboola-as-code/internal/pkg/service/common/errors"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop/serde"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/utctime"
+	"github.com/keboola/keboola-as-code/internal/pkg/telemetry"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+// Backend persists audit log entries.
+type Backend interface {
+	Write(ctx context.Context, entry Entry) error
+	// List returns entries of the project, from the newest to the oldest.
+	List(ctx context.Context, projectID keboola.ProjectID, limit int) ([]Entry, error)
+}
+
+type Log struct {
+	clock   clock.Clock
+	logger  log.Logger
+	tracer  telemetry.Tracer
+	backend Backend
+}
+
+type dependencies interface {
+	Clock() clock.Clock
+	Logger() log.Logger
+	Telemetry() telemetry.Telemetry
+	EtcdClient() *etcd.Client
+	EtcdSerde() *serde.Serde
+}
+
+func New(cfg Config, d dependencies) (*Log, error) {
+	var backend Backend
+	if cfg.Enabled {
+		switch cfg.Backend {
+		case BackendEtcd:
+			backend = NewEtcdBackend(d.EtcdClient(), d.EtcdSerde(), cfg.TTL)
+		case BackendFile:
+			backend = NewFileBackend(cfg.FilePath)
+		default:
+			return nil, errors.Errorf(`unexpected audit log backend "%s"`, cfg.Backend)
+		}
+	}
+	return NewWithBackend(d, backend), nil
+}
+
+// NewWithBackend creates the audit log with a custom backend, the log is disabled if the backend is nil.
+func NewWithBackend(d dependencies, backend Backend) *Log {
+	return &Log{
+		clock:   d.Clock(),
+		logger:  d.Logger().WithComponent("audit"),
+		tracer:  d.Telemetry().Tracer(),
+		backend: backend,
+	}
+}
+
+func (l *Log) Enabled() bool {
+	return l != nil && l.backend != nil
+}
+
+// Write stores the entry, the ID and the Created fields are set, if they are empty.
+// An error is only logged, the audit log must not break the API call, which has already been processed.
+func (l *Log) Write(ctx context.Context, entry Entry) {
+	if !l.Enabled() {
+		return
+	}
+
+	var err error
+	ctx, span := l.tracer.Start(ctx, "keboola.go.common.audit.Write")
+	defer span.End(&err)
+
+	if entry.Created.IsZero() {
+		entry.Created = utctime.From(l.clock.Now())
+	}
+	if entry.ID == "" {
+		// ID is sortable by the time, the request ID ensures uniqueness
+		suffix := entry.RequestID
+		if suffix == "" {
+			suffix = idgenerator.RequestID()
+		}
+		entry.ID = entry.Created.String() + "_" + suffix
+	}
+
+	if err = l.backend.Write(ctx, entry); err != nil {
+		l.logger.Warnf(ctx, `cannot write audit log entry "%s" of the endpoint "%s": %s`, entry.ID, entry.Endpoint, err)
+	}
+}
+
+// List returns entries of the project, from the newest to the oldest.
+func (l *Log) List(ctx context.Context, projectID keboola.ProjectID, limit int) (out []Entry, err error) {
+	if !l.Enabled() {
+		return nil, svcErrors.NewBadRequestError(errors.New("audit log is disabled"))
+	}
+
+	ctx, span := l.tracer.Start(ctx, "keboola.go.common.audit.List")
+	defer span.End(&err)
+
+	return l.backend.List(ctx, projectID, limit)
+}
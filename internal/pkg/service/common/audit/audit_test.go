@@ -0,0 +1,146 @@
+package audit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	etcd "go.etcd.io/etcd/client/v3"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/filesystem"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/audit"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/dependencies"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/utctime"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/etcdhelper"
+)
+
+func TestLog_Disabled(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	d := dependencies.NewMocked(t, ctx)
+
+	auditLog, err := audit.New(audit.NewConfig(), d)
+	require.NoError(t, err)
+	assert.False(t, auditLog.Enabled())
+
+	// Write is no-op
+	auditLog.Write(ctx, audit.Entry{ProjectID: 123, Endpoint: "CreateSource"})
+
+	// List returns an error
+	_, err = auditLog.List(ctx, 123, 10)
+	if assert.Error(t, err) {
+		assert.Equal(t, "audit log is disabled", err.Error())
+	}
+}
+
+func TestLog_FileBackend(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	cfg := audit.NewConfig()
+	cfg.Enabled = true
+	cfg.Backend = audit.BackendFile
+	cfg.FilePath = filesystem.Join(t.TempDir(), "audit.jsonl")
+
+	testLog(t, ctx, cfg)
+}
+
+func TestLog_EtcdBackend(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	cfg := audit.NewConfig()
+	cfg.Enabled = true
+	cfg.Backend = audit.BackendEtcd
+	cfg.TTL = time.Hour
+
+	d := testLog(t, ctx, cfg, dependencies.WithEtcdConfig(etcdhelper.TmpNamespace(t)))
+
+	// All keys are created in the same hour, so they share one lease with the TTL plus one hour
+	resp, err := d.EtcdClient().Get(ctx, audit.EtcdPrefix+"/", etcd.WithPrefix())
+	require.NoError(t, err)
+	require.Len(t, resp.Kvs, 4)
+	lease := resp.Kvs[0].Lease
+	assert.NotZero(t, lease)
+	for _, kv := range resp.Kvs {
+		assert.Equal(t, lease, kv.Lease)
+	}
+	ttl, err := d.EtcdClient().TimeToLive(ctx, etcd.LeaseID(lease))
+	require.NoError(t, err)
+	assert.Equal(t, int64((2 * time.Hour).Seconds()), ttl.GrantedTTL)
+}
+
+func TestEtcdBackend_LeasePerHour(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	d := dependencies.NewMocked(t, ctx, dependencies.WithEtcdConfig(etcdhelper.TmpNamespace(t)))
+	backend := audit.NewEtcdBackend(d.EtcdClient(), d.EtcdSerde(), time.Hour)
+
+	// Write entries to two hour buckets
+	start := time.Date(2000, 1, 1, 1, 0, 0, 0, time.UTC)
+	for _, created := range []time.Time{start, start.Add(59 * time.Minute), start.Add(time.Hour)} {
+		entry := audit.Entry{ProjectID: 123, ID: utctime.From(created).String(), Created: utctime.From(created), Endpoint: "CreateSource"}
+		require.NoError(t, backend.Write(ctx, entry))
+	}
+
+	// Entries of the same hour share the lease, the next hour has a new lease
+	resp, err := d.EtcdClient().Get(ctx, audit.EtcdPrefix+"/", etcd.WithPrefix(), etcd.WithSort(etcd.SortByKey, etcd.SortAscend))
+	require.NoError(t, err)
+	require.Len(t, resp.Kvs, 3)
+	assert.NotZero(t, resp.Kvs[0].Lease)
+	assert.Equal(t, resp.Kvs[0].Lease, resp.Kvs[1].Lease)
+	assert.NotEqual(t, resp.Kvs[1].Lease, resp.Kvs[2].Lease)
+}
+
+func testLog(t *testing.T, ctx context.Context, cfg audit.Config, opts ...dependencies.MockedOption) dependencies.Mocked {
+	t.Helper()
+
+	clk := clock.NewMock()
+	clk.Set(time.Date(2000, 1, 1, 1, 0, 0, 0, time.UTC))
+	d := dependencies.NewMocked(t, ctx, append(opts, dependencies.WithClock(clk))...)
+
+	auditLog, err := audit.New(cfg, d)
+	require.NoError(t, err)
+	require.True(t, auditLog.Enabled())
+
+	// Write entries of two projects
+	by := audit.By{Type: audit.ByUser, TokenID: "111", UserName: "John"}
+	for _, entry := range []audit.Entry{
+		{ProjectID: 123, RequestID: "req1", Endpoint: "CreateSource", By: by, ObjectKey: "123/456/my-source", After: audit.Summary{"name": "My Source"}},
+		{ProjectID: 456, RequestID: "req2", Endpoint: "CreateSource", By: by},
+		{ProjectID: 123, RequestID: "req3", Endpoint: "UpdateSource", By: by, Before: audit.Summary{"name": "My Source"}, After: audit.Summary{"name": "New Name"}},
+		{ProjectID: 123, RequestID: "req4", Endpoint: "DeleteSource", By: by, Result: audit.Result{StatusCode: 404, ErrorName: "stream.sourceNotFound", Error: "source not found"}},
+	} {
+		clk.Add(time.Second)
+		auditLog.Write(ctx, entry)
+	}
+
+	// List entries of the project, from the newest
+	entries, err := auditLog.List(ctx, 123, 10)
+	require.NoError(t, err)
+	if assert.Len(t, entries, 3) {
+		assert.Equal(t, "2000-01-01T01:00:04.000Z_req4", entries[0].ID)
+		assert.Equal(t, "DeleteSource", entries[0].Endpoint)
+		assert.Equal(t, audit.Result{StatusCode: 404, ErrorName: "stream.sourceNotFound", Error: "source not found"}, entries[0].Result)
+		assert.Equal(t, "2000-01-01T01:00:03.000Z_req3", entries[1].ID)
+		assert.Equal(t, audit.Summary{"name": "My Source"}, entries[1].Before)
+		assert.Equal(t, audit.Summary{"name": "New Name"}, entries[1].After)
+		assert.Equal(t, "2000-01-01T01:00:01.000Z_req1", entries[2].ID)
+		assert.Equal(t, "123/456/my-source", entries[2].ObjectKey)
+		assert.Equal(t, by, entries[2].By)
+	}
+
+	// Limit
+	entries, err = auditLog.List(ctx, 123, 1)
+	require.NoError(t, err)
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, "DeleteSource", entries[0].Endpoint)
+	}
+
+	return d
+}
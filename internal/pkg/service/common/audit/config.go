@@ -0,0 +1,35 @@
+package audit
+
+import (
+	"time"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+const (
+	BackendEtcd = "etcd"
+	BackendFile = "file"
+)
+
+type Config struct {
+	Enabled  bool          `configKey:"enabled" configUsage:"Enable audit log of mutating API calls."`
+	Backend  string        `configKey:"backend" configUsage:"Audit log backend: etcd or file. The file backend is for a single node deployment only, entries are listed only from the local file." validate:"required,oneof=etcd file"`
+	TTL      time.Duration `configKey:"ttl" configUsage:"Expiration of audit log entries in the etcd backend." validate:"required,minDuration=1m"`
+	FilePath string        `configKey:"filePath" configUsage:"Path to the JSON lines file of the file backend."`
+}
+
+func NewConfig() Config {
+	return Config{
+		Enabled:  false,
+		Backend:  BackendEtcd,
+		TTL:      30 * 24 * time.Hour,
+		FilePath: "",
+	}
+}
+
+func (c Config) Validate() error {
+	if c.Enabled && c.Backend == BackendFile && c.FilePath == "" {
+		return errors.New(`file path must be set for the "file" audit log backend`)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package audit
+
+import (
+	"strconv"
+
+	"github.com/keboola/go-client/pkg/keboola"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/utctime"
+)
+
+const (
+	ByUser = "user"
+)
+
+// Entry is one mutating API call in the audit log.
+type Entry struct {
+	ID         string            `json:"id" validate:"required"`
+	ProjectID  keboola.ProjectID `json:"projectId" validate:"required"`
+	Created    utctime.UTCTime   `json:"created" validate:"required"`
+	RequestID  string            `json:"requestId,omitempty"`
+	Service    string            `json:"service"`
+	Endpoint   string            `json:"endpoint" validate:"required"`
+	HTTPMethod string            `json:"httpMethod"`
+	HTTPPath   string            `json:"httpPath"`
+	By         By                `json:"by"`
+	// ObjectKey identifies the modified object, if the endpoint modifies a single object.
+	ObjectKey string `json:"objectKey,omitempty"`
+	// TaskID is set if the endpoint started an asynchronous task, see the "task" package.
+	TaskID string  `json:"taskId,omitempty"`
+	Before Summary `json:"before,omitempty"`
+	After  Summary `json:"after,omitempty"`
+	Result Result  `json:"result"`
+}
+
+// By describes the actor of the API call.
+// The structure matches definition.By of the Stream service, so values are comparable across services.
+type By struct {
+	Type      string `json:"type"`
+	TokenID   string `json:"tokenId,omitempty"`
+	TokenDesc string `json:"tokenDesc,omitempty"`
+	UserID    string `json:"userId,omitempty"`
+	UserName  string `json:"userName,omitempty"`
+}
+
+// Summary is a short description of the object state, only a few important fields are stored, not the whole object.
+type Summary map[string]any
+
+// Result of the API call.
+// Asynchronous operations are recorded when the task is created, the task result is available by the TaskID.
+type Result struct {
+	Success    bool   `json:"success"`
+	StatusCode int    `json:"statusCode,omitempty"`
+	ErrorName  string `json:"errorName,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+func ByFromToken(token keboola.Token) By {
+	v := By{
+		Type:      ByUser,
+		TokenID:   token.ID,
+		TokenDesc: token.Description,
+	}
+
+	if token.Admin != nil {
+		v.UserName = token.Admin.Name
+		v.UserID = strconv.Itoa(token.Admin.ID)
+	}
+
+	return v
+}
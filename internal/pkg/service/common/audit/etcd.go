@@ -0,0 +1,93 @@
+package audit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/keboola/go-client/pkg/keboola"
+	etcd "go.etcd.io/etcd/client/v3"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop/iterator"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop/serde"
+)
+
+const (
+	EtcdPrefix = "audit"
+	// leaseBucket is the time window of entries sharing one lease, see etcdBackend.
+	leaseBucket = time.Hour
+)
+
+// etcdBackend stores entries to the "audit/{projectID}/{entryID}" keys.
+// Entries created in the same hour are attached to one shared lease with the TTL plus one hour,
+// so the number of leases doesn't grow with the number of entries, and each entry is kept at least for the TTL.
+type etcdBackend struct {
+	client *etcd.Client
+	prefix etcdop.PrefixT[Entry]
+	ttl    time.Duration
+
+	lock        sync.Mutex
+	leaseBucket time.Time
+	leaseID     etcd.LeaseID
+}
+
+func NewEtcdBackend(client *etcd.Client, s *serde.Serde, ttl time.Duration) Backend {
+	return &etcdBackend{
+		client: client,
+		prefix: etcdop.NewTypedPrefix[Entry](EtcdPrefix, s),
+		ttl:    ttl,
+	}
+}
+
+func (b *etcdBackend) Write(ctx context.Context, entry Entry) error {
+	bucket := entry.Created.Time().Truncate(leaseBucket)
+
+	leaseID, err := b.lease(ctx, bucket)
+	if err != nil {
+		return err
+	}
+
+	err = b.prefix.Key(entry.ProjectID.String()+"/"+entry.ID).Put(b.client, entry, etcd.WithLease(leaseID)).Do(ctx).Err()
+	if err != nil {
+		// The lease may have been revoked, a new lease is granted by the next write
+		b.forgetLease(bucket, leaseID)
+	}
+	return err
+}
+
+func (b *etcdBackend) List(ctx context.Context, projectID keboola.ProjectID, limit int) ([]Entry, error) {
+	return b.prefix.
+		Add(projectID.String()).
+		GetAll(b.client, iterator.WithSort(etcd.SortDescend), iterator.WithLimit(limit)).
+		Do(ctx).
+		All()
+}
+
+// lease returns the lease of the time bucket, the lease is granted by the first write to the bucket.
+func (b *etcdBackend) lease(ctx context.Context, bucket time.Time) (etcd.LeaseID, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.leaseID != etcd.NoLease && b.leaseBucket.Equal(bucket) {
+		return b.leaseID, nil
+	}
+
+	lease, err := b.client.Grant(ctx, int64((b.ttl + leaseBucket).Seconds()))
+	if err != nil {
+		return etcd.NoLease, err
+	}
+
+	b.leaseBucket = bucket
+	b.leaseID = lease.ID
+	return lease.ID, nil
+}
+
+func (b *etcdBackend) forgetLease(bucket time.Time, leaseID etcd.LeaseID) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.leaseID == leaseID && b.leaseBucket.Equal(bucket) {
+		b.leaseID = etcd.NoLease
+	}
+}
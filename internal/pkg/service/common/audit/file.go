@@ -0,0 +1,91 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"slices"
+	"sync"
+
+	"github.com/keboola/go-client/pkg/keboola"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/encoding/json"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+const (
+	filePerm        = 0o600
+	fileMaxLineSize = 1024 * 1024
+)
+
+// fileBackend appends entries to a JSON lines file.
+// The file is not rotated, it is expected to be processed by an external log collector.
+// The backend is intended only for a single node deployment, List reads only the file of the node serving the request,
+// so with multiple replicas the listed entries are incomplete, use the etcd backend instead.
+type fileBackend struct {
+	lock sync.Mutex
+	path string
+}
+
+func NewFileBackend(path string) Backend {
+	return &fileBackend{path: path}
+}
+
+func (b *fileBackend) Write(_ context.Context, entry Entry) error {
+	line, err := json.Encode(entry, false)
+	if err != nil {
+		return err
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	// nolint: forbidigo // the file can be outside the working directory
+	file, err := os.OpenFile(b.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, filePerm)
+	if err != nil {
+		return err
+	}
+
+	_, err = file.Write(append(line, '\n'))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+func (b *fileBackend) List(_ context.Context, projectID keboola.ProjectID, limit int) ([]Entry, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	// nolint: forbidigo // the file can be outside the working directory
+	file, err := os.Open(b.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	// Entries are appended, so the newest entries are at the end
+	var out []Entry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, fileMaxLineSize)
+	for scanner.Scan() {
+		var entry Entry
+		if err := json.Decode(scanner.Bytes(), &entry); err != nil {
+			return nil, errors.Errorf(`cannot decode audit log file "%s": %w`, b.path, err)
+		}
+		if entry.ProjectID == projectID {
+			out = append(out, entry)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	slices.Reverse(out)
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
@@ -0,0 +1,88 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/keboola/go-client/pkg/keboola"
+)
+
+const recordCtxKey = ctxKey("audit-record")
+
+type ctxKey string
+
+// Record collects information about an API call during the request processing.
+// The record is created by the audit middleware, the service fills in the identity and the modified object.
+// All methods are no-op on a nil Record, so the service code doesn't have to check if the audit log is enabled.
+type Record struct {
+	projectID keboola.ProjectID
+	by        By
+	objectKey string
+	taskID    string
+	before    Summary
+	after     Summary
+}
+
+func NewRecord() *Record {
+	return &Record{}
+}
+
+func ContextWith(ctx context.Context, r *Record) context.Context {
+	return context.WithValue(ctx, recordCtxKey, r)
+}
+
+// FromContext returns the record of the API call, or nil, if the call is not audited.
+func FromContext(ctx context.Context) *Record {
+	r, _ := ctx.Value(recordCtxKey).(*Record)
+	return r
+}
+
+// SetIdentity sets the authenticated project and the actor, it should be called by the authentication handler.
+func (r *Record) SetIdentity(projectID keboola.ProjectID, by By) {
+	if r != nil {
+		r.projectID = projectID
+		r.by = by
+	}
+}
+
+func (r *Record) SetObjectKey(v string) {
+	if r != nil {
+		r.objectKey = v
+	}
+}
+
+func (r *Record) SetTaskID(v string) {
+	if r != nil {
+		r.taskID = v
+	}
+}
+
+// SetBefore sets summary of the object state before the call, it is nil for a new object.
+func (r *Record) SetBefore(v Summary) {
+	if r != nil {
+		r.before = v
+	}
+}
+
+// SetAfter sets summary of the expected object state after the call, it is nil for a deleted object.
+func (r *Record) SetAfter(v Summary) {
+	if r != nil {
+		r.after = v
+	}
+}
+
+// Authenticated returns true, if the identity has been set.
+// Calls without the identity, for example with an invalid token, are not audited.
+func (r *Record) Authenticated() bool {
+	return r != nil && r.projectID != 0
+}
+
+// ToEntry fills the entry with the collected values.
+func (r *Record) ToEntry(entry Entry) Entry {
+	entry.ProjectID = r.projectID
+	entry.By = r.by
+	entry.ObjectKey = r.objectKey
+	entry.TaskID = r.taskID
+	entry.Before = r.before
+	entry.After = r.after
+	return entry
+}
@@ -47,6 +47,7 @@ type BindConfig struct {
 type GenerateAndBindConfig struct {
 	Args                   []string              // optional
 	PositionalArgsTarget   *[]string             // optional
+	ConfigFilesTarget      *[]string             // optional, paths of used configuration files are stored to the target
 	ConfigFiles            []string              // optional
 	EnvNaming              *env.NamingConvention // optional
 	Envs                   env.Provider          // optional
@@ -180,6 +181,11 @@ func GenerateAndBind(cfg GenerateAndBindConfig, targets ...any) error {
 		*cfg.PositionalArgsTarget = flags.Args()
 	}
 
+	// Save paths of used configuration files
+	if cfg.ConfigFilesTarget != nil {
+		*cfg.ConfigFilesTarget = configFiles
+	}
+
 	return errs.ErrorOrNil()
 }
 
@@ -10,6 +10,7 @@
 //   - Generic type Value[T] can be used to get value source, see Value.SetBy.
 //   - Dumping of the configuration in JSON or YAML format, see the [NewDumper] function.
 //   - Ability to mask sensitive values in dump with the sensitive tag.
+//   - Hot reload of fields marked with the configReload tag, see the [NewReloader] function.
 //
 // # Configuration Structure
 //
@@ -17,6 +18,7 @@
 //   - configKey - name of the field in the configuration in camelCase style
 //   - configUsage - flag usage message
 //   - sensitive - if "true", then the field value is masked in the dump output
+//   - configReload - if "true", then the field and all its children can be modified by a configuration reload
 //
 // Example configuration structure:
 //
@@ -156,12 +158,30 @@
 //	if config.Interactive.IsSet() {
 //	  ...
 //	}
+//
+// # Reload
+//
+// The [Reloader] holds the current configuration and loads a new one on the SIGHUP signal or on a config file change.
+// Only fields marked with the configReload tag can be modified by the reload,
+// a change of any other field is rejected with the [NotReloadableError] and the current configuration is kept.
+//
+//	type Config struct {
+//	  DebugLog bool   `configKey:"debugLog" configReload:"true"`
+//	  Listen   string `configKey:"listen"`
+//	}
+//
+// Subscribers get the new typed configuration, see the Reloader.OnReload method.
+//
+//	reloader.OnReload(func(ctx context.Context, cfg Config) {
+//	  logLevel.SetVerbose(cfg.DebugLog)
+//	})
 package configmap
 
 const (
 	configKeyTag       = "configKey"
 	configShorthandTag = "configShorthand"
 	configUsageTag     = "configUsage"
+	configReloadTag    = "configReload"
 	sensitiveTag       = "sensitive"
 	validateTag        = "validate"
 	sensitiveMask      = "*****"
@@ -0,0 +1,289 @@
+package configmap
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+// WatchDebounce is the delay between a change of a configuration file and the reload, see Reloader.Watch.
+// Further changes within the delay are merged into one reload.
+const WatchDebounce = 200 * time.Millisecond
+
+// LoadFn loads a new configuration, for example from the same flags, ENVs and configuration files as at startup.
+type LoadFn[C any] func() (C, error)
+
+// OnReloadFn is called with the new configuration, after a successful reload.
+type OnReloadFn[C any] func(ctx context.Context, cfg C)
+
+// Reloader holds the current configuration and reloads it on request.
+// Only fields marked with the configReload tag can be changed by a reload.
+type Reloader[C any] struct {
+	load        LoadFn[C]
+	configFiles []string
+	// reloadLock serializes reloads, so subscribers get configurations in order
+	reloadLock  *sync.Mutex
+	lock        *sync.RWMutex
+	current     C
+	subscribers []OnReloadFn[C]
+}
+
+// NotReloadableError is returned by the reload, if a field without the configReload tag has been changed.
+type NotReloadableError struct {
+	Fields []string
+}
+
+func (e NotReloadableError) Error() string {
+	return `configuration cannot be reloaded, the following fields cannot be changed without a restart: "` + strings.Join(e.Fields, `", "`) + `"`
+}
+
+// NewReloader creates the Reloader with the current configuration.
+// The load function may be nil, then only the Update method can be used.
+// The configuration files are watched by the Watch method.
+func NewReloader[C any](current C, load LoadFn[C], configFiles ...string) *Reloader[C] {
+	return &Reloader[C]{
+		load:        load,
+		configFiles: configFiles,
+		reloadLock:  &sync.Mutex{},
+		lock:        &sync.RWMutex{},
+		current:     current,
+	}
+}
+
+// Current returns the current configuration.
+func (r *Reloader[C]) Current() C {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.current
+}
+
+// OnReload registers a callback, which is called with the new configuration after each successful reload.
+func (r *Reloader[C]) OnReload(fn OnReloadFn[C]) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.subscribers = append(r.subscribers, fn)
+}
+
+// Reload loads a new configuration and applies it by the Update method.
+func (r *Reloader[C]) Reload(ctx context.Context) (changed []string, err error) {
+	if r.load == nil {
+		return nil, errors.New("configuration reload is not supported")
+	}
+
+	cfg, err := r.load()
+	if err != nil {
+		return nil, errors.PrefixError(err, "cannot load configuration")
+	}
+
+	return r.Update(ctx, cfg)
+}
+
+// Update replaces the current configuration with the new one, and notifies subscribers.
+// Paths of the changed fields are returned, subscribers are not notified, if nothing has changed.
+// If a field without the configReload tag has been changed, the NotReloadableError is returned and the current configuration is kept.
+func (r *Reloader[C]) Update(ctx context.Context, cfg C) (changed []string, err error) {
+	r.reloadLock.Lock()
+	defer r.reloadLock.Unlock()
+
+	changed, notReloadable := diffConfigs(r.Current(), cfg)
+	if len(notReloadable) > 0 {
+		return nil, NotReloadableError{Fields: notReloadable}
+	}
+	if len(changed) == 0 {
+		return nil, nil
+	}
+
+	r.lock.Lock()
+	r.current = cfg
+	subscribers := r.subscribers
+	r.lock.Unlock()
+
+	for _, fn := range subscribers {
+		fn(ctx, cfg)
+	}
+
+	return changed, nil
+}
+
+// Watch reloads the configuration on the SIGHUP signal or on a change of a configuration file, until the context is cancelled.
+// Only events of the configuration files are processed, including a Kubernetes ConfigMap update,
+// and changes within the WatchDebounce interval are merged into one reload.
+func (r *Reloader[C]) Watch(ctx context.Context, wg *sync.WaitGroup, logger log.Logger) error {
+	logger = logger.WithComponent("config.reload")
+
+	reload := func(trigger string) {
+		logger.Infof(ctx, `reloading configuration, trigger: %s`, trigger)
+		if changed, err := r.Reload(ctx); err != nil {
+			logger.Errorf(ctx, `configuration reload failed: %s`, err)
+		} else if len(changed) == 0 {
+			logger.Info(ctx, `configuration reloaded, no change`)
+		} else {
+			logger.Infof(ctx, `configuration reloaded, changed fields: "%s"`, strings.Join(changed, `", "`))
+		}
+	}
+
+	// Reload on the SIGHUP signal
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer signal.Stop(signals)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-signals:
+				reload("SIGHUP")
+			}
+		}
+	}()
+
+	if len(r.configFiles) == 0 {
+		return nil
+	}
+
+	// Reload on a configuration file change.
+	// Directories are watched, because a file can be replaced, for example a Kubernetes ConfigMap is updated by a symlink swap.
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return errors.PrefixError(err, "cannot create FS watcher")
+	}
+	files := make(map[string]bool)
+	if err := r.watchConfigFiles(watcher, files); err != nil {
+		_ = watcher.Close()
+		return err
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer func() {
+			if err := watcher.Close(); err != nil {
+				logger.Warnf(ctx, `cannot close FS watcher: %s`, err)
+			}
+		}()
+
+		// Events are debounced, an editor or a ConfigMap update generates multiple events at once
+		var debounce <-chan time.Time
+		var trigger string
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				logger.Warnf(ctx, `FS watcher error: %s`, err)
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if (event.Has(fsnotify.Write) || event.Has(fsnotify.Create)) && files[filepath.Clean(event.Name)] { //nolint:forbidigo
+					trigger = "file " + event.Name
+					debounce = time.After(WatchDebounce)
+				}
+			case <-debounce:
+				debounce = nil
+				reload(trigger)
+				// The symlink target may have been changed
+				if err := r.watchConfigFiles(watcher, files); err != nil {
+					logger.Warnf(ctx, `FS watcher error: %s`, err)
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+// watchConfigFiles adds parent directories of the configuration files to the watcher,
+// and collects paths of the watched files: the configuration files, their resolved symlink targets,
+// and the "..data" symlinks, which are swapped on a Kubernetes ConfigMap update.
+func (r *Reloader[C]) watchConfigFiles(watcher *fsnotify.Watcher, files map[string]bool) error {
+	dirs := make(map[string]bool)
+	for _, path := range r.configFiles {
+		path = filepath.Clean(path) //nolint:forbidigo
+		dir := filepath.Dir(path)   //nolint:forbidigo
+		files[path] = true
+		files[filepath.Join(dir, "..data")] = true //nolint:forbidigo
+		dirs[dir] = true
+
+		// A symlink target outside the directory is watched too
+		if target, err := filepath.EvalSymlinks(path); err == nil && target != path { //nolint:forbidigo
+			files[target] = true
+			if targetDir := filepath.Dir(target); !strings.HasPrefix(targetDir, dir+string(filepath.Separator)) { //nolint:forbidigo
+				dirs[targetDir] = true
+			}
+		}
+	}
+
+	for dir := range dirs {
+		if err := watcher.Add(dir); err != nil {
+			return errors.PrefixErrorf(err, `cannot add path to the FS watcher "%s"`, dir)
+		}
+	}
+	return nil
+}
+
+// diffConfigs returns sorted paths of all changed fields, and paths of changed fields without the configReload tag.
+func diffConfigs(oldCfg, newCfg any) (changed, notReloadable []string) {
+	oldValues := flattenConfig(oldCfg)
+	newValues := flattenConfig(newCfg)
+	for path, newValue := range newValues {
+		oldValue, found := oldValues[path]
+		if found && reflect.DeepEqual(oldValue.value, newValue.value) {
+			continue
+		}
+		changed = append(changed, path)
+		if !newValue.reloadable {
+			notReloadable = append(notReloadable, path)
+		}
+	}
+	// Removed fields, for example a key removed from a map, or an item removed from a slice
+	for path, oldValue := range oldValues {
+		if _, found := newValues[path]; found {
+			continue
+		}
+		changed = append(changed, path)
+		if !oldValue.reloadable {
+			notReloadable = append(notReloadable, path)
+		}
+	}
+	sort.Strings(changed)
+	sort.Strings(notReloadable)
+	return changed, notReloadable
+}
+
+type flatValue struct {
+	value      any
+	reloadable bool
+}
+
+// flattenConfig maps paths of leaf fields to their values.
+func flattenConfig(cfg any) map[string]flatValue {
+	out := make(map[string]flatValue)
+	MustVisit(reflect.ValueOf(cfg), VisitConfig{
+		OnField: mapAndFilterField(),
+		OnValue: func(vc *VisitContext) error {
+			if vc.Leaf && vc.PrimitiveValue.IsValid() && vc.PrimitiveValue.CanInterface() {
+				out[vc.MappedPath.String()] = flatValue{value: vc.PrimitiveValue.Interface(), reloadable: vc.Reloadable}
+			}
+			return nil
+		},
+	})
+	return out
+}
@@ -0,0 +1,241 @@
+package configmap
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/log"
+)
+
+type reloadTestConfig struct {
+	Listen   string             `configKey:"listen"`
+	DebugLog bool               `configKey:"debugLog" configReload:"true"`
+	Nested   reloadNestedConfig `configKey:"nested" configReload:"true"`
+	Fixed    reloadNestedConfig `configKey:"fixed"`
+}
+
+type reloadNestedConfig struct {
+	Timeout time.Duration `configKey:"timeout"`
+	Tags    []string      `configKey:"tags"`
+}
+
+func TestReloader_Update(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	initial := reloadTestConfig{Listen: "0.0.0.0:8000", Nested: reloadNestedConfig{Timeout: time.Second}}
+	reloader := NewReloader(initial, nil)
+
+	var notified []reloadTestConfig
+	reloader.OnReload(func(ctx context.Context, cfg reloadTestConfig) {
+		notified = append(notified, cfg)
+	})
+
+	// No change
+	changed, err := reloader.Update(ctx, initial)
+	require.NoError(t, err)
+	assert.Empty(t, changed)
+	assert.Empty(t, notified)
+
+	// Change of reloadable fields
+	modified := initial
+	modified.DebugLog = true
+	modified.Nested.Timeout = time.Minute
+	modified.Nested.Tags = []string{"foo"}
+	changed, err = reloader.Update(ctx, modified)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"debugLog", "nested.tags", "nested.timeout"}, changed)
+	assert.Equal(t, []reloadTestConfig{modified}, notified)
+	assert.Equal(t, modified, reloader.Current())
+
+	// Change of not reloadable fields is rejected, the current configuration is kept
+	invalid := modified
+	invalid.DebugLog = false
+	invalid.Listen = "0.0.0.0:9000"
+	invalid.Fixed.Timeout = time.Hour
+	_, err = reloader.Update(ctx, invalid)
+	if assert.Error(t, err) {
+		assert.Equal(t, `configuration cannot be reloaded, the following fields cannot be changed without a restart: "fixed.timeout", "listen"`, err.Error())
+		assert.ErrorAs(t, err, &NotReloadableError{})
+	}
+	assert.Len(t, notified, 1)
+	assert.Equal(t, modified, reloader.Current())
+
+	// Reload without the load function
+	_, err = reloader.Reload(ctx)
+	if assert.Error(t, err) {
+		assert.Equal(t, "configuration reload is not supported", err.Error())
+	}
+}
+
+func TestDiffConfigs_Removed(t *testing.T) {
+	t.Parallel()
+
+	type limits struct {
+		Values map[string]int `configKey:"values" configReload:"true"`
+		Fixed  map[string]int `configKey:"fixed"`
+		Tags   []string       `configKey:"tags" configReload:"true"`
+	}
+
+	// Removed map key and slice item are reported
+	oldCfg := limits{Values: map[string]int{"foo": 1, "bar": 2}, Fixed: map[string]int{"foo": 1}, Tags: []string{"foo", "bar"}}
+	newCfg := limits{Values: map[string]int{"foo": 1}, Fixed: map[string]int{"foo": 1}, Tags: []string{"foo"}}
+	changed, notReloadable := diffConfigs(oldCfg, newCfg)
+	assert.Equal(t, []string{"tags", "values"}, changed)
+	assert.Empty(t, notReloadable)
+
+	// All keys removed
+	newCfg = limits{Fixed: map[string]int{}}
+	changed, notReloadable = diffConfigs(oldCfg, newCfg)
+	assert.Equal(t, []string{"fixed", "tags", "values"}, changed)
+	assert.Equal(t, []string{"fixed"}, notReloadable)
+}
+
+func TestReloader_Watch(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The configuration is loaded from a file
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("debugLog: false\n"), 0o600))
+	var configFiles []string
+	load := func() (reloadTestConfig, error) {
+		cfg := reloadTestConfig{}
+		err := GenerateAndBind(GenerateAndBindConfig{
+			Args:                   []string{"--config-file", path},
+			ConfigFilesTarget:      &configFiles,
+			GenerateConfigFileFlag: true,
+		}, &cfg)
+		return cfg, err
+	}
+	initial, err := load()
+	require.NoError(t, err)
+	assert.Equal(t, []string{path}, configFiles)
+
+	reloader := NewReloader(initial, load, configFiles...)
+	logger := log.NewDebugLogger()
+	wg := &sync.WaitGroup{}
+	require.NoError(t, reloader.Watch(ctx, wg, logger))
+
+	// Modify the file, the configuration is reloaded
+	require.NoError(t, os.WriteFile(path, []byte("debugLog: true\n"), 0o600))
+	assert.Eventually(t, func() bool {
+		return reloader.Current().DebugLog
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Modify a not reloadable field, the change is rejected
+	require.NoError(t, os.WriteFile(path, []byte("debugLog: false\nlisten: 0.0.0.0:9000\n"), 0o600))
+	assert.Eventually(t, func() bool {
+		return logger.CompareJSONMessages(`{"level":"error","message":"configuration reload failed: configuration cannot be reloaded, the following fields cannot be changed without a restart: \"listen\"","component":"config.reload"}`) == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.True(t, reloader.Current().DebugLog)
+
+	cancel()
+	wg.Wait()
+}
+
+func TestReloader_Watch_FilterAndDebounce(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("debugLog: false\n"), 0o600))
+	load, loads := newCountingLoad(path)
+	initial, err := load()
+	require.NoError(t, err)
+
+	reloader := NewReloader(initial, load, path)
+	wg := &sync.WaitGroup{}
+	require.NoError(t, reloader.Watch(ctx, wg, log.NewNopLogger()))
+
+	// Multiple changes in a row are merged into one reload
+	for _, content := range []string{"debugLog: true\n", "debugLog: false\n", "debugLog: true\n"} {
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	assert.Eventually(t, func() bool {
+		return reloader.Current().DebugLog
+	}, 5*time.Second, 10*time.Millisecond)
+	time.Sleep(2 * WatchDebounce)
+	assert.Equal(t, int64(2), loads.Load())
+
+	// A change of another file in the directory is ignored
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "other.yaml"), []byte("debugLog: false\n"), 0o600))
+	time.Sleep(2 * WatchDebounce)
+	assert.Equal(t, int64(2), loads.Load())
+
+	cancel()
+	wg.Wait()
+}
+
+func TestReloader_Watch_ConfigMap(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The directory has the same structure as a mounted Kubernetes ConfigMap:
+	// config.yaml -> ..data/config.yaml, ..data -> ..<timestamp>
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	updateConfigMap := func(version, content string) {
+		versionDir := filepath.Join(dir, "..v"+version)
+		require.NoError(t, os.Mkdir(versionDir, 0o700))
+		require.NoError(t, os.WriteFile(filepath.Join(versionDir, "config.yaml"), []byte(content), 0o600))
+		require.NoError(t, os.Symlink("..v"+version, filepath.Join(dir, "..data_tmp")))
+		require.NoError(t, os.Rename(filepath.Join(dir, "..data_tmp"), filepath.Join(dir, "..data")))
+	}
+	updateConfigMap("1", "debugLog: false\n")
+	require.NoError(t, os.Symlink(filepath.Join("..data", "config.yaml"), path))
+
+	load, _ := newCountingLoad(path)
+	initial, err := load()
+	require.NoError(t, err)
+
+	reloader := NewReloader(initial, load, path)
+	wg := &sync.WaitGroup{}
+	require.NoError(t, reloader.Watch(ctx, wg, log.NewNopLogger()))
+
+	// Swap the symlink, the configuration is reloaded
+	updateConfigMap("2", "debugLog: true\n")
+	require.NoError(t, os.RemoveAll(filepath.Join(dir, "..v1")))
+	assert.Eventually(t, func() bool {
+		return reloader.Current().DebugLog
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Swap the symlink again
+	updateConfigMap("3", "debugLog: false\n")
+	require.NoError(t, os.RemoveAll(filepath.Join(dir, "..v2")))
+	assert.Eventually(t, func() bool {
+		return !reloader.Current().DebugLog
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	wg.Wait()
+}
+
+// newCountingLoad creates a load function reading the configuration file, and the counter of its calls.
+func newCountingLoad(path string) (LoadFn[reloadTestConfig], *atomic.Int64) {
+	loads := &atomic.Int64{}
+	return func() (reloadTestConfig, error) {
+		loads.Add(1)
+		cfg := reloadTestConfig{}
+		err := GenerateAndBind(GenerateAndBindConfig{
+			Args:                   []string{"--config-file", path},
+			GenerateConfigFileFlag: true,
+		}, &cfg)
+		return cfg, err
+	}, loads
+}
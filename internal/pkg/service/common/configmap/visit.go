@@ -44,6 +44,8 @@ type VisitContext struct {
 	Leaf bool
 	// Sensitive is true, if the field has `sensitive:"true"` tag.
 	Sensitive bool
+	// Reloadable is true, if the field or its parent has `configReload:"true"` tag, see Reloader.
+	Reloadable bool
 	// Usage contains value from the "configUsage" tag, if any.
 	Usage string
 	// Usage contains value from the "configShorthand" tag, if any.
@@ -133,6 +135,9 @@ func doVisit(vc *VisitContext, cfg VisitConfig) error {
 			// Mark field and all its children as sensitive according to the tag
 			field.Sensitive = vc.Sensitive || field.StructField.Tag.Get(sensitiveTag) == "true"
 
+			// Mark field and all its children as reloadable according to the tag
+			field.Reloadable = vc.Reloadable || field.StructField.Tag.Get(configReloadTag) == "true"
+
 			// Set usage from the tag, or use parent value
 			field.Usage = vc.Usage
 			if usage := field.StructField.Tag.Get(configUsageTag); usage != "" {
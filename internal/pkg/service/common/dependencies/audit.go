@@ -0,0 +1,45 @@
+package dependencies
+
+import (
+	"context"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/audit"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+// auditScope implements AuditScope interface.
+type auditScope struct {
+	log *audit.Log
+}
+
+type auditScopeDeps interface {
+	BaseScope
+	EtcdClientScope
+}
+
+func NewAuditScope(ctx context.Context, cfg audit.Config, d auditScopeDeps) (AuditScope, error) {
+	return newAuditScope(ctx, cfg, d)
+}
+
+func newAuditScope(ctx context.Context, cfg audit.Config, d auditScopeDeps) (v *auditScope, err error) {
+	_, span := d.Telemetry().Tracer().Start(ctx, "keboola.go.common.dependencies.NewAuditScope")
+	defer span.End(&err)
+
+	auditLog, err := audit.New(cfg, d)
+	if err != nil {
+		return nil, err
+	}
+
+	return &auditScope{log: auditLog}, nil
+}
+
+func (v *auditScope) check() {
+	if v == nil {
+		panic(errors.New("dependencies audit scope is not initialized"))
+	}
+}
+
+func (v *auditScope) AuditLog() *audit.Log {
+	v.check()
+	return v.log
+}
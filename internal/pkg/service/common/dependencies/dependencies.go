@@ -69,6 +69,7 @@ import (
 	"github.com/keboola/keboola-as-code/internal/pkg/log"
 	"github.com/keboola/keboola-as-code/internal/pkg/model"
 	projectPkg "github.com/keboola/keboola-as-code/internal/pkg/project"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/audit"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/distlock"
 	distributionPkg "github.com/keboola/keboola-as-code/internal/pkg/service/common/distribution"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdclient"
@@ -141,6 +142,11 @@ type DistributedLockScope interface {
 	DistributedLockProvider() *distlock.Provider
 }
 
+// AuditScope dependencies to record mutating API calls.
+type AuditScope interface {
+	AuditLog() *audit.Log
+}
+
 // Mocked dependencies for tests.
 // All HTTP requests to APIs are handled by the MockedHttpTransport by default.
 type Mocked interface {
@@ -2,6 +2,7 @@ package dependencies
 
 import (
 	"context"
+	"net"
 	"time"
 
 	"github.com/keboola/go-client/pkg/keboola"
@@ -26,6 +27,15 @@ type PublicScopeOption func(*publicScopeConfig)
 type publicScopeConfig struct {
 	preloadComponents bool
 	logIndexLoading   bool
+	indexCache        IndexCache
+	offline           bool
+}
+
+// IndexCache stores the Storage API index with components, so it is available without network access.
+type IndexCache interface {
+	// LoadIndex returns nil, if the index of the host is not cached.
+	LoadIndex(ctx context.Context, storageAPIHost string) (*keboola.IndexComponents, error)
+	StoreIndex(ctx context.Context, storageAPIHost string, index *keboola.IndexComponents) error
 }
 
 func newPublicScopeConfig(opts []PublicScopeOption) publicScopeConfig {
@@ -50,6 +60,21 @@ func WithLogIndexLoading(v bool) PublicScopeOption {
 	}
 }
 
+// WithIndexCache defines the cache of the Storage API index, it is used only with WithPreloadComponents.
+// The loaded index is stored to the cache, the cached index is used if the Storage API is not reachable.
+func WithIndexCache(v IndexCache) PublicScopeOption {
+	return func(c *publicScopeConfig) {
+		c.indexCache = v
+	}
+}
+
+// WithOfflineIndex defines if the index should be loaded only from the cache, without an API request, see WithIndexCache.
+func WithOfflineIndex(v bool) PublicScopeOption {
+	return func(c *publicScopeConfig) {
+		c.offline = v
+	}
+}
+
 func NewPublicScope(ctx context.Context, baseScp BaseScope, storageAPIHost string, opts ...PublicScopeOption) (v PublicScope, err error) {
 	return newPublicScope(ctx, baseScp, storageAPIHost, opts...)
 }
@@ -76,7 +101,7 @@ func newPublicScope(ctx context.Context, baseScp BaseScope, storageAPIHost strin
 	var indexWithComponents *keboola.IndexComponents
 	if cfg.preloadComponents {
 		logger.Info(ctx, "loading Storage API index with components")
-		indexWithComponents, err = keboola.APIIndexWithComponents(ctx, storageAPIHost, keboola.WithClient(&baseHTTPClient))
+		indexWithComponents, err = loadIndexWithComponents(ctx, baseScp, cfg, storageAPIHost)
 		if err != nil {
 			return nil, err
 		}
@@ -106,6 +131,45 @@ func newPublicScope(ctx context.Context, baseScp BaseScope, storageAPIHost strin
 	return v, nil
 }
 
+func loadIndexWithComponents(ctx context.Context, baseScp BaseScope, cfg publicScopeConfig, storageAPIHost string) (*keboola.IndexComponents, error) {
+	if cfg.offline {
+		if cfg.indexCache == nil {
+			return nil, errors.New("offline mode requires the index cache")
+		}
+		index, err := cfg.indexCache.LoadIndex(ctx, storageAPIHost)
+		if err != nil {
+			return nil, err
+		}
+		if index == nil {
+			return nil, errors.Errorf(`Storage API index of the host "%s" is not cached, run the command once with network access`, storageAPIHost)
+		}
+		return index, nil
+	}
+
+	baseHTTPClient := baseScp.HTTPClient()
+	index, err := keboola.APIIndexWithComponents(ctx, storageAPIHost, keboola.WithClient(&baseHTTPClient))
+	if cfg.indexCache == nil {
+		return index, err
+	}
+
+	// Use the cached index, if the API is not reachable
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		if cached, cacheErr := cfg.indexCache.LoadIndex(ctx, storageAPIHost); cacheErr == nil && cached != nil {
+			baseScp.Logger().Warnf(ctx, `Storage API is not reachable, using the cached index: %s`, err)
+			return cached, nil
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cfg.indexCache.StoreIndex(ctx, storageAPIHost, index); err != nil {
+		baseScp.Logger().Warnf(ctx, `cannot store Storage API index to the cache: %s`, err)
+	}
+	return index, nil
+}
+
 func storageAPIIndexWithComponents(ctx context.Context, d BaseScope, keboolaPublicAPI *keboola.PublicAPI) (index *keboola.IndexComponents, err error) {
 	startTime := time.Now()
 	ctx, span := d.Telemetry().Tracer().Start(ctx, "keboola.go.common.dependencies.public.storageApiIndexWithComponents")
@@ -6,7 +6,9 @@ import (
 	"testing"
 
 	"github.com/benbjohnson/clock"
+	"github.com/keboola/go-client/pkg/keboola"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/keboola/keboola-as-code/internal/pkg/log"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/httpclient"
@@ -29,3 +31,40 @@ func TestNewPublicDeps_LazyLoadComponents(t *testing.T) {
 	assert.True(t, found)
 	assert.Equal(t, "keboola.ex-currency", c.ID.String())
 }
+
+type testIndexCache struct {
+	index *keboola.IndexComponents
+}
+
+func (c *testIndexCache) LoadIndex(_ context.Context, _ string) (*keboola.IndexComponents, error) {
+	return c.index, nil
+}
+
+func (c *testIndexCache) StoreIndex(_ context.Context, _ string, index *keboola.IndexComponents) error {
+	c.index = index
+	return nil
+}
+
+func TestNewPublicDeps_OfflineIndex(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	baseDeps := newBaseScope(ctx, log.NewNopLogger(), telemetry.NewNop(), os.Stdout, os.Stderr, clock.New(), servicectx.NewForTest(t), httpclient.New())
+
+	// The index is not cached
+	cache := &testIndexCache{}
+	opts := []PublicScopeOption{WithPreloadComponents(true), WithIndexCache(cache), WithOfflineIndex(true)}
+	_, err := newPublicScope(ctx, baseDeps, "https://connection.keboola.com", opts...)
+	if assert.Error(t, err) {
+		assert.Equal(t, `Storage API index of the host "https://connection.keboola.com" is not cached, run the command once with network access`, err.Error())
+	}
+
+	// The cached index is used without an API request
+	cache.index = &keboola.IndexComponents{
+		Components: keboola.Components{{ComponentKey: keboola.ComponentKey{ID: "keboola.ex-currency"}, Type: "extractor", Name: "Currency"}},
+	}
+	deps, err := newPublicScope(ctx, baseDeps, "https://connection.keboola.com", opts...)
+	require.NoError(t, err)
+	c, found := deps.Components().Get("keboola.ex-currency")
+	assert.True(t, found)
+	assert.Equal(t, "Currency", c.Name)
+}
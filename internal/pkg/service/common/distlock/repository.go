@@ -0,0 +1,92 @@
+package distlock
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	etcd "go.etcd.io/etcd/client/v3"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop/iterator"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop/op"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+// Repository provides read access to distributed locks, and repair operations.
+// Unlike the Provider, it doesn't start an etcd session, so it can be used by administration tools.
+type Repository struct {
+	client *etcd.Client
+	prefix etcdop.Prefix
+}
+
+// Lock is a key of the distributed lock, created by a session holding or waiting for the lock, see Provider.NewMutex.
+// For example, the Templates service locks a project by the "project/<project>" lock.
+type Lock struct {
+	Name  string     `json:"name"`
+	Key   etcdop.Key `json:"key"`
+	Lease string     `json:"lease"`
+}
+
+// LockInUseError is returned by the Repository.RemoveLock method, if the lease of a lock session is alive.
+type LockInUseError struct {
+	error
+}
+
+func (e LockInUseError) Unwrap() error {
+	return e.error
+}
+
+func NewRepository(client *etcd.Client) *Repository {
+	return &Repository{client: client, prefix: etcdop.Prefix(lockEtcdPrefix)}
+}
+
+// ListLocks lists all keys of distributed locks, optionally filtered by the lock name prefix, for example "project/".
+func (r *Repository) ListLocks(ctx context.Context, namePrefix string) (out []Lock, err error) {
+	err = r.prefix.GetAll(r.client).Do(ctx).ForEach(func(kv *op.KeyValue, _ *iterator.Header) error {
+		// The key of the mutex is "<name>/<lease>"
+		name := strings.TrimPrefix(string(kv.Key), r.prefix.Prefix())
+		if i := strings.LastIndex(name, "/"); i >= 0 {
+			name = name[:i]
+		}
+		if strings.HasPrefix(name, namePrefix) {
+			out = append(out, Lock{Name: name, Key: etcdop.Key(kv.Key), Lease: strconv.FormatInt(kv.Lease, 16)})
+		}
+		return nil
+	})
+	return out, err
+}
+
+// RemoveLock removes all keys of a stale distributed lock.
+// The lock is removed only if the leases of all sessions have expired, otherwise the LockInUseError is returned.
+// The force flag skips the check and removes also a live lock.
+func (r *Repository) RemoveLock(name string, force bool) *op.AtomicOp[op.NoResult] {
+	lock := r.prefix.Add(name)
+	var kvs []*op.KeyValue
+	return op.Atomic(r.client, &op.NoResult{}).
+		Read(func(ctx context.Context) op.Op {
+			kvs = nil
+			return lock.GetAll(r.client).ForEach(func(kv *op.KeyValue, _ *iterator.Header) error {
+				kvs = append(kvs, kv)
+				return nil
+			})
+		}).
+		Write(func(ctx context.Context) op.Op {
+			if len(kvs) == 0 {
+				return op.ErrorOp(errors.Errorf(`the lock "%s" not found`, name))
+			}
+			if !force {
+				for _, kv := range kvs {
+					// The TTL is -1, if the lease has expired or has been revoked
+					resp, err := r.client.TimeToLive(ctx, etcd.LeaseID(kv.Lease))
+					if err != nil {
+						return op.ErrorOp(err)
+					}
+					if resp.TTL > 0 {
+						return op.ErrorOp(LockInUseError{errors.Errorf(`the lock "%s" is held by a session with the lease "%x", the lease is alive`, name, kv.Lease)})
+					}
+				}
+			}
+			return lock.DeleteAll(r.client)
+		})
+}
@@ -1,27 +1,24 @@
 package distribution
 
 import (
-	"sort"
 	"sync"
-
-	"github.com/lafikl/consistent"
 )
 
 // Assigner locally assigns the owner for the key, see NodeFor and IsOwner methods. It is part of the Node.
 //
-// The hash ring/consistent hashing pattern is used to make the assigment,
-// it is provided by the "consistent" package, see TestConsistentHashLib for more information.
+// The weighted hash ring/consistent hashing pattern is used to make the assigment, see the ring struct.
+// Each node has a weight, the node gets a share of the keys proportional to its weight.
 type Assigner struct {
 	nodeID string
 	mutex  *sync.RWMutex
-	nodes  *consistent.Consistent
+	nodes  *ring
 }
 
 func newAssigner(nodeID string) *Assigner {
 	return &Assigner{
 		nodeID: nodeID,
 		mutex:  &sync.RWMutex{},
-		nodes:  consistent.New(),
+		nodes:  newRing(),
 	}
 }
 
@@ -34,24 +31,29 @@ func (a *Assigner) NodeID() string {
 func (a *Assigner) Nodes() []string {
 	a.mutex.RLock()
 	defer a.mutex.RUnlock()
-	out := a.nodes.Hosts()
-	sort.Strings(out)
-	return out
+	return a.nodes.nodes()
+}
+
+// NodeWeight method returns weight of the node, false is returned if the node is not known.
+func (a *Assigner) NodeWeight(nodeID string) (int, bool) {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+	return a.nodes.weight(nodeID)
 }
 
 // NodesCount method returns count of known nodes.
 func (a *Assigner) NodesCount() int {
 	a.mutex.RLock()
 	defer a.mutex.RUnlock()
-	return len(a.nodes.Hosts())
+	return len(a.nodes.weights)
 }
 
 // NodeFor returns ID of the key's owner node.
-// The consistent.ErrNoHosts may occur if there is no node in the list.
+// The ErrNoNodes may occur if there is no node with a non-zero weight in the list.
 func (a *Assigner) NodeFor(key string) (string, error) {
 	a.mutex.RLock()
 	defer a.mutex.RUnlock()
-	return a.nodes.Get(key)
+	return a.nodes.get(key)
 }
 
 // MustGetNodeFor returns ID of the key's owner node.
@@ -65,7 +67,7 @@ func (a *Assigner) MustGetNodeFor(key string) string {
 }
 
 // IsOwner method returns true, if the node is owner of the key.
-// The consistent.ErrNoHosts may occur if there is no node in the list.
+// The ErrNoNodes may occur if there is no node in the list.
 func (a *Assigner) IsOwner(key string) (bool, error) {
 	node, err := a.NodeFor(key)
 	if err != nil {
@@ -95,10 +97,10 @@ func (a *Assigner) HasNode(nodeID string) bool {
 }
 
 func (a *Assigner) clone() *Assigner {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
 	clone := newAssigner(a.nodeID)
-	for _, nodeID := range a.Nodes() {
-		clone.addNode(nodeID)
-	}
+	clone.nodes = a.nodes.clone()
 	return clone
 }
 
@@ -114,13 +116,14 @@ func (a *Assigner) unlock() {
 }
 
 func (a *Assigner) resetNodes() {
-	a.nodes = consistent.New()
+	a.nodes = newRing()
 }
 
-func (a *Assigner) addNode(nodeID string) {
-	a.nodes.Add(nodeID)
+// setNode adds the node or updates its weight.
+func (a *Assigner) setNode(nodeID string, weight int) {
+	a.nodes.set(nodeID, weight)
 }
 
 func (a *Assigner) removeNode(nodeID string) bool {
-	return a.nodes.Remove(nodeID)
+	return a.nodes.remove(nodeID)
 }
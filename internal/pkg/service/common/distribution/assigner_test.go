@@ -1,11 +1,22 @@
 package distribution_test
 
 import (
+	"context"
 	"fmt"
+	"reflect"
 	"testing"
+	"time"
 
+	"github.com/benbjohnson/clock"
 	"github.com/lafikl/consistent"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/dependencies"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/distribution"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdclient"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/etcdhelper"
 )
 
 // TestConsistentHashLib tests the library behavior and shows how it should be used.
@@ -57,3 +68,129 @@ func TestConsistentHashLib(t *testing.T) {
 		"node5": 23,
 	}, keysPerNode)
 }
+
+func TestAssigner_NodeWeight(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	etcdCfg := etcdhelper.TmpNamespace(t)
+	client := etcdhelper.ClientForTest(t, etcdCfg)
+
+	// Create nodes, the node2 has a higher weight
+	node1, d1 := createWeightedNode(t, ctx, etcdCfg, "node1", 1)
+	node2, d2 := createWeightedNode(t, ctx, etcdCfg, "node2", 3)
+	for _, node := range []*distribution.GroupNode{node1, node2} {
+		assert.Eventually(t, func() bool {
+			return reflect.DeepEqual([]string{"node1", "node2"}, node.Nodes())
+		}, 5*time.Second, 10*time.Millisecond)
+	}
+	weight, found := node1.NodeWeight("node2")
+	assert.True(t, found)
+	assert.Equal(t, 3, weight)
+
+	// Registration value of a node with the default weight is the plain node ID
+	etcdhelper.AssertKVsString(t, client, `
+<<<<<
+runtime/distribution/group/my-group/nodes/node1 (lease)
+-----
+node1
+>>>>>
+
+<<<<<
+runtime/distribution/group/my-group/nodes/node2 (lease)
+-----
+{
+  "nodeId": "node2",
+  "weight": 3
+}
+>>>>>
+`)
+
+	// Both nodes have the same assignment, the node2 gets more keys
+	keysPerNode := make(map[string]int)
+	for i := 1; i <= 100; i++ {
+		key := fmt.Sprintf("foo%02d", i)
+		assert.Equal(t, node1.MustGetNodeFor(key), node2.MustGetNodeFor(key))
+		keysPerNode[node1.MustGetNodeFor(key)]++
+	}
+	assert.Greater(t, keysPerNode["node2"], keysPerNode["node1"])
+
+	// Update weight of the node2
+	require.NoError(t, node2.UpdateWeight(ctx, 1))
+	assert.Eventually(t, func() bool {
+		weight, _ := node1.NodeWeight("node2")
+		return weight == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	d1.DebugLogger().AssertJSONMessages(t, `{"level":"info","message":"the node \"node2\" weight changed from 3 to 1"}`)
+
+	// Weight must be at least 1, so the ring cannot be empty
+	assert.EqualError(t, node2.UpdateWeight(ctx, 0), `weight of the node "node2" must be at least 1, found 0`)
+
+	d1.Process().Shutdown(ctx, errors.New("bye bye 1"))
+	d1.Process().WaitForShutdown()
+	d2.Process().Shutdown(ctx, errors.New("bye bye 2"))
+	d2.Process().WaitForShutdown()
+}
+
+func TestNode_UpdateWeight(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	etcdCfg := etcdhelper.TmpNamespace(t)
+	client := etcdhelper.ClientForTest(t, etcdCfg)
+
+	d := createDeps(t, ctx, clock.New(), nil, etcdCfg)
+	cfg := distribution.NewConfig()
+	cfg.StartupTimeout = time.Second
+	cfg.ShutdownTimeout = time.Second
+	node := distribution.NewNode("node1", cfg, d)
+	_, err := node.Group("group1")
+	require.NoError(t, err)
+
+	// The weight is updated in all groups, a group created later uses the new weight
+	require.NoError(t, node.UpdateWeight(ctx, 2))
+	_, err = node.Group("group2")
+	require.NoError(t, err)
+	etcdhelper.AssertKVsString(t, client, `
+<<<<<
+runtime/distribution/group/group1/nodes/node1 (lease)
+-----
+{
+  "nodeId": "node1",
+  "weight": 2
+}
+>>>>>
+
+<<<<<
+runtime/distribution/group/group2/nodes/node1 (lease)
+-----
+{
+  "nodeId": "node1",
+  "weight": 2
+}
+>>>>>
+`)
+
+	// Weight must be at least 1
+	assert.EqualError(t, node.UpdateWeight(ctx, 0), `weight of the node "node1" must be at least 1, found 0`)
+
+	d.Process().Shutdown(ctx, errors.New("bye bye"))
+	d.Process().WaitForShutdown()
+}
+
+func createWeightedNode(t *testing.T, ctx context.Context, etcdCfg etcdclient.Config, nodeID string, weight int) (*distribution.GroupNode, dependencies.Mocked) {
+	t.Helper()
+	d := createDeps(t, ctx, clock.New(), nil, etcdCfg)
+	cfg := distribution.NewConfig()
+	cfg.StartupTimeout = time.Second
+	cfg.ShutdownTimeout = time.Second
+	cfg.EventsGroupInterval = 10 * time.Millisecond
+	cfg.Weight = weight
+	groupNode, err := distribution.NewNode(nodeID, cfg, d).Group("my-group")
+	require.NoError(t, err)
+	return groupNode, d
+}
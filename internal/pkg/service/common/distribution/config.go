@@ -14,6 +14,12 @@ type Config struct {
 	// EventsGroupInterval configures how often changes in the cluster topology are processed.
 	// All changes in the interval are grouped together, so that updates do not occur too often. Use 0 to disable the grouping.
 	EventsGroupInterval time.Duration `configKey:"eventsGroupInterval" configUsage:"Interval of processing changes in the topology. Use 0 to disable the grouping." validate:"maxDuration=30s"`
+	// HandoffTimeout configures the maximum time to release an acquired key, when the key is assigned to another node.
+	// The new owner waits for the release acknowledgement at most this time.
+	HandoffTimeout time.Duration `configKey:"handoffTimeout" configUsage:"Maximum time to release a key assigned to another node, the new owner waits at most this time." validate:"required,minDuration=1s,maxDuration=5m"`
+	// Weight configures a share of the keys assigned to the node, proportionally to the weight of other nodes.
+	// It can be changed by the configuration reload, see the Node.UpdateWeight method.
+	Weight int `configKey:"weight" configUsage:"Weight of the node, keys are assigned proportionally to weights of the nodes." configReload:"true" validate:"required,min=1,max=1000"`
 	// TTLSeconds configures the number seconds after which the node is automatically un-registered if an outage occurs.
 	TTLSeconds int `configKey:"ttlSeconds" configUsage:"Seconds after which the node is automatically un-registered if an outage occurs." validate:"required,min=1,max=30"`
 }
@@ -24,6 +30,8 @@ func NewConfig() Config {
 		StartupTimeout:      60 * time.Second,
 		ShutdownTimeout:     10 * time.Second,
 		EventsGroupInterval: 5 * time.Second,
+		HandoffTimeout:      30 * time.Second,
+		Weight:              1,
 		TTLSeconds:          15,
 	}
 }
@@ -12,7 +12,7 @@
 //   - There can be several independent groups. Group name is an argument of the [NewNode] function.
 //   - Each [Node] in the group has a unique etcd key.
 //   - The key format is: runtime/distribution/group/<group>/nodes/<node_id>
-//   - The value format is: <node_id>
+//   - The value format is: <node_id>, or JSON {"nodeId":"<node_id>","weight":<weight>} if the weight is not the default 1.
 //
 // Node registration/deregistration:
 //   - Each node registers the own key during startup, see the key format above.
@@ -31,8 +31,15 @@
 // The [Hash Ring pattern] (or Consistent Hashing) is a distributed system design that uses a ring structure
 // and hash function to efficiently partition and balance data across nodes without centralized coordination.
 //
-// The [lafikl/consistent] library is used internally, is a lightweight implementation of the pattern,
-// for example usage see the TestConsistentHashLib.
+// The ring is implemented internally, it is compatible with the [lafikl/consistent] library,
+// for example usage of the library see the TestConsistentHashLib.
+//
+// Weighted nodes:
+//   - Each node has a weight, see [Config].Weight, the number of virtual nodes in the ring is proportional to the weight.
+//   - The weight can be changed at runtime by the [Node.UpdateWeight] method, the Stream service calls it on the configuration reload.
+//   - The weight is at least 1, so there is always a node to assign a key to, if the group is not empty.
+//   - The assignment is always local and deterministic, it depends only on the registered nodes and their weights.
+//   - Collisions of virtual nodes are resolved in favor of the lower node ID, so the order of registrations doesn't matter.
 //
 // # Benefits
 //
@@ -64,6 +71,18 @@
 //
 // Use [Node.OnChangeListener] method to create a listener for nodes distribution change events.
 //
+// # Hand-off
+//
+// The ownership of a key changes instantly, when a node is added or removed.
+// Use [GroupNode.Acquire] method instead of the IsOwner method, if the work based on the key must not run on two nodes.
+//   - The acquired key is recorded in etcd: runtime/distribution/group/<group>/owners/<key>, the value is <node_id>.
+//   - The record is bound to the session lease of the node, so it is removed if the node fails.
+//   - When the key is assigned to another node, the old owner calls [ReleaseFn] callbacks, see [GroupNode.OnRelease].
+//   - The callbacks get a context with the [Config].HandoffTimeout deadline, then the record is deleted - the release acknowledgement.
+//   - The new owner waits for the acknowledgement, at most the HandoffTimeout, then it takes over the key.
+//   - Use [GroupNode.TryAcquire] method in a periodic check, it doesn't wait, the key is skipped until it is released.
+//   - On shutdown, all acquired keys are released before the node is unregistered.
+//
 // [etcd lease]: https://etcd.io/docs/v3.5/learning/api/#lease-api
 // [Hash Ring pattern]: https://www.youtube.com/watch?v=UF9Iqmg94tk
 // [lafikl/consistent]: https://github.com/lafikl/consistent
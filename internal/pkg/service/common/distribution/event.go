@@ -7,6 +7,7 @@ import (
 const (
 	EventNodeAdded EventType = iota
 	EventNodeRemoved
+	EventNodeUpdated
 )
 
 // Event describes a distribution change - a change in the list of nodes.
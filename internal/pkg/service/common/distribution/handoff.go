@@ -0,0 +1,319 @@
+package distribution
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	etcd "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/concurrency"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/ctxattr"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop/op"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+// ReleaseFn is called on the old owner of the key, when the key is assigned to another node.
+// The work based on the key should be stopped before the ctx deadline, then the key is released.
+type ReleaseFn func(ctx context.Context, key string)
+
+// handoff implements graceful ownership hand-off of keys acquired by the GroupNode.Acquire method.
+//
+// Ownership of an acquired key is recorded in etcd, the record is bound to the node session lease.
+// When the key is assigned to another node, the old owner calls ReleaseFn callbacks and deletes the record - the release acknowledgement.
+// The new owner waits for the acknowledgement, or for the Config.HandoffTimeout, then takes over the key.
+type handoff struct {
+	clock        clock.Clock
+	assigner     *Assigner
+	logger       log.Logger
+	config       Config
+	client       *etcd.Client
+	ownersPrefix etcdop.Prefix
+	wg           *sync.WaitGroup
+
+	lock      *sync.Mutex
+	session   *concurrency.Session
+	keys      map[string]*ownedKey
+	callbacks []ReleaseFn
+	// waiting contains the start of the wait for the release of a key by the previous owner, see TryAcquire.
+	waiting map[string]time.Time
+}
+
+type ownedKey struct {
+	releasing bool
+}
+
+func newHandoff(clk clock.Clock, a *Assigner, logger log.Logger, cfg Config, client *etcd.Client, groupID string, wg *sync.WaitGroup) *handoff {
+	return &handoff{
+		clock:        clk,
+		assigner:     a,
+		logger:       logger,
+		config:       cfg,
+		client:       client,
+		ownersPrefix: GroupsEtcdPrefix.Add(groupID).Add("owners"),
+		wg:           wg,
+		lock:         &sync.Mutex{},
+		keys:         make(map[string]*ownedKey),
+		waiting:      make(map[string]time.Time),
+	}
+}
+
+// OnRelease registers a callback, it is called on the old owner of an acquired key, when the key is assigned to another node.
+// The callback is also called for all acquired keys on shutdown.
+func (n *GroupNode) OnRelease(fn ReleaseFn) {
+	n.handoff.lock.Lock()
+	defer n.handoff.lock.Unlock()
+	n.handoff.callbacks = append(n.handoff.callbacks, fn)
+}
+
+// Acquire method returns true, if the node is owner of the key and the key has been released by the previous owner.
+// Unlike the IsOwner method, it prevents the work based on the key from running on two nodes during a distribution change.
+// The method waits for the release acknowledgement of the previous owner, at most Config.HandoffTimeout.
+func (n *GroupNode) Acquire(ctx context.Context, key string) (bool, error) {
+	return n.handoff.acquire(ctx, key, true)
+}
+
+// TryAcquire method is a non-blocking variant of the Acquire method.
+// It returns false, if the key has not been released by the previous owner yet, the caller should try it again later.
+// The Config.HandoffTimeout is measured from the first attempt to acquire the key.
+func (n *GroupNode) TryAcquire(ctx context.Context, key string) (bool, error) {
+	return n.handoff.acquire(ctx, key, false)
+}
+
+// Release method releases the acquired key immediately, ReleaseFn callbacks are not called.
+// It should be called, when the work based on the key has been finished.
+func (n *GroupNode) Release(ctx context.Context, key string) error {
+	return n.handoff.release(ctx, key)
+}
+
+// setSession is called on each new session, records bound to an old lease have expired.
+func (h *handoff) setSession(session *concurrency.Session) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.session = session
+	h.keys = make(map[string]*ownedKey)
+	h.waiting = make(map[string]time.Time)
+}
+
+func (h *handoff) acquire(ctx context.Context, key string, wait bool) (bool, error) {
+	ctx = ctxattr.ContextWith(ctx, attribute.String("distribution.key", key))
+	deadline := h.clock.Now().Add(h.config.HandoffTimeout)
+	for {
+		if owner, err := h.assigner.IsOwner(key); err != nil || !owner {
+			h.stopWaiting(key)
+			return false, err
+		}
+
+		h.lock.Lock()
+		k, found := h.keys[key]
+		session := h.session
+		h.lock.Unlock()
+		if found {
+			return !k.releasing, nil
+		}
+		if session == nil {
+			return false, errors.New("distribution session is not ready")
+		}
+
+		// Create the ownership record, if it doesn't exist
+		etcdKey := h.ownersPrefix.Key(key)
+		if ok, err := etcdKey.PutIfNotExists(h.client, h.assigner.NodeID(), etcd.WithLease(session.Lease())).Do(ctx).ResultOrErr(); err != nil {
+			return false, err
+		} else if ok {
+			h.own(key)
+			return true, nil
+		}
+
+		// The record exists, check the previous owner
+		kv, err := etcdKey.Get(h.client).Do(ctx).ResultOrErr()
+		if err != nil {
+			return false, err
+		} else if kv == nil {
+			// The key has been released in the meantime
+			continue
+		} else if prevOwner := string(kv.Value); prevOwner == h.assigner.NodeID() {
+			// The record is bound to an old session
+			if err := etcdKey.Put(h.client, prevOwner, etcd.WithLease(session.Lease())).Do(ctx).Err(); err != nil {
+				return false, err
+			}
+			h.own(key)
+			return true, nil
+		} else if !wait && h.clock.Now().Before(h.startWaiting(ctx, key, prevOwner).Add(h.config.HandoffTimeout)) {
+			// The previous owner is releasing the key, try it again later
+			return false, nil
+		} else if wait && h.clock.Now().Before(deadline) {
+			h.logger.Infof(ctx, `waiting for release of the key "%s" by the node "%s"`, key, prevOwner)
+			if err := h.waitForChange(ctx, etcdKey, kv.ModRevision, deadline); err != nil {
+				return false, err
+			}
+		} else {
+			// The previous owner didn't release the key in time
+			h.logger.Warnf(ctx, `the key "%s" has not been released by the node "%s" in %s, taking over`, key, prevOwner, h.config.HandoffTimeout)
+			if err := etcdKey.Put(h.client, h.assigner.NodeID(), etcd.WithLease(session.Lease())).Do(ctx).Err(); err != nil {
+				return false, err
+			}
+			h.own(key)
+			return true, nil
+		}
+	}
+}
+
+// waitForChange waits for a modification or deletion of the record, or for the deadline.
+func (h *handoff) waitForChange(ctx context.Context, key etcdop.Key, modRevision int64, deadline time.Time) error {
+	watchCtx, cancel := h.withTimeout(ctx, deadline.Sub(h.clock.Now()))
+	defer cancel()
+
+	for resp := range h.client.Watch(watchCtx, key.Key(), etcd.WithRev(modRevision+1)) {
+		if len(resp.Events) > 0 {
+			return nil
+		}
+		if err := resp.Err(); err != nil && watchCtx.Err() == nil {
+			return err
+		}
+	}
+
+	// The deadline is not an error, only cancellation of the parent context
+	return ctx.Err()
+}
+
+// startWaiting returns the start of the wait for the release of the key by the previous owner, see TryAcquire.
+func (h *handoff) startWaiting(ctx context.Context, key, prevOwner string) time.Time {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	since, found := h.waiting[key]
+	if !found {
+		since = h.clock.Now()
+		h.waiting[key] = since
+		h.logger.Infof(ctx, `waiting for release of the key "%s" by the node "%s"`, key, prevOwner)
+	}
+	return since
+}
+
+func (h *handoff) stopWaiting(key string) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	delete(h.waiting, key)
+}
+
+func (h *handoff) own(key string) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.keys[key] = &ownedKey{}
+	delete(h.waiting, key)
+}
+
+func (h *handoff) release(ctx context.Context, key string) error {
+	h.lock.Lock()
+	delete(h.keys, key)
+	h.lock.Unlock()
+	return h.deleteRecord(ctx, key)
+}
+
+// deleteRecord deletes the ownership record, if it is still owned by the node.
+func (h *handoff) deleteRecord(ctx context.Context, key string) error {
+	etcdKey := h.ownersPrefix.Key(key)
+	return op.Txn(h.client).
+		If(etcd.Compare(etcd.Value(etcdKey.Key()), "=", h.assigner.NodeID())).
+		Then(etcdKey.Delete(h.client)).
+		Do(ctx).
+		Err()
+}
+
+// releaseLost starts release of the acquired keys, which have been assigned to another node.
+func (h *handoff) releaseLost(ctx context.Context) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	for key, k := range h.keys {
+		if k.releasing {
+			continue
+		}
+		// The ownership cannot be checked, for example if there is no node with a non-zero weight, the key is kept
+		if owner, err := h.assigner.IsOwner(key); err != nil {
+			h.logger.Warnf(ctx, `cannot check owner of the key "%s": %s`, key, err)
+			continue
+		} else if owner {
+			continue
+		}
+		k.releasing = true
+		h.wg.Add(1)
+		go func() {
+			defer h.wg.Done()
+			h.releaseKey(ctx, key)
+		}()
+	}
+}
+
+// releaseAll releases all acquired keys, it is called on shutdown.
+func (h *handoff) releaseAll(ctx context.Context) {
+	h.lock.Lock()
+	var keys []string
+	for key, k := range h.keys {
+		if !k.releasing {
+			k.releasing = true
+			keys = append(keys, key)
+		}
+	}
+	h.lock.Unlock()
+
+	if len(keys) == 0 {
+		return
+	}
+
+	h.logger.Infof(ctx, `releasing "%d" keys`, len(keys))
+	wg := &sync.WaitGroup{}
+	for _, key := range keys {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			h.releaseKey(ctx, key)
+		}()
+	}
+	wg.Wait()
+}
+
+// releaseKey calls the ReleaseFn callbacks and deletes the ownership record - the release acknowledgement.
+func (h *handoff) releaseKey(ctx context.Context, key string) {
+	ctx = ctxattr.ContextWith(context.WithoutCancel(ctx), attribute.String("distribution.key", key))
+	h.logger.Infof(ctx, `releasing the key "%s"`, key)
+
+	h.lock.Lock()
+	callbacks := h.callbacks
+	h.lock.Unlock()
+
+	releaseCtx, cancel := h.withTimeout(ctx, h.config.HandoffTimeout)
+	for _, fn := range callbacks {
+		fn(releaseCtx, key)
+	}
+	cancel()
+
+	h.lock.Lock()
+	if k, found := h.keys[key]; found && k.releasing {
+		delete(h.keys, key)
+	}
+	h.lock.Unlock()
+
+	// Create context for the acknowledgement, the release context could have timed out.
+	ackCtx, ackCancel := context.WithTimeout(ctx, h.config.ShutdownTimeout)
+	defer ackCancel()
+	if err := h.deleteRecord(ackCtx, key); err != nil {
+		h.logger.Warnf(ctx, `cannot release the key "%s": %s`, key, err)
+		return
+	}
+
+	h.logger.Infof(ctx, `released the key "%s"`, key)
+}
+
+// withTimeout cancels the context after the timeout measured by the clock.
+// The context has no deadline, a deadline from a mocked clock would be used by the etcd client as a real time.
+func (h *handoff) withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	timer := h.clock.AfterFunc(timeout, cancel)
+	return ctx, func() {
+		timer.Stop()
+		cancel()
+	}
+}
@@ -0,0 +1,252 @@
+package distribution_test
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/dependencies"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/distribution"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdclient"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/etcdhelper"
+)
+
+func TestHandoff(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	etcdCfg := etcdhelper.TmpNamespace(t)
+	client := etcdhelper.ClientForTest(t, etcdCfg)
+
+	// The node1 acquires all keys
+	node1, d1 := createHandoffNode(t, ctx, etcdCfg, "node1", 10*time.Second)
+	keys := []string{"foo1", "foo2", "foo3", "foo4", "foo5", "foo6"}
+	for _, key := range keys {
+		ok, err := node1.Acquire(ctx, key)
+		require.NoError(t, err)
+		assert.True(t, ok)
+	}
+
+	// Release callback blocks, until the test allows the release
+	allowRelease := make(chan struct{})
+	releasedLock := &sync.Mutex{}
+	var released []string
+	node1.OnRelease(func(ctx context.Context, key string) {
+		<-allowRelease
+		releasedLock.Lock()
+		released = append(released, key)
+		releasedLock.Unlock()
+	})
+
+	// The node2 joins the group, some keys are assigned to the node2
+	node2, d2 := createHandoffNode(t, ctx, etcdCfg, "node2", 10*time.Second)
+	assert.Eventually(t, func() bool {
+		return reflect.DeepEqual([]string{"node1", "node2"}, node1.Nodes())
+	}, 5*time.Second, 10*time.Millisecond)
+	var movedKeys []string
+	for _, key := range keys {
+		if node2.MustCheckIsOwner(key) {
+			movedKeys = append(movedKeys, key)
+		}
+	}
+	require.NotEmpty(t, movedKeys)
+	movedKey := movedKeys[0]
+
+	// The node2 waits for the release of the key by the node1
+	acquired := make(chan bool)
+	go func() {
+		ok, err := node2.Acquire(ctx, movedKey)
+		assert.NoError(t, err)
+		acquired <- ok
+	}()
+	select {
+	case <-acquired:
+		assert.Fail(t, "the key has been acquired before the release")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	// The node1 doesn't own the key, the release is in progress
+	ok, err := node1.Acquire(ctx, movedKey)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// Allow release, the node2 acquires the key
+	close(allowRelease)
+	select {
+	case ok := <-acquired:
+		assert.True(t, ok)
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "timeout")
+	}
+	assert.Eventually(t, func() bool {
+		releasedLock.Lock()
+		defer releasedLock.Unlock()
+		return len(released) == len(movedKeys)
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Record of the acquired key is owned by the node2
+	resp, err := client.Get(ctx, "runtime/distribution/group/my-group/owners/"+movedKey)
+	require.NoError(t, err)
+	require.Len(t, resp.Kvs, 1)
+	assert.Equal(t, "node2", string(resp.Kvs[0].Value))
+
+	// Explicit release of a key
+	require.NoError(t, node2.Release(ctx, movedKey))
+	resp, err = client.Get(ctx, "runtime/distribution/group/my-group/owners/"+movedKey)
+	require.NoError(t, err)
+	assert.Empty(t, resp.Kvs)
+
+	// Shutdown releases all keys
+	d1.Process().Shutdown(ctx, errors.New("bye bye 1"))
+	d1.Process().WaitForShutdown()
+	d2.Process().Shutdown(ctx, errors.New("bye bye 2"))
+	d2.Process().WaitForShutdown()
+	etcdhelper.AssertKVsString(t, client, "")
+}
+
+func TestHandoff_Timeout(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	etcdCfg := etcdhelper.TmpNamespace(t)
+	client := etcdhelper.ClientForTest(t, etcdCfg)
+
+	// The node1 acquires all keys, the release callback exceeds the timeout
+	handoffTimeout := 500 * time.Millisecond
+	node1, d1 := createHandoffNode(t, ctx, etcdCfg, "node1", handoffTimeout)
+	keys := []string{"foo1", "foo2", "foo3", "foo4", "foo5", "foo6"}
+	for _, key := range keys {
+		ok, err := node1.Acquire(ctx, key)
+		require.NoError(t, err)
+		assert.True(t, ok)
+	}
+	blockRelease := make(chan struct{})
+	node1.OnRelease(func(ctx context.Context, key string) {
+		<-blockRelease
+	})
+
+	// The node2 joins the group
+	node2, d2 := createHandoffNode(t, ctx, etcdCfg, "node2", handoffTimeout)
+	assert.Eventually(t, func() bool {
+		return reflect.DeepEqual([]string{"node1", "node2"}, node1.Nodes())
+	}, 5*time.Second, 10*time.Millisecond)
+	var movedKey string
+	for _, key := range keys {
+		if node2.MustCheckIsOwner(key) {
+			movedKey = key
+			break
+		}
+	}
+	require.NotEmpty(t, movedKey)
+
+	// The node2 takes over the key after the timeout
+	startTime := time.Now()
+	ok, err := node2.Acquire(ctx, movedKey)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.GreaterOrEqual(t, time.Since(startTime), handoffTimeout/2)
+	d2.DebugLogger().AssertJSONMessages(t, fmt.Sprintf(`
+{"level":"info","message":"waiting for release of the key \"%s\" by the node \"node1\""}
+{"level":"warn","message":"the key \"%s\" has not been released by the node \"node1\" in 500ms, taking over"}
+`, movedKey, movedKey))
+
+	// The late acknowledgement of the node1 doesn't delete the record of the node2
+	close(blockRelease)
+	time.Sleep(100 * time.Millisecond)
+	resp, err := client.Get(ctx, "runtime/distribution/group/my-group/owners/"+movedKey)
+	require.NoError(t, err)
+	require.Len(t, resp.Kvs, 1)
+	assert.Equal(t, "node2", string(resp.Kvs[0].Value))
+
+	d1.Process().Shutdown(ctx, errors.New("bye bye 1"))
+	d1.Process().WaitForShutdown()
+	d2.Process().Shutdown(ctx, errors.New("bye bye 2"))
+	d2.Process().WaitForShutdown()
+}
+
+func TestHandoff_TryAcquire(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	etcdCfg := etcdhelper.TmpNamespace(t)
+
+	// The node1 acquires all keys, the release callback blocks
+	handoffTimeout := 500 * time.Millisecond
+	node1, d1 := createHandoffNode(t, ctx, etcdCfg, "node1", handoffTimeout)
+	keys := []string{"foo1", "foo2", "foo3", "foo4", "foo5", "foo6"}
+	for _, key := range keys {
+		ok, err := node1.TryAcquire(ctx, key)
+		require.NoError(t, err)
+		assert.True(t, ok)
+	}
+	blockRelease := make(chan struct{})
+	node1.OnRelease(func(ctx context.Context, key string) {
+		<-blockRelease
+	})
+
+	// The node2 joins the group
+	node2, d2 := createHandoffNode(t, ctx, etcdCfg, "node2", handoffTimeout)
+	assert.Eventually(t, func() bool {
+		return reflect.DeepEqual([]string{"node1", "node2"}, node1.Nodes())
+	}, 5*time.Second, 10*time.Millisecond)
+	var movedKey string
+	for _, key := range keys {
+		if node2.MustCheckIsOwner(key) {
+			movedKey = key
+			break
+		}
+	}
+	require.NotEmpty(t, movedKey)
+
+	// The node2 doesn't wait for the release
+	startTime := time.Now()
+	ok, err := node2.TryAcquire(ctx, movedKey)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Less(t, time.Since(startTime), handoffTimeout)
+
+	// The node2 takes over the key, when the timeout from the first attempt is exceeded
+	assert.Eventually(t, func() bool {
+		ok, err := node2.TryAcquire(ctx, movedKey)
+		require.NoError(t, err)
+		return ok
+	}, 5*time.Second, 50*time.Millisecond)
+	assert.GreaterOrEqual(t, time.Since(startTime), handoffTimeout)
+	d2.DebugLogger().AssertJSONMessages(t, fmt.Sprintf(`
+{"level":"info","message":"waiting for release of the key \"%s\" by the node \"node1\""}
+{"level":"warn","message":"the key \"%s\" has not been released by the node \"node1\" in 500ms, taking over"}
+`, movedKey, movedKey))
+
+	close(blockRelease)
+	d1.Process().Shutdown(ctx, errors.New("bye bye 1"))
+	d1.Process().WaitForShutdown()
+	d2.Process().Shutdown(ctx, errors.New("bye bye 2"))
+	d2.Process().WaitForShutdown()
+}
+
+func createHandoffNode(t *testing.T, ctx context.Context, etcdCfg etcdclient.Config, nodeID string, handoffTimeout time.Duration) (*distribution.GroupNode, dependencies.Mocked) {
+	t.Helper()
+	d := createDeps(t, ctx, clock.New(), nil, etcdCfg)
+	cfg := distribution.NewConfig()
+	cfg.StartupTimeout = time.Second
+	cfg.ShutdownTimeout = time.Second
+	cfg.EventsGroupInterval = 10 * time.Millisecond
+	cfg.HandoffTimeout = handoffTimeout
+	groupNode, err := distribution.NewNode(nodeID, cfg, d).Group("my-group")
+	require.NoError(t, err)
+	return groupNode, d
+}
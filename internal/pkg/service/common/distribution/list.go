@@ -0,0 +1,53 @@
+package distribution
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	etcd "go.etcd.io/etcd/client/v3"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop/iterator"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop/op"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+const GroupsEtcdPrefix = etcdop.Prefix("runtime/distribution/group")
+
+// RegisteredNode is a node registered in a distribution group, see ListNodes.
+type RegisteredNode struct {
+	Group  string `json:"group"`
+	NodeID string `json:"nodeId"`
+	Weight int    `json:"weight"`
+}
+
+// ListNodes lists nodes registered in all distribution groups, sorted by the group and the node ID.
+// It doesn't register the caller into any group, so it can be used by administration tools.
+func ListNodes(ctx context.Context, client etcd.KV) (out []RegisteredNode, err error) {
+	err = GroupsEtcdPrefix.GetAll(client).Do(ctx).ForEach(func(kv *op.KeyValue, _ *iterator.Header) error {
+		// Key format: <group>/nodes/<node_id>, other keys, for example the owners of keys, are skipped
+		relative := strings.TrimPrefix(string(kv.Key), GroupsEtcdPrefix.Prefix())
+		group, nodeID, found := strings.Cut(relative, "/nodes/")
+		if !found || strings.Contains(nodeID, "/") {
+			return nil
+		}
+
+		reg, err := decodeRegistration(kv.Value)
+		if err != nil {
+			return errors.PrefixErrorf(err, `invalid registration "%s"`, kv.Key)
+		}
+
+		out = append(out, RegisteredNode{Group: group, NodeID: reg.NodeID, Weight: reg.Weight})
+		return nil
+	})
+
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Group != out[j].Group {
+			return out[i].Group < out[j].Group
+		}
+		return out[i].NodeID < out[j].NodeID
+	})
+
+	return out, err
+}
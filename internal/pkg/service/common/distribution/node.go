@@ -1,6 +1,7 @@
 package distribution
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"sync"
@@ -11,6 +12,7 @@ import (
 	"go.etcd.io/etcd/client/v3/concurrency"
 	"go.opentelemetry.io/otel/attribute"
 
+	"github.com/keboola/keboola-as-code/internal/pkg/encoding/json"
 	"github.com/keboola/keboola-as-code/internal/pkg/log"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/ctxattr"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop"
@@ -21,9 +23,11 @@ import (
 // Node is factory for GroupNode.
 type Node struct {
 	id           string
-	config       Config
 	dependencies dependencies
-	groups       map[string]*GroupNode
+
+	lock   *sync.Mutex
+	config Config
+	groups map[string]*GroupNode
 }
 
 // GroupNode is created within each node in the group.
@@ -34,6 +38,7 @@ type Node struct {
 // - Discovery of the self and other nodes in the cluster, see watch method.
 // - Embedded Assigner locally assigns an owner for a key, see documentation of the Assigner.
 // - Embedded listeners listen for distribution changes, when a node is added or removed.
+// - Graceful hand-off of acquired keys between nodes, see Acquire and OnRelease methods.
 type GroupNode struct {
 	*assigner
 	logger      log.Logger
@@ -41,6 +46,19 @@ type GroupNode struct {
 	client      *etcd.Client
 	groupPrefix etcdop.Prefix
 	listeners   *listeners
+	handoff     *handoff
+
+	lock    *sync.Mutex
+	session *concurrency.Session
+	weight  int
+}
+
+// nodeRegistration is value of the node key in etcd.
+// The value is the plain node ID, if the node has the default weight 1, so it is compatible with older nodes.
+// Otherwise, the value is JSON encoded nodeRegistration.
+type nodeRegistration struct {
+	NodeID string `json:"nodeId"`
+	Weight int    `json:"weight"`
 }
 
 type assigner = Assigner
@@ -56,10 +74,13 @@ func NewNode(nodeID string, cfg Config, d dependencies) *Node {
 	if nodeID == "" {
 		panic(errors.New("distribution.Node: node ID cannot be empty"))
 	}
-	return &Node{id: nodeID, config: cfg, dependencies: d, groups: make(map[string]*GroupNode)}
+	return &Node{id: nodeID, dependencies: d, lock: &sync.Mutex{}, config: cfg, groups: make(map[string]*GroupNode)}
 }
 
 func (n *Node) Group(group string) (*GroupNode, error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
 	if _, ok := n.groups[group]; ok {
 		return nil, errors.Errorf(`group "%s" has already been initialized`, group)
 	}
@@ -91,16 +112,20 @@ func newGroupMember(nodeID, groupID string, cfg Config, d dependencies) (*GroupN
 		logger:      d.Logger().WithComponent("distribution"),
 		config:      cfg,
 		client:      d.EtcdClient(),
-		groupPrefix: etcdop.NewPrefix(fmt.Sprintf("runtime/distribution/group/%s/nodes", groupID)),
+		groupPrefix: GroupsEtcdPrefix.Add(groupID).Add("nodes"),
+		lock:        &sync.Mutex{},
+		weight:      cfg.Weight,
 	}
 
 	// Graceful shutdown
 	watchCtx, watchCancel := context.WithCancel(ctx)
 	sessionCtx, sessionCancel := context.WithCancel(ctx)
 	wg := &sync.WaitGroup{}
+	g.handoff = newHandoff(d.Clock(), g.assigner, g.logger, cfg, g.client, groupID, wg)
 	d.Process().OnShutdown(func(ctx context.Context) {
 		g.logger.Info(ctx, "received shutdown request")
 		watchCancel()
+		g.handoff.releaseAll(ctx)
 		g.unregister(ctx, cfg.ShutdownTimeout)
 		sessionCancel()
 		wg.Wait()
@@ -146,6 +171,51 @@ func (n *GroupNode) CloneAssigner() *Assigner {
 	return n.assigner.clone()
 }
 
+// UpdateWeight changes weight of the node in all groups, it is called on the configuration reload.
+// Groups created later use the new weight.
+func (n *Node) UpdateWeight(ctx context.Context, weight int) error {
+	if weight < 1 {
+		return errors.Errorf(`weight of the node "%s" must be at least 1, found %d`, n.id, weight)
+	}
+
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.config.Weight = weight
+	errs := errors.NewMultiError()
+	for _, g := range n.groups {
+		if err := g.UpdateWeight(ctx, weight); err != nil {
+			errs.Append(err)
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+// UpdateWeight changes weight of the node, for example according to the actual load of the node.
+// The change is propagated to all nodes in the group, the keys are re-assigned.
+// The weight must be at least 1, so the ring is never empty.
+func (n *GroupNode) UpdateWeight(ctx context.Context, weight int) error {
+	if weight < 1 {
+		return errors.Errorf(`weight of the node "%s" must be at least 1, found %d`, n.nodeID, weight)
+	}
+
+	n.lock.Lock()
+	n.weight = weight
+	session := n.session
+	n.lock.Unlock()
+
+	// The node is not registered yet, the weight is used by the registration
+	if session == nil {
+		return nil
+	}
+
+	key := n.groupPrefix.Key(n.nodeID)
+	if err := key.Put(n.client, n.registrationValue(), etcd.WithLease(session.Lease())).Do(ctx).Err(); err != nil {
+		return errors.Errorf(`cannot update weight of the node "%s": %w`, n.nodeID, err)
+	}
+	return nil
+}
+
 // register node in the etcd prefix,
 // Un-registration is ensured double: by OnShutdown callback and by the lease.
 func (n *GroupNode) register(session *concurrency.Session) error {
@@ -157,12 +227,17 @@ func (n *GroupNode) register(session *concurrency.Session) error {
 	startTime := time.Now()
 	n.logger.Infof(ctx, `registering the node "%s"`, n.nodeID)
 
+	n.lock.Lock()
+	n.session = session
+	n.lock.Unlock()
+
 	key := n.groupPrefix.Key(n.nodeID)
-	if err := key.Put(session.Client(), n.nodeID, etcd.WithLease(session.Lease())).Do(ctx).Err(); err != nil {
+	if err := key.Put(session.Client(), n.registrationValue(), etcd.WithLease(session.Lease())).Do(ctx).Err(); err != nil {
 		return errors.Errorf(`cannot register the node "%s": %w`, n.nodeID, err)
 	}
 
 	n.logger.WithDuration(time.Since(startTime)).Infof(ctx, `the node "%s" registered`, n.nodeID)
+	n.handoff.setSession(session)
 	return nil
 }
 
@@ -190,6 +265,7 @@ func (n *GroupNode) watch(ctx context.Context, wg *sync.WaitGroup) error {
 		WithForEach(func(events []etcdop.WatchEvent[[]byte], _ *etcdop.Header, restart bool) {
 			modifiedNodes := n.updateNodesFrom(ctx, events, restart)
 			n.listeners.Notify(modifiedNodes)
+			n.handoff.releaseLost(ctx)
 		}).
 		BuildConsumer()
 
@@ -219,13 +295,30 @@ func (n *GroupNode) updateNodesFrom(ctx context.Context, events []etcdop.WatchEv
 	for _, rawEvent := range events {
 		switch rawEvent.Type {
 		case etcdop.CreateEvent, etcdop.UpdateEvent:
-			nodeID := string(rawEvent.Kv.Value)
-			event := Event{Type: EventNodeAdded, NodeID: nodeID, Message: fmt.Sprintf(`found a new node "%s"`, nodeID)}
+			reg, err := decodeRegistration(rawEvent.Kv.Value)
+			if err != nil {
+				n.logger.Errorf(ctx, `cannot decode the node registration "%s": %s`, rawEvent.Kv.Key, err)
+				continue
+			}
+			nodeID := reg.NodeID
+			var event Event
+			if weight, found := n.assigner.nodes.weight(nodeID); !found {
+				event = Event{Type: EventNodeAdded, NodeID: nodeID, Message: fmt.Sprintf(`found a new node "%s"`, nodeID)}
+			} else if weight != reg.Weight {
+				event = Event{Type: EventNodeUpdated, NodeID: nodeID, Message: fmt.Sprintf(`the node "%s" weight changed from %d to %d`, nodeID, weight, reg.Weight)}
+			} else {
+				continue
+			}
 			out = append(out, event)
-			n.assigner.addNode(nodeID)
+			n.assigner.setNode(nodeID, reg.Weight)
 			n.logger.Infof(ctx, event.Message)
 		case etcdop.DeleteEvent:
-			nodeID := string(rawEvent.PrevKv.Value)
+			reg, err := decodeRegistration(rawEvent.PrevKv.Value)
+			if err != nil {
+				n.logger.Errorf(ctx, `cannot decode the node registration "%s": %s`, rawEvent.PrevKv.Key, err)
+				continue
+			}
+			nodeID := reg.NodeID
 			event := Event{Type: EventNodeRemoved, NodeID: nodeID, Message: fmt.Sprintf(`the node "%s" gone`, nodeID)}
 			out = append(out, event)
 			n.assigner.removeNode(nodeID)
@@ -236,3 +329,28 @@ func (n *GroupNode) updateNodesFrom(ctx context.Context, events []etcdop.WatchEv
 	}
 	return out
 }
+
+func (n *GroupNode) registrationValue() string {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if n.weight == 1 {
+		return n.nodeID
+	}
+	return json.MustEncodeString(nodeRegistration{NodeID: n.nodeID, Weight: n.weight}, false)
+}
+
+func decodeRegistration(value []byte) (nodeRegistration, error) {
+	// Plain node ID, the node has the default weight
+	if !bytes.HasPrefix(value, []byte("{")) {
+		return nodeRegistration{NodeID: string(value), Weight: 1}, nil
+	}
+
+	var reg nodeRegistration
+	if err := json.Decode(value, &reg); err != nil {
+		return nodeRegistration{}, err
+	}
+	if reg.NodeID == "" {
+		return nodeRegistration{}, errors.New("node ID is empty")
+	}
+	return reg, nil
+}
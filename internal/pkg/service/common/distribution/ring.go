@@ -0,0 +1,132 @@
+package distribution
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sort"
+
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+// replicationFactor is number of virtual nodes in the ring per one unit of the node weight.
+const replicationFactor = 10
+
+// ErrNoNodes may occur if there is no node in the list.
+var ErrNoNodes = errors.New("no hosts added") // nolint: gochecknoglobals
+
+// ring is a weighted hash ring, the number of virtual nodes of a node is proportional to its weight.
+//
+// The hash function and the virtual nodes naming are compatible with the "lafikl/consistent" library,
+// so the assignment of nodes with the default weight 1 is the same as in the library.
+//
+// If virtual nodes of two nodes have the same hash, the point is owned by the node with the lower ID,
+// so the ring doesn't depend on the order in which the nodes were added or removed.
+type ring struct {
+	weights map[string]int
+	hashes  map[string][]uint64
+	points  map[uint64]string
+	sorted  []uint64
+}
+
+func newRing() *ring {
+	return &ring{
+		weights: make(map[string]int),
+		hashes:  make(map[string][]uint64),
+		points:  make(map[uint64]string),
+	}
+}
+
+// nodes returns IDs of all nodes, including nodes with zero weight.
+func (r *ring) nodes() []string {
+	out := make([]string, 0, len(r.weights))
+	for nodeID := range r.weights {
+		out = append(out, nodeID)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func (r *ring) weight(nodeID string) (int, bool) {
+	w, ok := r.weights[nodeID]
+	return w, ok
+}
+
+// set adds the node or updates its weight.
+func (r *ring) set(nodeID string, weight int) {
+	if w, ok := r.weights[nodeID]; ok && w == weight {
+		return
+	}
+
+	hashes := make([]uint64, 0, replicationFactor*weight)
+	for i := 0; i < replicationFactor*weight; i++ {
+		hashes = append(hashes, hashKey(fmt.Sprintf("%s%d", nodeID, i)))
+	}
+
+	r.weights[nodeID] = weight
+	r.hashes[nodeID] = hashes
+	r.build()
+}
+
+func (r *ring) remove(nodeID string) bool {
+	if _, ok := r.weights[nodeID]; !ok {
+		return false
+	}
+
+	delete(r.weights, nodeID)
+	delete(r.hashes, nodeID)
+	r.build()
+	return true
+}
+
+// get returns ID of the key's owner node.
+func (r *ring) get(key string) (string, error) {
+	if len(r.sorted) == 0 {
+		return "", ErrNoNodes
+	}
+	return r.points[r.sorted[r.search(hashKey(key))]], nil
+}
+
+func (r *ring) search(h uint64) int {
+	idx := sort.Search(len(r.sorted), func(i int) bool {
+		return r.sorted[i] >= h
+	})
+	if idx >= len(r.sorted) {
+		idx = 0
+	}
+	return idx
+}
+
+// build maps virtual nodes to nodes and sorts them, a collision is resolved in favor of the lower node ID.
+func (r *ring) build() {
+	r.points = make(map[uint64]string)
+	for nodeID, hashes := range r.hashes {
+		for _, h := range hashes {
+			if owner, found := r.points[h]; !found || nodeID < owner {
+				r.points[h] = nodeID
+			}
+		}
+	}
+
+	r.sorted = r.sorted[:0]
+	for h := range r.points {
+		r.sorted = append(r.sorted, h)
+	}
+	sort.Slice(r.sorted, func(i, j int) bool { return r.sorted[i] < r.sorted[j] })
+}
+
+func (r *ring) clone() *ring {
+	clone := newRing()
+	for nodeID, w := range r.weights {
+		clone.weights[nodeID] = w
+		clone.hashes[nodeID] = r.hashes[nodeID]
+	}
+	clone.build()
+	return clone
+}
+
+func hashKey(key string) uint64 {
+	out := blake2b.Sum512([]byte(key))
+	return binary.LittleEndian.Uint64(out[:])
+}
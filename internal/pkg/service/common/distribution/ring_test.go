@@ -0,0 +1,93 @@
+package distribution
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/lafikl/consistent"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRing_CompatibleWithLib tests that nodes with the default weight are assigned in the same way as by the "consistent" library.
+func TestRing_CompatibleWithLib(t *testing.T) {
+	t.Parallel()
+
+	lib := consistent.New()
+	r := newRing()
+	for i := 1; i <= 5; i++ {
+		lib.Add(fmt.Sprintf("node%d", i))
+		r.set(fmt.Sprintf("node%d", i), 1)
+	}
+
+	for i := 1; i <= 1000; i++ {
+		key := fmt.Sprintf("foo%02d", i)
+		expected, err := lib.Get(key)
+		require.NoError(t, err)
+		actual, err := r.get(key)
+		require.NoError(t, err)
+		assert.Equal(t, expected, actual, key)
+	}
+}
+
+func TestRing_Weighted(t *testing.T) {
+	t.Parallel()
+
+	r := newRing()
+
+	// Test no node
+	_, err := r.get("foo")
+	assert.Equal(t, ErrNoNodes, err)
+
+	// Add nodes
+	r.set("node1", 1)
+	r.set("node2", 3)
+	r.set("node3", 0)
+	assert.Equal(t, []string{"node1", "node2", "node3"}, r.nodes())
+
+	keysPerNode := make(map[string]int)
+	for i := 1; i <= 1000; i++ {
+		node, err := r.get(fmt.Sprintf("foo%02d", i))
+		require.NoError(t, err)
+		keysPerNode[node]++
+	}
+	// The node3 with zero weight has no key
+	assert.Equal(t, map[string]int{"node1": 349, "node2": 651}, keysPerNode)
+
+	// Update weight
+	r.set("node2", 1)
+	r.set("node3", 2)
+	keysPerNode = make(map[string]int)
+	for i := 1; i <= 1000; i++ {
+		node, err := r.get(fmt.Sprintf("foo%02d", i))
+		require.NoError(t, err)
+		keysPerNode[node]++
+	}
+	assert.Equal(t, map[string]int{"node1": 285, "node2": 225, "node3": 490}, keysPerNode)
+
+	// All nodes have zero weight
+	r.set("node1", 0)
+	r.set("node2", 0)
+	r.set("node3", 0)
+	_, err = r.get("foo")
+	assert.Equal(t, ErrNoNodes, err)
+}
+
+func TestRing_Collision(t *testing.T) {
+	t.Parallel()
+
+	// Simulate collision of the virtual nodes, the point 2 is owned by the node with the lower ID
+	r := newRing()
+	r.weights = map[string]int{"node1": 1, "node2": 1}
+	r.hashes = map[string][]uint64{"node2": {1, 2}, "node1": {2, 3}}
+	r.build()
+	assert.Equal(t, map[uint64]string{1: "node2", 2: "node1", 3: "node1"}, r.points)
+	assert.Equal(t, []uint64{1, 2, 3}, r.sorted)
+
+	// The point is not lost on removal of the node
+	delete(r.weights, "node1")
+	delete(r.hashes, "node1")
+	r.build()
+	assert.Equal(t, map[uint64]string{1: "node2", 2: "node2"}, r.points)
+	assert.Equal(t, []uint64{1, 2}, r.sorted)
+}
@@ -22,7 +22,19 @@ type Config struct {
 //   - Writing of the help message to STDOUT.
 //   - Dumping of the configuration in YAML or JSON format to STDERR.
 func Run[C any](runFn func(ctx context.Context, config C, posArgs []string) error, appConfig C, runConfig Config) {
-	if err := runOrErr(runFn, appConfig, runConfig); err != nil {
+	exitOnError(runOrErr(runFn, appConfig, runConfig))
+}
+
+// RunWithReload is similar to the Run method, but the runFn gets configmap.Reloader instead of the configuration.
+// The configuration is reloaded from the same flags, ENVs and configuration files as at startup,
+// each reload starts from the configuration created by the newConfig function.
+// Watching for reload triggers must be started by the runFn, see the configmap.Reloader.Watch method.
+func RunWithReload[C any](runFn func(ctx context.Context, reloader *configmap.Reloader[C], posArgs []string) error, newConfig func() C, runConfig Config) {
+	exitOnError(runWithReloadOrErr(runFn, newConfig, runConfig))
+}
+
+func exitOnError(err error) {
+	if err != nil {
 		errMsg := errors.Format(errors.PrefixError(err, "Error"), errors.FormatAsSentences())
 		_, _ = os.Stderr.WriteString(errMsg)
 		_, _ = os.Stderr.WriteString("\n")
@@ -31,23 +43,60 @@ func Run[C any](runFn func(ctx context.Context, config C, posArgs []string) erro
 }
 
 func runOrErr[C any](runFn func(ctx context.Context, config C, posArgs []string) error, appConfig C, runConfig Config) error {
+	posArgs, _, done, err := bind(&appConfig, runConfig)
+	if done || err != nil {
+		return err
+	}
+
+	// Run
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	return runFn(ctx, appConfig, posArgs)
+}
+
+func runWithReloadOrErr[C any](runFn func(ctx context.Context, reloader *configmap.Reloader[C], posArgs []string) error, newConfig func() C, runConfig Config) error {
+	appConfig := newConfig()
+	posArgs, configFiles, done, err := bind(&appConfig, runConfig)
+	if done || err != nil {
+		return err
+	}
+
+	// Reload from the same sources
+	load := func() (C, error) {
+		cfg := newConfig()
+		if _, _, _, err := bind(&cfg, runConfig); err != nil {
+			return cfg, err
+		}
+		return cfg, nil
+	}
+
+	// Run
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	return runFn(ctx, configmap.NewReloader(appConfig, load, configFiles...), posArgs)
+}
+
+// bind flags, ENVs and config files to configuration structure.
+// The done flag is true, if the configuration has been dumped, and the runFn should not be called.
+func bind[C any](appConfig *C, runConfig Config) (posArgs []string, configFiles []string, done bool, err error) {
 	// Load ENVs
 	envs, err := env.FromOs()
 	if err != nil {
-		return errors.Errorf("cannot load OS envs: %w", err)
+		return nil, nil, false, errors.Errorf("cannot load OS envs: %w", err)
 	}
 
-	// Bind flags, ENVs and config files to configuration structure
-	var posArgs []string // store remaining positional arguments
 	err = configmap.GenerateAndBind(configmap.GenerateAndBindConfig{
 		Args:                   os.Args,
 		EnvNaming:              env.NewNamingConvention(runConfig.ENVPrefix),
 		Envs:                   envs,
 		PositionalArgsTarget:   &posArgs,
+		ConfigFilesTarget:      &configFiles,
 		GenerateHelpFlag:       true,
 		GenerateConfigFileFlag: true,
 		GenerateDumpConfigFlag: true,
-	}, &appConfig)
+	}, appConfig)
 
 	// Print help
 	var helpErr configmap.HelpError
@@ -61,17 +110,13 @@ func runOrErr[C any](runFn func(ctx context.Context, config C, posArgs []string)
 	if errors.As(err, &dumpErr) {
 		_, _ = os.Stderr.WriteString("Writing configuration dump.\n\n")
 		fmt.Println(string(dumpErr.Dump))
-		return dumpErr.ValidationError
+		return nil, nil, true, dumpErr.ValidationError
 	}
 
 	// Handle other errors
 	if err != nil {
-		return err
+		return nil, nil, false, err
 	}
 
-	// Run
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-
-	return runFn(ctx, appConfig, posArgs)
+	return posArgs, configFiles, false, nil
 }
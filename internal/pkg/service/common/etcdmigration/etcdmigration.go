@@ -0,0 +1,56 @@
+// Package etcdmigration provides background migration of etcd values to the latest schema version on the service startup.
+// Each prefix is migrated by one node only, the owner of the prefix in the distribution group.
+// See etcdop.PrefixT.MigrateAll and serde.RegisterMigrations for details.
+package etcdmigration
+
+import (
+	"context"
+	"sync"
+
+	etcd "go.etcd.io/etcd/client/v3"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/distribution"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/servicectx"
+)
+
+const DistributionGroup = "etcd.migration"
+
+type dependencies interface {
+	Logger() log.Logger
+	Process() *servicectx.Process
+	EtcdClient() *etcd.Client
+	DistributionNode() *distribution.Node
+}
+
+// Start migrates values under the prefixes in background, the method doesn't wait for the migration.
+func Start(d dependencies, prefixes ...etcdop.MigratablePrefix) error {
+	logger := d.Logger().WithComponent("etcd.migration")
+
+	dist, err := d.DistributionNode().Group(DistributionGroup)
+	if err != nil {
+		return err
+	}
+
+	// Graceful shutdown
+	ctx, cancel := context.WithCancel(context.Background()) // nolint: contextcheck
+	wg := &sync.WaitGroup{}
+	d.Process().OnShutdown(func(ctx context.Context) {
+		logger.Info(ctx, "received shutdown request")
+		cancel()
+		wg.Wait()
+		logger.Info(ctx, "shutdown done")
+	})
+
+	// Only one node in the cluster should migrate the prefix
+	for _, prefix := range prefixes {
+		if owner, err := dist.IsOwner(prefix.Prefix()); err != nil {
+			logger.Errorf(ctx, `cannot check owner of the prefix "%s": %s`, prefix.Prefix(), err)
+		} else if owner {
+			prefix.MigrateAllInBackground(ctx, wg, logger, d.EtcdClient(), etcdop.DefaultMigrationBatchSize)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package etcdmigration_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	etcd "go.etcd.io/etcd/client/v3"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/dependencies"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/distribution"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdclient"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdmigration"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop/serde"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/etcdhelper"
+)
+
+type testValue struct {
+	FullName string `json:"fullName"`
+}
+
+type testDeps struct {
+	dependencies.Mocked
+	dependencies.DistributionScope
+}
+
+func TestStart(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	etcdCfg := etcdhelper.TmpNamespace(t)
+	client := etcdhelper.ClientForTest(t, etcdCfg)
+
+	// The version 2 renamed the "name" field to the "fullName"
+	s := serde.NewJSON(serde.NoValidation)
+	serde.RegisterMigrations[testValue](s, serde.Migration{
+		Version: 2,
+		Migrate: func(ctx context.Context, value map[string]any) error {
+			value["fullName"] = strings.ToUpper(value["name"].(string))
+			delete(value, "name")
+			return nil
+		},
+	})
+
+	// Old values under multiple prefixes
+	var prefixes []etcdop.MigratablePrefix
+	for i := 1; i <= 6; i++ {
+		prefix := etcdop.NewTypedPrefix[testValue](etcdop.NewPrefix(fmt.Sprintf("my/prefix%d", i)), s)
+		prefixes = append(prefixes, prefix)
+		_, err := client.Put(ctx, prefix.Prefix()+"key", `{"name":"foo"}`)
+		require.NoError(t, err)
+	}
+
+	// The node1 is already in the group, it doesn't start the migration
+	d1 := createDeps(t, ctx, etcdCfg, "node1")
+	node1, err := d1.DistributionNode().Group(etcdmigration.DistributionGroup)
+	require.NoError(t, err)
+
+	// The node2 migrates only prefixes it owns
+	d2 := createDeps(t, ctx, etcdCfg, "node2")
+	require.NoError(t, etcdmigration.Start(d2, prefixes...))
+	var migrated, skipped int
+	for _, prefix := range prefixes {
+		if node1.MustCheckIsOwner(prefix.Prefix()) {
+			skipped++
+			assert.Never(t, func() bool { return isMigrated(t, ctx, client, prefix) }, 200*time.Millisecond, 10*time.Millisecond)
+		} else {
+			migrated++
+			assert.Eventually(t, func() bool { return isMigrated(t, ctx, client, prefix) }, 5*time.Second, 10*time.Millisecond)
+		}
+	}
+	assert.Positive(t, migrated)
+	assert.Positive(t, skipped)
+
+	d1.Process().Shutdown(ctx, errors.New("bye bye 1"))
+	d1.Process().WaitForShutdown()
+	d2.Process().Shutdown(ctx, errors.New("bye bye 2"))
+	d2.Process().WaitForShutdown()
+}
+
+func isMigrated(t *testing.T, ctx context.Context, client *etcd.Client, prefix etcdop.MigratablePrefix) bool {
+	t.Helper()
+	resp, err := client.Get(ctx, prefix.Prefix()+"key")
+	require.NoError(t, err)
+	require.Len(t, resp.Kvs, 1)
+	return strings.HasPrefix(string(resp.Kvs[0].Value), `{"$schemaVersion":2`)
+}
+
+func createDeps(t *testing.T, ctx context.Context, etcdCfg etcdclient.Config, nodeID string) *testDeps {
+	t.Helper()
+	mock := dependencies.NewMocked(t, ctx, dependencies.WithEtcdConfig(etcdCfg))
+	cfg := distribution.NewConfig()
+	cfg.StartupTimeout = time.Second
+	cfg.ShutdownTimeout = time.Second
+	return &testDeps{Mocked: mock, DistributionScope: dependencies.NewDistributionScope(nodeID, cfg, mock)}
+}
@@ -0,0 +1,137 @@
+package etcdop
+
+import (
+	"context"
+	"sync"
+
+	etcd "go.etcd.io/etcd/client/v3"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop/op"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+const DefaultMigrationBatchSize = 100
+
+// MigratablePrefix is a typed prefix, whose values can be migrated to the latest schema version, see PrefixT.MigrateAll.
+type MigratablePrefix interface {
+	Prefix() string
+	MigrateAllInBackground(ctx context.Context, wg *sync.WaitGroup, logger log.Logger, client etcd.KV, batchSize int)
+}
+
+// MigrateAll rewrites all outdated values under the prefix to the latest schema version, see serde.RegisterMigrations.
+//
+// Outdated keys are rewritten in batches, each batch is an op.AtomicOp,
+// so a value modified concurrently is read again, and it is not overwritten by an old state.
+// Lease of a key is preserved.
+func (v PrefixT[T]) MigrateAll(ctx context.Context, client etcd.KV, batchSize int) (migrated int, err error) {
+	if batchSize <= 0 {
+		batchSize = DefaultMigrationBatchSize
+	}
+
+	// There is nothing to migrate, if the type has no migrations
+	var zero T
+	if v.serde.LatestVersion(&zero) == 1 {
+		return 0, nil
+	}
+
+	// Find outdated keys
+	var outdated []Key
+	itr := v.prefix.GetAll(client).Do(ctx)
+	for itr.Next() {
+		kv := itr.Value()
+		if ok, err := v.serde.IsOutdated(kv.Value, &zero); err != nil {
+			return 0, errors.Errorf(`cannot migrate "%s": %w`, kv.Key, err)
+		} else if ok {
+			outdated = append(outdated, Key(kv.Key))
+		}
+	}
+	if err := itr.Err(); err != nil {
+		return 0, err
+	}
+
+	// Rewrite outdated keys in batches
+	for start := 0; start < len(outdated); start += batchSize {
+		batch := outdated[start:min(start+batchSize, len(outdated))]
+		count, err := v.migrateBatch(ctx, client, batch)
+		migrated += count
+		if err != nil {
+			return migrated, err
+		}
+	}
+
+	return migrated, nil
+}
+
+// MigrateAllInBackground runs the MigrateAll method in background, the result is logged.
+// Nothing is started, if the type has no migrations.
+func (v PrefixT[T]) MigrateAllInBackground(ctx context.Context, wg *sync.WaitGroup, logger log.Logger, client etcd.KV, batchSize int) {
+	var zero T
+	if v.serde.LatestVersion(&zero) == 1 {
+		return
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		logger.Infof(ctx, `migrating values under the prefix "%s"`, v.Prefix())
+		migrated, err := v.MigrateAll(ctx, client, batchSize)
+		if err != nil {
+			logger.Errorf(ctx, `cannot migrate values under the prefix "%s", migrated %d values: %s`, v.Prefix(), migrated, err)
+			return
+		}
+		logger.Infof(ctx, `migrated %d values under the prefix "%s"`, migrated, v.Prefix())
+	}()
+}
+
+func (v PrefixT[T]) migrateBatch(ctx context.Context, client etcd.KV, keys []Key) (int, error) {
+	var zero T
+	var migrated int
+	kvs := make([]*op.KeyValue, len(keys))
+
+	atomicOp := op.Atomic(client, &migrated).
+		Read(func(ctx context.Context) op.Op {
+			txn := op.Txn(client)
+			for i, key := range keys {
+				txn.Then(key.Get(client).WithOnResult(func(kv *op.KeyValue) {
+					kvs[i] = kv
+				}))
+			}
+			return txn
+		}).
+		Write(func(ctx context.Context) op.Op {
+			migrated = 0
+			txn := op.Txn(client)
+			for _, kv := range kvs {
+				// The key has been deleted or migrated in the meantime
+				if kv == nil {
+					continue
+				}
+				if ok, err := v.serde.IsOutdated(kv.Value, &zero); err != nil {
+					return op.ErrorOp(errors.Errorf(`cannot migrate "%s": %w`, kv.Key, err))
+				} else if !ok {
+					continue
+				}
+
+				// Decode performs the upgrade, encode writes the latest version
+				value := new(T)
+				if err := v.serde.Decode(ctx, kv, value); err != nil {
+					return op.ErrorOp(errors.Errorf(`cannot migrate "%s": %w`, kv.Key, err))
+				}
+				encoded, err := v.serde.Encode(ctx, value)
+				if err != nil {
+					return op.ErrorOp(errors.Errorf(`cannot migrate "%s": %w`, kv.Key, err))
+				}
+
+				var opts []etcd.OpOption
+				if kv.Lease != 0 {
+					opts = append(opts, etcd.WithLease(etcd.LeaseID(kv.Lease)))
+				}
+				txn.Then(Key(kv.Key).Put(client, encoded, opts...))
+				migrated++
+			}
+			return txn
+		})
+
+	return atomicOp.Do(ctx).ResultOrErr()
+}
@@ -0,0 +1,95 @@
+package etcdop
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	etcd "go.etcd.io/etcd/client/v3"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop/serde"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/etcdhelper"
+)
+
+type migratedValue struct {
+	FullName string `json:"fullName"`
+}
+
+func TestPrefixT_MigrateAll(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := etcdhelper.ClientForTest(t, etcdhelper.TmpNamespace(t))
+
+	// The version 2 renamed the "name" field to the "fullName"
+	s := serde.NewJSON(serde.NoValidation)
+	serde.RegisterMigrations[migratedValue](s, serde.Migration{
+		Version: 2,
+		Migrate: func(ctx context.Context, value map[string]any) error {
+			value["fullName"] = strings.ToUpper(value["name"].(string))
+			delete(value, "name")
+			return nil
+		},
+	})
+	pfx := NewTypedPrefix[migratedValue]("my/prefix", s)
+
+	// Old values, one is bound to a lease
+	lease, err := client.Grant(ctx, 60)
+	require.NoError(t, err)
+	require.NoError(t, pfx.Key("key1").key.Put(client, `{"name":"foo"}`).Do(ctx).Err())
+	require.NoError(t, pfx.Key("key2").key.Put(client, `{"name":"bar"}`, etcd.WithLease(lease.ID)).Do(ctx).Err())
+	require.NoError(t, pfx.Key("key3").Put(client, migratedValue{FullName: "BAZ"}).Do(ctx).Err())
+
+	// Old values are upgraded on decode
+	value, err := pfx.Key("key1").GetOrErr(client).Do(ctx).ResultOrErr()
+	require.NoError(t, err)
+	assert.Equal(t, migratedValue{FullName: "FOO"}, value)
+
+	// Rewrite outdated values
+	migrated, err := pfx.MigrateAll(ctx, client, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, migrated)
+
+	// All values are in the latest version, the lease is kept
+	etcdhelper.AssertKVsString(t, client, `
+<<<<<
+my/prefix/key1
+-----
+{
+  "$schemaVersion": 2,
+  "$value": {
+    "fullName": "FOO"
+  }
+}
+>>>>>
+
+<<<<<
+my/prefix/key2 (lease)
+-----
+{
+  "$schemaVersion": 2,
+  "$value": {
+    "fullName": "BAR"
+  }
+}
+>>>>>
+
+<<<<<
+my/prefix/key3
+-----
+{
+  "$schemaVersion": 2,
+  "$value": {
+    "fullName": "BAZ"
+  }
+}
+>>>>>
+`)
+
+	// Nothing to migrate
+	migrated, err = pfx.MigrateAll(ctx, client, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 0, migrated)
+}
@@ -0,0 +1,169 @@
+package serde
+
+import (
+	"bytes"
+	"context"
+	stdjson "encoding/json"
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/encoding/json"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+// envelopePrefix is used to detect a versioned value, the envelope is always encoded with the version first.
+const envelopePrefix = `{"$schemaVersion":`
+
+// Migration upgrades a value from the Version-1 to the Version.
+// The first migration has the Version 2, values without the envelope are considered to be in the version 1.
+type Migration struct {
+	Version int
+	Migrate MigrateFn
+}
+
+// MigrateFn modifies the JSON object in place.
+// Numbers are represented as json.Number, so big integers are not changed.
+type MigrateFn func(ctx context.Context, value map[string]any) error
+
+// envelope wraps a value of a type with registered migrations.
+type envelope struct {
+	Version int             `json:"$schemaVersion"`
+	Value   json.RawMessage `json:"$value"`
+}
+
+// migrations is a registry of migrations, per value type.
+type migrations struct {
+	// registered is true, if at least one type has migrations.
+	// If it is false, the encoding and decoding skip the lock and the reflection.
+	registered atomic.Bool
+	lock       *sync.RWMutex
+	byType     map[reflect.Type][]Migration
+}
+
+func newMigrations() *migrations {
+	return &migrations{lock: &sync.RWMutex{}, byType: make(map[reflect.Type][]Migration)}
+}
+
+// RegisterMigrations registers migrations of the type T, the latest version of T is 1 + number of migrations.
+//
+// Values of T are encoded in the versioned envelope {"$schemaVersion":<version>,"$value":<value>}.
+// Old values are upgraded to the latest version when decoded, see Serde.Decode.
+// Use etcdop.PrefixT.MigrateAll method to rewrite all values under a prefix to the latest version,
+// the Stream and Templates services rewrite their prefixes on startup, see the etcdmigration package.
+//
+// Migrations are supported only by the JSON Serde, see NewJSON.
+func RegisterMigrations[T any](s *Serde, items ...Migration) {
+	if !s.json {
+		panic(errors.New("migrations are supported only by the JSON serde"))
+	}
+	for i, m := range items {
+		if expected := i + 2; m.Version != expected {
+			panic(errors.Errorf(`migration version %d expected, found %d`, expected, m.Version))
+		}
+		if m.Migrate == nil {
+			panic(errors.Errorf(`migration fn of the version %d cannot be nil`, m.Version))
+		}
+	}
+
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	s.migrations.lock.Lock()
+	defer s.migrations.lock.Unlock()
+	if _, found := s.migrations.byType[t]; found {
+		panic(errors.Errorf(`migrations of the type "%s" are already registered`, t.String()))
+	}
+	s.migrations.byType[t] = items
+	s.migrations.registered.Store(true)
+}
+
+// LatestVersion returns the latest schema version of the value type.
+func (v Serde) LatestVersion(value any) int {
+	return len(v.migrations.get(value)) + 1
+}
+
+// IsOutdated returns true, if the encoded value is not in the latest schema version of the value type.
+func (v Serde) IsOutdated(data []byte, value any) (bool, error) {
+	items := v.migrations.get(value)
+	if len(items) == 0 {
+		return false, nil
+	}
+	version, _, err := unwrapEnvelope(data)
+	if err != nil {
+		return false, err
+	}
+	return version < len(items)+1, nil
+}
+
+func (m *migrations) get(value any) []Migration {
+	// Fast path, there is no migration
+	if !m.registered.Load() {
+		return nil
+	}
+
+	t := reflect.TypeOf(value)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.byType[t]
+}
+
+// wrap the encoded value to the envelope, if the value type has some migrations.
+func (m *migrations) wrap(value any, data string) (string, error) {
+	items := m.get(value)
+	if len(items) == 0 {
+		return data, nil
+	}
+	return json.EncodeString(envelope{Version: len(items) + 1, Value: json.RawMessage(data)}, false)
+}
+
+// upgrade the encoded value to the latest version of the value type, the envelope is removed.
+func (m *migrations) upgrade(ctx context.Context, data []byte, value any) ([]byte, error) {
+	items := m.get(value)
+	if len(items) == 0 {
+		return data, nil
+	}
+
+	version, data, err := unwrapEnvelope(data)
+	if err != nil {
+		return nil, err
+	}
+
+	latest := len(items) + 1
+	switch {
+	case version == latest:
+		return data, nil
+	case version > latest || version < 1:
+		return nil, errors.Errorf(`unexpected schema version %d, the latest known version is %d`, version, latest)
+	}
+
+	// Decode the old value, keep numbers as they are
+	object := make(map[string]any)
+	decoder := stdjson.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&object); err != nil {
+		return nil, errors.PrefixErrorf(err, `cannot decode value in the schema version %d`, version)
+	}
+
+	// Apply migrations
+	for _, item := range items[version-1:] {
+		if err := item.Migrate(ctx, object); err != nil {
+			return nil, errors.PrefixErrorf(err, `cannot migrate value to the schema version %d`, item.Version)
+		}
+	}
+
+	return json.Encode(object, false)
+}
+
+// unwrapEnvelope returns version and the wrapped value, a value without the envelope is in the version 1.
+func unwrapEnvelope(data []byte) (int, []byte, error) {
+	if !bytes.HasPrefix(data, []byte(envelopePrefix)) {
+		return 1, data, nil
+	}
+	var e envelope
+	if err := json.Decode(data, &e); err != nil {
+		return 0, nil, errors.PrefixError(err, "cannot decode versioned value")
+	}
+	return e.Version, e.Value, nil
+}
@@ -0,0 +1,120 @@
+package serde
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/encoding/json"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop/op"
+)
+
+type testValue struct {
+	ID    int64  `json:"id"`
+	Value string `json:"value"`
+}
+
+type otherValue struct {
+	Value string `json:"value"`
+}
+
+func TestSerde_Migrations(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := NewJSON(NoValidation)
+	RegisterMigrations[testValue](
+		s,
+		Migration{Version: 2, Migrate: func(ctx context.Context, value map[string]any) error {
+			// The "value" field has been added
+			value["value"] = "default"
+			return nil
+		}},
+		Migration{Version: 3, Migrate: func(ctx context.Context, value map[string]any) error {
+			value["value"] = value["value"].(string) + "-v3"
+			return nil
+		}},
+	)
+	assert.Equal(t, 3, s.LatestVersion(testValue{}))
+	assert.Equal(t, 3, s.LatestVersion(&testValue{}))
+	assert.Equal(t, 1, s.LatestVersion(otherValue{}))
+
+	// Encode, the value is wrapped in the envelope
+	encoded, err := s.Encode(ctx, testValue{ID: 1, Value: "foo"})
+	require.NoError(t, err)
+	assert.Equal(t, `{"$schemaVersion":3,"$value":{"id":1,"value":"foo"}}`, encoded)
+
+	// Value of a type without migrations is not wrapped
+	encoded, err = s.Encode(ctx, otherValue{Value: "foo"})
+	require.NoError(t, err)
+	assert.Equal(t, `{"value":"foo"}`, encoded)
+
+	// Decode the latest version
+	var value testValue
+	require.NoError(t, s.Decode(ctx, &op.KeyValue{Value: []byte(`{"$schemaVersion":3,"$value":{"id":1,"value":"foo"}}`)}, &value))
+	assert.Equal(t, testValue{ID: 1, Value: "foo"}, value)
+
+	// Decode the version 1 without envelope, big integer is not changed
+	value = testValue{}
+	require.NoError(t, s.Decode(ctx, &op.KeyValue{Value: []byte(`{"id":9007199254740993}`)}, &value))
+	assert.Equal(t, testValue{ID: 9007199254740993, Value: "default-v3"}, value)
+
+	// Decode the version 2
+	value = testValue{}
+	require.NoError(t, s.Decode(ctx, &op.KeyValue{Value: []byte(`{"$schemaVersion":2,"$value":{"id":2,"value":"bar"}}`)}, &value))
+	assert.Equal(t, testValue{ID: 2, Value: "bar-v3"}, value)
+
+	// Outdated check
+	outdated, err := s.IsOutdated([]byte(`{"id":1}`), &value)
+	require.NoError(t, err)
+	assert.True(t, outdated)
+	outdated, err = s.IsOutdated([]byte(json.MustEncodeString(envelope{Version: 3, Value: []byte(`{}`)}, false)), &value)
+	require.NoError(t, err)
+	assert.False(t, outdated)
+
+	// Unknown newer version
+	err = s.Decode(ctx, &op.KeyValue{Value: []byte(`{"$schemaVersion":4,"$value":{}}`)}, &value)
+	if assert.Error(t, err) {
+		assert.Equal(t, "unexpected schema version 4, the latest known version is 3", err.Error())
+	}
+}
+
+func TestSerde_NoMigrations(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := NewJSON(NoValidation)
+
+	// The value is not wrapped and not upgraded
+	encoded, err := s.Encode(ctx, testValue{ID: 1, Value: "foo"})
+	require.NoError(t, err)
+	assert.Equal(t, `{"id":1,"value":"foo"}`, encoded)
+	var value testValue
+	require.NoError(t, s.Decode(ctx, &op.KeyValue{Value: []byte(encoded)}, &value))
+	assert.Equal(t, testValue{ID: 1, Value: "foo"}, value)
+
+	// The registry lookup is skipped, there is no lock and reflection on the hot path
+	assert.False(t, s.migrations.registered.Load())
+	assert.Nil(t, s.migrations.get(&value))
+}
+
+func TestRegisterMigrations_Invalid(t *testing.T) {
+	t.Parallel()
+
+	s := NewJSON(NoValidation)
+	assert.Panics(t, func() {
+		RegisterMigrations[testValue](s, Migration{Version: 3, Migrate: func(ctx context.Context, value map[string]any) error { return nil }})
+	})
+
+	RegisterMigrations[testValue](s)
+	assert.Panics(t, func() {
+		RegisterMigrations[testValue](s)
+	})
+
+	custom := New(nil, nil, NoValidation)
+	assert.Panics(t, func() {
+		RegisterMigrations[testValue](custom)
+	})
+}
@@ -17,6 +17,10 @@ type Serde struct {
 	decode DecodeFn
 	// validate a value before encode and after decode operation.
 	validate ValidateFn
+	// json is true, if the value is encoded to JSON, it is required by migrations.
+	json bool
+	// migrations upgrade old values to the latest schema version, see RegisterMigrations.
+	migrations *migrations
 }
 
 type EncodeFn func(ctx context.Context, value any) (string, error)
@@ -30,14 +34,14 @@ func NoValidation(ctx context.Context, v any) error {
 }
 
 func New(encode EncodeFn, decode DecodeFn, validate ValidateFn) *Serde {
-	return &Serde{encode: encode, decode: decode, validate: validate}
+	return &Serde{encode: encode, decode: decode, validate: validate, migrations: newMigrations()}
 }
 
 func NewJSON(validate ValidateFn) *Serde {
 	if validate == nil {
 		panic(errors.New("validate fn cannot be nil, use serde.NoValidation"))
 	}
-	return New(
+	s := New(
 		func(ctx context.Context, value any) (string, error) {
 			return json.EncodeString(value, false)
 		},
@@ -46,17 +50,27 @@ func NewJSON(validate ValidateFn) *Serde {
 		},
 		validate,
 	)
+	s.json = true
+	return s
 }
 
 func (v Serde) Encode(ctx context.Context, value any) (string, error) {
 	if err := v.validate(ctx, value); err != nil {
 		return "", err
 	}
-	return v.encode(ctx, value)
+	data, err := v.encode(ctx, value)
+	if err != nil {
+		return "", err
+	}
+	return v.migrations.wrap(value, data)
 }
 
 func (v Serde) Decode(ctx context.Context, kv *op.KeyValue, target any) error {
-	if err := v.decode(ctx, kv.Value, target); err != nil {
+	data, err := v.migrations.upgrade(ctx, kv.Value, target)
+	if err != nil {
+		return err
+	}
+	if err := v.decode(ctx, data, target); err != nil {
 		return err
 	}
 	if err := v.validate(ctx, target); err != nil {
@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	goa "goa.design/goa/v3/pkg"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/audit"
+	svcErrors "github.com/keboola/keboola-as-code/internal/pkg/service/common/errors"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+// Audit registers middleware to record mutating endpoint calls to the audit log, see the "audit" package.
+// Read-only requests and requests without an authenticated identity are not recorded.
+func Audit(auditLog *audit.Log) GoaMiddleware {
+	return func(next goa.Endpoint) goa.Endpoint {
+		return func(ctx context.Context, req any) (any, error) {
+			httpReq, found := RequestValue(ctx)
+			if !auditLog.Enabled() || !found || !isMutatingMethod(httpReq.Method) {
+				return next(ctx, req)
+			}
+
+			// Process the request, the record is filled by the service
+			record := audit.NewRecord()
+			res, err := next(audit.ContextWith(ctx, record), req)
+			if !record.Authenticated() {
+				return res, err
+			}
+
+			serviceName, _ := ctx.Value(goa.ServiceKey).(string)
+			endpointName, _ := ctx.Value(goa.MethodKey).(string)
+			requestID, _ := ctx.Value(RequestIDCtxKey).(string)
+			entry := record.ToEntry(audit.Entry{
+				RequestID:  requestID,
+				Service:    serviceName,
+				Endpoint:   endpointName,
+				HTTPMethod: httpReq.Method,
+				HTTPPath:   httpReq.URL.Path,
+				Result:     auditResult(err),
+			})
+
+			// The request context can be cancelled, but the entry should be written
+			auditLog.Write(context.WithoutCancel(ctx), entry)
+			return res, err
+		}
+	}
+}
+
+func auditResult(err error) audit.Result {
+	if err == nil {
+		return audit.Result{Success: true}
+	}
+
+	result := audit.Result{StatusCode: svcErrors.HTTPCodeFrom(err), Error: err.Error()}
+	var nameProvider svcErrors.WithName
+	if errors.As(err, &nameProvider) {
+		result.ErrorName = nameProvider.ErrorName()
+	}
+	return result
+}
+
+func isMutatingMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	default:
+		return true
+	}
+}
@@ -0,0 +1,101 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/keboola/go-client/pkg/keboola"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	goa "goa.design/goa/v3/pkg"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/audit"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/dependencies"
+	svcErrors "github.com/keboola/keboola-as-code/internal/pkg/service/common/errors"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/httpserver/middleware"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+type memoryBackend struct {
+	lock    sync.Mutex
+	entries []audit.Entry
+}
+
+func (b *memoryBackend) Write(_ context.Context, entry audit.Entry) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.entries = append(b.entries, entry)
+	return nil
+}
+
+func (b *memoryBackend) List(_ context.Context, _ keboola.ProjectID, _ int) ([]audit.Entry, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.entries, nil
+}
+
+func TestAuditMiddleware(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	backend := &memoryBackend{}
+	auditLog := audit.NewWithBackend(dependencies.NewMocked(t, ctx), backend)
+
+	// Endpoint fills in the record as the service does
+	endpoint := middleware.Audit(auditLog)(func(ctx context.Context, req any) (any, error) {
+		if req == "anonymous" {
+			return nil, svcErrors.NewForbiddenError(errors.New("missing token"))
+		}
+
+		record := audit.FromContext(ctx)
+		record.SetIdentity(123, audit.By{Type: audit.ByUser, TokenID: "111"})
+		record.SetObjectKey("123/456/my-source")
+		record.SetAfter(audit.Summary{"name": "My Source"})
+		if req == "fail" {
+			return nil, svcErrors.NewResourceNotFoundError("source", "my-source", "branch")
+		}
+		return "ok", nil
+	})
+
+	call := func(method string, req any) {
+		httpReq := httptest.NewRequest(method, "/v1/branches/456/sources", nil)
+		reqCtx := context.WithValue(ctx, middleware.RequestCtxKey, httpReq)
+		reqCtx = context.WithValue(reqCtx, middleware.RequestIDCtxKey, "my-request")
+		reqCtx = context.WithValue(reqCtx, goa.ServiceKey, "stream")
+		reqCtx = context.WithValue(reqCtx, goa.MethodKey, "CreateSource")
+		_, _ = endpoint(reqCtx, req)
+	}
+
+	// Read-only and unauthenticated calls are not recorded
+	call(http.MethodGet, "ok")
+	call(http.MethodPost, "anonymous")
+	assert.Empty(t, backend.entries)
+
+	// Mutating calls are recorded with the result
+	call(http.MethodPost, "ok")
+	call(http.MethodPost, "fail")
+	require.Len(t, backend.entries, 2)
+
+	entry := backend.entries[0]
+	assert.NotEmpty(t, entry.ID)
+	assert.False(t, entry.Created.IsZero())
+	assert.Equal(t, keboola.ProjectID(123), entry.ProjectID)
+	assert.Equal(t, "my-request", entry.RequestID)
+	assert.Equal(t, "stream", entry.Service)
+	assert.Equal(t, "CreateSource", entry.Endpoint)
+	assert.Equal(t, http.MethodPost, entry.HTTPMethod)
+	assert.Equal(t, "/v1/branches/456/sources", entry.HTTPPath)
+	assert.Equal(t, audit.By{Type: audit.ByUser, TokenID: "111"}, entry.By)
+	assert.Equal(t, "123/456/my-source", entry.ObjectKey)
+	assert.Equal(t, audit.Summary{"name": "My Source"}, entry.After)
+	assert.Equal(t, audit.Result{Success: true}, entry.Result)
+
+	result := backend.entries[1].Result
+	assert.False(t, result.Success)
+	assert.Equal(t, http.StatusNotFound, result.StatusCode)
+	assert.Equal(t, "sourceNotFound", result.ErrorName)
+	assert.Contains(t, result.Error, `source "my-source" not found`)
+}
@@ -0,0 +1,144 @@
+package task
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop/serde"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/utctime"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+const (
+	CancelEtcdPrefix = "runtime/task/cancel"
+)
+
+// CancelRequest is written to etcd by the CancelTask method.
+// The node running the task watches the requests and cancels the task context.
+type CancelRequest struct {
+	Key
+	RequestedAt utctime.UTCTime `json:"requestedAt"`
+}
+
+// TaskFinishedError is returned by the CancelTask method, if the task is no longer running.
+type TaskFinishedError struct {
+	error
+}
+
+func (e TaskFinishedError) Unwrap() error {
+	return e.error
+}
+
+func (e TaskFinishedError) ErrorName() string {
+	return "taskFinished"
+}
+
+func (e TaskFinishedError) StatusCode() int {
+	return http.StatusBadRequest
+}
+
+// CancelledError is the result error of a task cancelled by the CancelTask method.
+type CancelledError struct {
+	error
+}
+
+func (e CancelledError) Unwrap() error {
+	return e.error
+}
+
+func (e CancelledError) ErrorName() string {
+	return "taskCancelled"
+}
+
+func (e CancelledError) ErrorUserMessage() string {
+	return "The task has been cancelled."
+}
+
+// errCancelRequested is the cause of the task context cancellation, if a cancel request has been received.
+var errCancelRequested = errors.New("cancel requested") // nolint: gochecknoglobals
+
+// CancelTask requests cancellation of the running task.
+// The request is propagated through etcd to the node running the task, the node cancels the task context.
+// The task operation should stop as soon as possible, the task is then finished with an error.
+func (n *Node) CancelTask(ctx context.Context, k Key) (Task, error) {
+	task, err := n.GetTask(k).Do(ctx).ResultOrErr()
+	if err != nil {
+		return Task{}, err
+	}
+
+	if !task.IsProcessing() {
+		return task, TaskFinishedError{errors.Errorf(`task "%s" is already finished`, k.String())}
+	}
+
+	request := CancelRequest{Key: k, RequestedAt: utctime.UTCTime(n.clock.Now())}
+	if err := n.cancelEtcdPrefix.Key(k.String()).Put(n.client, request).Do(ctx).Err(); err != nil {
+		return Task{}, errors.Errorf(`cannot cancel task "%s": %w`, k.String(), err)
+	}
+
+	n.logger.Infof(ctx, `requested cancellation of the task "%s"`, k.String())
+	return task, nil
+}
+
+// runningTasks maps keys of the tasks running on the node to their context cancel functions.
+type runningTasks struct {
+	lock    sync.Mutex
+	cancels map[string]context.CancelCauseFunc
+}
+
+func newRunningTasks() *runningTasks {
+	return &runningTasks{cancels: make(map[string]context.CancelCauseFunc)}
+}
+
+func (r *runningTasks) add(k Key, cancel context.CancelCauseFunc) (remove func()) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.cancels[k.String()] = cancel
+	return func() {
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		delete(r.cancels, k.String())
+	}
+}
+
+func (r *runningTasks) cancel(k Key) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if cancel, found := r.cancels[k.String()]; found {
+		cancel(errCancelRequested)
+		return true
+	}
+	return false
+}
+
+// watchCancelRequests cancels tasks running on the node, if a cancel request is written to etcd.
+func (n *Node) watchCancelRequests(ctx context.Context, wg *sync.WaitGroup) error {
+	consumer := n.cancelEtcdPrefix.
+		GetAllAndWatch(ctx, n.client).
+		SetupConsumer().
+		WithForEach(func(events []etcdop.WatchEvent[CancelRequest], _ *etcdop.Header, _ bool) {
+			for _, event := range events {
+				if event.Type == etcdop.DeleteEvent {
+					continue
+				}
+				if n.runningTasks.cancel(event.Value.Key) {
+					n.logger.Infof(ctx, `cancelling task "%s"`, event.Value.Key.String())
+				}
+			}
+		}).
+		BuildConsumer()
+
+	return <-consumer.StartConsumer(ctx, wg, n.logger)
+}
+
+// isCancelRequested checks the cancel request directly,
+// the request may have been written before the task was registered as running.
+func (n *Node) isCancelRequested(ctx context.Context, k Key) (bool, error) {
+	r, err := n.cancelEtcdPrefix.Key(k.String()).GetKV(n.client).Do(ctx).ResultOrErr()
+	return r != nil, err
+}
+
+func newCancelPrefix(s *serde.Serde) etcdop.PrefixT[CancelRequest] {
+	return etcdop.NewTypedPrefix[CancelRequest](etcdop.NewPrefix(CancelEtcdPrefix), s)
+}
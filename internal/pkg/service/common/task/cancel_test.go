@@ -0,0 +1,163 @@
+package task_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/task"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/etcdhelper"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/ioutil"
+)
+
+func TestCancelTask(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	etcdCfg := etcdhelper.TmpNamespace(t)
+	client := etcdhelper.ClientForTest(t, etcdCfg)
+	ignoredEtcdKeys := etcdhelper.WithIgnoredKeyPattern("^(runtime/distribution/)")
+
+	logs := ioutil.NewAtomicWriter()
+	tel := newTestTelemetryWithFilter(t)
+
+	// Create nodes, the task runs on the node1, the cancel request is sent from the node2
+	node1, _ := createNode(t, ctx, etcdCfg, logs, tel, "node1")
+	node2, _ := createNode(t, ctx, etcdCfg, logs, tel, "node2")
+
+	// Start a task, it waits for the cancellation
+	taskStarted := make(chan struct{})
+	taskDone := make(chan struct{})
+	tKey := task.Key{ProjectID: 123, TaskID: "some.task"}
+	started, err := node1.StartTask(ctx, task.Config{
+		Key:  tKey,
+		Type: "some.task",
+		Context: func() (context.Context, context.CancelFunc) {
+			return context.WithTimeout(ctx, time.Minute)
+		},
+		Operation: func(ctx context.Context, logger log.Logger) task.Result {
+			defer close(taskDone)
+			close(taskStarted)
+			<-ctx.Done()
+			return task.ErrResult(ctx.Err())
+		},
+	})
+	require.NoError(t, err)
+	<-taskStarted
+
+	// Cancel the task
+	_, err = node2.CancelTask(ctx, started.Key)
+	require.NoError(t, err)
+	select {
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "timeout")
+	case <-taskDone:
+	}
+
+	// Wait for the task finalization
+	assert.Eventually(t, func() bool {
+		finished, err := node2.GetTask(started.Key).Do(ctx).ResultOrErr()
+		return err == nil && !finished.IsProcessing()
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The cancel request is deleted, the task is finished with the error
+	etcdhelper.AssertKVsString(t, client, `
+<<<<<
+task/123/some.task/%s
+-----
+{
+  "projectId": 123,
+  "taskId": "some.task/%s",
+  "type": "some.task",
+  "createdAt": "%s",
+  "finishedAt": "%s",
+  "cancelledAt": "%s",
+  "node": "node1",
+  "lock": "runtime/lock/task/123/some.task",
+  "error": "task has been cancelled: context canceled",
+  "userError": {
+    "name": "taskCancelled",
+    "message": "The task has been cancelled.",
+    "exceptionId": "test-service-%s"
+  },
+  "duration": %d
+}
+>>>>>
+`, ignoredEtcdKeys)
+
+	// Finished task cannot be cancelled
+	_, err = node2.CancelTask(ctx, started.Key)
+	if assert.Error(t, err) {
+		assert.ErrorAs(t, err, &task.TaskFinishedError{})
+	}
+}
+
+func TestTaskProgress(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	etcdCfg := etcdhelper.TmpNamespace(t)
+	tel := newTestTelemetryWithFilter(t)
+
+	d := createDeps(t, ctx, "node1", etcdCfg, nil, tel)
+	cfg := task.NewNodeConfig()
+	cfg.ProgressInterval = 10 * time.Millisecond
+	node, err := task.NewNode("node1", "test-service-", d, cfg)
+	require.NoError(t, err)
+
+	// Start a task, it reports progress
+	taskWork := make(chan struct{})
+	progressReported := make(chan struct{})
+	started, err := node.StartTask(ctx, task.Config{
+		Key:  task.Key{ProjectID: 123, TaskID: "some.task"},
+		Type: "some.task",
+		Context: func() (context.Context, context.CancelFunc) {
+			return context.WithTimeout(ctx, time.Minute)
+		},
+		Operation: func(ctx context.Context, logger log.Logger) task.Result {
+			task.SetProgress(ctx, 10, "step1", "ignored, overwritten before flush")
+			task.SetProgress(ctx, 50, "step2", "half done")
+			close(progressReported)
+			<-taskWork
+			task.SetProgress(ctx, 150, "step3", "done")
+			return task.OkResult("ok")
+		},
+	})
+	require.NoError(t, err)
+	<-progressReported
+
+	// Progress of the running task is written to etcd
+	assert.Eventually(t, func() bool {
+		running, err := node.GetTask(started.Key).Do(ctx).ResultOrErr()
+		return err == nil && running.Progress != nil && running.Progress.Percent == 50
+	}, 5*time.Second, 10*time.Millisecond)
+	running, err := node.GetTask(started.Key).Do(ctx).ResultOrErr()
+	require.NoError(t, err)
+	assert.True(t, running.IsProcessing())
+	assert.Equal(t, "step2", running.Progress.Step)
+	assert.Equal(t, "half done", running.Progress.Message)
+
+	// The last progress is stored together with the result, percent is limited to 100
+	close(taskWork)
+	assert.Eventually(t, func() bool {
+		finished, err := node.GetTask(started.Key).Do(ctx).ResultOrErr()
+		return err == nil && finished.IsSuccessful()
+	}, 5*time.Second, 10*time.Millisecond)
+	finished, err := node.GetTask(started.Key).Do(ctx).ResultOrErr()
+	require.NoError(t, err)
+	if assert.NotNil(t, finished.Progress) {
+		assert.Equal(t, 100, finished.Progress.Percent)
+		assert.Equal(t, "step3", finished.Progress.Step)
+	}
+
+	// Progress outside a task is ignored
+	task.SetProgress(ctx, 10, "step", "message")
+}
@@ -30,10 +30,11 @@ const (
 )
 
 type Cleaner struct {
-	clock          clock.Clock
-	logger         log.Logger
-	client         *etcd.Client
-	taskEtcdPrefix etcdop.PrefixT[Task]
+	clock           clock.Clock
+	logger          log.Logger
+	client          *etcd.Client
+	taskEtcdPrefix  etcdop.PrefixT[Task]
+	queueEtcdPrefix etcdop.PrefixT[QueueItem]
 }
 
 type cleanerDeps interface {
@@ -47,10 +48,11 @@ type cleanerDeps interface {
 
 func StartCleaner(d cleanerDeps, interval time.Duration) error {
 	c := &Cleaner{
-		clock:          d.Clock(),
-		logger:         d.Logger().WithComponent("task.cleanup"),
-		client:         d.EtcdClient(),
-		taskEtcdPrefix: newTaskPrefix(d.EtcdSerde()),
+		clock:           d.Clock(),
+		logger:          d.Logger().WithComponent("task.cleanup"),
+		client:          d.EtcdClient(),
+		taskEtcdPrefix:  newTaskPrefix(d.EtcdSerde()),
+		queueEtcdPrefix: newQueuePrefix(d.EtcdSerde()),
 	}
 
 	distGroup, err := d.DistributionNode().Group("task.cleanup")
@@ -125,6 +127,30 @@ func (c *Cleaner) clean(ctx context.Context) (err error) {
 	trace.SpanFromContext(ctx).SetAttributes(attribute.Int64("task.cleanup.deletedTasksCount", deletedTasksCount))
 	c.logger.With(attribute.Int64("deletedTasks", deletedTasksCount)).Infof(ctx, `deleted "%d" tasks`, deletedTasksCount)
 
+	// Go through queued tasks and delete old items in the dead-letter state
+	deletedItemsCount := int64(0)
+	err = c.queueEtcdPrefix.GetAll(c.client).Do(ctx).ForEachKV(func(kv *op.KeyValueT[QueueItem], header *iterator.Header) error {
+		if c.isQueueItemForCleanup(kv.Value) {
+			ctx := ctxattr.ContextWith(ctx, attribute.String("task", kv.Value.Key.String()))
+			if err := etcdop.Key(kv.Key()).Delete(c.client).Do(ctx).Err(); err == nil {
+				c.logger.Debug(ctx, `deleted dead-letter queue item`)
+				deletedItemsCount++
+			} else {
+				errs.Append(err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		errs.Append(err)
+	}
+
+	// Track number of deleted queue items
+	if deletedItemsCount > 0 {
+		trace.SpanFromContext(ctx).SetAttributes(attribute.Int64("task.cleanup.deletedQueueItemsCount", deletedItemsCount))
+		c.logger.With(attribute.Int64("deletedQueueItems", deletedItemsCount)).Infof(ctx, `deleted "%d" dead-letter queue items`, deletedItemsCount)
+	}
+
 	return errs.ErrorOrNil()
 }
 
@@ -149,3 +175,9 @@ func (c *Cleaner) isForCleanup(t Task) bool {
 	}
 	return false
 }
+
+// isQueueItemForCleanup returns true for an item in the dead-letter state, if the last attempt is as old as a failed task for cleanup.
+// Items waiting for the next attempt are never deleted, they are deleted when the task is finished.
+func (c *Cleaner) isQueueItemForCleanup(item QueueItem) bool {
+	return item.DeadLetter && c.clock.Now().Sub(item.NextAttemptAt.Time()) >= CleanupFailedTasksAfter
+}
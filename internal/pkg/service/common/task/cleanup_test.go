@@ -41,6 +41,7 @@ func TestCleanup(t *testing.T) {
 	}
 
 	taskPrefix := etcdop.NewTypedPrefix[task.Task](task.EtcdPrefix, d.EtcdSerde())
+	queuePrefix := etcdop.NewTypedPrefix[task.QueueItem](etcdop.NewPrefix(task.QueueEtcdPrefix), d.EtcdSerde())
 
 	// Start cleaner
 	cleanupInterval := 15 * time.Second
@@ -97,6 +98,28 @@ func TestCleanup(t *testing.T) {
 	}
 	assert.NoError(t, taskPrefix.Key(taskKey3.String()).Put(client, task3).Do(ctx).Err())
 
+	// Add queue item in the dead-letter state, the last attempt is old - will be deleted
+	item1 := task.QueueItem{
+		Key:        taskKey1,
+		Type:       "some.task",
+		Lock:       "lock1",
+		Timeout:    time.Minute,
+		QueueState: task.QueueState{Attempt: 3, MaxAttempts: 3, NextAttemptAt: createdAt, LastError: "err", DeadLetter: true},
+	}
+	assert.NoError(t, queuePrefix.Key(taskKey1.String()).Put(client, item1).Do(ctx).Err())
+
+	// Add queue item in the dead-letter state, the last attempt is recent - will be ignored
+	item2 := item1
+	item2.Key = taskKey3
+	item2.NextAttemptAt = time3Key
+	assert.NoError(t, queuePrefix.Key(taskKey3.String()).Put(client, item2).Do(ctx).Err())
+
+	// Add old queue item waiting for the next attempt - will be ignored
+	item3 := item1
+	item3.Key = taskKey2
+	item3.DeadLetter = false
+	assert.NoError(t, queuePrefix.Key(taskKey2.String()).Put(client, item3).Do(ctx).Err())
+
 	// Run the cleanup
 	clk.Add(cleanupInterval)
 	assert.EventuallyWithT(t, func(c *assert.CollectT) {
@@ -113,6 +136,8 @@ func TestCleanup(t *testing.T) {
 {"level":"debug","message":"deleted task","component":"task.cleanup","task":"123/some.task/2006-01-02T08:04:05.000Z_abcdef"}
 {"level":"debug","message":"deleted task","component":"task.cleanup","task":"456/other.task/2006-01-02T08:04:05.000Z_ghijkl"}
 {"level":"info","message":"deleted \"2\" tasks","component":"task.cleanup","deletedTasks":2}
+{"level":"debug","message":"deleted dead-letter queue item","component":"task.cleanup","task":"123/some.task/2006-01-02T08:04:05.000Z_abcdef"}
+{"level":"info","message":"deleted \"1\" dead-letter queue items","component":"task.cleanup","deletedQueueItems":1}
 {"level":"info","message":"exiting (bye bye)"}
 {"level":"info","message":"received shutdown request","component":"task.cleanup"}
 {"level":"info","message":"shutdown done","component":"task.cleanup"}
@@ -121,6 +146,39 @@ func TestCleanup(t *testing.T) {
 
 	// Check keys
 	etcdhelper.AssertKVsString(t, client, `
+<<<<<
+runtime/task/queue/456/other.task/2006-01-02T08:04:05.000Z_ghijkl
+-----
+{
+  "projectId": 456,
+  "taskId": "other.task/2006-01-02T08:04:05.000Z_ghijkl",
+  "type": "some.task",
+  "lock": "lock1",
+  "timeout": 60000000000,
+  "attempt": 3,
+  "maxAttempts": 3,
+  "nextAttemptAt": "2006-01-02T08:04:05.000Z",
+  "lastError": "err"
+}
+>>>>>
+
+<<<<<
+runtime/task/queue/789/third.task/2006-01-02T08:04:05.000Z_mnopqr
+-----
+{
+  "projectId": 789,
+  "taskId": "third.task/2006-01-02T08:04:05.000Z_mnopqr",
+  "type": "some.task",
+  "lock": "lock1",
+  "timeout": 60000000000,
+  "attempt": 3,
+  "maxAttempts": 3,
+  "nextAttemptAt": "%s",
+  "lastError": "err",
+  "deadLetter": true
+}
+>>>>>
+
 <<<<<
 task/789/third.task/2006-01-02T08:04:05.000Z_mnopqr
 -----
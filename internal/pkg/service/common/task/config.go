@@ -12,16 +12,32 @@ const (
 )
 
 type NodeConfig struct {
-	TTLSeconds      int           `configKey:"ttlSeconds" configUsage:"Defines time after the session is canceled if the client is unavailable." validate:"required"`
-	CleanupEnabled  bool          `configKey:"cleanupEnabled" configUsage:"Enable periodical tasks cleanup functionality."`
-	CleanupInterval time.Duration `configKey:"cleanupInterval" configUsage:"How often will old tasks be deleted." validate:"required"`
+	TTLSeconds       int           `configKey:"ttlSeconds" configUsage:"Defines time after the session is canceled if the client is unavailable." validate:"required"`
+	CleanupEnabled   bool          `configKey:"cleanupEnabled" configUsage:"Enable periodical tasks cleanup functionality."`
+	CleanupInterval  time.Duration `configKey:"cleanupInterval" configUsage:"How often will old tasks be deleted." validate:"required"`
+	ProgressInterval time.Duration `configKey:"progressInterval" configUsage:"How often is progress of a running task written to etcd." validate:"required"`
+	Queue            QueueConfig   `configKey:"queue"`
+}
+
+type QueueConfig struct {
+	CheckInterval  time.Duration `configKey:"checkInterval" configUsage:"How often are queued tasks checked by a worker node." validate:"required"`
+	MaxAttempts    int           `configKey:"maxAttempts" configUsage:"Default maximum number of attempts of a queued task." validate:"required,min=1"`
+	InitialBackoff time.Duration `configKey:"initialBackoff" configUsage:"Delay before the second attempt, it is doubled with each next attempt." validate:"required"`
+	MaxBackoff     time.Duration `configKey:"maxBackoff" configUsage:"Maximum delay between attempts." validate:"required,gtefield=InitialBackoff"`
 }
 
 func NewNodeConfig() NodeConfig {
 	return NodeConfig{
-		TTLSeconds:      15,
-		CleanupEnabled:  true,
-		CleanupInterval: 1 * time.Hour,
+		TTLSeconds:       15,
+		CleanupEnabled:   true,
+		CleanupInterval:  1 * time.Hour,
+		ProgressInterval: 2 * time.Second,
+		Queue: QueueConfig{
+			CheckInterval:  1 * time.Second,
+			MaxAttempts:    5,
+			InitialBackoff: 5 * time.Second,
+			MaxBackoff:     5 * time.Minute,
+		},
 	}
 }
 
@@ -31,6 +47,9 @@ type Config struct {
 	Lock      string
 	Context   ContextFactory
 	Operation Fn
+
+	// queueItem is set, if the task has been started from the queue, see StartQueueWorker.
+	queueItem *QueueItem
 }
 
 type ContextFactory func() (context.Context, context.CancelFunc)
@@ -1,3 +1,6 @@
 // Package task provides a task abstraction for long-running operations in the Worker node.
 // It is guaranteed that the task will run at most once, as well as resistance to outages.
+//
+// Optionally, a task can be enqueued by the Node.EnqueueTask method and picked up by any worker node, see Node.StartQueueWorker.
+// A queued task runs at least once, it is retried with an exponential backoff on retryable errors.
 package task
@@ -9,16 +9,19 @@ import (
 
 type Task struct {
 	Key
-	Type       string           `json:"type" validate:"required"`
-	CreatedAt  utctime.UTCTime  `json:"createdAt" validate:"required"`
-	FinishedAt *utctime.UTCTime `json:"finishedAt,omitempty"`
-	Node       string           `json:"node" validate:"required"`
-	Lock       etcdop.Key       `json:"lock" validate:"required"`
-	Result     string           `json:"result,omitempty"`
-	Error      string           `json:"error,omitempty"`
-	UserError  *Error           `json:"userError,omitempty"`
-	Outputs    Outputs          `json:"outputs,omitempty"`
-	Duration   *time.Duration   `json:"duration,omitempty"`
+	Type        string           `json:"type" validate:"required"`
+	CreatedAt   utctime.UTCTime  `json:"createdAt" validate:"required"`
+	FinishedAt  *utctime.UTCTime `json:"finishedAt,omitempty"`
+	CancelledAt *utctime.UTCTime `json:"cancelledAt,omitempty"`
+	Node        string           `json:"node" validate:"required_without=Queue"`
+	Lock        etcdop.Key       `json:"lock" validate:"required"`
+	Result      string           `json:"result,omitempty"`
+	Error       string           `json:"error,omitempty"`
+	UserError   *Error           `json:"userError,omitempty"`
+	Outputs     Outputs          `json:"outputs,omitempty"`
+	Progress    *Progress        `json:"progress,omitempty"`
+	Queue       *QueueState      `json:"queue,omitempty"`
+	Duration    *time.Duration   `json:"duration,omitempty"`
 }
 
 type Error struct {
@@ -50,7 +50,9 @@ type Node struct {
 	logger log.Logger
 	client *etcd.Client
 
-	tasksWg *sync.WaitGroup
+	process      *servicectx.Process
+	distribution *distribution.Node
+	tasksWg      *sync.WaitGroup
 
 	session *etcdop.Session
 
@@ -58,9 +60,12 @@ type Node struct {
 	config     NodeConfig
 	tasksCount *atomic.Int64
 
-	taskEtcdPrefix etcdop.PrefixT[Task]
-	taskLocksMutex *sync.Mutex
-	taskLocks      map[string]bool
+	taskEtcdPrefix   etcdop.PrefixT[Task]
+	cancelEtcdPrefix etcdop.PrefixT[CancelRequest]
+	queueEtcdPrefix  etcdop.PrefixT[QueueItem]
+	taskLocksMutex   *sync.Mutex
+	taskLocks        map[string]bool
+	runningTasks     *runningTasks
 
 	exceptionIDPrefix string
 }
@@ -96,12 +101,17 @@ func NewNode(nodeID string, exceptionIDPrefix string, d dependencies, cfg NodeCo
 		clock:             d.Clock(),
 		logger:            d.Logger().WithComponent("task"),
 		client:            d.EtcdClient(),
+		process:           proc,
+		distribution:      d.DistributionNode(),
 		nodeID:            nodeID,
 		config:            cfg,
 		tasksCount:        atomic.NewInt64(0),
 		taskEtcdPrefix:    newTaskPrefix(d.EtcdSerde()),
+		cancelEtcdPrefix:  newCancelPrefix(d.EtcdSerde()),
+		queueEtcdPrefix:   newQueuePrefix(d.EtcdSerde()),
 		taskLocksMutex:    &sync.Mutex{},
 		taskLocks:         make(map[string]bool),
+		runningTasks:      newRunningTasks(),
 		exceptionIDPrefix: exceptionIDPrefix,
 	}
 
@@ -136,6 +146,11 @@ func NewNode(nodeID string, exceptionIDPrefix string, d dependencies, cfg NodeCo
 		return nil, err
 	}
 
+	// Cancel running tasks on request
+	if err := n.watchCancelRequests(sessionCtx, sessionWg); err != nil {
+		return nil, err
+	}
+
 	return n, nil
 }
 
@@ -271,6 +286,21 @@ func (n *Node) runTask(logger log.Logger, task Task, cfg Config) (result Result,
 		panic(errors.Errorf(`task "%s" context must have a deadline`, cfg.Type))
 	}
 
+	// Register the running task, so it can be cancelled by a cancel request
+	ctx, cancelCause := context.WithCancelCause(ctx)
+	defer cancelCause(nil)
+	defer n.runningTasks.add(task.Key, cancelCause)()
+	if requested, err := n.isCancelRequested(ctx, task.Key); err != nil {
+		logger.Warnf(ctx, `cannot check cancel request: %s`, err)
+	} else if requested {
+		cancelCause(errCancelRequested)
+	}
+
+	// Report progress of the task
+	progress := newProgressReporter(n.clock.Now)
+	ctx = context.WithValue(ctx, progressCtxKey{}, progress)
+	stopProgress := n.flushProgress(ctx, task, progress)
+
 	// Setup telemetry
 	ctx, span := n.tracer.Start(ctx, spanName, trace.WithAttributes(spanStartAttrs(&task)...))
 	n.metrics.running.Add(ctx, 1, metric.WithAttributes(meterStartAttrs(&task)...))
@@ -292,6 +322,14 @@ func (n *Node) runTask(logger log.Logger, task Task, cfg Config) (result Result,
 		}()
 		result = cfg.Operation(ctx, logger)
 	}()
+	stopProgress()
+	task.Progress = progress.progress()
+
+	// Queued task failed with a retryable error, schedule the next attempt
+	if cfg.queueItem != nil && n.shouldRetry(ctx, task, result) {
+		n.metrics.running.Add(ctx, -1, metric.WithAttributes(meterStartAttrs(&task)...))
+		return result, n.retryQueuedTask(ctx, logger, task, *cfg.queueItem, result)
+	}
 
 	// Calculate duration
 	endTime := n.clock.Now()
@@ -304,6 +342,21 @@ func (n *Node) runTask(logger log.Logger, task Task, cfg Config) (result Result,
 	task.Duration = &duration
 	task.Outputs = result.Outputs
 
+	// Mark the cancelled task, the operation error is caused by the cancellation.
+	// If the operation finished successfully, despite the cancel request, the result is kept.
+	if result.Error != nil && errors.Is(context.Cause(ctx), errCancelRequested) {
+		task.CancelledAt = &finishedAt
+		result.Error = WrapUserError(CancelledError{errors.PrefixError(result.Error, "task has been cancelled")})
+	}
+
+	// Store the last error of the queued task, all attempts failed with a retryable error, the task is moved to the dead-letter state.
+	if task.Queue != nil && result.Error != nil {
+		state := *task.Queue
+		state.LastError = result.Error.Error()
+		state.DeadLetter = task.CancelledAt == nil && IsRetryableError(result.Error)
+		task.Queue = &state
+	}
+
 	// Use task outputs in log message and telemetry
 	var attrs []attribute.KeyValue
 	for k, v := range task.Outputs {
@@ -371,11 +424,15 @@ func (n *Node) runTask(logger log.Logger, task Task, cfg Config) (result Result,
 	n.metrics.duration.Record(finalizationCtx, durationMs, metric.WithAttributes(meterEndAttrs(&task, result)...))
 
 	// Update task and release lock in etcd
-	finalizeTaskOp := op.MergeToTxn(
-		n.client,
+	finalizeOps := []op.Op{
 		n.taskEtcdPrefix.Key(task.Key.String()).Put(n.client, task),
+		n.cancelEtcdPrefix.Key(task.Key.String()).Delete(n.client),
 		task.Lock.DeleteIfExists(n.client),
-	)
+	}
+	if cfg.queueItem != nil {
+		finalizeOps = append(finalizeOps, n.finalizeQueueItemOp(task, *cfg.queueItem))
+	}
+	finalizeTaskOp := op.MergeToTxn(n.client, finalizeOps...)
 	r := finalizeTaskOp.Do(finalizationCtx)
 	if err := r.Err(); err != nil {
 		err = errors.Errorf(`cannot update task and release lock: %w`, err)
@@ -391,6 +448,37 @@ func (n *Node) runTask(logger log.Logger, task Task, cfg Config) (result Result,
 	return result, nil
 }
 
+// flushProgress periodically writes the last reported progress to etcd, until the returned stop function is called.
+func (n *Node) flushProgress(ctx context.Context, task Task, progress *progressReporter) (stop func()) {
+	ticker := n.clock.Ticker(n.config.ProgressInterval)
+	done := make(chan struct{})
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if p, ok := progress.flush(); ok {
+					task.Progress = p
+					if err := n.taskEtcdPrefix.Key(task.Key.String()).Put(n.client, task).Do(ctx).Err(); err != nil {
+						n.logger.Warnf(ctx, `cannot update task progress: %s`, err)
+					}
+				}
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		wg.Wait()
+	}
+}
+
 // lockTaskLocally guarantees that the task runs at most once on the Worker node.
 // Uniqueness within the cluster is guaranteed by the etcd transaction, see StartTask method.
 func (n *Node) lockTaskLocally(lock string) (ok bool, unlock func()) {
@@ -413,6 +501,11 @@ func (n *Node) lockTaskLocally(lock string) (ok bool, unlock func()) {
 	}
 }
 
+// MigratablePrefixes returns prefixes of all values stored by the task package, see the etcdmigration package.
+func MigratablePrefixes(s *serde.Serde) []etcdop.MigratablePrefix {
+	return []etcdop.MigratablePrefix{newTaskPrefix(s), newQueuePrefix(s), newCancelPrefix(s)}
+}
+
 func newTaskPrefix(s *serde.Serde) etcdop.PrefixT[Task] {
 	return etcdop.NewTypedPrefix[Task](etcdop.NewPrefix(EtcdPrefix), s)
 }
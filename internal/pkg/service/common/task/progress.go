@@ -0,0 +1,72 @@
+package task
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/utctime"
+)
+
+type progressCtxKey struct{}
+
+// Progress of a running task, it is reported by the task operation using the SetProgress function.
+type Progress struct {
+	Percent   int             `json:"percent"`
+	Step      string          `json:"step,omitempty"`
+	Message   string          `json:"message,omitempty"`
+	UpdatedAt utctime.UTCTime `json:"updatedAt"`
+}
+
+// SetProgress reports progress of the task, the ctx must be the context of the task operation.
+// The progress is written to etcd at a throttled rate, see NodeConfig.ProgressInterval.
+// Outside a task, the call is ignored.
+func SetProgress(ctx context.Context, percent int, step, message string) {
+	if r, ok := ctx.Value(progressCtxKey{}).(*progressReporter); ok {
+		r.set(percent, step, message)
+	}
+}
+
+// progressReporter holds the last reported progress, until it is flushed to etcd.
+type progressReporter struct {
+	now   func() time.Time
+	lock  sync.Mutex
+	last  *Progress
+	dirty bool
+}
+
+func newProgressReporter(now func() time.Time) *progressReporter {
+	return &progressReporter{now: now}
+}
+
+func (r *progressReporter) set(percent int, step, message string) {
+	percent = min(max(percent, 0), 100)
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.last = &Progress{Percent: percent, Step: step, Message: message, UpdatedAt: utctime.UTCTime(r.now())}
+	r.dirty = true
+}
+
+// flush returns the last progress, if it has been modified since the last flush.
+func (r *progressReporter) flush() (*Progress, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if !r.dirty {
+		return nil, false
+	}
+	r.dirty = false
+	v := *r.last
+	return &v, true
+}
+
+// progress returns the last progress or nil.
+func (r *progressReporter) progress() *Progress {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.last == nil {
+		return nil
+	}
+	v := *r.last
+	return &v
+}
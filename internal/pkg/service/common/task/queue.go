@@ -0,0 +1,386 @@
+package task
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/keboola/go-client/pkg/keboola"
+	etcd "go.etcd.io/etcd/client/v3"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/encoding/json"
+	"github.com/keboola/keboola-as-code/internal/pkg/idgenerator"
+	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/ctxattr"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop/op"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop/serde"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/utctime"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+const (
+	QueueEtcdPrefix       = "runtime/task/queue"
+	queueDistributionName = "task.queue"
+)
+
+// QueueHandler runs a queued task of a type, the input is the JSON encoded QueueTaskConfig.Input.
+type QueueHandler func(ctx context.Context, logger log.Logger, input json.RawMessage) Result
+
+// QueueTaskConfig configures a task, which is enqueued by the EnqueueTask method.
+type QueueTaskConfig struct {
+	Type        string
+	Key         Key
+	Lock        string
+	Timeout     time.Duration
+	MaxAttempts int // if zero, the QueueConfig.MaxAttempts is used
+	Input       any // input is encoded to JSON and passed to the QueueHandler
+}
+
+// QueueState of a queued task, it is stored in the Task and in the QueueItem.
+type QueueState struct {
+	Attempt       int             `json:"attempt"`
+	MaxAttempts   int             `json:"maxAttempts" validate:"required,min=1"`
+	NextAttemptAt utctime.UTCTime `json:"nextAttemptAt"`
+	LastError     string          `json:"lastError,omitempty"`
+	// DeadLetter is true, if all attempts failed with a retryable error, the task is not retried anymore.
+	DeadLetter bool `json:"deadLetter,omitempty"`
+}
+
+// QueueItem is an enqueued task waiting for the next attempt.
+// It is deleted when the task is finished, only items in the dead-letter state are kept.
+type QueueItem struct {
+	Key
+	Type    string          `json:"type" validate:"required"`
+	Lock    string          `json:"lock" validate:"required"`
+	Timeout time.Duration   `json:"timeout" validate:"required"`
+	Input   json.RawMessage `json:"input,omitempty"`
+	QueueState
+}
+
+// RetryableError marks the wrapped error as transient, a queued task is retried later.
+type RetryableError struct {
+	error
+}
+
+func (e RetryableError) Unwrap() error {
+	return e.error
+}
+
+// WrapRetryableError marks the error as transient, a queued task is retried later.
+func WrapRetryableError(err error) error {
+	return RetryableError{error: err}
+}
+
+// IsRetryableError returns true for errors marked by the WrapRetryableError,
+// for transient Storage API errors and for network timeouts.
+func IsRetryableError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var retryableErr RetryableError
+	if errors.As(err, &retryableErr) {
+		return true
+	}
+
+	var storageErr *keboola.StorageError
+	if errors.As(err, &storageErr) {
+		code := storageErr.StatusCode()
+		return code == http.StatusTooManyRequests || code >= http.StatusInternalServerError
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+func (c QueueTaskConfig) Validate() error {
+	errs := errors.NewMultiError()
+	if c.Type == "" {
+		errs.Append(errors.New("task type must be configured"))
+	}
+	if c.Key == (Key{}) {
+		errs.Append(errors.New("task key must be configured"))
+	}
+	if c.Timeout <= 0 {
+		errs.Append(errors.New("task timeout must be configured"))
+	}
+	if c.MaxAttempts < 0 {
+		errs.Append(errors.New("max attempts cannot be negative"))
+	}
+	return errs.ErrorOrNil()
+}
+
+// EnqueueTask stores the task to the queue, it is run by a worker node, see the StartQueueWorker method.
+// The task is retried with an exponential backoff, if it fails with a retryable error, see IsRetryableError.
+// Unlike StartTask, the task can run more than once, so the operation should be idempotent.
+func (n *Node) EnqueueTask(ctx context.Context, cfg QueueTaskConfig) (t Task, err error) {
+	if err := cfg.Validate(); err != nil {
+		panic(err)
+	}
+
+	input, err := json.Encode(cfg.Input, false)
+	if err != nil {
+		return Task{}, errors.PrefixError(err, "cannot encode task input")
+	}
+
+	if cfg.Lock == "" {
+		cfg.Lock = cfg.Key.String()
+	}
+	if cfg.MaxAttempts == 0 {
+		cfg.MaxAttempts = n.config.Queue.MaxAttempts
+	}
+
+	// Append datetime and a random suffix to the task ID
+	createdAt := utctime.UTCTime(n.clock.Now())
+	taskKey := cfg.Key
+	taskKey.TaskID = ID(string(cfg.Key.TaskID) + "/" + createdAt.String() + "_" + idgenerator.Random(5))
+
+	state := QueueState{MaxAttempts: cfg.MaxAttempts, NextAttemptAt: createdAt}
+	task := Task{Key: taskKey, Type: cfg.Type, CreatedAt: createdAt, Lock: LockEtcdPrefix.Key(cfg.Lock), Queue: &state}
+	item := QueueItem{Key: taskKey, Type: cfg.Type, Lock: cfg.Lock, Timeout: cfg.Timeout, Input: input, QueueState: state}
+
+	enqueueOp := op.MergeToTxn(
+		n.client,
+		n.taskEtcdPrefix.Key(taskKey.String()).Put(n.client, task),
+		n.queueEtcdPrefix.Key(taskKey.String()).Put(n.client, item),
+	)
+	if err := enqueueOp.Do(ctx).Err(); err != nil {
+		return Task{}, errors.Errorf(`cannot enqueue task "%s": %w`, taskKey, err)
+	}
+
+	ctx = ctxattr.ContextWith(ctx, attribute.String("task", taskKey.String()))
+	n.logger.Infof(ctx, `enqueued task`)
+	return task, nil
+}
+
+// StartQueueWorker starts processing of the queued tasks on the node.
+// Queued tasks are distributed between worker nodes, each task is run by its owner.
+// Tasks of a type without a handler are skipped, all worker nodes should register the same handlers.
+//
+// The queue is mirrored to the memory by the etcd watch, so the queue is not loaded from etcd periodically.
+// Queued tasks are checked on each change of the queue and then in the CheckInterval,
+// to start tasks waiting for the next attempt and tasks of a node which left the cluster.
+func (n *Node) StartQueueWorker(handlers map[string]QueueHandler) error {
+	distGroup, err := n.distribution.Group(queueDistributionName)
+	if err != nil {
+		return err
+	}
+
+	// Graceful shutdown, the worker stops before the node waits for the running tasks
+	ctx, cancel := context.WithCancel(context.Background()) // nolint: contextcheck
+	ctx = ctxattr.ContextWith(ctx, attribute.String("node", n.nodeID))
+	wg := &sync.WaitGroup{}
+	n.process.OnShutdown(func(ctx context.Context) {
+		cancel()
+		wg.Wait()
+		n.logger.Info(ctx, "queue worker stopped")
+	})
+
+	// Mirror the queue, wake up the worker on each change
+	changed := make(chan struct{}, 1)
+	queue := etcdop.
+		SetupMirrorMap[QueueItem, string, QueueItem](
+		n.queueEtcdPrefix.GetAllAndWatch(ctx, n.client),
+		func(key string, item QueueItem) string {
+			return key
+		},
+		func(key string, item QueueItem, rawValue *op.KeyValue, oldValue *QueueItem) QueueItem {
+			return item
+		},
+	).
+		WithOnUpdate(func(_ etcdop.MirrorUpdate) {
+			select {
+			case changed <- struct{}{}:
+			default:
+			}
+		}).
+		BuildMirror()
+	if err := <-queue.StartMirroring(ctx, wg, n.logger); err != nil {
+		cancel()
+		return err
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		ticker := n.clock.Ticker(n.config.Queue.CheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-changed:
+			case <-ticker.C:
+			}
+
+			now := n.clock.Now()
+			queue.ForEach(func(_ string, item QueueItem) (stop bool) {
+				if item.DeadLetter || item.NextAttemptAt.Time().After(now) {
+					return false
+				}
+				if !distGroup.MustCheckIsOwner(item.Key.String()) {
+					return false
+				}
+				handler, found := handlers[item.Type]
+				if !found {
+					return false
+				}
+				if err := n.startQueuedTask(ctx, item, handler); err != nil && !errors.Is(err, context.Canceled) {
+					n.logger.Errorf(ctx, `cannot start queued task "%s": %s`, item.Key.String(), err)
+				}
+				return false
+			})
+		}
+	}()
+
+	n.logger.Info(ctx, "queue worker ready")
+	return nil
+}
+
+// startQueuedTask starts the next attempt of the queued task in background.
+func (n *Node) startQueuedTask(ctx context.Context, item QueueItem, handler QueueHandler) error {
+	lock := LockEtcdPrefix.Key(item.Lock)
+
+	// The lock is held during the attempt, skip the item if the previous attempt is still running
+	ok, unlock := n.lockTaskLocally(lock.Key())
+	if !ok {
+		return nil
+	}
+
+	// Load the current state of the task
+	task, err := n.GetTask(item.Key).Do(ctx).ResultOrErr()
+	if err != nil {
+		unlock()
+		return err
+	} else if task.Queue == nil {
+		unlock()
+		return errors.Errorf(`task "%s" is not a queued task`, item.Key.String())
+	} else if !task.IsProcessing() {
+		// The task has been finished, the delete event of the item has not been processed yet
+		unlock()
+		return nil
+	}
+
+	// Get session
+	session, err := n.session.Session()
+	if err != nil {
+		unlock()
+		return err
+	}
+
+	// Update task and acquire lock in etcd
+	state := *task.Queue
+	state.Attempt++
+	task.Queue = &state
+	task.Node = n.nodeID
+	ctx = ctxattr.ContextWith(ctx, attribute.String("task", task.Key.String()), attribute.String("node", n.nodeID))
+	startAttemptOp := op.MergeToTxn(
+		n.client,
+		n.taskEtcdPrefix.Key(task.Key.String()).Put(n.client, task),
+		lock.PutIfNotExists(n.client, task.Node, etcd.WithLease(session.Lease())),
+	)
+	if r := startAttemptOp.Do(ctx); r.Err() != nil {
+		unlock()
+		return r.Err()
+	} else if !r.Succeeded() {
+		unlock()
+		n.logger.Infof(ctx, `queued task ignored, the lock "%s" is in use`, lock.Key())
+		return nil
+	}
+
+	n.logger.Infof(ctx, `started queued task, attempt %d/%d`, state.Attempt, state.MaxAttempts)
+
+	cfg := Config{
+		Type: item.Type,
+		Key:  item.Key,
+		Lock: item.Lock,
+		Context: func() (context.Context, context.CancelFunc) {
+			return context.WithTimeout(context.Background(), item.Timeout)
+		},
+		Operation: func(ctx context.Context, logger log.Logger) Result {
+			return handler(ctx, logger, item.Input)
+		},
+		queueItem: &item,
+	}
+
+	go func() {
+		defer unlock()
+		// Error is logged and stored to DB
+		_, _ = n.runTask(n.logger, task, cfg)
+	}()
+
+	return nil
+}
+
+// shouldRetry returns true, if the queued task failed with a retryable error and there are remaining attempts.
+func (n *Node) shouldRetry(ctx context.Context, task Task, result Result) bool {
+	return task.Queue != nil &&
+		result.Error != nil &&
+		!errors.Is(context.Cause(ctx), errCancelRequested) &&
+		IsRetryableError(result.Error) &&
+		task.Queue.Attempt < task.Queue.MaxAttempts
+}
+
+// retryQueuedTask schedules the next attempt of the queued task and releases the lock.
+func (n *Node) retryQueuedTask(ctx context.Context, logger log.Logger, task Task, item QueueItem, result Result) error {
+	delay := n.config.Queue.backoff(task.Queue.Attempt)
+
+	state := *task.Queue
+	state.LastError = result.Error.Error()
+	state.NextAttemptAt = utctime.UTCTime(n.clock.Now().Add(delay))
+	task.Queue = &state
+	item.QueueState = state
+
+	logger.Warnf(ctx, `task attempt %d/%d failed, retrying in %s: %s`, state.Attempt, state.MaxAttempts, delay, result.Error)
+
+	// Create context for the update, the original context could have timed out.
+	updateCtx, updateCancel := context.WithTimeout(context.Background(), time.Duration(n.config.TTLSeconds)*time.Second)
+	defer updateCancel()
+
+	retryOp := op.MergeToTxn(
+		n.client,
+		n.taskEtcdPrefix.Key(task.Key.String()).Put(n.client, task),
+		n.queueEtcdPrefix.Key(task.Key.String()).Put(n.client, item),
+		task.Lock.DeleteIfExists(n.client),
+	)
+	if r := retryOp.Do(updateCtx); r.Err() != nil {
+		err := errors.Errorf(`cannot schedule next attempt of the task: %w`, r.Err())
+		logger.Error(ctx, err.Error())
+		return err
+	} else if !r.Succeeded() {
+		err := errors.Errorf(`cannot release task lock "%s", not found`, task.Lock.Key())
+		logger.Error(ctx, err.Error())
+		return err
+	}
+	logger.Debugf(ctx, `lock released "%s"`, task.Lock.Key())
+	return nil
+}
+
+// finalizeQueueItemOp deletes the item of the finished task from the queue, or moves the item to the dead-letter state.
+func (n *Node) finalizeQueueItemOp(task Task, item QueueItem) op.Op {
+	key := n.queueEtcdPrefix.Key(task.Key.String())
+	if task.Queue.DeadLetter {
+		item.QueueState = *task.Queue
+		return key.Put(n.client, item)
+	}
+	return key.Delete(n.client)
+}
+
+// backoff returns the delay before the next attempt, it is doubled with each attempt, up to the MaxBackoff.
+func (c QueueConfig) backoff(attempt int) time.Duration {
+	delay := c.InitialBackoff
+	for i := 1; i < attempt && delay < c.MaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, c.MaxBackoff)
+}
+
+func newQueuePrefix(s *serde.Serde) etcdop.PrefixT[QueueItem] {
+	return etcdop.NewTypedPrefix[QueueItem](etcdop.NewPrefix(QueueEtcdPrefix), s)
+}
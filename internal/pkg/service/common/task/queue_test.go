@@ -0,0 +1,284 @@
+package task_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/keboola/go-client/pkg/keboola"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	etcd "go.etcd.io/etcd/client/v3"
+	"go.uber.org/atomic"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/encoding/json"
+	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdclient"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/task"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/etcdhelper"
+)
+
+func TestQueue_RetryUntilSuccess(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	etcdCfg := etcdhelper.TmpNamespace(t)
+	client := etcdhelper.ClientForTest(t, etcdCfg)
+	ignoredEtcdKeys := etcdhelper.WithIgnoredKeyPattern("^(runtime/distribution/)")
+	node := createQueueNode(t, ctx, etcdCfg, "node1")
+
+	// The first two attempts fail with a retryable error
+	attempts := atomic.NewInt64(0)
+	require.NoError(t, node.StartQueueWorker(map[string]task.QueueHandler{
+		"some.task": func(ctx context.Context, logger log.Logger, input json.RawMessage) task.Result {
+			assert.JSONEq(t, `{"foo":"bar"}`, string(input))
+			if attempts.Inc() <= 2 {
+				return task.ErrResult(task.WrapRetryableError(errors.New("some transient error")))
+			}
+			return task.OkResult("done")
+		},
+	}))
+
+	enqueued, err := node.EnqueueTask(ctx, task.QueueTaskConfig{
+		Type:    "some.task",
+		Key:     task.Key{ProjectID: 123, TaskID: "some.task"},
+		Timeout: time.Minute,
+		Input:   map[string]string{"foo": "bar"},
+	})
+	require.NoError(t, err)
+	assert.True(t, enqueued.IsProcessing())
+	assert.Empty(t, enqueued.Node)
+
+	// Wait for the task
+	assert.Eventually(t, func() bool {
+		finished, err := node.GetTask(enqueued.Key).Do(ctx).ResultOrErr()
+		return err == nil && !finished.IsProcessing()
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, int64(3), attempts.Load())
+
+	// The queue item is deleted, the task contains the last error
+	etcdhelper.AssertKVsString(t, client, `
+<<<<<
+task/123/some.task/%s
+-----
+{
+  "projectId": 123,
+  "taskId": "some.task/%s",
+  "type": "some.task",
+  "createdAt": "%s",
+  "finishedAt": "%s",
+  "node": "node1",
+  "lock": "runtime/lock/task/123/some.task",
+  "result": "done",
+  "queue": {
+    "attempt": 3,
+    "maxAttempts": 3,
+    "nextAttemptAt": "%s",
+    "lastError": "some transient error"
+  },
+  "duration": %d
+}
+>>>>>
+`, ignoredEtcdKeys)
+}
+
+func TestQueue_DeadLetter(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	etcdCfg := etcdhelper.TmpNamespace(t)
+	client := etcdhelper.ClientForTest(t, etcdCfg)
+	ignoredEtcdKeys := etcdhelper.WithIgnoredKeyPattern("^(runtime/distribution/)")
+	node := createQueueNode(t, ctx, etcdCfg, "node1")
+
+	// All attempts fail with a retryable error
+	attempts := atomic.NewInt64(0)
+	require.NoError(t, node.StartQueueWorker(map[string]task.QueueHandler{
+		"some.task": func(ctx context.Context, logger log.Logger, input json.RawMessage) task.Result {
+			attempts.Inc()
+			return task.ErrResult(task.WrapRetryableError(errors.New("some transient error")))
+		},
+	}))
+
+	enqueued, err := node.EnqueueTask(ctx, task.QueueTaskConfig{
+		Type:        "some.task",
+		Key:         task.Key{ProjectID: 123, TaskID: "some.task"},
+		Timeout:     time.Minute,
+		MaxAttempts: 2,
+	})
+	require.NoError(t, err)
+
+	// Wait for the task
+	assert.Eventually(t, func() bool {
+		finished, err := node.GetTask(enqueued.Key).Do(ctx).ResultOrErr()
+		return err == nil && !finished.IsProcessing()
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// The dead-letter item is not retried
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, int64(2), attempts.Load())
+
+	// The queue item is kept in the dead-letter state
+	etcdhelper.AssertKVsString(t, client, `
+<<<<<
+runtime/task/queue/123/some.task/%s
+-----
+{
+  "projectId": 123,
+  "taskId": "some.task/%s",
+  "type": "some.task",
+  "lock": "123/some.task",
+  "timeout": 60000000000,
+  "input": null,
+  "attempt": 2,
+  "maxAttempts": 2,
+  "nextAttemptAt": "%s",
+  "lastError": "some transient error",
+  "deadLetter": true
+}
+>>>>>
+
+<<<<<
+task/123/some.task/%s
+-----
+{
+  "projectId": 123,
+  "taskId": "some.task/%s",
+  "type": "some.task",
+  "createdAt": "%s",
+  "finishedAt": "%s",
+  "node": "node1",
+  "lock": "runtime/lock/task/123/some.task",
+  "error": "some transient error",
+  "userError": {
+    "name": "unknownError",
+    "message": "Unknown error",
+    "exceptionId": "test-service-%s"
+  },
+  "queue": {
+    "attempt": 2,
+    "maxAttempts": 2,
+    "nextAttemptAt": "%s",
+    "lastError": "some transient error",
+    "deadLetter": true
+  },
+  "duration": %d
+}
+>>>>>
+`, ignoredEtcdKeys)
+}
+
+func TestQueue_NonRetryableError(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	etcdCfg := etcdhelper.TmpNamespace(t)
+	client := etcdhelper.ClientForTest(t, etcdCfg)
+	node := createQueueNode(t, ctx, etcdCfg, "node1")
+
+	// The error is not retryable, the task fails after the first attempt
+	attempts := atomic.NewInt64(0)
+	require.NoError(t, node.StartQueueWorker(map[string]task.QueueHandler{
+		"some.task": func(ctx context.Context, logger log.Logger, input json.RawMessage) task.Result {
+			attempts.Inc()
+			return task.ErrResult(errors.New("some error"))
+		},
+	}))
+
+	enqueued, err := node.EnqueueTask(ctx, task.QueueTaskConfig{
+		Type:    "some.task",
+		Key:     task.Key{ProjectID: 123, TaskID: "some.task"},
+		Timeout: time.Minute,
+	})
+	require.NoError(t, err)
+
+	// Wait for the task
+	assert.Eventually(t, func() bool {
+		finished, err := node.GetTask(enqueued.Key).Do(ctx).ResultOrErr()
+		return err == nil && finished.IsFailed()
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, int64(1), attempts.Load())
+
+	// The queue item is deleted
+	r, err := client.Get(ctx, task.QueueEtcdPrefix, etcd.WithPrefix(), etcd.WithCountOnly())
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), r.Count)
+}
+
+func TestIsRetryableError(t *testing.T) {
+	t.Parallel()
+
+	storageErr := func(code int) error {
+		err := &keboola.StorageError{Message: "some error"}
+		err.SetResponse(&http.Response{StatusCode: code})
+		return err
+	}
+
+	assert.False(t, task.IsRetryableError(nil))
+	assert.False(t, task.IsRetryableError(errors.New("some error")))
+	assert.True(t, task.IsRetryableError(task.WrapRetryableError(errors.New("some error"))))
+	assert.True(t, task.IsRetryableError(errors.PrefixError(task.WrapRetryableError(errors.New("some error")), "prefix")))
+	assert.True(t, task.IsRetryableError(storageErr(http.StatusTooManyRequests)))
+	assert.True(t, task.IsRetryableError(storageErr(http.StatusServiceUnavailable)))
+	assert.False(t, task.IsRetryableError(storageErr(http.StatusBadRequest)))
+	assert.True(t, task.IsRetryableError(&net.DNSError{IsTimeout: true}))
+	assert.False(t, task.IsRetryableError(&net.DNSError{IsNotFound: true}))
+}
+
+func TestQueue_StartedOnChange(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// The queue is not checked periodically during the test, the task is started by the watch event
+	etcdCfg := etcdhelper.TmpNamespace(t)
+	d := createDeps(t, ctx, "node1", etcdCfg, nil, newTestTelemetryWithFilter(t))
+	cfg := task.NewNodeConfig()
+	cfg.Queue.CheckInterval = time.Hour
+	node, err := task.NewNode("node1", "test-service-", d, cfg)
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	require.NoError(t, node.StartQueueWorker(map[string]task.QueueHandler{
+		"some.task": func(ctx context.Context, logger log.Logger, input json.RawMessage) task.Result {
+			close(done)
+			return task.OkResult("done")
+		},
+	}))
+
+	_, err = node.EnqueueTask(ctx, task.QueueTaskConfig{
+		Type:    "some.task",
+		Key:     task.Key{ProjectID: 123, TaskID: "some.task"},
+		Timeout: time.Minute,
+	})
+	require.NoError(t, err)
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		assert.Fail(t, "the queued task has not been started")
+	}
+}
+
+func createQueueNode(t *testing.T, ctx context.Context, etcdCfg etcdclient.Config, nodeID string) *task.Node {
+	t.Helper()
+	d := createDeps(t, ctx, nodeID, etcdCfg, nil, newTestTelemetryWithFilter(t))
+	cfg := task.NewNodeConfig()
+	cfg.Queue.CheckInterval = 10 * time.Millisecond
+	cfg.Queue.MaxAttempts = 3
+	cfg.Queue.InitialBackoff = 10 * time.Millisecond
+	cfg.Queue.MaxBackoff = 20 * time.Millisecond
+	node, err := task.NewNode(nodeID, "test-service-", d, cfg)
+	require.NoError(t, err)
+	return node
+}
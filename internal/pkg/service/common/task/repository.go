@@ -0,0 +1,91 @@
+package task
+
+import (
+	"context"
+	"strings"
+
+	etcd "go.etcd.io/etcd/client/v3"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop/iterator"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop/op"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop/serde"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+// Repository provides read access to tasks and task locks, and repair operations.
+// Unlike the Node, it doesn't start an etcd session, so it can be used by administration tools.
+type Repository struct {
+	client         *etcd.Client
+	taskEtcdPrefix etcdop.PrefixT[Task]
+}
+
+// Lock is a task lock, it is acquired by a node running the task, see Node.StartTask.
+type Lock struct {
+	Name   string     `json:"name"`
+	Key    etcdop.Key `json:"key"`
+	Node   string     `json:"node"`
+	Leased bool       `json:"leased"`
+}
+
+// LockInUseError is returned by the Repository.RemoveLock method, if the lease of the lock owner is alive.
+type LockInUseError struct {
+	error
+}
+
+func (e LockInUseError) Unwrap() error {
+	return e.error
+}
+
+func NewRepository(client *etcd.Client, s *serde.Serde) *Repository {
+	return &Repository{client: client, taskEtcdPrefix: newTaskPrefix(s)}
+}
+
+// ListAll tasks, optionally filtered by the task key prefix, for example "123/" for all tasks of the project.
+func (r *Repository) ListAll(keyPrefix string) iterator.DefinitionT[Task] {
+	return r.taskEtcdPrefix.GetAll(r.client).WithFilter(func(task Task) bool {
+		return strings.HasPrefix(task.Key.String(), keyPrefix)
+	})
+}
+
+// ListLocks lists all acquired task locks.
+func (r *Repository) ListLocks(ctx context.Context) (out []Lock, err error) {
+	err = LockEtcdPrefix.GetAll(r.client).Do(ctx).ForEach(func(kv *op.KeyValue, _ *iterator.Header) error {
+		out = append(out, Lock{
+			Name:   strings.TrimPrefix(string(kv.Key), LockEtcdPrefix.Prefix()),
+			Key:    etcdop.Key(kv.Key),
+			Node:   string(kv.Value),
+			Leased: kv.Lease != 0,
+		})
+		return nil
+	})
+	return out, err
+}
+
+// RemoveLock removes a stale task lock, so a new task with the lock can be started.
+// The lock is removed only if the lease of the owner session has expired or the lock has no lease,
+// otherwise the LockInUseError is returned. The force flag skips the check and removes also a live lock.
+func (r *Repository) RemoveLock(name string, force bool) *op.AtomicOp[op.NoResult] {
+	lock := LockEtcdPrefix.Key(name)
+	var kv *op.KeyValue
+	return op.Atomic(r.client, &op.NoResult{}).
+		Read(func(ctx context.Context) op.Op {
+			return lock.Get(r.client).WithResultTo(&kv)
+		}).
+		Write(func(ctx context.Context) op.Op {
+			if kv == nil {
+				return op.ErrorOp(errors.Errorf(`the lock "%s" not found`, name))
+			}
+			if !force && kv.Lease != 0 {
+				// The TTL is -1, if the lease has expired or has been revoked
+				resp, err := r.client.TimeToLive(ctx, etcd.LeaseID(kv.Lease))
+				if err != nil {
+					return op.ErrorOp(err)
+				}
+				if resp.TTL > 0 {
+					return op.ErrorOp(LockInUseError{errors.Errorf(`the lock "%s" is held by the node "%s", the lease of its session is alive`, name, string(kv.Value))})
+				}
+			}
+			return lock.Delete(r.client)
+		})
+}
@@ -0,0 +1,166 @@
+// Package admin provides commands for inspection and repair of the Stream service state in etcd.
+//
+// The commands understand the etcd schemas of the service,
+// objects are loaded and modified through the existing repositories, never through raw keys.
+//
+// Usage:
+//
+//	stream-admin list sources <project>[/<branch>]
+//	stream-admin list sinks <project>[/<branch>[/<source>]]
+//	stream-admin list files [<project>[/<branch>[/<source>[/<sink>]]]]
+//	stream-admin list slices [<project>[/<branch>[/<source>[/<sink>[/<file>[/<volume>]]]]]]
+//	stream-admin list tasks [<task key prefix>]
+//	stream-admin list task-locks
+//	stream-admin list locks [<lock name prefix>]
+//	stream-admin list nodes
+//	stream-admin [--confirm] repair close-file <project>/<branch>/<source>/<sink>/<file>
+//	stream-admin [--confirm] [--force] repair remove-task-lock <lock>
+//	stream-admin [--confirm] [--force] repair remove-lock <lock>
+//
+// Repair actions are guarded, without the --confirm flag the action is only described.
+// A lock is removed only if the lease of its owner session has expired, the --force flag removes also a live lock.
+//
+// The command connects only to etcd, the Storage API is used only by the close-file action,
+// to open a new file in a Keboola table sink.
+//
+// Tasks, task locks and distributed locks are common for the Stream and the Templates service.
+// Run the command with the etcd endpoint and namespace of the Templates service to inspect, for example, the "project/<project>" locks.
+package admin
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/benbjohnson/clock"
+	etcd "go.etcd.io/etcd/client/v3"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/encoding/json"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/distlock"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop/serde"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/task"
+	definitionRepo "github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/repository"
+	storageRepo "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/model/repository"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+const Usage = `Usage:
+  list sources <project>[/<branch>]
+  list sinks <project>[/<branch>[/<source>]]
+  list files [<project>[/<branch>[/<source>[/<sink>]]]]
+  list slices [<project>[/<branch>[/<source>[/<sink>[/<file>[/<volume>]]]]]]
+  list tasks [<task key prefix>]
+  list task-locks
+  list locks [<lock name prefix>]
+  list nodes
+  [--confirm] repair close-file <project>/<branch>/<source>/<sink>/<file>
+  [--confirm] [--force] repair remove-task-lock <lock>
+  [--confirm] [--force] repair remove-lock <lock>`
+
+type Admin struct {
+	clock        clock.Clock
+	client       *etcd.Client
+	definition   *definitionRepo.Repository
+	storage      *storageRepo.Repository
+	tasks        *task.Repository
+	locks        *distlock.Repository
+	enableBridge func(ctx context.Context) error
+	stdout       io.Writer
+	confirm      bool
+	force        bool
+}
+
+type dependencies interface {
+	Clock() clock.Clock
+	EtcdClient() *etcd.Client
+	EtcdSerde() *serde.Serde
+	DefinitionRepository() *definitionRepo.Repository
+	StorageRepository() *storageRepo.Repository
+	EnableKeboolaSinkBridge(ctx context.Context) error
+}
+
+// UsageError is returned if the command or its arguments are not valid.
+type UsageError struct {
+	error
+}
+
+func (e UsageError) Unwrap() error {
+	return e.error
+}
+
+func New(d dependencies, stdout io.Writer, cfg Config) *Admin {
+	return &Admin{
+		clock:        d.Clock(),
+		client:       d.EtcdClient(),
+		definition:   d.DefinitionRepository(),
+		storage:      d.StorageRepository(),
+		tasks:        task.NewRepository(d.EtcdClient(), d.EtcdSerde()),
+		locks:        distlock.NewRepository(d.EtcdClient()),
+		enableBridge: d.EnableKeboolaSinkBridge,
+		stdout:       stdout,
+		confirm:      cfg.Confirm,
+		force:        cfg.Force,
+	}
+}
+
+// Run the command specified by the positional arguments, for example: list sources 123.
+func (a *Admin) Run(ctx context.Context, args []string) error {
+	if len(args) < 2 {
+		return UsageError{errors.Errorf("expected a command and a subcommand\n%s", Usage)}
+	}
+
+	command, subcommand, args := args[0], args[1], args[2:]
+	switch command {
+	case "list":
+		return a.list(ctx, subcommand, args)
+	case "repair":
+		return a.repair(ctx, subcommand, args)
+	default:
+		return UsageError{errors.Errorf("unexpected command \"%s\"\n%s", command, Usage)}
+	}
+}
+
+// print the value as a pretty-printed JSON.
+func (a *Admin) print(value any) error {
+	out, err := json.EncodeString(value, true)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(a.stdout, strings.TrimSpace(out))
+	return err
+}
+
+// printList prints the list as a pretty-printed JSON array, an empty list is printed as [], not null.
+func printList[T any](a *Admin, items []T) error {
+	if items == nil {
+		items = []T{}
+	}
+	return a.print(items)
+}
+
+// printf prints a message for the user.
+func (a *Admin) printf(format string, args ...any) error {
+	_, err := fmt.Fprintf(a.stdout, format+"\n", args...)
+	return err
+}
+
+// optionalArg returns the only optional argument, or an empty string.
+func optionalArg(args []string) (string, error) {
+	switch len(args) {
+	case 0:
+		return "", nil
+	case 1:
+		return args[0], nil
+	default:
+		return "", UsageError{errors.Errorf("expected at most one argument, found %d\n%s", len(args), Usage)}
+	}
+}
+
+// requiredArg returns the only required argument.
+func requiredArg(args []string, name string) (string, error) {
+	if len(args) != 1 || args[0] == "" {
+		return "", UsageError{errors.Errorf("expected exactly one argument <%s>\n%s", name, Usage)}
+	}
+	return args[0], nil
+}
@@ -0,0 +1,286 @@
+package admin_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/keboola/go-client/pkg/keboola"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	etcd "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/concurrency"
+
+	commonDeps "github.com/keboola/keboola-as-code/internal/pkg/service/common/dependencies"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/distlock"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/task"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/utctime"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/admin"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/dependencies"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/test"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/test/dummy"
+)
+
+func TestAdmin_ListAndCloseFile(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	clk := clock.NewMock()
+	clk.Set(utctime.MustParse("2000-01-01T01:00:00.000Z").Time())
+	by := test.ByUser()
+
+	// Fixtures
+	projectID := keboola.ProjectID(123)
+	branchKey := key.BranchKey{ProjectID: projectID, BranchID: 456}
+	sourceKey := key.SourceKey{BranchKey: branchKey, SourceID: "my-source"}
+	sinkKey := key.SinkKey{SourceKey: sourceKey, SinkID: "my-sink"}
+
+	// Get services
+	d, mocked := dependencies.NewMockedAdminScope(t, ctx, commonDeps.WithClock(clk))
+	client := mocked.TestEtcdClient()
+	defRepo := d.DefinitionRepository()
+	fileRepo := d.StorageRepository().File()
+
+	// Register active volumes
+	session, err := concurrency.NewSession(client)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, session.Close()) }()
+	test.RegisterWriterVolumes(t, ctx, d.StorageRepository().Volume(), session, 1)
+
+	// Create parent branch, source and sink, the file is opened
+	branch := test.NewBranch(branchKey)
+	require.NoError(t, defRepo.Branch().Create(&branch, clk.Now(), by).Do(ctx).Err())
+	source := test.NewSource(sourceKey)
+	require.NoError(t, defRepo.Source().Create(&source, clk.Now(), by, "Create source").Do(ctx).Err())
+	sink := dummy.NewSinkWithLocalStorage(sinkKey)
+	require.NoError(t, defRepo.Sink().Create(&sink, clk.Now(), by, "Create sink").Do(ctx).Err())
+	fileKey := model.FileKey{SinkKey: sinkKey, FileID: model.FileID{OpenedAt: utctime.From(clk.Now())}}
+
+	var stdout bytes.Buffer
+	run := func(confirm bool, args ...string) error {
+		stdout.Reset()
+		return admin.New(d, &stdout, admin.Config{Confirm: confirm}).Run(ctx, args)
+	}
+
+	// List sources
+	require.NoError(t, run(false, "list", "sources", "123"))
+	assert.Contains(t, stdout.String(), `"sourceId": "my-source"`)
+	require.NoError(t, run(false, "list", "sources", "123/789"))
+	assert.Equal(t, "[]\n", stdout.String())
+
+	// List sinks
+	require.NoError(t, run(false, "list", "sinks", "123/456/my-source"))
+	assert.Contains(t, stdout.String(), `"sinkId": "my-sink"`)
+
+	// List files and slices
+	require.NoError(t, run(false, "list", "files", "123/456/my-source/my-sink"))
+	assert.Contains(t, stdout.String(), `"state": "writing"`)
+	require.NoError(t, run(false, "list", "slices"))
+	assert.Contains(t, stdout.String(), `"volumeId": "my-volume-1"`)
+
+	// Close the file without confirmation, nothing is changed
+	require.NoError(t, run(false, "repair", "close-file", fileKey.String()))
+	assert.Equal(t, `The file "123/456/my-source/my-sink/2000-01-01T01:00:00.000Z" will be closed and a new file will be opened in the sink "123/456/my-source/my-sink".
+Use the --confirm flag to proceed.
+`, stdout.String())
+	file, err := fileRepo.Get(fileKey).Do(ctx).ResultOrErr()
+	require.NoError(t, err)
+	assert.Equal(t, model.FileWriting, file.State)
+
+	// Close the file with confirmation
+	clk.Add(time.Hour)
+	require.NoError(t, run(true, "repair", "close-file", fileKey.String()))
+	assert.Contains(t, stdout.String(), `The file "123/456/my-source/my-sink/2000-01-01T01:00:00.000Z" has been closed, opened a new file "123/456/my-source/my-sink/2000-01-01T02:00:00.000Z".`)
+	file, err = fileRepo.Get(fileKey).Do(ctx).ResultOrErr()
+	require.NoError(t, err)
+	assert.Equal(t, model.FileClosing, file.State)
+
+	// The file cannot be closed twice
+	err = run(true, "repair", "close-file", fileKey.String())
+	if assert.Error(t, err) {
+		assert.Equal(t, `file "123/456/my-source/my-sink/2000-01-01T01:00:00.000Z" is in the "closing" state, only a file in the "writing" state can be closed`, err.Error())
+	}
+
+	// Invalid usage
+	err = run(false, "list", "sources")
+	if assert.Error(t, err) {
+		assert.ErrorAs(t, err, &admin.UsageError{})
+	}
+	err = run(false, "list", "sources", "123/456/my-source")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `invalid object key "123/456/my-source": expected at most 2 parts, found 3`)
+	}
+	err = run(false, "foo", "bar")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `unexpected command "foo"`)
+	}
+}
+
+func TestAdmin_TaskLocksAndNodes(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	d, mocked := dependencies.NewMockedAdminScope(t, ctx)
+	client := mocked.TestEtcdClient()
+
+	var stdout bytes.Buffer
+	run := func(confirm, force bool, args ...string) error {
+		stdout.Reset()
+		return admin.New(d, &stdout, admin.Config{Confirm: confirm, Force: force}).Run(ctx, args)
+	}
+
+	// No lock, no node
+	require.NoError(t, run(false, false, "list", "task-locks"))
+	assert.Equal(t, "[]\n", stdout.String())
+	require.NoError(t, run(false, false, "list", "nodes"))
+	assert.Equal(t, "[]\n", stdout.String())
+
+	// Create a stale lock without a lease, a lock of a live session, a task and a registered node
+	lock := task.LockEtcdPrefix.Key("my-lock")
+	require.NoError(t, lock.Put(client, "node1").Do(ctx).Err())
+	session, err := concurrency.NewSession(client)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, session.Close()) }()
+	liveLock := task.LockEtcdPrefix.Key("live-lock")
+	require.NoError(t, liveLock.Put(client, "node2", etcd.WithLease(session.Lease())).Do(ctx).Err())
+	taskValue := task.Task{
+		Key:       task.Key{ProjectID: 123, TaskID: "my-task"},
+		Type:      "some.task",
+		CreatedAt: utctime.MustParse("2000-01-01T01:00:00.000Z"),
+		Node:      "node1",
+		Lock:      lock,
+	}
+	taskKey := etcdop.NewTypedPrefix[task.Task](task.EtcdPrefix, d.EtcdSerde()).Key(taskValue.Key.String())
+	require.NoError(t, taskKey.Put(client, taskValue).Do(ctx).Err())
+	_, err = client.Put(ctx, "runtime/distribution/group/my-group/nodes/node1", `{"nodeId":"node1","weight":2}`)
+	require.NoError(t, err)
+
+	// List
+	require.NoError(t, run(false, false, "list", "task-locks"))
+	assert.Equal(t, `[
+  {
+    "name": "live-lock",
+    "key": "runtime/lock/task/live-lock",
+    "node": "node2",
+    "leased": true
+  },
+  {
+    "name": "my-lock",
+    "key": "runtime/lock/task/my-lock",
+    "node": "node1",
+    "leased": false
+  }
+]
+`, stdout.String())
+	require.NoError(t, run(false, false, "list", "tasks", "123/"))
+	assert.Contains(t, stdout.String(), `"taskId": "my-task"`)
+	require.NoError(t, run(false, false, "list", "nodes"))
+	assert.Equal(t, `[
+  {
+    "group": "my-group",
+    "nodeId": "node1",
+    "weight": 2
+  }
+]
+`, stdout.String())
+
+	// Remove without confirmation, nothing is changed
+	require.NoError(t, run(false, false, "repair", "remove-task-lock", "my-lock"))
+	assert.Equal(t, "The task lock \"my-lock\" will be removed, if the lease of its owner session has expired.\nUse the --confirm flag to proceed.\n", stdout.String())
+	resp, err := client.Get(ctx, lock.Key())
+	require.NoError(t, err)
+	assert.Len(t, resp.Kvs, 1)
+
+	// Remove with confirmation, the lock has no lease, so it is stale
+	require.NoError(t, run(true, false, "repair", "remove-task-lock", "my-lock"))
+	assert.Equal(t, "The task lock \"my-lock\" has been removed.\n", stdout.String())
+	resp, err = client.Get(ctx, lock.Key())
+	require.NoError(t, err)
+	assert.Empty(t, resp.Kvs)
+
+	// The lock doesn't exist
+	err = run(true, false, "repair", "remove-task-lock", "my-lock")
+	if assert.Error(t, err) {
+		assert.Equal(t, `the lock "my-lock" not found`, err.Error())
+	}
+
+	// The lease of the owner session is alive
+	err = run(true, false, "repair", "remove-task-lock", "live-lock")
+	if assert.Error(t, err) {
+		assert.ErrorAs(t, err, &task.LockInUseError{})
+		assert.Equal(t, `the lock "live-lock" is held by the node "node2", the lease of its session is alive`, err.Error())
+	}
+
+	// Remove the live lock by force
+	require.NoError(t, run(true, true, "repair", "remove-task-lock", "live-lock"))
+	assert.Equal(t, "The task lock \"live-lock\" has been removed.\n", stdout.String())
+	resp, err = client.Get(ctx, liveLock.Key())
+	require.NoError(t, err)
+	assert.Empty(t, resp.Kvs)
+}
+
+func TestAdmin_Locks(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	d, mocked := dependencies.NewMockedAdminScope(t, ctx)
+	client := mocked.TestEtcdClient()
+
+	var stdout bytes.Buffer
+	run := func(confirm, force bool, args ...string) error {
+		stdout.Reset()
+		return admin.New(d, &stdout, admin.Config{Confirm: confirm, Force: force}).Run(ctx, args)
+	}
+
+	// No lock
+	require.NoError(t, run(false, false, "list", "locks"))
+	assert.Equal(t, "[]\n", stdout.String())
+
+	// Lock a project, the same way as the Templates service
+	session, err := concurrency.NewSession(client)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, session.Close()) }()
+	require.NoError(t, concurrency.NewMutex(session, "lock/project/123").Lock(ctx))
+	require.NoError(t, concurrency.NewMutex(session, "lock/other").Lock(ctx))
+
+	// List
+	require.NoError(t, run(false, false, "list", "locks", "project/"))
+	assert.Equal(t, fmt.Sprintf(`[
+  {
+    "name": "project/123",
+    "key": "lock/project/123/%x",
+    "lease": "%x"
+  }
+]
+`, session.Lease(), session.Lease()), stdout.String())
+
+	// The lease of the session is alive
+	err = run(true, false, "repair", "remove-lock", "project/123")
+	if assert.Error(t, err) {
+		assert.ErrorAs(t, err, &distlock.LockInUseError{})
+		assert.Equal(t, fmt.Sprintf(`the lock "project/123" is held by a session with the lease "%x", the lease is alive`, session.Lease()), err.Error())
+	}
+
+	// Remove by force, without confirmation, nothing is changed
+	require.NoError(t, run(false, true, "repair", "remove-lock", "project/123"))
+	assert.Equal(t, "The lock \"project/123\" will be removed, even if the lease of its owner session is alive.\nUse the --confirm flag to proceed.\n", stdout.String())
+
+	// Remove by force, with confirmation
+	require.NoError(t, run(true, true, "repair", "remove-lock", "project/123"))
+	assert.Equal(t, "The lock \"project/123\" has been removed.\n", stdout.String())
+	require.NoError(t, run(false, false, "list", "locks"))
+	assert.Contains(t, stdout.String(), `"name": "other"`)
+	assert.NotContains(t, stdout.String(), `"name": "project/123"`)
+
+	// The lock doesn't exist
+	err = run(true, true, "repair", "remove-lock", "project/123")
+	if assert.Error(t, err) {
+		assert.Equal(t, `the lock "project/123" not found`, err.Error())
+	}
+}
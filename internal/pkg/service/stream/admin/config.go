@@ -0,0 +1,17 @@
+package admin
+
+import (
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/config"
+)
+
+// Config of the admin command.
+// The Stream service configuration is reused, so the command connects to the same etcd cluster as the service.
+type Config struct {
+	config.Config `configKey:",squash"`
+	Confirm       bool `configKey:"confirm" configUsage:"Confirm a repair action, without the flag the action is only described."`
+	Force         bool `configKey:"force" configUsage:"Remove a lock, even if the lease of its owner session is alive."`
+}
+
+func NewConfig() Config {
+	return Config{Config: config.New()}
+}
@@ -0,0 +1,83 @@
+package admin
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/keboola/go-client/pkg/keboola"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/utctime"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
+	volume "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/volume/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+// Number of parts of each object key, the parts are separated by "/".
+const (
+	projectKeyParts = iota + 1
+	branchKeyParts
+	sourceKeyParts
+	sinkKeyParts
+	fileKeyParts
+	fileVolumeKeyParts
+	sliceKeyParts
+)
+
+// parseObjectKey parses the object key from the string, for example "123/456/my-source/my-sink".
+// The type of the result depends on the number of parts, the maxParts limits the deepest accepted object.
+func parseObjectKey(str string, maxParts int) (fmt.Stringer, error) {
+	parts := strings.Split(strings.Trim(str, "/"), "/")
+	if len(parts) > maxParts {
+		return nil, errors.Errorf(`invalid object key "%s": expected at most %d parts, found %d`, str, maxParts, len(parts))
+	}
+
+	projectID, err := strconv.Atoi(parts[0])
+	if err != nil || projectID <= 0 {
+		return nil, errors.Errorf(`invalid object key "%s": invalid project ID "%s"`, str, parts[0])
+	}
+	projectKey := keboola.ProjectID(projectID)
+	if len(parts) == projectKeyParts {
+		return projectKey, nil
+	}
+
+	branchID, err := strconv.Atoi(parts[1])
+	if err != nil || branchID <= 0 {
+		return nil, errors.Errorf(`invalid object key "%s": invalid branch ID "%s"`, str, parts[1])
+	}
+	branchKey := key.BranchKey{ProjectID: projectKey, BranchID: keboola.BranchID(branchID)}
+	if len(parts) == branchKeyParts {
+		return branchKey, nil
+	}
+
+	sourceKey := key.SourceKey{BranchKey: branchKey, SourceID: key.SourceID(parts[2])}
+	if len(parts) == sourceKeyParts {
+		return sourceKey, nil
+	}
+
+	sinkKey := key.SinkKey{SourceKey: sourceKey, SinkID: key.SinkID(parts[3])}
+	if len(parts) == sinkKeyParts {
+		return sinkKey, nil
+	}
+
+	fileOpenedAt, err := utctime.Parse(parts[4])
+	if err != nil {
+		return nil, errors.Errorf(`invalid object key "%s": invalid file ID "%s"`, str, parts[4])
+	}
+	fileKey := model.FileKey{SinkKey: sinkKey, FileID: model.FileID{OpenedAt: fileOpenedAt}}
+	if len(parts) == fileKeyParts {
+		return fileKey, nil
+	}
+
+	fileVolumeKey := model.FileVolumeKey{FileKey: fileKey, VolumeID: volume.ID(parts[5])}
+	if len(parts) == fileVolumeKeyParts {
+		return fileVolumeKey, nil
+	}
+
+	sliceOpenedAt, err := utctime.Parse(parts[6])
+	if err != nil {
+		return nil, errors.Errorf(`invalid object key "%s": invalid slice ID "%s"`, str, parts[6])
+	}
+	return model.SliceKey{FileVolumeKey: fileVolumeKey, SliceID: model.SliceID{OpenedAt: sliceOpenedAt}}, nil
+}
@@ -0,0 +1,174 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/distribution"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/task"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+func (a *Admin) list(ctx context.Context, subcommand string, args []string) error {
+	switch subcommand {
+	case "sources":
+		return a.listSources(ctx, args)
+	case "sinks":
+		return a.listSinks(ctx, args)
+	case "files":
+		return a.listFiles(ctx, args)
+	case "slices":
+		return a.listSlices(ctx, args)
+	case "tasks":
+		return a.listTasks(ctx, args)
+	case "task-locks":
+		return a.listTaskLocks(ctx, args)
+	case "locks":
+		return a.listLocks(ctx, args)
+	case "nodes":
+		return a.listNodes(ctx, args)
+	default:
+		return UsageError{errors.Errorf("unexpected list subcommand \"%s\"\n%s", subcommand, Usage)}
+	}
+}
+
+func (a *Admin) listSources(ctx context.Context, args []string) error {
+	parentKey, err := parentKeyArg(args, branchKeyParts, true)
+	if err != nil {
+		return err
+	}
+
+	var sources []definition.Source
+	if err := a.definition.Source().List(parentKey).Do(ctx).AllTo(&sources); err != nil {
+		return err
+	}
+
+	return printList(a, sources)
+}
+
+func (a *Admin) listSinks(ctx context.Context, args []string) error {
+	parentKey, err := parentKeyArg(args, sourceKeyParts, true)
+	if err != nil {
+		return err
+	}
+
+	var sinks []definition.Sink
+	if err := a.definition.Sink().List(parentKey).Do(ctx).AllTo(&sinks); err != nil {
+		return err
+	}
+
+	return printList(a, sinks)
+}
+
+func (a *Admin) listFiles(ctx context.Context, args []string) error {
+	parentKey, err := parentKeyArg(args, sinkKeyParts, false)
+	if err != nil {
+		return err
+	}
+
+	var files []model.File
+	repo := a.storage.File()
+	if parentKey == nil {
+		err = repo.ListAll().Do(ctx).AllTo(&files)
+	} else {
+		err = repo.ListIn(parentKey).Do(ctx).AllTo(&files)
+	}
+	if err != nil {
+		return err
+	}
+
+	return printList(a, files)
+}
+
+func (a *Admin) listSlices(ctx context.Context, args []string) error {
+	parentKey, err := parentKeyArg(args, fileVolumeKeyParts, false)
+	if err != nil {
+		return err
+	}
+
+	var slices []model.Slice
+	repo := a.storage.Slice()
+	if parentKey == nil {
+		err = repo.ListAll().Do(ctx).AllTo(&slices)
+	} else {
+		err = repo.ListIn(parentKey).Do(ctx).AllTo(&slices)
+	}
+	if err != nil {
+		return err
+	}
+
+	return printList(a, slices)
+}
+
+func (a *Admin) listTasks(ctx context.Context, args []string) error {
+	keyPrefix, err := optionalArg(args)
+	if err != nil {
+		return err
+	}
+
+	var tasks []task.Task
+	if err := a.tasks.ListAll(keyPrefix).Do(ctx).AllTo(&tasks); err != nil {
+		return err
+	}
+
+	return printList(a, tasks)
+}
+
+func (a *Admin) listTaskLocks(ctx context.Context, args []string) error {
+	if len(args) > 0 {
+		return UsageError{errors.Errorf("unexpected arguments\n%s", Usage)}
+	}
+
+	locks, err := a.tasks.ListLocks(ctx)
+	if err != nil {
+		return err
+	}
+	return printList(a, locks)
+}
+
+func (a *Admin) listLocks(ctx context.Context, args []string) error {
+	namePrefix, err := optionalArg(args)
+	if err != nil {
+		return err
+	}
+
+	locks, err := a.locks.ListLocks(ctx, namePrefix)
+	if err != nil {
+		return err
+	}
+	return printList(a, locks)
+}
+
+func (a *Admin) listNodes(ctx context.Context, args []string) error {
+	if len(args) > 0 {
+		return UsageError{errors.Errorf("unexpected arguments\n%s", Usage)}
+	}
+
+	nodes, err := distribution.ListNodes(ctx, a.client)
+	if err != nil {
+		return err
+	}
+	return printList(a, nodes)
+}
+
+// parentKeyArg parses the parent object key from the arguments, the nil value means no parent, if the parent is optional.
+func parentKeyArg(args []string, maxParts int, required bool) (fmt.Stringer, error) {
+	var str string
+	var err error
+	if required {
+		str, err = requiredArg(args, "parent key")
+	} else {
+		str, err = optionalArg(args)
+	}
+	if err != nil || str == "" {
+		return nil, err
+	}
+
+	parentKey, err := parseObjectKey(str, maxParts)
+	if err != nil {
+		return nil, UsageError{err}
+	}
+	return parentKey, nil
+}
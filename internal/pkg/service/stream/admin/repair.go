@@ -0,0 +1,110 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+func (a *Admin) repair(ctx context.Context, subcommand string, args []string) error {
+	switch subcommand {
+	case "close-file":
+		return a.closeFile(ctx, args)
+	case "remove-task-lock":
+		return a.removeTaskLock(ctx, args)
+	case "remove-lock":
+		return a.removeLock(ctx, args)
+	default:
+		return UsageError{errors.Errorf("unexpected repair subcommand \"%s\"\n%s", subcommand, Usage)}
+	}
+}
+
+// closeFile force-closes the active file in the model.FileWriting state, a new file is opened in the sink.
+// It is the same operation as the rotation of the file, when the import conditions are met.
+func (a *Admin) closeFile(ctx context.Context, args []string) error {
+	str, err := requiredArg(args, "file key")
+	if err != nil {
+		return err
+	}
+
+	objectKey, err := parseObjectKey(str, fileKeyParts)
+	if err != nil {
+		return UsageError{err}
+	}
+	fileKey, ok := objectKey.(model.FileKey)
+	if !ok {
+		return UsageError{errors.Errorf(`invalid file key "%s"`, str)}
+	}
+
+	file, err := a.storage.File().Get(fileKey).Do(ctx).ResultOrErr()
+	if err != nil {
+		return err
+	}
+
+	if file.State != model.FileWriting {
+		return errors.Errorf(`file "%s" is in the "%s" state, only a file in the "%s" state can be closed`, file.FileKey, file.State, model.FileWriting)
+	}
+
+	if !a.confirm {
+		return a.printf(`The file "%s" will be closed and a new file will be opened in the sink "%s".`+"\n"+`Use the --confirm flag to proceed.`, file.FileKey, file.SinkKey)
+	}
+
+	// The Keboola sink bridge opens a new file in the Storage API
+	if err := a.enableBridge(ctx); err != nil {
+		return errors.PrefixError(err, "cannot connect to the Storage API")
+	}
+
+	opened, err := a.storage.File().Rotate(file.SinkKey, a.clock.Now()).Do(ctx).ResultOrErr()
+	if err != nil {
+		return err
+	}
+
+	if err := a.printf(`The file "%s" has been closed, opened a new file "%s".`, file.FileKey, opened.FileKey); err != nil {
+		return err
+	}
+	return a.print(opened)
+}
+
+// removeTaskLock removes a stale task lock, the lease of the owner session must be expired, unless the force flag is set.
+func (a *Admin) removeTaskLock(ctx context.Context, args []string) error {
+	lock, err := requiredArg(args, "lock")
+	if err != nil {
+		return err
+	}
+
+	if !a.confirm {
+		return a.printf(`The task lock "%s" will be removed, %s.`+"\n"+`Use the --confirm flag to proceed.`, lock, a.removeLockCondition())
+	}
+
+	if err := a.tasks.RemoveLock(lock, a.force).Do(ctx).Err(); err != nil {
+		return err
+	}
+
+	return a.printf(`The task lock "%s" has been removed.`, lock)
+}
+
+// removeLock removes a stale distributed lock, the leases of all sessions must be expired, unless the force flag is set.
+func (a *Admin) removeLock(ctx context.Context, args []string) error {
+	lock, err := requiredArg(args, "lock")
+	if err != nil {
+		return err
+	}
+
+	if !a.confirm {
+		return a.printf(`The lock "%s" will be removed, %s.`+"\n"+`Use the --confirm flag to proceed.`, lock, a.removeLockCondition())
+	}
+
+	if err := a.locks.RemoveLock(lock, a.force).Do(ctx).Err(); err != nil {
+		return err
+	}
+
+	return a.printf(`The lock "%s" has been removed.`, lock)
+}
+
+func (a *Admin) removeLockCondition() string {
+	if a.force {
+		return "even if the lease of its owner session is alive"
+	}
+	return "if the lease of its owner session has expired"
+}
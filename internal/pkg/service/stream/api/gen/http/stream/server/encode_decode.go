@@ -2361,6 +2361,149 @@ func EncodeGetTaskError(encoder func(context.Context, http.ResponseWriter) goaht
 	}
 }
 
+// EncodeCancelTaskResponse returns an encoder for responses returned by the
+// stream CancelTask endpoint.
+func EncodeCancelTaskResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*stream.Task)
+		enc := encoder(ctx, w)
+		body := NewCancelTaskResponseBody(res)
+		w.WriteHeader(http.StatusAccepted)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeCancelTaskRequest returns a decoder for requests sent to the stream
+// CancelTask endpoint.
+func DecodeCancelTaskRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+	return func(r *http.Request) (any, error) {
+		var (
+			taskID          string
+			storageAPIToken string
+			err             error
+
+			params = mux.Vars(r)
+		)
+		taskID = params["taskId"]
+		storageAPIToken = r.Header.Get("X-StorageApi-Token")
+		if storageAPIToken == "" {
+			err = goa.MergeErrors(err, goa.MissingFieldError("X-StorageApi-Token", "header"))
+		}
+		if err != nil {
+			return nil, err
+		}
+		payload := NewCancelTaskPayload(taskID, storageAPIToken)
+		if strings.Contains(payload.StorageAPIToken, " ") {
+			// Remove authorization scheme prefix (e.g. "Bearer")
+			cred := strings.SplitN(payload.StorageAPIToken, " ", 2)[1]
+			payload.StorageAPIToken = cred
+		}
+
+		return payload, nil
+	}
+}
+
+// EncodeCancelTaskError returns an encoder for errors returned by the
+// CancelTask stream endpoint.
+func EncodeCancelTaskError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder, formatter func(ctx context.Context, err error) goahttp.Statuser) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ErrorEncoder(encoder, formatter)
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		var en goa.GoaErrorNamer
+		if !errors.As(v, &en) {
+			return encodeError(ctx, w, v)
+		}
+		switch en.GoaErrorName() {
+		case "stream.api.taskNotFound":
+			var res *stream.GenericError
+			errors.As(v, &res)
+			res.StatusCode = http.StatusNotFound
+			enc := encoder(ctx, w)
+			var body any
+			if false { // formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewCancelTaskStreamAPITaskNotFoundResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		case "stream.api.taskFinished":
+			var res *stream.GenericError
+			errors.As(v, &res)
+			res.StatusCode = http.StatusBadRequest
+			enc := encoder(ctx, w)
+			var body any
+			if false { // formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewCancelTaskStreamAPITaskFinishedResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusBadRequest)
+			return enc.Encode(body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+
+// EncodeListAuditLogResponse returns an encoder for responses returned by the
+// stream ListAuditLog endpoint.
+func EncodeListAuditLogResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*stream.AuditLog)
+		enc := encoder(ctx, w)
+		body := NewListAuditLogResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeListAuditLogRequest returns a decoder for requests sent to the stream
+// ListAuditLog endpoint.
+func DecodeListAuditLogRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+	return func(r *http.Request) (any, error) {
+		var (
+			limit           int
+			storageAPIToken string
+			err             error
+		)
+		{
+			limitRaw := r.URL.Query().Get("limit")
+			if limitRaw == "" {
+				limit = 100
+			} else {
+				v, err2 := strconv.ParseInt(limitRaw, 10, strconv.IntSize)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("limit", limitRaw, "integer"))
+				}
+				limit = int(v)
+			}
+		}
+		if limit < 1 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError("limit", limit, 1, true))
+		}
+		if limit > 1000 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError("limit", limit, 1000, false))
+		}
+		storageAPIToken = r.Header.Get("X-StorageApi-Token")
+		if storageAPIToken == "" {
+			err = goa.MergeErrors(err, goa.MissingFieldError("X-StorageApi-Token", "header"))
+		}
+		if err != nil {
+			return nil, err
+		}
+		payload := NewListAuditLogPayload(limit, storageAPIToken)
+		if strings.Contains(payload.StorageAPIToken, " ") {
+			// Remove authorization scheme prefix (e.g. "Bearer")
+			cred := strings.SplitN(payload.StorageAPIToken, " ", 2)[1]
+			payload.StorageAPIToken = cred
+		}
+
+		return payload, nil
+	}
+}
+
 // EncodeAggregationSourcesResponse returns an encoder for responses returned
 // by the stream AggregationSources endpoint.
 func EncodeAggregationSourcesResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
@@ -2428,6 +2571,22 @@ func DecodeAggregationSourcesRequest(mux goahttp.Muxer, decoder func(*http.Reque
 	}
 }
 
+// marshalStreamTaskProgressToTaskProgressResponseBody builds a value of type
+// *TaskProgressResponseBody from a value of type *stream.TaskProgress.
+func marshalStreamTaskProgressToTaskProgressResponseBody(v *stream.TaskProgress) *TaskProgressResponseBody {
+	if v == nil {
+		return nil
+	}
+	res := &TaskProgressResponseBody{
+		Percent:   v.Percent,
+		Step:      v.Step,
+		Message:   v.Message,
+		UpdatedAt: v.UpdatedAt,
+	}
+
+	return res
+}
+
 // marshalStreamTaskOutputsToTaskOutputsResponseBody builds a value of type
 // *TaskOutputsResponseBody from a value of type *stream.TaskOutputs.
 func marshalStreamTaskOutputsToTaskOutputsResponseBody(v *stream.TaskOutputs) *TaskOutputsResponseBody {
@@ -2933,6 +3092,61 @@ func marshalStreamSinkFileStatisticsToSinkFileStatisticsResponseBody(v *stream.S
 	return res
 }
 
+// marshalStreamAuditEntryToAuditEntryResponseBody builds a value of type
+// *AuditEntryResponseBody from a value of type *stream.AuditEntry.
+func marshalStreamAuditEntryToAuditEntryResponseBody(v *stream.AuditEntry) *AuditEntryResponseBody {
+	res := &AuditEntryResponseBody{
+		ID:         v.ID,
+		Created:    v.Created,
+		RequestID:  v.RequestID,
+		Endpoint:   v.Endpoint,
+		HTTPMethod: v.HTTPMethod,
+		HTTPPath:   v.HTTPPath,
+		ObjectKey:  v.ObjectKey,
+	}
+	if v.TaskID != nil {
+		taskID := string(*v.TaskID)
+		res.TaskID = &taskID
+	}
+	if v.By != nil {
+		res.By = marshalStreamByToByResponseBody(v.By)
+	}
+	if v.Before != nil {
+		res.Before = make(map[string]any, len(v.Before))
+		for key, val := range v.Before {
+			tk := key
+			tv := val
+			res.Before[tk] = tv
+		}
+	}
+	if v.After != nil {
+		res.After = make(map[string]any, len(v.After))
+		for key, val := range v.After {
+			tk := key
+			tv := val
+			res.After[tk] = tv
+		}
+	}
+	if v.Result != nil {
+		res.Result = marshalStreamAuditResultToAuditResultResponseBody(v.Result)
+	}
+
+	return res
+}
+
+// marshalStreamAuditResultToAuditResultResponseBody builds a value of type
+// *AuditResultResponseBody from a value of type *stream.AuditResult.
+func marshalStreamAuditResultToAuditResultResponseBody(v *stream.AuditResult) *AuditResultResponseBody {
+	res := &AuditResultResponseBody{
+		Success:    v.Success,
+		StatusCode: v.StatusCode,
+		ErrorName:  v.ErrorName,
+		Error:      v.Error,
+	}
+
+	return res
+}
+
 // marshalStreamAggregatedSourceToAggregatedSourceResponseBody builds a value
 // of type *AggregatedSourceResponseBody from a value of type
 // *stream.AggregatedSource.
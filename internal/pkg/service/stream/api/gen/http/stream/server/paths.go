@@ -147,6 +147,16 @@ func GetTaskStreamPath(taskID string) string {
 	return fmt.Sprintf("/v1/tasks/%v", taskID)
 }
 
+// CancelTaskStreamPath returns the URL path to the stream service CancelTask HTTP endpoint.
+func CancelTaskStreamPath(taskID string) string {
+	return fmt.Sprintf("/v1/tasks/%v", taskID)
+}
+
+// ListAuditLogStreamPath returns the URL path to the stream service ListAuditLog HTTP endpoint.
+func ListAuditLogStreamPath() string {
+	return "/v1/audit"
+}
+
 // AggregationSourcesStreamPath returns the URL path to the stream service AggregationSources HTTP endpoint.
 func AggregationSourcesStreamPath(branchID string) string {
 	return fmt.Sprintf("/v1/branches/%v/aggregation/sources", branchID)
@@ -48,6 +48,8 @@ type Server struct {
 	DisableSink           http.Handler
 	EnableSink            http.Handler
 	GetTask               http.Handler
+	CancelTask            http.Handler
+	ListAuditLog          http.Handler
 	AggregationSources    http.Handler
 	CORS                  http.Handler
 	OpenapiJSON           http.Handler
@@ -131,6 +133,8 @@ func New(
 			{"DisableSink", "PUT", "/v1/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/disable"},
 			{"EnableSink", "PUT", "/v1/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/enable"},
 			{"GetTask", "GET", "/v1/tasks/{*taskId}"},
+			{"CancelTask", "DELETE", "/v1/tasks/{*taskId}"},
+			{"ListAuditLog", "GET", "/v1/audit"},
 			{"AggregationSources", "GET", "/v1/branches/{branchId}/aggregation/sources"},
 			{"CORS", "OPTIONS", "/"},
 			{"CORS", "OPTIONS", "/v1"},
@@ -151,6 +155,7 @@ func New(
 			{"CORS", "OPTIONS", "/v1/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/disable"},
 			{"CORS", "OPTIONS", "/v1/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/enable"},
 			{"CORS", "OPTIONS", "/v1/tasks/{*taskId}"},
+			{"CORS", "OPTIONS", "/v1/audit"},
 			{"CORS", "OPTIONS", "/v1/branches/{branchId}/aggregation/sources"},
 			{"CORS", "OPTIONS", "/v1/documentation/openapi.json"},
 			{"CORS", "OPTIONS", "/v1/documentation/openapi.yaml"},
@@ -190,6 +195,8 @@ func New(
 		DisableSink:           NewDisableSinkHandler(e.DisableSink, mux, decoder, encoder, errhandler, formatter),
 		EnableSink:            NewEnableSinkHandler(e.EnableSink, mux, decoder, encoder, errhandler, formatter),
 		GetTask:               NewGetTaskHandler(e.GetTask, mux, decoder, encoder, errhandler, formatter),
+		CancelTask:            NewCancelTaskHandler(e.CancelTask, mux, decoder, encoder, errhandler, formatter),
+		ListAuditLog:          NewListAuditLogHandler(e.ListAuditLog, mux, decoder, encoder, errhandler, formatter),
 		AggregationSources:    NewAggregationSourcesHandler(e.AggregationSources, mux, decoder, encoder, errhandler, formatter),
 		CORS:                  NewCORSHandler(),
 		OpenapiJSON:           http.FileServer(fileSystemOpenapiJSON),
@@ -232,6 +239,8 @@ func (s *Server) Use(m func(http.Handler) http.Handler) {
 	s.DisableSink = m(s.DisableSink)
 	s.EnableSink = m(s.EnableSink)
 	s.GetTask = m(s.GetTask)
+	s.CancelTask = m(s.CancelTask)
+	s.ListAuditLog = m(s.ListAuditLog)
 	s.AggregationSources = m(s.AggregationSources)
 	s.CORS = m(s.CORS)
 }
@@ -268,6 +277,8 @@ func Mount(mux goahttp.Muxer, h *Server) {
 	MountDisableSinkHandler(mux, h.DisableSink)
 	MountEnableSinkHandler(mux, h.EnableSink)
 	MountGetTaskHandler(mux, h.GetTask)
+	MountCancelTaskHandler(mux, h.CancelTask)
+	MountListAuditLogHandler(mux, h.ListAuditLog)
 	MountAggregationSourcesHandler(mux, h.AggregationSources)
 	MountCORSHandler(mux, h.CORS)
 	MountOpenapiJSON(mux, goahttp.Replace("", "/openapi.json", h.OpenapiJSON))
@@ -1625,6 +1636,108 @@ func NewGetTaskHandler(
 	})
 }
 
+// MountCancelTaskHandler configures the mux to serve the "stream" service
+// "CancelTask" endpoint.
+func MountCancelTaskHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := HandleStreamOrigin(h).(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("DELETE", "/v1/tasks/{*taskId}", f)
+}
+
+// NewCancelTaskHandler creates a HTTP handler which loads the HTTP request and
+// calls the "stream" service "CancelTask" endpoint.
+func NewCancelTaskHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeCancelTaskRequest(mux, decoder)
+		encodeResponse = EncodeCancelTaskResponse(encoder)
+		encodeError    = EncodeCancelTaskError(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "CancelTask")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "stream")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+
+// MountListAuditLogHandler configures the mux to serve the "stream" service
+// "ListAuditLog" endpoint.
+func MountListAuditLogHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := HandleStreamOrigin(h).(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/v1/audit", f)
+}
+
+// NewListAuditLogHandler creates a HTTP handler which loads the HTTP request
+// and calls the "stream" service "ListAuditLog" endpoint.
+func NewListAuditLogHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeListAuditLogRequest(mux, decoder)
+		encodeResponse = EncodeListAuditLogResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "ListAuditLog")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "stream")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+
 // MountAggregationSourcesHandler configures the mux to serve the "stream"
 // service "AggregationSources" endpoint.
 func MountAggregationSourcesHandler(mux goahttp.Muxer, h http.Handler) {
@@ -1730,6 +1843,7 @@ func MountCORSHandler(mux goahttp.Muxer, h http.Handler) {
 	mux.Handle("OPTIONS", "/v1/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/disable", h.ServeHTTP)
 	mux.Handle("OPTIONS", "/v1/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/enable", h.ServeHTTP)
 	mux.Handle("OPTIONS", "/v1/tasks/{*taskId}", h.ServeHTTP)
+	mux.Handle("OPTIONS", "/v1/audit", h.ServeHTTP)
 	mux.Handle("OPTIONS", "/v1/branches/{branchId}/aggregation/sources", h.ServeHTTP)
 	mux.Handle("OPTIONS", "/v1/documentation/openapi.json", h.ServeHTTP)
 	mux.Handle("OPTIONS", "/v1/documentation/openapi.yaml", h.ServeHTTP)
@@ -112,11 +112,14 @@ type CreateSourceResponseBody struct {
 	CreatedAt string `form:"createdAt" json:"createdAt" xml:"createdAt"`
 	// Date and time of the task end.
 	FinishedAt *string `form:"finishedAt,omitempty" json:"finishedAt,omitempty" xml:"finishedAt,omitempty"`
+	// Date and time of the task cancellation.
+	CancelledAt *string `form:"cancelledAt,omitempty" json:"cancelledAt,omitempty" xml:"cancelledAt,omitempty"`
 	// Duration of the task in milliseconds.
-	Duration *int64                   `form:"duration,omitempty" json:"duration,omitempty" xml:"duration,omitempty"`
-	Result   *string                  `form:"result,omitempty" json:"result,omitempty" xml:"result,omitempty"`
-	Error    *string                  `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
-	Outputs  *TaskOutputsResponseBody `form:"outputs,omitempty" json:"outputs,omitempty" xml:"outputs,omitempty"`
+	Duration *int64                    `form:"duration,omitempty" json:"duration,omitempty" xml:"duration,omitempty"`
+	Progress *TaskProgressResponseBody `form:"progress,omitempty" json:"progress,omitempty" xml:"progress,omitempty"`
+	Result   *string                   `form:"result,omitempty" json:"result,omitempty" xml:"result,omitempty"`
+	Error    *string                   `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	Outputs  *TaskOutputsResponseBody  `form:"outputs,omitempty" json:"outputs,omitempty" xml:"outputs,omitempty"`
 }
 
 // UpdateSourceResponseBody is the type of the "stream" service "UpdateSource"
@@ -135,11 +138,14 @@ type UpdateSourceResponseBody struct {
 	CreatedAt string `form:"createdAt" json:"createdAt" xml:"createdAt"`
 	// Date and time of the task end.
 	FinishedAt *string `form:"finishedAt,omitempty" json:"finishedAt,omitempty" xml:"finishedAt,omitempty"`
+	// Date and time of the task cancellation.
+	CancelledAt *string `form:"cancelledAt,omitempty" json:"cancelledAt,omitempty" xml:"cancelledAt,omitempty"`
 	// Duration of the task in milliseconds.
-	Duration *int64                   `form:"duration,omitempty" json:"duration,omitempty" xml:"duration,omitempty"`
-	Result   *string                  `form:"result,omitempty" json:"result,omitempty" xml:"result,omitempty"`
-	Error    *string                  `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
-	Outputs  *TaskOutputsResponseBody `form:"outputs,omitempty" json:"outputs,omitempty" xml:"outputs,omitempty"`
+	Duration *int64                    `form:"duration,omitempty" json:"duration,omitempty" xml:"duration,omitempty"`
+	Progress *TaskProgressResponseBody `form:"progress,omitempty" json:"progress,omitempty" xml:"progress,omitempty"`
+	Result   *string                   `form:"result,omitempty" json:"result,omitempty" xml:"result,omitempty"`
+	Error    *string                   `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	Outputs  *TaskOutputsResponseBody  `form:"outputs,omitempty" json:"outputs,omitempty" xml:"outputs,omitempty"`
 }
 
 // ListSourcesResponseBody is the type of the "stream" service "ListSources"
@@ -186,11 +192,14 @@ type DeleteSourceResponseBody struct {
 	CreatedAt string `form:"createdAt" json:"createdAt" xml:"createdAt"`
 	// Date and time of the task end.
 	FinishedAt *string `form:"finishedAt,omitempty" json:"finishedAt,omitempty" xml:"finishedAt,omitempty"`
+	// Date and time of the task cancellation.
+	CancelledAt *string `form:"cancelledAt,omitempty" json:"cancelledAt,omitempty" xml:"cancelledAt,omitempty"`
 	// Duration of the task in milliseconds.
-	Duration *int64                   `form:"duration,omitempty" json:"duration,omitempty" xml:"duration,omitempty"`
-	Result   *string                  `form:"result,omitempty" json:"result,omitempty" xml:"result,omitempty"`
-	Error    *string                  `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
-	Outputs  *TaskOutputsResponseBody `form:"outputs,omitempty" json:"outputs,omitempty" xml:"outputs,omitempty"`
+	Duration *int64                    `form:"duration,omitempty" json:"duration,omitempty" xml:"duration,omitempty"`
+	Progress *TaskProgressResponseBody `form:"progress,omitempty" json:"progress,omitempty" xml:"progress,omitempty"`
+	Result   *string                   `form:"result,omitempty" json:"result,omitempty" xml:"result,omitempty"`
+	Error    *string                   `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	Outputs  *TaskOutputsResponseBody  `form:"outputs,omitempty" json:"outputs,omitempty" xml:"outputs,omitempty"`
 }
 
 // GetSourceSettingsResponseBody is the type of the "stream" service
@@ -215,11 +224,14 @@ type UpdateSourceSettingsResponseBody struct {
 	CreatedAt string `form:"createdAt" json:"createdAt" xml:"createdAt"`
 	// Date and time of the task end.
 	FinishedAt *string `form:"finishedAt,omitempty" json:"finishedAt,omitempty" xml:"finishedAt,omitempty"`
+	// Date and time of the task cancellation.
+	CancelledAt *string `form:"cancelledAt,omitempty" json:"cancelledAt,omitempty" xml:"cancelledAt,omitempty"`
 	// Duration of the task in milliseconds.
-	Duration *int64                   `form:"duration,omitempty" json:"duration,omitempty" xml:"duration,omitempty"`
-	Result   *string                  `form:"result,omitempty" json:"result,omitempty" xml:"result,omitempty"`
-	Error    *string                  `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
-	Outputs  *TaskOutputsResponseBody `form:"outputs,omitempty" json:"outputs,omitempty" xml:"outputs,omitempty"`
+	Duration *int64                    `form:"duration,omitempty" json:"duration,omitempty" xml:"duration,omitempty"`
+	Progress *TaskProgressResponseBody `form:"progress,omitempty" json:"progress,omitempty" xml:"progress,omitempty"`
+	Result   *string                   `form:"result,omitempty" json:"result,omitempty" xml:"result,omitempty"`
+	Error    *string                   `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	Outputs  *TaskOutputsResponseBody  `form:"outputs,omitempty" json:"outputs,omitempty" xml:"outputs,omitempty"`
 }
 
 // TestSourceResponseBody is the type of the "stream" service "TestSource"
@@ -248,11 +260,14 @@ type DisableSourceResponseBody struct {
 	CreatedAt string `form:"createdAt" json:"createdAt" xml:"createdAt"`
 	// Date and time of the task end.
 	FinishedAt *string `form:"finishedAt,omitempty" json:"finishedAt,omitempty" xml:"finishedAt,omitempty"`
+	// Date and time of the task cancellation.
+	CancelledAt *string `form:"cancelledAt,omitempty" json:"cancelledAt,omitempty" xml:"cancelledAt,omitempty"`
 	// Duration of the task in milliseconds.
-	Duration *int64                   `form:"duration,omitempty" json:"duration,omitempty" xml:"duration,omitempty"`
-	Result   *string                  `form:"result,omitempty" json:"result,omitempty" xml:"result,omitempty"`
-	Error    *string                  `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
-	Outputs  *TaskOutputsResponseBody `form:"outputs,omitempty" json:"outputs,omitempty" xml:"outputs,omitempty"`
+	Duration *int64                    `form:"duration,omitempty" json:"duration,omitempty" xml:"duration,omitempty"`
+	Progress *TaskProgressResponseBody `form:"progress,omitempty" json:"progress,omitempty" xml:"progress,omitempty"`
+	Result   *string                   `form:"result,omitempty" json:"result,omitempty" xml:"result,omitempty"`
+	Error    *string                   `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	Outputs  *TaskOutputsResponseBody  `form:"outputs,omitempty" json:"outputs,omitempty" xml:"outputs,omitempty"`
 }
 
 // EnableSourceResponseBody is the type of the "stream" service "EnableSource"
@@ -271,11 +286,14 @@ type EnableSourceResponseBody struct {
 	CreatedAt string `form:"createdAt" json:"createdAt" xml:"createdAt"`
 	// Date and time of the task end.
 	FinishedAt *string `form:"finishedAt,omitempty" json:"finishedAt,omitempty" xml:"finishedAt,omitempty"`
+	// Date and time of the task cancellation.
+	CancelledAt *string `form:"cancelledAt,omitempty" json:"cancelledAt,omitempty" xml:"cancelledAt,omitempty"`
 	// Duration of the task in milliseconds.
-	Duration *int64                   `form:"duration,omitempty" json:"duration,omitempty" xml:"duration,omitempty"`
-	Result   *string                  `form:"result,omitempty" json:"result,omitempty" xml:"result,omitempty"`
-	Error    *string                  `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
-	Outputs  *TaskOutputsResponseBody `form:"outputs,omitempty" json:"outputs,omitempty" xml:"outputs,omitempty"`
+	Duration *int64                    `form:"duration,omitempty" json:"duration,omitempty" xml:"duration,omitempty"`
+	Progress *TaskProgressResponseBody `form:"progress,omitempty" json:"progress,omitempty" xml:"progress,omitempty"`
+	Result   *string                   `form:"result,omitempty" json:"result,omitempty" xml:"result,omitempty"`
+	Error    *string                   `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	Outputs  *TaskOutputsResponseBody  `form:"outputs,omitempty" json:"outputs,omitempty" xml:"outputs,omitempty"`
 }
 
 // CreateSinkResponseBody is the type of the "stream" service "CreateSink"
@@ -294,11 +312,14 @@ type CreateSinkResponseBody struct {
 	CreatedAt string `form:"createdAt" json:"createdAt" xml:"createdAt"`
 	// Date and time of the task end.
 	FinishedAt *string `form:"finishedAt,omitempty" json:"finishedAt,omitempty" xml:"finishedAt,omitempty"`
+	// Date and time of the task cancellation.
+	CancelledAt *string `form:"cancelledAt,omitempty" json:"cancelledAt,omitempty" xml:"cancelledAt,omitempty"`
 	// Duration of the task in milliseconds.
-	Duration *int64                   `form:"duration,omitempty" json:"duration,omitempty" xml:"duration,omitempty"`
-	Result   *string                  `form:"result,omitempty" json:"result,omitempty" xml:"result,omitempty"`
-	Error    *string                  `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
-	Outputs  *TaskOutputsResponseBody `form:"outputs,omitempty" json:"outputs,omitempty" xml:"outputs,omitempty"`
+	Duration *int64                    `form:"duration,omitempty" json:"duration,omitempty" xml:"duration,omitempty"`
+	Progress *TaskProgressResponseBody `form:"progress,omitempty" json:"progress,omitempty" xml:"progress,omitempty"`
+	Result   *string                   `form:"result,omitempty" json:"result,omitempty" xml:"result,omitempty"`
+	Error    *string                   `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	Outputs  *TaskOutputsResponseBody  `form:"outputs,omitempty" json:"outputs,omitempty" xml:"outputs,omitempty"`
 }
 
 // GetSinkResponseBody is the type of the "stream" service "GetSink" endpoint
@@ -342,11 +363,14 @@ type UpdateSinkSettingsResponseBody struct {
 	CreatedAt string `form:"createdAt" json:"createdAt" xml:"createdAt"`
 	// Date and time of the task end.
 	FinishedAt *string `form:"finishedAt,omitempty" json:"finishedAt,omitempty" xml:"finishedAt,omitempty"`
+	// Date and time of the task cancellation.
+	CancelledAt *string `form:"cancelledAt,omitempty" json:"cancelledAt,omitempty" xml:"cancelledAt,omitempty"`
 	// Duration of the task in milliseconds.
-	Duration *int64                   `form:"duration,omitempty" json:"duration,omitempty" xml:"duration,omitempty"`
-	Result   *string                  `form:"result,omitempty" json:"result,omitempty" xml:"result,omitempty"`
-	Error    *string                  `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
-	Outputs  *TaskOutputsResponseBody `form:"outputs,omitempty" json:"outputs,omitempty" xml:"outputs,omitempty"`
+	Duration *int64                    `form:"duration,omitempty" json:"duration,omitempty" xml:"duration,omitempty"`
+	Progress *TaskProgressResponseBody `form:"progress,omitempty" json:"progress,omitempty" xml:"progress,omitempty"`
+	Result   *string                   `form:"result,omitempty" json:"result,omitempty" xml:"result,omitempty"`
+	Error    *string                   `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	Outputs  *TaskOutputsResponseBody  `form:"outputs,omitempty" json:"outputs,omitempty" xml:"outputs,omitempty"`
 }
 
 // ListSinksResponseBody is the type of the "stream" service "ListSinks"
@@ -375,11 +399,14 @@ type UpdateSinkResponseBody struct {
 	CreatedAt string `form:"createdAt" json:"createdAt" xml:"createdAt"`
 	// Date and time of the task end.
 	FinishedAt *string `form:"finishedAt,omitempty" json:"finishedAt,omitempty" xml:"finishedAt,omitempty"`
+	// Date and time of the task cancellation.
+	CancelledAt *string `form:"cancelledAt,omitempty" json:"cancelledAt,omitempty" xml:"cancelledAt,omitempty"`
 	// Duration of the task in milliseconds.
-	Duration *int64                   `form:"duration,omitempty" json:"duration,omitempty" xml:"duration,omitempty"`
-	Result   *string                  `form:"result,omitempty" json:"result,omitempty" xml:"result,omitempty"`
-	Error    *string                  `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
-	Outputs  *TaskOutputsResponseBody `form:"outputs,omitempty" json:"outputs,omitempty" xml:"outputs,omitempty"`
+	Duration *int64                    `form:"duration,omitempty" json:"duration,omitempty" xml:"duration,omitempty"`
+	Progress *TaskProgressResponseBody `form:"progress,omitempty" json:"progress,omitempty" xml:"progress,omitempty"`
+	Result   *string                   `form:"result,omitempty" json:"result,omitempty" xml:"result,omitempty"`
+	Error    *string                   `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	Outputs  *TaskOutputsResponseBody  `form:"outputs,omitempty" json:"outputs,omitempty" xml:"outputs,omitempty"`
 }
 
 // DeleteSinkResponseBody is the type of the "stream" service "DeleteSink"
@@ -398,11 +425,14 @@ type DeleteSinkResponseBody struct {
 	CreatedAt string `form:"createdAt" json:"createdAt" xml:"createdAt"`
 	// Date and time of the task end.
 	FinishedAt *string `form:"finishedAt,omitempty" json:"finishedAt,omitempty" xml:"finishedAt,omitempty"`
+	// Date and time of the task cancellation.
+	CancelledAt *string `form:"cancelledAt,omitempty" json:"cancelledAt,omitempty" xml:"cancelledAt,omitempty"`
 	// Duration of the task in milliseconds.
-	Duration *int64                   `form:"duration,omitempty" json:"duration,omitempty" xml:"duration,omitempty"`
-	Result   *string                  `form:"result,omitempty" json:"result,omitempty" xml:"result,omitempty"`
-	Error    *string                  `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
-	Outputs  *TaskOutputsResponseBody `form:"outputs,omitempty" json:"outputs,omitempty" xml:"outputs,omitempty"`
+	Duration *int64                    `form:"duration,omitempty" json:"duration,omitempty" xml:"duration,omitempty"`
+	Progress *TaskProgressResponseBody `form:"progress,omitempty" json:"progress,omitempty" xml:"progress,omitempty"`
+	Result   *string                   `form:"result,omitempty" json:"result,omitempty" xml:"result,omitempty"`
+	Error    *string                   `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	Outputs  *TaskOutputsResponseBody  `form:"outputs,omitempty" json:"outputs,omitempty" xml:"outputs,omitempty"`
 }
 
 // SinkStatisticsTotalResponseBody is the type of the "stream" service
@@ -434,11 +464,14 @@ type DisableSinkResponseBody struct {
 	CreatedAt string `form:"createdAt" json:"createdAt" xml:"createdAt"`
 	// Date and time of the task end.
 	FinishedAt *string `form:"finishedAt,omitempty" json:"finishedAt,omitempty" xml:"finishedAt,omitempty"`
+	// Date and time of the task cancellation.
+	CancelledAt *string `form:"cancelledAt,omitempty" json:"cancelledAt,omitempty" xml:"cancelledAt,omitempty"`
 	// Duration of the task in milliseconds.
-	Duration *int64                   `form:"duration,omitempty" json:"duration,omitempty" xml:"duration,omitempty"`
-	Result   *string                  `form:"result,omitempty" json:"result,omitempty" xml:"result,omitempty"`
-	Error    *string                  `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
-	Outputs  *TaskOutputsResponseBody `form:"outputs,omitempty" json:"outputs,omitempty" xml:"outputs,omitempty"`
+	Duration *int64                    `form:"duration,omitempty" json:"duration,omitempty" xml:"duration,omitempty"`
+	Progress *TaskProgressResponseBody `form:"progress,omitempty" json:"progress,omitempty" xml:"progress,omitempty"`
+	Result   *string                   `form:"result,omitempty" json:"result,omitempty" xml:"result,omitempty"`
+	Error    *string                   `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	Outputs  *TaskOutputsResponseBody  `form:"outputs,omitempty" json:"outputs,omitempty" xml:"outputs,omitempty"`
 }
 
 // EnableSinkResponseBody is the type of the "stream" service "EnableSink"
@@ -457,11 +490,14 @@ type EnableSinkResponseBody struct {
 	CreatedAt string `form:"createdAt" json:"createdAt" xml:"createdAt"`
 	// Date and time of the task end.
 	FinishedAt *string `form:"finishedAt,omitempty" json:"finishedAt,omitempty" xml:"finishedAt,omitempty"`
+	// Date and time of the task cancellation.
+	CancelledAt *string `form:"cancelledAt,omitempty" json:"cancelledAt,omitempty" xml:"cancelledAt,omitempty"`
 	// Duration of the task in milliseconds.
-	Duration *int64                   `form:"duration,omitempty" json:"duration,omitempty" xml:"duration,omitempty"`
-	Result   *string                  `form:"result,omitempty" json:"result,omitempty" xml:"result,omitempty"`
-	Error    *string                  `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
-	Outputs  *TaskOutputsResponseBody `form:"outputs,omitempty" json:"outputs,omitempty" xml:"outputs,omitempty"`
+	Duration *int64                    `form:"duration,omitempty" json:"duration,omitempty" xml:"duration,omitempty"`
+	Progress *TaskProgressResponseBody `form:"progress,omitempty" json:"progress,omitempty" xml:"progress,omitempty"`
+	Result   *string                   `form:"result,omitempty" json:"result,omitempty" xml:"result,omitempty"`
+	Error    *string                   `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	Outputs  *TaskOutputsResponseBody  `form:"outputs,omitempty" json:"outputs,omitempty" xml:"outputs,omitempty"`
 }
 
 // GetTaskResponseBody is the type of the "stream" service "GetTask" endpoint
@@ -480,11 +516,46 @@ type GetTaskResponseBody struct {
 	CreatedAt string `form:"createdAt" json:"createdAt" xml:"createdAt"`
 	// Date and time of the task end.
 	FinishedAt *string `form:"finishedAt,omitempty" json:"finishedAt,omitempty" xml:"finishedAt,omitempty"`
+	// Date and time of the task cancellation.
+	CancelledAt *string `form:"cancelledAt,omitempty" json:"cancelledAt,omitempty" xml:"cancelledAt,omitempty"`
 	// Duration of the task in milliseconds.
-	Duration *int64                   `form:"duration,omitempty" json:"duration,omitempty" xml:"duration,omitempty"`
-	Result   *string                  `form:"result,omitempty" json:"result,omitempty" xml:"result,omitempty"`
-	Error    *string                  `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
-	Outputs  *TaskOutputsResponseBody `form:"outputs,omitempty" json:"outputs,omitempty" xml:"outputs,omitempty"`
+	Duration *int64                    `form:"duration,omitempty" json:"duration,omitempty" xml:"duration,omitempty"`
+	Progress *TaskProgressResponseBody `form:"progress,omitempty" json:"progress,omitempty" xml:"progress,omitempty"`
+	Result   *string                   `form:"result,omitempty" json:"result,omitempty" xml:"result,omitempty"`
+	Error    *string                   `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	Outputs  *TaskOutputsResponseBody  `form:"outputs,omitempty" json:"outputs,omitempty" xml:"outputs,omitempty"`
+}
+
+// CancelTaskResponseBody is the type of the "stream" service "CancelTask"
+// endpoint HTTP response body.
+type CancelTaskResponseBody struct {
+	TaskID string `form:"taskId" json:"taskId" xml:"taskId"`
+	// Task type.
+	Type string `form:"type" json:"type" xml:"type"`
+	// URL of the task.
+	URL string `form:"url" json:"url" xml:"url"`
+	// Task status, one of: processing, success, error
+	Status string `form:"status" json:"status" xml:"status"`
+	// Shortcut for status != "processing".
+	IsFinished bool `form:"isFinished" json:"isFinished" xml:"isFinished"`
+	// Date and time of the task creation.
+	CreatedAt string `form:"createdAt" json:"createdAt" xml:"createdAt"`
+	// Date and time of the task end.
+	FinishedAt *string `form:"finishedAt,omitempty" json:"finishedAt,omitempty" xml:"finishedAt,omitempty"`
+	// Date and time of the task cancellation.
+	CancelledAt *string `form:"cancelledAt,omitempty" json:"cancelledAt,omitempty" xml:"cancelledAt,omitempty"`
+	// Duration of the task in milliseconds.
+	Duration *int64                    `form:"duration,omitempty" json:"duration,omitempty" xml:"duration,omitempty"`
+	Progress *TaskProgressResponseBody `form:"progress,omitempty" json:"progress,omitempty" xml:"progress,omitempty"`
+	Result   *string                   `form:"result,omitempty" json:"result,omitempty" xml:"result,omitempty"`
+	Error    *string                   `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	Outputs  *TaskOutputsResponseBody  `form:"outputs,omitempty" json:"outputs,omitempty" xml:"outputs,omitempty"`
+}
+
+// ListAuditLogResponseBody is the type of the "stream" service "ListAuditLog"
+// endpoint HTTP response body.
+type ListAuditLogResponseBody struct {
+	Entries []*AuditEntryResponseBody `form:"entries" json:"entries" xml:"entries"`
 }
 
 // AggregationSourcesResponseBody is the type of the "stream" service
@@ -952,6 +1023,42 @@ type GetTaskStreamAPITaskNotFoundResponseBody struct {
 	Message string `form:"message" json:"message" xml:"message"`
 }
 
+// CancelTaskStreamAPITaskNotFoundResponseBody is the type of the "stream"
+// service "CancelTask" endpoint HTTP response body for the
+// "stream.api.taskNotFound" error.
+type CancelTaskStreamAPITaskNotFoundResponseBody struct {
+	// HTTP status code.
+	StatusCode int `form:"statusCode" json:"statusCode" xml:"statusCode"`
+	// Name of error.
+	Name string `form:"error" json:"error" xml:"error"`
+	// Error message.
+	Message string `form:"message" json:"message" xml:"message"`
+}
+
+// CancelTaskStreamAPITaskFinishedResponseBody is the type of the "stream"
+// service "CancelTask" endpoint HTTP response body for the
+// "stream.api.taskFinished" error.
+type CancelTaskStreamAPITaskFinishedResponseBody struct {
+	// HTTP status code.
+	StatusCode int `form:"statusCode" json:"statusCode" xml:"statusCode"`
+	// Name of error.
+	Name string `form:"error" json:"error" xml:"error"`
+	// Error message.
+	Message string `form:"message" json:"message" xml:"message"`
+}
+
+// TaskProgressResponseBody is used to define fields on response body types.
+type TaskProgressResponseBody struct {
+	// Progress in percent.
+	Percent int `form:"percent" json:"percent" xml:"percent"`
+	// Current step of the operation.
+	Step *string `form:"step,omitempty" json:"step,omitempty" xml:"step,omitempty"`
+	// Message describing the current state.
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Date and time of the last progress update.
+	UpdatedAt string `form:"updatedAt" json:"updatedAt" xml:"updatedAt"`
+}
+
 // TaskOutputsResponseBody is used to define fields on response body types.
 type TaskOutputsResponseBody struct {
 	// Absolute URL of the entity.
@@ -1199,6 +1306,44 @@ type SinkFileStatisticsResponseBody struct {
 	Levels *LevelsResponseBody `form:"levels" json:"levels" xml:"levels"`
 }
 
+// AuditEntryResponseBody is used to define fields on response body types.
+type AuditEntryResponseBody struct {
+	// Unique ID of the entry.
+	ID string `form:"id" json:"id" xml:"id"`
+	// Date and time of the API call.
+	Created string `form:"created" json:"created" xml:"created"`
+	// ID of the API request.
+	RequestID *string `form:"requestId,omitempty" json:"requestId,omitempty" xml:"requestId,omitempty"`
+	// Name of the API endpoint.
+	Endpoint string `form:"endpoint" json:"endpoint" xml:"endpoint"`
+	// HTTP method of the API request.
+	HTTPMethod string `form:"httpMethod" json:"httpMethod" xml:"httpMethod"`
+	// HTTP path of the API request.
+	HTTPPath string          `form:"httpPath" json:"httpPath" xml:"httpPath"`
+	By       *ByResponseBody `form:"by" json:"by" xml:"by"`
+	// Key of the modified object.
+	ObjectKey *string `form:"objectKey,omitempty" json:"objectKey,omitempty" xml:"objectKey,omitempty"`
+	// ID of the asynchronous task started by the API call.
+	TaskID *string `form:"taskId,omitempty" json:"taskId,omitempty" xml:"taskId,omitempty"`
+	// Summary of the object state before the API call.
+	Before map[string]any `form:"before,omitempty" json:"before,omitempty" xml:"before,omitempty"`
+	// Summary of the expected object state after the API call.
+	After  map[string]any           `form:"after,omitempty" json:"after,omitempty" xml:"after,omitempty"`
+	Result *AuditResultResponseBody `form:"result" json:"result" xml:"result"`
+}
+
+// AuditResultResponseBody is used to define fields on response body types.
+type AuditResultResponseBody struct {
+	// True, if the API call succeeded.
+	Success bool `form:"success" json:"success" xml:"success"`
+	// HTTP status code of the failed API call.
+	StatusCode *int `form:"statusCode,omitempty" json:"statusCode,omitempty" xml:"statusCode,omitempty"`
+	// Name of the error.
+	ErrorName *string `form:"errorName,omitempty" json:"errorName,omitempty" xml:"errorName,omitempty"`
+	// Error message.
+	Error *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+}
+
 // AggregatedSourceResponseBody is used to define fields on response body types.
 type AggregatedSourceResponseBody struct {
 	ProjectID int    `form:"projectId" json:"projectId" xml:"projectId"`
@@ -1313,16 +1458,20 @@ func NewAPIVersionIndexResponseBody(res *stream.ServiceDetail) *APIVersionIndexR
 // the "CreateSource" endpoint of the "stream" service.
 func NewCreateSourceResponseBody(res *stream.Task) *CreateSourceResponseBody {
 	body := &CreateSourceResponseBody{
-		TaskID:     string(res.TaskID),
-		Type:       res.Type,
-		URL:        res.URL,
-		Status:     res.Status,
-		IsFinished: res.IsFinished,
-		CreatedAt:  res.CreatedAt,
-		FinishedAt: res.FinishedAt,
-		Duration:   res.Duration,
-		Result:     res.Result,
-		Error:      res.Error,
+		TaskID:      string(res.TaskID),
+		Type:        res.Type,
+		URL:         res.URL,
+		Status:      res.Status,
+		IsFinished:  res.IsFinished,
+		CreatedAt:   res.CreatedAt,
+		FinishedAt:  res.FinishedAt,
+		CancelledAt: res.CancelledAt,
+		Duration:    res.Duration,
+		Result:      res.Result,
+		Error:       res.Error,
+	}
+	if res.Progress != nil {
+		body.Progress = marshalStreamTaskProgressToTaskProgressResponseBody(res.Progress)
 	}
 	if res.Outputs != nil {
 		body.Outputs = marshalStreamTaskOutputsToTaskOutputsResponseBody(res.Outputs)
@@ -1334,16 +1483,20 @@ func NewCreateSourceResponseBody(res *stream.Task) *CreateSourceResponseBody {
 // the "UpdateSource" endpoint of the "stream" service.
 func NewUpdateSourceResponseBody(res *stream.Task) *UpdateSourceResponseBody {
 	body := &UpdateSourceResponseBody{
-		TaskID:     string(res.TaskID),
-		Type:       res.Type,
-		URL:        res.URL,
-		Status:     res.Status,
-		IsFinished: res.IsFinished,
-		CreatedAt:  res.CreatedAt,
-		FinishedAt: res.FinishedAt,
-		Duration:   res.Duration,
-		Result:     res.Result,
-		Error:      res.Error,
+		TaskID:      string(res.TaskID),
+		Type:        res.Type,
+		URL:         res.URL,
+		Status:      res.Status,
+		IsFinished:  res.IsFinished,
+		CreatedAt:   res.CreatedAt,
+		FinishedAt:  res.FinishedAt,
+		CancelledAt: res.CancelledAt,
+		Duration:    res.Duration,
+		Result:      res.Result,
+		Error:       res.Error,
+	}
+	if res.Progress != nil {
+		body.Progress = marshalStreamTaskProgressToTaskProgressResponseBody(res.Progress)
 	}
 	if res.Outputs != nil {
 		body.Outputs = marshalStreamTaskOutputsToTaskOutputsResponseBody(res.Outputs)
@@ -1405,16 +1558,20 @@ func NewGetSourceResponseBody(res *stream.Source) *GetSourceResponseBody {
 // the "DeleteSource" endpoint of the "stream" service.
 func NewDeleteSourceResponseBody(res *stream.Task) *DeleteSourceResponseBody {
 	body := &DeleteSourceResponseBody{
-		TaskID:     string(res.TaskID),
-		Type:       res.Type,
-		URL:        res.URL,
-		Status:     res.Status,
-		IsFinished: res.IsFinished,
-		CreatedAt:  res.CreatedAt,
-		FinishedAt: res.FinishedAt,
-		Duration:   res.Duration,
-		Result:     res.Result,
-		Error:      res.Error,
+		TaskID:      string(res.TaskID),
+		Type:        res.Type,
+		URL:         res.URL,
+		Status:      res.Status,
+		IsFinished:  res.IsFinished,
+		CreatedAt:   res.CreatedAt,
+		FinishedAt:  res.FinishedAt,
+		CancelledAt: res.CancelledAt,
+		Duration:    res.Duration,
+		Result:      res.Result,
+		Error:       res.Error,
+	}
+	if res.Progress != nil {
+		body.Progress = marshalStreamTaskProgressToTaskProgressResponseBody(res.Progress)
 	}
 	if res.Outputs != nil {
 		body.Outputs = marshalStreamTaskOutputsToTaskOutputsResponseBody(res.Outputs)
@@ -1439,16 +1596,20 @@ func NewGetSourceSettingsResponseBody(res *stream.SettingsResult) *GetSourceSett
 // result of the "UpdateSourceSettings" endpoint of the "stream" service.
 func NewUpdateSourceSettingsResponseBody(res *stream.Task) *UpdateSourceSettingsResponseBody {
 	body := &UpdateSourceSettingsResponseBody{
-		TaskID:     string(res.TaskID),
-		Type:       res.Type,
-		URL:        res.URL,
-		Status:     res.Status,
-		IsFinished: res.IsFinished,
-		CreatedAt:  res.CreatedAt,
-		FinishedAt: res.FinishedAt,
-		Duration:   res.Duration,
-		Result:     res.Result,
-		Error:      res.Error,
+		TaskID:      string(res.TaskID),
+		Type:        res.Type,
+		URL:         res.URL,
+		Status:      res.Status,
+		IsFinished:  res.IsFinished,
+		CreatedAt:   res.CreatedAt,
+		FinishedAt:  res.FinishedAt,
+		CancelledAt: res.CancelledAt,
+		Duration:    res.Duration,
+		Result:      res.Result,
+		Error:       res.Error,
+	}
+	if res.Progress != nil {
+		body.Progress = marshalStreamTaskProgressToTaskProgressResponseBody(res.Progress)
 	}
 	if res.Outputs != nil {
 		body.Outputs = marshalStreamTaskOutputsToTaskOutputsResponseBody(res.Outputs)
@@ -1479,16 +1640,20 @@ func NewTestSourceResponseBody(res *stream.TestResult) *TestSourceResponseBody {
 // of the "DisableSource" endpoint of the "stream" service.
 func NewDisableSourceResponseBody(res *stream.Task) *DisableSourceResponseBody {
 	body := &DisableSourceResponseBody{
-		TaskID:     string(res.TaskID),
-		Type:       res.Type,
-		URL:        res.URL,
-		Status:     res.Status,
-		IsFinished: res.IsFinished,
-		CreatedAt:  res.CreatedAt,
-		FinishedAt: res.FinishedAt,
-		Duration:   res.Duration,
-		Result:     res.Result,
-		Error:      res.Error,
+		TaskID:      string(res.TaskID),
+		Type:        res.Type,
+		URL:         res.URL,
+		Status:      res.Status,
+		IsFinished:  res.IsFinished,
+		CreatedAt:   res.CreatedAt,
+		FinishedAt:  res.FinishedAt,
+		CancelledAt: res.CancelledAt,
+		Duration:    res.Duration,
+		Result:      res.Result,
+		Error:       res.Error,
+	}
+	if res.Progress != nil {
+		body.Progress = marshalStreamTaskProgressToTaskProgressResponseBody(res.Progress)
 	}
 	if res.Outputs != nil {
 		body.Outputs = marshalStreamTaskOutputsToTaskOutputsResponseBody(res.Outputs)
@@ -1500,16 +1665,20 @@ func NewDisableSourceResponseBody(res *stream.Task) *DisableSourceResponseBody {
 // the "EnableSource" endpoint of the "stream" service.
 func NewEnableSourceResponseBody(res *stream.Task) *EnableSourceResponseBody {
 	body := &EnableSourceResponseBody{
-		TaskID:     string(res.TaskID),
-		Type:       res.Type,
-		URL:        res.URL,
-		Status:     res.Status,
-		IsFinished: res.IsFinished,
-		CreatedAt:  res.CreatedAt,
-		FinishedAt: res.FinishedAt,
-		Duration:   res.Duration,
-		Result:     res.Result,
-		Error:      res.Error,
+		TaskID:      string(res.TaskID),
+		Type:        res.Type,
+		URL:         res.URL,
+		Status:      res.Status,
+		IsFinished:  res.IsFinished,
+		CreatedAt:   res.CreatedAt,
+		FinishedAt:  res.FinishedAt,
+		CancelledAt: res.CancelledAt,
+		Duration:    res.Duration,
+		Result:      res.Result,
+		Error:       res.Error,
+	}
+	if res.Progress != nil {
+		body.Progress = marshalStreamTaskProgressToTaskProgressResponseBody(res.Progress)
 	}
 	if res.Outputs != nil {
 		body.Outputs = marshalStreamTaskOutputsToTaskOutputsResponseBody(res.Outputs)
@@ -1521,16 +1690,20 @@ func NewEnableSourceResponseBody(res *stream.Task) *EnableSourceResponseBody {
 // the "CreateSink" endpoint of the "stream" service.
 func NewCreateSinkResponseBody(res *stream.Task) *CreateSinkResponseBody {
 	body := &CreateSinkResponseBody{
-		TaskID:     string(res.TaskID),
-		Type:       res.Type,
-		URL:        res.URL,
-		Status:     res.Status,
-		IsFinished: res.IsFinished,
-		CreatedAt:  res.CreatedAt,
-		FinishedAt: res.FinishedAt,
-		Duration:   res.Duration,
-		Result:     res.Result,
-		Error:      res.Error,
+		TaskID:      string(res.TaskID),
+		Type:        res.Type,
+		URL:         res.URL,
+		Status:      res.Status,
+		IsFinished:  res.IsFinished,
+		CreatedAt:   res.CreatedAt,
+		FinishedAt:  res.FinishedAt,
+		CancelledAt: res.CancelledAt,
+		Duration:    res.Duration,
+		Result:      res.Result,
+		Error:       res.Error,
+	}
+	if res.Progress != nil {
+		body.Progress = marshalStreamTaskProgressToTaskProgressResponseBody(res.Progress)
 	}
 	if res.Outputs != nil {
 		body.Outputs = marshalStreamTaskOutputsToTaskOutputsResponseBody(res.Outputs)
@@ -1585,16 +1758,20 @@ func NewGetSinkSettingsResponseBody(res *stream.SettingsResult) *GetSinkSettings
 // result of the "UpdateSinkSettings" endpoint of the "stream" service.
 func NewUpdateSinkSettingsResponseBody(res *stream.Task) *UpdateSinkSettingsResponseBody {
 	body := &UpdateSinkSettingsResponseBody{
-		TaskID:     string(res.TaskID),
-		Type:       res.Type,
-		URL:        res.URL,
-		Status:     res.Status,
-		IsFinished: res.IsFinished,
-		CreatedAt:  res.CreatedAt,
-		FinishedAt: res.FinishedAt,
-		Duration:   res.Duration,
-		Result:     res.Result,
-		Error:      res.Error,
+		TaskID:      string(res.TaskID),
+		Type:        res.Type,
+		URL:         res.URL,
+		Status:      res.Status,
+		IsFinished:  res.IsFinished,
+		CreatedAt:   res.CreatedAt,
+		FinishedAt:  res.FinishedAt,
+		CancelledAt: res.CancelledAt,
+		Duration:    res.Duration,
+		Result:      res.Result,
+		Error:       res.Error,
+	}
+	if res.Progress != nil {
+		body.Progress = marshalStreamTaskProgressToTaskProgressResponseBody(res.Progress)
 	}
 	if res.Outputs != nil {
 		body.Outputs = marshalStreamTaskOutputsToTaskOutputsResponseBody(res.Outputs)
@@ -1628,16 +1805,20 @@ func NewListSinksResponseBody(res *stream.SinksList) *ListSinksResponseBody {
 // the "UpdateSink" endpoint of the "stream" service.
 func NewUpdateSinkResponseBody(res *stream.Task) *UpdateSinkResponseBody {
 	body := &UpdateSinkResponseBody{
-		TaskID:     string(res.TaskID),
-		Type:       res.Type,
-		URL:        res.URL,
-		Status:     res.Status,
-		IsFinished: res.IsFinished,
-		CreatedAt:  res.CreatedAt,
-		FinishedAt: res.FinishedAt,
-		Duration:   res.Duration,
-		Result:     res.Result,
-		Error:      res.Error,
+		TaskID:      string(res.TaskID),
+		Type:        res.Type,
+		URL:         res.URL,
+		Status:      res.Status,
+		IsFinished:  res.IsFinished,
+		CreatedAt:   res.CreatedAt,
+		FinishedAt:  res.FinishedAt,
+		CancelledAt: res.CancelledAt,
+		Duration:    res.Duration,
+		Result:      res.Result,
+		Error:       res.Error,
+	}
+	if res.Progress != nil {
+		body.Progress = marshalStreamTaskProgressToTaskProgressResponseBody(res.Progress)
 	}
 	if res.Outputs != nil {
 		body.Outputs = marshalStreamTaskOutputsToTaskOutputsResponseBody(res.Outputs)
@@ -1649,16 +1830,20 @@ func NewUpdateSinkResponseBody(res *stream.Task) *UpdateSinkResponseBody {
 // the "DeleteSink" endpoint of the "stream" service.
 func NewDeleteSinkResponseBody(res *stream.Task) *DeleteSinkResponseBody {
 	body := &DeleteSinkResponseBody{
-		TaskID:     string(res.TaskID),
-		Type:       res.Type,
-		URL:        res.URL,
-		Status:     res.Status,
-		IsFinished: res.IsFinished,
-		CreatedAt:  res.CreatedAt,
-		FinishedAt: res.FinishedAt,
-		Duration:   res.Duration,
-		Result:     res.Result,
-		Error:      res.Error,
+		TaskID:      string(res.TaskID),
+		Type:        res.Type,
+		URL:         res.URL,
+		Status:      res.Status,
+		IsFinished:  res.IsFinished,
+		CreatedAt:   res.CreatedAt,
+		FinishedAt:  res.FinishedAt,
+		CancelledAt: res.CancelledAt,
+		Duration:    res.Duration,
+		Result:      res.Result,
+		Error:       res.Error,
+	}
+	if res.Progress != nil {
+		body.Progress = marshalStreamTaskProgressToTaskProgressResponseBody(res.Progress)
 	}
 	if res.Outputs != nil {
 		body.Outputs = marshalStreamTaskOutputsToTaskOutputsResponseBody(res.Outputs)
@@ -1698,16 +1883,20 @@ func NewSinkStatisticsFilesResponseBody(res *stream.SinkStatisticsFilesResult) *
 // the "DisableSink" endpoint of the "stream" service.
 func NewDisableSinkResponseBody(res *stream.Task) *DisableSinkResponseBody {
 	body := &DisableSinkResponseBody{
-		TaskID:     string(res.TaskID),
-		Type:       res.Type,
-		URL:        res.URL,
-		Status:     res.Status,
-		IsFinished: res.IsFinished,
-		CreatedAt:  res.CreatedAt,
-		FinishedAt: res.FinishedAt,
-		Duration:   res.Duration,
-		Result:     res.Result,
-		Error:      res.Error,
+		TaskID:      string(res.TaskID),
+		Type:        res.Type,
+		URL:         res.URL,
+		Status:      res.Status,
+		IsFinished:  res.IsFinished,
+		CreatedAt:   res.CreatedAt,
+		FinishedAt:  res.FinishedAt,
+		CancelledAt: res.CancelledAt,
+		Duration:    res.Duration,
+		Result:      res.Result,
+		Error:       res.Error,
+	}
+	if res.Progress != nil {
+		body.Progress = marshalStreamTaskProgressToTaskProgressResponseBody(res.Progress)
 	}
 	if res.Outputs != nil {
 		body.Outputs = marshalStreamTaskOutputsToTaskOutputsResponseBody(res.Outputs)
@@ -1719,16 +1908,20 @@ func NewDisableSinkResponseBody(res *stream.Task) *DisableSinkResponseBody {
 // the "EnableSink" endpoint of the "stream" service.
 func NewEnableSinkResponseBody(res *stream.Task) *EnableSinkResponseBody {
 	body := &EnableSinkResponseBody{
-		TaskID:     string(res.TaskID),
-		Type:       res.Type,
-		URL:        res.URL,
-		Status:     res.Status,
-		IsFinished: res.IsFinished,
-		CreatedAt:  res.CreatedAt,
-		FinishedAt: res.FinishedAt,
-		Duration:   res.Duration,
-		Result:     res.Result,
-		Error:      res.Error,
+		TaskID:      string(res.TaskID),
+		Type:        res.Type,
+		URL:         res.URL,
+		Status:      res.Status,
+		IsFinished:  res.IsFinished,
+		CreatedAt:   res.CreatedAt,
+		FinishedAt:  res.FinishedAt,
+		CancelledAt: res.CancelledAt,
+		Duration:    res.Duration,
+		Result:      res.Result,
+		Error:       res.Error,
+	}
+	if res.Progress != nil {
+		body.Progress = marshalStreamTaskProgressToTaskProgressResponseBody(res.Progress)
 	}
 	if res.Outputs != nil {
 		body.Outputs = marshalStreamTaskOutputsToTaskOutputsResponseBody(res.Outputs)
@@ -1740,16 +1933,45 @@ func NewEnableSinkResponseBody(res *stream.Task) *EnableSinkResponseBody {
 // "GetTask" endpoint of the "stream" service.
 func NewGetTaskResponseBody(res *stream.Task) *GetTaskResponseBody {
 	body := &GetTaskResponseBody{
-		TaskID:     string(res.TaskID),
-		Type:       res.Type,
-		URL:        res.URL,
-		Status:     res.Status,
-		IsFinished: res.IsFinished,
-		CreatedAt:  res.CreatedAt,
-		FinishedAt: res.FinishedAt,
-		Duration:   res.Duration,
-		Result:     res.Result,
-		Error:      res.Error,
+		TaskID:      string(res.TaskID),
+		Type:        res.Type,
+		URL:         res.URL,
+		Status:      res.Status,
+		IsFinished:  res.IsFinished,
+		CreatedAt:   res.CreatedAt,
+		FinishedAt:  res.FinishedAt,
+		CancelledAt: res.CancelledAt,
+		Duration:    res.Duration,
+		Result:      res.Result,
+		Error:       res.Error,
+	}
+	if res.Progress != nil {
+		body.Progress = marshalStreamTaskProgressToTaskProgressResponseBody(res.Progress)
+	}
+	if res.Outputs != nil {
+		body.Outputs = marshalStreamTaskOutputsToTaskOutputsResponseBody(res.Outputs)
+	}
+	return body
+}
+
+// NewCancelTaskResponseBody builds the HTTP response body from the result of
+// the "CancelTask" endpoint of the "stream" service.
+func NewCancelTaskResponseBody(res *stream.Task) *CancelTaskResponseBody {
+	body := &CancelTaskResponseBody{
+		TaskID:      string(res.TaskID),
+		Type:        res.Type,
+		URL:         res.URL,
+		Status:      res.Status,
+		IsFinished:  res.IsFinished,
+		CreatedAt:   res.CreatedAt,
+		FinishedAt:  res.FinishedAt,
+		CancelledAt: res.CancelledAt,
+		Duration:    res.Duration,
+		Result:      res.Result,
+		Error:       res.Error,
+	}
+	if res.Progress != nil {
+		body.Progress = marshalStreamTaskProgressToTaskProgressResponseBody(res.Progress)
 	}
 	if res.Outputs != nil {
 		body.Outputs = marshalStreamTaskOutputsToTaskOutputsResponseBody(res.Outputs)
@@ -1757,6 +1979,21 @@ func NewGetTaskResponseBody(res *stream.Task) *GetTaskResponseBody {
 	return body
 }
 
+// NewListAuditLogResponseBody builds the HTTP response body from the result of
+// the "ListAuditLog" endpoint of the "stream" service.
+func NewListAuditLogResponseBody(res *stream.AuditLog) *ListAuditLogResponseBody {
+	body := &ListAuditLogResponseBody{}
+	if res.Entries != nil {
+		body.Entries = make([]*AuditEntryResponseBody, len(res.Entries))
+		for i, val := range res.Entries {
+			body.Entries[i] = marshalStreamAuditEntryToAuditEntryResponseBody(val)
+		}
+	} else {
+		body.Entries = []*AuditEntryResponseBody{}
+	}
+	return body
+}
+
 // NewAggregationSourcesResponseBody builds the HTTP response body from the
 // result of the "AggregationSources" endpoint of the "stream" service.
 func NewAggregationSourcesResponseBody(res *stream.AggregatedSourcesResult) *AggregationSourcesResponseBody {
@@ -2214,6 +2451,28 @@ func NewGetTaskStreamAPITaskNotFoundResponseBody(res *stream.GenericError) *GetT
 	return body
 }
 
+// NewCancelTaskStreamAPITaskNotFoundResponseBody builds the HTTP response body
+// from the result of the "CancelTask" endpoint of the "stream" service.
+func NewCancelTaskStreamAPITaskNotFoundResponseBody(res *stream.GenericError) *CancelTaskStreamAPITaskNotFoundResponseBody {
+	body := &CancelTaskStreamAPITaskNotFoundResponseBody{
+		StatusCode: res.StatusCode,
+		Name:       res.Name,
+		Message:    res.Message,
+	}
+	return body
+}
+
+// NewCancelTaskStreamAPITaskFinishedResponseBody builds the HTTP response body
+// from the result of the "CancelTask" endpoint of the "stream" service.
+func NewCancelTaskStreamAPITaskFinishedResponseBody(res *stream.GenericError) *CancelTaskStreamAPITaskFinishedResponseBody {
+	body := &CancelTaskStreamAPITaskFinishedResponseBody{
+		StatusCode: res.StatusCode,
+		Name:       res.Name,
+		Message:    res.Message,
+	}
+	return body
+}
+
 // NewCreateSourcePayload builds a stream service CreateSource endpoint payload.
 func NewCreateSourcePayload(body *CreateSourceRequestBody, branchID string, storageAPIToken string) *stream.CreateSourcePayload {
 	v := &stream.CreateSourcePayload{
@@ -2528,6 +2787,24 @@ func NewGetTaskPayload(taskID string, storageAPIToken string) *stream.GetTaskPay
 	return v
 }
 
+// NewCancelTaskPayload builds a stream service CancelTask endpoint payload.
+func NewCancelTaskPayload(taskID string, storageAPIToken string) *stream.CancelTaskPayload {
+	v := &stream.CancelTaskPayload{}
+	v.TaskID = stream.TaskID(taskID)
+	v.StorageAPIToken = storageAPIToken
+
+	return v
+}
+
+// NewListAuditLogPayload builds a stream service ListAuditLog endpoint payload.
+func NewListAuditLogPayload(limit int, storageAPIToken string) *stream.ListAuditLogPayload {
+	v := &stream.ListAuditLogPayload{}
+	v.Limit = limit
+	v.StorageAPIToken = storageAPIToken
+
+	return v
+}
+
 // NewAggregationSourcesPayload builds a stream service AggregationSources
 // endpoint payload.
 func NewAggregationSourcesPayload(branchID string, afterID string, limit int, storageAPIToken string) *stream.AggregationSourcesPayload {
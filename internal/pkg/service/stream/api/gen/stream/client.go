@@ -44,11 +44,13 @@ type Client struct {
 	DisableSinkEndpoint           goa.Endpoint
 	EnableSinkEndpoint            goa.Endpoint
 	GetTaskEndpoint               goa.Endpoint
+	CancelTaskEndpoint            goa.Endpoint
+	ListAuditLogEndpoint          goa.Endpoint
 	AggregationSourcesEndpoint    goa.Endpoint
 }
 
 // NewClient initializes a "stream" service client given the endpoints.
-func NewClient(aPIRootIndex, aPIVersionIndex, healthCheck, createSource, updateSource, listSources, getSource, deleteSource, getSourceSettings, updateSourceSettings, testSource, sourceStatisticsClear, disableSource, enableSource, createSink, getSink, getSinkSettings, updateSinkSettings, listSinks, updateSink, deleteSink, sinkStatisticsTotal, sinkStatisticsFiles, sinkStatisticsClear, disableSink, enableSink, getTask, aggregationSources goa.Endpoint) *Client {
+func NewClient(aPIRootIndex, aPIVersionIndex, healthCheck, createSource, updateSource, listSources, getSource, deleteSource, getSourceSettings, updateSourceSettings, testSource, sourceStatisticsClear, disableSource, enableSource, createSink, getSink, getSinkSettings, updateSinkSettings, listSinks, updateSink, deleteSink, sinkStatisticsTotal, sinkStatisticsFiles, sinkStatisticsClear, disableSink, enableSink, getTask, cancelTask, listAuditLog, aggregationSources goa.Endpoint) *Client {
 	return &Client{
 		APIRootIndexEndpoint:          aPIRootIndex,
 		APIVersionIndexEndpoint:       aPIVersionIndex,
@@ -77,6 +79,8 @@ func NewClient(aPIRootIndex, aPIVersionIndex, healthCheck, createSource, updateS
 		DisableSinkEndpoint:           disableSink,
 		EnableSinkEndpoint:            enableSink,
 		GetTaskEndpoint:               getTask,
+		CancelTaskEndpoint:            cancelTask,
+		ListAuditLogEndpoint:          listAuditLog,
 		AggregationSourcesEndpoint:    aggregationSources,
 	}
 }
@@ -430,6 +434,30 @@ func (c *Client) GetTask(ctx context.Context, p *GetTaskPayload) (res *Task, err
 	return ires.(*Task), nil
 }
 
+// CancelTask calls the "CancelTask" endpoint of the "stream" service.
+// CancelTask may return the following errors:
+//   - "stream.api.taskNotFound" (type *GenericError): Task not found error.
+//   - "stream.api.taskFinished" (type *GenericError): Task already finished error.
+//   - error: internal error
+func (c *Client) CancelTask(ctx context.Context, p *CancelTaskPayload) (res *Task, err error) {
+	var ires any
+	ires, err = c.CancelTaskEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*Task), nil
+}
+
+// ListAuditLog calls the "ListAuditLog" endpoint of the "stream" service.
+func (c *Client) ListAuditLog(ctx context.Context, p *ListAuditLogPayload) (res *AuditLog, err error) {
+	var ires any
+	ires, err = c.ListAuditLogEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*AuditLog), nil
+}
+
 // AggregationSources calls the "AggregationSources" endpoint of the "stream"
 // service.
 func (c *Client) AggregationSources(ctx context.Context, p *AggregationSourcesPayload) (res *AggregatedSourcesResult, err error) {
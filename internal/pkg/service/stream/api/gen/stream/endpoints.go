@@ -46,6 +46,8 @@ type Endpoints struct {
 	DisableSink           goa.Endpoint
 	EnableSink            goa.Endpoint
 	GetTask               goa.Endpoint
+	CancelTask            goa.Endpoint
+	ListAuditLog          goa.Endpoint
 	AggregationSources    goa.Endpoint
 }
 
@@ -90,6 +92,8 @@ func NewEndpoints(s Service) *Endpoints {
 		DisableSink:           NewDisableSinkEndpoint(s, a.APIKeyAuth),
 		EnableSink:            NewEnableSinkEndpoint(s, a.APIKeyAuth),
 		GetTask:               NewGetTaskEndpoint(s, a.APIKeyAuth),
+		CancelTask:            NewCancelTaskEndpoint(s, a.APIKeyAuth),
+		ListAuditLog:          NewListAuditLogEndpoint(s, a.APIKeyAuth),
 		AggregationSources:    NewAggregationSourcesEndpoint(s, a.APIKeyAuth),
 	}
 }
@@ -123,6 +127,8 @@ func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
 	e.DisableSink = m(e.DisableSink)
 	e.EnableSink = m(e.EnableSink)
 	e.GetTask = m(e.GetTask)
+	e.CancelTask = m(e.CancelTask)
+	e.ListAuditLog = m(e.ListAuditLog)
 	e.AggregationSources = m(e.AggregationSources)
 }
 
@@ -633,6 +639,46 @@ func NewGetTaskEndpoint(s Service, authAPIKeyFn security.AuthAPIKeyFunc) goa.End
 	}
 }
 
+// NewCancelTaskEndpoint returns an endpoint function that calls the method
+// "CancelTask" of service "stream".
+func NewCancelTaskEndpoint(s Service, authAPIKeyFn security.AuthAPIKeyFunc) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*CancelTaskPayload)
+		var err error
+		sc := security.APIKeyScheme{
+			Name:           "storage-api-token",
+			Scopes:         []string{},
+			RequiredScopes: []string{},
+		}
+		ctx, err = authAPIKeyFn(ctx, p.StorageAPIToken, &sc)
+		if err != nil {
+			return nil, err
+		}
+		deps := ctx.Value(dependencies.ProjectRequestScopeCtxKey).(dependencies.ProjectRequestScope)
+		return s.CancelTask(ctx, deps, p)
+	}
+}
+
+// NewListAuditLogEndpoint returns an endpoint function that calls the method
+// "ListAuditLog" of service "stream".
+func NewListAuditLogEndpoint(s Service, authAPIKeyFn security.AuthAPIKeyFunc) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*ListAuditLogPayload)
+		var err error
+		sc := security.APIKeyScheme{
+			Name:           "storage-api-token",
+			Scopes:         []string{},
+			RequiredScopes: []string{},
+		}
+		ctx, err = authAPIKeyFn(ctx, p.StorageAPIToken, &sc)
+		if err != nil {
+			return nil, err
+		}
+		deps := ctx.Value(dependencies.ProjectRequestScopeCtxKey).(dependencies.ProjectRequestScope)
+		return s.ListAuditLog(ctx, deps, p)
+	}
+}
+
 // NewAggregationSourcesEndpoint returns an endpoint function that calls the
 // method "AggregationSources" of service "stream".
 func NewAggregationSourcesEndpoint(s Service, authAPIKeyFn security.AuthAPIKeyFunc) goa.Endpoint {
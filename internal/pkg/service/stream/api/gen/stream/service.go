@@ -78,6 +78,11 @@ type Service interface {
 	EnableSink(context.Context, dependencies.SinkRequestScope, *EnableSinkPayload) (res *Task, err error)
 	// Get details of a task.
 	GetTask(context.Context, dependencies.ProjectRequestScope, *GetTaskPayload) (res *Task, err error)
+	// Request cancellation of a running task. The task is cancelled
+	// asynchronously, poll the task to get the final state.
+	CancelTask(context.Context, dependencies.ProjectRequestScope, *CancelTaskPayload) (res *Task, err error)
+	// List mutating API calls in the project, from the newest to the oldest.
+	ListAuditLog(context.Context, dependencies.ProjectRequestScope, *ListAuditLogPayload) (res *AuditLog, err error)
 	// Details about sources for the UI.
 	AggregationSources(context.Context, dependencies.BranchRequestScope, *AggregationSourcesPayload) (res *AggregatedSourcesResult, err error)
 }
@@ -102,7 +107,7 @@ const ServiceName = "stream"
 // MethodNames lists the service method names as defined in the design. These
 // are the same values that are set in the endpoint request contexts under the
 // MethodKey key.
-var MethodNames = [28]string{"ApiRootIndex", "ApiVersionIndex", "HealthCheck", "CreateSource", "UpdateSource", "ListSources", "GetSource", "DeleteSource", "GetSourceSettings", "UpdateSourceSettings", "TestSource", "SourceStatisticsClear", "DisableSource", "EnableSource", "CreateSink", "GetSink", "GetSinkSettings", "UpdateSinkSettings", "ListSinks", "UpdateSink", "DeleteSink", "SinkStatisticsTotal", "SinkStatisticsFiles", "SinkStatisticsClear", "DisableSink", "EnableSink", "GetTask", "AggregationSources"}
+var MethodNames = [30]string{"ApiRootIndex", "ApiVersionIndex", "HealthCheck", "CreateSource", "UpdateSource", "ListSources", "GetSource", "DeleteSource", "GetSourceSettings", "UpdateSourceSettings", "TestSource", "SourceStatisticsClear", "DisableSource", "EnableSource", "CreateSink", "GetSink", "GetSinkSettings", "UpdateSinkSettings", "ListSinks", "UpdateSink", "DeleteSink", "SinkStatisticsTotal", "SinkStatisticsFiles", "SinkStatisticsClear", "DisableSink", "EnableSink", "GetTask", "CancelTask", "ListAuditLog", "AggregationSources"}
 
 // A mapping from imported data to a destination table.
 type AggregatedSink struct {
@@ -173,6 +178,50 @@ type AggregationSourcesPayload struct {
 	Limit int
 }
 
+// A mutating API call.
+type AuditEntry struct {
+	// Unique ID of the entry.
+	ID string
+	// Date and time of the API call.
+	Created string
+	// ID of the API request.
+	RequestID *string
+	// Name of the API endpoint.
+	Endpoint string
+	// HTTP method of the API request.
+	HTTPMethod string
+	// HTTP path of the API request.
+	HTTPPath string
+	By       *By
+	// Key of the modified object.
+	ObjectKey *string
+	// ID of the asynchronous task started by the API call.
+	TaskID *TaskID
+	// Summary of the object state before the API call.
+	Before map[string]any
+	// Summary of the expected object state after the API call.
+	After  map[string]any
+	Result *AuditResult
+}
+
+// AuditLog is the result type of the stream service ListAuditLog method.
+type AuditLog struct {
+	Entries []*AuditEntry
+}
+
+// Result of the API call, the result of an asynchronous operation is available
+// in the task.
+type AuditResult struct {
+	// True, if the API call succeeded.
+	Success bool
+	// HTTP status code of the failed API call.
+	StatusCode *int
+	// Name of the error.
+	ErrorName *string
+	// Error message.
+	Error *string
+}
+
 // ID of the branch.
 type BranchID = keboola.BranchID
 
@@ -193,6 +242,13 @@ type By struct {
 	UserName *string
 }
 
+// CancelTaskPayload is the payload type of the stream service CancelTask
+// method.
+type CancelTaskPayload struct {
+	StorageAPIToken string
+	TaskID          TaskID
+}
+
 // CreateSinkPayload is the payload type of the stream service CreateSink
 // method.
 type CreateSinkPayload struct {
@@ -376,6 +432,14 @@ type Levels struct {
 	Target  *Level
 }
 
+// ListAuditLogPayload is the payload type of the stream service ListAuditLog
+// method.
+type ListAuditLogPayload struct {
+	StorageAPIToken string
+	// Maximum number of returned entries.
+	Limit int
+}
+
 // ListSinksPayload is the payload type of the stream service ListSinks method.
 type ListSinksPayload struct {
 	StorageAPIToken string
@@ -671,8 +735,11 @@ type Task struct {
 	CreatedAt string
 	// Date and time of the task end.
 	FinishedAt *string
+	// Date and time of the task cancellation.
+	CancelledAt *string
 	// Duration of the task in milliseconds.
 	Duration *int64
+	Progress *TaskProgress
 	Result   *string
 	Error    *string
 	Outputs  *TaskOutputs
@@ -695,6 +762,18 @@ type TaskOutputs struct {
 	SinkID *SinkID
 }
 
+// Progress of the task, reported by the running operation.
+type TaskProgress struct {
+	// Progress in percent.
+	Percent int
+	// Current step of the operation.
+	Step *string
+	// Message describing the current state.
+	Message *string
+	// Date and time of the last progress update.
+	UpdatedAt string
+}
+
 // TestResult is the result type of the stream service TestSource method.
 type TestResult struct {
 	ProjectID ProjectID
@@ -0,0 +1,60 @@
+package mapper
+
+import (
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/audit"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/task"
+	api "github.com/keboola/keboola-as-code/internal/pkg/service/stream/api/gen/stream"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition"
+)
+
+func (m *Mapper) NewAuditLogResponse(entries []audit.Entry) *api.AuditLog {
+	out := &api.AuditLog{Entries: make([]*api.AuditEntry, 0, len(entries))}
+	for _, entry := range entries {
+		out.Entries = append(out.Entries, m.NewAuditEntryResponse(entry))
+	}
+	return out
+}
+
+func (m *Mapper) NewAuditEntryResponse(entry audit.Entry) *api.AuditEntry {
+	out := &api.AuditEntry{
+		ID:         entry.ID,
+		Created:    entry.Created.String(),
+		Endpoint:   entry.Endpoint,
+		HTTPMethod: entry.HTTPMethod,
+		HTTPPath:   entry.HTTPPath,
+		By: m.NewByResponse(definition.By{
+			Type:      definition.ByType(entry.By.Type),
+			TokenID:   entry.By.TokenID,
+			TokenDesc: entry.By.TokenDesc,
+			UserID:    entry.By.UserID,
+			UserName:  entry.By.UserName,
+		}),
+		Before: entry.Before,
+		After:  entry.After,
+		Result: &api.AuditResult{Success: entry.Result.Success},
+	}
+
+	if entry.RequestID != "" {
+		out.RequestID = &entry.RequestID
+	}
+	if entry.ObjectKey != "" {
+		out.ObjectKey = &entry.ObjectKey
+	}
+	if entry.TaskID != "" {
+		v := task.ID(entry.TaskID)
+		out.TaskID = &v
+	}
+
+	// Result
+	if entry.Result.StatusCode != 0 {
+		out.Result.StatusCode = &entry.Result.StatusCode
+	}
+	if entry.Result.ErrorName != "" {
+		out.Result.ErrorName = &entry.Result.ErrorName
+	}
+	if entry.Result.Error != "" {
+		out.Result.Error = &entry.Result.Error
+	}
+
+	return out
+}
@@ -32,11 +32,29 @@ func (m *Mapper) NewTaskResponse(entity task.Task) (*api.Task, error) {
 		v := entity.FinishedAt.String()
 		response.FinishedAt = &v
 	}
+	if entity.CancelledAt != nil {
+		v := entity.CancelledAt.String()
+		response.CancelledAt = &v
+	}
 	if entity.Duration != nil {
 		v := entity.Duration.Milliseconds()
 		response.Duration = &v
 	}
 
+	// Progress
+	if entity.Progress != nil {
+		response.Progress = &api.TaskProgress{
+			Percent:   entity.Progress.Percent,
+			UpdatedAt: entity.Progress.UpdatedAt.String(),
+		}
+		if entity.Progress.Step != "" {
+			response.Progress.Step = &entity.Progress.Step
+		}
+		if entity.Progress.Message != "" {
+			response.Progress.Message = &entity.Progress.Message
+		}
+	}
+
 	// Status
 	switch {
 	case entity.IsProcessing():
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/audit"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/configpatch"
+	api "github.com/keboola/keboola-as-code/internal/pkg/service/stream/api/gen/stream"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/dependencies"
+)
+
+func (s *service) ListAuditLog(ctx context.Context, d dependencies.ProjectRequestScope, payload *api.ListAuditLogPayload) (*api.AuditLog, error) {
+	entries, err := d.AuditLog().List(ctx, d.ProjectID(), payload.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.mapper.NewAuditLogResponse(entries), nil
+}
+
+// auditSourceChange records the source state before and after the API call, if the call is audited.
+// The change function returns the expected state after the call, or nil, if the source is deleted.
+// If the source cannot be loaded, the summaries are skipped, the error is reported by the operation itself.
+func (s *service) auditSourceChange(ctx context.Context, k key.SourceKey, change func(definition.Source) *definition.Source) {
+	record := audit.FromContext(ctx)
+	if record == nil {
+		return
+	}
+
+	source, err := s.definition.Source().Get(k).Do(ctx).ResultOrErr()
+	if err != nil {
+		return
+	}
+
+	record.SetBefore(sourceSummary(source))
+	if after := change(source); after != nil {
+		record.SetAfter(sourceSummary(*after))
+	}
+}
+
+// auditSinkChange records the sink state before and after the API call, if the call is audited.
+// The change function returns the expected state after the call, or nil, if the sink is deleted.
+// If the sink cannot be loaded, the summaries are skipped, the error is reported by the operation itself.
+func (s *service) auditSinkChange(ctx context.Context, k key.SinkKey, change func(definition.Sink) *definition.Sink) {
+	record := audit.FromContext(ctx)
+	if record == nil {
+		return
+	}
+
+	sink, err := s.definition.Sink().Get(k).Do(ctx).ResultOrErr()
+	if err != nil {
+		return
+	}
+
+	record.SetBefore(sinkSummary(sink))
+	if after := change(sink); after != nil {
+		record.SetAfter(sinkSummary(*after))
+	}
+}
+
+func sourceSummary(v definition.Source) audit.Summary {
+	out := audit.Summary{
+		"type":     v.Type.String(),
+		"name":     v.Name,
+		"disabled": v.IsDisabled(),
+	}
+	if v.Description != "" {
+		out["description"] = v.Description
+	}
+	if len(v.Config) > 0 {
+		out["settings"] = settingsSummary(v.Config)
+	}
+	return out
+}
+
+func sinkSummary(v definition.Sink) audit.Summary {
+	out := audit.Summary{
+		"type":     v.Type.String(),
+		"name":     v.Name,
+		"disabled": v.IsDisabled(),
+	}
+	if v.Description != "" {
+		out["description"] = v.Description
+	}
+	if v.Table != nil && v.Table.Keboola != nil {
+		out["tableId"] = v.Table.Keboola.TableID.String()
+	}
+	if len(v.Config) > 0 {
+		out["settings"] = settingsSummary(v.Config)
+	}
+	return out
+}
+
+func settingsSummary(kvs configpatch.PatchKVs) map[string]any {
+	out := make(map[string]any, len(kvs))
+	for _, kv := range kvs {
+		out[kv.KeyPath] = kv.Value
+	}
+	return out
+}
+
+func auditBy(by definition.By) audit.By {
+	return audit.By{
+		Type:      by.Type.String(),
+		TokenID:   by.TokenID,
+		TokenDesc: by.TokenDesc,
+		UserID:    by.UserID,
+		UserName:  by.UserName,
+	}
+}
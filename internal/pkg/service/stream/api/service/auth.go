@@ -7,6 +7,7 @@ import (
 	"github.com/dimfeld/httptreemux/v5"
 	"goa.design/goa/v3/security"
 
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/audit"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/dependencies"
 	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
@@ -21,6 +22,7 @@ func (s *service) APIKeyAuth(ctx context.Context, tokenStr string, scheme *secur
 		if err == nil {
 			ctx = context.WithValue(ctx, dependencies.ProjectRequestScopeCtxKey, prjReqScp)
 			ctx = context.WithValue(ctx, dependencies.KeboolaProjectAPICtxKey, prjReqScp.KeboolaProjectAPI())
+			audit.FromContext(ctx).SetIdentity(prjReqScp.ProjectID(), auditBy(prjReqScp.RequestUser()))
 		} else {
 			return nil, err
 		}
@@ -10,6 +10,7 @@ import (
 	"golang.org/x/exp/maps"
 
 	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/audit"
 	svcerrors "github.com/keboola/keboola-as-code/internal/pkg/service/common/errors"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop/iterator"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/task"
@@ -34,6 +35,7 @@ func (s *service) CreateSink(ctx context.Context, d dependencies.SourceRequestSc
 	if err := s.sinkMustNotExist(ctx, sink.SinkKey); err != nil {
 		return nil, err
 	}
+	audit.FromContext(ctx).SetAfter(sinkSummary(sink))
 
 	// Create sink in a task
 	t, err := s.startTask(ctx, taskConfig{
@@ -120,9 +122,13 @@ func (s *service) UpdateSink(ctx context.Context, d dependencies.SinkRequestScop
 	if err != nil {
 		return nil, err
 	}
-	if _, err := update(sink); err != nil {
+	updated, err := update(sink)
+	if err != nil {
 		return nil, err
 	}
+	record := audit.FromContext(ctx)
+	record.SetBefore(sinkSummary(sink))
+	record.SetAfter(sinkSummary(updated))
 
 	// Update sink in a task
 	t, err := s.startTask(ctx, taskConfig{
@@ -165,6 +171,10 @@ func (s *service) DeleteSink(ctx context.Context, d dependencies.SinkRequestScop
 		return nil, err
 	}
 
+	s.auditSinkChange(ctx, d.SinkKey(), func(definition.Sink) *definition.Sink {
+		return nil
+	})
+
 	// Delete sink in a task
 	t, err := s.startTask(ctx, taskConfig{
 		Type:      "delete.sink",
@@ -221,6 +231,10 @@ func (s *service) UpdateSinkSettings(ctx context.Context, d dependencies.SinkReq
 		sink.Config = sink.Config.With(patch)
 		return sink, err
 	}
+	s.auditSinkChange(ctx, d.SinkKey(), func(sink definition.Sink) *definition.Sink {
+		sink, _ = update(sink)
+		return &sink
+	})
 
 	// Update sink settings in a task, it triggers file rotation
 	t, err := s.startTask(ctx, taskConfig{
@@ -319,6 +333,7 @@ func (s *service) SinkStatisticsClear(ctx context.Context, d dependencies.SinkRe
 	if err := s.sinkMustExists(ctx, d.SinkKey()); err != nil {
 		return err
 	}
+	audit.FromContext(ctx).SetObjectKey(d.SinkKey().String())
 
 	return d.StatisticsRepository().ResetSinkStats(d.SinkKey()).Do(ctx).Err()
 }
@@ -328,6 +343,11 @@ func (s *service) DisableSink(ctx context.Context, d dependencies.SinkRequestSco
 		return nil, err
 	}
 
+	s.auditSinkChange(ctx, d.SinkKey(), func(sink definition.Sink) *definition.Sink {
+		sink.Disable(s.clock.Now(), d.RequestUser(), "API", true)
+		return &sink
+	})
+
 	// Disable sink in a task
 	t, err := s.startTask(ctx, taskConfig{
 		Type:      "disable.sink",
@@ -356,6 +376,11 @@ func (s *service) EnableSink(ctx context.Context, d dependencies.SinkRequestScop
 		return nil, err
 	}
 
+	s.auditSinkChange(ctx, d.SinkKey(), func(sink definition.Sink) *definition.Sink {
+		sink.Enable(s.clock.Now(), d.RequestUser())
+		return &sink
+	})
+
 	// Enable sink in a task
 	t, err := s.startTask(ctx, taskConfig{
 		Type:      "enable.sink",
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/audit"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop/iterator"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/task"
 	api "github.com/keboola/keboola-as-code/internal/pkg/service/stream/api/gen/stream"
@@ -27,6 +28,7 @@ func (s *service) CreateSource(ctx context.Context, d dependencies.BranchRequest
 	if err := s.sourceMustNotExist(ctx, source.SourceKey); err != nil {
 		return nil, err
 	}
+	audit.FromContext(ctx).SetAfter(sourceSummary(source))
 
 	// Create source in a task
 	t, err := s.startTask(ctx, taskConfig{
@@ -70,9 +72,13 @@ func (s *service) UpdateSource(ctx context.Context, d dependencies.SourceRequest
 	if err != nil {
 		return nil, err
 	}
-	if _, err := update(source); err != nil {
+	updated, err := update(source)
+	if err != nil {
 		return nil, err
 	}
+	record := audit.FromContext(ctx)
+	record.SetBefore(sourceSummary(source))
+	record.SetAfter(sourceSummary(updated))
 
 	// Update source in a task
 	t, err := s.startTask(ctx, taskConfig{
@@ -124,6 +130,10 @@ func (s *service) DeleteSource(ctx context.Context, d dependencies.SourceRequest
 		return nil, err
 	}
 
+	s.auditSourceChange(ctx, d.SourceKey(), func(definition.Source) *definition.Source {
+		return nil
+	})
+
 	// Delete source in a task
 	t, err := s.startTask(ctx, taskConfig{
 		Type:      "delete.source",
@@ -180,6 +190,10 @@ func (s *service) UpdateSourceSettings(ctx context.Context, d dependencies.Sourc
 		source.Config = source.Config.With(patch)
 		return source, err
 	}
+	s.auditSourceChange(ctx, d.SourceKey(), func(source definition.Source) *definition.Source {
+		source, _ = update(source)
+		return &source
+	})
 
 	// Update source settings in a task
 	t, err := s.startTask(ctx, taskConfig{
@@ -228,6 +242,7 @@ func (s *service) SourceStatisticsClear(ctx context.Context, d dependencies.Sour
 	if err := s.sourceMustExists(ctx, d.SourceKey()); err != nil {
 		return err
 	}
+	audit.FromContext(ctx).SetObjectKey(d.SourceKey().String())
 
 	sinks, err := s.definition.Sink().List(d.SourceKey()).Do(ctx).All()
 	if err != nil {
@@ -247,6 +262,11 @@ func (s *service) DisableSource(ctx context.Context, d dependencies.SourceReques
 		return nil, err
 	}
 
+	s.auditSourceChange(ctx, d.SourceKey(), func(source definition.Source) *definition.Source {
+		source.Disable(s.clock.Now(), d.RequestUser(), "API", true)
+		return &source
+	})
+
 	// Disable source in a task
 	t, err := s.startTask(ctx, taskConfig{
 		Type:      "disable.source",
@@ -275,6 +295,11 @@ func (s *service) EnableSource(ctx context.Context, d dependencies.SourceRequest
 		return nil, err
 	}
 
+	s.auditSourceChange(ctx, d.SourceKey(), func(source definition.Source) *definition.Source {
+		source.Enable(s.clock.Now(), d.RequestUser())
+		return &source
+	})
+
 	// Enable source in a task
 	t, err := s.startTask(ctx, taskConfig{
 		Type:      "enable.source",
@@ -9,6 +9,7 @@ import (
 	"github.com/keboola/go-client/pkg/keboola"
 
 	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/audit"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/rollback"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/task"
 	api "github.com/keboola/keboola-as-code/internal/pkg/service/stream/api/gen/stream"
@@ -33,6 +34,16 @@ func (s *service) GetTask(ctx context.Context, d dependencies.ProjectRequestScop
 	return s.mapper.NewTaskResponse(t)
 }
 
+func (s *service) CancelTask(ctx context.Context, d dependencies.ProjectRequestScope, payload *api.CancelTaskPayload) (*api.Task, error) {
+	audit.FromContext(ctx).SetTaskID(payload.TaskID.String())
+	t, err := s.tasks.CancelTask(ctx, task.Key{ProjectID: d.ProjectID(), TaskID: payload.TaskID})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.mapper.NewTaskResponse(t)
+}
+
 func (s *service) startTask(ctx context.Context, cfg taskConfig) (task.Task, error) {
 	cfg.Type = "api." + cfg.Type
 
@@ -45,7 +56,10 @@ func (s *service) startTask(ctx context.Context, cfg taskConfig) (task.Task, err
 	}
 
 	taskID := task.ID(cfg.Type + "/" + objectKey)
-	return s.tasks.StartTask(ctx, task.Config{
+	record := audit.FromContext(ctx)
+	record.SetObjectKey(cfg.ObjectKey.String())
+
+	t, err := s.tasks.StartTask(ctx, task.Config{
 		Type: cfg.Type,
 		Key:  task.Key{ProjectID: cfg.ProjectID, TaskID: taskID},
 		Lock: cfg.ObjectKey.String(),
@@ -60,4 +74,8 @@ func (s *service) startTask(ctx context.Context, cfg taskConfig) (task.Task, err
 			return result
 		},
 	})
+	if err == nil {
+		record.SetTaskID(t.TaskID.String())
+	}
+	return t, err
 }
@@ -56,6 +56,7 @@ func Start(ctx context.Context, d dependencies.APIScope, cfg config.Config) erro
 			swaggerFs := http.FS(swaggerui.SwaggerFS)
 			endpoints := streamGen.NewEndpoints(svc)
 			endpoints.Use(middleware.OpenTelemetryExtractEndpoint())
+			endpoints.Use(middleware.Audit(d.AuditLog()))
 			server := apiServer.New(endpoints, c.Muxer, c.Decoder, c.Encoder, c.ErrorHandler, c.ErrorFormatter, docsFs, docsFs, docsFs, docsFs, swaggerFs)
 
 			// Mount endpoints
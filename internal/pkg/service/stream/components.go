@@ -39,6 +39,13 @@ func StartComponents(ctx context.Context, serviceScp dependencies.ServiceScope,
 	var distScp commonDeps.DistributionScope
 	if componentsMap[ComponentAPI] || componentsMap[ComponentStorageWriter] || componentsMap[ComponentHTTPSource] || componentsMap[ComponentStorageCoordinator] {
 		distScp = commonDeps.NewDistributionScope(cfg.NodeID, cfg.Distribution, serviceScp)
+
+		// The weight of the node can be changed by the configuration reload
+		serviceScp.ConfigReloader().OnReload(func(ctx context.Context, cfg config.Config) {
+			if err := distScp.DistributionNode().UpdateWeight(ctx, cfg.Distribution.Weight); err != nil {
+				serviceScp.Logger().Errorf(ctx, `cannot update weight of the distribution node: %s`, err)
+			}
+		})
 	}
 
 	// Common task scope
@@ -91,7 +98,11 @@ func StartComponents(ctx context.Context, serviceScp dependencies.ServiceScope,
 	}
 
 	if componentsMap[ComponentAPI] {
-		apiScp, err := dependencies.NewAPIScope(serviceScp, distScp, taskScp, cfg) // nolint:forbidigo
+		auditScp, err := commonDeps.NewAuditScope(ctx, cfg.API.Audit, serviceScp)
+		if err != nil {
+			return err
+		}
+		apiScp, err := dependencies.NewAPIScope(serviceScp, distScp, taskScp, auditScp, cfg) // nolint:forbidigo
 		if err != nil {
 			return err
 		}
@@ -3,6 +3,7 @@ package config
 import (
 	"net/url"
 
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/audit"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/distribution"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdclient"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/task"
@@ -11,6 +12,7 @@ import (
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage"
 	"github.com/keboola/keboola-as-code/internal/pkg/telemetry/datadog"
 	"github.com/keboola/keboola-as-code/internal/pkg/telemetry/metric/prometheus"
+	"github.com/keboola/keboola-as-code/internal/pkg/telemetry/otlp"
 	"github.com/keboola/keboola-as-code/internal/pkg/telemetry/pprof"
 	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
 	"github.com/keboola/keboola-as-code/internal/pkg/utils/strhelper"
@@ -18,7 +20,7 @@ import (
 
 // Config of the Stream services.
 type Config struct {
-	DebugLog        bool                `configKey:"debugLog"  configUsage:"Enable logging at DEBUG level."`
+	DebugLog        bool                `configKey:"debugLog"  configUsage:"Enable logging at DEBUG level." configReload:"true"`
 	DebugHTTPClient bool                `configKey:"debugHTTPClient" configUsage:"Log HTTP client requests and responses as debug messages."`
 	NodeID          string              `configKey:"nodeID" configUsage:"Unique ID of the node in the cluster." validate:"required"`
 	Hostname        string              `configKey:"hostname" configUsage:"Hostname for communication between nodes." validate:"required"`
@@ -27,6 +29,7 @@ type Config struct {
 	Datadog         datadog.Config      `configKey:"datadog"`
 	Etcd            etcdclient.Config   `configKey:"etcd"`
 	Metrics         prometheus.Config   `configKey:"metrics"`
+	OTLP            otlp.Config         `configKey:"otlp"`
 	API             API                 `configKey:"api"`
 	Distribution    distribution.Config `configKey:"distribution"`
 	Source          source.Config       `configKey:"source"`
@@ -44,6 +47,7 @@ type API struct {
 	Listen    string          `configKey:"listen" configUsage:"Listen address of the configuration HTTP API." validate:"required,hostname_port"`
 	PublicURL *url.URL        `configKey:"publicUrl" configUsage:"Public URL of the configuration HTTP API for link generation." validate:"required"`
 	Task      task.NodeConfig `configKey:"task" configUsage:"Background tasks configuration." validate:"required"`
+	Audit     audit.Config    `configKey:"audit" configUsage:"Audit log of mutating API calls."`
 }
 
 func New() Config {
@@ -56,10 +60,12 @@ func New() Config {
 		Datadog:         datadog.NewConfig(),
 		Etcd:            etcdclient.NewConfig(),
 		Metrics:         prometheus.NewConfig(),
+		OTLP:            otlp.NewConfig(),
 		API: API{
 			Listen:    "0.0.0.0:8000",
 			PublicURL: &url.URL{Scheme: "http", Host: "localhost:8000"},
 			Task:      task.NewNodeConfig(),
+			Audit:     audit.NewConfig(),
 		},
 		Distribution: distribution.NewConfig(),
 		Source:       source.NewConfig(),
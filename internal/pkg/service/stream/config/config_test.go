@@ -83,6 +83,29 @@ etcd:
 metrics:
     # Prometheus scraping metrics listen address. Validation rules: required,hostname_port
     listen: 0.0.0.0:9000
+otlp:
+    # Enable export of traces and metrics to an OTLP receiver, for example OpenTelemetry Collector.
+    enabled: false
+    # OTLP protocol: grpc or http. Validation rules: required,oneof=grpc http
+    protocol: grpc
+    # OTLP receiver endpoint in the host:port format, the default port is 4317 for gRPC and 4318 for HTTP.
+    endpoint: ""
+    # Disable TLS for the connection to the OTLP receiver.
+    insecure: false
+    # Timeout of an export request. Validation rules: required,minDuration=1s,maxDuration=5m
+    timeout: 10s
+    # Additional resource attributes in the key=value format.
+    resourceAttributes: []
+    traces:
+        # Enable export of traces.
+        enabled: true
+        # Ratio of sampled traces, from 0 to 1. Child spans follow the sampling decision of the parent span. Validation rules: min=0,max=1
+        samplingRatio: 1
+    metrics:
+        # Enable export of metrics, it replaces the Prometheus metrics endpoint.
+        enabled: true
+        # Interval between metrics exports. Validation rules: required,minDuration=1s,maxDuration=10m
+        exportInterval: 30s
 api:
     # Listen address of the configuration HTTP API. Validation rules: required,hostname_port
     listen: 0.0.0.0:8000
@@ -95,6 +118,26 @@ api:
         cleanupEnabled: true
         # How often will old tasks be deleted. Validation rules: required
         cleanupInterval: 1h0m0s
+        # How often is progress of a running task written to etcd. Validation rules: required
+        progressInterval: 2s
+        queue:
+            # How often are queued tasks checked by a worker node. Validation rules: required
+            checkInterval: 1s
+            # Default maximum number of attempts of a queued task. Validation rules: required,min=1
+            maxAttempts: 5
+            # Delay before the second attempt, it is doubled with each next attempt. Validation rules: required
+            initialBackoff: 5s
+            # Maximum delay between attempts. Validation rules: required,gtefield=InitialBackoff
+            maxBackoff: 5m0s
+    audit:
+        # Enable audit log of mutating API calls.
+        enabled: false
+        # Audit log backend: etcd or file. The file backend is for a single node deployment only, entries are listed only from the local file. Validation rules: required,oneof=etcd file
+        backend: etcd
+        # Expiration of audit log entries in the etcd backend. Validation rules: required,minDuration=1m
+        ttl: 720h0m0s
+        # Path to the JSON lines file of the file backend.
+        filePath: ""
 distribution:
     # The maximum time to wait for creating a new session. Validation rules: required,minDuration=1s,maxDuration=1m
     grantTimeout: 5s
@@ -104,6 +147,10 @@ distribution:
     shutdownTimeout: 10s
     # Interval of processing changes in the topology. Use 0 to disable the grouping. Validation rules: maxDuration=30s
     eventsGroupInterval: 5s
+    # Maximum time to release a key assigned to another node, the new owner waits at most this time. Validation rules: required,minDuration=1s,maxDuration=5m
+    handoffTimeout: 30s
+    # Weight of the node, keys are assigned proportionally to weights of the nodes. Validation rules: required,min=1,max=1000
+    weight: 1
     # Seconds after which the node is automatically un-registered if an outage occurs. Validation rules: required,min=1,max=30
     ttlSeconds: 15
 source:
@@ -0,0 +1,141 @@
+package dependencies
+
+import (
+	"context"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/benbjohnson/clock"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/dependencies"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/distlock"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/httpclient"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/servicectx"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/config"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition"
+	definitionRepo "github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/repository"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/plugin"
+	keboolaSinkBridge "github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/type/tablesink/keboola/bridge"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/model"
+	storageRepo "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/model/repository"
+	"github.com/keboola/keboola-as-code/internal/pkg/telemetry"
+)
+
+// adminScope implements AdminScope interface.
+type adminScope struct {
+	dependencies.BaseScope
+	dependencies.EtcdClientScope
+	dependencies.DistributedLockScope
+	config               config.Config
+	plugins              *plugin.Plugins
+	definitionRepository *definitionRepo.Repository
+	storageRepository    *storageRepo.Repository
+
+	bridgeLock    sync.Mutex
+	keboolaBridge *keboolaSinkBridge.Bridge
+}
+
+// adminBridgeScope provides dependencies for the Keboola sink bridge, see adminScope.EnableKeboolaSinkBridge.
+type adminBridgeScope struct {
+	*adminScope
+	dependencies.PublicScope
+}
+
+// mockedAdminScope wraps the mocked ServiceScope, the Keboola sink bridge is already registered.
+type mockedAdminScope struct {
+	ServiceScope
+}
+
+// NewAdminScope creates dependencies for the admin command.
+// The scope contains only the etcd client and the repositories, so the inspection doesn't depend on the Storage API.
+func NewAdminScope(
+	ctx context.Context,
+	cfg config.Config,
+	proc *servicectx.Process,
+	logger log.Logger,
+	tel telemetry.Telemetry,
+	stdout io.Writer,
+	stderr io.Writer,
+) (v AdminScope, err error) {
+	ctx, span := tel.Tracer().Start(ctx, "keboola.go.stream.dependencies.NewAdminScope")
+	defer span.End(&err)
+
+	// The HTTP client is used only by the Keboola sink bridge, see EnableKeboolaSinkBridge
+	httpClient := httpclient.New(
+		httpclient.WithTelemetry(tel),
+		httpclient.WithUserAgent(userAgent),
+	)
+
+	d := &adminScope{config: cfg}
+
+	d.BaseScope = dependencies.NewBaseScope(ctx, logger, tel, stdout, stderr, clock.New(), proc, httpClient)
+
+	d.EtcdClientScope, err = dependencies.NewEtcdClientScope(ctx, d, cfg.Etcd)
+	if err != nil {
+		return nil, err
+	}
+
+	d.DistributedLockScope, err = dependencies.NewDistributedLockScope(ctx, distlock.NewConfig(), d)
+	if err != nil {
+		return nil, err
+	}
+
+	d.plugins = plugin.New(d.Logger())
+
+	d.definitionRepository = definitionRepo.New(d)
+
+	d.storageRepository, err = storageRepo.New(cfg.Storage.Level, d, model.DefaultBackoff())
+	if err != nil {
+		return nil, err
+	}
+
+	d.plugins.RegisterSinkWithLocalStorage(func(sinkType definition.SinkType) bool {
+		return sinkType == definition.SinkTypeTable
+	})
+
+	return d, nil
+}
+
+func NewMockedAdminScope(tb testing.TB, ctx context.Context, opts ...dependencies.MockedOption) (AdminScope, Mocked) {
+	tb.Helper()
+	svcScp, mock := NewMockedServiceScope(tb, ctx, opts...)
+	return &mockedAdminScope{ServiceScope: svcScp}, mock
+}
+
+func (v *adminScope) Plugins() *plugin.Plugins {
+	return v.plugins
+}
+
+func (v *adminScope) DefinitionRepository() *definitionRepo.Repository {
+	return v.definitionRepository
+}
+
+func (v *adminScope) StorageRepository() *storageRepo.Repository {
+	return v.storageRepository
+}
+
+// EnableKeboolaSinkBridge loads the Storage API index and registers the Keboola sink bridge.
+// The bridge is required to open a new file in a Keboola table sink, for example on a file rotation.
+func (v *adminScope) EnableKeboolaSinkBridge(ctx context.Context) error {
+	v.bridgeLock.Lock()
+	defer v.bridgeLock.Unlock()
+
+	if v.keboolaBridge != nil {
+		return nil
+	}
+
+	publicScp, err := dependencies.NewPublicScope(ctx, v, v.config.StorageAPIHost)
+	if err != nil {
+		return err
+	}
+
+	v.keboolaBridge = keboolaSinkBridge.New(&adminBridgeScope{adminScope: v, PublicScope: publicScp}, keboolaProjectAPIFromContext, v.config.Sink.Table.Keboola)
+	return nil
+}
+
+// EnableKeboolaSinkBridge is a no-op, the bridge is part of the mocked ServiceScope.
+func (v *mockedAdminScope) EnableKeboolaSinkBridge(_ context.Context) error {
+	return nil
+}
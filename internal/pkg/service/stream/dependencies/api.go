@@ -17,13 +17,14 @@ type apiScope struct {
 	ServiceScope
 	dependencies.DistributionScope
 	dependencies.TaskScope
+	dependencies.AuditScope
 	logger              log.Logger
 	apiPublicURL        *url.URL
 	httpSourcePublicURL *url.URL
 }
 
-func NewAPIScope(serviceScp ServiceScope, distScp dependencies.DistributionScope, taskScp dependencies.TaskScope, cfg config.Config) (v APIScope, err error) {
-	return newAPIScope(serviceScp, distScp, taskScp, cfg), nil
+func NewAPIScope(serviceScp ServiceScope, distScp dependencies.DistributionScope, taskScp dependencies.TaskScope, auditScp dependencies.AuditScope, cfg config.Config) (v APIScope, err error) {
+	return newAPIScope(serviceScp, distScp, taskScp, auditScp, cfg), nil
 }
 
 func NewMockedAPIScope(tb testing.TB, ctx context.Context, opts ...dependencies.MockedOption) (APIScope, Mocked) {
@@ -41,13 +42,16 @@ func NewMockedAPIScopeWithConfig(tb testing.TB, ctx context.Context, modifyConfi
 	tasksScp, err := dependencies.NewTaskScope(ctx, mock.TestConfig().NodeID, exceptionIDPrefix, svcScp, svcScp, distScp, mock.TestConfig().API.Task)
 	require.NoError(tb, err)
 
-	apiScp := newAPIScope(svcScp, distScp, tasksScp, mock.TestConfig())
+	auditScp, err := dependencies.NewAuditScope(ctx, mock.TestConfig().API.Audit, svcScp)
+	require.NoError(tb, err)
+
+	apiScp := newAPIScope(svcScp, distScp, tasksScp, auditScp, mock.TestConfig())
 
 	mock.DebugLogger().Truncate()
 	return apiScp, mock
 }
 
-func newAPIScope(svcScope ServiceScope, distScp dependencies.DistributionScope, tasksScp dependencies.TaskScope, cfg config.Config) APIScope {
+func newAPIScope(svcScope ServiceScope, distScp dependencies.DistributionScope, tasksScp dependencies.TaskScope, auditScp dependencies.AuditScope, cfg config.Config) APIScope {
 	d := &apiScope{}
 
 	d.ServiceScope = svcScope
@@ -56,6 +60,8 @@ func newAPIScope(svcScope ServiceScope, distScp dependencies.DistributionScope,
 
 	d.TaskScope = tasksScp
 
+	d.AuditScope = auditScp
+
 	d.logger = svcScope.Logger().WithComponent("api")
 
 	d.apiPublicURL = cfg.API.PublicURL
@@ -11,6 +11,7 @@
 //   - [ProjectRequestScope] contains short-lived dependencies for a request with authentication.
 //   - [SourceScope] contains long-lived dependencies for source nodes.
 //   - [StorageScope] contains long-lived dependencies for local storage writer/reader nodes.
+//   - [AdminScope] contains only the etcd client and the repositories, for the admin command.
 //
 // Dependency containers creation:
 //   - [ServiceScope] is created during the creation of [APIScope] or [StorageScope].
@@ -22,6 +23,7 @@
 //   - [StorageScope] is created at startup of a local storage writer/reader node.
 //   - [StorageWriterScope] is created at startup of a local storage writer node.
 //   - [StorageReaderScope] is created at startup of a local storage reader node.
+//   - [AdminScope] is created at startup of the admin command.
 //
 // The package also provides mocked dependency implementations for tests:
 //   - [NewMockedServiceScope]
@@ -32,6 +34,7 @@
 //   - [NewMockedStorageScope]
 //   - [NewMockedStorageWriterScope]
 //   - [NewMockedStorageReaderScope]
+//   - [NewMockedAdminScope]
 //
 // Dependencies injection to service endpoints:
 //   - Each service endpoint method gets [PublicRequestScope] as a parameter.
@@ -41,8 +44,10 @@
 package dependencies
 
 import (
+	"context"
 	"net/url"
 
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/configmap"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/dependencies"
 	aggregationRepo "github.com/keboola/keboola-as-code/internal/pkg/service/stream/aggregation/repository"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/config"
@@ -79,6 +84,7 @@ type ServiceScope interface {
 	dependencies.PublicScope
 	dependencies.EtcdClientScope
 	dependencies.DistributedLockScope
+	ConfigReloader() *configmap.Reloader[config.Config]
 	Plugins() *plugin.Plugins
 	DefinitionRepository() *definitionRepo.Repository
 	StorageRepository() *storageRepo.Repository
@@ -87,10 +93,22 @@ type ServiceScope interface {
 	KeboolaSinkBridge() *keboolaSinkBridge.Bridge
 }
 
+// AdminScope contains only the etcd client and the repositories, it is used by the admin command.
+type AdminScope interface {
+	dependencies.BaseScope
+	dependencies.EtcdClientScope
+	dependencies.DistributedLockScope
+	Plugins() *plugin.Plugins
+	DefinitionRepository() *definitionRepo.Repository
+	StorageRepository() *storageRepo.Repository
+	EnableKeboolaSinkBridge(ctx context.Context) error
+}
+
 type APIScope interface {
 	ServiceScope
 	dependencies.DistributionScope
 	dependencies.TaskScope
+	dependencies.AuditScope
 	APIPublicURL() *url.URL
 	HTTPSourcePublicURL() *url.URL
 }
@@ -3,6 +3,7 @@ package dependencies
 import (
 	"context"
 	"io"
+	"net/http"
 	"testing"
 
 	"github.com/benbjohnson/clock"
@@ -11,6 +12,7 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/configmap"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/dependencies"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/distlock"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/httpclient"
@@ -40,6 +42,7 @@ type serviceScope struct {
 	dependencies.EtcdClientScope
 	dependencies.DistributedLockScope
 	logger                      log.Logger
+	configReloader              *configmap.Reloader[config.Config]
 	plugins                     *plugin.Plugins
 	definitionRepository        *definitionRepo.Repository
 	storageRepository           *storageRepo.Repository
@@ -48,6 +51,27 @@ type serviceScope struct {
 	keboolaBridge               *keboolaSinkBridge.Bridge
 }
 
+type ServiceScopeOption func(*serviceScopeConfig)
+
+type serviceScopeConfig struct {
+	httpTransport http.RoundTripper
+}
+
+func newServiceScopeConfig(opts []ServiceScopeOption) serviceScopeConfig {
+	cfg := serviceScopeConfig{}
+	for _, o := range opts {
+		o(&cfg)
+	}
+	return cfg
+}
+
+// WithHTTPTransport replaces the transport of the HTTP client used for requests to other APIs, for example by a fake Storage API.
+func WithHTTPTransport(v http.RoundTripper) ServiceScopeOption {
+	return func(c *serviceScopeConfig) {
+		c.httpTransport = v
+	}
+}
+
 type parentScopes struct {
 	dependencies.BaseScope
 	dependencies.PublicScope
@@ -58,27 +82,29 @@ type parentScopes struct {
 
 func NewServiceScope(
 	ctx context.Context,
-	cfg config.Config,
+	configReloader *configmap.Reloader[config.Config],
 	proc *servicectx.Process,
 	logger log.Logger,
 	tel telemetry.Telemetry,
 	stdout io.Writer,
 	stderr io.Writer,
+	opts ...ServiceScopeOption,
 ) (v ServiceScope, err error) {
 	ctx, span := tel.Tracer().Start(ctx, "keboola.go.stream.dependencies.NewServiceScope")
 	defer span.End(&err)
 
-	p, err := newParentScopes(ctx, cfg, proc, logger, tel, stdout, stderr)
+	p, err := newParentScopes(ctx, configReloader.Current(), newServiceScopeConfig(opts), proc, logger, tel, stdout, stderr)
 	if err != nil {
 		return nil, err
 	}
 
-	return newServiceScope(p.BaseScope, p.PublicScope, p.EtcdClientScope, p.DistributedLockScope, cfg, model.DefaultBackoff())
+	return newServiceScope(p.BaseScope, p.PublicScope, p.EtcdClientScope, p.DistributedLockScope, configReloader, model.DefaultBackoff())
 }
 
 func newParentScopes(
 	ctx context.Context,
 	cfg config.Config,
+	scopeCfg serviceScopeConfig,
 	proc *servicectx.Process,
 	logger log.Logger,
 	tel telemetry.Telemetry,
@@ -101,6 +127,9 @@ func newParentScopes(
 			}
 		},
 	)
+	if scopeCfg.httpTransport != nil {
+		httpClient = httpClient.WithTransport(scopeCfg.httpTransport)
+	}
 
 	d := &parentScopes{}
 
@@ -154,7 +183,7 @@ func NewMockedServiceScopeWithConfig(tb testing.TB, ctx context.Context, modifyC
 	require.NoError(tb, err)
 
 	backoff := model.NoRandomizationBackoff()
-	serviceScp, err := newServiceScope(mock, mock, mock, distLockScope, cfg, backoff)
+	serviceScp, err := newServiceScope(mock, mock, mock, distLockScope, configmap.NewReloader(cfg, nil), backoff)
 	require.NoError(tb, err)
 
 	mock.DebugLogger().Truncate()
@@ -167,9 +196,11 @@ func NewMockedServiceScopeWithConfig(tb testing.TB, ctx context.Context, modifyC
 	return serviceScp, mock
 }
 
-func newServiceScope(baseScp dependencies.BaseScope, publicScp dependencies.PublicScope, etcdClientScp dependencies.EtcdClientScope, distLockScp dependencies.DistributedLockScope, cfg config.Config, storageBackoff model.RetryBackoff) (ServiceScope, error) {
+func newServiceScope(baseScp dependencies.BaseScope, publicScp dependencies.PublicScope, etcdClientScp dependencies.EtcdClientScope, distLockScp dependencies.DistributedLockScope, configReloader *configmap.Reloader[config.Config], storageBackoff model.RetryBackoff) (ServiceScope, error) {
 	var err error
 
+	cfg := configReloader.Current()
+
 	d := &serviceScope{}
 
 	d.BaseScope = baseScp
@@ -195,24 +226,36 @@ func newServiceScope(baseScp dependencies.BaseScope, publicScp dependencies.Publ
 		return sinkType == definition.SinkTypeTable
 	})
 
-	apiCtxProvider := func(ctx context.Context) *keboola.AuthorizedAPI {
-		api, _ := ctx.Value(KeboolaProjectAPICtxKey).(*keboola.AuthorizedAPI)
-		return api
-	}
-
-	d.keboolaBridge = keboolaSinkBridge.New(d, apiCtxProvider, cfg.Sink.Table.Keboola)
+	d.keboolaBridge = keboolaSinkBridge.New(d, keboolaProjectAPIFromContext, cfg.Sink.Table.Keboola)
 
 	d.storageStatisticsRepository = statsRepo.New(d)
 
 	d.aggregationRepository = aggregationRepo.New(d)
 
+	d.configReloader = configReloader
+
+	// New files use the reloaded storage level configuration
+	configReloader.OnReload(func(ctx context.Context, cfg config.Config) {
+		d.storageRepository.File().UpdateConfig(cfg.Storage.Level)
+	})
+
 	return d, nil
 }
 
+// keboolaProjectAPIFromContext returns the authorized API of the project request, see KeboolaProjectAPICtxKey.
+func keboolaProjectAPIFromContext(ctx context.Context) *keboola.AuthorizedAPI {
+	api, _ := ctx.Value(KeboolaProjectAPICtxKey).(*keboola.AuthorizedAPI)
+	return api
+}
+
 func (v *serviceScope) Logger() log.Logger {
 	return v.logger
 }
 
+func (v *serviceScope) ConfigReloader() *configmap.Reloader[config.Config] {
+	return v.configReloader
+}
+
 func (v *serviceScope) Plugins() *plugin.Plugins {
 	return v.plugins
 }
@@ -0,0 +1,57 @@
+package devmode
+
+import (
+	"net/url"
+	"path/filepath"
+	"time"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/duration"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/config"
+)
+
+// Config of the dev mode.
+// The Stream service configuration is reused, defaults are modified to run all components on a laptop.
+type Config struct {
+	config.Config `configKey:",squash"`
+	DataDir       string `configKey:"dataDir" configUsage:"Directory for the embedded etcd data, local volumes and the fake Storage target." validate:"required"`
+	Volumes       int    `configKey:"volumes" configUsage:"Number of local volumes created in the volumes path." validate:"min=1,max=16"`
+}
+
+func NewConfig() Config {
+	cfg := Config{
+		Config:  config.New(),
+		DataDir: "/tmp/stream-dev",
+		Volumes: 1,
+	}
+
+	cfg.NodeID = "dev-node"
+	cfg.Hostname = "localhost"
+	cfg.StorageAPIHost = FakeStorageAPIHost
+	cfg.Datadog.Enabled = false
+	cfg.Etcd.Endpoint = "localhost:23790"
+	cfg.Etcd.Namespace = "stream"
+	cfg.Source.HTTP.PublicURL = &url.URL{Scheme: "http", Host: "localhost:7000"}
+
+	// Shorter intervals, so results are visible soon
+	cfg.Storage.Level.Staging.Upload.MinInterval = duration.From(1 * time.Second)
+	cfg.Storage.Level.Staging.Upload.Trigger.Interval = duration.From(5 * time.Second)
+	cfg.Storage.Level.Target.Import.MinInterval = duration.From(30 * time.Second)
+	cfg.Storage.Level.Target.Import.Trigger.Interval = duration.From(30 * time.Second)
+
+	return cfg
+}
+
+// Normalize places volumes to the data directory, if the path is not set.
+func (c *Config) Normalize() {
+	if c.Storage.VolumesPath == "" && c.DataDir != "" {
+		c.Storage.VolumesPath = filepath.Join(c.DataDir, "volumes")
+	}
+}
+
+func (c *Config) etcdDir() string {
+	return filepath.Join(c.DataDir, "etcd")
+}
+
+func (c *Config) targetDir() string {
+	return filepath.Join(c.DataDir, "target")
+}
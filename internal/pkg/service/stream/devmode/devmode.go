@@ -0,0 +1,70 @@
+// Package devmode runs all Stream service components in one process, without external dependencies.
+//
+// The dev mode is intended for local end-to-end testing of source and sink definitions:
+//   - An embedded etcd server is started, see StartEtcd.
+//   - Local volumes are created in the data directory.
+//   - Storage API requests are handled in-memory by the FakeStorageAPI.
+//   - Data of the Keboola table sinks are written to the data directory, see RegisterLocalTarget.
+package devmode
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/configmap"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/servicectx"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/dependencies"
+	"github.com/keboola/keboola-as-code/internal/pkg/telemetry"
+)
+
+const (
+	dataDirPerm = 0o750
+)
+
+// Start prepares the data directory, starts the embedded etcd and all service components.
+func Start(ctx context.Context, cfg Config, logger log.Logger, proc *servicectx.Process, tel telemetry.Telemetry) (dependencies.ServiceScope, error) {
+	if err := os.MkdirAll(cfg.DataDir, dataDirPerm); err != nil {
+		return nil, err
+	}
+
+	// Create volumes, each volume is a "{type}/{label}" directory
+	for i := 1; i <= cfg.Volumes; i++ {
+		if err := os.MkdirAll(filepath.Join(cfg.Storage.VolumesPath, "hdd", fmt.Sprintf("volume-%d", i)), dataDirPerm); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := StartEtcd(ctx, logger, proc, cfg.Etcd.Endpoint, cfg.etcdDir()); err != nil {
+		return nil, err
+	}
+
+	// Create dependencies, requests to the Storage API are handled by the fake implementation
+	storageAPI := NewFakeStorageAPI(cfg.StorageAPIHost)
+	d, err := dependencies.NewServiceScope(
+		ctx, configmap.NewReloader(cfg.Config, nil), proc, logger, tel, os.Stdout, os.Stderr, // nolint:forbidigo
+		dependencies.WithHTTPTransport(storageAPI.Transport()),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	RegisterLocalTarget(d.Logger(), d.Plugins(), cfg.targetDir())
+
+	components := stream.Components{
+		stream.ComponentStorageCoordinator,
+		stream.ComponentStorageWriter,
+		stream.ComponentStorageReader,
+		stream.ComponentAPI,
+		stream.ComponentHTTPSource,
+	}
+	if err := stream.StartComponents(ctx, d, cfg.Config, components...); err != nil {
+		return nil, err
+	}
+
+	logger.Infof(ctx, `dev mode is running, API: %s, HTTP source: %s, target dir: %q`, cfg.API.PublicURL, cfg.Source.HTTP.PublicURL, cfg.targetDir())
+	return d, nil
+}
@@ -0,0 +1,146 @@
+package devmode_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/encoding/json"
+	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/configmap"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/duration"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/servicectx"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/devmode"
+	"github.com/keboola/keboola-as-code/internal/pkg/telemetry"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/netutils"
+)
+
+func TestStart(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	apiAddr := fmt.Sprintf("localhost:%d", netutils.FreePortForTest(t))
+	sourceAddr := fmt.Sprintf("localhost:%d", netutils.FreePortForTest(t))
+
+	cfg := devmode.NewConfig()
+	cfg.DataDir = t.TempDir()
+	cfg.Etcd.Endpoint = fmt.Sprintf("localhost:%d", netutils.FreePortForTest(t))
+	cfg.API.Listen = apiAddr
+	cfg.API.PublicURL = &url.URL{Scheme: "http", Host: apiAddr}
+	cfg.Source.HTTP.Listen = sourceAddr
+	cfg.Source.HTTP.PublicURL = &url.URL{Scheme: "http", Host: sourceAddr}
+	cfg.Storage.Level.Local.Writer.Network.Listen = fmt.Sprintf("localhost:%d", netutils.FreePortForTest(t))
+	require.NoError(t, configmap.ValidateAndNormalize(&cfg))
+
+	// Import the file after 3 records, intervals are below the production minimum, so the test is fast
+	cfg.Storage.Level.Staging.Upload.MinInterval = duration.From(time.Second)
+	cfg.Storage.Level.Staging.Upload.Trigger.Interval = duration.From(time.Second)
+	cfg.Storage.Level.Target.Import.MinInterval = duration.From(time.Second)
+	cfg.Storage.Level.Target.Import.Trigger.Count = 3
+
+	logger := log.NewDebugLogger()
+	proc := servicectx.New(servicectx.WithLogger(logger), servicectx.WithoutSignals())
+	defer func() {
+		proc.Shutdown(ctx, errors.New("bye bye"))
+		proc.WaitForShutdown()
+	}()
+
+	_, err := devmode.Start(ctx, cfg, logger, proc, telemetry.NewNop())
+	require.NoError(t, err)
+
+	// Create source and sink, requests to the Storage API are handled by the fake implementation
+	apiURL := cfg.API.PublicURL.String()
+	createTask := apiRequest(t, ctx, http.MethodPost, apiURL+"/v1/branches/default/sources", map[string]any{
+		"sourceId": "my-source",
+		"name":     "My Source",
+		"type":     "http",
+	})
+	waitForTask(t, ctx, createTask)
+	createTask = apiRequest(t, ctx, http.MethodPost, apiURL+"/v1/branches/default/sources/my-source/sinks", map[string]any{
+		"sinkId": "my-sink",
+		"name":   "My Sink",
+		"type":   "table",
+		"table": map[string]any{
+			"type":    "keboola",
+			"tableId": "in.c-my-bucket.my-table",
+			"mapping": map[string]any{
+				"columns": []map[string]any{
+					{"type": "body", "name": "body"},
+				},
+			},
+		},
+	})
+	waitForTask(t, ctx, createTask)
+
+	// Send records to the HTTP source
+	source := apiRequest(t, ctx, http.MethodGet, apiURL+"/v1/branches/default/sources/my-source", nil)
+	sourceURL := source["http"].(map[string]any)["url"].(string)
+	for i := 1; i <= 3; i++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, sourceURL, strings.NewReader(fmt.Sprintf("record%d", i)))
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	// Records are imported to the CSV file in the target directory
+	tablePath := filepath.Join(cfg.DataDir, "target", "tables", strconv.Itoa(devmode.FakeProjectID), strconv.Itoa(devmode.FakeBranchID), "my-source", "my-sink.csv") // nolint:forbidigo
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		content, err := os.ReadFile(tablePath) // nolint:forbidigo
+		if assert.NoError(c, err) {
+			assert.Equal(c, "\"record1\"\n\"record2\"\n\"record3\"\n", string(content))
+		}
+	}, time.Minute, 100*time.Millisecond)
+}
+
+func apiRequest(t *testing.T, ctx context.Context, method, url string, body any) map[string]any {
+	t.Helper()
+
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(json.MustEncode(body, false))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-StorageApi-Token", "my-token")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Less(t, resp.StatusCode, http.StatusBadRequest, string(respBody))
+
+	result := make(map[string]any)
+	require.NoError(t, json.Decode(respBody, &result))
+	return result
+}
+
+func waitForTask(t *testing.T, ctx context.Context, task map[string]any) {
+	t.Helper()
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		task = apiRequest(t, ctx, http.MethodGet, task["url"].(string), nil)
+		isFinished, _ := task["isFinished"].(bool)
+		assert.True(c, isFinished)
+	}, 30*time.Second, 100*time.Millisecond)
+	require.Equal(t, "success", task["status"], task["error"])
+}
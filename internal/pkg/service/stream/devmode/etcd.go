@@ -0,0 +1,79 @@
+package devmode
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"strconv"
+	"time"
+
+	"go.etcd.io/etcd/server/v3/embed"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/servicectx"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+const (
+	etcdStartTimeout = 30 * time.Second
+	etcdName         = "stream-dev"
+)
+
+// StartEtcd starts an embedded single-node etcd server, the client endpoint is taken from the etcd client configuration.
+// Data are stored in the dataDir, so definitions are preserved between runs.
+// The server is stopped on the process shutdown, after all components.
+func StartEtcd(ctx context.Context, logger log.Logger, proc *servicectx.Process, endpoint, dataDir string) (*embed.Etcd, error) {
+	logger = logger.WithComponent("etcd.embedded")
+
+	clientURL, err := url.Parse("http://" + endpoint)
+	if err != nil {
+		return nil, errors.PrefixErrorf(err, `invalid etcd endpoint "%s"`, endpoint)
+	}
+
+	// The peer URL is not used by a single node cluster, any free port is enough
+	peerPort, err := freePort(clientURL.Hostname())
+	if err != nil {
+		return nil, err
+	}
+	peerURL := &url.URL{Scheme: "http", Host: net.JoinHostPort(clientURL.Hostname(), strconv.Itoa(peerPort))}
+
+	cfg := embed.NewConfig()
+	cfg.Name = etcdName
+	cfg.Dir = dataDir
+	cfg.LogLevel = "error"
+	cfg.ListenClientUrls = []url.URL{*clientURL}
+	cfg.AdvertiseClientUrls = []url.URL{*clientURL}
+	cfg.ListenPeerUrls = []url.URL{*peerURL}
+	cfg.AdvertisePeerUrls = []url.URL{*peerURL}
+	cfg.InitialCluster = cfg.InitialClusterFromName(cfg.Name)
+
+	server, err := embed.StartEtcd(cfg)
+	if err != nil {
+		return nil, errors.PrefixError(err, "cannot start embedded etcd")
+	}
+
+	select {
+	case <-server.Server.ReadyNotify():
+		logger.Infof(ctx, `embedded etcd is listening on %q, data dir %q`, clientURL.Host, dataDir)
+	case <-time.After(etcdStartTimeout):
+		server.Close()
+		return nil, errors.Errorf("embedded etcd is not ready after %s", etcdStartTimeout)
+	}
+
+	proc.OnShutdown(func(ctx context.Context) {
+		logger.Info(ctx, "stopping embedded etcd")
+		server.Close()
+		logger.Info(ctx, "embedded etcd stopped")
+	})
+
+	return server, nil
+}
+
+func freePort(host string) (int, error) {
+	listener, err := net.Listen("tcp", net.JoinHostPort(host, "0"))
+	if err != nil {
+		return 0, errors.PrefixError(err, "cannot find a free port")
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
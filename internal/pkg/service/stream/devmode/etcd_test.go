@@ -0,0 +1,66 @@
+package devmode_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	etcd "go.etcd.io/etcd/client/v3"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/servicectx"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/devmode"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/netutils"
+)
+
+func TestStartEtcd(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dataDir := t.TempDir()
+	endpoint := fmt.Sprintf("localhost:%d", netutils.FreePortForTest(t))
+
+	// Start the server, write a key
+	logger := log.NewDebugLogger()
+	proc := servicectx.New(servicectx.WithLogger(logger), servicectx.WithoutSignals())
+	_, err := devmode.StartEtcd(ctx, logger, proc, endpoint, dataDir)
+	require.NoError(t, err)
+	client := newEtcdClient(t, endpoint)
+	_, err = client.Put(ctx, "foo", "bar")
+	require.NoError(t, err)
+	require.NoError(t, client.Close())
+
+	// Stop the server on shutdown
+	proc.Shutdown(ctx, errors.New("bye bye"))
+	proc.WaitForShutdown()
+	logger.AssertJSONMessages(t, `
+{"level":"info","message":"embedded etcd is listening on \"%s\", data dir \"%s\"","component":"etcd.embedded"}
+{"level":"info","message":"stopping embedded etcd","component":"etcd.embedded"}
+{"level":"info","message":"embedded etcd stopped","component":"etcd.embedded"}
+`)
+
+	// Data are preserved between runs
+	proc = servicectx.New(servicectx.WithLogger(logger), servicectx.WithoutSignals())
+	_, err = devmode.StartEtcd(ctx, logger, proc, endpoint, dataDir)
+	require.NoError(t, err)
+	client = newEtcdClient(t, endpoint)
+	resp, err := client.Get(ctx, "foo")
+	require.NoError(t, err)
+	if assert.Len(t, resp.Kvs, 1) {
+		assert.Equal(t, "bar", string(resp.Kvs[0].Value))
+	}
+	require.NoError(t, client.Close())
+	proc.Shutdown(ctx, errors.New("bye bye"))
+	proc.WaitForShutdown()
+}
+
+func newEtcdClient(t *testing.T, endpoint string) *etcd.Client {
+	t.Helper()
+	client, err := etcd.New(etcd.Config{Endpoints: []string{endpoint}, DialTimeout: 10 * time.Second})
+	require.NoError(t, err)
+	return client
+}
@@ -0,0 +1,407 @@
+package devmode
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/keboola/go-client/pkg/keboola"
+	"github.com/keboola/go-client/pkg/keboola/storage_file_upload/s3"
+	"github.com/relvacode/iso8601"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/encoding/json"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+const (
+	// FakeStorageAPIHost is the default Storage API host in the dev mode, requests are handled by the FakeStorageAPI.
+	FakeStorageAPIHost = "connection.keboola.local"
+	// FakeProjectID is ID of the project of each token.
+	FakeProjectID = 1
+	// FakeBranchID is ID of the default branch.
+	FakeBranchID = 1
+	// fakeCredentialsExpiration is expiration of staging file credentials, the file is rotated before the expiration.
+	fakeCredentialsExpiration = 24 * time.Hour
+)
+
+// FakeStorageAPI is an in-memory implementation of the Storage API endpoints used by the Stream service.
+// Any token is accepted, it belongs to the FakeProjectID project with the FakeBranchID default branch.
+// Buckets, tables, tokens and staging files are stored only in memory, data are written by the localTarget.
+type FakeStorageAPI struct {
+	mux     *http.ServeMux
+	lock    *sync.Mutex
+	nextID  int
+	buckets map[keboola.BucketKey]keboola.Bucket
+	tables  map[keboola.TableKey]keboola.Table
+	jobs    map[keboola.StorageJobID]keboola.Table
+}
+
+// handlerTransport is a http.RoundTripper, which serves each request in-process by the handler, without a network connection.
+type handlerTransport struct {
+	handler http.Handler
+}
+
+// responseWriter buffers the response written by the handler, see handlerTransport.
+type responseWriter struct {
+	header http.Header
+	body   bytes.Buffer
+	status int
+}
+
+func NewFakeStorageAPI(host string) *FakeStorageAPI {
+	api := &FakeStorageAPI{
+		mux:     http.NewServeMux(),
+		lock:    &sync.Mutex{},
+		nextID:  1000,
+		buckets: make(map[keboola.BucketKey]keboola.Bucket),
+		tables:  make(map[keboola.TableKey]keboola.Table),
+		jobs:    make(map[keboola.StorageJobID]keboola.Table),
+	}
+
+	api.registerIndex(host)
+	api.registerBranches()
+	api.registerBuckets()
+	api.registerTables()
+	api.registerTokens()
+	api.registerFiles()
+
+	return api
+}
+
+// Transport of the HTTP client, see the dependencies.WithHTTPTransport option.
+func (api *FakeStorageAPI) Transport() http.RoundTripper {
+	return &handlerTransport{handler: api.mux}
+}
+
+func (api *FakeStorageAPI) registerIndex(host string) {
+	index := &keboola.IndexComponents{
+		Index: keboola.Index{
+			Services: keboola.Services{
+				{ID: "encryption", URL: "https://encryption.keboola.local"},
+				{ID: "scheduler", URL: "https://scheduler.keboola.local"},
+				{ID: "queue", URL: "https://queue.keboola.local"},
+				{ID: "sandboxes", URL: "https://sandboxes.keboola.local"},
+			},
+			Features: keboola.Features{},
+		},
+		Components: keboola.Components{},
+	}
+	api.mux.HandleFunc("GET "+host+"/v2/storage/{$}", jsonHandler(http.StatusOK, index))
+
+	// Any token is valid
+	api.mux.HandleFunc("GET "+host+"/v2/storage/tokens/verify", func(w http.ResponseWriter, request *http.Request) {
+		writeJSON(w, http.StatusOK, keboola.Token{
+			ID:       "dev-token",
+			Token:    request.Header.Get("X-StorageApi-Token"),
+			IsMaster: true,
+			Owner:    keboola.TokenOwner{ID: FakeProjectID, Name: "Dev Project", Features: keboola.Features{}},
+		})
+	})
+}
+
+func (api *FakeStorageAPI) registerBranches() {
+	branch := &keboola.Branch{BranchKey: keboola.BranchKey{ID: FakeBranchID}, Name: "Main", IsDefault: true}
+	api.mux.HandleFunc("GET /v2/storage/dev-branches", jsonHandler(http.StatusOK, []*keboola.Branch{branch}))
+	api.mux.HandleFunc(fmt.Sprintf("GET /v2/storage/dev-branches/%d", FakeBranchID), jsonHandler(http.StatusOK, branch))
+}
+
+func (api *FakeStorageAPI) registerBuckets() {
+	// Get bucket
+	api.mux.HandleFunc("GET /v2/storage/branch/{branchID}/buckets/{bucketID}", func(w http.ResponseWriter, request *http.Request) {
+		api.lock.Lock()
+		defer api.lock.Unlock()
+
+		branchID, err := branchIDFromPath(request)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		bucketID, err := keboola.ParseBucketID(request.PathValue("bucketID"))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		if bucket, ok := api.buckets[keboola.BucketKey{BranchID: branchID, BucketID: bucketID}]; ok {
+			writeJSON(w, http.StatusOK, bucket)
+			return
+		}
+
+		writeJSON(w, http.StatusNotFound, &keboola.StorageError{ErrCode: "storage.buckets.notFound"})
+	})
+
+	// Create bucket
+	api.mux.HandleFunc("POST /v2/storage/branch/{branchID}/buckets", func(w http.ResponseWriter, request *http.Request) {
+		api.lock.Lock()
+		defer api.lock.Unlock()
+
+		branchID, err := branchIDFromPath(request)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		data := make(map[string]any)
+		if err := decodeBody(request, &data); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		name, _ := data["name"].(string)
+		bucketID, err := keboola.ParseBucketID(fmt.Sprintf("%s.c-%s", data["stage"], strings.TrimPrefix(name, "c-")))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		bucket := keboola.Bucket{BucketKey: keboola.BucketKey{BranchID: branchID, BucketID: bucketID}}
+		api.buckets[bucket.BucketKey] = bucket
+		writeJSON(w, http.StatusOK, bucket)
+	})
+}
+
+func (api *FakeStorageAPI) registerTables() {
+	// Get table
+	api.mux.HandleFunc("GET /v2/storage/branch/{branchID}/tables/{tableID}", func(w http.ResponseWriter, request *http.Request) {
+		api.lock.Lock()
+		defer api.lock.Unlock()
+
+		branchID, err := branchIDFromPath(request)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		tableID, err := keboola.ParseTableID(request.PathValue("tableID"))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		if table, ok := api.tables[keboola.TableKey{BranchID: branchID, TableID: tableID}]; ok {
+			writeJSON(w, http.StatusOK, table)
+			return
+		}
+
+		writeJSON(w, http.StatusNotFound, &keboola.StorageError{ErrCode: "storage.tables.notFound"})
+	})
+
+	// Create table, the table is created immediately, but a job is returned, as in the real API
+	api.mux.HandleFunc("POST /v2/storage/branch/{branchID}/buckets/{bucketID}/tables-definition", func(w http.ResponseWriter, request *http.Request) {
+		api.lock.Lock()
+		defer api.lock.Unlock()
+
+		branchID, err := branchIDFromPath(request)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		bucketID, err := keboola.ParseBucketID(request.PathValue("bucketID"))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		data := keboola.CreateTableRequest{}
+		if err := decodeBody(request, &data); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		table := keboola.Table{
+			TableKey:   keboola.TableKey{BranchID: branchID, TableID: keboola.TableID{BucketID: bucketID, TableName: data.Name}},
+			PrimaryKey: data.TableDefinition.PrimaryKeyNames,
+			Columns:    data.TableDefinition.Columns.Names(),
+		}
+		jobID := keboola.StorageJobID(api.newID())
+		api.tables[table.TableKey] = table
+		api.jobs[jobID] = table
+
+		writeJSON(w, http.StatusCreated, &keboola.StorageJob{
+			StorageJobKey: keboola.StorageJobKey{ID: jobID},
+			Status:        keboola.StorageJobStatusProcessing,
+		})
+	})
+
+	// Get job
+	api.mux.HandleFunc("GET /v2/storage/jobs/{jobID}", func(w http.ResponseWriter, request *http.Request) {
+		api.lock.Lock()
+		defer api.lock.Unlock()
+
+		jobIDRaw := request.PathValue("jobID")
+		jobIDInt, err := strconv.Atoi(jobIDRaw)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, errors.Errorf(`unexpected job ID "%s"`, jobIDRaw))
+			return
+		}
+
+		jobID := keboola.StorageJobID(jobIDInt)
+		table, ok := api.jobs[jobID]
+		if !ok {
+			writeJSON(w, http.StatusNotFound, &keboola.StorageError{ErrCode: "storage.jobs.notFound"})
+			return
+		}
+
+		writeJSON(w, http.StatusOK, &keboola.StorageJob{
+			StorageJobKey: keboola.StorageJobKey{ID: jobID},
+			Status:        keboola.StorageJobStatusSuccess,
+			Results: keboola.StorageJobResult{
+				"primaryKey": table.PrimaryKey,
+				"columns":    table.Columns,
+			},
+		})
+	})
+
+	// Add table metadata, metadata are not stored
+	api.mux.HandleFunc(
+		"POST /v2/storage/branch/{branchID}/tables/{tableID}/metadata",
+		jsonHandler(http.StatusCreated, keboola.TableMetadataResponse{Metadata: keboola.TableMetadata{}}),
+	)
+}
+
+func (api *FakeStorageAPI) registerTokens() {
+	// Create token
+	api.mux.HandleFunc("POST /v2/storage/tokens", func(w http.ResponseWriter, request *http.Request) {
+		api.lock.Lock()
+		defer api.lock.Unlock()
+
+		token := keboola.Token{}
+		if err := decodeBody(request, &token); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		id := api.newID()
+		token.ID = strconv.Itoa(id)
+		token.Token = fmt.Sprintf("dev-token-%d", id)
+		token.Owner = keboola.TokenOwner{ID: FakeProjectID, Name: "Dev Project", Features: keboola.Features{}}
+		writeJSON(w, http.StatusCreated, token)
+	})
+
+	// Delete token
+	api.mux.HandleFunc("DELETE /v2/storage/tokens/{tokenID}", noContentHandler)
+}
+
+func (api *FakeStorageAPI) registerFiles() {
+	// Prepare staging file, the credentials are not used, slices are written by the localTarget
+	api.mux.HandleFunc("POST /v2/storage/branch/{branchID}/files/prepare", func(w http.ResponseWriter, request *http.Request) {
+		api.lock.Lock()
+		defer api.lock.Unlock()
+
+		branchID, err := branchIDFromPath(request)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, &keboola.FileUploadCredentials{
+			File: keboola.File{
+				FileKey:  keboola.FileKey{BranchID: branchID, FileID: keboola.FileID(api.newID())},
+				Provider: s3.Provider,
+				IsSliced: true,
+			},
+			S3UploadParams: &s3.UploadParams{
+				Path:        s3.Path{Key: "dev", Bucket: "dev"},
+				Credentials: s3.Credentials{Expiration: iso8601.Time{Time: time.Now().Add(fakeCredentialsExpiration)}},
+			},
+		})
+	})
+
+	// Delete staging file
+	api.mux.HandleFunc("DELETE /v2/storage/branch/{branchID}/files/{fileID}", noContentHandler)
+
+	// Events are not stored
+	event := &keboola.Event{ID: "1", Type: "info"}
+	api.mux.HandleFunc("POST /v2/storage/events", jsonHandler(http.StatusOK, event))
+	api.mux.HandleFunc("POST /v2/storage/branch/{branchID}/events", jsonHandler(http.StatusOK, event))
+}
+
+func (api *FakeStorageAPI) newID() int {
+	api.nextID++
+	return api.nextID
+}
+
+func (t *handlerTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	if request.Body != nil {
+		defer request.Body.Close()
+	}
+
+	w := &responseWriter{header: make(http.Header), status: http.StatusOK}
+	t.handler.ServeHTTP(w, request)
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", w.status, http.StatusText(w.status)),
+		StatusCode:    w.status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        w.header,
+		Body:          io.NopCloser(&w.body),
+		ContentLength: int64(w.body.Len()),
+		Request:       request,
+	}, nil
+}
+
+func (w *responseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *responseWriter) Write(p []byte) (int, error) {
+	return w.body.Write(p)
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	w.status = status
+}
+
+func jsonHandler(status int, body any) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, status, body)
+	}
+}
+
+func noContentHandler(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	bodyBytes, err := json.Encode(body, false)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(bodyBytes)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(json.MustEncode(&keboola.StorageError{Message: err.Error(), ErrCode: "storage.devMode.error"}, false))
+}
+
+func decodeBody(request *http.Request, target any) error {
+	dataBytes, err := io.ReadAll(request.Body)
+	if err != nil {
+		return err
+	}
+	return json.Decode(dataBytes, target)
+}
+
+func branchIDFromPath(request *http.Request) (keboola.BranchID, error) {
+	branchID, err := strconv.Atoi(request.PathValue("branchID"))
+	if err != nil {
+		return 0, errors.Errorf(`cannot parse branchID from url "%s"`, request.URL)
+	}
+
+	return keboola.BranchID(branchID), nil
+}
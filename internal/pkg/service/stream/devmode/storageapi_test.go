@@ -0,0 +1,70 @@
+package devmode_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/keboola/go-client/pkg/client"
+	"github.com/keboola/go-client/pkg/keboola"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/devmode"
+)
+
+func TestFakeStorageAPI(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	fake := devmode.NewFakeStorageAPI(devmode.FakeStorageAPIHost)
+	httpClient := client.New().WithTransport(fake.Transport())
+
+	// Any token is valid
+	api, err := keboola.NewAuthorizedAPI(ctx, "https://"+devmode.FakeStorageAPIHost, "my-token", keboola.WithClient(&httpClient))
+	require.NoError(t, err)
+	token, err := api.VerifyTokenRequest("my-token").Send(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, devmode.FakeProjectID, token.ProjectID())
+
+	// Default branch
+	branch, err := api.GetDefaultBranchRequest().Send(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, keboola.BranchID(devmode.FakeBranchID), branch.ID)
+
+	// Bucket doesn't exist, create it
+	bucketKey := keboola.BucketKey{BranchID: branch.ID, BucketID: keboola.MustParseBucketID("in.c-my-bucket")}
+	_, err = api.GetBucketRequest(bucketKey).Send(ctx)
+	var storageErr *keboola.StorageError
+	if assert.ErrorAs(t, err, &storageErr) {
+		assert.Equal(t, http.StatusNotFound, storageErr.StatusCode())
+	}
+	_, err = api.CreateBucketRequest(&keboola.Bucket{BucketKey: bucketKey}).Send(ctx)
+	require.NoError(t, err)
+	bucket, err := api.GetBucketRequest(bucketKey).Send(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, bucketKey, bucket.BucketKey)
+
+	// Create table
+	tableKey := keboola.TableKey{BranchID: branch.ID, TableID: keboola.MustParseTableID("in.c-my-bucket.my-table")}
+	tableDef := keboola.TableDefinition{Columns: keboola.Columns{{Name: "id"}, {Name: "body"}}, PrimaryKeyNames: []string{"id"}}
+	_, err = api.CreateTableDefinitionRequest(tableKey, tableDef).Send(ctx)
+	require.NoError(t, err)
+	table, err := api.GetTableRequest(tableKey).Send(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"id", "body"}, table.Columns)
+	assert.Equal(t, []string{"id"}, table.PrimaryKey)
+
+	// Create token
+	sinkToken, err := api.CreateTokenRequest(keboola.WithDescription("my-sink")).Send(ctx)
+	require.NoError(t, err)
+	assert.NotEmpty(t, sinkToken.Token)
+	require.NoError(t, api.DeleteTokenRequest(sinkToken.ID).SendOrErr(ctx))
+
+	// Create staging file
+	file, err := api.CreateFileResourceRequest(branch.ID, "my-file", keboola.WithIsSliced(true)).Send(ctx)
+	require.NoError(t, err)
+	assert.True(t, file.CredentialsExpiration().After(time.Now().Add(time.Hour)))
+	require.NoError(t, api.DeleteFileRequest(file.FileKey).SendOrErr(ctx))
+}
@@ -0,0 +1,141 @@
+package devmode
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/plugin"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/type/tablesink/keboola/bridge"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/diskreader"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/compression"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/statistics"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+const (
+	targetDirPerm  = 0o750
+	targetFilePerm = 0o640
+	targetFileExt  = ".csv"
+)
+
+// localTarget replaces upload and import of the Keboola table sink, data are written to a local directory.
+//   - Slices are uploaded to "{dir}/staging/{fileKey}/{slicePath}", without compression.
+//   - On import, slices of the file are appended to the "{dir}/tables/{sinkKey}.csv" and the staging directory is removed.
+type localTarget struct {
+	logger log.Logger
+	dir    string
+}
+
+// RegisterLocalTarget overrides slice uploader and file importer registered by the Keboola bridge.
+// It must be called after the service scope is created.
+func RegisterLocalTarget(logger log.Logger, plugins *plugin.Plugins, dir string) {
+	t := &localTarget{logger: logger.WithComponent("target.local"), dir: dir}
+	plugins.RegisterSliceUploader(bridge.StagingFileProvider, t.uploadSlice)
+	plugins.RegisterFileImporter(bridge.TargetProvider, t.importFile)
+}
+
+func (t *localTarget) uploadSlice(ctx context.Context, volume *diskreader.Volume, slice plugin.Slice, _ statistics.Value) (err error) {
+	if slice.LocalStorage.IsEmpty {
+		t.logger.Info(ctx, "empty slice, skipped upload")
+		return nil
+	}
+
+	reader, err := volume.OpenReader(slice.SliceKey, slice.LocalStorage, slice.EncodingCompression, compression.NewNoneConfig())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := reader.Close(ctx); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}()
+
+	dir := t.stagingDir(slice.FileKey.String())
+	if err := os.MkdirAll(dir, targetDirPerm); err != nil {
+		return err
+	}
+
+	// Data are decompressed, so the compression extension is removed
+	path := filepath.Join(dir, strings.TrimSuffix(filepath.Base(slice.StagingStorage.Path), ".gz"))
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, targetFilePerm)
+	if err != nil {
+		return err
+	}
+
+	if _, err := reader.WriteTo(file); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	t.logger.Infof(ctx, `uploaded slice to %q`, path)
+	return nil
+}
+
+func (t *localTarget) importFile(ctx context.Context, file plugin.File, _ statistics.Value) error {
+	if file.IsEmpty {
+		t.logger.Info(ctx, "empty file, skipped import")
+		return nil
+	}
+
+	stagingDir := t.stagingDir(file.FileKey.String())
+	entries, err := os.ReadDir(stagingDir)
+	if err != nil {
+		return errors.PrefixErrorf(err, `cannot read staging directory "%s"`, stagingDir)
+	}
+
+	// Slice paths start with the slice opening time, so they are sorted chronologically
+	slices := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			slices = append(slices, filepath.Join(stagingDir, entry.Name()))
+		}
+	}
+	sort.Strings(slices)
+
+	tablePath := filepath.Join(t.dir, "tables", filepath.FromSlash(file.SinkKey.String())+targetFileExt)
+	if err := os.MkdirAll(filepath.Dir(tablePath), targetDirPerm); err != nil {
+		return err
+	}
+
+	table, err := os.OpenFile(tablePath, os.O_WRONLY|os.O_CREATE|os.O_APPEND, targetFilePerm)
+	if err != nil {
+		return err
+	}
+
+	for _, path := range slices {
+		if err := appendFile(table, path); err != nil {
+			_ = table.Close()
+			return err
+		}
+	}
+
+	if err := table.Close(); err != nil {
+		return err
+	}
+
+	t.logger.Infof(ctx, `imported %d slices to %q`, len(slices), tablePath)
+	return os.RemoveAll(stagingDir)
+}
+
+func (t *localTarget) stagingDir(fileKey string) string {
+	return filepath.Join(t.dir, "staging", filepath.FromSlash(fileKey))
+}
+
+func appendFile(w io.Writer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(w, f)
+	return err
+}
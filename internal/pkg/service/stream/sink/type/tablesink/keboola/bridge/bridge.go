@@ -19,10 +19,10 @@ import (
 )
 
 const (
-	// stagingFileProvider marks staging files provided by the Keboola Storage API.
-	stagingFileProvider = stagingModel.FileProvider("keboola")
-	// targetProvider marks files which destination is a Keboola table.
-	targetProvider = targetModel.Provider("keboola")
+	// StagingFileProvider marks staging files provided by the Keboola Storage API.
+	StagingFileProvider = stagingModel.FileProvider("keboola")
+	// TargetProvider marks files which destination is a Keboola table.
+	TargetProvider = targetModel.Provider("keboola")
 
 	// sinkMetaKey is a key of the table metadata that marks each table created by the stream.sink.
 	sinkMetaKey = "KBC.stream.sink.id"
@@ -73,8 +73,8 @@ func New(d dependencies, apiProvider apiProvider, config keboolasink.Config) *Br
 	b.setupOnFileOpen()
 	b.deleteCredentialsOnFileDelete()
 	b.deleteTokenOnSinkDeactivation()
-	b.plugins.RegisterFileImporter(targetProvider, b.importFile)
-	b.plugins.RegisterSliceUploader(stagingFileProvider, b.uploadSlice)
+	b.plugins.RegisterFileImporter(TargetProvider, b.importFile)
+	b.plugins.RegisterSliceUploader(StagingFileProvider, b.uploadSlice)
 
 	return b
 }
@@ -84,5 +84,5 @@ func (b *Bridge) isKeboolaTableSink(sink *definition.Sink) bool {
 }
 
 func (b *Bridge) isKeboolaStagingFile(file *model.File) bool {
-	return file.StagingStorage.Provider == stagingFileProvider
+	return file.StagingStorage.Provider == StagingFileProvider
 }
@@ -61,8 +61,8 @@ func (b *Bridge) setupOnFileOpen() {
 
 			// Update file entity
 			file.Mapping = sink.Table.Mapping
-			file.StagingStorage.Provider = stagingFileProvider // staging file is provided by the Keboola
-			file.TargetStorage.Provider = targetProvider       // destination is a Keboola table
+			file.StagingStorage.Provider = StagingFileProvider // staging file is provided by the Keboola
+			file.TargetStorage.Provider = TargetProvider       // destination is a Keboola table
 			file.StagingStorage.Expiration = utctime.From(keboolaFile.UploadCredentials.CredentialsExpiration())
 		}
 
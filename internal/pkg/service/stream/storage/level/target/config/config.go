@@ -11,7 +11,7 @@ import (
 // Config configures the target storage.
 type Config struct {
 	Operator OperatorConfig `configKey:"operator"`
-	Import   ImportConfig   `configKey:"import"`
+	Import   ImportConfig   `configKey:"import" configReload:"true"`
 }
 
 // ConfigPatch is same as the Config, but with optional/nullable fields.
@@ -4,8 +4,6 @@ import (
 	"context"
 	"time"
 
-	"github.com/keboola/go-utils/pkg/deepcopy"
-
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/configpatch"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop/op"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/utctime"
@@ -38,7 +36,7 @@ func (r *Repository) openFileForSink(k key.SinkKey, now time.Time, source *defin
 	// Open a new file
 	atomicOp.Write(func(ctx context.Context) op.Op {
 		// Apply configuration overrides from the source and the sink
-		cfg := deepcopy.Copy(r.config).(level.Config)
+		cfg := r.levelConfig()
 		patch := level.ConfigPatch{}
 		for _, kvs := range []configpatch.PatchKVs{source.Config, sink.Config} {
 			err := configpatch.ApplyKVs(&cfg, &patch, kvs.In("storage.level"), configpatch.WithModifyProtected())
@@ -2,6 +2,7 @@ package file
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/keboola/go-utils/pkg/deepcopy"
@@ -26,6 +27,7 @@ type Repository struct {
 	logger     log.Logger
 	client     *etcd.Client
 	schema     schema.File
+	configLock *sync.RWMutex
 	config     level.Config
 	backoff    model.RetryBackoff
 	volumes    *volumeRepo.Repository
@@ -46,6 +48,7 @@ func NewRepository(cfg level.Config, d dependencies, backoff model.RetryBackoff,
 		logger:     d.Logger().WithComponent("file.repository"),
 		client:     d.EtcdClient(),
 		schema:     schema.New(d.EtcdSerde()),
+		configLock: &sync.RWMutex{},
 		config:     cfg,
 		backoff:    backoff,
 		volumes:    volumes,
@@ -60,6 +63,20 @@ func NewRepository(cfg level.Config, d dependencies, backoff model.RetryBackoff,
 	return r
 }
 
+// UpdateConfig replaces the default storage level configuration, it is applied to newly opened files.
+func (r *Repository) UpdateConfig(cfg level.Config) {
+	r.configLock.Lock()
+	defer r.configLock.Unlock()
+	r.config = cfg
+}
+
+// levelConfig returns a copy of the default storage level configuration.
+func (r *Repository) levelConfig() level.Config {
+	r.configLock.RLock()
+	defer r.configLock.RUnlock()
+	return deepcopy.Copy(r.config).(level.Config)
+}
+
 func (r *Repository) save(ctx context.Context, now time.Time, old, updated *model.File) *op.TxnOp[model.File] {
 	// Call plugins
 	if err := r.plugins.Executor().OnFileSave(ctx, now, old, updated); err != nil {
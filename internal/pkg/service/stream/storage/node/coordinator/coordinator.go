@@ -6,9 +6,17 @@ package coordinator
 import (
 	"context"
 
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdmigration"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/task"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/config"
+	branchSchema "github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/repository/branch/schema"
+	sinkSchema "github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/repository/sink/schema"
+	sourceSchema "github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/repository/source/schema"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/dependencies"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/metacleanup"
+	fileSchema "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/model/repository/file/schema"
+	sliceSchema "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/model/repository/slice/schema"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/node/coordinator/fileimport"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/node/coordinator/filerotation"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/node/coordinator/slicerotation"
@@ -34,5 +42,22 @@ func Start(ctx context.Context, d dependencies.CoordinatorScope, cfg config.Conf
 		return err
 	}
 
+	if err := etcdmigration.Start(d, migratablePrefixes(d)...); err != nil {
+		return err
+	}
+
 	return nil
 }
+
+// migratablePrefixes returns prefixes of the Stream service values, they are migrated to the latest schema version on startup.
+func migratablePrefixes(d dependencies.CoordinatorScope) []etcdop.MigratablePrefix {
+	s := d.EtcdSerde()
+	prefixes := []etcdop.MigratablePrefix{
+		branchSchema.New(s).PrefixT,
+		sourceSchema.New(s).PrefixT,
+		sinkSchema.New(s).PrefixT,
+		fileSchema.New(s).PrefixT,
+		sliceSchema.New(s).PrefixT,
+	}
+	return append(prefixes, task.MigratablePrefixes(s)...)
+}
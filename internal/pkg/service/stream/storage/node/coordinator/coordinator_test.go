@@ -3,10 +3,14 @@ package coordinator_test
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop/serde"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/dependencies"
 	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
 )
@@ -31,6 +35,7 @@ func TestStart_Ok(t *testing.T) {
 {"level":"info","message":"joining distribution group","distribution.group":"operator.slice.rotation","distribution.node":"test-node","component":"distribution"}
 {"level":"info","message":"joining distribution group","distribution.group":"operator.file.import","distribution.node":"test-node","component":"distribution"}
 {"level":"info","message":"joining distribution group","distribution.group":"storage.metadata.cleanup","distribution.node":"test-node","component":"distribution"}
+{"level":"info","message":"joining distribution group","distribution.group":"etcd.migration","distribution.node":"test-node","component":"distribution"}
 {"level":"info","message":"exiting (bye bye)"}
 {"level":"info","message":"received shutdown request","component":"distribution.mutex.provider"}
 {"level":"info","message":"closing etcd session: context canceled","component":"distribution.mutex.provider.etcd.session"}
@@ -41,3 +46,37 @@ func TestStart_Ok(t *testing.T) {
 {"level":"info","message":"exited"}
 `)
 }
+
+func TestStart_Migration(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	d, mock := dependencies.NewMockedCoordinatorScope(t, ctx)
+	client := d.EtcdClient()
+
+	// Branch value in the version 1
+	_, err := client.Put(ctx, "definition/branch/all/123/456", `{"projectId":123,"branchId":456,"created":{"at":"2000-01-01T00:00:00.000Z","by":{"type":"user","tokenId":"111","tokenDesc":"foo"}}}`)
+	require.NoError(t, err)
+
+	// The version 2 is registered
+	serde.RegisterMigrations[definition.Branch](d.EtcdSerde(), serde.Migration{
+		Version: 2,
+		Migrate: func(ctx context.Context, value map[string]any) error {
+			return nil
+		},
+	})
+
+	// The coordinator migrates the value on startup
+	require.NoError(t, stream.StartComponents(ctx, d, mock.TestConfig(), stream.ComponentStorageCoordinator))
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		resp, err := client.Get(ctx, "definition/branch/all/123/456")
+		require.NoError(c, err)
+		require.Len(c, resp.Kvs, 1)
+		assert.Contains(c, string(resp.Kvs[0].Value), `{"$schemaVersion":2,`)
+	}, 5*time.Second, 10*time.Millisecond)
+	mock.DebugLogger().AssertJSONMessages(t, `{"level":"info","message":"migrated 1 values under the prefix \"definition/branch/\"","component":"etcd.migration"}`)
+
+	// Shutdown
+	d.Process().Shutdown(ctx, errors.New("bye bye"))
+	d.Process().WaitForShutdown()
+}
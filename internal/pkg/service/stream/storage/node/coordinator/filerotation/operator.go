@@ -53,6 +53,11 @@ type operator struct {
 	openedSlicesNotifier chan struct{}
 	openedSlicesCount    map[model.FileKey]int
 
+	// operations tracks running file checks per source key - the distribution key.
+	// The key is released to another node after the checks have been finished.
+	operationsLock sync.Mutex
+	operations     map[string]*sync.WaitGroup
+
 	// OTEL metrics
 	metrics *node.Metrics
 }
@@ -102,6 +107,7 @@ func Start(d dependencies, config targetConfig.OperatorConfig) error {
 		locks:           d.DistributedLockProvider(),
 		telemetry:       d.Telemetry(),
 		metrics:         node.NewMetrics(d.Telemetry().Meter()),
+		operations:      make(map[string]*sync.WaitGroup),
 	}
 
 	// Join the distribution group
@@ -110,6 +116,11 @@ func Start(d dependencies, config targetConfig.OperatorConfig) error {
 		if err != nil {
 			return err
 		}
+
+		// Wait for running checks, before the source is handed over to another node
+		o.distribution.OnRelease(func(ctx context.Context, sourceKey string) {
+			o.waitForOperations(ctx, sourceKey)
+		})
 	}
 
 	// Graceful shutdown
@@ -315,6 +326,19 @@ func (o *operator) checkFile(ctx context.Context, file *fileData) {
 		return
 	}
 
+	// The operation must be registered before the key is acquired, so the release waits for it
+	sourceKey := file.FileKey.SourceKey.String()
+	done := o.startOperation(sourceKey)
+	defer done()
+
+	// Skip the file, if the source has not been released by the previous owner yet, or it is being released
+	if acquired, err := o.distribution.TryAcquire(ctx, sourceKey); err != nil {
+		o.logger.Errorf(ctx, `cannot acquire the source "%s": %s`, sourceKey, err)
+		return
+	} else if !acquired {
+		return
+	}
+
 	switch file.State {
 	case model.FileWriting:
 		o.rotateFile(ctx, file)
@@ -325,6 +349,46 @@ func (o *operator) checkFile(ctx context.Context, file *fileData) {
 	}
 }
 
+// startOperation registers a running check of a file from the source.
+// The returned function must be called when the check is finished.
+func (o *operator) startOperation(sourceKey string) (done func()) {
+	o.operationsLock.Lock()
+	defer o.operationsLock.Unlock()
+
+	wg, found := o.operations[sourceKey]
+	if !found {
+		wg = &sync.WaitGroup{}
+		o.operations[sourceKey] = wg
+	}
+
+	wg.Add(1)
+	return wg.Done
+}
+
+// waitForOperations waits for running checks of files from the source, at most until the context is done.
+func (o *operator) waitForOperations(ctx context.Context, sourceKey string) {
+	o.operationsLock.Lock()
+	wg, found := o.operations[sourceKey]
+	delete(o.operations, sourceKey)
+	o.operationsLock.Unlock()
+
+	if !found {
+		return
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		wg.Wait()
+	}()
+
+	select {
+	case <-ctx.Done():
+		o.logger.Warnf(ctx, `file operations of the source "%s" have not been finished before the release: %s`, sourceKey, ctx.Err())
+	case <-done:
+	}
+}
+
 func (o *operator) rotateFile(ctx context.Context, file *fileData) {
 	startTime := o.clock.Now()
 
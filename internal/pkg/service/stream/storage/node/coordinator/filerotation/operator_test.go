@@ -14,6 +14,7 @@ import (
 
 	"github.com/keboola/keboola-as-code/internal/pkg/log"
 	commonDeps "github.com/keboola/keboola-as-code/internal/pkg/service/common/dependencies"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/distribution"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/duration"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/utctime"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/config"
@@ -182,6 +183,40 @@ func TestFileRotation(t *testing.T) {
 	ts.logger.AssertNoErrorMessage(t)
 }
 
+func TestFileRotation_AcquireSource(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	ts := setup(t, ctx)
+	defer ts.teardown(t)
+	ts.prepareFixtures(t, ctx)
+
+	// Trigger check - the source is acquired by the node
+	ts.triggerCheck(t, false, `
+{"level":"debug","message":"skipping file rotation: no record","component":"storage.node.operator.file.rotation"}
+`)
+	ownerKey := distribution.GroupsEtcdPrefix.Add("operator.file.rotation").Add("owners").Key(ts.sink.SourceKey.String())
+	exists, err := ownerKey.Exists(ts.client).Do(ctx).ResultOrErr()
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	// Shutdown - the source is released, the release waits for running checks
+	ts.dependencies.Process().Shutdown(ctx, errors.New("bye bye"))
+	ts.dependencies.Process().WaitForShutdown()
+	ts.logger.AssertJSONMessages(t, `
+{"level":"info","message":"releasing the key \"%s\"","distribution.key":"%s"}
+{"level":"info","message":"released the key \"%s\"","distribution.key":"%s"}
+`)
+	exists, err = ownerKey.Exists(ts.client).Do(ctx).ResultOrErr()
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	// No error is logged
+	ts.logger.AssertNoErrorMessage(t)
+}
+
 type testState struct {
 	interval      time.Duration
 	importTrigger targetConfig.ImportTrigger
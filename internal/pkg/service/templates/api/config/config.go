@@ -2,11 +2,14 @@ package config
 
 import (
 	"net/url"
+	"time"
 
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/audit"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdclient"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/task"
 	"github.com/keboola/keboola-as-code/internal/pkg/telemetry/datadog"
 	"github.com/keboola/keboola-as-code/internal/pkg/telemetry/metric/prometheus"
+	"github.com/keboola/keboola-as-code/internal/pkg/telemetry/otlp"
 	"github.com/keboola/keboola-as-code/internal/pkg/telemetry/pprof"
 	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
 	"github.com/keboola/keboola-as-code/internal/pkg/utils/strhelper"
@@ -15,22 +18,26 @@ import (
 // Config of the Templates API.
 // See "configmap" package for more information.
 type Config struct {
-	DebugLog        bool              `configKey:"debugLog" configUsage:"Enable debug log level."`
+	DebugLog        bool              `configKey:"debugLog" configUsage:"Enable debug log level." configReload:"true"`
 	DebugHTTPClient bool              `configKey:"debugHTTPClient" configUsage:"Log HTTP client requests and responses as debug messages."`
 	NodeID          string            `configKey:"nodeID" configUsage:"Unique ID of the node in the cluster." validate:"required"`
 	PProf           pprof.Config      `configKey:"pprof"`
 	Datadog         datadog.Config    `configKey:"datadog"`
 	Etcd            etcdclient.Config `configKey:"etcd"`
 	Metrics         prometheus.Config `configKey:"metrics"`
+	OTLP            otlp.Config       `configKey:"otlp"`
 	API             API               `configKey:"api"`
 	StorageAPIHost  string            `configKey:"storage-api-host" configUsage:"Host of the Storage API."`
 	Repositories    Repositories      `configKey:"repositories" configUsage:"Default repositories, <name1>|<repo1>|<branch1>;..."`
 }
 
 type API struct {
-	Listen    string          `configKey:"listen" configUsage:"Listen address of the configuration HTTP API." validate:"required,hostname_port"`
-	PublicURL *url.URL        `configKey:"publicUrl" configUsage:"Public URL of the configuration HTTP API for link generation." validate:"required"`
-	Task      task.NodeConfig `configKey:"task" configUsage:"Background tasks configuration." validate:"required"`
+	Listen                  string          `configKey:"listen" configUsage:"Listen address of the configuration HTTP API." validate:"required,hostname_port"`
+	PublicURL               *url.URL        `configKey:"publicUrl" configUsage:"Public URL of the configuration HTTP API for link generation." validate:"required"`
+	Task                    task.NodeConfig `configKey:"task" configUsage:"Background tasks configuration." validate:"required"`
+	TaskTimeout             time.Duration   `configKey:"taskTimeout" configUsage:"Timeout of template operations running as background tasks." configReload:"true" validate:"required"`
+	RepositoryWebhookSecret string          `configKey:"repositoryWebhookSecret" configUsage:"Secret to verify signature of the repository webhook requests. Webhook is disabled if empty." sensitive:"true"`
+	Audit                   audit.Config    `configKey:"audit" configUsage:"Audit log of mutating API calls."`
 }
 
 func New() Config {
@@ -42,10 +49,13 @@ func New() Config {
 		Datadog:         datadog.NewConfig(),
 		Etcd:            etcdclient.NewConfig(),
 		Metrics:         prometheus.NewConfig(),
+		OTLP:            otlp.NewConfig(),
 		API: API{
-			Listen:    "0.0.0.0:8000",
-			PublicURL: &url.URL{Scheme: "http", Host: "localhost:8000"},
-			Task:      task.NewNodeConfig(),
+			Listen:      "0.0.0.0:8000",
+			PublicURL:   &url.URL{Scheme: "http", Host: "localhost:8000"},
+			Task:        task.NewNodeConfig(),
+			TaskTimeout: 5 * time.Minute,
+			Audit:       audit.NewConfig(),
 		},
 		StorageAPIHost: "",
 		Repositories:   DefaultRepositories(),
@@ -6,9 +6,13 @@ import (
 	"strings"
 
 	"github.com/keboola/keboola-as-code/internal/pkg/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/template/repository/archive"
 	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
 )
 
+// archivePrefix distinguishes an archive from a git repository, both are defined by an HTTPS URL.
+const archivePrefix = "archive+"
+
 type Repositories []model.TemplateRepository
 
 func DefaultRepositories() Repositories {
@@ -52,6 +56,21 @@ func (r Repositories) MarshalText() ([]byte, error) {
 			out.WriteString("|")
 			out.WriteString("file://")
 			out.WriteString(repo.URL)
+		case model.RepositoryTypeArchive:
+			out.WriteString(repo.Name)
+			out.WriteString("|")
+			if !strings.HasPrefix(repo.URL, archive.OCIScheme) {
+				out.WriteString(archivePrefix)
+			}
+			out.WriteString(repo.URL)
+			if repo.Checksum != "" || repo.PublicKey != "" {
+				out.WriteString("|")
+				out.WriteString(repo.Checksum)
+			}
+			if repo.PublicKey != "" {
+				out.WriteString("|")
+				out.WriteString(repo.PublicKey)
+			}
 		default:
 			panic(errors.Errorf(`unexpected repo.Type value "%v"`, repo.Type))
 		}
@@ -95,6 +114,28 @@ func (r *Repositories) UnmarshalText(inBytes []byte) error {
 				Name: name,
 				URL:  strings.TrimPrefix(path, "file://"),
 			})
+		case strings.HasPrefix(path, archivePrefix+"https://") || strings.HasPrefix(path, archive.OCIScheme):
+			if len(parts) > 4 {
+				return errors.Errorf(`invalid repository definition "%s": required format <name>|archive+https://<archive>|<checksum>|<publicKey> or <name>|oci://<registry>/<repository>:<tag>|<checksum>|<publicKey>`, definition)
+			}
+			repo := model.TemplateRepository{
+				Type: model.RepositoryTypeArchive,
+				Name: name,
+				URL:  strings.TrimPrefix(path, archivePrefix),
+			}
+			if len(parts) > 2 {
+				repo.Checksum = parts[2]
+			}
+			if len(parts) > 3 {
+				repo.PublicKey = parts[3]
+			}
+			if repo.Checksum == "" && repo.PublicKey == "" {
+				return errors.Errorf(`invalid repository definition "%s": archive must be verified by a checksum or a public key`, definition)
+			}
+			if repo.Checksum != "" && (!strings.HasPrefix(repo.Checksum, "sha256:") || len(repo.Checksum) != 71) {
+				return errors.Errorf(`invalid repository checksum "%s": required format sha256:<hex>`, repo.Checksum)
+			}
+			out = append(out, repo)
 		case strings.HasPrefix(path, "https://"):
 			if len(parts) != 3 {
 				return errors.Errorf(`invalid repository definition "%s": required format <name>|https://<repository>|<branch>`, definition)
@@ -109,7 +150,7 @@ func (r *Repositories) UnmarshalText(inBytes []byte) error {
 				Ref:  parts[2],
 			})
 		default:
-			return errors.Errorf(`invalid repository path "%s": must start with "file://" or "https://"`, path)
+			return errors.Errorf(`invalid repository path "%s": must start with "file://", "https://", "archive+https://" or "oci://"`, path)
 		}
 	}
 
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -27,9 +28,20 @@ func TestRepositories_MarshalText(t *testing.T) {
 			Name: "dir",
 			URL:  "bar",
 		},
+		{
+			Type: model.RepositoryTypeArchive,
+			Name: "archive",
+			URL:  "https://bar.com/templates.tar.gz",
+		},
+		{
+			Type:      model.RepositoryTypeArchive,
+			Name:      "oci",
+			URL:       "oci://registry.com/templates:v1",
+			PublicKey: "key",
+		},
 	}).MarshalText()
 	assert.NoError(t, err)
-	assert.Equal(t, "git|https://bar.com|baz;dir|file://bar", string(result))
+	assert.Equal(t, "git|https://bar.com|baz;dir|file://bar;archive|archive+https://bar.com/templates.tar.gz;oci|oci://registry.com/templates:v1||key", string(result))
 }
 
 func TestRepositories_UnmarshalText(t *testing.T) {
@@ -77,13 +89,39 @@ func TestRepositories_UnmarshalText(t *testing.T) {
 		},
 	}, result)
 
+	checksum := "sha256:" + strings.Repeat("a", 64)
+	err = result.UnmarshalText([]byte("foo1|archive+https://bar.com/templates.tar.gz|" + checksum + ";foo2|oci://registry.com/templates:v1||key"))
+	assert.NoError(t, err)
+	assert.Equal(t, Repositories{
+		{
+			Type:     model.RepositoryTypeArchive,
+			Name:     "foo1",
+			URL:      "https://bar.com/templates.tar.gz",
+			Checksum: checksum,
+		},
+		{
+			Type:      model.RepositoryTypeArchive,
+			Name:      "foo2",
+			URL:       "oci://registry.com/templates:v1",
+			PublicKey: "key",
+		},
+	}, result)
+
+	err = result.UnmarshalText([]byte("foo|archive+https://bar.com/templates.tar.gz"))
+	assert.Error(t, err)
+	assert.Equal(t, `invalid repository definition "foo|archive+https://bar.com/templates.tar.gz": archive must be verified by a checksum or a public key`, err.Error())
+
+	err = result.UnmarshalText([]byte("foo|oci://registry.com/templates:v1|md5:abc"))
+	assert.Error(t, err)
+	assert.Equal(t, `invalid repository checksum "md5:abc": required format sha256:<hex>`, err.Error())
+
 	err = result.UnmarshalText([]byte("foo"))
 	assert.Error(t, err)
 	assert.Equal(t, `invalid repository definition "foo": required format <name>|https://<repository>|<branch> or <name>|file://<repository>`, err.Error())
 
 	err = result.UnmarshalText([]byte("foo|ftp://bar.com"))
 	assert.Error(t, err)
-	assert.Equal(t, `invalid repository path "ftp://bar.com": must start with "file://" or "https://"`, err.Error())
+	assert.Equal(t, `invalid repository path "ftp://bar.com": must start with "file://", "https://", "archive+https://" or "oci://"`, err.Error())
 
 	err = result.UnmarshalText([]byte("foo|file://bar|abc"))
 	assert.Error(t, err)
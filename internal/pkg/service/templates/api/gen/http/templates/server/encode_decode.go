@@ -13,6 +13,7 @@ import (
 	"errors"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"unicode/utf8"
 
@@ -156,6 +157,138 @@ func EncodeRepositoryIndexError(encoder func(context.Context, http.ResponseWrite
 	}
 }
 
+// EncodeRefreshRepositoryResponse returns an encoder for responses returned by
+// the templates RefreshRepository endpoint.
+func EncodeRefreshRepositoryResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*templates.Task)
+		enc := encoder(ctx, w)
+		body := NewRefreshRepositoryResponseBody(res)
+		w.WriteHeader(http.StatusAccepted)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeRefreshRepositoryRequest returns a decoder for requests sent to the
+// templates RefreshRepository endpoint.
+func DecodeRefreshRepositoryRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+	return func(r *http.Request) (any, error) {
+		var (
+			repository      string
+			storageAPIToken string
+			err             error
+
+			params = mux.Vars(r)
+		)
+		repository = params["repository"]
+		storageAPIToken = r.Header.Get("X-StorageApi-Token")
+		if storageAPIToken == "" {
+			err = goa.MergeErrors(err, goa.MissingFieldError("X-StorageApi-Token", "header"))
+		}
+		if err != nil {
+			return nil, err
+		}
+		payload := NewRefreshRepositoryPayload(repository, storageAPIToken)
+		if strings.Contains(payload.StorageAPIToken, " ") {
+			// Remove authorization scheme prefix (e.g. "Bearer")
+			cred := strings.SplitN(payload.StorageAPIToken, " ", 2)[1]
+			payload.StorageAPIToken = cred
+		}
+
+		return payload, nil
+	}
+}
+
+// EncodeRefreshRepositoryError returns an encoder for errors returned by the
+// RefreshRepository templates endpoint.
+func EncodeRefreshRepositoryError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder, formatter func(ctx context.Context, err error) goahttp.Statuser) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ErrorEncoder(encoder, formatter)
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		var en goa.GoaErrorNamer
+		if !errors.As(v, &en) {
+			return encodeError(ctx, w, v)
+		}
+		switch en.GoaErrorName() {
+		case "templates.repositoryNotFound":
+			var res *templates.GenericError
+			errors.As(v, &res)
+			res.StatusCode = http.StatusNotFound
+			enc := encoder(ctx, w)
+			var body any
+			if false { // formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewRefreshRepositoryTemplatesRepositoryNotFoundResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+
+// EncodeRepositoryWebhookResponse returns an encoder for responses returned by
+// the templates RepositoryWebhook endpoint.
+func EncodeRepositoryWebhookResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		w.WriteHeader(http.StatusAccepted)
+		return nil
+	}
+}
+
+// DecodeRepositoryWebhookRequest returns a decoder for requests sent to the
+// templates RepositoryWebhook endpoint.
+func DecodeRepositoryWebhookRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+	return func(r *http.Request) (any, error) {
+		var (
+			repository string
+			signature  *string
+
+			params = mux.Vars(r)
+		)
+		repository = params["repository"]
+		signatureRaw := r.Header.Get("X-Hub-Signature-256")
+		if signatureRaw != "" {
+			signature = &signatureRaw
+		}
+		payload := NewRepositoryWebhookRequest(repository, signature)
+
+		return payload, nil
+	}
+}
+
+// EncodeRepositoryWebhookError returns an encoder for errors returned by the
+// RepositoryWebhook templates endpoint.
+func EncodeRepositoryWebhookError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder, formatter func(ctx context.Context, err error) goahttp.Statuser) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ErrorEncoder(encoder, formatter)
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		var en goa.GoaErrorNamer
+		if !errors.As(v, &en) {
+			return encodeError(ctx, w, v)
+		}
+		switch en.GoaErrorName() {
+		case "templates.repositoryNotFound":
+			var res *templates.GenericError
+			errors.As(v, &res)
+			res.StatusCode = http.StatusNotFound
+			enc := encoder(ctx, w)
+			var body any
+			if false { // formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewRepositoryWebhookTemplatesRepositoryNotFoundResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+
 // EncodeTemplatesIndexResponse returns an encoder for responses returned by
 // the templates TemplatesIndex endpoint.
 func EncodeTemplatesIndexResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
@@ -452,6 +585,7 @@ func DecodeInputsIndexRequest(mux goahttp.Muxer, decoder func(*http.Request) goa
 			repository      string
 			template        string
 			version         string
+			format          string
 			storageAPIToken string
 			err             error
 
@@ -466,6 +600,15 @@ func DecodeInputsIndexRequest(mux goahttp.Muxer, decoder func(*http.Request) goa
 			err = goa.MergeErrors(err, goa.InvalidLengthError("template", template, utf8.RuneCountInString(template), 40, false))
 		}
 		version = params["version"]
+		formatRaw := r.URL.Query().Get("format")
+		if formatRaw != "" {
+			format = formatRaw
+		} else {
+			format = "default"
+		}
+		if !(format == "default" || format == "jsonSchema") {
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError("format", format, []any{"default", "jsonSchema"}))
+		}
 		storageAPIToken = r.Header.Get("X-StorageApi-Token")
 		if storageAPIToken == "" {
 			err = goa.MergeErrors(err, goa.MissingFieldError("X-StorageApi-Token", "header"))
@@ -473,7 +616,7 @@ func DecodeInputsIndexRequest(mux goahttp.Muxer, decoder func(*http.Request) goa
 		if err != nil {
 			return nil, err
 		}
-		payload := NewInputsIndexPayload(repository, template, version, storageAPIToken)
+		payload := NewInputsIndexPayload(repository, template, version, format, storageAPIToken)
 		if strings.Contains(payload.StorageAPIToken, " ") {
 			// Remove authorization scheme prefix (e.g. "Bearer")
 			cred := strings.SplitN(payload.StorageAPIToken, " ", 2)[1]
@@ -829,6 +972,83 @@ func EncodeUseTemplateVersionError(encoder func(context.Context, http.ResponseWr
 	}
 }
 
+// EncodeInputLookupResponse returns an encoder for responses returned by the
+// templates InputLookup endpoint.
+func EncodeInputLookupResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*templates.InputLookupResult)
+		enc := encoder(ctx, w)
+		body := NewInputLookupResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeInputLookupRequest returns a decoder for requests sent to the
+// templates InputLookup endpoint.
+func DecodeInputLookupRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+	return func(r *http.Request) (any, error) {
+		var (
+			branch          string
+			kind            string
+			storageAPIToken string
+			err             error
+
+			params = mux.Vars(r)
+		)
+		branch = params["branch"]
+		kind = params["kind"]
+		if !(kind == "table" || kind == "bucket" || kind == "connection") {
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError("kind", kind, []any{"table", "bucket", "connection"}))
+		}
+		storageAPIToken = r.Header.Get("X-StorageApi-Token")
+		if storageAPIToken == "" {
+			err = goa.MergeErrors(err, goa.MissingFieldError("X-StorageApi-Token", "header"))
+		}
+		if err != nil {
+			return nil, err
+		}
+		payload := NewInputLookupPayload(branch, kind, storageAPIToken)
+		if strings.Contains(payload.StorageAPIToken, " ") {
+			// Remove authorization scheme prefix (e.g. "Bearer")
+			cred := strings.SplitN(payload.StorageAPIToken, " ", 2)[1]
+			payload.StorageAPIToken = cred
+		}
+
+		return payload, nil
+	}
+}
+
+// EncodeInputLookupError returns an encoder for errors returned by the
+// InputLookup templates endpoint.
+func EncodeInputLookupError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder, formatter func(ctx context.Context, err error) goahttp.Statuser) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ErrorEncoder(encoder, formatter)
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		var en goa.GoaErrorNamer
+		if !errors.As(v, &en) {
+			return encodeError(ctx, w, v)
+		}
+		switch en.GoaErrorName() {
+		case "templates.branchNotFound":
+			var res *templates.GenericError
+			errors.As(v, &res)
+			res.StatusCode = http.StatusNotFound
+			enc := encoder(ctx, w)
+			var body any
+			if false { // formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewInputLookupTemplatesBranchNotFoundResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+
 // EncodeInstancesIndexResponse returns an encoder for responses returned by
 // the templates InstancesIndex endpoint.
 func EncodeInstancesIndexResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
@@ -1144,6 +1364,7 @@ func DecodeDeleteInstanceRequest(mux goahttp.Muxer, decoder func(*http.Request)
 		var (
 			branch          string
 			instanceID      string
+			keepConfigs     []string
 			storageAPIToken string
 			err             error
 
@@ -1151,6 +1372,7 @@ func DecodeDeleteInstanceRequest(mux goahttp.Muxer, decoder func(*http.Request)
 		)
 		branch = params["branch"]
 		instanceID = params["instanceId"]
+		keepConfigs = r.URL.Query()["keepConfigs"]
 		storageAPIToken = r.Header.Get("X-StorageApi-Token")
 		if storageAPIToken == "" {
 			err = goa.MergeErrors(err, goa.MissingFieldError("X-StorageApi-Token", "header"))
@@ -1158,7 +1380,7 @@ func DecodeDeleteInstanceRequest(mux goahttp.Muxer, decoder func(*http.Request)
 		if err != nil {
 			return nil, err
 		}
-		payload := NewDeleteInstancePayload(branch, instanceID, storageAPIToken)
+		payload := NewDeleteInstancePayload(branch, instanceID, keepConfigs, storageAPIToken)
 		if strings.Contains(payload.StorageAPIToken, " ") {
 			// Remove authorization scheme prefix (e.g. "Bearer")
 			cred := strings.SplitN(payload.StorageAPIToken, " ", 2)[1]
@@ -1228,6 +1450,96 @@ func EncodeDeleteInstanceError(encoder func(context.Context, http.ResponseWriter
 	}
 }
 
+// EncodeDeleteInstancePreviewResponse returns an encoder for responses
+// returned by the templates DeleteInstancePreview endpoint.
+func EncodeDeleteInstancePreviewResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*templates.InstanceDeletePreview)
+		enc := encoder(ctx, w)
+		body := NewDeleteInstancePreviewResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeDeleteInstancePreviewRequest returns a decoder for requests sent to
+// the templates DeleteInstancePreview endpoint.
+func DecodeDeleteInstancePreviewRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+	return func(r *http.Request) (any, error) {
+		var (
+			branch          string
+			instanceID      string
+			keepConfigs     []string
+			storageAPIToken string
+			err             error
+
+			params = mux.Vars(r)
+		)
+		branch = params["branch"]
+		instanceID = params["instanceId"]
+		keepConfigs = r.URL.Query()["keepConfigs"]
+		storageAPIToken = r.Header.Get("X-StorageApi-Token")
+		if storageAPIToken == "" {
+			err = goa.MergeErrors(err, goa.MissingFieldError("X-StorageApi-Token", "header"))
+		}
+		if err != nil {
+			return nil, err
+		}
+		payload := NewDeleteInstancePreviewPayload(branch, instanceID, keepConfigs, storageAPIToken)
+		if strings.Contains(payload.StorageAPIToken, " ") {
+			// Remove authorization scheme prefix (e.g. "Bearer")
+			cred := strings.SplitN(payload.StorageAPIToken, " ", 2)[1]
+			payload.StorageAPIToken = cred
+		}
+
+		return payload, nil
+	}
+}
+
+// EncodeDeleteInstancePreviewError returns an encoder for errors returned by
+// the DeleteInstancePreview templates endpoint.
+func EncodeDeleteInstancePreviewError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder, formatter func(ctx context.Context, err error) goahttp.Statuser) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ErrorEncoder(encoder, formatter)
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		var en goa.GoaErrorNamer
+		if !errors.As(v, &en) {
+			return encodeError(ctx, w, v)
+		}
+		switch en.GoaErrorName() {
+		case "templates.branchNotFound":
+			var res *templates.GenericError
+			errors.As(v, &res)
+			res.StatusCode = http.StatusNotFound
+			enc := encoder(ctx, w)
+			var body any
+			if false { // formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewDeleteInstancePreviewTemplatesBranchNotFoundResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		case "templates.instanceNotFound":
+			var res *templates.GenericError
+			errors.As(v, &res)
+			res.StatusCode = http.StatusNotFound
+			enc := encoder(ctx, w)
+			var body any
+			if false { // formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewDeleteInstancePreviewTemplatesInstanceNotFoundResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+
 // EncodeUpgradeInstanceResponse returns an encoder for responses returned by
 // the templates UpgradeInstance endpoint.
 func EncodeUpgradeInstanceResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
@@ -1649,30 +1961,32 @@ func EncodeUpgradeInstanceValidateInputsError(encoder func(context.Context, http
 	}
 }
 
-// EncodeGetTaskResponse returns an encoder for responses returned by the
-// templates GetTask endpoint.
-func EncodeGetTaskResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+// EncodeInstanceHistoryIndexResponse returns an encoder for responses returned
+// by the templates InstanceHistoryIndex endpoint.
+func EncodeInstanceHistoryIndexResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
 	return func(ctx context.Context, w http.ResponseWriter, v any) error {
-		res, _ := v.(*templates.Task)
+		res, _ := v.(*templates.InstanceHistory)
 		enc := encoder(ctx, w)
-		body := NewGetTaskResponseBody(res)
+		body := NewInstanceHistoryIndexResponseBody(res)
 		w.WriteHeader(http.StatusOK)
 		return enc.Encode(body)
 	}
 }
 
-// DecodeGetTaskRequest returns a decoder for requests sent to the templates
-// GetTask endpoint.
-func DecodeGetTaskRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+// DecodeInstanceHistoryIndexRequest returns a decoder for requests sent to the
+// templates InstanceHistoryIndex endpoint.
+func DecodeInstanceHistoryIndexRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
 	return func(r *http.Request) (any, error) {
 		var (
-			taskID          string
+			branch          string
+			instanceID      string
 			storageAPIToken string
 			err             error
 
 			params = mux.Vars(r)
 		)
-		taskID = params["taskId"]
+		branch = params["branch"]
+		instanceID = params["instanceId"]
 		storageAPIToken = r.Header.Get("X-StorageApi-Token")
 		if storageAPIToken == "" {
 			err = goa.MergeErrors(err, goa.MissingFieldError("X-StorageApi-Token", "header"))
@@ -1680,7 +1994,7 @@ func DecodeGetTaskRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp
 		if err != nil {
 			return nil, err
 		}
-		payload := NewGetTaskPayload(taskID, storageAPIToken)
+		payload := NewInstanceHistoryIndexPayload(branch, instanceID, storageAPIToken)
 		if strings.Contains(payload.StorageAPIToken, " ") {
 			// Remove authorization scheme prefix (e.g. "Bearer")
 			cred := strings.SplitN(payload.StorageAPIToken, " ", 2)[1]
@@ -1691,7 +2005,425 @@ func DecodeGetTaskRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp
 	}
 }
 
-// EncodeGetTaskError returns an encoder for errors returned by the GetTask
+// EncodeInstanceHistoryIndexError returns an encoder for errors returned by
+// the InstanceHistoryIndex templates endpoint.
+func EncodeInstanceHistoryIndexError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder, formatter func(ctx context.Context, err error) goahttp.Statuser) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ErrorEncoder(encoder, formatter)
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		var en goa.GoaErrorNamer
+		if !errors.As(v, &en) {
+			return encodeError(ctx, w, v)
+		}
+		switch en.GoaErrorName() {
+		case "templates.branchNotFound":
+			var res *templates.GenericError
+			errors.As(v, &res)
+			res.StatusCode = http.StatusNotFound
+			enc := encoder(ctx, w)
+			var body any
+			if false { // formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewInstanceHistoryIndexTemplatesBranchNotFoundResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		case "templates.instanceNotFound":
+			var res *templates.GenericError
+			errors.As(v, &res)
+			res.StatusCode = http.StatusNotFound
+			enc := encoder(ctx, w)
+			var body any
+			if false { // formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewInstanceHistoryIndexTemplatesInstanceNotFoundResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+
+// EncodeRollbackInstancePreviewResponse returns an encoder for responses
+// returned by the templates RollbackInstancePreview endpoint.
+func EncodeRollbackInstancePreviewResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*templates.InstanceRollbackPreview)
+		enc := encoder(ctx, w)
+		body := NewRollbackInstancePreviewResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeRollbackInstancePreviewRequest returns a decoder for requests sent to
+// the templates RollbackInstancePreview endpoint.
+func DecodeRollbackInstancePreviewRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+	return func(r *http.Request) (any, error) {
+		var (
+			branch          string
+			instanceID      string
+			storageAPIToken string
+			err             error
+
+			params = mux.Vars(r)
+		)
+		branch = params["branch"]
+		instanceID = params["instanceId"]
+		storageAPIToken = r.Header.Get("X-StorageApi-Token")
+		if storageAPIToken == "" {
+			err = goa.MergeErrors(err, goa.MissingFieldError("X-StorageApi-Token", "header"))
+		}
+		if err != nil {
+			return nil, err
+		}
+		payload := NewRollbackInstancePreviewPayload(branch, instanceID, storageAPIToken)
+		if strings.Contains(payload.StorageAPIToken, " ") {
+			// Remove authorization scheme prefix (e.g. "Bearer")
+			cred := strings.SplitN(payload.StorageAPIToken, " ", 2)[1]
+			payload.StorageAPIToken = cred
+		}
+
+		return payload, nil
+	}
+}
+
+// EncodeRollbackInstancePreviewError returns an encoder for errors returned by
+// the RollbackInstancePreview templates endpoint.
+func EncodeRollbackInstancePreviewError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder, formatter func(ctx context.Context, err error) goahttp.Statuser) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ErrorEncoder(encoder, formatter)
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		var en goa.GoaErrorNamer
+		if !errors.As(v, &en) {
+			return encodeError(ctx, w, v)
+		}
+		switch en.GoaErrorName() {
+		case "templates.templateNotFound":
+			var res *templates.GenericError
+			errors.As(v, &res)
+			res.StatusCode = http.StatusNotFound
+			enc := encoder(ctx, w)
+			var body any
+			if false { // formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewRollbackInstancePreviewTemplatesTemplateNotFoundResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		case "templates.branchNotFound":
+			var res *templates.GenericError
+			errors.As(v, &res)
+			res.StatusCode = http.StatusNotFound
+			enc := encoder(ctx, w)
+			var body any
+			if false { // formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewRollbackInstancePreviewTemplatesBranchNotFoundResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		case "templates.instanceNotFound":
+			var res *templates.GenericError
+			errors.As(v, &res)
+			res.StatusCode = http.StatusNotFound
+			enc := encoder(ctx, w)
+			var body any
+			if false { // formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewRollbackInstancePreviewTemplatesInstanceNotFoundResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		case "templates.versionNotFound":
+			var res *templates.GenericError
+			errors.As(v, &res)
+			res.StatusCode = http.StatusNotFound
+			enc := encoder(ctx, w)
+			var body any
+			if false { // formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewRollbackInstancePreviewTemplatesVersionNotFoundResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		case "templates.rollbackNotAvailable":
+			var res *templates.GenericError
+			errors.As(v, &res)
+			res.StatusCode = http.StatusBadRequest
+			enc := encoder(ctx, w)
+			var body any
+			if false { // formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewRollbackInstancePreviewTemplatesRollbackNotAvailableResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusBadRequest)
+			return enc.Encode(body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+
+// EncodeRollbackInstanceResponse returns an encoder for responses returned by
+// the templates RollbackInstance endpoint.
+func EncodeRollbackInstanceResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*templates.Task)
+		enc := encoder(ctx, w)
+		body := NewRollbackInstanceResponseBody(res)
+		w.WriteHeader(http.StatusAccepted)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeRollbackInstanceRequest returns a decoder for requests sent to the
+// templates RollbackInstance endpoint.
+func DecodeRollbackInstanceRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+	return func(r *http.Request) (any, error) {
+		var (
+			branch          string
+			instanceID      string
+			storageAPIToken string
+			err             error
+
+			params = mux.Vars(r)
+		)
+		branch = params["branch"]
+		instanceID = params["instanceId"]
+		storageAPIToken = r.Header.Get("X-StorageApi-Token")
+		if storageAPIToken == "" {
+			err = goa.MergeErrors(err, goa.MissingFieldError("X-StorageApi-Token", "header"))
+		}
+		if err != nil {
+			return nil, err
+		}
+		payload := NewRollbackInstancePayload(branch, instanceID, storageAPIToken)
+		if strings.Contains(payload.StorageAPIToken, " ") {
+			// Remove authorization scheme prefix (e.g. "Bearer")
+			cred := strings.SplitN(payload.StorageAPIToken, " ", 2)[1]
+			payload.StorageAPIToken = cred
+		}
+
+		return payload, nil
+	}
+}
+
+// EncodeRollbackInstanceError returns an encoder for errors returned by the
+// RollbackInstance templates endpoint.
+func EncodeRollbackInstanceError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder, formatter func(ctx context.Context, err error) goahttp.Statuser) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ErrorEncoder(encoder, formatter)
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		var en goa.GoaErrorNamer
+		if !errors.As(v, &en) {
+			return encodeError(ctx, w, v)
+		}
+		switch en.GoaErrorName() {
+		case "templates.templateNotFound":
+			var res *templates.GenericError
+			errors.As(v, &res)
+			res.StatusCode = http.StatusNotFound
+			enc := encoder(ctx, w)
+			var body any
+			if false { // formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewRollbackInstanceTemplatesTemplateNotFoundResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		case "templates.branchNotFound":
+			var res *templates.GenericError
+			errors.As(v, &res)
+			res.StatusCode = http.StatusNotFound
+			enc := encoder(ctx, w)
+			var body any
+			if false { // formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewRollbackInstanceTemplatesBranchNotFoundResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		case "templates.instanceNotFound":
+			var res *templates.GenericError
+			errors.As(v, &res)
+			res.StatusCode = http.StatusNotFound
+			enc := encoder(ctx, w)
+			var body any
+			if false { // formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewRollbackInstanceTemplatesInstanceNotFoundResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		case "templates.versionNotFound":
+			var res *templates.GenericError
+			errors.As(v, &res)
+			res.StatusCode = http.StatusNotFound
+			enc := encoder(ctx, w)
+			var body any
+			if false { // formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewRollbackInstanceTemplatesVersionNotFoundResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		case "templates.rollbackNotAvailable":
+			var res *templates.GenericError
+			errors.As(v, &res)
+			res.StatusCode = http.StatusBadRequest
+			enc := encoder(ctx, w)
+			var body any
+			if false { // formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewRollbackInstanceTemplatesRollbackNotAvailableResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusBadRequest)
+			return enc.Encode(body)
+		case "templates.projectLocked":
+			var res *templates.ProjectLockedError
+			errors.As(v, &res)
+
+			enc := encoder(ctx, w)
+			var body any
+			if false { // formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewRollbackInstanceTemplatesProjectLockedResponseBody(res)
+			}
+			w.Header().Set("Retry-After", res.RetryAfter)
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return enc.Encode(body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+
+// EncodeListAuditLogResponse returns an encoder for responses returned by the
+// templates ListAuditLog endpoint.
+func EncodeListAuditLogResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*templates.AuditLog)
+		enc := encoder(ctx, w)
+		body := NewListAuditLogResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeListAuditLogRequest returns a decoder for requests sent to the
+// templates ListAuditLog endpoint.
+func DecodeListAuditLogRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+	return func(r *http.Request) (any, error) {
+		var (
+			limit           int
+			storageAPIToken string
+			err             error
+		)
+		{
+			limitRaw := r.URL.Query().Get("limit")
+			if limitRaw == "" {
+				limit = 100
+			} else {
+				v, err2 := strconv.ParseInt(limitRaw, 10, strconv.IntSize)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("limit", limitRaw, "integer"))
+				}
+				limit = int(v)
+			}
+		}
+		if limit < 1 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError("limit", limit, 1, true))
+		}
+		if limit > 1000 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError("limit", limit, 1000, false))
+		}
+		storageAPIToken = r.Header.Get("X-StorageApi-Token")
+		if storageAPIToken == "" {
+			err = goa.MergeErrors(err, goa.MissingFieldError("X-StorageApi-Token", "header"))
+		}
+		if err != nil {
+			return nil, err
+		}
+		payload := NewListAuditLogPayload(limit, storageAPIToken)
+		if strings.Contains(payload.StorageAPIToken, " ") {
+			// Remove authorization scheme prefix (e.g. "Bearer")
+			cred := strings.SplitN(payload.StorageAPIToken, " ", 2)[1]
+			payload.StorageAPIToken = cred
+		}
+
+		return payload, nil
+	}
+}
+
+// EncodeGetTaskResponse returns an encoder for responses returned by the
+// templates GetTask endpoint.
+func EncodeGetTaskResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*templates.Task)
+		enc := encoder(ctx, w)
+		body := NewGetTaskResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeGetTaskRequest returns a decoder for requests sent to the templates
+// GetTask endpoint.
+func DecodeGetTaskRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+	return func(r *http.Request) (any, error) {
+		var (
+			taskID          string
+			storageAPIToken string
+			err             error
+
+			params = mux.Vars(r)
+		)
+		taskID = params["taskId"]
+		storageAPIToken = r.Header.Get("X-StorageApi-Token")
+		if storageAPIToken == "" {
+			err = goa.MergeErrors(err, goa.MissingFieldError("X-StorageApi-Token", "header"))
+		}
+		if err != nil {
+			return nil, err
+		}
+		payload := NewGetTaskPayload(taskID, storageAPIToken)
+		if strings.Contains(payload.StorageAPIToken, " ") {
+			// Remove authorization scheme prefix (e.g. "Bearer")
+			cred := strings.SplitN(payload.StorageAPIToken, " ", 2)[1]
+			payload.StorageAPIToken = cred
+		}
+
+		return payload, nil
+	}
+}
+
+// EncodeGetTaskError returns an encoder for errors returned by the GetTask
 // templates endpoint.
 func EncodeGetTaskError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder, formatter func(ctx context.Context, err error) goahttp.Statuser) func(context.Context, http.ResponseWriter, error) error {
 	encodeError := goahttp.ErrorEncoder(encoder, formatter)
@@ -1721,6 +2453,92 @@ func EncodeGetTaskError(encoder func(context.Context, http.ResponseWriter) goaht
 	}
 }
 
+// EncodeCancelTaskResponse returns an encoder for responses returned by the
+// templates CancelTask endpoint.
+func EncodeCancelTaskResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*templates.Task)
+		enc := encoder(ctx, w)
+		body := NewCancelTaskResponseBody(res)
+		w.WriteHeader(http.StatusAccepted)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeCancelTaskRequest returns a decoder for requests sent to the templates
+// CancelTask endpoint.
+func DecodeCancelTaskRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error) {
+	return func(r *http.Request) (any, error) {
+		var (
+			taskID          string
+			storageAPIToken string
+			err             error
+
+			params = mux.Vars(r)
+		)
+		taskID = params["taskId"]
+		storageAPIToken = r.Header.Get("X-StorageApi-Token")
+		if storageAPIToken == "" {
+			err = goa.MergeErrors(err, goa.MissingFieldError("X-StorageApi-Token", "header"))
+		}
+		if err != nil {
+			return nil, err
+		}
+		payload := NewCancelTaskPayload(taskID, storageAPIToken)
+		if strings.Contains(payload.StorageAPIToken, " ") {
+			// Remove authorization scheme prefix (e.g. "Bearer")
+			cred := strings.SplitN(payload.StorageAPIToken, " ", 2)[1]
+			payload.StorageAPIToken = cred
+		}
+
+		return payload, nil
+	}
+}
+
+// EncodeCancelTaskError returns an encoder for errors returned by the
+// CancelTask templates endpoint.
+func EncodeCancelTaskError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder, formatter func(ctx context.Context, err error) goahttp.Statuser) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ErrorEncoder(encoder, formatter)
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		var en goa.GoaErrorNamer
+		if !errors.As(v, &en) {
+			return encodeError(ctx, w, v)
+		}
+		switch en.GoaErrorName() {
+		case "templates.taskNotFound":
+			var res *templates.GenericError
+			errors.As(v, &res)
+			res.StatusCode = http.StatusNotFound
+			enc := encoder(ctx, w)
+			var body any
+			if false { // formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewCancelTaskTemplatesTaskNotFoundResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		case "templates.taskFinished":
+			var res *templates.GenericError
+			errors.As(v, &res)
+			res.StatusCode = http.StatusBadRequest
+			enc := encoder(ctx, w)
+			var body any
+			if false { // formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewCancelTaskTemplatesTaskFinishedResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusBadRequest)
+			return enc.Encode(body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+
 // marshalTemplatesRepositoryToRepositoryResponseBody builds a value of type
 // *RepositoryResponseBody from a value of type *templates.Repository.
 func marshalTemplatesRepositoryToRepositoryResponseBody(v *templates.Repository) *RepositoryResponseBody {
@@ -1732,6 +2550,9 @@ func marshalTemplatesRepositoryToRepositoryResponseBody(v *templates.Repository)
 	if v.Author != nil {
 		res.Author = marshalTemplatesAuthorToAuthorResponseBody(v.Author)
 	}
+	if v.Status != nil {
+		res.Status = marshalTemplatesRepositoryStatusToRepositoryStatusResponseBody(v.Status)
+	}
 
 	return res
 }
@@ -1747,6 +2568,54 @@ func marshalTemplatesAuthorToAuthorResponseBody(v *templates.Author) *AuthorResp
 	return res
 }
 
+// marshalTemplatesRepositoryStatusToRepositoryStatusResponseBody builds a
+// value of type *RepositoryStatusResponseBody from a value of type
+// *templates.RepositoryStatus.
+func marshalTemplatesRepositoryStatusToRepositoryStatusResponseBody(v *templates.RepositoryStatus) *RepositoryStatusResponseBody {
+	if v == nil {
+		return nil
+	}
+	res := &RepositoryStatusResponseBody{
+		CommitHash:  v.CommitHash,
+		LastUpdate:  v.LastUpdate,
+		LastError:   v.LastError,
+		LastErrorAt: v.LastErrorAt,
+	}
+
+	return res
+}
+
+// marshalTemplatesTaskProgressToTaskProgressResponseBody builds a value of
+// type *TaskProgressResponseBody from a value of type *templates.TaskProgress.
+func marshalTemplatesTaskProgressToTaskProgressResponseBody(v *templates.TaskProgress) *TaskProgressResponseBody {
+	if v == nil {
+		return nil
+	}
+	res := &TaskProgressResponseBody{
+		Percent:   v.Percent,
+		Step:      v.Step,
+		Message:   v.Message,
+		UpdatedAt: v.UpdatedAt,
+	}
+
+	return res
+}
+
+// marshalTemplatesTaskOutputsToTaskOutputsResponseBody builds a value of type
+// *TaskOutputsResponseBody from a value of type *templates.TaskOutputs.
+func marshalTemplatesTaskOutputsToTaskOutputsResponseBody(v *templates.TaskOutputs) *TaskOutputsResponseBody {
+	if v == nil {
+		return nil
+	}
+	res := &TaskOutputsResponseBody{}
+	if v.InstanceID != nil {
+		instanceID := string(*v.InstanceID)
+		res.InstanceID = &instanceID
+	}
+
+	return res
+}
+
 // marshalTemplatesTemplateToTemplateResponseBody builds a value of type
 // *TemplateResponseBody from a value of type *templates.Template.
 func marshalTemplatesTemplateToTemplateResponseBody(v *templates.Template) *TemplateResponseBody {
@@ -1979,16 +2848,13 @@ func unmarshalInputValueRequestBodyToTemplatesInputValue(v *InputValueRequestBod
 	return res
 }
 
-// marshalTemplatesTaskOutputsToTaskOutputsResponseBody builds a value of type
-// *TaskOutputsResponseBody from a value of type *templates.TaskOutputs.
-func marshalTemplatesTaskOutputsToTaskOutputsResponseBody(v *templates.TaskOutputs) *TaskOutputsResponseBody {
-	if v == nil {
-		return nil
-	}
-	res := &TaskOutputsResponseBody{}
-	if v.InstanceID != nil {
-		instanceID := string(*v.InstanceID)
-		res.InstanceID = &instanceID
+// marshalTemplatesInputLookupOptionToInputLookupOptionResponseBody builds a
+// value of type *InputLookupOptionResponseBody from a value of type
+// *templates.InputLookupOption.
+func marshalTemplatesInputLookupOptionToInputLookupOptionResponseBody(v *templates.InputLookupOption) *InputLookupOptionResponseBody {
+	res := &InputLookupOptionResponseBody{
+		Value: v.Value,
+		Label: v.Label,
 	}
 
 	return res
@@ -2102,3 +2968,177 @@ func marshalTemplatesVersionDetailToVersionDetailResponseBody(v *templates.Versi
 
 	return res
 }
+
+// marshalTemplatesDeletePreviewConfigToDeletePreviewConfigResponseBody builds
+// a value of type *DeletePreviewConfigResponseBody from a value of type
+// *templates.DeletePreviewConfig.
+func marshalTemplatesDeletePreviewConfigToDeletePreviewConfigResponseBody(v *templates.DeletePreviewConfig) *DeletePreviewConfigResponseBody {
+	res := &DeletePreviewConfigResponseBody{
+		ComponentID: v.ComponentID,
+		ConfigID:    v.ConfigID,
+		Name:        v.Name,
+		Action:      v.Action,
+	}
+	if v.Rows != nil {
+		res.Rows = make([]*DeletePreviewRowResponseBody, len(v.Rows))
+		for i, val := range v.Rows {
+			res.Rows[i] = marshalTemplatesDeletePreviewRowToDeletePreviewRowResponseBody(val)
+		}
+	} else {
+		res.Rows = []*DeletePreviewRowResponseBody{}
+	}
+	if v.OutputTables != nil {
+		res.OutputTables = make([]string, len(v.OutputTables))
+		for i, val := range v.OutputTables {
+			res.OutputTables[i] = val
+		}
+	} else {
+		res.OutputTables = []string{}
+	}
+
+	return res
+}
+
+// marshalTemplatesDeletePreviewRowToDeletePreviewRowResponseBody builds a
+// value of type *DeletePreviewRowResponseBody from a value of type
+// *templates.DeletePreviewRow.
+func marshalTemplatesDeletePreviewRowToDeletePreviewRowResponseBody(v *templates.DeletePreviewRow) *DeletePreviewRowResponseBody {
+	res := &DeletePreviewRowResponseBody{
+		RowID: v.RowID,
+		Name:  v.Name,
+	}
+
+	return res
+}
+
+// marshalTemplatesInstanceHistoryRecordToInstanceHistoryRecordResponseBody
+// builds a value of type *InstanceHistoryRecordResponseBody from a value of
+// type *templates.InstanceHistoryRecord.
+func marshalTemplatesInstanceHistoryRecordToInstanceHistoryRecordResponseBody(v *templates.InstanceHistoryRecord) *InstanceHistoryRecordResponseBody {
+	res := &InstanceHistoryRecordResponseBody{
+		Operation:      v.Operation,
+		RepositoryName: v.RepositoryName,
+		TemplateID:     string(v.TemplateID),
+		Version:        v.Version,
+		Name:           v.Name,
+		Error:          v.Error,
+	}
+	if v.Changed != nil {
+		res.Changed = marshalTemplatesChangeInfoToChangeInfoResponseBody(v.Changed)
+	}
+	if v.Inputs != nil {
+		res.Inputs = make([]*InstanceHistoryInputResponseBody, len(v.Inputs))
+		for i, val := range v.Inputs {
+			res.Inputs[i] = marshalTemplatesInstanceHistoryInputToInstanceHistoryInputResponseBody(val)
+		}
+	} else {
+		res.Inputs = []*InstanceHistoryInputResponseBody{}
+	}
+
+	return res
+}
+
+// marshalTemplatesInstanceHistoryInputToInstanceHistoryInputResponseBody
+// builds a value of type *InstanceHistoryInputResponseBody from a value of
+// type *templates.InstanceHistoryInput.
+func marshalTemplatesInstanceHistoryInputToInstanceHistoryInputResponseBody(v *templates.InstanceHistoryInput) *InstanceHistoryInputResponseBody {
+	res := &InstanceHistoryInputResponseBody{
+		ID:      v.ID,
+		Value:   v.Value,
+		Skipped: v.Skipped,
+	}
+
+	return res
+}
+
+// marshalTemplatesRollbackChangeToRollbackChangeResponseBody builds a value of
+// type *RollbackChangeResponseBody from a value of type
+// *templates.RollbackChange.
+func marshalTemplatesRollbackChangeToRollbackChangeResponseBody(v *templates.RollbackChange) *RollbackChangeResponseBody {
+	res := &RollbackChangeResponseBody{
+		ComponentID: v.ComponentID,
+		ConfigID:    v.ConfigID,
+		RowID:       v.RowID,
+		Name:        v.Name,
+		Action:      v.Action,
+	}
+	if v.ChangedFields != nil {
+		res.ChangedFields = make([]string, len(v.ChangedFields))
+		for i, val := range v.ChangedFields {
+			res.ChangedFields[i] = val
+		}
+	} else {
+		res.ChangedFields = []string{}
+	}
+
+	return res
+}
+
+// marshalTemplatesAuditEntryToAuditEntryResponseBody builds a value of type
+// *AuditEntryResponseBody from a value of type *templates.AuditEntry.
+func marshalTemplatesAuditEntryToAuditEntryResponseBody(v *templates.AuditEntry) *AuditEntryResponseBody {
+	res := &AuditEntryResponseBody{
+		ID:         v.ID,
+		Created:    v.Created,
+		RequestID:  v.RequestID,
+		Endpoint:   v.Endpoint,
+		HTTPMethod: v.HTTPMethod,
+		HTTPPath:   v.HTTPPath,
+		ObjectKey:  v.ObjectKey,
+	}
+	if v.TaskID != nil {
+		taskID := string(*v.TaskID)
+		res.TaskID = &taskID
+	}
+	if v.By != nil {
+		res.By = marshalTemplatesAuditByToAuditByResponseBody(v.By)
+	}
+	if v.Before != nil {
+		res.Before = make(map[string]any, len(v.Before))
+		for key, val := range v.Before {
+			tk := key
+			tv := val
+			res.Before[tk] = tv
+		}
+	}
+	if v.After != nil {
+		res.After = make(map[string]any, len(v.After))
+		for key, val := range v.After {
+			tk := key
+			tv := val
+			res.After[tk] = tv
+		}
+	}
+	if v.Result != nil {
+		res.Result = marshalTemplatesAuditResultToAuditResultResponseBody(v.Result)
+	}
+
+	return res
+}
+
+// marshalTemplatesAuditByToAuditByResponseBody builds a value of type
+// *AuditByResponseBody from a value of type *templates.AuditBy.
+func marshalTemplatesAuditByToAuditByResponseBody(v *templates.AuditBy) *AuditByResponseBody {
+	res := &AuditByResponseBody{
+		Type:      v.Type,
+		TokenID:   v.TokenID,
+		TokenDesc: v.TokenDesc,
+		UserID:    v.UserID,
+		UserName:  v.UserName,
+	}
+
+	return res
+}
+
+// marshalTemplatesAuditResultToAuditResultResponseBody builds a value of type
+// *AuditResultResponseBody from a value of type *templates.AuditResult.
+func marshalTemplatesAuditResultToAuditResultResponseBody(v *templates.AuditResult) *AuditResultResponseBody {
+	res := &AuditResultResponseBody{
+		Success:    v.Success,
+		StatusCode: v.StatusCode,
+		ErrorName:  v.ErrorName,
+		Error:      v.Error,
+	}
+
+	return res
+}
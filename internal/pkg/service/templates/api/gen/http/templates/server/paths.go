@@ -37,6 +37,16 @@ func RepositoryIndexTemplatesPath(repository string) string {
 	return fmt.Sprintf("/v1/repositories/%v", repository)
 }
 
+// RefreshRepositoryTemplatesPath returns the URL path to the templates service RefreshRepository HTTP endpoint.
+func RefreshRepositoryTemplatesPath(repository string) string {
+	return fmt.Sprintf("/v1/repositories/%v/refresh", repository)
+}
+
+// RepositoryWebhookTemplatesPath returns the URL path to the templates service RepositoryWebhook HTTP endpoint.
+func RepositoryWebhookTemplatesPath(repository string) string {
+	return fmt.Sprintf("/v1/webhooks/repositories/%v", repository)
+}
+
 // TemplatesIndexTemplatesPath returns the URL path to the templates service TemplatesIndex HTTP endpoint.
 func TemplatesIndexTemplatesPath(repository string) string {
 	return fmt.Sprintf("/v1/repositories/%v/templates", repository)
@@ -67,6 +77,11 @@ func UseTemplateVersionTemplatesPath(repository string, template string, version
 	return fmt.Sprintf("/v1/repositories/%v/templates/%v/%v/use", repository, template, version)
 }
 
+// InputLookupTemplatesPath returns the URL path to the templates service InputLookup HTTP endpoint.
+func InputLookupTemplatesPath(branch string, kind string) string {
+	return fmt.Sprintf("/v1/project/%v/input-lookup/%v", branch, kind)
+}
+
 // InstancesIndexTemplatesPath returns the URL path to the templates service InstancesIndex HTTP endpoint.
 func InstancesIndexTemplatesPath(branch string) string {
 	return fmt.Sprintf("/v1/project/%v/instances", branch)
@@ -87,6 +102,11 @@ func DeleteInstanceTemplatesPath(branch string, instanceID string) string {
 	return fmt.Sprintf("/v1/project/%v/instances/%v", branch, instanceID)
 }
 
+// DeleteInstancePreviewTemplatesPath returns the URL path to the templates service DeleteInstancePreview HTTP endpoint.
+func DeleteInstancePreviewTemplatesPath(branch string, instanceID string) string {
+	return fmt.Sprintf("/v1/project/%v/instances/%v/delete-preview", branch, instanceID)
+}
+
 // UpgradeInstanceTemplatesPath returns the URL path to the templates service UpgradeInstance HTTP endpoint.
 func UpgradeInstanceTemplatesPath(branch string, instanceID string, version string) string {
 	return fmt.Sprintf("/v1/project/%v/instances/%v/upgrade/%v", branch, instanceID, version)
@@ -102,7 +122,32 @@ func UpgradeInstanceValidateInputsTemplatesPath(branch string, instanceID string
 	return fmt.Sprintf("/v1/project/%v/instances/%v/upgrade/%v/inputs", branch, instanceID, version)
 }
 
+// InstanceHistoryIndexTemplatesPath returns the URL path to the templates service InstanceHistoryIndex HTTP endpoint.
+func InstanceHistoryIndexTemplatesPath(branch string, instanceID string) string {
+	return fmt.Sprintf("/v1/project/%v/instances/%v/history", branch, instanceID)
+}
+
+// RollbackInstancePreviewTemplatesPath returns the URL path to the templates service RollbackInstancePreview HTTP endpoint.
+func RollbackInstancePreviewTemplatesPath(branch string, instanceID string) string {
+	return fmt.Sprintf("/v1/project/%v/instances/%v/rollback", branch, instanceID)
+}
+
+// RollbackInstanceTemplatesPath returns the URL path to the templates service RollbackInstance HTTP endpoint.
+func RollbackInstanceTemplatesPath(branch string, instanceID string) string {
+	return fmt.Sprintf("/v1/project/%v/instances/%v/rollback", branch, instanceID)
+}
+
+// ListAuditLogTemplatesPath returns the URL path to the templates service ListAuditLog HTTP endpoint.
+func ListAuditLogTemplatesPath() string {
+	return "/v1/audit"
+}
+
 // GetTaskTemplatesPath returns the URL path to the templates service GetTask HTTP endpoint.
 func GetTaskTemplatesPath(taskID string) string {
 	return fmt.Sprintf("/v1/tasks/%v", taskID)
 }
+
+// CancelTaskTemplatesPath returns the URL path to the templates service CancelTask HTTP endpoint.
+func CancelTaskTemplatesPath(taskID string) string {
+	return fmt.Sprintf("/v1/tasks/%v", taskID)
+}
@@ -26,20 +26,29 @@ type Server struct {
 	HealthCheck                   http.Handler
 	RepositoriesIndex             http.Handler
 	RepositoryIndex               http.Handler
+	RefreshRepository             http.Handler
+	RepositoryWebhook             http.Handler
 	TemplatesIndex                http.Handler
 	TemplateIndex                 http.Handler
 	VersionIndex                  http.Handler
 	InputsIndex                   http.Handler
 	ValidateInputs                http.Handler
 	UseTemplateVersion            http.Handler
+	InputLookup                   http.Handler
 	InstancesIndex                http.Handler
 	InstanceIndex                 http.Handler
 	UpdateInstance                http.Handler
 	DeleteInstance                http.Handler
+	DeleteInstancePreview         http.Handler
 	UpgradeInstance               http.Handler
 	UpgradeInstanceInputsIndex    http.Handler
 	UpgradeInstanceValidateInputs http.Handler
+	InstanceHistoryIndex          http.Handler
+	RollbackInstancePreview       http.Handler
+	RollbackInstance              http.Handler
+	ListAuditLog                  http.Handler
 	GetTask                       http.Handler
+	CancelTask                    http.Handler
 	CORS                          http.Handler
 	OpenapiJSON                   http.Handler
 	OpenapiYaml                   http.Handler
@@ -100,35 +109,51 @@ func New(
 			{"HealthCheck", "GET", "/health-check"},
 			{"RepositoriesIndex", "GET", "/v1/repositories"},
 			{"RepositoryIndex", "GET", "/v1/repositories/{repository}"},
+			{"RefreshRepository", "POST", "/v1/repositories/{repository}/refresh"},
+			{"RepositoryWebhook", "POST", "/v1/webhooks/repositories/{repository}"},
 			{"TemplatesIndex", "GET", "/v1/repositories/{repository}/templates"},
 			{"TemplateIndex", "GET", "/v1/repositories/{repository}/templates/{template}"},
 			{"VersionIndex", "GET", "/v1/repositories/{repository}/templates/{template}/{version}"},
 			{"InputsIndex", "GET", "/v1/repositories/{repository}/templates/{template}/{version}/inputs"},
 			{"ValidateInputs", "POST", "/v1/repositories/{repository}/templates/{template}/{version}/validate"},
 			{"UseTemplateVersion", "POST", "/v1/repositories/{repository}/templates/{template}/{version}/use"},
+			{"InputLookup", "GET", "/v1/project/{branch}/input-lookup/{kind}"},
 			{"InstancesIndex", "GET", "/v1/project/{branch}/instances"},
 			{"InstanceIndex", "GET", "/v1/project/{branch}/instances/{instanceId}"},
 			{"UpdateInstance", "PUT", "/v1/project/{branch}/instances/{instanceId}"},
 			{"DeleteInstance", "DELETE", "/v1/project/{branch}/instances/{instanceId}"},
+			{"DeleteInstancePreview", "GET", "/v1/project/{branch}/instances/{instanceId}/delete-preview"},
 			{"UpgradeInstance", "POST", "/v1/project/{branch}/instances/{instanceId}/upgrade/{version}"},
 			{"UpgradeInstanceInputsIndex", "GET", "/v1/project/{branch}/instances/{instanceId}/upgrade/{version}/inputs"},
 			{"UpgradeInstanceValidateInputs", "POST", "/v1/project/{branch}/instances/{instanceId}/upgrade/{version}/inputs"},
+			{"InstanceHistoryIndex", "GET", "/v1/project/{branch}/instances/{instanceId}/history"},
+			{"RollbackInstancePreview", "GET", "/v1/project/{branch}/instances/{instanceId}/rollback"},
+			{"RollbackInstance", "POST", "/v1/project/{branch}/instances/{instanceId}/rollback"},
+			{"ListAuditLog", "GET", "/v1/audit"},
 			{"GetTask", "GET", "/v1/tasks/{*taskId}"},
+			{"CancelTask", "DELETE", "/v1/tasks/{*taskId}"},
 			{"CORS", "OPTIONS", "/"},
 			{"CORS", "OPTIONS", "/v1"},
 			{"CORS", "OPTIONS", "/health-check"},
 			{"CORS", "OPTIONS", "/v1/repositories"},
 			{"CORS", "OPTIONS", "/v1/repositories/{repository}"},
+			{"CORS", "OPTIONS", "/v1/repositories/{repository}/refresh"},
+			{"CORS", "OPTIONS", "/v1/webhooks/repositories/{repository}"},
 			{"CORS", "OPTIONS", "/v1/repositories/{repository}/templates"},
 			{"CORS", "OPTIONS", "/v1/repositories/{repository}/templates/{template}"},
 			{"CORS", "OPTIONS", "/v1/repositories/{repository}/templates/{template}/{version}"},
 			{"CORS", "OPTIONS", "/v1/repositories/{repository}/templates/{template}/{version}/inputs"},
 			{"CORS", "OPTIONS", "/v1/repositories/{repository}/templates/{template}/{version}/validate"},
 			{"CORS", "OPTIONS", "/v1/repositories/{repository}/templates/{template}/{version}/use"},
+			{"CORS", "OPTIONS", "/v1/project/{branch}/input-lookup/{kind}"},
 			{"CORS", "OPTIONS", "/v1/project/{branch}/instances"},
 			{"CORS", "OPTIONS", "/v1/project/{branch}/instances/{instanceId}"},
+			{"CORS", "OPTIONS", "/v1/project/{branch}/instances/{instanceId}/delete-preview"},
 			{"CORS", "OPTIONS", "/v1/project/{branch}/instances/{instanceId}/upgrade/{version}"},
 			{"CORS", "OPTIONS", "/v1/project/{branch}/instances/{instanceId}/upgrade/{version}/inputs"},
+			{"CORS", "OPTIONS", "/v1/project/{branch}/instances/{instanceId}/history"},
+			{"CORS", "OPTIONS", "/v1/project/{branch}/instances/{instanceId}/rollback"},
+			{"CORS", "OPTIONS", "/v1/audit"},
 			{"CORS", "OPTIONS", "/v1/tasks/{*taskId}"},
 			{"CORS", "OPTIONS", "/v1/documentation/openapi.json"},
 			{"CORS", "OPTIONS", "/v1/documentation/openapi.yaml"},
@@ -146,20 +171,29 @@ func New(
 		HealthCheck:                   NewHealthCheckHandler(e.HealthCheck, mux, decoder, encoder, errhandler, formatter),
 		RepositoriesIndex:             NewRepositoriesIndexHandler(e.RepositoriesIndex, mux, decoder, encoder, errhandler, formatter),
 		RepositoryIndex:               NewRepositoryIndexHandler(e.RepositoryIndex, mux, decoder, encoder, errhandler, formatter),
+		RefreshRepository:             NewRefreshRepositoryHandler(e.RefreshRepository, mux, decoder, encoder, errhandler, formatter),
+		RepositoryWebhook:             NewRepositoryWebhookHandler(e.RepositoryWebhook, mux, decoder, encoder, errhandler, formatter),
 		TemplatesIndex:                NewTemplatesIndexHandler(e.TemplatesIndex, mux, decoder, encoder, errhandler, formatter),
 		TemplateIndex:                 NewTemplateIndexHandler(e.TemplateIndex, mux, decoder, encoder, errhandler, formatter),
 		VersionIndex:                  NewVersionIndexHandler(e.VersionIndex, mux, decoder, encoder, errhandler, formatter),
 		InputsIndex:                   NewInputsIndexHandler(e.InputsIndex, mux, decoder, encoder, errhandler, formatter),
 		ValidateInputs:                NewValidateInputsHandler(e.ValidateInputs, mux, decoder, encoder, errhandler, formatter),
 		UseTemplateVersion:            NewUseTemplateVersionHandler(e.UseTemplateVersion, mux, decoder, encoder, errhandler, formatter),
+		InputLookup:                   NewInputLookupHandler(e.InputLookup, mux, decoder, encoder, errhandler, formatter),
 		InstancesIndex:                NewInstancesIndexHandler(e.InstancesIndex, mux, decoder, encoder, errhandler, formatter),
 		InstanceIndex:                 NewInstanceIndexHandler(e.InstanceIndex, mux, decoder, encoder, errhandler, formatter),
 		UpdateInstance:                NewUpdateInstanceHandler(e.UpdateInstance, mux, decoder, encoder, errhandler, formatter),
 		DeleteInstance:                NewDeleteInstanceHandler(e.DeleteInstance, mux, decoder, encoder, errhandler, formatter),
+		DeleteInstancePreview:         NewDeleteInstancePreviewHandler(e.DeleteInstancePreview, mux, decoder, encoder, errhandler, formatter),
 		UpgradeInstance:               NewUpgradeInstanceHandler(e.UpgradeInstance, mux, decoder, encoder, errhandler, formatter),
 		UpgradeInstanceInputsIndex:    NewUpgradeInstanceInputsIndexHandler(e.UpgradeInstanceInputsIndex, mux, decoder, encoder, errhandler, formatter),
 		UpgradeInstanceValidateInputs: NewUpgradeInstanceValidateInputsHandler(e.UpgradeInstanceValidateInputs, mux, decoder, encoder, errhandler, formatter),
+		InstanceHistoryIndex:          NewInstanceHistoryIndexHandler(e.InstanceHistoryIndex, mux, decoder, encoder, errhandler, formatter),
+		RollbackInstancePreview:       NewRollbackInstancePreviewHandler(e.RollbackInstancePreview, mux, decoder, encoder, errhandler, formatter),
+		RollbackInstance:              NewRollbackInstanceHandler(e.RollbackInstance, mux, decoder, encoder, errhandler, formatter),
+		ListAuditLog:                  NewListAuditLogHandler(e.ListAuditLog, mux, decoder, encoder, errhandler, formatter),
 		GetTask:                       NewGetTaskHandler(e.GetTask, mux, decoder, encoder, errhandler, formatter),
+		CancelTask:                    NewCancelTaskHandler(e.CancelTask, mux, decoder, encoder, errhandler, formatter),
 		CORS:                          NewCORSHandler(),
 		OpenapiJSON:                   http.FileServer(fileSystemOpenapiJSON),
 		OpenapiYaml:                   http.FileServer(fileSystemOpenapiYaml),
@@ -179,20 +213,29 @@ func (s *Server) Use(m func(http.Handler) http.Handler) {
 	s.HealthCheck = m(s.HealthCheck)
 	s.RepositoriesIndex = m(s.RepositoriesIndex)
 	s.RepositoryIndex = m(s.RepositoryIndex)
+	s.RefreshRepository = m(s.RefreshRepository)
+	s.RepositoryWebhook = m(s.RepositoryWebhook)
 	s.TemplatesIndex = m(s.TemplatesIndex)
 	s.TemplateIndex = m(s.TemplateIndex)
 	s.VersionIndex = m(s.VersionIndex)
 	s.InputsIndex = m(s.InputsIndex)
 	s.ValidateInputs = m(s.ValidateInputs)
 	s.UseTemplateVersion = m(s.UseTemplateVersion)
+	s.InputLookup = m(s.InputLookup)
 	s.InstancesIndex = m(s.InstancesIndex)
 	s.InstanceIndex = m(s.InstanceIndex)
 	s.UpdateInstance = m(s.UpdateInstance)
 	s.DeleteInstance = m(s.DeleteInstance)
+	s.DeleteInstancePreview = m(s.DeleteInstancePreview)
 	s.UpgradeInstance = m(s.UpgradeInstance)
 	s.UpgradeInstanceInputsIndex = m(s.UpgradeInstanceInputsIndex)
 	s.UpgradeInstanceValidateInputs = m(s.UpgradeInstanceValidateInputs)
+	s.InstanceHistoryIndex = m(s.InstanceHistoryIndex)
+	s.RollbackInstancePreview = m(s.RollbackInstancePreview)
+	s.RollbackInstance = m(s.RollbackInstance)
+	s.ListAuditLog = m(s.ListAuditLog)
 	s.GetTask = m(s.GetTask)
+	s.CancelTask = m(s.CancelTask)
 	s.CORS = m(s.CORS)
 }
 
@@ -206,20 +249,29 @@ func Mount(mux goahttp.Muxer, h *Server) {
 	MountHealthCheckHandler(mux, h.HealthCheck)
 	MountRepositoriesIndexHandler(mux, h.RepositoriesIndex)
 	MountRepositoryIndexHandler(mux, h.RepositoryIndex)
+	MountRefreshRepositoryHandler(mux, h.RefreshRepository)
+	MountRepositoryWebhookHandler(mux, h.RepositoryWebhook)
 	MountTemplatesIndexHandler(mux, h.TemplatesIndex)
 	MountTemplateIndexHandler(mux, h.TemplateIndex)
 	MountVersionIndexHandler(mux, h.VersionIndex)
 	MountInputsIndexHandler(mux, h.InputsIndex)
 	MountValidateInputsHandler(mux, h.ValidateInputs)
 	MountUseTemplateVersionHandler(mux, h.UseTemplateVersion)
+	MountInputLookupHandler(mux, h.InputLookup)
 	MountInstancesIndexHandler(mux, h.InstancesIndex)
 	MountInstanceIndexHandler(mux, h.InstanceIndex)
 	MountUpdateInstanceHandler(mux, h.UpdateInstance)
 	MountDeleteInstanceHandler(mux, h.DeleteInstance)
+	MountDeleteInstancePreviewHandler(mux, h.DeleteInstancePreview)
 	MountUpgradeInstanceHandler(mux, h.UpgradeInstance)
 	MountUpgradeInstanceInputsIndexHandler(mux, h.UpgradeInstanceInputsIndex)
 	MountUpgradeInstanceValidateInputsHandler(mux, h.UpgradeInstanceValidateInputs)
+	MountInstanceHistoryIndexHandler(mux, h.InstanceHistoryIndex)
+	MountRollbackInstancePreviewHandler(mux, h.RollbackInstancePreview)
+	MountRollbackInstanceHandler(mux, h.RollbackInstance)
+	MountListAuditLogHandler(mux, h.ListAuditLog)
 	MountGetTaskHandler(mux, h.GetTask)
+	MountCancelTaskHandler(mux, h.CancelTask)
 	MountCORSHandler(mux, h.CORS)
 	MountOpenapiJSON(mux, goahttp.Replace("", "/openapi.json", h.OpenapiJSON))
 	MountOpenapiYaml(mux, goahttp.Replace("", "/openapi.yaml", h.OpenapiYaml))
@@ -453,6 +505,109 @@ func NewRepositoryIndexHandler(
 	})
 }
 
+// MountRefreshRepositoryHandler configures the mux to serve the "templates"
+// service "RefreshRepository" endpoint.
+func MountRefreshRepositoryHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := HandleTemplatesOrigin(h).(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("POST", "/v1/repositories/{repository}/refresh", f)
+}
+
+// NewRefreshRepositoryHandler creates a HTTP handler which loads the HTTP
+// request and calls the "templates" service "RefreshRepository" endpoint.
+func NewRefreshRepositoryHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeRefreshRepositoryRequest(mux, decoder)
+		encodeResponse = EncodeRefreshRepositoryResponse(encoder)
+		encodeError    = EncodeRefreshRepositoryError(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "RefreshRepository")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "templates")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+
+// MountRepositoryWebhookHandler configures the mux to serve the "templates"
+// service "RepositoryWebhook" endpoint.
+func MountRepositoryWebhookHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := HandleTemplatesOrigin(h).(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("POST", "/v1/webhooks/repositories/{repository}", f)
+}
+
+// NewRepositoryWebhookHandler creates a HTTP handler which loads the HTTP
+// request and calls the "templates" service "RepositoryWebhook" endpoint.
+func NewRepositoryWebhookHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeRepositoryWebhookRequest(mux, decoder)
+		encodeResponse = EncodeRepositoryWebhookResponse(encoder)
+		encodeError    = EncodeRepositoryWebhookError(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "RepositoryWebhook")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "templates")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		data := &templates.RepositoryWebhookRequestData{Payload: payload.(*templates.RepositoryWebhookRequest), Body: r.Body}
+		res, err := endpoint(ctx, data)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+
 // MountTemplatesIndexHandler configures the mux to serve the "templates"
 // service "TemplatesIndex" endpoint.
 func MountTemplatesIndexHandler(mux goahttp.Muxer, h http.Handler) {
@@ -759,6 +914,57 @@ func NewUseTemplateVersionHandler(
 	})
 }
 
+// MountInputLookupHandler configures the mux to serve the "templates" service
+// "InputLookup" endpoint.
+func MountInputLookupHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := HandleTemplatesOrigin(h).(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/v1/project/{branch}/input-lookup/{kind}", f)
+}
+
+// NewInputLookupHandler creates a HTTP handler which loads the HTTP request
+// and calls the "templates" service "InputLookup" endpoint.
+func NewInputLookupHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeInputLookupRequest(mux, decoder)
+		encodeResponse = EncodeInputLookupResponse(encoder)
+		encodeError    = EncodeInputLookupError(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "InputLookup")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "templates")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+
 // MountInstancesIndexHandler configures the mux to serve the "templates"
 // service "InstancesIndex" endpoint.
 func MountInstancesIndexHandler(mux goahttp.Muxer, h http.Handler) {
@@ -963,6 +1169,57 @@ func NewDeleteInstanceHandler(
 	})
 }
 
+// MountDeleteInstancePreviewHandler configures the mux to serve the
+// "templates" service "DeleteInstancePreview" endpoint.
+func MountDeleteInstancePreviewHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := HandleTemplatesOrigin(h).(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/v1/project/{branch}/instances/{instanceId}/delete-preview", f)
+}
+
+// NewDeleteInstancePreviewHandler creates a HTTP handler which loads the HTTP
+// request and calls the "templates" service "DeleteInstancePreview" endpoint.
+func NewDeleteInstancePreviewHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeDeleteInstancePreviewRequest(mux, decoder)
+		encodeResponse = EncodeDeleteInstancePreviewResponse(encoder)
+		encodeError    = EncodeDeleteInstancePreviewError(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "DeleteInstancePreview")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "templates")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+
 // MountUpgradeInstanceHandler configures the mux to serve the "templates"
 // service "UpgradeInstance" endpoint.
 func MountUpgradeInstanceHandler(mux goahttp.Muxer, h http.Handler) {
@@ -1118,6 +1375,211 @@ func NewUpgradeInstanceValidateInputsHandler(
 	})
 }
 
+// MountInstanceHistoryIndexHandler configures the mux to serve the "templates"
+// service "InstanceHistoryIndex" endpoint.
+func MountInstanceHistoryIndexHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := HandleTemplatesOrigin(h).(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/v1/project/{branch}/instances/{instanceId}/history", f)
+}
+
+// NewInstanceHistoryIndexHandler creates a HTTP handler which loads the HTTP
+// request and calls the "templates" service "InstanceHistoryIndex" endpoint.
+func NewInstanceHistoryIndexHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeInstanceHistoryIndexRequest(mux, decoder)
+		encodeResponse = EncodeInstanceHistoryIndexResponse(encoder)
+		encodeError    = EncodeInstanceHistoryIndexError(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "InstanceHistoryIndex")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "templates")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+
+// MountRollbackInstancePreviewHandler configures the mux to serve the
+// "templates" service "RollbackInstancePreview" endpoint.
+func MountRollbackInstancePreviewHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := HandleTemplatesOrigin(h).(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/v1/project/{branch}/instances/{instanceId}/rollback", f)
+}
+
+// NewRollbackInstancePreviewHandler creates a HTTP handler which loads the
+// HTTP request and calls the "templates" service "RollbackInstancePreview"
+// endpoint.
+func NewRollbackInstancePreviewHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeRollbackInstancePreviewRequest(mux, decoder)
+		encodeResponse = EncodeRollbackInstancePreviewResponse(encoder)
+		encodeError    = EncodeRollbackInstancePreviewError(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "RollbackInstancePreview")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "templates")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+
+// MountRollbackInstanceHandler configures the mux to serve the "templates"
+// service "RollbackInstance" endpoint.
+func MountRollbackInstanceHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := HandleTemplatesOrigin(h).(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("POST", "/v1/project/{branch}/instances/{instanceId}/rollback", f)
+}
+
+// NewRollbackInstanceHandler creates a HTTP handler which loads the HTTP
+// request and calls the "templates" service "RollbackInstance" endpoint.
+func NewRollbackInstanceHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeRollbackInstanceRequest(mux, decoder)
+		encodeResponse = EncodeRollbackInstanceResponse(encoder)
+		encodeError    = EncodeRollbackInstanceError(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "RollbackInstance")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "templates")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+
+// MountListAuditLogHandler configures the mux to serve the "templates" service
+// "ListAuditLog" endpoint.
+func MountListAuditLogHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := HandleTemplatesOrigin(h).(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/v1/audit", f)
+}
+
+// NewListAuditLogHandler creates a HTTP handler which loads the HTTP request
+// and calls the "templates" service "ListAuditLog" endpoint.
+func NewListAuditLogHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeListAuditLogRequest(mux, decoder)
+		encodeResponse = EncodeListAuditLogResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "ListAuditLog")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "templates")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+
 // MountGetTaskHandler configures the mux to serve the "templates" service
 // "GetTask" endpoint.
 func MountGetTaskHandler(mux goahttp.Muxer, h http.Handler) {
@@ -1169,6 +1631,57 @@ func NewGetTaskHandler(
 	})
 }
 
+// MountCancelTaskHandler configures the mux to serve the "templates" service
+// "CancelTask" endpoint.
+func MountCancelTaskHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := HandleTemplatesOrigin(h).(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("DELETE", "/v1/tasks/{*taskId}", f)
+}
+
+// NewCancelTaskHandler creates a HTTP handler which loads the HTTP request and
+// calls the "templates" service "CancelTask" endpoint.
+func NewCancelTaskHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeCancelTaskRequest(mux, decoder)
+		encodeResponse = EncodeCancelTaskResponse(encoder)
+		encodeError    = EncodeCancelTaskError(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "CancelTask")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "templates")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+
 // MountOpenapiJSON configures the mux to serve GET request made to
 // "/v1/documentation/openapi.json".
 func MountOpenapiJSON(mux goahttp.Muxer, h http.Handler) {
@@ -1209,16 +1722,23 @@ func MountCORSHandler(mux goahttp.Muxer, h http.Handler) {
 	mux.Handle("OPTIONS", "/health-check", h.ServeHTTP)
 	mux.Handle("OPTIONS", "/v1/repositories", h.ServeHTTP)
 	mux.Handle("OPTIONS", "/v1/repositories/{repository}", h.ServeHTTP)
+	mux.Handle("OPTIONS", "/v1/repositories/{repository}/refresh", h.ServeHTTP)
+	mux.Handle("OPTIONS", "/v1/webhooks/repositories/{repository}", h.ServeHTTP)
 	mux.Handle("OPTIONS", "/v1/repositories/{repository}/templates", h.ServeHTTP)
 	mux.Handle("OPTIONS", "/v1/repositories/{repository}/templates/{template}", h.ServeHTTP)
 	mux.Handle("OPTIONS", "/v1/repositories/{repository}/templates/{template}/{version}", h.ServeHTTP)
 	mux.Handle("OPTIONS", "/v1/repositories/{repository}/templates/{template}/{version}/inputs", h.ServeHTTP)
 	mux.Handle("OPTIONS", "/v1/repositories/{repository}/templates/{template}/{version}/validate", h.ServeHTTP)
 	mux.Handle("OPTIONS", "/v1/repositories/{repository}/templates/{template}/{version}/use", h.ServeHTTP)
+	mux.Handle("OPTIONS", "/v1/project/{branch}/input-lookup/{kind}", h.ServeHTTP)
 	mux.Handle("OPTIONS", "/v1/project/{branch}/instances", h.ServeHTTP)
 	mux.Handle("OPTIONS", "/v1/project/{branch}/instances/{instanceId}", h.ServeHTTP)
+	mux.Handle("OPTIONS", "/v1/project/{branch}/instances/{instanceId}/delete-preview", h.ServeHTTP)
 	mux.Handle("OPTIONS", "/v1/project/{branch}/instances/{instanceId}/upgrade/{version}", h.ServeHTTP)
 	mux.Handle("OPTIONS", "/v1/project/{branch}/instances/{instanceId}/upgrade/{version}/inputs", h.ServeHTTP)
+	mux.Handle("OPTIONS", "/v1/project/{branch}/instances/{instanceId}/history", h.ServeHTTP)
+	mux.Handle("OPTIONS", "/v1/project/{branch}/instances/{instanceId}/rollback", h.ServeHTTP)
+	mux.Handle("OPTIONS", "/v1/audit", h.ServeHTTP)
 	mux.Handle("OPTIONS", "/v1/tasks/{*taskId}", h.ServeHTTP)
 	mux.Handle("OPTIONS", "/v1/documentation/openapi.json", h.ServeHTTP)
 	mux.Handle("OPTIONS", "/v1/documentation/openapi.yaml", h.ServeHTTP)
@@ -82,6 +82,34 @@ type RepositoryIndexResponseBody struct {
 	// Git branch or tag.
 	Ref    string              `form:"ref" json:"ref" xml:"ref"`
 	Author *AuthorResponseBody `form:"author" json:"author" xml:"author"`
+	// Result of the last repository pull. It is set only in the repository detail.
+	Status *RepositoryStatusResponseBody `form:"status,omitempty" json:"status,omitempty" xml:"status,omitempty"`
+}
+
+// RefreshRepositoryResponseBody is the type of the "templates" service
+// "RefreshRepository" endpoint HTTP response body.
+type RefreshRepositoryResponseBody struct {
+	ID string `form:"id" json:"id" xml:"id"`
+	// Task type.
+	Type string `form:"type" json:"type" xml:"type"`
+	// URL of the task.
+	URL string `form:"url" json:"url" xml:"url"`
+	// Task status, one of: processing, success, error
+	Status string `form:"status" json:"status" xml:"status"`
+	// Shortcut for status != "processing".
+	IsFinished bool `form:"isFinished" json:"isFinished" xml:"isFinished"`
+	// Date and time of the task creation.
+	CreatedAt string `form:"createdAt" json:"createdAt" xml:"createdAt"`
+	// Date and time of the task end.
+	FinishedAt *string `form:"finishedAt,omitempty" json:"finishedAt,omitempty" xml:"finishedAt,omitempty"`
+	// Date and time of the task cancellation.
+	CancelledAt *string `form:"cancelledAt,omitempty" json:"cancelledAt,omitempty" xml:"cancelledAt,omitempty"`
+	// Duration of the task in milliseconds.
+	Duration *int64                    `form:"duration,omitempty" json:"duration,omitempty" xml:"duration,omitempty"`
+	Progress *TaskProgressResponseBody `form:"progress,omitempty" json:"progress,omitempty" xml:"progress,omitempty"`
+	Result   *string                   `form:"result,omitempty" json:"result,omitempty" xml:"result,omitempty"`
+	Error    *string                   `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	Outputs  *TaskOutputsResponseBody  `form:"outputs,omitempty" json:"outputs,omitempty" xml:"outputs,omitempty"`
 }
 
 // TemplatesIndexResponseBody is the type of the "templates" service
@@ -144,6 +172,9 @@ type InputsIndexResponseBody struct {
 	StepGroups []*StepGroupResponseBody `form:"stepGroups" json:"stepGroups" xml:"stepGroups"`
 	// Initial state - same structure as the validation result.
 	InitialState *ValidationResultResponseBody `form:"initialState" json:"initialState" xml:"initialState"`
+	// JSON Schema of the inputs values with the "x-keboola-*" UI hints. Present
+	// only in the "jsonSchema" format.
+	JSONSchema any `form:"jsonSchema,omitempty" json:"jsonSchema,omitempty" xml:"jsonSchema,omitempty"`
 }
 
 // ValidateInputsResponseBody is the type of the "templates" service
@@ -171,11 +202,23 @@ type UseTemplateVersionResponseBody struct {
 	CreatedAt string `form:"createdAt" json:"createdAt" xml:"createdAt"`
 	// Date and time of the task end.
 	FinishedAt *string `form:"finishedAt,omitempty" json:"finishedAt,omitempty" xml:"finishedAt,omitempty"`
+	// Date and time of the task cancellation.
+	CancelledAt *string `form:"cancelledAt,omitempty" json:"cancelledAt,omitempty" xml:"cancelledAt,omitempty"`
 	// Duration of the task in milliseconds.
-	Duration *int64                   `form:"duration,omitempty" json:"duration,omitempty" xml:"duration,omitempty"`
-	Result   *string                  `form:"result,omitempty" json:"result,omitempty" xml:"result,omitempty"`
-	Error    *string                  `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
-	Outputs  *TaskOutputsResponseBody `form:"outputs,omitempty" json:"outputs,omitempty" xml:"outputs,omitempty"`
+	Duration *int64                    `form:"duration,omitempty" json:"duration,omitempty" xml:"duration,omitempty"`
+	Progress *TaskProgressResponseBody `form:"progress,omitempty" json:"progress,omitempty" xml:"progress,omitempty"`
+	Result   *string                   `form:"result,omitempty" json:"result,omitempty" xml:"result,omitempty"`
+	Error    *string                   `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	Outputs  *TaskOutputsResponseBody  `form:"outputs,omitempty" json:"outputs,omitempty" xml:"outputs,omitempty"`
+}
+
+// InputLookupResponseBody is the type of the "templates" service "InputLookup"
+// endpoint HTTP response body.
+type InputLookupResponseBody struct {
+	// Kind of the input.
+	Kind string `form:"kind" json:"kind" xml:"kind"`
+	// Existing objects. Value is a table ID, a bucket ID or a workspace ID.
+	Options []*InputLookupOptionResponseBody `form:"options" json:"options" xml:"options"`
 }
 
 // InstancesIndexResponseBody is the type of the "templates" service
@@ -255,11 +298,21 @@ type DeleteInstanceResponseBody struct {
 	CreatedAt string `form:"createdAt" json:"createdAt" xml:"createdAt"`
 	// Date and time of the task end.
 	FinishedAt *string `form:"finishedAt,omitempty" json:"finishedAt,omitempty" xml:"finishedAt,omitempty"`
+	// Date and time of the task cancellation.
+	CancelledAt *string `form:"cancelledAt,omitempty" json:"cancelledAt,omitempty" xml:"cancelledAt,omitempty"`
 	// Duration of the task in milliseconds.
-	Duration *int64                   `form:"duration,omitempty" json:"duration,omitempty" xml:"duration,omitempty"`
-	Result   *string                  `form:"result,omitempty" json:"result,omitempty" xml:"result,omitempty"`
-	Error    *string                  `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
-	Outputs  *TaskOutputsResponseBody `form:"outputs,omitempty" json:"outputs,omitempty" xml:"outputs,omitempty"`
+	Duration *int64                    `form:"duration,omitempty" json:"duration,omitempty" xml:"duration,omitempty"`
+	Progress *TaskProgressResponseBody `form:"progress,omitempty" json:"progress,omitempty" xml:"progress,omitempty"`
+	Result   *string                   `form:"result,omitempty" json:"result,omitempty" xml:"result,omitempty"`
+	Error    *string                   `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	Outputs  *TaskOutputsResponseBody  `form:"outputs,omitempty" json:"outputs,omitempty" xml:"outputs,omitempty"`
+}
+
+// DeleteInstancePreviewResponseBody is the type of the "templates" service
+// "DeleteInstancePreview" endpoint HTTP response body.
+type DeleteInstancePreviewResponseBody struct {
+	// Configurations of the instance and their schedulers.
+	Configurations []*DeletePreviewConfigResponseBody `form:"configurations" json:"configurations" xml:"configurations"`
 }
 
 // UpgradeInstanceResponseBody is the type of the "templates" service
@@ -278,11 +331,14 @@ type UpgradeInstanceResponseBody struct {
 	CreatedAt string `form:"createdAt" json:"createdAt" xml:"createdAt"`
 	// Date and time of the task end.
 	FinishedAt *string `form:"finishedAt,omitempty" json:"finishedAt,omitempty" xml:"finishedAt,omitempty"`
+	// Date and time of the task cancellation.
+	CancelledAt *string `form:"cancelledAt,omitempty" json:"cancelledAt,omitempty" xml:"cancelledAt,omitempty"`
 	// Duration of the task in milliseconds.
-	Duration *int64                   `form:"duration,omitempty" json:"duration,omitempty" xml:"duration,omitempty"`
-	Result   *string                  `form:"result,omitempty" json:"result,omitempty" xml:"result,omitempty"`
-	Error    *string                  `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
-	Outputs  *TaskOutputsResponseBody `form:"outputs,omitempty" json:"outputs,omitempty" xml:"outputs,omitempty"`
+	Duration *int64                    `form:"duration,omitempty" json:"duration,omitempty" xml:"duration,omitempty"`
+	Progress *TaskProgressResponseBody `form:"progress,omitempty" json:"progress,omitempty" xml:"progress,omitempty"`
+	Result   *string                   `form:"result,omitempty" json:"result,omitempty" xml:"result,omitempty"`
+	Error    *string                   `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	Outputs  *TaskOutputsResponseBody  `form:"outputs,omitempty" json:"outputs,omitempty" xml:"outputs,omitempty"`
 }
 
 // UpgradeInstanceInputsIndexResponseBody is the type of the "templates"
@@ -292,6 +348,9 @@ type UpgradeInstanceInputsIndexResponseBody struct {
 	StepGroups []*StepGroupResponseBody `form:"stepGroups" json:"stepGroups" xml:"stepGroups"`
 	// Initial state - same structure as the validation result.
 	InitialState *ValidationResultResponseBody `form:"initialState" json:"initialState" xml:"initialState"`
+	// JSON Schema of the inputs values with the "x-keboola-*" UI hints. Present
+	// only in the "jsonSchema" format.
+	JSONSchema any `form:"jsonSchema,omitempty" json:"jsonSchema,omitempty" xml:"jsonSchema,omitempty"`
 }
 
 // UpgradeInstanceValidateInputsResponseBody is the type of the "templates"
@@ -303,6 +362,54 @@ type UpgradeInstanceValidateInputsResponseBody struct {
 	StepGroups []*StepGroupValidationResultResponseBody `form:"stepGroups" json:"stepGroups" xml:"stepGroups"`
 }
 
+// InstanceHistoryIndexResponseBody is the type of the "templates" service
+// "InstanceHistoryIndex" endpoint HTTP response body.
+type InstanceHistoryIndexResponseBody struct {
+	// Operations which modified the instance, from the newest.
+	Records []*InstanceHistoryRecordResponseBody `form:"records" json:"records" xml:"records"`
+}
+
+// RollbackInstancePreviewResponseBody is the type of the "templates" service
+// "RollbackInstancePreview" endpoint HTTP response body.
+type RollbackInstancePreviewResponseBody struct {
+	// The previous state of the instance to be restored.
+	Target *InstanceHistoryRecordResponseBody `form:"target" json:"target" xml:"target"`
+	// Configurations and rows modified by the rollback.
+	Changes []*RollbackChangeResponseBody `form:"changes" json:"changes" xml:"changes"`
+}
+
+// RollbackInstanceResponseBody is the type of the "templates" service
+// "RollbackInstance" endpoint HTTP response body.
+type RollbackInstanceResponseBody struct {
+	ID string `form:"id" json:"id" xml:"id"`
+	// Task type.
+	Type string `form:"type" json:"type" xml:"type"`
+	// URL of the task.
+	URL string `form:"url" json:"url" xml:"url"`
+	// Task status, one of: processing, success, error
+	Status string `form:"status" json:"status" xml:"status"`
+	// Shortcut for status != "processing".
+	IsFinished bool `form:"isFinished" json:"isFinished" xml:"isFinished"`
+	// Date and time of the task creation.
+	CreatedAt string `form:"createdAt" json:"createdAt" xml:"createdAt"`
+	// Date and time of the task end.
+	FinishedAt *string `form:"finishedAt,omitempty" json:"finishedAt,omitempty" xml:"finishedAt,omitempty"`
+	// Date and time of the task cancellation.
+	CancelledAt *string `form:"cancelledAt,omitempty" json:"cancelledAt,omitempty" xml:"cancelledAt,omitempty"`
+	// Duration of the task in milliseconds.
+	Duration *int64                    `form:"duration,omitempty" json:"duration,omitempty" xml:"duration,omitempty"`
+	Progress *TaskProgressResponseBody `form:"progress,omitempty" json:"progress,omitempty" xml:"progress,omitempty"`
+	Result   *string                   `form:"result,omitempty" json:"result,omitempty" xml:"result,omitempty"`
+	Error    *string                   `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	Outputs  *TaskOutputsResponseBody  `form:"outputs,omitempty" json:"outputs,omitempty" xml:"outputs,omitempty"`
+}
+
+// ListAuditLogResponseBody is the type of the "templates" service
+// "ListAuditLog" endpoint HTTP response body.
+type ListAuditLogResponseBody struct {
+	Entries []*AuditEntryResponseBody `form:"entries" json:"entries" xml:"entries"`
+}
+
 // GetTaskResponseBody is the type of the "templates" service "GetTask"
 // endpoint HTTP response body.
 type GetTaskResponseBody struct {
@@ -319,11 +426,40 @@ type GetTaskResponseBody struct {
 	CreatedAt string `form:"createdAt" json:"createdAt" xml:"createdAt"`
 	// Date and time of the task end.
 	FinishedAt *string `form:"finishedAt,omitempty" json:"finishedAt,omitempty" xml:"finishedAt,omitempty"`
+	// Date and time of the task cancellation.
+	CancelledAt *string `form:"cancelledAt,omitempty" json:"cancelledAt,omitempty" xml:"cancelledAt,omitempty"`
+	// Duration of the task in milliseconds.
+	Duration *int64                    `form:"duration,omitempty" json:"duration,omitempty" xml:"duration,omitempty"`
+	Progress *TaskProgressResponseBody `form:"progress,omitempty" json:"progress,omitempty" xml:"progress,omitempty"`
+	Result   *string                   `form:"result,omitempty" json:"result,omitempty" xml:"result,omitempty"`
+	Error    *string                   `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	Outputs  *TaskOutputsResponseBody  `form:"outputs,omitempty" json:"outputs,omitempty" xml:"outputs,omitempty"`
+}
+
+// CancelTaskResponseBody is the type of the "templates" service "CancelTask"
+// endpoint HTTP response body.
+type CancelTaskResponseBody struct {
+	ID string `form:"id" json:"id" xml:"id"`
+	// Task type.
+	Type string `form:"type" json:"type" xml:"type"`
+	// URL of the task.
+	URL string `form:"url" json:"url" xml:"url"`
+	// Task status, one of: processing, success, error
+	Status string `form:"status" json:"status" xml:"status"`
+	// Shortcut for status != "processing".
+	IsFinished bool `form:"isFinished" json:"isFinished" xml:"isFinished"`
+	// Date and time of the task creation.
+	CreatedAt string `form:"createdAt" json:"createdAt" xml:"createdAt"`
+	// Date and time of the task end.
+	FinishedAt *string `form:"finishedAt,omitempty" json:"finishedAt,omitempty" xml:"finishedAt,omitempty"`
+	// Date and time of the task cancellation.
+	CancelledAt *string `form:"cancelledAt,omitempty" json:"cancelledAt,omitempty" xml:"cancelledAt,omitempty"`
 	// Duration of the task in milliseconds.
-	Duration *int64                   `form:"duration,omitempty" json:"duration,omitempty" xml:"duration,omitempty"`
-	Result   *string                  `form:"result,omitempty" json:"result,omitempty" xml:"result,omitempty"`
-	Error    *string                  `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
-	Outputs  *TaskOutputsResponseBody `form:"outputs,omitempty" json:"outputs,omitempty" xml:"outputs,omitempty"`
+	Duration *int64                    `form:"duration,omitempty" json:"duration,omitempty" xml:"duration,omitempty"`
+	Progress *TaskProgressResponseBody `form:"progress,omitempty" json:"progress,omitempty" xml:"progress,omitempty"`
+	Result   *string                   `form:"result,omitempty" json:"result,omitempty" xml:"result,omitempty"`
+	Error    *string                   `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	Outputs  *TaskOutputsResponseBody  `form:"outputs,omitempty" json:"outputs,omitempty" xml:"outputs,omitempty"`
 }
 
 // RepositoryIndexTemplatesRepositoryNotFoundResponseBody is the type of the
@@ -338,6 +474,30 @@ type RepositoryIndexTemplatesRepositoryNotFoundResponseBody struct {
 	Message string `form:"message" json:"message" xml:"message"`
 }
 
+// RefreshRepositoryTemplatesRepositoryNotFoundResponseBody is the type of the
+// "templates" service "RefreshRepository" endpoint HTTP response body for the
+// "templates.repositoryNotFound" error.
+type RefreshRepositoryTemplatesRepositoryNotFoundResponseBody struct {
+	// HTTP status code.
+	StatusCode int `form:"statusCode" json:"statusCode" xml:"statusCode"`
+	// Name of error.
+	Name string `form:"error" json:"error" xml:"error"`
+	// Error message.
+	Message string `form:"message" json:"message" xml:"message"`
+}
+
+// RepositoryWebhookTemplatesRepositoryNotFoundResponseBody is the type of the
+// "templates" service "RepositoryWebhook" endpoint HTTP response body for the
+// "templates.repositoryNotFound" error.
+type RepositoryWebhookTemplatesRepositoryNotFoundResponseBody struct {
+	// HTTP status code.
+	StatusCode int `form:"statusCode" json:"statusCode" xml:"statusCode"`
+	// Name of error.
+	Name string `form:"error" json:"error" xml:"error"`
+	// Error message.
+	Message string `form:"message" json:"message" xml:"message"`
+}
+
 // TemplatesIndexTemplatesRepositoryNotFoundResponseBody is the type of the
 // "templates" service "TemplatesIndex" endpoint HTTP response body for the
 // "templates.repositoryNotFound" error.
@@ -541,6 +701,18 @@ type UseTemplateVersionInvalidInputsResponseBody struct {
 	ValidationResult *ValidationResultResponseBody `form:"ValidationResult" json:"ValidationResult" xml:"ValidationResult"`
 }
 
+// InputLookupTemplatesBranchNotFoundResponseBody is the type of the
+// "templates" service "InputLookup" endpoint HTTP response body for the
+// "templates.branchNotFound" error.
+type InputLookupTemplatesBranchNotFoundResponseBody struct {
+	// HTTP status code.
+	StatusCode int `form:"statusCode" json:"statusCode" xml:"statusCode"`
+	// Name of error.
+	Name string `form:"error" json:"error" xml:"error"`
+	// Error message.
+	Message string `form:"message" json:"message" xml:"message"`
+}
+
 // InstancesIndexTemplatesBranchNotFoundResponseBody is the type of the
 // "templates" service "InstancesIndex" endpoint HTTP response body for the
 // "templates.branchNotFound" error.
@@ -661,6 +833,30 @@ type DeleteInstanceTemplatesProjectLockedResponseBody struct {
 	Message string `form:"message" json:"message" xml:"message"`
 }
 
+// DeleteInstancePreviewTemplatesBranchNotFoundResponseBody is the type of the
+// "templates" service "DeleteInstancePreview" endpoint HTTP response body for
+// the "templates.branchNotFound" error.
+type DeleteInstancePreviewTemplatesBranchNotFoundResponseBody struct {
+	// HTTP status code.
+	StatusCode int `form:"statusCode" json:"statusCode" xml:"statusCode"`
+	// Name of error.
+	Name string `form:"error" json:"error" xml:"error"`
+	// Error message.
+	Message string `form:"message" json:"message" xml:"message"`
+}
+
+// DeleteInstancePreviewTemplatesInstanceNotFoundResponseBody is the type of
+// the "templates" service "DeleteInstancePreview" endpoint HTTP response body
+// for the "templates.instanceNotFound" error.
+type DeleteInstancePreviewTemplatesInstanceNotFoundResponseBody struct {
+	// HTTP status code.
+	StatusCode int `form:"statusCode" json:"statusCode" xml:"statusCode"`
+	// Name of error.
+	Name string `form:"error" json:"error" xml:"error"`
+	// Error message.
+	Message string `form:"message" json:"message" xml:"message"`
+}
+
 // UpgradeInstanceTemplatesTemplateNotFoundResponseBody is the type of the
 // "templates" service "UpgradeInstance" endpoint HTTP response body for the
 // "templates.templateNotFound" error.
@@ -828,6 +1024,162 @@ type UpgradeInstanceValidateInputsTemplatesVersionNotFoundResponseBody struct {
 	Message string `form:"message" json:"message" xml:"message"`
 }
 
+// InstanceHistoryIndexTemplatesBranchNotFoundResponseBody is the type of the
+// "templates" service "InstanceHistoryIndex" endpoint HTTP response body for
+// the "templates.branchNotFound" error.
+type InstanceHistoryIndexTemplatesBranchNotFoundResponseBody struct {
+	// HTTP status code.
+	StatusCode int `form:"statusCode" json:"statusCode" xml:"statusCode"`
+	// Name of error.
+	Name string `form:"error" json:"error" xml:"error"`
+	// Error message.
+	Message string `form:"message" json:"message" xml:"message"`
+}
+
+// InstanceHistoryIndexTemplatesInstanceNotFoundResponseBody is the type of the
+// "templates" service "InstanceHistoryIndex" endpoint HTTP response body for
+// the "templates.instanceNotFound" error.
+type InstanceHistoryIndexTemplatesInstanceNotFoundResponseBody struct {
+	// HTTP status code.
+	StatusCode int `form:"statusCode" json:"statusCode" xml:"statusCode"`
+	// Name of error.
+	Name string `form:"error" json:"error" xml:"error"`
+	// Error message.
+	Message string `form:"message" json:"message" xml:"message"`
+}
+
+// RollbackInstancePreviewTemplatesTemplateNotFoundResponseBody is the type of
+// the "templates" service "RollbackInstancePreview" endpoint HTTP response
+// body for the "templates.templateNotFound" error.
+type RollbackInstancePreviewTemplatesTemplateNotFoundResponseBody struct {
+	// HTTP status code.
+	StatusCode int `form:"statusCode" json:"statusCode" xml:"statusCode"`
+	// Name of error.
+	Name string `form:"error" json:"error" xml:"error"`
+	// Error message.
+	Message string `form:"message" json:"message" xml:"message"`
+}
+
+// RollbackInstancePreviewTemplatesBranchNotFoundResponseBody is the type of
+// the "templates" service "RollbackInstancePreview" endpoint HTTP response
+// body for the "templates.branchNotFound" error.
+type RollbackInstancePreviewTemplatesBranchNotFoundResponseBody struct {
+	// HTTP status code.
+	StatusCode int `form:"statusCode" json:"statusCode" xml:"statusCode"`
+	// Name of error.
+	Name string `form:"error" json:"error" xml:"error"`
+	// Error message.
+	Message string `form:"message" json:"message" xml:"message"`
+}
+
+// RollbackInstancePreviewTemplatesInstanceNotFoundResponseBody is the type of
+// the "templates" service "RollbackInstancePreview" endpoint HTTP response
+// body for the "templates.instanceNotFound" error.
+type RollbackInstancePreviewTemplatesInstanceNotFoundResponseBody struct {
+	// HTTP status code.
+	StatusCode int `form:"statusCode" json:"statusCode" xml:"statusCode"`
+	// Name of error.
+	Name string `form:"error" json:"error" xml:"error"`
+	// Error message.
+	Message string `form:"message" json:"message" xml:"message"`
+}
+
+// RollbackInstancePreviewTemplatesVersionNotFoundResponseBody is the type of
+// the "templates" service "RollbackInstancePreview" endpoint HTTP response
+// body for the "templates.versionNotFound" error.
+type RollbackInstancePreviewTemplatesVersionNotFoundResponseBody struct {
+	// HTTP status code.
+	StatusCode int `form:"statusCode" json:"statusCode" xml:"statusCode"`
+	// Name of error.
+	Name string `form:"error" json:"error" xml:"error"`
+	// Error message.
+	Message string `form:"message" json:"message" xml:"message"`
+}
+
+// RollbackInstancePreviewTemplatesRollbackNotAvailableResponseBody is the type
+// of the "templates" service "RollbackInstancePreview" endpoint HTTP response
+// body for the "templates.rollbackNotAvailable" error.
+type RollbackInstancePreviewTemplatesRollbackNotAvailableResponseBody struct {
+	// HTTP status code.
+	StatusCode int `form:"statusCode" json:"statusCode" xml:"statusCode"`
+	// Name of error.
+	Name string `form:"error" json:"error" xml:"error"`
+	// Error message.
+	Message string `form:"message" json:"message" xml:"message"`
+}
+
+// RollbackInstanceTemplatesTemplateNotFoundResponseBody is the type of the
+// "templates" service "RollbackInstance" endpoint HTTP response body for the
+// "templates.templateNotFound" error.
+type RollbackInstanceTemplatesTemplateNotFoundResponseBody struct {
+	// HTTP status code.
+	StatusCode int `form:"statusCode" json:"statusCode" xml:"statusCode"`
+	// Name of error.
+	Name string `form:"error" json:"error" xml:"error"`
+	// Error message.
+	Message string `form:"message" json:"message" xml:"message"`
+}
+
+// RollbackInstanceTemplatesBranchNotFoundResponseBody is the type of the
+// "templates" service "RollbackInstance" endpoint HTTP response body for the
+// "templates.branchNotFound" error.
+type RollbackInstanceTemplatesBranchNotFoundResponseBody struct {
+	// HTTP status code.
+	StatusCode int `form:"statusCode" json:"statusCode" xml:"statusCode"`
+	// Name of error.
+	Name string `form:"error" json:"error" xml:"error"`
+	// Error message.
+	Message string `form:"message" json:"message" xml:"message"`
+}
+
+// RollbackInstanceTemplatesInstanceNotFoundResponseBody is the type of the
+// "templates" service "RollbackInstance" endpoint HTTP response body for the
+// "templates.instanceNotFound" error.
+type RollbackInstanceTemplatesInstanceNotFoundResponseBody struct {
+	// HTTP status code.
+	StatusCode int `form:"statusCode" json:"statusCode" xml:"statusCode"`
+	// Name of error.
+	Name string `form:"error" json:"error" xml:"error"`
+	// Error message.
+	Message string `form:"message" json:"message" xml:"message"`
+}
+
+// RollbackInstanceTemplatesVersionNotFoundResponseBody is the type of the
+// "templates" service "RollbackInstance" endpoint HTTP response body for the
+// "templates.versionNotFound" error.
+type RollbackInstanceTemplatesVersionNotFoundResponseBody struct {
+	// HTTP status code.
+	StatusCode int `form:"statusCode" json:"statusCode" xml:"statusCode"`
+	// Name of error.
+	Name string `form:"error" json:"error" xml:"error"`
+	// Error message.
+	Message string `form:"message" json:"message" xml:"message"`
+}
+
+// RollbackInstanceTemplatesRollbackNotAvailableResponseBody is the type of the
+// "templates" service "RollbackInstance" endpoint HTTP response body for the
+// "templates.rollbackNotAvailable" error.
+type RollbackInstanceTemplatesRollbackNotAvailableResponseBody struct {
+	// HTTP status code.
+	StatusCode int `form:"statusCode" json:"statusCode" xml:"statusCode"`
+	// Name of error.
+	Name string `form:"error" json:"error" xml:"error"`
+	// Error message.
+	Message string `form:"message" json:"message" xml:"message"`
+}
+
+// RollbackInstanceTemplatesProjectLockedResponseBody is the type of the
+// "templates" service "RollbackInstance" endpoint HTTP response body for the
+// "templates.projectLocked" error.
+type RollbackInstanceTemplatesProjectLockedResponseBody struct {
+	// HTTP status code.
+	StatusCode int `form:"statusCode" json:"statusCode" xml:"statusCode"`
+	// Name of error.
+	Name string `form:"error" json:"error" xml:"error"`
+	// Error message.
+	Message string `form:"message" json:"message" xml:"message"`
+}
+
 // GetTaskTemplatesTaskNotFoundResponseBody is the type of the "templates"
 // service "GetTask" endpoint HTTP response body for the
 // "templates.taskNotFound" error.
@@ -840,6 +1192,30 @@ type GetTaskTemplatesTaskNotFoundResponseBody struct {
 	Message string `form:"message" json:"message" xml:"message"`
 }
 
+// CancelTaskTemplatesTaskNotFoundResponseBody is the type of the "templates"
+// service "CancelTask" endpoint HTTP response body for the
+// "templates.taskNotFound" error.
+type CancelTaskTemplatesTaskNotFoundResponseBody struct {
+	// HTTP status code.
+	StatusCode int `form:"statusCode" json:"statusCode" xml:"statusCode"`
+	// Name of error.
+	Name string `form:"error" json:"error" xml:"error"`
+	// Error message.
+	Message string `form:"message" json:"message" xml:"message"`
+}
+
+// CancelTaskTemplatesTaskFinishedResponseBody is the type of the "templates"
+// service "CancelTask" endpoint HTTP response body for the
+// "templates.taskFinished" error.
+type CancelTaskTemplatesTaskFinishedResponseBody struct {
+	// HTTP status code.
+	StatusCode int `form:"statusCode" json:"statusCode" xml:"statusCode"`
+	// Name of error.
+	Name string `form:"error" json:"error" xml:"error"`
+	// Error message.
+	Message string `form:"message" json:"message" xml:"message"`
+}
+
 // RepositoryResponseBody is used to define fields on response body types.
 type RepositoryResponseBody struct {
 	// Template repository name. Use "keboola" for default Keboola repository.
@@ -849,6 +1225,8 @@ type RepositoryResponseBody struct {
 	// Git branch or tag.
 	Ref    string              `form:"ref" json:"ref" xml:"ref"`
 	Author *AuthorResponseBody `form:"author" json:"author" xml:"author"`
+	// Result of the last repository pull. It is set only in the repository detail.
+	Status *RepositoryStatusResponseBody `form:"status,omitempty" json:"status,omitempty" xml:"status,omitempty"`
 }
 
 // AuthorResponseBody is used to define fields on response body types.
@@ -859,6 +1237,36 @@ type AuthorResponseBody struct {
 	URL string `form:"url" json:"url" xml:"url"`
 }
 
+// RepositoryStatusResponseBody is used to define fields on response body types.
+type RepositoryStatusResponseBody struct {
+	// Hash of the checked out commit.
+	CommitHash string `form:"commitHash" json:"commitHash" xml:"commitHash"`
+	// Date and time of the last successful pull.
+	LastUpdate *string `form:"lastUpdate,omitempty" json:"lastUpdate,omitempty" xml:"lastUpdate,omitempty"`
+	// Error of the last failed pull. It is cleared by the next successful pull.
+	LastError *string `form:"lastError,omitempty" json:"lastError,omitempty" xml:"lastError,omitempty"`
+	// Date and time of the last failed pull.
+	LastErrorAt *string `form:"lastErrorAt,omitempty" json:"lastErrorAt,omitempty" xml:"lastErrorAt,omitempty"`
+}
+
+// TaskProgressResponseBody is used to define fields on response body types.
+type TaskProgressResponseBody struct {
+	// Progress in percent.
+	Percent int `form:"percent" json:"percent" xml:"percent"`
+	// Current step of the operation.
+	Step *string `form:"step,omitempty" json:"step,omitempty" xml:"step,omitempty"`
+	// Message describing the current state.
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Date and time of the last progress update.
+	UpdatedAt string `form:"updatedAt" json:"updatedAt" xml:"updatedAt"`
+}
+
+// TaskOutputsResponseBody is used to define fields on response body types.
+type TaskOutputsResponseBody struct {
+	// ID of the created/updated template instance.
+	InstanceID *string `form:"instanceId,omitempty" json:"instanceId,omitempty" xml:"instanceId,omitempty"`
+}
+
 // TemplateResponseBody is used to define fields on response body types.
 type TemplateResponseBody struct {
 	ID string `form:"id" json:"id" xml:"id"`
@@ -996,10 +1404,13 @@ type InputValidationResultResponseBody struct {
 	Error *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
 }
 
-// TaskOutputsResponseBody is used to define fields on response body types.
-type TaskOutputsResponseBody struct {
-	// ID of the created/updated template instance.
-	InstanceID *string `form:"instanceId,omitempty" json:"instanceId,omitempty" xml:"instanceId,omitempty"`
+// InputLookupOptionResponseBody is used to define fields on response body
+// types.
+type InputLookupOptionResponseBody struct {
+	// Value of the option.
+	Value string `form:"value" json:"value" xml:"value"`
+	// Visible label of the option.
+	Label string `form:"label" json:"label" xml:"label"`
 }
 
 // InstanceResponseBody is used to define fields on response body types.
@@ -1079,11 +1490,136 @@ type VersionDetailResponseBody struct {
 	Readme string `form:"readme" json:"readme" xml:"readme"`
 }
 
-// StepPayloadRequestBody is used to define fields on request body types.
-type StepPayloadRequestBody struct {
-	// Unique ID of the step.
-	ID *string `form:"id,omitempty" json:"id,omitempty" xml:"id,omitempty"`
-	// Input values.
+// DeletePreviewConfigResponseBody is used to define fields on response body
+// types.
+type DeletePreviewConfigResponseBody struct {
+	// Component ID.
+	ComponentID string `form:"componentId" json:"componentId" xml:"componentId"`
+	// Configuration ID.
+	ConfigID string `form:"configId" json:"configId" xml:"configId"`
+	// Name of the configuration.
+	Name string `form:"name" json:"name" xml:"name"`
+	// The configuration is deleted or kept as a regular configuration.
+	Action string `form:"action" json:"action" xml:"action"`
+	// Rows of the configuration.
+	Rows []*DeletePreviewRowResponseBody `form:"rows" json:"rows" xml:"rows"`
+	// Storage tables written by the configuration or its rows.
+	OutputTables []string `form:"outputTables" json:"outputTables" xml:"outputTables"`
+}
+
+// DeletePreviewRowResponseBody is used to define fields on response body types.
+type DeletePreviewRowResponseBody struct {
+	// Row ID.
+	RowID string `form:"rowId" json:"rowId" xml:"rowId"`
+	// Name of the row.
+	Name string `form:"name" json:"name" xml:"name"`
+}
+
+// InstanceHistoryRecordResponseBody is used to define fields on response body
+// types.
+type InstanceHistoryRecordResponseBody struct {
+	// Operation type.
+	Operation string `form:"operation" json:"operation" xml:"operation"`
+	// Date of the operation and who made it.
+	Changed *ChangeInfoResponseBody `form:"changed" json:"changed" xml:"changed"`
+	// Name of the template repository.
+	RepositoryName string `form:"repositoryName" json:"repositoryName" xml:"repositoryName"`
+	TemplateID     string `form:"templateId" json:"templateId" xml:"templateId"`
+	// Semantic version of the template.
+	Version string `form:"version" json:"version" xml:"version"`
+	// Name of the instance.
+	Name string `form:"name" json:"name" xml:"name"`
+	// Values of the template inputs, hidden inputs are not included.
+	Inputs []*InstanceHistoryInputResponseBody `form:"inputs" json:"inputs" xml:"inputs"`
+	// Error of the operation. If it is set, the instance may be partially modified.
+	Error *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+}
+
+// InstanceHistoryInputResponseBody is used to define fields on response body
+// types.
+type InstanceHistoryInputResponseBody struct {
+	// Input ID.
+	ID string `form:"id" json:"id" xml:"id"`
+	// Input value.
+	Value any `form:"value,omitempty" json:"value,omitempty" xml:"value,omitempty"`
+	// The input was not visible or the step was skipped, the default value was
+	// used.
+	Skipped bool `form:"skipped" json:"skipped" xml:"skipped"`
+}
+
+// RollbackChangeResponseBody is used to define fields on response body types.
+type RollbackChangeResponseBody struct {
+	// Component ID.
+	ComponentID string `form:"componentId" json:"componentId" xml:"componentId"`
+	// Configuration ID.
+	ConfigID string `form:"configId" json:"configId" xml:"configId"`
+	// Row ID, it is set if the change is in a configuration row.
+	RowID *string `form:"rowId,omitempty" json:"rowId,omitempty" xml:"rowId,omitempty"`
+	// Name of the configuration or row.
+	Name string `form:"name" json:"name" xml:"name"`
+	// The object is created, updated or deleted by the rollback.
+	Action string `form:"action" json:"action" xml:"action"`
+	// Changed fields, it is set if the object is updated.
+	ChangedFields []string `form:"changedFields" json:"changedFields" xml:"changedFields"`
+}
+
+// AuditEntryResponseBody is used to define fields on response body types.
+type AuditEntryResponseBody struct {
+	// Unique ID of the entry.
+	ID string `form:"id" json:"id" xml:"id"`
+	// Date and time of the API call.
+	Created string `form:"created" json:"created" xml:"created"`
+	// ID of the API request.
+	RequestID *string `form:"requestId,omitempty" json:"requestId,omitempty" xml:"requestId,omitempty"`
+	// Name of the API endpoint.
+	Endpoint string `form:"endpoint" json:"endpoint" xml:"endpoint"`
+	// HTTP method of the API request.
+	HTTPMethod string `form:"httpMethod" json:"httpMethod" xml:"httpMethod"`
+	// HTTP path of the API request.
+	HTTPPath string               `form:"httpPath" json:"httpPath" xml:"httpPath"`
+	By       *AuditByResponseBody `form:"by" json:"by" xml:"by"`
+	// Key of the modified object.
+	ObjectKey *string `form:"objectKey,omitempty" json:"objectKey,omitempty" xml:"objectKey,omitempty"`
+	// ID of the asynchronous task started by the API call.
+	TaskID *string `form:"taskId,omitempty" json:"taskId,omitempty" xml:"taskId,omitempty"`
+	// Summary of the object state before the API call.
+	Before map[string]any `form:"before,omitempty" json:"before,omitempty" xml:"before,omitempty"`
+	// Summary of the expected object state after the API call.
+	After  map[string]any           `form:"after,omitempty" json:"after,omitempty" xml:"after,omitempty"`
+	Result *AuditResultResponseBody `form:"result" json:"result" xml:"result"`
+}
+
+// AuditByResponseBody is used to define fields on response body types.
+type AuditByResponseBody struct {
+	// Type of the actor.
+	Type string `form:"type" json:"type" xml:"type"`
+	// ID of the token.
+	TokenID *string `form:"tokenId,omitempty" json:"tokenId,omitempty" xml:"tokenId,omitempty"`
+	// Description of the token.
+	TokenDesc *string `form:"tokenDesc,omitempty" json:"tokenDesc,omitempty" xml:"tokenDesc,omitempty"`
+	// ID of the user.
+	UserID *string `form:"userId,omitempty" json:"userId,omitempty" xml:"userId,omitempty"`
+	// Name of the user.
+	UserName *string `form:"userName,omitempty" json:"userName,omitempty" xml:"userName,omitempty"`
+}
+
+// AuditResultResponseBody is used to define fields on response body types.
+type AuditResultResponseBody struct {
+	// True, if the API call succeeded.
+	Success bool `form:"success" json:"success" xml:"success"`
+	// HTTP status code of the failed API call.
+	StatusCode *int `form:"statusCode,omitempty" json:"statusCode,omitempty" xml:"statusCode,omitempty"`
+	// Name of the error.
+	ErrorName *string `form:"errorName,omitempty" json:"errorName,omitempty" xml:"errorName,omitempty"`
+	// Error message.
+	Error *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+}
+
+// StepPayloadRequestBody is used to define fields on request body types.
+type StepPayloadRequestBody struct {
+	// Unique ID of the step.
+	ID *string `form:"id,omitempty" json:"id,omitempty" xml:"id,omitempty"`
+	// Input values.
 	Inputs []*InputValueRequestBody `form:"inputs,omitempty" json:"inputs,omitempty" xml:"inputs,omitempty"`
 }
 
@@ -1131,6 +1667,34 @@ func NewRepositoryIndexResponseBody(res *templates.Repository) *RepositoryIndexR
 	if res.Author != nil {
 		body.Author = marshalTemplatesAuthorToAuthorResponseBody(res.Author)
 	}
+	if res.Status != nil {
+		body.Status = marshalTemplatesRepositoryStatusToRepositoryStatusResponseBody(res.Status)
+	}
+	return body
+}
+
+// NewRefreshRepositoryResponseBody builds the HTTP response body from the
+// result of the "RefreshRepository" endpoint of the "templates" service.
+func NewRefreshRepositoryResponseBody(res *templates.Task) *RefreshRepositoryResponseBody {
+	body := &RefreshRepositoryResponseBody{
+		ID:          string(res.ID),
+		Type:        res.Type,
+		URL:         res.URL,
+		Status:      res.Status,
+		IsFinished:  res.IsFinished,
+		CreatedAt:   res.CreatedAt,
+		FinishedAt:  res.FinishedAt,
+		CancelledAt: res.CancelledAt,
+		Duration:    res.Duration,
+		Result:      res.Result,
+		Error:       res.Error,
+	}
+	if res.Progress != nil {
+		body.Progress = marshalTemplatesTaskProgressToTaskProgressResponseBody(res.Progress)
+	}
+	if res.Outputs != nil {
+		body.Outputs = marshalTemplatesTaskOutputsToTaskOutputsResponseBody(res.Outputs)
+	}
 	return body
 }
 
@@ -1225,7 +1789,9 @@ func NewVersionIndexResponseBody(res *templates.VersionDetailExtended) *VersionI
 // NewInputsIndexResponseBody builds the HTTP response body from the result of
 // the "InputsIndex" endpoint of the "templates" service.
 func NewInputsIndexResponseBody(res *templates.Inputs) *InputsIndexResponseBody {
-	body := &InputsIndexResponseBody{}
+	body := &InputsIndexResponseBody{
+		JSONSchema: res.JSONSchema,
+	}
 	if res.StepGroups != nil {
 		body.StepGroups = make([]*StepGroupResponseBody, len(res.StepGroups))
 		for i, val := range res.StepGroups {
@@ -1261,16 +1827,20 @@ func NewValidateInputsResponseBody(res *templates.ValidationResult) *ValidateInp
 // result of the "UseTemplateVersion" endpoint of the "templates" service.
 func NewUseTemplateVersionResponseBody(res *templates.Task) *UseTemplateVersionResponseBody {
 	body := &UseTemplateVersionResponseBody{
-		ID:         string(res.ID),
-		Type:       res.Type,
-		URL:        res.URL,
-		Status:     res.Status,
-		IsFinished: res.IsFinished,
-		CreatedAt:  res.CreatedAt,
-		FinishedAt: res.FinishedAt,
-		Duration:   res.Duration,
-		Result:     res.Result,
-		Error:      res.Error,
+		ID:          string(res.ID),
+		Type:        res.Type,
+		URL:         res.URL,
+		Status:      res.Status,
+		IsFinished:  res.IsFinished,
+		CreatedAt:   res.CreatedAt,
+		FinishedAt:  res.FinishedAt,
+		CancelledAt: res.CancelledAt,
+		Duration:    res.Duration,
+		Result:      res.Result,
+		Error:       res.Error,
+	}
+	if res.Progress != nil {
+		body.Progress = marshalTemplatesTaskProgressToTaskProgressResponseBody(res.Progress)
 	}
 	if res.Outputs != nil {
 		body.Outputs = marshalTemplatesTaskOutputsToTaskOutputsResponseBody(res.Outputs)
@@ -1278,6 +1848,23 @@ func NewUseTemplateVersionResponseBody(res *templates.Task) *UseTemplateVersionR
 	return body
 }
 
+// NewInputLookupResponseBody builds the HTTP response body from the result of
+// the "InputLookup" endpoint of the "templates" service.
+func NewInputLookupResponseBody(res *templates.InputLookupResult) *InputLookupResponseBody {
+	body := &InputLookupResponseBody{
+		Kind: res.Kind,
+	}
+	if res.Options != nil {
+		body.Options = make([]*InputLookupOptionResponseBody, len(res.Options))
+		for i, val := range res.Options {
+			body.Options[i] = marshalTemplatesInputLookupOptionToInputLookupOptionResponseBody(val)
+		}
+	} else {
+		body.Options = []*InputLookupOptionResponseBody{}
+	}
+	return body
+}
+
 // NewInstancesIndexResponseBody builds the HTTP response body from the result
 // of the "InstancesIndex" endpoint of the "templates" service.
 func NewInstancesIndexResponseBody(res *templates.Instances) *InstancesIndexResponseBody {
@@ -1371,16 +1958,20 @@ func NewUpdateInstanceResponseBody(res *templates.InstanceDetail) *UpdateInstanc
 // of the "DeleteInstance" endpoint of the "templates" service.
 func NewDeleteInstanceResponseBody(res *templates.Task) *DeleteInstanceResponseBody {
 	body := &DeleteInstanceResponseBody{
-		ID:         string(res.ID),
-		Type:       res.Type,
-		URL:        res.URL,
-		Status:     res.Status,
-		IsFinished: res.IsFinished,
-		CreatedAt:  res.CreatedAt,
-		FinishedAt: res.FinishedAt,
-		Duration:   res.Duration,
-		Result:     res.Result,
-		Error:      res.Error,
+		ID:          string(res.ID),
+		Type:        res.Type,
+		URL:         res.URL,
+		Status:      res.Status,
+		IsFinished:  res.IsFinished,
+		CreatedAt:   res.CreatedAt,
+		FinishedAt:  res.FinishedAt,
+		CancelledAt: res.CancelledAt,
+		Duration:    res.Duration,
+		Result:      res.Result,
+		Error:       res.Error,
+	}
+	if res.Progress != nil {
+		body.Progress = marshalTemplatesTaskProgressToTaskProgressResponseBody(res.Progress)
 	}
 	if res.Outputs != nil {
 		body.Outputs = marshalTemplatesTaskOutputsToTaskOutputsResponseBody(res.Outputs)
@@ -1388,20 +1979,39 @@ func NewDeleteInstanceResponseBody(res *templates.Task) *DeleteInstanceResponseB
 	return body
 }
 
+// NewDeleteInstancePreviewResponseBody builds the HTTP response body from the
+// result of the "DeleteInstancePreview" endpoint of the "templates" service.
+func NewDeleteInstancePreviewResponseBody(res *templates.InstanceDeletePreview) *DeleteInstancePreviewResponseBody {
+	body := &DeleteInstancePreviewResponseBody{}
+	if res.Configurations != nil {
+		body.Configurations = make([]*DeletePreviewConfigResponseBody, len(res.Configurations))
+		for i, val := range res.Configurations {
+			body.Configurations[i] = marshalTemplatesDeletePreviewConfigToDeletePreviewConfigResponseBody(val)
+		}
+	} else {
+		body.Configurations = []*DeletePreviewConfigResponseBody{}
+	}
+	return body
+}
+
 // NewUpgradeInstanceResponseBody builds the HTTP response body from the result
 // of the "UpgradeInstance" endpoint of the "templates" service.
 func NewUpgradeInstanceResponseBody(res *templates.Task) *UpgradeInstanceResponseBody {
 	body := &UpgradeInstanceResponseBody{
-		ID:         string(res.ID),
-		Type:       res.Type,
-		URL:        res.URL,
-		Status:     res.Status,
-		IsFinished: res.IsFinished,
-		CreatedAt:  res.CreatedAt,
-		FinishedAt: res.FinishedAt,
-		Duration:   res.Duration,
-		Result:     res.Result,
-		Error:      res.Error,
+		ID:          string(res.ID),
+		Type:        res.Type,
+		URL:         res.URL,
+		Status:      res.Status,
+		IsFinished:  res.IsFinished,
+		CreatedAt:   res.CreatedAt,
+		FinishedAt:  res.FinishedAt,
+		CancelledAt: res.CancelledAt,
+		Duration:    res.Duration,
+		Result:      res.Result,
+		Error:       res.Error,
+	}
+	if res.Progress != nil {
+		body.Progress = marshalTemplatesTaskProgressToTaskProgressResponseBody(res.Progress)
 	}
 	if res.Outputs != nil {
 		body.Outputs = marshalTemplatesTaskOutputsToTaskOutputsResponseBody(res.Outputs)
@@ -1413,7 +2023,9 @@ func NewUpgradeInstanceResponseBody(res *templates.Task) *UpgradeInstanceRespons
 // the result of the "UpgradeInstanceInputsIndex" endpoint of the "templates"
 // service.
 func NewUpgradeInstanceInputsIndexResponseBody(res *templates.Inputs) *UpgradeInstanceInputsIndexResponseBody {
-	body := &UpgradeInstanceInputsIndexResponseBody{}
+	body := &UpgradeInstanceInputsIndexResponseBody{
+		JSONSchema: res.JSONSchema,
+	}
 	if res.StepGroups != nil {
 		body.StepGroups = make([]*StepGroupResponseBody, len(res.StepGroups))
 		for i, val := range res.StepGroups {
@@ -1446,20 +2058,123 @@ func NewUpgradeInstanceValidateInputsResponseBody(res *templates.ValidationResul
 	return body
 }
 
+// NewInstanceHistoryIndexResponseBody builds the HTTP response body from the
+// result of the "InstanceHistoryIndex" endpoint of the "templates" service.
+func NewInstanceHistoryIndexResponseBody(res *templates.InstanceHistory) *InstanceHistoryIndexResponseBody {
+	body := &InstanceHistoryIndexResponseBody{}
+	if res.Records != nil {
+		body.Records = make([]*InstanceHistoryRecordResponseBody, len(res.Records))
+		for i, val := range res.Records {
+			body.Records[i] = marshalTemplatesInstanceHistoryRecordToInstanceHistoryRecordResponseBody(val)
+		}
+	} else {
+		body.Records = []*InstanceHistoryRecordResponseBody{}
+	}
+	return body
+}
+
+// NewRollbackInstancePreviewResponseBody builds the HTTP response body from
+// the result of the "RollbackInstancePreview" endpoint of the "templates"
+// service.
+func NewRollbackInstancePreviewResponseBody(res *templates.InstanceRollbackPreview) *RollbackInstancePreviewResponseBody {
+	body := &RollbackInstancePreviewResponseBody{}
+	if res.Target != nil {
+		body.Target = marshalTemplatesInstanceHistoryRecordToInstanceHistoryRecordResponseBody(res.Target)
+	}
+	if res.Changes != nil {
+		body.Changes = make([]*RollbackChangeResponseBody, len(res.Changes))
+		for i, val := range res.Changes {
+			body.Changes[i] = marshalTemplatesRollbackChangeToRollbackChangeResponseBody(val)
+		}
+	} else {
+		body.Changes = []*RollbackChangeResponseBody{}
+	}
+	return body
+}
+
+// NewRollbackInstanceResponseBody builds the HTTP response body from the
+// result of the "RollbackInstance" endpoint of the "templates" service.
+func NewRollbackInstanceResponseBody(res *templates.Task) *RollbackInstanceResponseBody {
+	body := &RollbackInstanceResponseBody{
+		ID:          string(res.ID),
+		Type:        res.Type,
+		URL:         res.URL,
+		Status:      res.Status,
+		IsFinished:  res.IsFinished,
+		CreatedAt:   res.CreatedAt,
+		FinishedAt:  res.FinishedAt,
+		CancelledAt: res.CancelledAt,
+		Duration:    res.Duration,
+		Result:      res.Result,
+		Error:       res.Error,
+	}
+	if res.Progress != nil {
+		body.Progress = marshalTemplatesTaskProgressToTaskProgressResponseBody(res.Progress)
+	}
+	if res.Outputs != nil {
+		body.Outputs = marshalTemplatesTaskOutputsToTaskOutputsResponseBody(res.Outputs)
+	}
+	return body
+}
+
+// NewListAuditLogResponseBody builds the HTTP response body from the result of
+// the "ListAuditLog" endpoint of the "templates" service.
+func NewListAuditLogResponseBody(res *templates.AuditLog) *ListAuditLogResponseBody {
+	body := &ListAuditLogResponseBody{}
+	if res.Entries != nil {
+		body.Entries = make([]*AuditEntryResponseBody, len(res.Entries))
+		for i, val := range res.Entries {
+			body.Entries[i] = marshalTemplatesAuditEntryToAuditEntryResponseBody(val)
+		}
+	} else {
+		body.Entries = []*AuditEntryResponseBody{}
+	}
+	return body
+}
+
 // NewGetTaskResponseBody builds the HTTP response body from the result of the
 // "GetTask" endpoint of the "templates" service.
 func NewGetTaskResponseBody(res *templates.Task) *GetTaskResponseBody {
 	body := &GetTaskResponseBody{
-		ID:         string(res.ID),
-		Type:       res.Type,
-		URL:        res.URL,
-		Status:     res.Status,
-		IsFinished: res.IsFinished,
-		CreatedAt:  res.CreatedAt,
-		FinishedAt: res.FinishedAt,
-		Duration:   res.Duration,
-		Result:     res.Result,
-		Error:      res.Error,
+		ID:          string(res.ID),
+		Type:        res.Type,
+		URL:         res.URL,
+		Status:      res.Status,
+		IsFinished:  res.IsFinished,
+		CreatedAt:   res.CreatedAt,
+		FinishedAt:  res.FinishedAt,
+		CancelledAt: res.CancelledAt,
+		Duration:    res.Duration,
+		Result:      res.Result,
+		Error:       res.Error,
+	}
+	if res.Progress != nil {
+		body.Progress = marshalTemplatesTaskProgressToTaskProgressResponseBody(res.Progress)
+	}
+	if res.Outputs != nil {
+		body.Outputs = marshalTemplatesTaskOutputsToTaskOutputsResponseBody(res.Outputs)
+	}
+	return body
+}
+
+// NewCancelTaskResponseBody builds the HTTP response body from the result of
+// the "CancelTask" endpoint of the "templates" service.
+func NewCancelTaskResponseBody(res *templates.Task) *CancelTaskResponseBody {
+	body := &CancelTaskResponseBody{
+		ID:          string(res.ID),
+		Type:        res.Type,
+		URL:         res.URL,
+		Status:      res.Status,
+		IsFinished:  res.IsFinished,
+		CreatedAt:   res.CreatedAt,
+		FinishedAt:  res.FinishedAt,
+		CancelledAt: res.CancelledAt,
+		Duration:    res.Duration,
+		Result:      res.Result,
+		Error:       res.Error,
+	}
+	if res.Progress != nil {
+		body.Progress = marshalTemplatesTaskProgressToTaskProgressResponseBody(res.Progress)
 	}
 	if res.Outputs != nil {
 		body.Outputs = marshalTemplatesTaskOutputsToTaskOutputsResponseBody(res.Outputs)
@@ -1479,6 +2194,30 @@ func NewRepositoryIndexTemplatesRepositoryNotFoundResponseBody(res *templates.Ge
 	return body
 }
 
+// NewRefreshRepositoryTemplatesRepositoryNotFoundResponseBody builds the HTTP
+// response body from the result of the "RefreshRepository" endpoint of the
+// "templates" service.
+func NewRefreshRepositoryTemplatesRepositoryNotFoundResponseBody(res *templates.GenericError) *RefreshRepositoryTemplatesRepositoryNotFoundResponseBody {
+	body := &RefreshRepositoryTemplatesRepositoryNotFoundResponseBody{
+		StatusCode: res.StatusCode,
+		Name:       res.Name,
+		Message:    res.Message,
+	}
+	return body
+}
+
+// NewRepositoryWebhookTemplatesRepositoryNotFoundResponseBody builds the HTTP
+// response body from the result of the "RepositoryWebhook" endpoint of the
+// "templates" service.
+func NewRepositoryWebhookTemplatesRepositoryNotFoundResponseBody(res *templates.GenericError) *RepositoryWebhookTemplatesRepositoryNotFoundResponseBody {
+	body := &RepositoryWebhookTemplatesRepositoryNotFoundResponseBody{
+		StatusCode: res.StatusCode,
+		Name:       res.Name,
+		Message:    res.Message,
+	}
+	return body
+}
+
 // NewTemplatesIndexTemplatesRepositoryNotFoundResponseBody builds the HTTP
 // response body from the result of the "TemplatesIndex" endpoint of the
 // "templates" service.
@@ -1685,6 +2424,18 @@ func NewUseTemplateVersionInvalidInputsResponseBody(res *templates.ValidationErr
 	return body
 }
 
+// NewInputLookupTemplatesBranchNotFoundResponseBody builds the HTTP response
+// body from the result of the "InputLookup" endpoint of the "templates"
+// service.
+func NewInputLookupTemplatesBranchNotFoundResponseBody(res *templates.GenericError) *InputLookupTemplatesBranchNotFoundResponseBody {
+	body := &InputLookupTemplatesBranchNotFoundResponseBody{
+		StatusCode: res.StatusCode,
+		Name:       res.Name,
+		Message:    res.Message,
+	}
+	return body
+}
+
 // NewInstancesIndexTemplatesBranchNotFoundResponseBody builds the HTTP
 // response body from the result of the "InstancesIndex" endpoint of the
 // "templates" service.
@@ -1805,6 +2556,30 @@ func NewDeleteInstanceTemplatesProjectLockedResponseBody(res *templates.ProjectL
 	return body
 }
 
+// NewDeleteInstancePreviewTemplatesBranchNotFoundResponseBody builds the HTTP
+// response body from the result of the "DeleteInstancePreview" endpoint of the
+// "templates" service.
+func NewDeleteInstancePreviewTemplatesBranchNotFoundResponseBody(res *templates.GenericError) *DeleteInstancePreviewTemplatesBranchNotFoundResponseBody {
+	body := &DeleteInstancePreviewTemplatesBranchNotFoundResponseBody{
+		StatusCode: res.StatusCode,
+		Name:       res.Name,
+		Message:    res.Message,
+	}
+	return body
+}
+
+// NewDeleteInstancePreviewTemplatesInstanceNotFoundResponseBody builds the
+// HTTP response body from the result of the "DeleteInstancePreview" endpoint
+// of the "templates" service.
+func NewDeleteInstancePreviewTemplatesInstanceNotFoundResponseBody(res *templates.GenericError) *DeleteInstancePreviewTemplatesInstanceNotFoundResponseBody {
+	body := &DeleteInstancePreviewTemplatesInstanceNotFoundResponseBody{
+		StatusCode: res.StatusCode,
+		Name:       res.Name,
+		Message:    res.Message,
+	}
+	return body
+}
+
 // NewUpgradeInstanceTemplatesTemplateNotFoundResponseBody builds the HTTP
 // response body from the result of the "UpgradeInstance" endpoint of the
 // "templates" service.
@@ -1974,6 +2749,162 @@ func NewUpgradeInstanceValidateInputsTemplatesVersionNotFoundResponseBody(res *t
 	return body
 }
 
+// NewInstanceHistoryIndexTemplatesBranchNotFoundResponseBody builds the HTTP
+// response body from the result of the "InstanceHistoryIndex" endpoint of the
+// "templates" service.
+func NewInstanceHistoryIndexTemplatesBranchNotFoundResponseBody(res *templates.GenericError) *InstanceHistoryIndexTemplatesBranchNotFoundResponseBody {
+	body := &InstanceHistoryIndexTemplatesBranchNotFoundResponseBody{
+		StatusCode: res.StatusCode,
+		Name:       res.Name,
+		Message:    res.Message,
+	}
+	return body
+}
+
+// NewInstanceHistoryIndexTemplatesInstanceNotFoundResponseBody builds the HTTP
+// response body from the result of the "InstanceHistoryIndex" endpoint of the
+// "templates" service.
+func NewInstanceHistoryIndexTemplatesInstanceNotFoundResponseBody(res *templates.GenericError) *InstanceHistoryIndexTemplatesInstanceNotFoundResponseBody {
+	body := &InstanceHistoryIndexTemplatesInstanceNotFoundResponseBody{
+		StatusCode: res.StatusCode,
+		Name:       res.Name,
+		Message:    res.Message,
+	}
+	return body
+}
+
+// NewRollbackInstancePreviewTemplatesTemplateNotFoundResponseBody builds the
+// HTTP response body from the result of the "RollbackInstancePreview" endpoint
+// of the "templates" service.
+func NewRollbackInstancePreviewTemplatesTemplateNotFoundResponseBody(res *templates.GenericError) *RollbackInstancePreviewTemplatesTemplateNotFoundResponseBody {
+	body := &RollbackInstancePreviewTemplatesTemplateNotFoundResponseBody{
+		StatusCode: res.StatusCode,
+		Name:       res.Name,
+		Message:    res.Message,
+	}
+	return body
+}
+
+// NewRollbackInstancePreviewTemplatesBranchNotFoundResponseBody builds the
+// HTTP response body from the result of the "RollbackInstancePreview" endpoint
+// of the "templates" service.
+func NewRollbackInstancePreviewTemplatesBranchNotFoundResponseBody(res *templates.GenericError) *RollbackInstancePreviewTemplatesBranchNotFoundResponseBody {
+	body := &RollbackInstancePreviewTemplatesBranchNotFoundResponseBody{
+		StatusCode: res.StatusCode,
+		Name:       res.Name,
+		Message:    res.Message,
+	}
+	return body
+}
+
+// NewRollbackInstancePreviewTemplatesInstanceNotFoundResponseBody builds the
+// HTTP response body from the result of the "RollbackInstancePreview" endpoint
+// of the "templates" service.
+func NewRollbackInstancePreviewTemplatesInstanceNotFoundResponseBody(res *templates.GenericError) *RollbackInstancePreviewTemplatesInstanceNotFoundResponseBody {
+	body := &RollbackInstancePreviewTemplatesInstanceNotFoundResponseBody{
+		StatusCode: res.StatusCode,
+		Name:       res.Name,
+		Message:    res.Message,
+	}
+	return body
+}
+
+// NewRollbackInstancePreviewTemplatesVersionNotFoundResponseBody builds the
+// HTTP response body from the result of the "RollbackInstancePreview" endpoint
+// of the "templates" service.
+func NewRollbackInstancePreviewTemplatesVersionNotFoundResponseBody(res *templates.GenericError) *RollbackInstancePreviewTemplatesVersionNotFoundResponseBody {
+	body := &RollbackInstancePreviewTemplatesVersionNotFoundResponseBody{
+		StatusCode: res.StatusCode,
+		Name:       res.Name,
+		Message:    res.Message,
+	}
+	return body
+}
+
+// NewRollbackInstancePreviewTemplatesRollbackNotAvailableResponseBody builds
+// the HTTP response body from the result of the "RollbackInstancePreview"
+// endpoint of the "templates" service.
+func NewRollbackInstancePreviewTemplatesRollbackNotAvailableResponseBody(res *templates.GenericError) *RollbackInstancePreviewTemplatesRollbackNotAvailableResponseBody {
+	body := &RollbackInstancePreviewTemplatesRollbackNotAvailableResponseBody{
+		StatusCode: res.StatusCode,
+		Name:       res.Name,
+		Message:    res.Message,
+	}
+	return body
+}
+
+// NewRollbackInstanceTemplatesTemplateNotFoundResponseBody builds the HTTP
+// response body from the result of the "RollbackInstance" endpoint of the
+// "templates" service.
+func NewRollbackInstanceTemplatesTemplateNotFoundResponseBody(res *templates.GenericError) *RollbackInstanceTemplatesTemplateNotFoundResponseBody {
+	body := &RollbackInstanceTemplatesTemplateNotFoundResponseBody{
+		StatusCode: res.StatusCode,
+		Name:       res.Name,
+		Message:    res.Message,
+	}
+	return body
+}
+
+// NewRollbackInstanceTemplatesBranchNotFoundResponseBody builds the HTTP
+// response body from the result of the "RollbackInstance" endpoint of the
+// "templates" service.
+func NewRollbackInstanceTemplatesBranchNotFoundResponseBody(res *templates.GenericError) *RollbackInstanceTemplatesBranchNotFoundResponseBody {
+	body := &RollbackInstanceTemplatesBranchNotFoundResponseBody{
+		StatusCode: res.StatusCode,
+		Name:       res.Name,
+		Message:    res.Message,
+	}
+	return body
+}
+
+// NewRollbackInstanceTemplatesInstanceNotFoundResponseBody builds the HTTP
+// response body from the result of the "RollbackInstance" endpoint of the
+// "templates" service.
+func NewRollbackInstanceTemplatesInstanceNotFoundResponseBody(res *templates.GenericError) *RollbackInstanceTemplatesInstanceNotFoundResponseBody {
+	body := &RollbackInstanceTemplatesInstanceNotFoundResponseBody{
+		StatusCode: res.StatusCode,
+		Name:       res.Name,
+		Message:    res.Message,
+	}
+	return body
+}
+
+// NewRollbackInstanceTemplatesVersionNotFoundResponseBody builds the HTTP
+// response body from the result of the "RollbackInstance" endpoint of the
+// "templates" service.
+func NewRollbackInstanceTemplatesVersionNotFoundResponseBody(res *templates.GenericError) *RollbackInstanceTemplatesVersionNotFoundResponseBody {
+	body := &RollbackInstanceTemplatesVersionNotFoundResponseBody{
+		StatusCode: res.StatusCode,
+		Name:       res.Name,
+		Message:    res.Message,
+	}
+	return body
+}
+
+// NewRollbackInstanceTemplatesRollbackNotAvailableResponseBody builds the HTTP
+// response body from the result of the "RollbackInstance" endpoint of the
+// "templates" service.
+func NewRollbackInstanceTemplatesRollbackNotAvailableResponseBody(res *templates.GenericError) *RollbackInstanceTemplatesRollbackNotAvailableResponseBody {
+	body := &RollbackInstanceTemplatesRollbackNotAvailableResponseBody{
+		StatusCode: res.StatusCode,
+		Name:       res.Name,
+		Message:    res.Message,
+	}
+	return body
+}
+
+// NewRollbackInstanceTemplatesProjectLockedResponseBody builds the HTTP
+// response body from the result of the "RollbackInstance" endpoint of the
+// "templates" service.
+func NewRollbackInstanceTemplatesProjectLockedResponseBody(res *templates.ProjectLockedError) *RollbackInstanceTemplatesProjectLockedResponseBody {
+	body := &RollbackInstanceTemplatesProjectLockedResponseBody{
+		StatusCode: res.StatusCode,
+		Name:       res.Name,
+		Message:    res.Message,
+	}
+	return body
+}
+
 // NewGetTaskTemplatesTaskNotFoundResponseBody builds the HTTP response body
 // from the result of the "GetTask" endpoint of the "templates" service.
 func NewGetTaskTemplatesTaskNotFoundResponseBody(res *templates.GenericError) *GetTaskTemplatesTaskNotFoundResponseBody {
@@ -1985,6 +2916,28 @@ func NewGetTaskTemplatesTaskNotFoundResponseBody(res *templates.GenericError) *G
 	return body
 }
 
+// NewCancelTaskTemplatesTaskNotFoundResponseBody builds the HTTP response body
+// from the result of the "CancelTask" endpoint of the "templates" service.
+func NewCancelTaskTemplatesTaskNotFoundResponseBody(res *templates.GenericError) *CancelTaskTemplatesTaskNotFoundResponseBody {
+	body := &CancelTaskTemplatesTaskNotFoundResponseBody{
+		StatusCode: res.StatusCode,
+		Name:       res.Name,
+		Message:    res.Message,
+	}
+	return body
+}
+
+// NewCancelTaskTemplatesTaskFinishedResponseBody builds the HTTP response body
+// from the result of the "CancelTask" endpoint of the "templates" service.
+func NewCancelTaskTemplatesTaskFinishedResponseBody(res *templates.GenericError) *CancelTaskTemplatesTaskFinishedResponseBody {
+	body := &CancelTaskTemplatesTaskFinishedResponseBody{
+		StatusCode: res.StatusCode,
+		Name:       res.Name,
+		Message:    res.Message,
+	}
+	return body
+}
+
 // NewRepositoriesIndexPayload builds a templates service RepositoriesIndex
 // endpoint payload.
 func NewRepositoriesIndexPayload(storageAPIToken string) *templates.RepositoriesIndexPayload {
@@ -2004,6 +2957,26 @@ func NewRepositoryIndexPayload(repository string, storageAPIToken string) *templ
 	return v
 }
 
+// NewRefreshRepositoryPayload builds a templates service RefreshRepository
+// endpoint payload.
+func NewRefreshRepositoryPayload(repository string, storageAPIToken string) *templates.RefreshRepositoryPayload {
+	v := &templates.RefreshRepositoryPayload{}
+	v.Repository = repository
+	v.StorageAPIToken = storageAPIToken
+
+	return v
+}
+
+// NewRepositoryWebhookRequest builds a templates service RepositoryWebhook
+// endpoint payload.
+func NewRepositoryWebhookRequest(repository string, signature *string) *templates.RepositoryWebhookRequest {
+	v := &templates.RepositoryWebhookRequest{}
+	v.Repository = repository
+	v.Signature = signature
+
+	return v
+}
+
 // NewTemplatesIndexPayload builds a templates service TemplatesIndex endpoint
 // payload.
 func NewTemplatesIndexPayload(repository string, storageAPIToken string) *templates.TemplatesIndexPayload {
@@ -2039,11 +3012,12 @@ func NewVersionIndexPayload(repository string, template string, version string,
 
 // NewInputsIndexPayload builds a templates service InputsIndex endpoint
 // payload.
-func NewInputsIndexPayload(repository string, template string, version string, storageAPIToken string) *templates.InputsIndexPayload {
+func NewInputsIndexPayload(repository string, template string, version string, format string, storageAPIToken string) *templates.InputsIndexPayload {
 	v := &templates.InputsIndexPayload{}
 	v.Repository = repository
 	v.Template = templates.TemplateID(template)
 	v.Version = version
+	v.Format = format
 	v.StorageAPIToken = storageAPIToken
 
 	return v
@@ -2084,6 +3058,17 @@ func NewUseTemplateVersionPayload(body *UseTemplateVersionRequestBody, repositor
 	return v
 }
 
+// NewInputLookupPayload builds a templates service InputLookup endpoint
+// payload.
+func NewInputLookupPayload(branch string, kind string, storageAPIToken string) *templates.InputLookupPayload {
+	v := &templates.InputLookupPayload{}
+	v.Branch = branch
+	v.Kind = kind
+	v.StorageAPIToken = storageAPIToken
+
+	return v
+}
+
 // NewInstancesIndexPayload builds a templates service InstancesIndex endpoint
 // payload.
 func NewInstancesIndexPayload(branch string, storageAPIToken string) *templates.InstancesIndexPayload {
@@ -2120,10 +3105,23 @@ func NewUpdateInstancePayload(body *UpdateInstanceRequestBody, branch string, in
 
 // NewDeleteInstancePayload builds a templates service DeleteInstance endpoint
 // payload.
-func NewDeleteInstancePayload(branch string, instanceID string, storageAPIToken string) *templates.DeleteInstancePayload {
+func NewDeleteInstancePayload(branch string, instanceID string, keepConfigs []string, storageAPIToken string) *templates.DeleteInstancePayload {
 	v := &templates.DeleteInstancePayload{}
 	v.Branch = branch
 	v.InstanceID = templates.InstanceID(instanceID)
+	v.KeepConfigs = keepConfigs
+	v.StorageAPIToken = storageAPIToken
+
+	return v
+}
+
+// NewDeleteInstancePreviewPayload builds a templates service
+// DeleteInstancePreview endpoint payload.
+func NewDeleteInstancePreviewPayload(branch string, instanceID string, keepConfigs []string, storageAPIToken string) *templates.DeleteInstancePreviewPayload {
+	v := &templates.DeleteInstancePreviewPayload{}
+	v.Branch = branch
+	v.InstanceID = templates.InstanceID(instanceID)
+	v.KeepConfigs = keepConfigs
 	v.StorageAPIToken = storageAPIToken
 
 	return v
@@ -2173,6 +3171,49 @@ func NewUpgradeInstanceValidateInputsPayload(body *UpgradeInstanceValidateInputs
 	return v
 }
 
+// NewInstanceHistoryIndexPayload builds a templates service
+// InstanceHistoryIndex endpoint payload.
+func NewInstanceHistoryIndexPayload(branch string, instanceID string, storageAPIToken string) *templates.InstanceHistoryIndexPayload {
+	v := &templates.InstanceHistoryIndexPayload{}
+	v.Branch = branch
+	v.InstanceID = templates.InstanceID(instanceID)
+	v.StorageAPIToken = storageAPIToken
+
+	return v
+}
+
+// NewRollbackInstancePreviewPayload builds a templates service
+// RollbackInstancePreview endpoint payload.
+func NewRollbackInstancePreviewPayload(branch string, instanceID string, storageAPIToken string) *templates.RollbackInstancePreviewPayload {
+	v := &templates.RollbackInstancePreviewPayload{}
+	v.Branch = branch
+	v.InstanceID = templates.InstanceID(instanceID)
+	v.StorageAPIToken = storageAPIToken
+
+	return v
+}
+
+// NewRollbackInstancePayload builds a templates service RollbackInstance
+// endpoint payload.
+func NewRollbackInstancePayload(branch string, instanceID string, storageAPIToken string) *templates.RollbackInstancePayload {
+	v := &templates.RollbackInstancePayload{}
+	v.Branch = branch
+	v.InstanceID = templates.InstanceID(instanceID)
+	v.StorageAPIToken = storageAPIToken
+
+	return v
+}
+
+// NewListAuditLogPayload builds a templates service ListAuditLog endpoint
+// payload.
+func NewListAuditLogPayload(limit int, storageAPIToken string) *templates.ListAuditLogPayload {
+	v := &templates.ListAuditLogPayload{}
+	v.Limit = limit
+	v.StorageAPIToken = storageAPIToken
+
+	return v
+}
+
 // NewGetTaskPayload builds a templates service GetTask endpoint payload.
 func NewGetTaskPayload(taskID string, storageAPIToken string) *templates.GetTaskPayload {
 	v := &templates.GetTaskPayload{}
@@ -2182,6 +3223,15 @@ func NewGetTaskPayload(taskID string, storageAPIToken string) *templates.GetTask
 	return v
 }
 
+// NewCancelTaskPayload builds a templates service CancelTask endpoint payload.
+func NewCancelTaskPayload(taskID string, storageAPIToken string) *templates.CancelTaskPayload {
+	v := &templates.CancelTaskPayload{}
+	v.TaskID = templates.TaskID(taskID)
+	v.StorageAPIToken = storageAPIToken
+
+	return v
+}
+
 // ValidateValidateInputsRequestBody runs the validations defined on
 // ValidateInputsRequestBody
 func ValidateValidateInputsRequestBody(body *ValidateInputsRequestBody, errContext []string) (err error) {
@@ -10,6 +10,7 @@ package templates
 
 import (
 	"context"
+	"io"
 
 	goa "goa.design/goa/v3/pkg"
 )
@@ -21,44 +22,62 @@ type Client struct {
 	HealthCheckEndpoint                   goa.Endpoint
 	RepositoriesIndexEndpoint             goa.Endpoint
 	RepositoryIndexEndpoint               goa.Endpoint
+	RefreshRepositoryEndpoint             goa.Endpoint
+	RepositoryWebhookEndpoint             goa.Endpoint
 	TemplatesIndexEndpoint                goa.Endpoint
 	TemplateIndexEndpoint                 goa.Endpoint
 	VersionIndexEndpoint                  goa.Endpoint
 	InputsIndexEndpoint                   goa.Endpoint
 	ValidateInputsEndpoint                goa.Endpoint
 	UseTemplateVersionEndpoint            goa.Endpoint
+	InputLookupEndpoint                   goa.Endpoint
 	InstancesIndexEndpoint                goa.Endpoint
 	InstanceIndexEndpoint                 goa.Endpoint
 	UpdateInstanceEndpoint                goa.Endpoint
 	DeleteInstanceEndpoint                goa.Endpoint
+	DeleteInstancePreviewEndpoint         goa.Endpoint
 	UpgradeInstanceEndpoint               goa.Endpoint
 	UpgradeInstanceInputsIndexEndpoint    goa.Endpoint
 	UpgradeInstanceValidateInputsEndpoint goa.Endpoint
+	InstanceHistoryIndexEndpoint          goa.Endpoint
+	RollbackInstancePreviewEndpoint       goa.Endpoint
+	RollbackInstanceEndpoint              goa.Endpoint
+	ListAuditLogEndpoint                  goa.Endpoint
 	GetTaskEndpoint                       goa.Endpoint
+	CancelTaskEndpoint                    goa.Endpoint
 }
 
 // NewClient initializes a "templates" service client given the endpoints.
-func NewClient(aPIRootIndex, aPIVersionIndex, healthCheck, repositoriesIndex, repositoryIndex, templatesIndex, templateIndex, versionIndex, inputsIndex, validateInputs, useTemplateVersion, instancesIndex, instanceIndex, updateInstance, deleteInstance, upgradeInstance, upgradeInstanceInputsIndex, upgradeInstanceValidateInputs, getTask goa.Endpoint) *Client {
+func NewClient(aPIRootIndex, aPIVersionIndex, healthCheck, repositoriesIndex, repositoryIndex, refreshRepository, repositoryWebhook, templatesIndex, templateIndex, versionIndex, inputsIndex, validateInputs, useTemplateVersion, inputLookup, instancesIndex, instanceIndex, updateInstance, deleteInstance, deleteInstancePreview, upgradeInstance, upgradeInstanceInputsIndex, upgradeInstanceValidateInputs, instanceHistoryIndex, rollbackInstancePreview, rollbackInstance, listAuditLog, getTask, cancelTask goa.Endpoint) *Client {
 	return &Client{
 		APIRootIndexEndpoint:                  aPIRootIndex,
 		APIVersionIndexEndpoint:               aPIVersionIndex,
 		HealthCheckEndpoint:                   healthCheck,
 		RepositoriesIndexEndpoint:             repositoriesIndex,
 		RepositoryIndexEndpoint:               repositoryIndex,
+		RefreshRepositoryEndpoint:             refreshRepository,
+		RepositoryWebhookEndpoint:             repositoryWebhook,
 		TemplatesIndexEndpoint:                templatesIndex,
 		TemplateIndexEndpoint:                 templateIndex,
 		VersionIndexEndpoint:                  versionIndex,
 		InputsIndexEndpoint:                   inputsIndex,
 		ValidateInputsEndpoint:                validateInputs,
 		UseTemplateVersionEndpoint:            useTemplateVersion,
+		InputLookupEndpoint:                   inputLookup,
 		InstancesIndexEndpoint:                instancesIndex,
 		InstanceIndexEndpoint:                 instanceIndex,
 		UpdateInstanceEndpoint:                updateInstance,
 		DeleteInstanceEndpoint:                deleteInstance,
+		DeleteInstancePreviewEndpoint:         deleteInstancePreview,
 		UpgradeInstanceEndpoint:               upgradeInstance,
 		UpgradeInstanceInputsIndexEndpoint:    upgradeInstanceInputsIndex,
 		UpgradeInstanceValidateInputsEndpoint: upgradeInstanceValidateInputs,
+		InstanceHistoryIndexEndpoint:          instanceHistoryIndex,
+		RollbackInstancePreviewEndpoint:       rollbackInstancePreview,
+		RollbackInstanceEndpoint:              rollbackInstance,
+		ListAuditLogEndpoint:                  listAuditLog,
 		GetTaskEndpoint:                       getTask,
+		CancelTaskEndpoint:                    cancelTask,
 	}
 }
 
@@ -114,6 +133,30 @@ func (c *Client) RepositoryIndex(ctx context.Context, p *RepositoryIndexPayload)
 	return ires.(*Repository), nil
 }
 
+// RefreshRepository calls the "RefreshRepository" endpoint of the "templates"
+// service.
+// RefreshRepository may return the following errors:
+//   - "templates.repositoryNotFound" (type *GenericError): Repository not found error.
+//   - error: internal error
+func (c *Client) RefreshRepository(ctx context.Context, p *RefreshRepositoryPayload) (res *Task, err error) {
+	var ires any
+	ires, err = c.RefreshRepositoryEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*Task), nil
+}
+
+// RepositoryWebhook calls the "RepositoryWebhook" endpoint of the "templates"
+// service.
+// RepositoryWebhook may return the following errors:
+//   - "templates.repositoryNotFound" (type *GenericError): Repository not found error.
+//   - error: internal error
+func (c *Client) RepositoryWebhook(ctx context.Context, p *RepositoryWebhookRequest, req io.ReadCloser) (err error) {
+	_, err = c.RepositoryWebhookEndpoint(ctx, &RepositoryWebhookRequestData{Payload: p, Body: req})
+	return
+}
+
 // TemplatesIndex calls the "TemplatesIndex" endpoint of the "templates"
 // service.
 // TemplatesIndex may return the following errors:
@@ -206,6 +249,19 @@ func (c *Client) UseTemplateVersion(ctx context.Context, p *UseTemplateVersionPa
 	return ires.(*Task), nil
 }
 
+// InputLookup calls the "InputLookup" endpoint of the "templates" service.
+// InputLookup may return the following errors:
+//   - "templates.branchNotFound" (type *GenericError): Branch not found error.
+//   - error: internal error
+func (c *Client) InputLookup(ctx context.Context, p *InputLookupPayload) (res *InputLookupResult, err error) {
+	var ires any
+	ires, err = c.InputLookupEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*InputLookupResult), nil
+}
+
 // InstancesIndex calls the "InstancesIndex" endpoint of the "templates"
 // service.
 // InstancesIndex may return the following errors:
@@ -267,6 +323,21 @@ func (c *Client) DeleteInstance(ctx context.Context, p *DeleteInstancePayload) (
 	return ires.(*Task), nil
 }
 
+// DeleteInstancePreview calls the "DeleteInstancePreview" endpoint of the
+// "templates" service.
+// DeleteInstancePreview may return the following errors:
+//   - "templates.branchNotFound" (type *GenericError): Branch not found error.
+//   - "templates.instanceNotFound" (type *GenericError): Instance not found error.
+//   - error: internal error
+func (c *Client) DeleteInstancePreview(ctx context.Context, p *DeleteInstancePreviewPayload) (res *InstanceDeletePreview, err error) {
+	var ires any
+	ires, err = c.DeleteInstancePreviewEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*InstanceDeletePreview), nil
+}
+
 // UpgradeInstance calls the "UpgradeInstance" endpoint of the "templates"
 // service.
 // UpgradeInstance may return the following errors:
@@ -320,6 +391,68 @@ func (c *Client) UpgradeInstanceValidateInputs(ctx context.Context, p *UpgradeIn
 	return ires.(*ValidationResult), nil
 }
 
+// InstanceHistoryIndex calls the "InstanceHistoryIndex" endpoint of the
+// "templates" service.
+// InstanceHistoryIndex may return the following errors:
+//   - "templates.branchNotFound" (type *GenericError): Branch not found error.
+//   - "templates.instanceNotFound" (type *GenericError): Instance not found error.
+//   - error: internal error
+func (c *Client) InstanceHistoryIndex(ctx context.Context, p *InstanceHistoryIndexPayload) (res *InstanceHistory, err error) {
+	var ires any
+	ires, err = c.InstanceHistoryIndexEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*InstanceHistory), nil
+}
+
+// RollbackInstancePreview calls the "RollbackInstancePreview" endpoint of the
+// "templates" service.
+// RollbackInstancePreview may return the following errors:
+//   - "templates.templateNotFound" (type *GenericError): Template not found error.
+//   - "templates.branchNotFound" (type *GenericError): Branch not found error.
+//   - "templates.instanceNotFound" (type *GenericError): Instance not found error.
+//   - "templates.versionNotFound" (type *GenericError): Version not found error.
+//   - "templates.rollbackNotAvailable" (type *GenericError): Rollback not available error.
+//   - error: internal error
+func (c *Client) RollbackInstancePreview(ctx context.Context, p *RollbackInstancePreviewPayload) (res *InstanceRollbackPreview, err error) {
+	var ires any
+	ires, err = c.RollbackInstancePreviewEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*InstanceRollbackPreview), nil
+}
+
+// RollbackInstance calls the "RollbackInstance" endpoint of the "templates"
+// service.
+// RollbackInstance may return the following errors:
+//   - "templates.templateNotFound" (type *GenericError): Template not found error.
+//   - "templates.branchNotFound" (type *GenericError): Branch not found error.
+//   - "templates.instanceNotFound" (type *GenericError): Instance not found error.
+//   - "templates.versionNotFound" (type *GenericError): Version not found error.
+//   - "templates.rollbackNotAvailable" (type *GenericError): Rollback not available error.
+//   - "templates.projectLocked" (type *ProjectLockedError): Access to branch metadata must be atomic, so only one write operation can run at a time. If this error occurs, the client should make retries, see Retry-After header.
+//   - error: internal error
+func (c *Client) RollbackInstance(ctx context.Context, p *RollbackInstancePayload) (res *Task, err error) {
+	var ires any
+	ires, err = c.RollbackInstanceEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*Task), nil
+}
+
+// ListAuditLog calls the "ListAuditLog" endpoint of the "templates" service.
+func (c *Client) ListAuditLog(ctx context.Context, p *ListAuditLogPayload) (res *AuditLog, err error) {
+	var ires any
+	ires, err = c.ListAuditLogEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*AuditLog), nil
+}
+
 // GetTask calls the "GetTask" endpoint of the "templates" service.
 // GetTask may return the following errors:
 //   - "templates.taskNotFound" (type *GenericError): Task not found error.
@@ -332,3 +465,17 @@ func (c *Client) GetTask(ctx context.Context, p *GetTaskPayload) (res *Task, err
 	}
 	return ires.(*Task), nil
 }
+
+// CancelTask calls the "CancelTask" endpoint of the "templates" service.
+// CancelTask may return the following errors:
+//   - "templates.taskNotFound" (type *GenericError): Task not found error.
+//   - "templates.taskFinished" (type *GenericError): Task already finished error.
+//   - error: internal error
+func (c *Client) CancelTask(ctx context.Context, p *CancelTaskPayload) (res *Task, err error) {
+	var ires any
+	ires, err = c.CancelTaskEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*Task), nil
+}
@@ -10,6 +10,7 @@ package templates
 
 import (
 	"context"
+	"io"
 
 	dependencies "github.com/keboola/keboola-as-code/internal/pkg/service/templates/dependencies"
 	goa "goa.design/goa/v3/pkg"
@@ -23,20 +24,38 @@ type Endpoints struct {
 	HealthCheck                   goa.Endpoint
 	RepositoriesIndex             goa.Endpoint
 	RepositoryIndex               goa.Endpoint
+	RefreshRepository             goa.Endpoint
+	RepositoryWebhook             goa.Endpoint
 	TemplatesIndex                goa.Endpoint
 	TemplateIndex                 goa.Endpoint
 	VersionIndex                  goa.Endpoint
 	InputsIndex                   goa.Endpoint
 	ValidateInputs                goa.Endpoint
 	UseTemplateVersion            goa.Endpoint
+	InputLookup                   goa.Endpoint
 	InstancesIndex                goa.Endpoint
 	InstanceIndex                 goa.Endpoint
 	UpdateInstance                goa.Endpoint
 	DeleteInstance                goa.Endpoint
+	DeleteInstancePreview         goa.Endpoint
 	UpgradeInstance               goa.Endpoint
 	UpgradeInstanceInputsIndex    goa.Endpoint
 	UpgradeInstanceValidateInputs goa.Endpoint
+	InstanceHistoryIndex          goa.Endpoint
+	RollbackInstancePreview       goa.Endpoint
+	RollbackInstance              goa.Endpoint
+	ListAuditLog                  goa.Endpoint
 	GetTask                       goa.Endpoint
+	CancelTask                    goa.Endpoint
+}
+
+// RepositoryWebhookRequestData holds both the payload and the HTTP request
+// body reader of the "RepositoryWebhook" method.
+type RepositoryWebhookRequestData struct {
+	// Payload is the method payload.
+	Payload *RepositoryWebhookRequest
+	// Body streams the HTTP request body.
+	Body io.ReadCloser
 }
 
 // NewEndpoints wraps the methods of the "templates" service with endpoints.
@@ -49,20 +68,29 @@ func NewEndpoints(s Service) *Endpoints {
 		HealthCheck:                   NewHealthCheckEndpoint(s),
 		RepositoriesIndex:             NewRepositoriesIndexEndpoint(s, a.APIKeyAuth),
 		RepositoryIndex:               NewRepositoryIndexEndpoint(s, a.APIKeyAuth),
+		RefreshRepository:             NewRefreshRepositoryEndpoint(s, a.APIKeyAuth),
+		RepositoryWebhook:             NewRepositoryWebhookEndpoint(s),
 		TemplatesIndex:                NewTemplatesIndexEndpoint(s, a.APIKeyAuth),
 		TemplateIndex:                 NewTemplateIndexEndpoint(s, a.APIKeyAuth),
 		VersionIndex:                  NewVersionIndexEndpoint(s, a.APIKeyAuth),
 		InputsIndex:                   NewInputsIndexEndpoint(s, a.APIKeyAuth),
 		ValidateInputs:                NewValidateInputsEndpoint(s, a.APIKeyAuth),
 		UseTemplateVersion:            NewUseTemplateVersionEndpoint(s, a.APIKeyAuth),
+		InputLookup:                   NewInputLookupEndpoint(s, a.APIKeyAuth),
 		InstancesIndex:                NewInstancesIndexEndpoint(s, a.APIKeyAuth),
 		InstanceIndex:                 NewInstanceIndexEndpoint(s, a.APIKeyAuth),
 		UpdateInstance:                NewUpdateInstanceEndpoint(s, a.APIKeyAuth),
 		DeleteInstance:                NewDeleteInstanceEndpoint(s, a.APIKeyAuth),
+		DeleteInstancePreview:         NewDeleteInstancePreviewEndpoint(s, a.APIKeyAuth),
 		UpgradeInstance:               NewUpgradeInstanceEndpoint(s, a.APIKeyAuth),
 		UpgradeInstanceInputsIndex:    NewUpgradeInstanceInputsIndexEndpoint(s, a.APIKeyAuth),
 		UpgradeInstanceValidateInputs: NewUpgradeInstanceValidateInputsEndpoint(s, a.APIKeyAuth),
+		InstanceHistoryIndex:          NewInstanceHistoryIndexEndpoint(s, a.APIKeyAuth),
+		RollbackInstancePreview:       NewRollbackInstancePreviewEndpoint(s, a.APIKeyAuth),
+		RollbackInstance:              NewRollbackInstanceEndpoint(s, a.APIKeyAuth),
+		ListAuditLog:                  NewListAuditLogEndpoint(s, a.APIKeyAuth),
 		GetTask:                       NewGetTaskEndpoint(s, a.APIKeyAuth),
+		CancelTask:                    NewCancelTaskEndpoint(s, a.APIKeyAuth),
 	}
 }
 
@@ -73,20 +101,29 @@ func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
 	e.HealthCheck = m(e.HealthCheck)
 	e.RepositoriesIndex = m(e.RepositoriesIndex)
 	e.RepositoryIndex = m(e.RepositoryIndex)
+	e.RefreshRepository = m(e.RefreshRepository)
+	e.RepositoryWebhook = m(e.RepositoryWebhook)
 	e.TemplatesIndex = m(e.TemplatesIndex)
 	e.TemplateIndex = m(e.TemplateIndex)
 	e.VersionIndex = m(e.VersionIndex)
 	e.InputsIndex = m(e.InputsIndex)
 	e.ValidateInputs = m(e.ValidateInputs)
 	e.UseTemplateVersion = m(e.UseTemplateVersion)
+	e.InputLookup = m(e.InputLookup)
 	e.InstancesIndex = m(e.InstancesIndex)
 	e.InstanceIndex = m(e.InstanceIndex)
 	e.UpdateInstance = m(e.UpdateInstance)
 	e.DeleteInstance = m(e.DeleteInstance)
+	e.DeleteInstancePreview = m(e.DeleteInstancePreview)
 	e.UpgradeInstance = m(e.UpgradeInstance)
 	e.UpgradeInstanceInputsIndex = m(e.UpgradeInstanceInputsIndex)
 	e.UpgradeInstanceValidateInputs = m(e.UpgradeInstanceValidateInputs)
+	e.InstanceHistoryIndex = m(e.InstanceHistoryIndex)
+	e.RollbackInstancePreview = m(e.RollbackInstancePreview)
+	e.RollbackInstance = m(e.RollbackInstance)
+	e.ListAuditLog = m(e.ListAuditLog)
 	e.GetTask = m(e.GetTask)
+	e.CancelTask = m(e.CancelTask)
 }
 
 // NewAPIRootIndexEndpoint returns an endpoint function that calls the method
@@ -156,6 +193,36 @@ func NewRepositoryIndexEndpoint(s Service, authAPIKeyFn security.AuthAPIKeyFunc)
 	}
 }
 
+// NewRefreshRepositoryEndpoint returns an endpoint function that calls the
+// method "RefreshRepository" of service "templates".
+func NewRefreshRepositoryEndpoint(s Service, authAPIKeyFn security.AuthAPIKeyFunc) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*RefreshRepositoryPayload)
+		var err error
+		sc := security.APIKeyScheme{
+			Name:           "storage-api-token",
+			Scopes:         []string{},
+			RequiredScopes: []string{},
+		}
+		ctx, err = authAPIKeyFn(ctx, p.StorageAPIToken, &sc)
+		if err != nil {
+			return nil, err
+		}
+		deps := ctx.Value(dependencies.ProjectRequestScopeCtxKey).(dependencies.ProjectRequestScope)
+		return s.RefreshRepository(ctx, deps, p)
+	}
+}
+
+// NewRepositoryWebhookEndpoint returns an endpoint function that calls the
+// method "RepositoryWebhook" of service "templates".
+func NewRepositoryWebhookEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		ep := req.(*RepositoryWebhookRequestData)
+		deps := ctx.Value(dependencies.PublicRequestScopeCtxKey).(dependencies.PublicRequestScope)
+		return nil, s.RepositoryWebhook(ctx, deps, ep.Payload, ep.Body)
+	}
+}
+
 // NewTemplatesIndexEndpoint returns an endpoint function that calls the method
 // "TemplatesIndex" of service "templates".
 func NewTemplatesIndexEndpoint(s Service, authAPIKeyFn security.AuthAPIKeyFunc) goa.Endpoint {
@@ -276,6 +343,26 @@ func NewUseTemplateVersionEndpoint(s Service, authAPIKeyFn security.AuthAPIKeyFu
 	}
 }
 
+// NewInputLookupEndpoint returns an endpoint function that calls the method
+// "InputLookup" of service "templates".
+func NewInputLookupEndpoint(s Service, authAPIKeyFn security.AuthAPIKeyFunc) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*InputLookupPayload)
+		var err error
+		sc := security.APIKeyScheme{
+			Name:           "storage-api-token",
+			Scopes:         []string{},
+			RequiredScopes: []string{},
+		}
+		ctx, err = authAPIKeyFn(ctx, p.StorageAPIToken, &sc)
+		if err != nil {
+			return nil, err
+		}
+		deps := ctx.Value(dependencies.ProjectRequestScopeCtxKey).(dependencies.ProjectRequestScope)
+		return s.InputLookup(ctx, deps, p)
+	}
+}
+
 // NewInstancesIndexEndpoint returns an endpoint function that calls the method
 // "InstancesIndex" of service "templates".
 func NewInstancesIndexEndpoint(s Service, authAPIKeyFn security.AuthAPIKeyFunc) goa.Endpoint {
@@ -356,6 +443,26 @@ func NewDeleteInstanceEndpoint(s Service, authAPIKeyFn security.AuthAPIKeyFunc)
 	}
 }
 
+// NewDeleteInstancePreviewEndpoint returns an endpoint function that calls the
+// method "DeleteInstancePreview" of service "templates".
+func NewDeleteInstancePreviewEndpoint(s Service, authAPIKeyFn security.AuthAPIKeyFunc) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*DeleteInstancePreviewPayload)
+		var err error
+		sc := security.APIKeyScheme{
+			Name:           "storage-api-token",
+			Scopes:         []string{},
+			RequiredScopes: []string{},
+		}
+		ctx, err = authAPIKeyFn(ctx, p.StorageAPIToken, &sc)
+		if err != nil {
+			return nil, err
+		}
+		deps := ctx.Value(dependencies.ProjectRequestScopeCtxKey).(dependencies.ProjectRequestScope)
+		return s.DeleteInstancePreview(ctx, deps, p)
+	}
+}
+
 // NewUpgradeInstanceEndpoint returns an endpoint function that calls the
 // method "UpgradeInstance" of service "templates".
 func NewUpgradeInstanceEndpoint(s Service, authAPIKeyFn security.AuthAPIKeyFunc) goa.Endpoint {
@@ -416,6 +523,86 @@ func NewUpgradeInstanceValidateInputsEndpoint(s Service, authAPIKeyFn security.A
 	}
 }
 
+// NewInstanceHistoryIndexEndpoint returns an endpoint function that calls the
+// method "InstanceHistoryIndex" of service "templates".
+func NewInstanceHistoryIndexEndpoint(s Service, authAPIKeyFn security.AuthAPIKeyFunc) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*InstanceHistoryIndexPayload)
+		var err error
+		sc := security.APIKeyScheme{
+			Name:           "storage-api-token",
+			Scopes:         []string{},
+			RequiredScopes: []string{},
+		}
+		ctx, err = authAPIKeyFn(ctx, p.StorageAPIToken, &sc)
+		if err != nil {
+			return nil, err
+		}
+		deps := ctx.Value(dependencies.ProjectRequestScopeCtxKey).(dependencies.ProjectRequestScope)
+		return s.InstanceHistoryIndex(ctx, deps, p)
+	}
+}
+
+// NewRollbackInstancePreviewEndpoint returns an endpoint function that calls
+// the method "RollbackInstancePreview" of service "templates".
+func NewRollbackInstancePreviewEndpoint(s Service, authAPIKeyFn security.AuthAPIKeyFunc) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*RollbackInstancePreviewPayload)
+		var err error
+		sc := security.APIKeyScheme{
+			Name:           "storage-api-token",
+			Scopes:         []string{},
+			RequiredScopes: []string{},
+		}
+		ctx, err = authAPIKeyFn(ctx, p.StorageAPIToken, &sc)
+		if err != nil {
+			return nil, err
+		}
+		deps := ctx.Value(dependencies.ProjectRequestScopeCtxKey).(dependencies.ProjectRequestScope)
+		return s.RollbackInstancePreview(ctx, deps, p)
+	}
+}
+
+// NewRollbackInstanceEndpoint returns an endpoint function that calls the
+// method "RollbackInstance" of service "templates".
+func NewRollbackInstanceEndpoint(s Service, authAPIKeyFn security.AuthAPIKeyFunc) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*RollbackInstancePayload)
+		var err error
+		sc := security.APIKeyScheme{
+			Name:           "storage-api-token",
+			Scopes:         []string{},
+			RequiredScopes: []string{},
+		}
+		ctx, err = authAPIKeyFn(ctx, p.StorageAPIToken, &sc)
+		if err != nil {
+			return nil, err
+		}
+		deps := ctx.Value(dependencies.ProjectRequestScopeCtxKey).(dependencies.ProjectRequestScope)
+		return s.RollbackInstance(ctx, deps, p)
+	}
+}
+
+// NewListAuditLogEndpoint returns an endpoint function that calls the method
+// "ListAuditLog" of service "templates".
+func NewListAuditLogEndpoint(s Service, authAPIKeyFn security.AuthAPIKeyFunc) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*ListAuditLogPayload)
+		var err error
+		sc := security.APIKeyScheme{
+			Name:           "storage-api-token",
+			Scopes:         []string{},
+			RequiredScopes: []string{},
+		}
+		ctx, err = authAPIKeyFn(ctx, p.StorageAPIToken, &sc)
+		if err != nil {
+			return nil, err
+		}
+		deps := ctx.Value(dependencies.ProjectRequestScopeCtxKey).(dependencies.ProjectRequestScope)
+		return s.ListAuditLog(ctx, deps, p)
+	}
+}
+
 // NewGetTaskEndpoint returns an endpoint function that calls the method
 // "GetTask" of service "templates".
 func NewGetTaskEndpoint(s Service, authAPIKeyFn security.AuthAPIKeyFunc) goa.Endpoint {
@@ -435,3 +622,23 @@ func NewGetTaskEndpoint(s Service, authAPIKeyFn security.AuthAPIKeyFunc) goa.End
 		return s.GetTask(ctx, deps, p)
 	}
 }
+
+// NewCancelTaskEndpoint returns an endpoint function that calls the method
+// "CancelTask" of service "templates".
+func NewCancelTaskEndpoint(s Service, authAPIKeyFn security.AuthAPIKeyFunc) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*CancelTaskPayload)
+		var err error
+		sc := security.APIKeyScheme{
+			Name:           "storage-api-token",
+			Scopes:         []string{},
+			RequiredScopes: []string{},
+		}
+		ctx, err = authAPIKeyFn(ctx, p.StorageAPIToken, &sc)
+		if err != nil {
+			return nil, err
+		}
+		deps := ctx.Value(dependencies.ProjectRequestScopeCtxKey).(dependencies.ProjectRequestScope)
+		return s.CancelTask(ctx, deps, p)
+	}
+}
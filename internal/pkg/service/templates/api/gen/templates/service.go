@@ -10,6 +10,7 @@ package templates
 
 import (
 	"context"
+	"io"
 
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/task"
 	dependencies "github.com/keboola/keboola-as-code/internal/pkg/service/templates/dependencies"
@@ -29,13 +30,22 @@ type Service interface {
 	// Get details of specified repository. Use "keboola" for default Keboola
 	// repository.
 	RepositoryIndex(context.Context, dependencies.ProjectRequestScope, *RepositoryIndexPayload) (res *Repository, err error)
+	// Pull the repository immediately, without waiting for the periodic update.
+	// The pull runs as an asynchronous task.
+	RefreshRepository(context.Context, dependencies.ProjectRequestScope, *RefreshRepositoryPayload) (res *Task, err error)
+	// Webhook target for a git host, for example GitHub "push" event. The request
+	// body must be signed by the configured secret, the signature is read from the
+	// "X-Hub-Signature-256" header. All instances of the default repository are
+	// pulled by an asynchronous task.
+	RepositoryWebhook(context.Context, dependencies.PublicRequestScope, *RepositoryWebhookRequest, io.ReadCloser) (err error)
 	// List all templates  defined in the repository.
 	TemplatesIndex(context.Context, dependencies.ProjectRequestScope, *TemplatesIndexPayload) (res *Templates, err error)
 	// Get detail and versions of specified template.
 	TemplateIndex(context.Context, dependencies.ProjectRequestScope, *TemplateIndexPayload) (res *TemplateDetail, err error)
 	// Get details of specified template version.
 	VersionIndex(context.Context, dependencies.ProjectRequestScope, *VersionIndexPayload) (res *VersionDetailExtended, err error)
-	// Get inputs for the "use" API call.
+	// Get inputs for the "use" API call. Use the "jsonSchema" format to get the
+	// inputs also as a JSON Schema for external UIs.
 	InputsIndex(context.Context, dependencies.ProjectRequestScope, *InputsIndexPayload) (res *Inputs, err error)
 	// Validate inputs for the "use" API call.
 	// Only configured steps should be send.
@@ -43,6 +53,9 @@ type Service interface {
 	// Validate inputs and use template in the branch.
 	// Only configured steps should be send.
 	UseTemplateVersion(context.Context, dependencies.ProjectRequestScope, *UseTemplateVersionPayload) (res *Task, err error)
+	// List existing tables, buckets or workspaces in the branch.
+	// They are options for inputs of the "table", "bucket" and "connection" kinds.
+	InputLookup(context.Context, dependencies.ProjectRequestScope, *InputLookupPayload) (res *InputLookupResult, err error)
 	// InstancesIndex implements InstancesIndex.
 	InstancesIndex(context.Context, dependencies.ProjectRequestScope, *InstancesIndexPayload) (res *Instances, err error)
 	// InstanceIndex implements InstanceIndex.
@@ -51,14 +64,32 @@ type Service interface {
 	UpdateInstance(context.Context, dependencies.ProjectRequestScope, *UpdateInstancePayload) (res *InstanceDetail, err error)
 	// DeleteInstance implements DeleteInstance.
 	DeleteInstance(context.Context, dependencies.ProjectRequestScope, *DeleteInstancePayload) (res *Task, err error)
+	// List configurations, rows and schedulers to be deleted, and storage tables
+	// they write. The tables are not deleted.
+	DeleteInstancePreview(context.Context, dependencies.ProjectRequestScope, *DeleteInstancePreviewPayload) (res *InstanceDeletePreview, err error)
 	// UpgradeInstance implements UpgradeInstance.
 	UpgradeInstance(context.Context, dependencies.ProjectRequestScope, *UpgradeInstancePayload) (res *Task, err error)
 	// UpgradeInstanceInputsIndex implements UpgradeInstanceInputsIndex.
 	UpgradeInstanceInputsIndex(context.Context, dependencies.ProjectRequestScope, *UpgradeInstanceInputsIndexPayload) (res *Inputs, err error)
 	// UpgradeInstanceValidateInputs implements UpgradeInstanceValidateInputs.
 	UpgradeInstanceValidateInputs(context.Context, dependencies.ProjectRequestScope, *UpgradeInstanceValidateInputsPayload) (res *ValidationResult, err error)
+	// List operations which modified the instance, from the newest. Values of the
+	// hidden inputs, for example passwords, are not included.
+	InstanceHistoryIndex(context.Context, dependencies.ProjectRequestScope, *InstanceHistoryIndexPayload) (res *InstanceHistory, err error)
+	// Show the previous state of the instance from the history and changes of the
+	// configurations caused by the rollback. Nothing is modified.
+	RollbackInstancePreview(context.Context, dependencies.ProjectRequestScope, *RollbackInstancePreviewPayload) (res *InstanceRollbackPreview, err error)
+	// Re-generate the instance in the previous version with the previous inputs,
+	// see the instance history. It can be used also to clean up a failed upgrade.
+	// Values of the hidden inputs are loaded from the current configurations.
+	RollbackInstance(context.Context, dependencies.ProjectRequestScope, *RollbackInstancePayload) (res *Task, err error)
+	// List mutating API calls in the project, from the newest to the oldest.
+	ListAuditLog(context.Context, dependencies.ProjectRequestScope, *ListAuditLogPayload) (res *AuditLog, err error)
 	// Get details of a task.
 	GetTask(context.Context, dependencies.ProjectRequestScope, *GetTaskPayload) (res *Task, err error)
+	// Request cancellation of a running task. The task is cancelled
+	// asynchronously, poll the task to get the final state.
+	CancelTask(context.Context, dependencies.ProjectRequestScope, *CancelTaskPayload) (res *Task, err error)
 }
 
 // Auther defines the authorization functions to be implemented by the service.
@@ -81,7 +112,65 @@ const ServiceName = "templates"
 // MethodNames lists the service method names as defined in the design. These
 // are the same values that are set in the endpoint request contexts under the
 // MethodKey key.
-var MethodNames = [19]string{"ApiRootIndex", "ApiVersionIndex", "HealthCheck", "RepositoriesIndex", "RepositoryIndex", "TemplatesIndex", "TemplateIndex", "VersionIndex", "InputsIndex", "ValidateInputs", "UseTemplateVersion", "InstancesIndex", "InstanceIndex", "UpdateInstance", "DeleteInstance", "UpgradeInstance", "UpgradeInstanceInputsIndex", "UpgradeInstanceValidateInputs", "GetTask"}
+var MethodNames = [28]string{"ApiRootIndex", "ApiVersionIndex", "HealthCheck", "RepositoriesIndex", "RepositoryIndex", "RefreshRepository", "RepositoryWebhook", "TemplatesIndex", "TemplateIndex", "VersionIndex", "InputsIndex", "ValidateInputs", "UseTemplateVersion", "InputLookup", "InstancesIndex", "InstanceIndex", "UpdateInstance", "DeleteInstance", "DeleteInstancePreview", "UpgradeInstance", "UpgradeInstanceInputsIndex", "UpgradeInstanceValidateInputs", "InstanceHistoryIndex", "RollbackInstancePreview", "RollbackInstance", "ListAuditLog", "GetTask", "CancelTask"}
+
+// Information about the API call actor.
+type AuditBy struct {
+	// Type of the actor.
+	Type string
+	// ID of the token.
+	TokenID *string
+	// Description of the token.
+	TokenDesc *string
+	// ID of the user.
+	UserID *string
+	// Name of the user.
+	UserName *string
+}
+
+// A mutating API call.
+type AuditEntry struct {
+	// Unique ID of the entry.
+	ID string
+	// Date and time of the API call.
+	Created string
+	// ID of the API request.
+	RequestID *string
+	// Name of the API endpoint.
+	Endpoint string
+	// HTTP method of the API request.
+	HTTPMethod string
+	// HTTP path of the API request.
+	HTTPPath string
+	By       *AuditBy
+	// Key of the modified object.
+	ObjectKey *string
+	// ID of the asynchronous task started by the API call.
+	TaskID *TaskID
+	// Summary of the object state before the API call.
+	Before map[string]any
+	// Summary of the expected object state after the API call.
+	After  map[string]any
+	Result *AuditResult
+}
+
+// AuditLog is the result type of the templates service ListAuditLog method.
+type AuditLog struct {
+	Entries []*AuditEntry
+}
+
+// Result of the API call, the result of an asynchronous operation is available
+// in the task.
+type AuditResult struct {
+	// True, if the API call succeeded.
+	Success bool
+	// HTTP status code of the failed API call.
+	StatusCode *int
+	// Name of the error.
+	ErrorName *string
+	// Error message.
+	Error *string
+}
 
 // Author of template or repository.
 type Author struct {
@@ -91,6 +180,13 @@ type Author struct {
 	URL string
 }
 
+// CancelTaskPayload is the payload type of the templates service CancelTask
+// method.
+type CancelTaskPayload struct {
+	StorageAPIToken string
+	TaskID          TaskID
+}
+
 // Date of change and who made it.
 type ChangeInfo struct {
 	// Date and time of the change.
@@ -113,11 +209,49 @@ type Config struct {
 // DeleteInstance method.
 type DeleteInstancePayload struct {
 	StorageAPIToken string
-	InstanceID      InstanceID
+	// Configurations to keep, in the "<componentId>:<configId>" format. They are
+	// converted to regular configurations, the template metadata are removed.
+	KeepConfigs []string
+	InstanceID  InstanceID
+	// ID of the branch. Use "default" for default branch.
+	Branch string
+}
+
+// DeleteInstancePreviewPayload is the payload type of the templates service
+// DeleteInstancePreview method.
+type DeleteInstancePreviewPayload struct {
+	StorageAPIToken string
+	// Configurations to keep, in the "<componentId>:<configId>" format. They are
+	// converted to regular configurations, the template metadata are removed.
+	KeepConfigs []string
+	InstanceID  InstanceID
 	// ID of the branch. Use "default" for default branch.
 	Branch string
 }
 
+// The configuration affected by the instance deletion.
+type DeletePreviewConfig struct {
+	// Component ID.
+	ComponentID string
+	// Configuration ID.
+	ConfigID string
+	// Name of the configuration.
+	Name string
+	// The configuration is deleted or kept as a regular configuration.
+	Action string
+	// Rows of the configuration.
+	Rows []*DeletePreviewRow
+	// Storage tables written by the configuration or its rows.
+	OutputTables []string
+}
+
+type DeletePreviewRow struct {
+	// Row ID.
+	RowID string
+	// Name of the row.
+	Name string
+}
+
 // Generic error
 type GenericError struct {
 	// HTTP status code.
@@ -156,6 +290,32 @@ type Input struct {
 	OauthInputID *string
 }
 
+type InputLookupOption struct {
+	// Value of the option.
+	Value string
+	// Visible label of the option.
+	Label string
+}
+
+// InputLookupPayload is the payload type of the templates service InputLookup
+// method.
+type InputLookupPayload struct {
+	StorageAPIToken string
+	// Kind of the input.
+	Kind string
+	// ID of the branch. Use "default" for default branch.
+	Branch string
+}
+
+// InputLookupResult is the result type of the templates service InputLookup
+// method.
+type InputLookupResult struct {
+	// Kind of the input.
+	Kind string
+	// Existing objects. Value is a table ID, a bucket ID or a workspace ID.
+	Options []*InputLookupOption
+}
+
 // Input option for type = select OR multiselect.
 type InputOption struct {
 	// Visible label of the option.
@@ -188,12 +348,18 @@ type Inputs struct {
 	StepGroups []*StepGroup
 	// Initial state - same structure as the validation result.
 	InitialState *ValidationResult
+	// JSON Schema of the inputs values with the "x-keboola-*" UI hints. Present
+	// only in the "jsonSchema" format.
+	JSONSchema any
 }
 
 // InputsIndexPayload is the payload type of the templates service InputsIndex
 // method.
 type InputsIndexPayload struct {
 	StorageAPIToken string
+	// Format of the response. The "jsonSchema" format adds the inputs as a JSON
+	// Schema with UI hints.
+	Format string
 	// Semantic version of the template. Use "default" for default version.
 	Version  string
 	Template TemplateID
@@ -223,6 +389,13 @@ type Instance struct {
 	Configurations []*Config
 }
 
+// InstanceDeletePreview is the result type of the templates service
+// DeleteInstancePreview method.
+type InstanceDeletePreview struct {
+	// Configurations of the instance and their schedulers.
+	Configurations []*DeletePreviewConfig
+}
+
 // InstanceDetail is the result type of the templates service InstanceIndex
 // method.
 type InstanceDetail struct {
@@ -250,6 +423,51 @@ type InstanceDetail struct {
 	Configurations []*Config
 }
 
+// InstanceHistory is the result type of the templates service
+// InstanceHistoryIndex method.
+type InstanceHistory struct {
+	// Operations which modified the instance, from the newest.
+	Records []*InstanceHistoryRecord
+}
+
+// InstanceHistoryIndexPayload is the payload type of the templates service
+// InstanceHistoryIndex method.
+type InstanceHistoryIndexPayload struct {
+	StorageAPIToken string
+	InstanceID      InstanceID
+	// ID of the branch. Use "default" for default branch.
+	Branch string
+}
+
+type InstanceHistoryInput struct {
+	// Input ID.
+	ID string
+	// Input value.
+	Value any
+	// The input was not visible or the step was skipped, the default value was
+	// used.
+	Skipped bool
+}
+
+// An operation which modified the template instance.
+type InstanceHistoryRecord struct {
+	// Operation type.
+	Operation string
+	// Date of the operation and who made it.
+	Changed *ChangeInfo
+	// Name of the template repository.
+	RepositoryName string
+	TemplateID     TemplateID
+	// Semantic version of the template.
+	Version string
+	// Name of the instance.
+	Name string
+	// Values of the template inputs, hidden inputs are not included.
+	Inputs []*InstanceHistoryInput
+	// Error of the operation. If it is set, the instance may be partially modified.
+	Error *string
+}
+
 // ID of the template instance.
 type InstanceID = string
 
@@ -262,6 +480,15 @@ type InstanceIndexPayload struct {
 	Branch string
 }
 
+// InstanceRollbackPreview is the result type of the templates service
+// RollbackInstancePreview method.
+type InstanceRollbackPreview struct {
+	// The previous state of the instance to be restored.
+	Target *InstanceHistoryRecord
+	// Configurations and rows modified by the rollback.
+	Changes []*RollbackChange
+}
+
 // Instances is the result type of the templates service InstancesIndex method.
 type Instances struct {
 	// All instances found in branch.
@@ -276,6 +503,14 @@ type InstancesIndexPayload struct {
 	Branch string
 }
 
+// ListAuditLogPayload is the payload type of the templates service
+// ListAuditLog method.
+type ListAuditLogPayload struct {
+	StorageAPIToken string
+	// Maximum number of returned entries.
+	Limit int
+}
+
 // Main config of the instance, usually an orchestration. Optional.
 type MainConfig struct {
 	// Component ID.
@@ -297,6 +532,15 @@ type ProjectLockedError struct {
 	RetryAfter string
 }
 
+// RefreshRepositoryPayload is the payload type of the templates service
+// RefreshRepository method.
+type RefreshRepositoryPayload struct {
+	StorageAPIToken string
+	// Name of the template repository. Use "keboola" for default Keboola
+	// repository.
+	Repository string
+}
+
 // Repositories is the result type of the templates service RepositoriesIndex
 // method.
 type Repositories struct {
@@ -320,6 +564,8 @@ type Repository struct {
 	// Git branch or tag.
 	Ref    string
 	Author *Author
+	// Result of the last repository pull. It is set only in the repository detail.
+	Status *RepositoryStatus
 }
 
 // RepositoryIndexPayload is the payload type of the templates service
@@ -331,6 +577,62 @@ type RepositoryIndexPayload struct {
 	Repository string
 }
 
+// Result of the last repository pull.
+type RepositoryStatus struct {
+	// Hash of the checked out commit.
+	CommitHash string
+	// Date and time of the last successful pull.
+	LastUpdate *string
+	// Error of the last failed pull. It is cleared by the next successful pull.
+	LastError *string
+	// Date and time of the last failed pull.
+	LastErrorAt *string
+}
+
+// RepositoryWebhookRequest is the payload type of the templates service
+// RepositoryWebhook method.
+type RepositoryWebhookRequest struct {
+	// HMAC SHA256 signature of the request body, in the "sha256=<hex>" format.
+	Signature *string
+	// Name of the template repository. Use "keboola" for default Keboola
+	// repository.
+	Repository string
+}
+
+// The configuration or row modified by the rollback.
+type RollbackChange struct {
+	// Component ID.
+	ComponentID string
+	// Configuration ID.
+	ConfigID string
+	// Row ID, it is set if the change is in a configuration row.
+	RowID *string
+	// Name of the configuration or row.
+	Name string
+	// The object is created, updated or deleted by the rollback.
+	Action string
+	// Changed fields, it is set if the object is updated.
+	ChangedFields []string
+}
+
+// RollbackInstancePayload is the payload type of the templates service
+// RollbackInstance method.
+type RollbackInstancePayload struct {
+	StorageAPIToken string
+	InstanceID      InstanceID
+	// ID of the branch. Use "default" for default branch.
+	Branch string
+}
+
+// RollbackInstancePreviewPayload is the payload type of the templates service
+// RollbackInstancePreview method.
+type RollbackInstancePreviewPayload struct {
+	StorageAPIToken string
+	InstanceID      InstanceID
+	// ID of the branch. Use "default" for default branch.
+	Branch string
+}
+
 // ServiceDetail is the result type of the templates service ApiVersionIndex
 // method.
 type ServiceDetail struct {
@@ -404,7 +706,7 @@ type StepValidationResult struct {
 	Inputs []*InputValidationResult
 }
 
-// Task is the result type of the templates service UseTemplateVersion method.
+// Task is the result type of the templates service RefreshRepository method.
 type Task struct {
 	ID TaskID
 	// Task type.
@@ -419,8 +721,11 @@ type Task struct {
 	CreatedAt string
 	// Date and time of the task end.
 	FinishedAt *string
+	// Date and time of the task cancellation.
+	CancelledAt *string
 	// Duration of the task in milliseconds.
 	Duration *int64
+	Progress *TaskProgress
 	Result   *string
 	Error    *string
 	Outputs  *TaskOutputs
@@ -435,6 +740,18 @@ type TaskOutputs struct {
 	InstanceID *InstanceID
 }
 
+// Progress of the task, reported by the running operation.
+type TaskProgress struct {
+	// Progress in percent.
+	Percent int
+	// Current step of the operation.
+	Step *string
+	// Message describing the current state.
+	Message *string
+	// Date and time of the last progress update.
+	UpdatedAt string
+}
+
 // Template.
 type Template struct {
 	ID TemplateID
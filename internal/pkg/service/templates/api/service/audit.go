@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/audit"
+	. "github.com/keboola/keboola-as-code/internal/pkg/service/templates/api/gen/templates"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/templates/dependencies"
+	storeModel "github.com/keboola/keboola-as-code/internal/pkg/service/templates/store/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/template"
+)
+
+func (s *service) ListAuditLog(ctx context.Context, d dependencies.ProjectRequestScope, payload *ListAuditLogPayload) (res *AuditLog, err error) {
+	entries, err := d.AuditLog().List(ctx, d.ProjectID(), payload.Limit)
+	if err != nil {
+		return nil, err
+	}
+	return AuditLogResponse(entries), nil
+}
+
+// auditInstance records the template instance modified by the API call, if the call is audited.
+// The before summary is nil for a new instance, the after summary is nil for a deleted instance.
+func auditInstance(ctx context.Context, d dependencies.ProjectRequestScope, branchKey model.BranchKey, instanceID string, before, after audit.Summary) {
+	record := audit.FromContext(ctx)
+	record.SetObjectKey(storeModel.InstanceKey{ProjectID: d.ProjectID(), BranchID: branchKey.ID, InstanceID: instanceID}.String())
+	record.SetBefore(before)
+	record.SetAfter(after)
+}
+
+func instanceSummary(instance *model.TemplateInstance) audit.Summary {
+	return audit.Summary{
+		"repository": instance.RepositoryName,
+		"templateId": instance.TemplateID,
+		"version":    instance.Version,
+		"name":       instance.InstanceName,
+	}
+}
+
+func templateSummary(tmpl *template.Template, instanceName string) audit.Summary {
+	return audit.Summary{
+		"repository": tmpl.Repository().Name,
+		"templateId": tmpl.TemplateID(),
+		"version":    tmpl.Version(),
+		"name":       instanceName,
+	}
+}
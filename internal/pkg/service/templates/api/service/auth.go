@@ -5,6 +5,7 @@ import (
 
 	"goa.design/goa/v3/security"
 
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/audit"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/templates/dependencies"
 	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
 )
@@ -18,6 +19,9 @@ func (s *service) APIKeyAuth(ctx context.Context, tokenStr string, scheme *secur
 			return nil, err
 		}
 
+		// Fill in the audit log record, if the request is audited
+		audit.FromContext(ctx).SetIdentity(prjReqScp.ProjectID(), audit.ByFromToken(prjReqScp.StorageAPIToken()))
+
 		// Update context
 		return context.WithValue(ctx, dependencies.ProjectRequestScopeCtxKey, prjReqScp), nil
 	}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/project"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/utctime"
+	. "github.com/keboola/keboola-as-code/internal/pkg/service/templates/api/gen/templates"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/templates/dependencies"
+	storeModel "github.com/keboola/keboola-as-code/internal/pkg/service/templates/store/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/template"
+	"github.com/keboola/keboola-as-code/internal/pkg/template/context/upgrade"
+	"github.com/keboola/keboola-as-code/internal/pkg/template/input"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+// addHistoryRecord stores the operation to the instance history.
+// The operation has already been pushed to the project, so an error is only logged.
+func addHistoryRecord(ctx context.Context, d dependencies.ProjectRequestScope, record storeModel.InstanceHistoryRecord, opErr error) {
+	record.ProjectID = d.ProjectID()
+	record.TokenID = d.StorageAPITokenID()
+	if opErr != nil {
+		record.Error = opErr.Error()
+	}
+	if err := d.Store().AddInstanceHistoryRecord(ctx, record); err != nil {
+		d.Logger().Warnf(ctx, `cannot store history of the template instance "%s": %s`, record.InstanceID, err)
+	}
+}
+
+// newHistoryRecord creates a history record of the operation with the template.
+func newHistoryRecord(branchKey model.BranchKey, instanceID, instanceName string, op storeModel.InstanceOperation, tmpl *template.Template, values template.InputsValues) storeModel.InstanceHistoryRecord {
+	return storeModel.InstanceHistoryRecord{
+		InstanceKey:    storeModel.InstanceKey{BranchID: branchKey.ID, InstanceID: instanceID},
+		Operation:      op,
+		RepositoryName: tmpl.Repository().Name,
+		TemplateID:     tmpl.TemplateID(),
+		Version:        tmpl.Version(),
+		InstanceName:   instanceName,
+		Inputs:         historyInputs(tmpl.Inputs(), values),
+	}
+}
+
+// renameHistoryRecord creates a history record of the instance rename, based on the last record.
+// The rename doesn't modify the configurations, so the version, inputs and the error of the last operation are kept.
+// A failed operation is still reported as failed and the rollback target is not affected.
+func renameHistoryRecord(last storeModel.InstanceHistoryRecord, instanceName string) storeModel.InstanceHistoryRecord {
+	record := last
+	record.Created = utctime.UTCTime{}
+	record.Operation = storeModel.InstanceOperationUpdate
+	record.InstanceName = instanceName
+	return record
+}
+
+// historyInputs converts the input values to the history record.
+// Values of the secret inputs are not stored, they are loaded from the configurations on rollback.
+func historyInputs(groups template.StepsGroups, values template.InputsValues) []storeModel.InstanceInput {
+	inputs := groups.InputsMap()
+	out := make([]storeModel.InstanceInput, 0, len(values))
+	for _, v := range values {
+		item := storeModel.InstanceInput{ID: v.ID, Skipped: v.Skipped}
+		if inputDef, found := inputs[v.ID]; !found || !isSecretInput(inputDef) {
+			item.Value = v.Value
+		}
+		out = append(out, item)
+	}
+	return out
+}
+
+func isSecretInput(i *input.Input) bool {
+	return i.Kind == input.KindHidden || i.Kind == input.KindOAuth
+}
+
+// instanceHistory loads history records of the instance, from the oldest to the newest.
+func instanceHistory(ctx context.Context, d dependencies.ProjectRequestScope, branchKey model.BranchKey, instanceID string) ([]storeModel.InstanceHistoryRecord, error) {
+	return d.Store().InstanceHistory(ctx, storeModel.InstanceKey{ProjectID: d.ProjectID(), BranchID: branchKey.ID, InstanceID: instanceID})
+}
+
+// rollbackTarget returns the newest successful record with a different state than the last operation.
+// If the last operation failed, the newest successful record is returned, so the failed operation is cleaned up.
+func rollbackTarget(records []storeModel.InstanceHistoryRecord) (storeModel.InstanceHistoryRecord, bool) {
+	if len(records) == 0 {
+		return storeModel.InstanceHistoryRecord{}, false
+	}
+	current := records[len(records)-1]
+	for i := len(records) - 2; i >= 0; i-- {
+		record := records[i]
+		if record.Failed() {
+			continue
+		}
+		if current.Failed() || !record.SameState(current) {
+			return record, true
+		}
+	}
+	return storeModel.InstanceHistoryRecord{}, false
+}
+
+// getRollbackTarget loads the instance history and the template version to roll back to.
+func getRollbackTarget(ctx context.Context, d dependencies.ProjectRequestScope, branchKey model.BranchKey, instanceID string) (storeModel.InstanceHistoryRecord, *template.Template, error) {
+	records, err := instanceHistory(ctx, d, branchKey, instanceID)
+	if err != nil {
+		return storeModel.InstanceHistoryRecord{}, nil, err
+	}
+
+	target, found := rollbackTarget(records)
+	if !found {
+		return storeModel.InstanceHistoryRecord{}, nil, &GenericError{
+			Name:    "templates.rollbackNotAvailable",
+			Message: fmt.Sprintf(`Instance "%s" has no previous state in the history.`, instanceID),
+		}
+	}
+
+	_, tmpl, err := getTemplateVersion(ctx, d, target.RepositoryName, target.TemplateID, target.Version)
+	if err != nil {
+		return storeModel.InstanceHistoryRecord{}, nil, err
+	}
+
+	return target, tmpl, nil
+}
+
+// rollbackInputs converts the history record to the input values of the template.
+// Values not stored in the history, for example passwords, are exported from the current configurations.
+func rollbackInputs(ctx context.Context, d dependencies.ProjectRequestScope, prjState *project.State, branchKey model.BranchKey, instanceID string, tmpl *template.Template, record storeModel.InstanceHistoryRecord) (template.InputsValues, error) {
+	stored := make(map[string]storeModel.InstanceInput)
+	for _, item := range record.Inputs {
+		stored[item.ID] = item
+	}
+
+	errs := errors.NewMultiError()
+	values := make(template.InputsValues, 0)
+	groups := upgrade.ExportInputsValues(ctx, d.Logger().Infof, prjState.State(), branchKey, instanceID, tmpl.Inputs())
+	_ = groups.VisitInputs(func(_ *input.StepsGroupExt, _ *input.StepExt, inputDef *input.Input) error {
+		item, found := stored[inputDef.ID]
+		if !found || item.Value == nil {
+			values = append(values, template.InputValue{ID: inputDef.ID, Value: inputDef.DefaultOrEmpty(), Skipped: item.Skipped})
+			return nil
+		}
+
+		value, err := inputDef.Type.ParseValue(item.Value)
+		if err != nil {
+			errs.Append(errors.Errorf(`invalid value of the input "%s" in the history: %w`, inputDef.ID, err))
+			return nil
+		}
+		values = append(values, template.InputValue{ID: inputDef.ID, Value: value, Skipped: item.Skipped})
+		return nil
+	})
+
+	return values, errs.ErrorOrNil()
+}
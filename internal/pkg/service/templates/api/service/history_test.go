@@ -0,0 +1,108 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/utctime"
+	storeModel "github.com/keboola/keboola-as-code/internal/pkg/service/templates/store/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/template"
+	"github.com/keboola/keboola-as-code/internal/pkg/template/input"
+)
+
+func TestHistoryInputs(t *testing.T) {
+	t.Parallel()
+
+	groups := template.StepsGroups{
+		{
+			Steps: input.Steps{
+				{
+					Inputs: input.Inputs{
+						{ID: "name", Type: input.TypeString, Kind: input.KindInput},
+						{ID: "password", Type: input.TypeString, Kind: input.KindHidden},
+						{ID: "oauth", Type: input.TypeObject, Kind: input.KindOAuth},
+					},
+				},
+			},
+		},
+	}
+	values := template.InputsValues{
+		{ID: "name", Value: "foo"},
+		{ID: "password", Value: "secret"},
+		{ID: "oauth", Value: map[string]any{"token": "secret"}, Skipped: true},
+	}
+
+	assert.Equal(t, []storeModel.InstanceInput{
+		{ID: "name", Value: "foo"},
+		{ID: "password"},
+		{ID: "oauth", Skipped: true},
+	}, historyInputs(groups, values))
+}
+
+func TestRenameHistoryRecord(t *testing.T) {
+	t.Parallel()
+
+	failed := storeModel.InstanceHistoryRecord{
+		Created:      utctime.MustParse("2024-01-01T00:00:00.000Z"),
+		Operation:    storeModel.InstanceOperationUpgrade,
+		Version:      "2.0.0",
+		InstanceName: "old",
+		Inputs:       []storeModel.InstanceInput{{ID: "name", Value: "foo"}},
+		Error:        "cannot push changes",
+	}
+
+	// The rename doesn't mark the failed upgrade as successful
+	renamed := renameHistoryRecord(failed, "new")
+	assert.Equal(t, storeModel.InstanceHistoryRecord{
+		Operation:    storeModel.InstanceOperationUpdate,
+		Version:      "2.0.0",
+		InstanceName: "new",
+		Inputs:       []storeModel.InstanceInput{{ID: "name", Value: "foo"}},
+		Error:        "cannot push changes",
+	}, renamed)
+	assert.True(t, renamed.Failed())
+	assert.True(t, renamed.SameState(failed))
+}
+
+func TestRollbackTarget(t *testing.T) {
+	t.Parallel()
+
+	v1 := storeModel.InstanceHistoryRecord{Operation: storeModel.InstanceOperationUse, Version: "1.0.0", Inputs: []storeModel.InstanceInput{{ID: "name", Value: "foo"}}}
+	v2 := storeModel.InstanceHistoryRecord{Operation: storeModel.InstanceOperationUpgrade, Version: "2.0.0", Inputs: []storeModel.InstanceInput{{ID: "name", Value: "foo"}}}
+	v2Renamed := renameHistoryRecord(v2, "renamed")
+	v2Failed := v2
+	v2Failed.Error = "cannot push changes"
+	v2FailedRenamed := renameHistoryRecord(v2Failed, "renamed")
+	v1Inputs := v1
+	v1Inputs.Operation = storeModel.InstanceOperationUpgrade
+	v1Inputs.Inputs = []storeModel.InstanceInput{{ID: "name", Value: "bar"}}
+
+	cases := []struct {
+		name     string
+		records  []storeModel.InstanceHistoryRecord
+		expected *storeModel.InstanceHistoryRecord
+	}{
+		{name: "empty", records: nil},
+		{name: "only use", records: []storeModel.InstanceHistoryRecord{v1}},
+		{name: "upgrade", records: []storeModel.InstanceHistoryRecord{v1, v2}, expected: &v1},
+		{name: "inputs", records: []storeModel.InstanceHistoryRecord{v1, v1Inputs}, expected: &v1},
+		{name: "rename is skipped", records: []storeModel.InstanceHistoryRecord{v1, v2, v2Renamed}, expected: &v1},
+		{name: "failed upgrade", records: []storeModel.InstanceHistoryRecord{v1, v2Failed}, expected: &v1},
+		{name: "failed re-upgrade", records: []storeModel.InstanceHistoryRecord{v1, v2, v2Failed}, expected: &v2},
+		{name: "rename after failed upgrade", records: []storeModel.InstanceHistoryRecord{v1, v2, v2Failed, v2FailedRenamed}, expected: &v2},
+		{name: "rollback of rollback", records: []storeModel.InstanceHistoryRecord{v1, v2, v1}, expected: &v2},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			target, found := rollbackTarget(tc.records)
+			if tc.expected == nil {
+				assert.False(t, found)
+			} else if assert.True(t, found) {
+				assert.Equal(t, *tc.expected, target)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"slices"
 
 	. "github.com/keboola/keboola-as-code/internal/pkg/service/common/errors"
 	. "github.com/keboola/keboola-as-code/internal/pkg/service/templates/api/gen/templates"
@@ -142,3 +143,42 @@ func inputsPayloadToMap(payload []*StepPayload) map[string]map[string]any {
 	}
 	return v
 }
+
+// withMappedInputs adds values passed from the required templates to the payload.
+// Values entered by the user take precedence, the original payload is not modified.
+func withMappedInputs(groups template.StepsGroups, original []*StepPayload, values map[string]any) []*StepPayload {
+	if len(values) == 0 {
+		return original
+	}
+
+	payload := make([]*StepPayload, 0, len(original))
+	steps := make(map[string]*StepPayload)
+	for _, stepPayload := range original {
+		clone := &StepPayload{ID: stepPayload.ID, Inputs: slices.Clone(stepPayload.Inputs)}
+		steps[clone.ID] = clone
+		payload = append(payload, clone)
+	}
+
+	current := inputsPayloadToMap(payload)
+	for _, group := range groups.ToExtended() {
+		for _, step := range group.Steps {
+			for _, input := range step.Inputs {
+				value, found := values[input.ID]
+				if !found {
+					continue
+				}
+				if _, found := current[step.ID][input.ID]; found {
+					continue
+				}
+				stepPayload, found := steps[step.ID]
+				if !found {
+					stepPayload = &StepPayload{ID: step.ID}
+					steps[step.ID] = stepPayload
+					payload = append(payload, stepPayload)
+				}
+				stepPayload.Inputs = append(stepPayload.Inputs, &InputValue{ID: input.ID, Value: value})
+			}
+		}
+	}
+	return payload
+}
@@ -338,3 +338,28 @@ func TestValidateInputs(t *testing.T) {
 func strPtr(str string) *string {
 	return &str
 }
+
+func TestWithMappedInputs(t *testing.T) {
+	t.Parallel()
+
+	groups := template.StepsGroups{
+		{
+			Required: input.RequiredAll,
+			Steps: template.Steps{
+				{Inputs: template.Inputs{{ID: "foo", Type: input.TypeString, Kind: input.KindInput}, {ID: "bar", Type: input.TypeString, Kind: input.KindInput}}},
+				{Inputs: template.Inputs{{ID: "baz", Type: input.TypeString, Kind: input.KindInput}}},
+			},
+		},
+	}
+	payload := []*StepPayload{{ID: "g01-s01", Inputs: []*InputValue{{ID: "foo", Value: "user value"}}}}
+	values := map[string]any{"foo": "mapped foo", "bar": "mapped bar", "baz": "mapped baz"}
+
+	// Values entered by the user are kept
+	assert.Equal(t, []*StepPayload{
+		{ID: "g01-s01", Inputs: []*InputValue{{ID: "foo", Value: "user value"}, {ID: "bar", Value: "mapped bar"}}},
+		{ID: "g01-s02", Inputs: []*InputValue{{ID: "baz", Value: "mapped baz"}}},
+	}, withMappedInputs(groups, payload, values))
+
+	// The original payload is not modified, so the values can be mapped again
+	assert.Equal(t, []*StepPayload{{ID: "g01-s01", Inputs: []*InputValue{{ID: "foo", Value: "user value"}}}}, payload)
+}
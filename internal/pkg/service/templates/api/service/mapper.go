@@ -9,13 +9,17 @@ import (
 	"github.com/spf13/cast"
 
 	templatesDesign "github.com/keboola/keboola-as-code/api/templates"
+	"github.com/keboola/keboola-as-code/internal/pkg/diff"
 	"github.com/keboola/keboola-as-code/internal/pkg/model"
+	deleteTemplate "github.com/keboola/keboola-as-code/internal/pkg/plan/delete-template"
 	"github.com/keboola/keboola-as-code/internal/pkg/project"
 	"github.com/keboola/keboola-as-code/internal/pkg/search"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/audit"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/task"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/templates/api/config"
 	. "github.com/keboola/keboola-as-code/internal/pkg/service/templates/api/gen/templates"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/templates/dependencies"
+	storeModel "github.com/keboola/keboola-as-code/internal/pkg/service/templates/store/model"
 	"github.com/keboola/keboola-as-code/internal/pkg/template"
 	"github.com/keboola/keboola-as-code/internal/pkg/template/context/upgrade"
 	"github.com/keboola/keboola-as-code/internal/pkg/template/input"
@@ -52,11 +56,29 @@ func (m Mapper) TaskPayload(model task.Task) (r *Task) {
 		out.FinishedAt = &v
 	}
 
+	if model.CancelledAt != nil {
+		v := model.CancelledAt.String()
+		out.CancelledAt = &v
+	}
+
 	if model.Duration != nil {
 		v := model.Duration.Milliseconds()
 		out.Duration = &v
 	}
 
+	if model.Progress != nil {
+		out.Progress = &TaskProgress{
+			Percent:   model.Progress.Percent,
+			UpdatedAt: model.Progress.UpdatedAt.String(),
+		}
+		if model.Progress.Step != "" {
+			out.Progress.Step = &model.Progress.Step
+		}
+		if model.Progress.Message != "" {
+			out.Progress.Message = &model.Progress.Message
+		}
+	}
+
 	switch {
 	case model.IsProcessing():
 		out.Status = templatesDesign.TaskStatusProcessing
@@ -119,6 +141,26 @@ func RepositoryResponse(ctx context.Context, d dependencies.ProjectRequestScope,
 	}
 }
 
+func RepositoryStatusResponse(d dependencies.ProjectRequestScope, repo model.TemplateRepository) *RepositoryStatus {
+	status, found := d.RepositoryManager().RepositoryStatus(repo)
+	if !found {
+		return nil
+	}
+
+	out := &RepositoryStatus{CommitHash: status.CommitHash}
+	if !status.LastUpdate.IsZero() {
+		v := status.LastUpdate.UTC().Format(time.RFC3339)
+		out.LastUpdate = &v
+	}
+	if status.LastError != nil {
+		v := status.LastError.Error()
+		out.LastError = &v
+		at := status.LastErrorAt.UTC().Format(time.RFC3339)
+		out.LastErrorAt = &at
+	}
+	return out
+}
+
 func TemplatesResponse(ctx context.Context, d dependencies.ProjectRequestScope, repo *repository.Repository, templates []repository.TemplateRecord) (out *Templates, err error) {
 	ctx, span := d.Telemetry().Tracer().Start(ctx, "api.server.templates.mapper.TemplatesResponse")
 	defer span.End(&err)
@@ -357,6 +399,14 @@ func InputsResponse(ctx context.Context, d dependencies.ProjectRequestScope, ste
 	return out
 }
 
+func InputLookupResponse(kind input.Kind, options input.Options) (out *InputLookupResult) {
+	out = &InputLookupResult{Kind: string(kind), Options: make([]*InputLookupOption, 0)}
+	for _, o := range options {
+		out.Options = append(out.Options, &InputLookupOption{Value: o.Value, Label: o.Label})
+	}
+	return out
+}
+
 func OptionsResponse(options input.Options) (out []*InputOption) {
 	for _, opt := range options {
 		out = append(out, &InputOption{
@@ -512,6 +562,39 @@ func instanceConfigurations(prjState *project.State, branchKey model.BranchKey,
 	return out
 }
 
+func DeletePreviewResponse(plan *deleteTemplate.Plan) *InstanceDeletePreview {
+	out := &InstanceDeletePreview{Configurations: make([]*DeletePreviewConfig, 0)}
+	for _, action := range plan.DeleteActions() {
+		configKey, ok := action.State.Key().(model.ConfigKey)
+		if !ok {
+			continue
+		}
+		out.Configurations = append(out.Configurations, deletePreviewConfig(configKey, action.State.ObjectName(), "delete", action.Rows, action.Tables))
+	}
+	for _, action := range plan.KeepActions() {
+		out.Configurations = append(out.Configurations, deletePreviewConfig(action.State.ConfigKey, action.State.ObjectName(), "keep", action.Rows, action.Tables))
+	}
+	return out
+}
+
+func deletePreviewConfig(key model.ConfigKey, name, action string, rows []model.ObjectState, tables []string) *DeletePreviewConfig {
+	out := &DeletePreviewConfig{
+		ComponentID:  string(key.ComponentID),
+		ConfigID:     string(key.ID),
+		Name:         name,
+		Action:       action,
+		Rows:         make([]*DeletePreviewRow, 0),
+		OutputTables: make([]string, 0),
+	}
+	for _, row := range rows {
+		if rowKey, ok := row.Key().(model.ConfigRowKey); ok {
+			out.Rows = append(out.Rows, &DeletePreviewRow{RowID: string(rowKey.ID), Name: row.ObjectName()})
+		}
+	}
+	out.OutputTables = append(out.OutputTables, tables...)
+	return out
+}
+
 func configurationsByInstance(prjState *project.State, branchKey model.BranchKey) map[string][]*Config {
 	out := make(map[string][]*Config, 0)
 	branchConfigs := prjState.RemoteObjects().ConfigsWithRowsFrom(branchKey)
@@ -594,3 +677,126 @@ func hasRequirements(tmpl repository.TemplateRecord, d dependencies.ProjectReque
 	}
 	return true
 }
+
+func InstanceHistoryResponse(records []storeModel.InstanceHistoryRecord) *InstanceHistory {
+	out := &InstanceHistory{Records: make([]*InstanceHistoryRecord, 0, len(records))}
+	for i := len(records) - 1; i >= 0; i-- {
+		out.Records = append(out.Records, InstanceHistoryRecordResponse(records[i]))
+	}
+	return out
+}
+
+func InstanceHistoryRecordResponse(record storeModel.InstanceHistoryRecord) *InstanceHistoryRecord {
+	out := &InstanceHistoryRecord{
+		Operation: string(record.Operation),
+		Changed: &ChangeInfo{
+			Date:    record.Created.Time().Format(time.RFC3339),
+			TokenID: record.TokenID,
+		},
+		RepositoryName: record.RepositoryName,
+		TemplateID:     record.TemplateID,
+		Version:        record.Version,
+		Name:           record.InstanceName,
+		Inputs:         make([]*InstanceHistoryInput, 0, len(record.Inputs)),
+	}
+	for _, item := range record.Inputs {
+		out.Inputs = append(out.Inputs, &InstanceHistoryInput{ID: item.ID, Value: item.Value, Skipped: item.Skipped})
+	}
+	if record.Failed() {
+		v := record.Error
+		out.Error = &v
+	}
+	return out
+}
+
+// RollbackPreviewResponse maps differences between the remote state (current) and the local state (after rollback).
+func RollbackPreviewResponse(target storeModel.InstanceHistoryRecord, results *diff.Results) *InstanceRollbackPreview {
+	out := &InstanceRollbackPreview{Target: InstanceHistoryRecordResponse(target), Changes: make([]*RollbackChange, 0)}
+	for _, result := range results.Results {
+		var action string
+		switch result.State {
+		case diff.ResultNotEqual:
+			action = "update"
+		case diff.ResultOnlyInLocal:
+			action = "create"
+		case diff.ResultOnlyInRemote:
+			action = "delete"
+		default:
+			continue
+		}
+
+		change := &RollbackChange{Name: result.ObjectName(), Action: action, ChangedFields: make([]string, 0)}
+		switch k := result.Key().(type) {
+		case model.ConfigKey:
+			change.ComponentID = string(k.ComponentID)
+			change.ConfigID = string(k.ID)
+		case model.ConfigRowKey:
+			rowID := string(k.ID)
+			change.ComponentID = string(k.ComponentID)
+			change.ConfigID = string(k.ConfigID)
+			change.RowID = &rowID
+		default:
+			// Branch metadata are always modified, the instance record is updated
+			continue
+		}
+		for _, field := range result.ChangedFields.All() {
+			change.ChangedFields = append(change.ChangedFields, field.Name())
+		}
+		out.Changes = append(out.Changes, change)
+	}
+	return out
+}
+
+func AuditLogResponse(entries []audit.Entry) *AuditLog {
+	out := &AuditLog{Entries: make([]*AuditEntry, 0, len(entries))}
+	for _, entry := range entries {
+		out.Entries = append(out.Entries, AuditEntryResponse(entry))
+	}
+	return out
+}
+
+func AuditEntryResponse(entry audit.Entry) *AuditEntry {
+	out := &AuditEntry{
+		ID:         entry.ID,
+		Created:    entry.Created.String(),
+		Endpoint:   entry.Endpoint,
+		HTTPMethod: entry.HTTPMethod,
+		HTTPPath:   entry.HTTPPath,
+		By:         &AuditBy{Type: entry.By.Type},
+		Before:     entry.Before,
+		After:      entry.After,
+		Result:     &AuditResult{Success: entry.Result.Success},
+	}
+	if entry.RequestID != "" {
+		out.RequestID = &entry.RequestID
+	}
+	if entry.ObjectKey != "" {
+		out.ObjectKey = &entry.ObjectKey
+	}
+	if entry.TaskID != "" {
+		v := task.ID(entry.TaskID)
+		out.TaskID = &v
+	}
+	if entry.By.TokenID != "" {
+		out.By.TokenID = &entry.By.TokenID
+	}
+	if entry.By.TokenDesc != "" {
+		out.By.TokenDesc = &entry.By.TokenDesc
+	}
+	if entry.By.UserID != "" {
+		out.By.UserID = &entry.By.UserID
+	}
+	if entry.By.UserName != "" {
+		out.By.UserName = &entry.By.UserName
+	}
+	if entry.Result.StatusCode != 0 {
+		out.Result.StatusCode = &entry.Result.StatusCode
+	}
+	if entry.Result.ErrorName != "" {
+		out.Result.ErrorName = &entry.Result.ErrorName
+	}
+	if entry.Result.Error != "" {
+		out.Result.Error = &entry.Result.Error
+	}
+	return out
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"sync"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/encoding/json"
+	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/task"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/utctime"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/templates/dependencies"
+	"github.com/keboola/keboola-as-code/internal/pkg/template/repository/manager"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+const (
+	RepositoryRefreshEtcdPrefix = "runtime/template/repository/refresh"
+)
+
+// RefreshRequest is written to etcd by the refresh endpoint and the webhook.
+// Each API node watches the requests and pulls the repository, if it is loaded by the node.
+// There is one key per repository, a new request overwrites the previous one.
+type RefreshRequest struct {
+	Repository model.TemplateRepository `json:"repository"`
+	// AllRefs is true, if all references of the repository URL should be pulled, for example a different ref used by a project feature.
+	AllRefs bool `json:"allRefs,omitempty"`
+	// NodeID of the node that created the request, the node pulls the repository itself, in the task.
+	NodeID      string          `json:"nodeId"`
+	RequestedAt utctime.UTCTime `json:"requestedAt"`
+}
+
+// repositoryRefreshInput is the input of the queued task created by the repository webhook.
+type repositoryRefreshInput struct {
+	Repository string `json:"repository"`
+}
+
+type repositoryRefresh struct {
+	nodeID  string
+	deps    dependencies.APIScope
+	prefix  etcdop.PrefixT[RefreshRequest]
+	manager *manager.Manager
+}
+
+func newRepositoryRefresh(d dependencies.APIScope) *repositoryRefresh {
+	return &repositoryRefresh{
+		nodeID:  d.APIConfig().NodeID,
+		deps:    d,
+		prefix:  etcdop.NewTypedPrefix[RefreshRequest](etcdop.NewPrefix(RepositoryRefreshEtcdPrefix), d.EtcdSerde()),
+		manager: d.RepositoryManager(),
+	}
+}
+
+// Request notifies other API nodes and pulls the repository on the current node.
+func (r *repositoryRefresh) Request(ctx context.Context, ref model.TemplateRepository, allRefs bool) error {
+	request := RefreshRequest{
+		Repository:  ref,
+		AllRefs:     allRefs,
+		NodeID:      r.nodeID,
+		RequestedAt: utctime.UTCTime(r.deps.Clock().Now()),
+	}
+	if err := r.prefix.Key(hex.EncodeToString([]byte(ref.Hash()))).Put(r.deps.EtcdClient(), request).Do(ctx).Err(); err != nil {
+		return errors.PrefixErrorf(err, `cannot notify other nodes about refresh of the repository "%s"`, ref.Name)
+	}
+	return r.pull(ctx, request, true)
+}
+
+// Watch pulls repositories on the current node, if a refresh request is written to etcd by another node.
+// Only new requests are processed, a repository is always loaded fresh on the node start.
+func (r *repositoryRefresh) Watch(ctx context.Context, wg *sync.WaitGroup) error {
+	logger := r.deps.Logger()
+	consumer := r.prefix.
+		Watch(ctx, r.deps.EtcdClient()).
+		SetupConsumer().
+		WithForEach(func(events []etcdop.WatchEvent[RefreshRequest], _ *etcdop.Header, _ bool) {
+			for _, event := range events {
+				if event.Type == etcdop.DeleteEvent || event.Value.NodeID == r.nodeID {
+					continue
+				}
+				request := event.Value
+				if err := r.pull(ctx, request, false); err != nil {
+					logger.Errorf(ctx, `cannot refresh repository "%s": %s`, request.Repository.Name, err)
+				} else {
+					logger.Infof(ctx, `refreshed repository "%s", requested by the node "%s"`, request.Repository.Name, request.NodeID)
+				}
+			}
+		}).
+		BuildConsumer()
+
+	return <-consumer.StartConsumer(ctx, wg, logger)
+}
+
+// pull updates repositories of the request.
+// The requesting node loads the repository, if it is not loaded yet, other nodes pull only the loaded repositories.
+func (r *repositoryRefresh) pull(ctx context.Context, request RefreshRequest, load bool) error {
+	var refs []model.TemplateRepository
+	for _, ref := range r.manager.ManagedRepositoriesByURL(request.Repository.URL) {
+		if request.AllRefs || ref.Hash() == request.Repository.Hash() {
+			refs = append(refs, ref)
+		}
+	}
+	if len(refs) == 0 && load {
+		refs = append(refs, request.Repository)
+	}
+
+	errs := errors.NewMultiError()
+	for _, ref := range refs {
+		if err := r.manager.UpdateRepository(ctx, ref); err != nil {
+			errs.Append(errors.PrefixErrorf(err, `cannot update repository "%s"`, ref.String()))
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+// repositoryRefreshHandler runs the queued task created by the repository webhook.
+// A failed pull, for example because of a network error, is retried.
+func (s *service) repositoryRefreshHandler(ctx context.Context, logger log.Logger, input json.RawMessage) task.Result {
+	var in repositoryRefreshInput
+	if err := json.Decode(input, &in); err != nil {
+		return task.ErrResult(errors.PrefixError(err, "cannot decode task input"))
+	}
+
+	repoManager := s.deps.RepositoryManager()
+	repoRef, found := defaultRepository(repoManager, in.Repository)
+	if !found {
+		return task.ErrResult(errors.Errorf(`repository "%s" not found`, in.Repository))
+	}
+
+	if err := s.refresh.Request(ctx, repoRef, true); err != nil {
+		return task.ErrResult(task.WrapRetryableError(err))
+	}
+
+	return repositoryRefreshResult(repoManager, repoRef)
+}
+
+// defaultRepository finds the default repository by the name.
+func defaultRepository(m *manager.Manager, name string) (model.TemplateRepository, bool) {
+	for _, r := range m.DefaultRepositories() {
+		if r.Name == name {
+			return r, true
+		}
+	}
+	return model.TemplateRepository{}, false
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/encoding/json"
+	"github.com/keboola/keboola-as-code/internal/pkg/filesystem"
+	"github.com/keboola/keboola-as-code/internal/pkg/model"
+	commonDeps "github.com/keboola/keboola-as-code/internal/pkg/service/common/dependencies"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/task"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/templates/api/config"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/templates/dependencies"
+)
+
+func TestRepositoryRefresh_OtherNode(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Both nodes use the same local repository
+	_, filename, _, _ := runtime.Caller(0)
+	repoDir := filesystem.Join(filesystem.Dir(filename), "..", "..", "dependencies", "git_test", "repository")
+	ref := model.TemplateRepository{Type: model.RepositoryTypeDir, Name: "keboola", URL: repoDir}
+
+	// Create two nodes connected to the same etcd namespace
+	cfg1 := config.New()
+	cfg1.NodeID = "node-1"
+	cfg1.Repositories = []model.TemplateRepository{ref}
+	d1, mock1 := dependencies.NewMockedAPIScope(t, ctx, cfg1)
+	cfg2 := config.New()
+	cfg2.NodeID = "node-2"
+	cfg2.Repositories = []model.TemplateRepository{ref}
+	d2, mock2 := dependencies.NewMockedAPIScope(t, ctx, cfg2, commonDeps.WithEtcdConfig(mock1.TestEtcdConfig()))
+
+	wg := &sync.WaitGroup{}
+	refresh1 := newRepositoryRefresh(d1)
+	refresh2 := newRepositoryRefresh(d2)
+	require.NoError(t, refresh1.Watch(ctx, wg))
+	require.NoError(t, refresh2.Watch(ctx, wg))
+
+	// The repository is loaded by the second node
+	_, unlockFn, err := d2.RepositoryManager().Repository(ctx, ref)
+	require.NoError(t, err)
+	unlockFn()
+	mock1.DebugLogger().Truncate()
+	mock2.DebugLogger().Truncate()
+
+	// Refresh is requested on the first node, the second node pulls the repository too
+	require.NoError(t, refresh1.Request(ctx, ref, false))
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.Contains(c, mock2.DebugLogger().AllMessages(), `refreshed repository \"keboola\", requested by the node \"node-1\"`)
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// The first node pulls the repository only once, in the request
+	assert.NotContains(t, mock1.DebugLogger().AllMessages(), `refreshed repository`)
+
+	cancel()
+	wg.Wait()
+}
+
+func TestRepositoryRefreshHandler(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	_, filename, _, _ := runtime.Caller(0)
+	repoDir := filesystem.Join(filesystem.Dir(filename), "..", "..", "dependencies", "git_test", "repository")
+	cfg := config.New()
+	cfg.Repositories = []model.TemplateRepository{{Type: model.RepositoryTypeDir, Name: "keboola", URL: repoDir}}
+	d, _ := dependencies.NewMockedAPIScope(t, ctx, cfg)
+	s := &service{deps: d, refresh: newRepositoryRefresh(d)}
+
+	// Default repository
+	result := s.repositoryRefreshHandler(ctx, d.Logger(), json.RawMessage(`{"repository":"keboola"}`))
+	require.NoError(t, result.Error)
+	assert.Equal(t, `repository "keboola" is up to date`, result.Result)
+
+	// Unknown repository, the error is not retried
+	result = s.repositoryRefreshHandler(ctx, d.Logger(), json.RawMessage(`{"repository":"unknown"}`))
+	if assert.Error(t, result.Error) {
+		assert.Equal(t, `repository "unknown" not found`, result.Error.Error())
+		assert.False(t, task.IsRetryableError(result.Error))
+	}
+}
@@ -2,33 +2,47 @@ package service
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io"
 	"net/http"
 	"path"
 	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/c2h5oh/datasize"
 	"github.com/keboola/go-client/pkg/keboola"
 	"github.com/keboola/go-utils/pkg/deepcopy"
 	"github.com/spf13/cast"
 
+	"github.com/keboola/keboola-as-code/internal/pkg/diff"
 	"github.com/keboola/keboola-as-code/internal/pkg/filesystem"
 	"github.com/keboola/keboola-as-code/internal/pkg/filesystem/aferofs"
 	"github.com/keboola/keboola-as-code/internal/pkg/log"
 	"github.com/keboola/keboola-as-code/internal/pkg/model"
 	"github.com/keboola/keboola-as-code/internal/pkg/project"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/audit"
 	. "github.com/keboola/keboola-as-code/internal/pkg/service/common/errors"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdmigration"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/task"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/templates/api/config"
 	. "github.com/keboola/keboola-as-code/internal/pkg/service/templates/api/gen/templates"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/templates/dependencies"
+	storeModel "github.com/keboola/keboola-as-code/internal/pkg/service/templates/store/model"
 	"github.com/keboola/keboola-as-code/internal/pkg/template"
+	"github.com/keboola/keboola-as-code/internal/pkg/template/input"
 	"github.com/keboola/keboola-as-code/internal/pkg/template/repository"
+	"github.com/keboola/keboola-as-code/internal/pkg/template/repository/manager"
 	"github.com/keboola/keboola-as-code/internal/pkg/template/repository/manifest"
 	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
 	deleteTemplate "github.com/keboola/keboola-as-code/pkg/lib/operation/project/local/template/delete"
+	prerequisitesTemplate "github.com/keboola/keboola-as-code/pkg/lib/operation/project/local/template/prerequisites"
 	renameInst "github.com/keboola/keboola-as-code/pkg/lib/operation/project/local/template/rename"
 	upgradeTemplate "github.com/keboola/keboola-as-code/pkg/lib/operation/project/local/template/upgrade"
 	useTemplate "github.com/keboola/keboola-as-code/pkg/lib/operation/project/local/template/use"
@@ -37,17 +51,26 @@ import (
 )
 
 const (
-	ProjectLockedRetryAfter = 5 * time.Second
-	TemplateUpgradeTaskType = "template.upgrade"
-	TemplateUseTaskType     = "template.use"
-	TemplateDeleteTaskType  = "template.delete"
+	ProjectLockedRetryAfter   = 5 * time.Second
+	ProjectUnlockTimeout      = 30 * time.Second
+	TemplateUpgradeTaskType   = "template.upgrade"
+	TemplateUseTaskType       = "template.use"
+	TemplateDeleteTaskType    = "template.delete"
+	TemplateRollbackTaskType  = "template.rollback"
+	RepositoryRefreshTaskType = "template.repository.refresh"
+	// RepositoryWebhookMaxBodySize is the maximum size of the webhook body, GitHub caps payloads at 25 MB.
+	RepositoryWebhookMaxBodySize = 25 * datasize.MB
+	InputsFormatJSONSchema       = "jsonSchema"
 )
 
 type service struct {
-	config config.Config
-	deps   dependencies.APIScope
-	tasks  *task.Node
-	mapper *Mapper
+	config  config.Config
+	deps    dependencies.APIScope
+	tasks   *task.Node
+	mapper  *Mapper
+	refresh *repositoryRefresh
+	// taskTimeout can be changed by the configuration reload
+	taskTimeout atomic.Int64
 }
 
 func New(ctx context.Context, d dependencies.APIScope) (Service, error) {
@@ -60,12 +83,19 @@ func New(ctx context.Context, d dependencies.APIScope) (Service, error) {
 	}
 
 	s := &service{
-		config: d.APIConfig(),
-		deps:   d,
-		tasks:  d.TaskNode(),
-		mapper: NewMapper(d),
+		config:  d.APIConfig(),
+		deps:    d,
+		tasks:   d.TaskNode(),
+		mapper:  NewMapper(d),
+		refresh: newRepositoryRefresh(d),
 	}
 
+	// Apply reloaded task timeout to new tasks
+	s.taskTimeout.Store(int64(s.config.API.TaskTimeout))
+	d.ConfigReloader().OnReload(func(ctx context.Context, cfg config.Config) {
+		s.taskTimeout.Store(int64(cfg.API.TaskTimeout))
+	})
+
 	// Graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background()) // nolint: contextcheck
 	wg := &sync.WaitGroup{}
@@ -77,9 +107,40 @@ func New(ctx context.Context, d dependencies.APIScope) (Service, error) {
 		d.Logger().Info(ctx, "shutdown done")
 	})
 
+	// Pull repositories refreshed by other nodes
+	if err := s.refresh.Watch(ctx, wg); err != nil {
+		return nil, err
+	}
+
+	// Run queued tasks
+	if err := s.tasks.StartQueueWorker(map[string]task.QueueHandler{
+		RepositoryRefreshTaskType: s.repositoryRefreshHandler,
+	}); err != nil {
+		return nil, err
+	}
+
+	// Migrate etcd values to the latest schema version
+	if err := etcdmigration.Start(d, s.migratablePrefixes()...); err != nil {
+		return nil, err
+	}
+
 	return s, nil
 }
 
+// migratablePrefixes returns prefixes of the Templates service values, they are migrated to the latest schema version on startup.
+func (s *service) migratablePrefixes() []etcdop.MigratablePrefix {
+	prefixes := []etcdop.MigratablePrefix{
+		s.deps.Schema().InstanceHistory().PrefixT,
+		s.refresh.prefix,
+	}
+	return append(prefixes, task.MigratablePrefixes(s.deps.EtcdSerde())...)
+}
+
+// currentTaskTimeout returns the timeout of a new template task, see config.API.TaskTimeout.
+func (s *service) currentTaskTimeout() time.Duration {
+	return time.Duration(s.taskTimeout.Load())
+}
+
 func (s *service) APIRootIndex(context.Context, dependencies.PublicRequestScope) (err error) {
 	// Redirect / -> /v1
 	return nil
@@ -108,7 +169,87 @@ func (s *service) RepositoryIndex(ctx context.Context, d dependencies.ProjectReq
 	if err != nil {
 		return nil, err
 	}
-	return RepositoryResponse(ctx, d, repo), nil
+	res = RepositoryResponse(ctx, d, repo)
+	res.Status = RepositoryStatusResponse(d, repo.Definition())
+	return res, nil
+}
+
+func (s *service) RefreshRepository(ctx context.Context, d dependencies.ProjectRequestScope, payload *RefreshRepositoryPayload) (res *Task, err error) {
+	repoRef, err := repositoryRef(d, payload.Repository)
+	if err != nil {
+		return nil, err
+	}
+
+	audit.FromContext(ctx).SetObjectKey(d.ProjectID().String() + "/" + payload.Repository)
+
+	tKey := task.Key{
+		ProjectID: d.ProjectID(),
+		TaskID:    task.ID(RepositoryRefreshTaskType),
+	}
+
+	t, err := s.tasks.StartTask(ctx, task.Config{
+		Type: RepositoryRefreshTaskType,
+		Key:  tKey,
+		Context: func() (context.Context, context.CancelFunc) {
+			return context.WithTimeout(context.Background(), s.currentTaskTimeout())
+		},
+		Operation: func(ctx context.Context, logger log.Logger) task.Result {
+			if err := s.refresh.Request(ctx, repoRef, false); err != nil {
+				return task.ErrResult(err)
+			}
+			return repositoryRefreshResult(d.RepositoryManager(), repoRef)
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	audit.FromContext(ctx).SetTaskID(t.TaskID.String())
+	return s.mapper.TaskPayload(t), nil
+}
+
+func (s *service) RepositoryWebhook(ctx context.Context, d dependencies.PublicRequestScope, payload *RepositoryWebhookRequest, body io.ReadCloser) (err error) {
+	defer body.Close()
+
+	// Webhook is disabled, if the secret is not configured
+	secret := s.config.API.RepositoryWebhookSecret
+	if secret == "" {
+		return NewForbiddenError(errors.New("repository webhook is disabled"))
+	}
+
+	// Verify signature of the body, the size of the body is limited, the request is not authenticated yet
+	content, err := io.ReadAll(io.LimitReader(body, int64(RepositoryWebhookMaxBodySize.Bytes())+1))
+	if err != nil {
+		return NewBadRequestError(errors.Errorf("cannot read request body: %w", err))
+	}
+	if uint64(len(content)) > RepositoryWebhookMaxBodySize.Bytes() {
+		return NewBadRequestError(errors.Errorf("request body is too large, the maximum size is %s", RepositoryWebhookMaxBodySize.String()))
+	}
+	if payload.Signature == nil || !verifyWebhookSignature(secret, content, *payload.Signature) {
+		return NewForbiddenError(errors.New("invalid webhook signature"))
+	}
+
+	// Webhook is available only for default repositories, there is no project token
+	repoRef, found := defaultRepository(s.deps.RepositoryManager(), payload.Repository)
+	if !found {
+		return &GenericError{
+			Name:    "templates.repositoryNotFound",
+			Message: fmt.Sprintf(`Repository "%s" not found.`, payload.Repository),
+		}
+	}
+
+	// Pull all managed instances of the repository, for example a different ref used by a project feature.
+	// The task is enqueued and retried, if the pull fails, see repositoryRefreshHandler.
+	// Other API nodes are notified through etcd.
+	_, err = s.tasks.EnqueueTask(ctx, task.QueueTaskConfig{
+		Type: RepositoryRefreshTaskType,
+		Key: task.Key{
+			SystemTask: true,
+			TaskID:     task.ID(RepositoryRefreshTaskType),
+		},
+		Timeout: s.currentTaskTimeout(),
+		Input:   repositoryRefreshInput{Repository: repoRef.Name},
+	})
+	return err
 }
 
 func (s *service) TemplatesIndex(ctx context.Context, d dependencies.ProjectRequestScope, payload *TemplatesIndexPayload) (res *Templates, err error) {
@@ -140,7 +281,12 @@ func (s *service) InputsIndex(ctx context.Context, d dependencies.ProjectRequest
 	if err != nil {
 		return nil, err
 	}
-	return InputsResponse(ctx, d, tmpl.Inputs().ToExtended()), nil
+
+	res = InputsResponse(ctx, d, tmpl.Inputs().ToExtended())
+	if payload.Format == InputsFormatJSONSchema {
+		res.JSONSchema = tmpl.Inputs().JSONSchema()
+	}
+	return res, nil
 }
 
 func (s *service) ValidateInputs(ctx context.Context, d dependencies.ProjectRequestScope, payload *ValidateInputsPayload) (res *ValidationResult, err error) {
@@ -155,12 +301,17 @@ func (s *service) ValidateInputs(ctx context.Context, d dependencies.ProjectRequ
 }
 
 func (s *service) UseTemplateVersion(ctx context.Context, d dependencies.ProjectRequestScope, payload *UseTemplateVersionPayload) (res *Task, err error) {
-	// Lock project
-	if unlockFn, err := tryLockProject(ctx, d); err != nil {
+	// Lock project, the lock is held until the task is finished
+	unlockFn, err := tryLockProject(ctx, d)
+	if err != nil {
 		return nil, err
-	} else {
-		defer unlockFn(ctx)
 	}
+	taskStarted := false
+	defer func() {
+		if !taskStarted {
+			unlockFn(ctx)
+		}
+	}()
 
 	// Note:
 	//   A very strange code follows.
@@ -178,18 +329,21 @@ func (s *service) UseTemplateVersion(ctx context.Context, d dependencies.Project
 		return nil, err
 	}
 
-	// Process inputs
-	result, values, err := validateInputs(ctx, tmpl.Inputs(), payload.Steps)
-	if err != nil {
-		return nil, err
-	}
-	if !result.Valid {
-		return nil, &ValidationError{
-			Name:             "InvalidInputs",
-			Message:          "Inputs are not valid.",
-			ValidationResult: result,
+	// Validate prerequisites and inputs before the task is started,
+	// the prerequisites are resolved again in the task, against the state in which they are installed.
+	var prjState *project.State
+	if len(tmpl.TemplateRecord().Requirements.Templates) > 0 {
+		if prjState, err = loadBranchState(ctx, d, branchKey); err != nil {
+			return nil, err
 		}
 	}
+	if _, _, err := resolveUseInputs(ctx, d, prjState, branchKey, tmpl, payload.Steps); err != nil {
+		return nil, err
+	}
+
+	record := audit.FromContext(ctx)
+	record.SetObjectKey(d.ProjectID().String() + "/" + branchKey.ID.String())
+	record.SetAfter(templateSummary(tmpl, payload.Name))
 
 	tKey := task.Key{
 		ProjectID: d.ProjectID(),
@@ -200,28 +354,25 @@ func (s *service) UseTemplateVersion(ctx context.Context, d dependencies.Project
 		Type: TemplateUseTaskType,
 		Key:  tKey,
 		Context: func() (context.Context, context.CancelFunc) {
-			return context.WithTimeout(context.Background(), 5*time.Minute)
+			return context.WithTimeout(context.Background(), s.currentTaskTimeout())
 		},
 		Operation: func(ctx context.Context, logger log.Logger) task.Result {
-			// Create virtual fs, after refactoring it will be removed
-			fs := aferofs.NewMemoryFs(filesystem.WithLogger(d.Logger()))
-
-			// Create fake manifest
-			m := project.NewManifest(123, "foo")
+			defer unlockFn(ctx)
 
 			// Load all from the target branch, we need shared codes
-			m.Filter().SetAllowedBranches(model.AllowedBranches{model.AllowedBranch(cast.ToString(branchKey.ID))})
-			prj := project.NewWithManifest(ctx, fs, m)
-
-			// Load project state
-			prjState, err := prj.LoadState(loadState.Options{LoadRemoteState: true}, d)
+			task.SetProgress(ctx, 0, "load", "Loading the branch.")
+			prjState, err := loadBranchState(ctx, d, branchKey)
 			if err != nil {
 				return task.ErrResult(err)
 			}
 
-			// Copy remote state to the local
-			for _, objectState := range prjState.All() {
-				objectState.SetLocalState(deepcopy.Copy(objectState.RemoteState()).(model.Object))
+			// Resolve and install or upgrade required templates, in the same state
+			prerequisites, values, err := resolveUseInputs(ctx, d, prjState, branchKey, tmpl, payload.Steps)
+			if err != nil {
+				return task.ErrResult(err)
+			}
+			if _, err := prerequisitesTemplate.Install(ctx, prjState, branchKey, prerequisites, d); err != nil {
+				return task.ErrResult(err)
 			}
 
 			// Options
@@ -232,16 +383,21 @@ func (s *service) UseTemplateVersion(ctx context.Context, d dependencies.Project
 			}
 
 			// Use template
+			task.SetProgress(ctx, 30, "use", "Generating configurations from the template.")
 			opResult, err := useTemplate.Run(ctx, prjState, tmpl, options, d)
 			if err != nil {
 				return task.ErrResult(err)
 			}
 
 			// Push changes
+			record := newHistoryRecord(branchKey, opResult.InstanceID, payload.Name, storeModel.InstanceOperationUse, tmpl, values)
 			changeDesc := fmt.Sprintf("From template %s", tmpl.FullName())
+			task.SetProgress(ctx, 60, "push", "Pushing changes to the project.")
 			if err := push.Run(ctx, prjState, push.Options{ChangeDescription: changeDesc, SkipValidation: true}, d); err != nil {
+				addHistoryRecord(ctx, d, record, err)
 				return task.ErrResult(err)
 			}
+			addHistoryRecord(ctx, d, record, nil)
 
 			return task.
 				OkResult(fmt.Sprintf(`template instance with id "%s" created`, opResult.InstanceID)).
@@ -251,9 +407,26 @@ func (s *service) UseTemplateVersion(ctx context.Context, d dependencies.Project
 	if err != nil {
 		return nil, err
 	}
+	taskStarted = true
+	audit.FromContext(ctx).SetTaskID(t.TaskID.String())
 	return s.mapper.TaskPayload(t), nil
 }
 
+func (s *service) InputLookup(ctx context.Context, d dependencies.ProjectRequestScope, payload *InputLookupPayload) (res *InputLookupResult, err error) {
+	branchKey, err := getBranch(ctx, d, payload.Branch)
+	if err != nil {
+		return nil, err
+	}
+
+	kind := input.Kind(payload.Kind)
+	storage, err := input.LoadStorageObjects(ctx, d.KeboolaProjectAPI(), branchKey.ID, input.Kinds{kind})
+	if err != nil {
+		return nil, err
+	}
+
+	return InputLookupResponse(kind, storage.Options(kind)), nil
+}
+
 func (s *service) InstancesIndex(ctx context.Context, d dependencies.ProjectRequestScope, payload *InstancesIndexPayload) (res *Instances, err error) {
 	branchKey, err := getBranch(ctx, d, payload.Branch)
 	if err != nil {
@@ -326,6 +499,10 @@ func (s *service) UpdateInstance(ctx context.Context, d dependencies.ProjectRequ
 		NewName:  payload.Name,
 	}
 
+	after := instanceSummary(instance)
+	after["name"] = payload.Name
+	auditInstance(ctx, d, branchKey, payload.InstanceID, instanceSummary(instance), after)
+
 	err = renameInst.Run(ctx, prjState, opts, d)
 	if err != nil {
 		return nil, err
@@ -337,28 +514,48 @@ func (s *service) UpdateInstance(ctx context.Context, d dependencies.ProjectRequ
 		return nil, err
 	}
 
+	// Add the rename to the history, version and inputs are not modified
+	if records, err := instanceHistory(ctx, d, branchKey, payload.InstanceID); err != nil {
+		d.Logger().Warnf(ctx, `cannot load history of the template instance "%s": %s`, payload.InstanceID, err)
+	} else if len(records) > 0 {
+		addHistoryRecord(ctx, d, renameHistoryRecord(records[len(records)-1], payload.Name), nil)
+	}
+
 	return InstanceResponse(ctx, d, prjState, branchKey, payload.InstanceID)
 }
 
 func (s *service) DeleteInstance(ctx context.Context, d dependencies.ProjectRequestScope, payload *DeleteInstancePayload) (*Task, error) {
-	// Lock project
-	if unlockFn, err := tryLockProject(ctx, d); err != nil {
+	// Lock project, the lock is held until the task is finished
+	unlockFn, err := tryLockProject(ctx, d)
+	if err != nil {
 		return nil, err
-	} else {
-		defer unlockFn(ctx)
 	}
+	taskStarted := false
+	defer func() {
+		if !taskStarted {
+			unlockFn(ctx)
+		}
+	}()
 
 	// Get instance
-	prjState, branchKey, _, err := getTemplateInstance(ctx, d, payload.Branch, payload.InstanceID, true)
+	prjState, branchKey, instance, err := getTemplateInstance(ctx, d, payload.Branch, payload.InstanceID, true)
 	if err != nil {
 		return nil, err
 	}
+	auditInstance(ctx, d, branchKey, payload.InstanceID, instanceSummary(instance), nil)
+
+	// Configs converted to regular configs
+	keepConfigs, err := deleteTemplate.ParseConfigKeys(branchKey, payload.KeepConfigs)
+	if err != nil {
+		return nil, NewBadRequestError(err)
+	}
 
 	// Delete template instance
 	deleteOpts := deleteTemplate.Options{
-		Branch:   branchKey,
-		DryRun:   false,
-		Instance: payload.InstanceID,
+		Branch:      branchKey,
+		DryRun:      false,
+		Instance:    payload.InstanceID,
+		KeepConfigs: keepConfigs,
 	}
 
 	taskKey := task.Key{
@@ -370,11 +567,12 @@ func (s *service) DeleteInstance(ctx context.Context, d dependencies.ProjectRequ
 		Type: TemplateDeleteTaskType,
 		Key:  taskKey,
 		Context: func() (context.Context, context.CancelFunc) {
-			return context.WithTimeout(context.Background(), time.Minute*5)
+			return context.WithTimeout(context.Background(), s.currentTaskTimeout())
 		},
 		Operation: func(ctx context.Context, logger log.Logger) task.Result {
-			err = deleteTemplate.Run(ctx, prjState, deleteOpts, d)
-			if err != nil {
+			defer unlockFn(ctx)
+
+			if err := deleteTemplate.Run(ctx, prjState, deleteOpts, d); err != nil {
 				return task.ErrResult(err)
 			}
 
@@ -384,6 +582,12 @@ func (s *service) DeleteInstance(ctx context.Context, d dependencies.ProjectRequ
 				return task.ErrResult(err)
 			}
 
+			// Delete history of the instance
+			historyKey := storeModel.InstanceKey{ProjectID: d.ProjectID(), BranchID: branchKey.ID, InstanceID: payload.InstanceID}
+			if err := d.Store().DeleteInstanceHistory(ctx, historyKey); err != nil {
+				logger.Warnf(ctx, `cannot delete history of the template instance "%s": %s`, payload.InstanceID, err)
+			}
+
 			return task.OkResult(fmt.Sprintf(`"template instance with id "%s" is deleted"`, deleteOpts.Instance)).
 				WithOutput("instanceId", deleteOpts.Instance)
 		},
@@ -391,16 +595,45 @@ func (s *service) DeleteInstance(ctx context.Context, d dependencies.ProjectRequ
 	if err != nil {
 		return nil, err
 	}
+	taskStarted = true
+	audit.FromContext(ctx).SetTaskID(t.TaskID.String())
 	return s.mapper.TaskPayload(t), err
 }
 
+func (s *service) DeleteInstancePreview(ctx context.Context, d dependencies.ProjectRequestScope, payload *DeleteInstancePreviewPayload) (*InstanceDeletePreview, error) {
+	// Get instance
+	prjState, branchKey, _, err := getTemplateInstance(ctx, d, payload.Branch, payload.InstanceID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// Configs converted to regular configs
+	keepConfigs, err := deleteTemplate.ParseConfigKeys(branchKey, payload.KeepConfigs)
+	if err != nil {
+		return nil, NewBadRequestError(err)
+	}
+
+	// Plan the deletion, nothing is modified
+	plan, err := deleteTemplate.Plan(prjState, deleteTemplate.Options{Branch: branchKey, Instance: payload.InstanceID, KeepConfigs: keepConfigs})
+	if err != nil {
+		return nil, NewBadRequestError(err)
+	}
+
+	return DeletePreviewResponse(plan), nil
+}
+
 func (s *service) UpgradeInstance(ctx context.Context, d dependencies.ProjectRequestScope, payload *UpgradeInstancePayload) (res *Task, err error) {
-	// Lock project
-	if unlockFn, err := tryLockProject(ctx, d); err != nil {
+	// Lock project, the lock is held until the task is finished
+	unlockFn, err := tryLockProject(ctx, d)
+	if err != nil {
 		return nil, err
-	} else {
-		defer unlockFn(ctx)
 	}
+	taskStarted := false
+	defer func() {
+		if !taskStarted {
+			unlockFn(ctx)
+		}
+	}()
 
 	// Get instance
 	prjState, branchKey, instance, err := getTemplateInstance(ctx, d, payload.Branch, payload.InstanceID, true)
@@ -427,6 +660,8 @@ func (s *service) UpgradeInstance(ctx context.Context, d dependencies.ProjectReq
 		}
 	}
 
+	auditInstance(ctx, d, branchKey, instance.InstanceID, instanceSummary(instance), templateSummary(tmpl, instance.InstanceName))
+
 	tKey := task.Key{
 		ProjectID: d.ProjectID(),
 		TaskID:    task.ID(TemplateUpgradeTaskType),
@@ -436,25 +671,32 @@ func (s *service) UpgradeInstance(ctx context.Context, d dependencies.ProjectReq
 		Type: TemplateUpgradeTaskType,
 		Key:  tKey,
 		Context: func() (context.Context, context.CancelFunc) {
-			return context.WithTimeout(context.Background(), 5*time.Minute)
+			return context.WithTimeout(context.Background(), s.currentTaskTimeout())
 		},
 		Operation: func(ctx context.Context, logger log.Logger) task.Result {
+			defer unlockFn(ctx)
+
 			// Upgrade template instance
 			upgradeOpts := upgradeTemplate.Options{
 				Branch:   branchKey,
 				Instance: *instance,
 				Inputs:   values,
 			}
-			_, err = upgradeTemplate.Run(ctx, prjState, tmpl, upgradeOpts, d)
-			if err != nil {
+			record := newHistoryRecord(branchKey, instance.InstanceID, instance.InstanceName, storeModel.InstanceOperationUpgrade, tmpl, values)
+			task.SetProgress(ctx, 0, "upgrade", "Generating configurations from the template.")
+			if _, err := upgradeTemplate.Run(ctx, prjState, tmpl, upgradeOpts, d); err != nil {
+				addHistoryRecord(ctx, d, record, err)
 				return task.ErrResult(err)
 			}
 
 			// Push changes
 			changeDesc := fmt.Sprintf("Upgraded from template %s", tmpl.FullName())
+			task.SetProgress(ctx, 50, "push", "Pushing changes to the project.")
 			if err := push.Run(ctx, prjState, push.Options{ChangeDescription: changeDesc, AllowRemoteDelete: true, DryRun: false, SkipValidation: true}, d); err != nil {
+				addHistoryRecord(ctx, d, record, err)
 				return task.ErrResult(err)
 			}
+			addHistoryRecord(ctx, d, record, nil)
 
 			return task.
 				OkResult(fmt.Sprintf(`template instance with id "%s" upgraded`, instance.InstanceID)).
@@ -464,6 +706,8 @@ func (s *service) UpgradeInstance(ctx context.Context, d dependencies.ProjectReq
 	if err != nil {
 		return nil, err
 	}
+	taskStarted = true
+	audit.FromContext(ctx).SetTaskID(t.TaskID.String())
 	return s.mapper.TaskPayload(t), nil
 }
 
@@ -501,6 +745,137 @@ func (s *service) UpgradeInstanceValidateInputs(ctx context.Context, d dependenc
 	})
 }
 
+func (s *service) InstanceHistoryIndex(ctx context.Context, d dependencies.ProjectRequestScope, payload *InstanceHistoryIndexPayload) (res *InstanceHistory, err error) {
+	// Get instance
+	_, branchKey, _, err := getTemplateInstance(ctx, d, payload.Branch, payload.InstanceID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := instanceHistory(ctx, d, branchKey, payload.InstanceID)
+	if err != nil {
+		return nil, err
+	}
+	return InstanceHistoryResponse(records), nil
+}
+
+func (s *service) RollbackInstancePreview(ctx context.Context, d dependencies.ProjectRequestScope, payload *RollbackInstancePreviewPayload) (res *InstanceRollbackPreview, err error) {
+	// Get instance
+	prjState, branchKey, instance, err := getTemplateInstance(ctx, d, payload.Branch, payload.InstanceID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the previous state
+	target, tmpl, err := getRollbackTarget(ctx, d, branchKey, instance.InstanceID)
+	if err != nil {
+		return nil, err
+	}
+	values, err := rollbackInputs(ctx, d, prjState, branchKey, instance.InstanceID, tmpl, target)
+	if err != nil {
+		return nil, err
+	}
+
+	// Re-generate the instance only in the local state, nothing is pushed
+	upgradeOpts := upgradeTemplate.Options{
+		Branch:      branchKey,
+		Instance:    *instance,
+		Inputs:      values,
+		SkipEncrypt: true,
+	}
+	if _, err := upgradeTemplate.Run(ctx, prjState, tmpl, upgradeOpts, d); err != nil {
+		return nil, err
+	}
+
+	// Compare the local state (after rollback) with the remote state (current)
+	results, err := diff.NewDiffer(prjState).Diff()
+	if err != nil {
+		return nil, err
+	}
+	return RollbackPreviewResponse(target, results), nil
+}
+
+func (s *service) RollbackInstance(ctx context.Context, d dependencies.ProjectRequestScope, payload *RollbackInstancePayload) (res *Task, err error) {
+	// Lock project, the lock is held until the task is finished
+	unlockFn, err := tryLockProject(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+	taskStarted := false
+	defer func() {
+		if !taskStarted {
+			unlockFn(ctx)
+		}
+	}()
+
+	// Get instance
+	prjState, branchKey, instance, err := getTemplateInstance(ctx, d, payload.Branch, payload.InstanceID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the previous state
+	target, tmpl, err := getRollbackTarget(ctx, d, branchKey, instance.InstanceID)
+	if err != nil {
+		return nil, err
+	}
+	values, err := rollbackInputs(ctx, d, prjState, branchKey, instance.InstanceID, tmpl, target)
+	if err != nil {
+		return nil, err
+	}
+
+	auditInstance(ctx, d, branchKey, instance.InstanceID, instanceSummary(instance), templateSummary(tmpl, instance.InstanceName))
+
+	tKey := task.Key{
+		ProjectID: d.ProjectID(),
+		TaskID:    task.ID(TemplateRollbackTaskType),
+	}
+
+	t, err := s.tasks.StartTask(ctx, task.Config{
+		Type: TemplateRollbackTaskType,
+		Key:  tKey,
+		Context: func() (context.Context, context.CancelFunc) {
+			return context.WithTimeout(context.Background(), s.currentTaskTimeout())
+		},
+		Operation: func(ctx context.Context, logger log.Logger) task.Result {
+			defer unlockFn(ctx)
+
+			// Re-generate the instance in the previous version
+			upgradeOpts := upgradeTemplate.Options{
+				Branch:   branchKey,
+				Instance: *instance,
+				Inputs:   values,
+			}
+			record := newHistoryRecord(branchKey, instance.InstanceID, instance.InstanceName, storeModel.InstanceOperationRollback, tmpl, values)
+			task.SetProgress(ctx, 0, "rollback", "Generating configurations from the previous version.")
+			if _, err := upgradeTemplate.Run(ctx, prjState, tmpl, upgradeOpts, d); err != nil {
+				addHistoryRecord(ctx, d, record, err)
+				return task.ErrResult(err)
+			}
+
+			// Push changes
+			changeDesc := fmt.Sprintf("Rolled back to template %s", tmpl.FullName())
+			task.SetProgress(ctx, 50, "push", "Pushing changes to the project.")
+			if err := push.Run(ctx, prjState, push.Options{ChangeDescription: changeDesc, AllowRemoteDelete: true, DryRun: false, SkipValidation: true}, d); err != nil {
+				addHistoryRecord(ctx, d, record, err)
+				return task.ErrResult(err)
+			}
+			addHistoryRecord(ctx, d, record, nil)
+
+			return task.
+				OkResult(fmt.Sprintf(`template instance with id "%s" rolled back to version "%s"`, instance.InstanceID, tmpl.Version())).
+				WithOutput("instanceId", instance.InstanceID).
+				WithOutput("version", tmpl.Version())
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	taskStarted = true
+	audit.FromContext(ctx).SetTaskID(t.TaskID.String())
+	return s.mapper.TaskPayload(t), nil
+}
+
 func (s *service) GetTask(ctx context.Context, d dependencies.ProjectRequestScope, payload *GetTaskPayload) (res *Task, err error) {
 	t, err := s.tasks.GetTask(task.Key{ProjectID: d.ProjectID(), TaskID: payload.TaskID}).Do(ctx).ResultOrErr()
 	if err != nil {
@@ -509,6 +884,36 @@ func (s *service) GetTask(ctx context.Context, d dependencies.ProjectRequestScop
 	return s.mapper.TaskPayload(t), nil
 }
 
+func (s *service) CancelTask(ctx context.Context, d dependencies.ProjectRequestScope, payload *CancelTaskPayload) (res *Task, err error) {
+	audit.FromContext(ctx).SetTaskID(payload.TaskID.String())
+	t, err := s.tasks.CancelTask(ctx, task.Key{ProjectID: d.ProjectID(), TaskID: payload.TaskID})
+	if err != nil {
+		return nil, err
+	}
+	return s.mapper.TaskPayload(t), nil
+}
+
+// verifyWebhookSignature checks the "sha256=<hex>" HMAC signature of the body, in the format used by GitHub.
+func verifyWebhookSignature(secret string, body []byte, signature string) bool {
+	expected, found := strings.CutPrefix(signature, "sha256=")
+	if !found {
+		return false
+	}
+	expectedMAC, err := hex.DecodeString(expected)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expectedMAC)
+}
+
+func repositoryRefreshResult(m *manager.Manager, ref model.TemplateRepository) task.Result {
+	status, _ := m.RepositoryStatus(ref)
+	return task.OkResult(fmt.Sprintf(`repository "%s" is up to date`, ref.Name)).
+		WithOutput("commitHash", status.CommitHash)
+}
+
 func repositoryRef(d dependencies.ProjectRequestScope, name string) (model.TemplateRepository, error) {
 	if repo, found := d.ProjectRepositories().Get(name); found {
 		return repo, nil
@@ -691,6 +1096,65 @@ func getTemplateInstance(ctx context.Context, d dependencies.ProjectRequestScope
 	return prjState, branchKey, instance, nil
 }
 
+// loadBranchState loads all objects from the branch, the remote state is copied to the local state.
+// resolveUseInputs resolves the required templates in the project state, they are installed or upgraded with default inputs.
+// Values passed from the required templates are added to the inputs, and all inputs are validated.
+// The project state is used only if the template has some requirements.
+func resolveUseInputs(ctx context.Context, d dependencies.ProjectRequestScope, prjState *project.State, branchKey model.BranchKey, tmpl *template.Template, steps []*StepPayload) (prerequisitesTemplate.Prerequisites, template.InputsValues, error) {
+	var prerequisites prerequisitesTemplate.Prerequisites
+	if requirements := tmpl.TemplateRecord().Requirements.Templates; len(requirements) > 0 {
+		var err error
+		prerequisites, err = prerequisitesTemplate.Resolve(ctx, prjState, branchKey, tmpl, prerequisitesTemplate.DefaultInputs, d)
+		if err != nil {
+			return nil, nil, NewBadRequestError(err)
+		}
+		mappedValues, err := prerequisites.InputsFor(requirements)
+		if err != nil {
+			return nil, nil, NewBadRequestError(err)
+		}
+		steps = withMappedInputs(tmpl.Inputs(), steps, mappedValues)
+	}
+
+	// Process inputs
+	result, values, err := validateInputs(ctx, tmpl.Inputs(), steps)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !result.Valid {
+		return nil, nil, &ValidationError{
+			Name:             "InvalidInputs",
+			Message:          "Inputs are not valid.",
+			ValidationResult: result,
+		}
+	}
+	return prerequisites, values, nil
+}
+
+func loadBranchState(ctx context.Context, d dependencies.ProjectRequestScope, branchKey model.BranchKey) (*project.State, error) {
+	// Create virtual fs, after refactoring it will be removed
+	fs := aferofs.NewMemoryFs(filesystem.WithLogger(d.Logger()))
+
+	// Create fake manifest
+	m := project.NewManifest(123, "foo")
+
+	// Only one branch
+	m.Filter().SetAllowedBranches(model.AllowedBranches{model.AllowedBranch(cast.ToString(branchKey.ID))})
+	prj := project.NewWithManifest(ctx, fs, m)
+
+	// Load project state
+	prjState, err := prj.LoadState(loadState.Options{LoadRemoteState: true}, d)
+	if err != nil {
+		return nil, err
+	}
+
+	// Copy remote state to the local
+	for _, objectState := range prjState.All() {
+		objectState.SetLocalState(deepcopy.Copy(objectState.RemoteState()).(model.Object))
+	}
+
+	return prjState, nil
+}
+
 // tryLockProject.
 func tryLockProject(ctx context.Context, d dependencies.ProjectRequestScope) (unlock func(ctx context.Context), err error) {
 	d.Logger().Infof(ctx, `requested lock for project "%d"`, d.ProjectID())
@@ -712,6 +1176,9 @@ func tryLockProject(ctx context.Context, d dependencies.ProjectRequestScope) (un
 
 	// Locked!
 	unlockFn := func(ctx context.Context) {
+		// The context may be already done, for example after the task timeout, the lock must be released anyway
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), ProjectUnlockTimeout)
+		defer cancel()
 		if err := mutex.Unlock(ctx); err != nil {
 			d.Logger().Warnf(ctx, `cannot unlock project "%d": %s`, d.ProjectID(), err)
 		}
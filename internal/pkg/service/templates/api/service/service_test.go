@@ -2,16 +2,24 @@ package service
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/keboola/go-client/pkg/keboola"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	commonDeps "github.com/keboola/keboola-as-code/internal/pkg/service/common/dependencies"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop/serde"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/templates/api/config"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/templates/api/gen/templates"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/templates/dependencies"
+	storeModel "github.com/keboola/keboola-as-code/internal/pkg/service/templates/store/model"
 )
 
 func Test_getTemplateVersion_Requirements(t *testing.T) {
@@ -98,3 +106,101 @@ func Test_getTemplateVersion_Requirements(t *testing.T) {
 		})
 	}
 }
+
+func Test_verifyWebhookSignature(t *testing.T) {
+	t.Parallel()
+
+	secret := "my-secret"
+	body := []byte(`{"ref":"refs/heads/main"}`)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	assert.True(t, verifyWebhookSignature(secret, body, signature))
+	assert.False(t, verifyWebhookSignature("other-secret", body, signature))
+	assert.False(t, verifyWebhookSignature(secret, []byte(`{}`), signature))
+	assert.False(t, verifyWebhookSignature(secret, body, strings.TrimPrefix(signature, "sha256=")))
+	assert.False(t, verifyWebhookSignature(secret, body, "sha256=foo"))
+	assert.False(t, verifyWebhookSignature(secret, body, ""))
+}
+
+func TestRepositoryWebhook_BodyTooLarge(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.New()
+	cfg.API.RepositoryWebhookSecret = "my-secret"
+	s := &service{config: cfg}
+
+	body := io.NopCloser(io.LimitReader(zeroReader{}, int64(RepositoryWebhookMaxBodySize.Bytes())+1))
+	err := s.RepositoryWebhook(context.Background(), nil, &templates.RepositoryWebhookRequest{Repository: "keboola"}, body)
+	if assert.Error(t, err) {
+		assert.Equal(t, "request body is too large, the maximum size is 25MB", err.Error())
+	}
+}
+
+func TestNew_Migration(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	d, mock := dependencies.NewMockedProjectRequestScope(t, ctx, config.New())
+	client := d.EtcdClient()
+
+	// History record in the version 1
+	key := "template/instance/history/123/456/inst1/2000-01-01T00:00:00.000Z"
+	_, err := client.Put(ctx, key, `{"projectId":123,"branchId":456,"instanceId":"inst1","created":"2000-01-01T00:00:00.000Z","operation":"use","repositoryName":"keboola","templateId":"tmpl1","version":"1.0.0"}`)
+	require.NoError(t, err)
+
+	// The version 2 is registered
+	serde.RegisterMigrations[storeModel.InstanceHistoryRecord](d.EtcdSerde(), serde.Migration{
+		Version: 2,
+		Migrate: func(ctx context.Context, value map[string]any) error {
+			return nil
+		},
+	})
+
+	// The service migrates the value on startup
+	_, err = New(ctx, d)
+	require.NoError(t, err)
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		resp, err := client.Get(ctx, key)
+		require.NoError(c, err)
+		require.Len(c, resp.Kvs, 1)
+		assert.Contains(c, string(resp.Kvs[0].Value), `{"$schemaVersion":2,`)
+	}, 5*time.Second, 10*time.Millisecond)
+	mock.DebugLogger().AssertJSONMessages(t, `{"level":"info","message":"migrated 1 values under the prefix \"template/instance/history/\"","component":"api.etcd.migration"}`)
+}
+
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
+
+func Test_tryLockProject_UnlockAfterContextDone(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	d, _ := dependencies.NewMockedProjectRequestScope(t, ctx, config.New())
+
+	// Lock the project
+	taskCtx, taskCancel := context.WithCancel(ctx)
+	unlockFn, err := tryLockProject(taskCtx, d)
+	assert.NoError(t, err)
+
+	// The project is locked
+	_, err = tryLockProject(ctx, d)
+	assert.Error(t, err)
+
+	// The lock is released, even if the task context is already done, for example after the task timeout
+	taskCancel()
+	unlockFn(taskCtx)
+	unlockFn, err = tryLockProject(ctx, d)
+	if assert.NoError(t, err) {
+		unlockFn(ctx)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/benbjohnson/clock"
 
 	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/configmap"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/dependencies"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/distlock"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/distribution"
@@ -32,8 +33,9 @@ type apiScope struct {
 	dependencies.DistributionScope
 	dependencies.DistributedLockScope
 	dependencies.TaskScope
+	dependencies.AuditScope
 	logger            log.Logger
-	config            config.Config
+	configReloader    *configmap.Reloader[config.Config]
 	schema            *schema.Schema
 	store             *store.Store
 	repositoryManager *repositoryManager.Manager
@@ -46,22 +48,23 @@ type parentScopes struct {
 	dependencies.DistributionScope
 	dependencies.DistributedLockScope
 	dependencies.TaskScope
+	dependencies.AuditScope
 }
 
 func NewAPIScope(
 	ctx context.Context,
-	cfg config.Config,
+	configReloader *configmap.Reloader[config.Config],
 	proc *servicectx.Process,
 	logger log.Logger,
 	tel telemetry.Telemetry,
 	stdout io.Writer,
 	stderr io.Writer,
 ) (v APIScope, err error) {
-	parentSc, err := newParentScopes(ctx, cfg, proc, logger, tel, stdout, stderr)
+	parentSc, err := newParentScopes(ctx, configReloader.Current(), proc, logger, tel, stdout, stderr)
 	if err != nil {
 		return nil, err
 	}
-	return newAPIScope(ctx, parentSc, cfg)
+	return newAPIScope(ctx, parentSc, configReloader)
 }
 
 func newParentScopes(
@@ -119,13 +122,20 @@ func newParentScopes(
 		return nil, err
 	}
 
+	d.AuditScope, err = dependencies.NewAuditScope(ctx, cfg.API.Audit, d)
+	if err != nil {
+		return nil, err
+	}
+
 	return d, nil
 }
 
-func newAPIScope(ctx context.Context, p *parentScopes, cfg config.Config) (v *apiScope, err error) {
+func newAPIScope(ctx context.Context, p *parentScopes, configReloader *configmap.Reloader[config.Config]) (v *apiScope, err error) {
 	ctx, span := p.Telemetry().Tracer().Start(ctx, "keboola.go.templates.api.dependencies.NewAPIScope")
 	defer span.End(&err)
 
+	cfg := configReloader.Current()
+
 	d := &apiScope{}
 
 	d.BaseScope = p.BaseScope
@@ -140,9 +150,11 @@ func newAPIScope(ctx context.Context, p *parentScopes, cfg config.Config) (v *ap
 
 	d.TaskScope = p.TaskScope
 
+	d.AuditScope = p.AuditScope
+
 	d.logger = p.Logger().WithComponent("api")
 
-	d.config = cfg
+	d.configReloader = configReloader
 
 	d.schema = schema.New(d)
 
@@ -160,8 +172,13 @@ func (v *apiScope) Logger() log.Logger {
 	return v.logger
 }
 
+// APIConfig returns the current configuration, fields with the configReload tag can be changed by a reload.
 func (v *apiScope) APIConfig() config.Config {
-	return v.config
+	return v.configReloader.Current()
+}
+
+func (v *apiScope) ConfigReloader() *configmap.Reloader[config.Config] {
+	return v.configReloader
 }
 
 func (v *apiScope) Schema() *schema.Schema {
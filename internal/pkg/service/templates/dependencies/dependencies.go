@@ -30,6 +30,7 @@ import (
 	"context"
 
 	"github.com/keboola/keboola-as-code/internal/pkg/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/configmap"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/dependencies"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/templates/api/config"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/templates/store"
@@ -55,7 +56,9 @@ type APIScope interface {
 	dependencies.DistributionScope
 	dependencies.DistributedLockScope
 	dependencies.TaskScope
+	dependencies.AuditScope
 	APIConfig() config.Config
+	ConfigReloader() *configmap.Reloader[config.Config]
 	Schema() *schema.Schema
 	Store() *store.Store
 	RepositoryManager() *repositoryManager.Manager
@@ -68,7 +68,10 @@ func NewMockedAPIScope(tb testing.TB, ctx context.Context, cfg config.Config, op
 	p.TaskScope, err = dependencies.NewTaskScope(ctx, cfg.NodeID, exceptionIDPrefix, mock, mock, p.DistributionScope, cfg.API.Task)
 	require.NoError(tb, err)
 
-	apiScp, err := newAPIScope(ctx, p, cfg)
+	p.AuditScope, err = dependencies.NewAuditScope(ctx, cfg.API.Audit, mock)
+	require.NoError(tb, err)
+
+	apiScp, err := newAPIScope(ctx, p, configmap.NewReloader(cfg, nil))
 	require.NoError(tb, err)
 
 	mock.DebugLogger().Truncate()
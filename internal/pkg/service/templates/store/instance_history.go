@@ -0,0 +1,55 @@
+package store
+
+import (
+	"context"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/utctime"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/templates/store/model"
+)
+
+// InstanceHistoryLimit is maximum number of history records stored per template instance, older records are deleted.
+const InstanceHistoryLimit = 20
+
+// AddInstanceHistoryRecord stores the record and deletes records over the InstanceHistoryLimit.
+// The Created field is set to the current time, if it is empty.
+func (s *Store) AddInstanceHistoryRecord(ctx context.Context, record model.InstanceHistoryRecord) (err error) {
+	ctx, span := s.tracer.Start(ctx, "keboola.go.templates.store.AddInstanceHistoryRecord")
+	defer span.End(&err)
+
+	if record.Created.IsZero() {
+		record.Created = utctime.From(s.clock.Now())
+	}
+
+	if err := s.schema.InstanceHistory().ByKey(record).Put(s.client, record).Do(ctx).Err(); err != nil {
+		return err
+	}
+
+	// Delete the oldest records over the limit
+	kvs, err := s.schema.InstanceHistory().InInstance(record.InstanceKey).GetAll(s.client).Do(ctx).AllKVs()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(kvs)-InstanceHistoryLimit; i++ {
+		if err := s.schema.InstanceHistory().ByKey(kvs[i].Value).Delete(s.client).Do(ctx).Err(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// InstanceHistory returns history records of the template instance, from the oldest to the newest.
+func (s *Store) InstanceHistory(ctx context.Context, k model.InstanceKey) (out []model.InstanceHistoryRecord, err error) {
+	ctx, span := s.tracer.Start(ctx, "keboola.go.templates.store.InstanceHistory")
+	defer span.End(&err)
+
+	return s.schema.InstanceHistory().InInstance(k).GetAll(s.client).Do(ctx).All()
+}
+
+// DeleteInstanceHistory deletes all history records of the template instance, for example when the instance is deleted.
+func (s *Store) DeleteInstanceHistory(ctx context.Context, k model.InstanceKey) (err error) {
+	ctx, span := s.tracer.Start(ctx, "keboola.go.templates.store.DeleteInstanceHistory")
+	defer span.End(&err)
+
+	return s.schema.InstanceHistory().InInstance(k).DeleteAll(s.client).Do(ctx).Err()
+}
@@ -0,0 +1,68 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/utctime"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/templates/api/config"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/templates/dependencies"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/templates/store"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/templates/store/model"
+)
+
+func TestStore_InstanceHistory(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	d, _ := dependencies.NewMockedAPIScope(t, ctx, config.New())
+	s := d.Store()
+
+	instanceKey := model.InstanceKey{ProjectID: 123, BranchID: 456, InstanceID: "my-instance"}
+	otherKey := model.InstanceKey{ProjectID: 123, BranchID: 456, InstanceID: "other-instance"}
+	start := utctime.MustParse("2000-01-01T01:00:00.000Z")
+
+	// Add records over the limit
+	for i := 0; i < store.InstanceHistoryLimit+2; i++ {
+		require.NoError(t, s.AddInstanceHistoryRecord(ctx, model.InstanceHistoryRecord{
+			InstanceKey:    instanceKey,
+			Created:        start.Add(time.Duration(i) * time.Second),
+			Operation:      model.InstanceOperationUpgrade,
+			RepositoryName: "keboola",
+			TemplateID:     "my-template",
+			Version:        "1.0.0",
+			Inputs:         []model.InstanceInput{{ID: "input", Value: float64(i)}},
+		}))
+	}
+	require.NoError(t, s.AddInstanceHistoryRecord(ctx, model.InstanceHistoryRecord{
+		InstanceKey:    otherKey,
+		Created:        start,
+		Operation:      model.InstanceOperationUse,
+		RepositoryName: "keboola",
+		TemplateID:     "my-template",
+		Version:        "1.0.0",
+	}))
+
+	// The oldest records have been deleted, records are sorted from the oldest
+	records, err := s.InstanceHistory(ctx, instanceKey)
+	require.NoError(t, err)
+	require.Len(t, records, store.InstanceHistoryLimit)
+	assert.Equal(t, start.Add(2*time.Second), records[0].Created)
+	assert.Equal(t, float64(2), records[0].Inputs[0].Value)
+	assert.Equal(t, start.Add(time.Duration(store.InstanceHistoryLimit+1)*time.Second), records[len(records)-1].Created)
+
+	// Delete history, other instance is not affected
+	require.NoError(t, s.DeleteInstanceHistory(ctx, instanceKey))
+	records, err = s.InstanceHistory(ctx, instanceKey)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+	records, err = s.InstanceHistory(ctx, otherKey)
+	require.NoError(t, err)
+	assert.Len(t, records, 1)
+}
@@ -0,0 +1,66 @@
+// Package model contains entities stored by the Templates service in etcd.
+package model
+
+import (
+	"reflect"
+
+	"github.com/keboola/go-client/pkg/keboola"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/utctime"
+)
+
+const (
+	InstanceOperationUse      = InstanceOperation("use")
+	InstanceOperationUpgrade  = InstanceOperation("upgrade")
+	InstanceOperationUpdate   = InstanceOperation("update")
+	InstanceOperationRollback = InstanceOperation("rollback")
+)
+
+// InstanceOperation is an operation which modified a template instance.
+type InstanceOperation string
+
+// InstanceKey identifies a template instance in a project branch.
+type InstanceKey struct {
+	ProjectID  keboola.ProjectID `json:"projectId" validate:"required"`
+	BranchID   keboola.BranchID  `json:"branchId" validate:"required"`
+	InstanceID string            `json:"instanceId" validate:"required"`
+}
+
+// InstanceHistoryRecord is one operation in the history of a template instance.
+// Values of the hidden inputs, for example passwords, are not stored.
+type InstanceHistoryRecord struct {
+	InstanceKey
+	Created        utctime.UTCTime   `json:"created" validate:"required"`
+	TokenID        string            `json:"tokenId"`
+	Operation      InstanceOperation `json:"operation" validate:"required"`
+	RepositoryName string            `json:"repositoryName" validate:"required"`
+	TemplateID     string            `json:"templateId" validate:"required"`
+	Version        string            `json:"version" validate:"required"`
+	InstanceName   string            `json:"instanceName"`
+	Inputs         []InstanceInput   `json:"inputs,omitempty"`
+	// Error is set if the operation failed, the instance may be partially modified.
+	Error string `json:"error,omitempty"`
+}
+
+// InstanceInput is a value of a template input used by the operation.
+type InstanceInput struct {
+	ID      string `json:"id"`
+	Value   any    `json:"value,omitempty"`
+	Skipped bool   `json:"skipped,omitempty"`
+}
+
+func (v InstanceKey) String() string {
+	return v.ProjectID.String() + "/" + v.BranchID.String() + "/" + v.InstanceID
+}
+
+func (v InstanceHistoryRecord) Failed() bool {
+	return v.Error != ""
+}
+
+// SameState returns true if both records result in the same template version and inputs.
+func (v InstanceHistoryRecord) SameState(other InstanceHistoryRecord) bool {
+	return v.RepositoryName == other.RepositoryName &&
+		v.TemplateID == other.TemplateID &&
+		v.Version == other.Version &&
+		reflect.DeepEqual(v.Inputs, other.Inputs)
+}
@@ -0,0 +1,28 @@
+package schema
+
+import (
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/templates/store/model"
+)
+
+type (
+	// InstanceHistory is an etcd prefix that stores history records of all template instances.
+	InstanceHistory struct {
+		etcdop.PrefixT[model.InstanceHistoryRecord]
+	}
+	// InstanceHistoryInInstance is an etcd prefix that stores history records of the template instance.
+	// Records are sorted from the oldest to the newest by the key.
+	InstanceHistoryInInstance InstanceHistory
+)
+
+func (s *Schema) InstanceHistory() InstanceHistory {
+	return InstanceHistory{PrefixT: etcdop.NewTypedPrefix[model.InstanceHistoryRecord]("template/instance/history", s.serde)}
+}
+
+func (v InstanceHistory) InInstance(k model.InstanceKey) InstanceHistoryInInstance {
+	return InstanceHistoryInInstance{PrefixT: v.PrefixT.Add(k.String())}
+}
+
+func (v InstanceHistory) ByKey(r model.InstanceHistoryRecord) etcdop.KeyT[model.InstanceHistoryRecord] {
+	return v.InInstance(r.InstanceKey).PrefixT.Key(r.Created.String())
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/spf13/cast"
 	"golang.org/x/sync/semaphore"
 
+	"github.com/keboola/keboola-as-code/internal/pkg/encoding/json"
 	"github.com/keboola/keboola-as-code/internal/pkg/mapper"
 	"github.com/keboola/keboola-as-code/internal/pkg/model"
 	"github.com/keboola/keboola-as-code/internal/pkg/state/local"
@@ -55,6 +56,54 @@ func (m *Manager) Manifest() manifest.Manifest {
 	return m.localManager.Manifest()
 }
 
+// ToAPIForm converts the local state of the object to the form returned by the Storage API.
+// It is used to load objects from a local project instead of the API, see UnitOfWork.LoadFrom.
+func (m *Manager) ToAPIForm(ctx context.Context, objectState model.ObjectState) (model.Object, error) {
+	// Invoke mapper
+	apiObject := deepcopy.Copy(objectState.LocalState()).(model.Object)
+	recipe := model.NewRemoteSaveRecipe(objectState.Manifest(), apiObject, model.NewChangedFields())
+	if err := m.mapper.MapBeforeRemoteSave(ctx, recipe); err != nil {
+		return nil, err
+	}
+
+	// Convert to the API value and back, so only the values stored in the API are kept.
+	// Content is encoded to JSON and decoded back, to get the same types as from the API.
+	value, _ := recipe.Object.(model.ToAPIObject).ToAPIObject("", nil)
+	switch v := value.(type) {
+	case *keboola.Branch:
+		branch := model.NewBranch(v)
+		branch.Metadata = recipe.Object.(*model.Branch).Metadata
+		return branch, nil
+	case *keboola.Config:
+		config := model.NewConfig(v)
+		config.Metadata = recipe.Object.(*model.Config).Metadata
+		if err := normalizeContent(&config.Content); err != nil {
+			return nil, err
+		}
+		return config, nil
+	case *keboola.ConfigRow:
+		row := model.NewConfigRow(v)
+		if err := normalizeContent(&row.Content); err != nil {
+			return nil, err
+		}
+		return row, nil
+	default:
+		return nil, errors.Errorf(`unexpected type "%T"`, value)
+	}
+}
+
+func normalizeContent(content **orderedmap.OrderedMap) error {
+	if *content == nil {
+		return nil
+	}
+	out := orderedmap.New()
+	if err := json.ConvertByJSON(*content, out); err != nil {
+		return err
+	}
+	*content = out
+	return nil
+}
+
 func (m *Manager) NewUnitOfWork(ctx context.Context, changeDescription string) *UnitOfWork {
 	return &UnitOfWork{
 		Manager:           m,
@@ -194,6 +243,25 @@ func (u *UnitOfWork) LoadAll(filter model.ObjectsFilter) {
 	u.runGroupFor(-1).Add(req)
 }
 
+// LoadFrom loads the remote state from the objects instead of the Storage API.
+// The objects must be in the form returned by the API, see Manager.ToAPIForm.
+func (u *UnitOfWork) LoadFrom(objects []model.Object, filter model.ObjectsFilter) {
+	// Parents must be loaded before children
+	objects = append([]model.Object(nil), objects...)
+	sort.SliceStable(objects, func(i, j int) bool {
+		return objects[i].Level() < objects[j].Level()
+	})
+
+	for _, object := range objects {
+		if filter.IsObjectIgnored(object) {
+			continue
+		}
+		if err := u.loadObject(object); err != nil {
+			u.errors.Append(err)
+		}
+	}
+}
+
 func (u *UnitOfWork) loadObject(object model.Object) error {
 	// Get object state
 	objectState, found := u.state.Get(object.Key())
@@ -307,6 +375,13 @@ func (u *UnitOfWork) createOrUpdate(objectState model.ObjectState, object model.
 		}
 		changedFields.Remove("metadata")
 		u.runGroupFor(metadataRequestLevel).Add(u.keboolaProjectAPI.AppendMetadataRequest(v.ToAPIObjectKey(), v.ToAPIMetadata()))
+
+		// The append request doesn't remove keys, removed keys must be deleted one by one.
+		if exists {
+			if keys := removedMetadataKeys(objectState.RemoteState(), recipe.Object); len(keys) > 0 {
+				u.runGroupFor(metadataRequestLevel).Add(u.deleteMetadataRequest(object, keys))
+			}
+		}
 	}
 
 	// Create or update
@@ -319,6 +394,46 @@ func (u *UnitOfWork) createOrUpdate(objectState model.ObjectState, object model.
 	}
 }
 
+// deleteMetadataRequest lists metadata of the object, to get IDs, and deletes metadata with the keys.
+func (u *UnitOfWork) deleteMetadataRequest(object model.Object, keys map[string]bool) request.Sendable {
+	api := u.keboolaProjectAPI
+	switch v := object.(type) {
+	case *model.Branch:
+		key := keboola.BranchKey{ID: v.ID}
+		return api.
+			ListBranchMetadataRequest(key).
+			WithOnSuccess(func(ctx context.Context, metadata *keboola.MetadataDetails) error {
+				wg := request.NewWaitGroup(ctx)
+				for _, item := range *metadata {
+					if keys[item.Key] {
+						wg.Send(api.DeleteBranchMetadataRequest(key, item.ID))
+					}
+				}
+				return wg.Wait()
+			})
+	case *model.Config:
+		key := keboola.ConfigKey{BranchID: v.BranchID, ComponentID: v.ComponentID, ID: v.ID}
+		return api.
+			ListConfigMetadataRequest(v.BranchID).
+			WithOnSuccess(func(ctx context.Context, metadata *keboola.ConfigsMetadata) error {
+				wg := request.NewWaitGroup(ctx)
+				for _, config := range *metadata {
+					if config.ComponentID != key.ComponentID || config.ConfigID != key.ID {
+						continue
+					}
+					for _, item := range config.Metadata {
+						if keys[item.Key] {
+							wg.Send(api.DeleteConfigMetadataRequest(key, item.ID))
+						}
+					}
+				}
+				return wg.Wait()
+			})
+	default:
+		panic(errors.Errorf(`unexpected type "%T"`, object))
+	}
+}
+
 func (u *UnitOfWork) createRequest(objectState model.ObjectState, object model.Object, recipe *model.RemoteSaveRecipe) request.APIRequest[keboola.Object] {
 	apiObject, _ := recipe.Object.(model.ToAPIObject).ToAPIObject(u.changeDescription, nil)
 	request := u.keboolaProjectAPI.
@@ -411,3 +526,19 @@ func (u *UnitOfWork) runGroupFor(level int) *request.RunGroup {
 	u.runGroups.Set(key, grp)
 	return grp
 }
+
+// removedMetadataKeys returns metadata keys present in the remote object, but missing in the saved object.
+func removedMetadataKeys(remote, saved model.Object) map[string]bool {
+	remoteMetadata, ok := remote.(model.ToAPIMetadata)
+	if !ok {
+		return nil
+	}
+	savedMetadata := saved.(model.ToAPIMetadata).ToAPIMetadata()
+	keys := make(map[string]bool)
+	for key := range remoteMetadata.ToAPIMetadata() {
+		if _, found := savedMetadata[key]; !found {
+			keys[key] = true
+		}
+	}
+	return keys
+}
@@ -207,6 +207,67 @@ func TestRemoteLoadMapper(t *testing.T) {
 	}, testMapperInst.remoteChanges)
 }
 
+func TestRemoteLoadFrom(t *testing.T) {
+	t.Parallel()
+	testMapperInst := &testMapper{}
+	uow, _, projectState := newTestRemoteUOW(t, testMapperInst)
+
+	// Local objects
+	branchKey := model.BranchKey{ID: 123}
+	branchState := &model.BranchState{
+		BranchManifest: &model.BranchManifest{BranchKey: branchKey},
+		Local:          &model.Branch{BranchKey: branchKey, Name: "My branch", Metadata: model.BranchMetadata{"KBC.KaC.branch-meta": "val1"}},
+	}
+	configKey := model.ConfigKey{BranchID: 123, ComponentID: `foo.bar`, ID: `456`}
+	configState := &model.ConfigState{
+		ConfigManifest: &model.ConfigManifest{ConfigKey: configKey},
+		Local: &model.Config{
+			ConfigKey: configKey,
+			Name:      "internal name",
+			Content:   orderedmap.FromPairs([]orderedmap.Pair{{Key: "key", Value: "internal value"}}),
+			Metadata:  model.ConfigMetadata{"KBC.KaC.config-meta": "val2"},
+		},
+	}
+	ignoredKey := model.ConfigKey{BranchID: 123, ComponentID: `foo.bar`, ID: `789`}
+	ignoredState := &model.ConfigState{
+		ConfigManifest: &model.ConfigManifest{ConfigKey: ignoredKey},
+		Local:          &model.Config{ConfigKey: ignoredKey, Name: "ignored", Content: orderedmap.New()},
+	}
+
+	// Convert objects to the API form, the local state is unchanged
+	var objects []model.Object
+	for _, objectState := range []model.ObjectState{configState, ignoredState, branchState} {
+		object, err := uow.ToAPIForm(context.Background(), objectState)
+		assert.NoError(t, err)
+		objects = append(objects, object)
+	}
+	assert.Equal(t, `modified name`, objects[0].(*model.Config).Name)
+	assert.Equal(t, `{"key":"api value","new":"value"}`, json.MustEncodeString(objects[0].(*model.Config).Content, false))
+	assert.Equal(t, model.ConfigMetadata{"KBC.KaC.config-meta": "val2"}, objects[0].(*model.Config).Metadata)
+	assert.Equal(t, model.BranchMetadata{"KBC.KaC.branch-meta": "val1"}, objects[2].(*model.Branch).Metadata)
+	assert.Equal(t, `internal name`, configState.Local.Name)
+
+	// Load objects, parents are loaded first, no API request is sent
+	filter := model.NoFilter()
+	filter.SetAllowedKeys([]model.Key{branchKey, configKey})
+	uow.LoadFrom(objects, filter)
+	assert.NoError(t, uow.Invoke())
+
+	// Config has been loaded and mapped
+	assert.Len(t, projectState.Configs(), 1)
+	configRaw, found := projectState.Get(configKey)
+	assert.True(t, found)
+	config := configRaw.(*model.ConfigState).Remote
+	assert.Equal(t, `internal name`, config.Name)
+	assert.Equal(t, `{"key":"internal value","new":"value"}`, json.MustEncodeString(config.Content, false))
+
+	// AfterRemoteOperation event has been called
+	assert.Equal(t, []string{
+		`loaded branch "123"`,
+		`loaded config "branch:123/component:foo.bar/config:456"`,
+	}, testMapperInst.remoteChanges)
+}
+
 func TestLoadConfigMetadata(t *testing.T) {
 	t.Parallel()
 	uow, httpTransport, projectState := newTestRemoteUOW(t)
@@ -463,6 +524,57 @@ func TestSaveConfigMetadata_Update_NoChange(t *testing.T) {
 	assert.NoError(t, uow.Invoke())
 }
 
+func TestSaveConfigMetadata_Update_RemovedKey(t *testing.T) {
+	t.Parallel()
+	uow, httpTransport, _ := newTestRemoteUOW(t)
+
+	// Mocked response: append metadata
+	httpTransport.RegisterResponder(resty.MethodPost, `=~/storage/branch/123/components/foo.bar/configs/456/metadata$`,
+		httpmock.NewStringResponder(200, `[]`),
+	)
+
+	// Mocked response: list metadata, to get IDs
+	httpTransport.RegisterResponder(resty.MethodGet, `=~/storage/branch/123/search/component-configurations$`,
+		httpmock.NewJsonResponderOrPanic(200, []keboola.ConfigMetadataItem{
+			{
+				ComponentID: "foo.bar",
+				ConfigID:    "456",
+				Metadata: keboola.MetadataDetails{
+					{ID: "1", Key: "KBC-KaC-meta1", Value: "val1", Timestamp: "xxx"},
+					{ID: "2", Key: "KBC-KaC-meta2", Value: "val2", Timestamp: "xxx"},
+				},
+			},
+			{
+				ComponentID: "foo.bar",
+				ConfigID:    "789",
+				Metadata: keboola.MetadataDetails{
+					{ID: "3", Key: "KBC-KaC-meta2", Value: "val2", Timestamp: "xxx"},
+				},
+			},
+		}),
+	)
+
+	// Mocked response: delete metadata
+	httpTransport.RegisterResponder(resty.MethodDelete, `=~/storage/branch/123/components/foo.bar/configs/456/metadata/2$`,
+		httpmock.NewStringResponder(204, ``),
+	)
+
+	// Fixtures
+	configKey := model.ConfigKey{BranchID: 123, ComponentID: "foo.bar", ID: "456"}
+	objectState := &model.ConfigState{
+		ConfigManifest: &model.ConfigManifest{ConfigKey: configKey},
+		Local:          &model.Config{ConfigKey: configKey, Metadata: map[string]string{"KBC-KaC-meta1": "val1"}},
+		Remote:         &model.Config{ConfigKey: configKey, Metadata: map[string]string{"KBC-KaC-meta1": "val1", "KBC-KaC-meta2": "val2"}},
+	}
+
+	// Save
+	uow.SaveObject(objectState, objectState.Local, model.NewChangedFields("metadata"))
+	assert.NoError(t, uow.Invoke())
+
+	// Only the removed key of the config has been deleted, other requests are not mocked
+	assert.Equal(t, 1, httpTransport.GetCallCountInfo()["DELETE =~/storage/branch/123/components/foo.bar/configs/456/metadata/2$"])
+}
+
 func newTestRemoteUOW(t *testing.T, mappers ...any) (*remote.UnitOfWork, *httpmock.MockTransport, *state.Registry) {
 	t.Helper()
 	c, httpTransport := client.NewMockedClient()
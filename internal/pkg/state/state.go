@@ -48,6 +48,7 @@ type LoadOptions struct {
 	IgnoreNotFoundErr bool // not found error will be ignored
 	LocalFilter       *model.ObjectsFilter
 	RemoteFilter      *model.ObjectsFilter
+	RemoteObjects     []model.Object // if set, the remote state is loaded from the objects instead of the API, see remote.UnitOfWork.LoadFrom
 }
 
 // ObjectsContainer is Project or Template.
@@ -128,7 +129,7 @@ func (s *State) Load(ctx context.Context, options LoadOptions) (ok bool, localEr
 	// Remote
 	if options.LoadRemoteState {
 		s.logger.Debugf(ctx, "Loading project remote state.")
-		if err := s.loadRemoteState(ctx, options.RemoteFilter); err != nil {
+		if err := s.loadRemoteState(ctx, options.RemoteFilter, options.RemoteObjects); err != nil {
 			remoteErrors.Append(err)
 		}
 	}
@@ -255,7 +256,7 @@ func (s *State) loadLocalState(ctx context.Context, _filter *model.ObjectsFilter
 }
 
 // loadRemoteState from API to unified internal state.
-func (s *State) loadRemoteState(ctx context.Context, _filter *model.ObjectsFilter) (err error) {
+func (s *State) loadRemoteState(ctx context.Context, _filter *model.ObjectsFilter, objects []model.Object) (err error) {
 	ctx, span := s.tracer.Start(ctx, "keboola.go.declarative.state.load.remote")
 	defer span.End(&err)
 
@@ -268,6 +269,10 @@ func (s *State) loadRemoteState(ctx context.Context, _filter *model.ObjectsFilte
 	}
 
 	uow := s.remoteManager.NewUnitOfWork(ctx, "")
-	uow.LoadAll(filter)
+	if objects != nil {
+		uow.LoadFrom(objects, filter)
+	} else {
+		uow.LoadAll(filter)
+	}
 	return uow.Invoke()
 }
@@ -0,0 +1,86 @@
+package otlp
+
+import (
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+const (
+	ProtocolGRPC = "grpc"
+	ProtocolHTTP = "http"
+)
+
+type Config struct {
+	Enabled            bool          `configKey:"enabled" configUsage:"Enable export of traces and metrics to an OTLP receiver, for example OpenTelemetry Collector."`
+	Protocol           string        `configKey:"protocol" configUsage:"OTLP protocol: grpc or http." validate:"required,oneof=grpc http"`
+	Endpoint           string        `configKey:"endpoint" configUsage:"OTLP receiver endpoint in the host:port format, the default port is 4317 for gRPC and 4318 for HTTP."`
+	Insecure           bool          `configKey:"insecure" configUsage:"Disable TLS for the connection to the OTLP receiver."`
+	Timeout            time.Duration `configKey:"timeout" configUsage:"Timeout of an export request." validate:"required,minDuration=1s,maxDuration=5m"`
+	ResourceAttributes []string      `configKey:"resourceAttributes" configUsage:"Additional resource attributes in the key=value format."`
+	Traces             TracesConfig  `configKey:"traces"`
+	Metrics            MetricsConfig `configKey:"metrics"`
+}
+
+type TracesConfig struct {
+	Enabled       bool    `configKey:"enabled" configUsage:"Enable export of traces."`
+	SamplingRatio float64 `configKey:"samplingRatio" configUsage:"Ratio of sampled traces, from 0 to 1. Child spans follow the sampling decision of the parent span." validate:"min=0,max=1"`
+}
+
+type MetricsConfig struct {
+	Enabled        bool          `configKey:"enabled" configUsage:"Enable export of metrics, it replaces the Prometheus metrics endpoint."`
+	ExportInterval time.Duration `configKey:"exportInterval" configUsage:"Interval between metrics exports." validate:"required,minDuration=1s,maxDuration=10m"`
+}
+
+func NewConfig() Config {
+	return Config{
+		Enabled:  false,
+		Protocol: ProtocolGRPC,
+		Endpoint: "",
+		Insecure: false,
+		Timeout:  10 * time.Second,
+		Traces: TracesConfig{
+			Enabled:       true,
+			SamplingRatio: 1.0,
+		},
+		Metrics: MetricsConfig{
+			Enabled:        true,
+			ExportInterval: 30 * time.Second,
+		},
+	}
+}
+
+func (c Config) TracesEnabled() bool {
+	return c.Enabled && c.Traces.Enabled
+}
+
+func (c Config) MetricsEnabled() bool {
+	return c.Enabled && c.Metrics.Enabled
+}
+
+func (c Config) Validate() error {
+	errs := errors.NewMultiError()
+	if c.Enabled && c.Endpoint == "" {
+		errs.Append(errors.New(`"endpoint" is required when OTLP export is enabled`))
+	}
+	if _, err := c.Attributes(); err != nil {
+		errs.Append(err)
+	}
+	return errs.ErrorOrNil()
+}
+
+// Attributes parses the additional resource attributes.
+func (c Config) Attributes() (out []attribute.KeyValue, err error) {
+	for _, item := range c.ResourceAttributes {
+		k, v, found := strings.Cut(item, "=")
+		k = strings.TrimSpace(k)
+		if !found || k == "" {
+			return nil, errors.Errorf(`resource attribute "%s" is not in the key=value format`, item)
+		}
+		out = append(out, attribute.String(k, strings.TrimSpace(v)))
+	}
+	return out, nil
+}
@@ -0,0 +1,148 @@
+// Package otlp provides OpenTelemetry tracer and meter providers, which export data to an OTLP receiver, for example OpenTelemetry Collector.
+// Both gRPC and HTTP protocols are supported, see Config.
+package otlp
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/bridge/opencensus"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/servicectx"
+	"github.com/keboola/keboola-as-code/internal/pkg/telemetry/metric/prometheus"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+const (
+	shutdownTimeout = 30 * time.Second
+)
+
+// NewTracerProvider creates tracer provider, sampled spans are exported to the OTLP receiver in batches.
+// Remaining spans are flushed on the process shutdown.
+func NewTracerProvider(ctx context.Context, cfg Config, logger log.Logger, proc *servicectx.Process, serviceName string, attrs ...attribute.KeyValue) (*tracesdk.TracerProvider, error) {
+	logger = logger.WithComponent("otlp.traces")
+
+	res, err := newResource(ctx, cfg, serviceName, attrs)
+	if err != nil {
+		return nil, err
+	}
+
+	var exporter tracesdk.SpanExporter
+	switch cfg.Protocol {
+	case ProtocolGRPC:
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Endpoint), otlptracegrpc.WithTimeout(cfg.Timeout)}
+		if cfg.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		exporter, err = otlptracegrpc.New(ctx, opts...)
+	case ProtocolHTTP:
+		opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(cfg.Endpoint), otlptracehttp.WithTimeout(cfg.Timeout)}
+		if cfg.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		exporter, err = otlptracehttp.New(ctx, opts...)
+	default:
+		return nil, errors.Errorf(`unexpected OTLP protocol "%s"`, cfg.Protocol)
+	}
+	if err != nil {
+		return nil, errors.PrefixError(err, "cannot create OTLP trace exporter")
+	}
+
+	tp := tracesdk.NewTracerProvider(
+		tracesdk.WithBatcher(exporter),
+		tracesdk.WithResource(res),
+		tracesdk.WithSampler(tracesdk.ParentBased(tracesdk.TraceIDRatioBased(cfg.Traces.SamplingRatio))),
+	)
+
+	proc.OnShutdown(func(ctx context.Context) {
+		ctx, cancel := context.WithTimeout(ctx, shutdownTimeout)
+		defer cancel()
+		if err := tp.Shutdown(ctx); err != nil {
+			logger.Errorf(ctx, `cannot shutdown tracer provider: %s`, err)
+		}
+	})
+
+	// Register legacy OpenCensus tracing for go-cloud (https://github.com/google/go-cloud/issues/2877).
+	opencensus.InstallTraceBridge(opencensus.WithTracerProvider(tp))
+
+	logger.Infof(ctx, `exporting traces to %q, protocol %q`, cfg.Endpoint, cfg.Protocol)
+	return tp, nil
+}
+
+// NewMeterProvider creates meter provider, metrics are periodically exported to the OTLP receiver.
+// Metrics are exported for the last time on the process shutdown.
+func NewMeterProvider(ctx context.Context, cfg Config, logger log.Logger, proc *servicectx.Process, serviceName string, attrs ...attribute.KeyValue) (*metric.MeterProvider, error) {
+	logger = logger.WithComponent("otlp.metrics")
+
+	res, err := newResource(ctx, cfg, serviceName, attrs)
+	if err != nil {
+		return nil, err
+	}
+
+	var exporter metric.Exporter
+	switch cfg.Protocol {
+	case ProtocolGRPC:
+		opts := []otlpmetricgrpc.Option{otlpmetricgrpc.WithEndpoint(cfg.Endpoint), otlpmetricgrpc.WithTimeout(cfg.Timeout)}
+		if cfg.Insecure {
+			opts = append(opts, otlpmetricgrpc.WithInsecure())
+		}
+		exporter, err = otlpmetricgrpc.New(ctx, opts...)
+	case ProtocolHTTP:
+		opts := []otlpmetrichttp.Option{otlpmetrichttp.WithEndpoint(cfg.Endpoint), otlpmetrichttp.WithTimeout(cfg.Timeout)}
+		if cfg.Insecure {
+			opts = append(opts, otlpmetrichttp.WithInsecure())
+		}
+		exporter, err = otlpmetrichttp.New(ctx, opts...)
+	default:
+		return nil, errors.Errorf(`unexpected OTLP protocol "%s"`, cfg.Protocol)
+	}
+	if err != nil {
+		return nil, errors.PrefixError(err, "cannot create OTLP metric exporter")
+	}
+
+	mp := metric.NewMeterProvider(
+		metric.WithReader(metric.NewPeriodicReader(
+			exporter,
+			metric.WithInterval(cfg.Metrics.ExportInterval),
+			// Register legacy OpenCensus metrics, for go-cloud (https://github.com/google/go-cloud/issues/2877)
+			metric.WithProducer(opencensus.NewMetricProducer()),
+		)),
+		metric.WithResource(res),
+		// Metrics have the same names and attributes as metrics exported to Prometheus
+		metric.WithView(prometheus.View()),
+	)
+
+	proc.OnShutdown(func(ctx context.Context) {
+		ctx, cancel := context.WithTimeout(ctx, shutdownTimeout)
+		defer cancel()
+		if err := mp.Shutdown(ctx); err != nil {
+			logger.Errorf(ctx, `cannot shutdown meter provider: %s`, err)
+		}
+	})
+
+	logger.Infof(ctx, `exporting metrics to %q, protocol %q`, cfg.Endpoint, cfg.Protocol)
+	return mp, nil
+}
+
+func newResource(ctx context.Context, cfg Config, serviceName string, attrs []attribute.KeyValue) (*resource.Resource, error) {
+	cfgAttrs, err := cfg.Attributes()
+	if err != nil {
+		return nil, err
+	}
+
+	// Attributes from the configuration take precedence
+	all := []attribute.KeyValue{attribute.String("service.name", serviceName)}
+	all = append(all, attrs...)
+	all = append(all, cfgAttrs...)
+
+	return resource.New(ctx, resource.WithAttributes(all...))
+}
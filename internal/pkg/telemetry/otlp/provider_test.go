@@ -0,0 +1,210 @@
+package otlp_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	colmetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/servicectx"
+	"github.com/keboola/keboola-as-code/internal/pkg/telemetry/otlp"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+func TestProviders_GRPC(t *testing.T) {
+	t.Parallel()
+
+	receiver := &testReceiver{}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	srv := grpc.NewServer()
+	coltracepb.RegisterTraceServiceServer(srv, &testTraceService{receiver: receiver})
+	colmetricpb.RegisterMetricsServiceServer(srv, &testMetricsService{receiver: receiver})
+	go func() { _ = srv.Serve(listener) }()
+	defer srv.Stop()
+
+	testProviders(t, otlp.ProtocolGRPC, listener.Addr().String(), receiver)
+}
+
+func TestProviders_HTTP(t *testing.T) {
+	t.Parallel()
+
+	receiver := &testReceiver{}
+	handler := http.NewServeMux()
+	handler.HandleFunc("/v1/traces", func(w http.ResponseWriter, r *http.Request) {
+		req := &coltracepb.ExportTraceServiceRequest{}
+		if handleHTTPExport(w, r, req, &coltracepb.ExportTraceServiceResponse{}) {
+			receiver.addTraces(req)
+		}
+	})
+	handler.HandleFunc("/v1/metrics", func(w http.ResponseWriter, r *http.Request) {
+		req := &colmetricpb.ExportMetricsServiceRequest{}
+		if handleHTTPExport(w, r, req, &colmetricpb.ExportMetricsServiceResponse{}) {
+			receiver.addMetrics(req)
+		}
+	})
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+
+	testProviders(t, otlp.ProtocolHTTP, strings.TrimPrefix(srv.URL, "http://"), receiver)
+}
+
+func TestConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	cfg := otlp.NewConfig()
+	require.NoError(t, cfg.Validate())
+
+	cfg.Enabled = true
+	cfg.ResourceAttributes = []string{"foo=bar", "invalid"}
+	err := cfg.Validate()
+	if assert.Error(t, err) {
+		assert.Equal(t, "- \"endpoint\" is required when OTLP export is enabled\n- resource attribute \"invalid\" is not in the key=value format", err.Error())
+	}
+
+	cfg.Endpoint = "localhost:4317"
+	cfg.ResourceAttributes = []string{"foo=bar", " env = prod "}
+	require.NoError(t, cfg.Validate())
+	attrs, err := cfg.Attributes()
+	require.NoError(t, err)
+	assert.Equal(t, []attribute.KeyValue{attribute.String("foo", "bar"), attribute.String("env", "prod")}, attrs)
+}
+
+func testProviders(t *testing.T, protocol, endpoint string, receiver *testReceiver) {
+	t.Helper()
+
+	ctx := context.Background()
+	logger := log.NewDebugLogger()
+	proc := servicectx.New(servicectx.WithLogger(logger), servicectx.WithoutSignals())
+
+	cfg := otlp.NewConfig()
+	cfg.Enabled = true
+	cfg.Protocol = protocol
+	cfg.Endpoint = endpoint
+	cfg.Insecure = true
+	cfg.ResourceAttributes = []string{"env=test"}
+
+	tp, err := otlp.NewTracerProvider(ctx, cfg, logger, proc, "my-service", attribute.String("node", "my-node"))
+	require.NoError(t, err)
+	mp, err := otlp.NewMeterProvider(ctx, cfg, logger, proc, "my-service")
+	require.NoError(t, err)
+
+	// Create a span and a metric
+	_, span := tp.Tracer("test").Start(ctx, "my-span")
+	span.End()
+	counter, err := mp.Meter("test").Int64Counter("my-counter")
+	require.NoError(t, err)
+	counter.Add(ctx, 1)
+
+	// Data are flushed on shutdown
+	proc.Shutdown(ctx, errors.New("bye bye"))
+	proc.WaitForShutdown()
+
+	receiver.lock.Lock()
+	defer receiver.lock.Unlock()
+	assert.Equal(t, []string{"my-span"}, receiver.spans)
+	assert.Equal(t, []string{"my-counter"}, receiver.metrics)
+	assert.Equal(t, []string{"env=test", "node=my-node", "service.name=my-service"}, receiver.traceResource)
+	assert.Equal(t, []string{"env=test", "service.name=my-service"}, receiver.metricResource)
+}
+
+// testReceiver is an in-process OTLP receiver, it collects names of received spans and metrics.
+type testReceiver struct {
+	lock           sync.Mutex
+	spans          []string
+	metrics        []string
+	traceResource  []string
+	metricResource []string
+}
+
+func (r *testReceiver) addTraces(req *coltracepb.ExportTraceServiceRequest) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for _, rs := range req.GetResourceSpans() {
+		r.traceResource = resourceAttrs(rs.GetResource())
+		for _, ss := range rs.GetScopeSpans() {
+			for _, span := range ss.GetSpans() {
+				r.spans = append(r.spans, span.GetName())
+			}
+		}
+	}
+}
+
+func (r *testReceiver) addMetrics(req *colmetricpb.ExportMetricsServiceRequest) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for _, rm := range req.GetResourceMetrics() {
+		r.metricResource = resourceAttrs(rm.GetResource())
+		for _, sm := range rm.GetScopeMetrics() {
+			for _, metric := range sm.GetMetrics() {
+				r.metrics = append(r.metrics, metric.GetName())
+			}
+		}
+	}
+}
+
+type testTraceService struct {
+	coltracepb.UnimplementedTraceServiceServer
+	receiver *testReceiver
+}
+
+func (s *testTraceService) Export(_ context.Context, req *coltracepb.ExportTraceServiceRequest) (*coltracepb.ExportTraceServiceResponse, error) {
+	s.receiver.addTraces(req)
+	return &coltracepb.ExportTraceServiceResponse{}, nil
+}
+
+type testMetricsService struct {
+	colmetricpb.UnimplementedMetricsServiceServer
+	receiver *testReceiver
+}
+
+func (s *testMetricsService) Export(_ context.Context, req *colmetricpb.ExportMetricsServiceRequest) (*colmetricpb.ExportMetricsServiceResponse, error) {
+	s.receiver.addMetrics(req)
+	return &colmetricpb.ExportMetricsServiceResponse{}, nil
+}
+
+// handleHTTPExport decodes protobuf request and writes protobuf response, see OTLP/HTTP specification.
+func handleHTTPExport(w http.ResponseWriter, r *http.Request, req, resp proto.Message) bool {
+	body, err := io.ReadAll(r.Body)
+	if err == nil {
+		err = proto.Unmarshal(body, req)
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return false
+	}
+
+	out, err := proto.Marshal(resp)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return false
+	}
+
+	w.Header().Set("Content-Type", "application/x-protobuf")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(out)
+	return true
+}
+
+func resourceAttrs(res *resourcepb.Resource) (out []string) {
+	for _, kv := range res.GetAttributes() {
+		out = append(out, kv.GetKey()+"="+kv.GetValue().GetStringValue())
+	}
+	sort.Strings(out)
+	return out
+}
@@ -21,3 +21,10 @@ type Context interface {
 	JsonnetContext() *jsonnet.Context
 	Replacements() (*replacevalues.Values, error)
 }
+
+// ContextWithRemoteObjects is implemented by a Context, which loads the remote objects from another source than the API.
+// For example, a template can be created from a local project, see "internal/pkg/template/context/create".
+type ContextWithRemoteObjects interface {
+	Context
+	RemoteObjects() []model.Object
+}
@@ -1,4 +1,4 @@
-// Package create represents the process of replacing values when creating a template from a remote project.
+// Package create represents the process of replacing values when creating a template from a remote or local project.
 package create
 
 import (
@@ -35,11 +35,13 @@ import (
 //     - Functions calls are generated by "jsonnet.FormatAst", and "jsonnet.ReplacePlaceholdersRecursive".
 //
 // Context.RemoteObjectsFilter() defines which objects will be part of the template.
+// Context.RemoteObjects() returns objects of a local project, if the template is created from the local project.
 // Context.Replacements() returns placeholders for ConfigId / ConfigRowId Jsonnet functions.
 type Context struct {
 	_context
-	remoteFilter model.ObjectsFilter
-	replacements *replacevalues.Values
+	remoteFilter  model.ObjectsFilter
+	remoteObjects []model.Object
+	replacements  *replacevalues.Values
 }
 
 type _context context.Context
@@ -70,6 +72,19 @@ func NewContext(ctx context.Context, sourceBranch model.BranchKey, configs []Con
 	}
 }
 
+// NewContextFromObjects creates the context, the project objects are not loaded from the API, but the provided objects are used.
+// The objects must be in the form returned by the API, see "remote.Manager.ToAPIForm".
+func NewContextFromObjects(ctx context.Context, sourceBranch model.BranchKey, configs []ConfigDef, objects []model.Object) *Context {
+	c := NewContext(ctx, sourceBranch, configs)
+	c.remoteObjects = objects
+	return c
+}
+
+// RemoteObjects returns the project objects, if they are not loaded from the API, otherwise nil.
+func (c *Context) RemoteObjects() []model.Object {
+	return c.remoteObjects
+}
+
 func (c *Context) RemoteObjectsFilter() model.ObjectsFilter {
 	return c.remoteFilter
 }
@@ -58,6 +58,7 @@ type Context struct {
 	placeholdersCount int
 	ticketsResolved   bool
 	projectBackends   []string
+	environment       function.Environment
 
 	lock          *sync.Mutex
 	placeholders  PlaceholdersMap
@@ -95,7 +96,7 @@ const (
 	instanceIDShortLength = 8
 )
 
-func NewContext(ctx context.Context, templateRef model.TemplateRef, objectsRoot filesystem.Fs, instanceID string, targetBranch model.BranchKey, inputsValues template.InputsValues, inputsDefsMap map[string]*template.Input, tickets *keboola.TicketProvider, components *model.ComponentsMap, projectState *state.State, projectBackends []string) *Context {
+func NewContext(ctx context.Context, templateRef model.TemplateRef, objectsRoot filesystem.Fs, instanceID string, targetBranch model.BranchKey, inputsValues template.InputsValues, inputsDefsMap map[string]*template.Input, tickets *keboola.TicketProvider, components *model.ComponentsMap, projectState *state.State, projectBackends []string, env function.Environment) *Context {
 	ctx = template.NewContext(ctx)
 	c := &Context{
 		_context:        ctx,
@@ -113,6 +114,7 @@ func NewContext(ctx context.Context, templateRef model.TemplateRef, objectsRoot
 		inputsUsage:     metadata.NewInputsUsage(),
 		inputsDefsMap:   inputsDefsMap,
 		projectBackends: projectBackends,
+		environment:     env,
 	}
 
 	// Convert inputsValues to map
@@ -236,6 +238,13 @@ func (c *Context) registerJsonnetFunctions() {
 	c.jsonnetCtx.NativeFunctionWithAlias(function.ComponentIsAvailable(c.components))
 	c.jsonnetCtx.NativeFunctionWithAlias(function.SnowflakeWriterComponentID(c.components))
 	c.jsonnetCtx.NativeFunctionWithAlias(function.HasProjectBackend(c.projectBackends))
+	c.jsonnetCtx.NativeFunctionWithAlias(function.StackHost(c.environment.StackHost))
+	c.jsonnetCtx.NativeFunctionWithAlias(function.HasProjectFeature(c.environment.Features))
+	c.jsonnetCtx.NativeFunctionWithAlias(function.ConfigDefaultBucket(c.components))
+	tables := function.CachedTables(c.environment.Tables)
+	c.jsonnetCtx.NativeFunctionWithAlias(function.TableExists(tables))
+	c.jsonnetCtx.NativeFunctionWithAlias(function.TableColumns(tables))
+	c.jsonnetCtx.NativeFunctionWithAlias(function.Hash())
 }
 
 // mapID maps ConfigId/ConfigRowId in Jsonnet files to a <<~~ticket:123~~>> placeholder.
@@ -0,0 +1,312 @@
+package input
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/keboola/go-utils/pkg/orderedmap"
+	"github.com/spf13/cast"
+)
+
+// JSONSchemaVersion is the JSON Schema dialect of the exported inputs.
+const JSONSchemaVersion = "https://json-schema.org/draft/2020-12/schema"
+
+// JSONSchema exports the inputs as a JSON Schema of the inputs values, for external UIs.
+//
+// Validation rules are converted to the standard keywords where possible.
+// The original rules, "showIf" conditions, kinds and the layout of steps and groups
+// are kept in the "x-keboola-*" keywords, so the UI can render the same form as the CLI.
+// The Templates API validates the values using the original rules, the schema is only a hint.
+func (g StepsGroups) JSONSchema() *orderedmap.OrderedMap {
+	properties := orderedmap.New()
+	required := make([]string, 0)
+	groups := make([]any, 0)
+
+	for _, group := range g.ToExtended() {
+		steps := make([]any, 0)
+		for _, step := range group.Steps {
+			inputIDs := make([]string, 0)
+			for _, input := range step.Inputs {
+				inputIDs = append(inputIDs, input.ID)
+				properties.Set(input.ID, input.jsonSchema(step.ID))
+
+				// The value is always required only if the input is always visible.
+				if group.Required == RequiredAll && input.If == "" && input.Rules.isRequired() {
+					required = append(required, input.ID)
+				}
+			}
+
+			stepSchema := orderedmap.New()
+			stepSchema.Set("id", step.ID)
+			stepSchema.Set("icon", step.Icon)
+			stepSchema.Set("name", step.Name)
+			stepSchema.Set("description", step.Description)
+			stepSchema.Set("dialogName", step.NameForDialog())
+			stepSchema.Set("dialogDescription", step.DescriptionForDialog())
+			stepSchema.Set("inputs", inputIDs)
+			steps = append(steps, stepSchema)
+		}
+
+		groupSchema := orderedmap.New()
+		groupSchema.Set("id", group.ID)
+		groupSchema.Set("description", group.Description)
+		groupSchema.Set("required", string(group.Required))
+		groupSchema.Set("steps", steps)
+		groups = append(groups, groupSchema)
+	}
+
+	out := orderedmap.New()
+	out.Set("$schema", JSONSchemaVersion)
+	out.Set("type", "object")
+	out.Set("properties", properties)
+	if len(required) > 0 {
+		out.Set("required", required)
+	}
+	out.Set("additionalProperties", false)
+	out.Set("x-keboola-stepsGroups", groups)
+	return out
+}
+
+// jsonSchema returns JSON Schema of the input value.
+func (i Input) jsonSchema(stepID string) *orderedmap.OrderedMap {
+	out := orderedmap.New()
+	out.Set("title", i.Name)
+	if i.Description != "" {
+		out.Set("description", i.Description)
+	}
+
+	// Type and options
+	typeSchema := i.Type.jsonSchema()
+	for _, key := range typeSchema.Keys() {
+		value, _ := typeSchema.Get(key)
+		out.Set(key, value)
+	}
+	switch i.Kind {
+	case KindSelect:
+		out.Set("oneOf", i.Options.jsonSchema())
+	case KindMultiSelect:
+		items := orderedmap.New()
+		items.Set("type", "string")
+		items.Set("oneOf", i.Options.jsonSchema())
+		out.Set("items", items)
+		out.Set("uniqueItems", true)
+	case KindHidden:
+		out.Set("writeOnly", true)
+	}
+	if i.Default != nil {
+		out.Set("default", i.Default)
+	}
+
+	// Rules
+	if constraints := i.Rules.jsonSchema(i.Type); len(constraints.Keys()) > 0 {
+		if i.Rules.isOmitEmpty() {
+			// Constraints are not applied to the empty value.
+			empty := orderedmap.New()
+			empty.Set("const", i.Type.EmptyValue())
+			out.Set("anyOf", []any{empty, constraints})
+		} else {
+			for _, key := range constraints.Keys() {
+				value, _ := constraints.Get(key)
+				out.Set(key, value)
+			}
+		}
+	}
+
+	// UI hints
+	out.Set("x-keboola-type", string(i.Type))
+	out.Set("x-keboola-kind", string(i.Kind))
+	out.Set("x-keboola-step", stepID)
+	if !i.Rules.Empty() {
+		out.Set("x-keboola-rules", string(i.Rules))
+	}
+	if i.If != "" {
+		out.Set("x-keboola-showIf", string(i.If))
+	}
+	if i.ComponentID != "" {
+		out.Set("x-keboola-componentId", i.ComponentID)
+	}
+	if i.OauthInputID != "" {
+		out.Set("x-keboola-oauthInputId", i.OauthInputID)
+	}
+	return out
+}
+
+// jsonSchema returns JSON Schema keywords for the type.
+func (t Type) jsonSchema() *orderedmap.OrderedMap {
+	out := orderedmap.New()
+	switch t {
+	case TypeString:
+		out.Set("type", "string")
+	case TypeInt:
+		out.Set("type", "integer")
+	case TypeDouble:
+		out.Set("type", "number")
+	case TypeBool:
+		out.Set("type", "boolean")
+	case TypeStringArray:
+		items := orderedmap.New()
+		items.Set("type", "string")
+		out.Set("type", "array")
+		out.Set("items", items)
+	case TypeObject:
+		out.Set("type", "object")
+	}
+	return out
+}
+
+// jsonSchema returns the options as a list of constants with titles.
+func (options Options) jsonSchema() []any {
+	out := make([]any, 0, len(options))
+	for _, o := range options {
+		item := orderedmap.New()
+		item.Set("const", o.Value)
+		item.Set("title", o.Label)
+		out = append(out, item)
+	}
+	return out
+}
+
+// rule is one validation rule, for example "min=5".
+type rule struct {
+	name  string
+	param string
+}
+
+// parse splits the rules to the items.
+func (r Rules) parse() (out []rule) {
+	for _, item := range strings.Split(string(r), ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		name, param, _ := strings.Cut(item, "=")
+		out = append(out, rule{name: name, param: param})
+	}
+	return out
+}
+
+func (r Rules) has(name string) bool {
+	for _, item := range r.parse() {
+		if item.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (r Rules) isRequired() bool {
+	return r.has("required")
+}
+
+func (r Rules) isOmitEmpty() bool {
+	return r.has("omitempty")
+}
+
+// jsonSchema converts the rules to the JSON Schema keywords.
+// Unknown rules are skipped, they are still present in the "x-keboola-rules" keyword.
+func (r Rules) jsonSchema(t Type) *orderedmap.OrderedMap {
+	out := orderedmap.New()
+	var patterns []string
+
+	// Keywords for the length: string length, array items or numeric value.
+	minKey, maxKey := "", ""
+	switch t {
+	case TypeString:
+		minKey, maxKey = "minLength", "maxLength"
+	case TypeStringArray:
+		minKey, maxKey = "minItems", "maxItems"
+	case TypeInt, TypeDouble:
+		minKey, maxKey = "minimum", "maximum"
+	case TypeObject:
+		minKey, maxKey = "minProperties", "maxProperties"
+	}
+
+	for _, item := range r.parse() {
+		// Rules with the "|" operator cannot be converted.
+		if strings.Contains(item.name, "|") || strings.Contains(item.param, "|") {
+			continue
+		}
+
+		number, err := cast.ToFloat64E(item.param)
+		isNumber := item.param != "" && err == nil
+
+		switch item.name {
+		case "required":
+			if t == TypeString || t == TypeStringArray || t == TypeObject {
+				out.Set(minKey, 1)
+			}
+		case "min", "gte":
+			if isNumber && minKey != "" {
+				out.Set(minKey, number)
+			}
+		case "max", "lte":
+			if isNumber && maxKey != "" {
+				out.Set(maxKey, number)
+			}
+		case "len":
+			if isNumber && minKey != "" {
+				out.Set(minKey, number)
+				out.Set(maxKey, number)
+			}
+		case "gt":
+			if isNumber && (t == TypeInt || t == TypeDouble) {
+				out.Set("exclusiveMinimum", number)
+			}
+		case "lt":
+			if isNumber && (t == TypeInt || t == TypeDouble) {
+				out.Set("exclusiveMaximum", number)
+			}
+		case "oneof":
+			if t == TypeString || t == TypeInt || t == TypeDouble {
+				values := make([]any, 0)
+				for _, v := range strings.Fields(item.param) {
+					if t == TypeString {
+						values = append(values, v)
+					} else {
+						values = append(values, cast.ToFloat64(v))
+					}
+				}
+				out.Set("enum", values)
+			}
+		case "email":
+			out.Set("format", "email")
+		case "url", "uri":
+			out.Set("format", "uri")
+		case "hostname":
+			out.Set("format", "hostname")
+		case "ipv4", "ipv6", "uuid":
+			out.Set("format", item.name)
+		case "alpha":
+			patterns = append(patterns, `^[a-zA-Z]*$`)
+		case "alphanum":
+			patterns = append(patterns, `^[a-zA-Z0-9]*$`)
+		case "numeric":
+			patterns = append(patterns, `^[-+]?[0-9]+(?:\.[0-9]+)?$`)
+		case "startswith":
+			patterns = append(patterns, "^"+regexp.QuoteMeta(item.param))
+		case "endswith":
+			patterns = append(patterns, regexp.QuoteMeta(item.param)+"$")
+		case "contains":
+			patterns = append(patterns, regexp.QuoteMeta(item.param))
+		}
+	}
+
+	// Patterns apply to strings only, multiple patterns must all match.
+	if t == TypeString {
+		switch len(patterns) {
+		case 0:
+		case 1:
+			out.Set("pattern", patterns[0])
+		default:
+			allOf := make([]any, 0, len(patterns))
+			for _, pattern := range patterns {
+				item := orderedmap.New()
+				item.Set("pattern", pattern)
+				allOf = append(allOf, item)
+			}
+			out.Set("allOf", allOf)
+		}
+	}
+
+	return out
+}
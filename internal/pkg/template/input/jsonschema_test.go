@@ -0,0 +1,215 @@
+package input
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/encoding/json"
+)
+
+func TestStepsGroups_JSONSchema(t *testing.T) {
+	t.Parallel()
+
+	groups := StepsGroups{
+		{
+			Description: "Group One",
+			Required:    RequiredAll,
+			Steps: Steps{
+				{
+					Icon:        "common:settings",
+					Name:        "Step One",
+					Description: "Description",
+					Inputs: Inputs{
+						{ID: "host", Name: "Host", Type: TypeString, Kind: KindInput, Rules: "required,hostname"},
+						{ID: "password", Name: "Password", Type: TypeString, Kind: KindHidden, Rules: "required", If: "host != ''"},
+						{ID: "port", Name: "Port", Type: TypeInt, Kind: KindInput, Default: 3306, Rules: "min=1,max=65535"},
+						{ID: "prefix", Name: "Prefix", Type: TypeString, Kind: KindInput, Rules: "omitempty,alphanum,max=10"},
+						{
+							ID:      "tables",
+							Name:    "Tables",
+							Type:    TypeStringArray,
+							Kind:    KindMultiSelect,
+							Options: Options{{Value: "a", Label: "Table A"}, {Value: "b", Label: "Table B"}},
+						},
+					},
+				},
+			},
+		},
+		{
+			Description: "Group Two",
+			Required:    RequiredOptional,
+			Steps: Steps{
+				{
+					Icon:        "common:settings",
+					Name:        "Step Two",
+					Description: "Description",
+					DialogName:  "Dialog Name",
+					Inputs: Inputs{
+						{ID: "debug", Name: "Debug", Type: TypeBool, Kind: KindConfirm, Rules: "required"},
+					},
+				},
+			},
+		},
+	}
+
+	expected := `
+{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "properties": {
+    "host": {
+      "title": "Host",
+      "type": "string",
+      "minLength": 1,
+      "format": "hostname",
+      "x-keboola-type": "string",
+      "x-keboola-kind": "input",
+      "x-keboola-step": "g01-s01",
+      "x-keboola-rules": "required,hostname"
+    },
+    "password": {
+      "title": "Password",
+      "type": "string",
+      "writeOnly": true,
+      "minLength": 1,
+      "x-keboola-type": "string",
+      "x-keboola-kind": "hidden",
+      "x-keboola-step": "g01-s01",
+      "x-keboola-rules": "required",
+      "x-keboola-showIf": "host != ''"
+    },
+    "port": {
+      "title": "Port",
+      "type": "integer",
+      "default": 3306,
+      "minimum": 1,
+      "maximum": 65535,
+      "x-keboola-type": "int",
+      "x-keboola-kind": "input",
+      "x-keboola-step": "g01-s01",
+      "x-keboola-rules": "min=1,max=65535"
+    },
+    "prefix": {
+      "title": "Prefix",
+      "type": "string",
+      "anyOf": [
+        {
+          "const": ""
+        },
+        {
+          "maxLength": 10,
+          "pattern": "^[a-zA-Z0-9]*$"
+        }
+      ],
+      "x-keboola-type": "string",
+      "x-keboola-kind": "input",
+      "x-keboola-step": "g01-s01",
+      "x-keboola-rules": "omitempty,alphanum,max=10"
+    },
+    "tables": {
+      "title": "Tables",
+      "type": "array",
+      "items": {
+        "type": "string",
+        "oneOf": [
+          {
+            "const": "a",
+            "title": "Table A"
+          },
+          {
+            "const": "b",
+            "title": "Table B"
+          }
+        ]
+      },
+      "uniqueItems": true,
+      "x-keboola-type": "string[]",
+      "x-keboola-kind": "multiselect",
+      "x-keboola-step": "g01-s01"
+    },
+    "debug": {
+      "title": "Debug",
+      "type": "boolean",
+      "x-keboola-type": "bool",
+      "x-keboola-kind": "confirm",
+      "x-keboola-step": "g02-s01",
+      "x-keboola-rules": "required"
+    }
+  },
+  "required": [
+    "host"
+  ],
+  "additionalProperties": false,
+  "x-keboola-stepsGroups": [
+    {
+      "id": "g01",
+      "description": "Group One",
+      "required": "all",
+      "steps": [
+        {
+          "id": "g01-s01",
+          "icon": "common:settings",
+          "name": "Step One",
+          "description": "Description",
+          "dialogName": "Step One",
+          "dialogDescription": "Description",
+          "inputs": [
+            "host",
+            "password",
+            "port",
+            "prefix",
+            "tables"
+          ]
+        }
+      ]
+    },
+    {
+      "id": "g02",
+      "description": "Group Two",
+      "required": "optional",
+      "steps": [
+        {
+          "id": "g02-s01",
+          "icon": "common:settings",
+          "name": "Step Two",
+          "description": "Description",
+          "dialogName": "Dialog Name",
+          "dialogDescription": "Description",
+          "inputs": [
+            "debug"
+          ]
+        }
+      ]
+    }
+  ]
+}
+`
+	assert.JSONEq(t, expected, json.MustEncodeString(groups.JSONSchema(), true))
+}
+
+func TestRules_JSONSchema(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		rules    Rules
+		t        Type
+		expected string
+	}{
+		{rules: "", t: TypeString, expected: `{}`},
+		{rules: "required,email", t: TypeString, expected: `{"minLength":1,"format":"email"}`},
+		{rules: "len=5", t: TypeString, expected: `{"minLength":5,"maxLength":5}`},
+		{rules: "gt=0,lte=10.5", t: TypeDouble, expected: `{"exclusiveMinimum":0,"maximum":10.5}`},
+		{rules: "oneof=a b c", t: TypeString, expected: `{"enum":["a","b","c"]}`},
+		{rules: "oneof=1 2", t: TypeInt, expected: `{"enum":[1,2]}`},
+		{rules: "startswith=a.b,endswith=c", t: TypeString, expected: `{"allOf":[{"pattern":"^a\\.b"},{"pattern":"c$"}]}`},
+		{rules: "required,min=2", t: TypeStringArray, expected: `{"minItems":2}`},
+		{rules: "required", t: TypeObject, expected: `{"minProperties":1}`},
+		{rules: "email|url", t: TypeString, expected: `{}`},
+		{rules: "custom_rule", t: TypeString, expected: `{}`},
+	}
+
+	for _, c := range cases {
+		assert.JSONEq(t, c.expected, json.MustEncodeString(c.rules.jsonSchema(c.t), false), string(c.rules))
+	}
+}
@@ -15,6 +15,9 @@ const (
 	KindMultiSelect   = Kind("multiselect")
 	KindOAuth         = Kind("oauth")
 	KindOAuthAccounts = Kind("oauthAccounts")
+	KindTable         = Kind("table")
+	KindBucket        = Kind("bucket")
+	KindConnection    = Kind("connection")
 )
 
 // Kind represents how Input is displayed to the user.
@@ -24,7 +27,12 @@ type Kind string
 type Kinds []Kind
 
 func allKinds() Kinds {
-	return Kinds{KindInput, KindHidden, KindTextarea, KindConfirm, KindSelect, KindMultiSelect, KindOAuth, KindOAuthAccounts}
+	return Kinds{KindInput, KindHidden, KindTextarea, KindConfirm, KindSelect, KindMultiSelect, KindOAuth, KindOAuthAccounts, KindTable, KindBucket, KindConnection}
+}
+
+// StorageKinds are filled with an existing object from the project, see StorageObjects.
+func StorageKinds() Kinds {
+	return Kinds{KindTable, KindBucket, KindConnection}
 }
 
 func (v Kinds) String() string {
@@ -48,6 +56,16 @@ func (k Kind) IsValid() bool {
 	return false
 }
 
+// IsStorage returns true, if the input value references an existing object from the project.
+func (k Kind) IsStorage() bool {
+	for _, v := range StorageKinds() {
+		if v == k {
+			return true
+		}
+	}
+	return false
+}
+
 // ValidateType validates that type is valid for kind.
 func (k Kind) ValidateType(t Type) error {
 	switch k {
@@ -83,6 +101,10 @@ func (k Kind) ValidateType(t Type) error {
 		if t != TypeObject {
 			return errors.Errorf("should be object for kind=%s, found %s", k, t)
 		}
+	case KindTable, KindBucket, KindConnection:
+		if t != TypeString {
+			return errors.Errorf("should be string for kind=%s, found %s", k, t)
+		}
 	default:
 		panic(errors.Errorf(`unexpected kind "%s"`, t))
 	}
@@ -15,6 +15,9 @@ func TestKind_IsValid(t *testing.T) {
 	assert.True(t, KindSelect.IsValid())
 	assert.True(t, KindMultiSelect.IsValid())
 	assert.True(t, KindOAuth.IsValid())
+	assert.True(t, KindTable.IsValid())
+	assert.True(t, KindBucket.IsValid())
+	assert.True(t, KindConnection.IsValid())
 	assert.False(t, Kind("foo").IsValid())
 }
 
@@ -66,4 +69,21 @@ func TestKind_ValidateType(t *testing.T) {
 	err = KindOAuthAccounts.ValidateType(TypeString)
 	assert.Error(t, err)
 	assert.Equal(t, "should be object for kind=oauthAccounts, found string", err.Error())
+
+	// Storage kinds
+	assert.NoError(t, KindTable.ValidateType(TypeString))
+	assert.NoError(t, KindBucket.ValidateType(TypeString))
+	assert.NoError(t, KindConnection.ValidateType(TypeString))
+	err = KindTable.ValidateType(TypeStringArray)
+	assert.Error(t, err)
+	assert.Equal(t, "should be string for kind=table, found string[]", err.Error())
+}
+
+func TestKind_IsStorage(t *testing.T) {
+	t.Parallel()
+	assert.True(t, KindTable.IsStorage())
+	assert.True(t, KindBucket.IsStorage())
+	assert.True(t, KindConnection.IsStorage())
+	assert.False(t, KindInput.IsStorage())
+	assert.False(t, KindSelect.IsStorage())
 }
@@ -0,0 +1,117 @@
+package input
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/keboola/go-client/pkg/keboola"
+	"github.com/spf13/cast"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+// StorageObjects contains existing objects from the project, as options for the StorageKinds.
+// - KindTable: value is a table ID, for example "in.c-bucket.table".
+// - KindBucket: value is a bucket ID, for example "in.c-bucket".
+// - KindConnection: value is an ID of an existing workspace, credentials are never stored in the template.
+type StorageObjects struct {
+	options map[Kind]Options
+}
+
+// StorageKindsOf returns storage kinds used by the inputs.
+func StorageKindsOf(inputs map[string]*Input) Kinds {
+	used := make(map[Kind]bool)
+	for _, inputDef := range inputs {
+		if inputDef.Kind.IsStorage() {
+			used[inputDef.Kind] = true
+		}
+	}
+
+	out := make(Kinds, 0)
+	for _, kind := range StorageKinds() {
+		if used[kind] {
+			out = append(out, kind)
+		}
+	}
+	return out
+}
+
+func NewStorageObjects() *StorageObjects {
+	return &StorageObjects{options: make(map[Kind]Options)}
+}
+
+// LoadStorageObjects loads objects of the specified kinds from the branch using the Storage API.
+func LoadStorageObjects(ctx context.Context, api *keboola.AuthorizedAPI, branchID keboola.BranchID, kinds Kinds) (*StorageObjects, error) {
+	out := NewStorageObjects()
+	for _, kind := range kinds {
+		switch kind {
+		case KindTable:
+			tables, err := api.ListTablesRequest(branchID).Send(ctx)
+			if err != nil {
+				return nil, errors.Errorf("cannot load tables: %w", err)
+			}
+			for _, table := range *tables {
+				out.Add(kind, table.TableID.String(), table.DisplayName)
+			}
+		case KindBucket:
+			buckets, err := api.ListBucketsRequest(branchID).Send(ctx)
+			if err != nil {
+				return nil, errors.Errorf("cannot load buckets: %w", err)
+			}
+			for _, bucket := range *buckets {
+				out.Add(kind, bucket.BucketID.String(), bucket.DisplayName)
+			}
+		case KindConnection:
+			workspaces, err := api.ListWorkspaces(ctx, branchID)
+			if err != nil {
+				return nil, errors.Errorf("cannot load workspaces: %w", err)
+			}
+			for _, w := range workspaces {
+				out.Add(kind, string(w.Config.ID), w.Config.Name)
+			}
+		default:
+			panic(errors.Errorf(`kind "%s" is not a storage kind`, kind))
+		}
+	}
+	return out, nil
+}
+
+// Add an existing object, the label is composed from the name and the ID.
+func (o *StorageObjects) Add(kind Kind, id, name string) {
+	label := id
+	if name != "" && name != id {
+		label = fmt.Sprintf("%s (%s)", name, id)
+	}
+	o.options[kind] = append(o.options[kind], Option{Value: id, Label: label})
+}
+
+// Options returns existing objects of the kind.
+func (o *StorageObjects) Options(kind Kind) Options {
+	out := make(Options, len(o.options[kind]))
+	copy(out, o.options[kind])
+	return out
+}
+
+// ValidateValue checks that the value references an existing object.
+// Empty value is valid, it is checked by the input rules, if it is required.
+func (o *StorageObjects) ValidateValue(inputDef *Input, value any) error {
+	if !inputDef.Kind.IsStorage() {
+		return nil
+	}
+
+	id := cast.ToString(value)
+	if id == "" || o.options[inputDef.Kind].ContainsID(id) {
+		return nil
+	}
+
+	var object string
+	switch inputDef.Kind {
+	case KindTable:
+		object = "table"
+	case KindBucket:
+		object = "bucket"
+	default:
+		object = "workspace"
+	}
+	return errors.Errorf(`%s %s "%s" not found in the project`, inputDef.Name, object, id)
+}
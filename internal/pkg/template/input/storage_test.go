@@ -0,0 +1,49 @@
+package input
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorageKindsOf(t *testing.T) {
+	t.Parallel()
+	inputs := map[string]*Input{
+		"a": {ID: "a", Kind: KindConnection},
+		"b": {ID: "b", Kind: KindInput},
+		"c": {ID: "c", Kind: KindTable},
+		"d": {ID: "d", Kind: KindTable},
+	}
+	assert.Equal(t, Kinds{KindTable, KindConnection}, StorageKindsOf(inputs))
+	assert.Equal(t, Kinds{}, StorageKindsOf(map[string]*Input{"b": {ID: "b", Kind: KindInput}}))
+}
+
+func TestStorageObjects(t *testing.T) {
+	t.Parallel()
+	objects := NewStorageObjects()
+	objects.Add(KindTable, "in.c-bucket.table", "table")
+	objects.Add(KindBucket, "in.c-bucket", "in.c-bucket")
+	objects.Add(KindConnection, "123", "My Workspace")
+
+	assert.Equal(t, Options{{Value: "in.c-bucket.table", Label: "table (in.c-bucket.table)"}}, objects.Options(KindTable))
+	assert.Equal(t, Options{{Value: "in.c-bucket", Label: "in.c-bucket"}}, objects.Options(KindBucket))
+	assert.Equal(t, Options{{Value: "123", Label: "My Workspace (123)"}}, objects.Options(KindConnection))
+	assert.Empty(t, objects.Options(KindSelect))
+
+	table := &Input{ID: "table", Name: "Source", Kind: KindTable}
+	assert.NoError(t, objects.ValidateValue(table, "in.c-bucket.table"))
+	assert.NoError(t, objects.ValidateValue(table, ""))
+	err := objects.ValidateValue(table, "in.c-bucket.missing")
+	if assert.Error(t, err) {
+		assert.Equal(t, `Source table "in.c-bucket.missing" not found in the project`, err.Error())
+	}
+
+	connection := &Input{ID: "connection", Name: "Connection", Kind: KindConnection}
+	err = objects.ValidateValue(connection, "456")
+	if assert.Error(t, err) {
+		assert.Equal(t, `Connection workspace "456" not found in the project`, err.Error())
+	}
+
+	// Other kinds are not validated
+	assert.NoError(t, objects.ValidateValue(&Input{ID: "foo", Kind: KindInput}, "bar"))
+}
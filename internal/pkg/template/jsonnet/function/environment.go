@@ -0,0 +1,38 @@
+package function
+
+import (
+	"sync"
+
+	"github.com/keboola/go-client/pkg/keboola"
+)
+
+// Environment contains information about the target project, it is used by the Jsonnet functions.
+// In template tests, the values can be mocked, see template.TestEnvironment.
+type Environment struct {
+	StackHost string
+	Features  keboola.FeaturesMap
+	Tables    TablesProvider // optional, nil means no table exists
+}
+
+// Tables maps ID of an existing table to its columns.
+type Tables map[string][]string
+
+// TablesProvider loads existing tables. It is called lazily, only if a table function is used.
+type TablesProvider func() (Tables, error)
+
+// CachedTables wraps the provider, so the tables are loaded at most once.
+func CachedTables(provider TablesProvider) TablesProvider {
+	var once sync.Once
+	var tables Tables
+	var err error
+	return func() (Tables, error) {
+		once.Do(func() {
+			if provider == nil {
+				tables = make(Tables)
+				return
+			}
+			tables, err = provider()
+		})
+		return tables, err
+	}
+}
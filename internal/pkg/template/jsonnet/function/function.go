@@ -7,9 +7,13 @@
 package function
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+
 	"github.com/google/go-jsonnet/ast"
 	"github.com/keboola/go-client/pkg/keboola"
 
+	"github.com/keboola/keboola-as-code/internal/pkg/encoding/json"
 	"github.com/keboola/keboola-as-code/internal/pkg/encoding/jsonnet"
 	"github.com/keboola/keboola-as-code/internal/pkg/model"
 	"github.com/keboola/keboola-as-code/internal/pkg/template/input"
@@ -180,3 +184,120 @@ func HasProjectBackend(backends []string) *jsonnet.NativeFunction {
 		},
 	}
 }
+
+// StackHost Jsonnet function returns host of the Storage API in the stack, for example "connection.keboola.com".
+func StackHost(host string) *jsonnet.NativeFunction {
+	return &jsonnet.NativeFunction{
+		Name:   `StackHost`,
+		Params: ast.Identifiers{},
+		Func: func(params []any) (any, error) {
+			return host, nil
+		},
+	}
+}
+
+// HasProjectFeature Jsonnet function returns true if the project has the feature flag, otherwise false.
+func HasProjectFeature(features keboola.FeaturesMap) *jsonnet.NativeFunction {
+	return &jsonnet.NativeFunction{
+		Name:   `HasProjectFeature`,
+		Params: ast.Identifiers{"feature"},
+		Func: func(params []any) (any, error) {
+			if len(params) != 1 {
+				return nil, errors.Errorf("one parameter expected, found %d", len(params))
+			} else if feature, ok := params[0].(string); !ok {
+				return nil, errors.New("parameter must be a string")
+			} else {
+				return features.Has(feature), nil
+			}
+		},
+	}
+}
+
+// ConfigDefaultBucket Jsonnet function returns default bucket of the configuration, for example "in.c-ex-generic-v2-123".
+// The configId parameter can be a result of the ConfigId function.
+func ConfigDefaultBucket(components *model.ComponentsMap) *jsonnet.NativeFunction {
+	return &jsonnet.NativeFunction{
+		Name:   `ConfigDefaultBucket`,
+		Params: ast.Identifiers{"componentId", "configId"},
+		Func: func(params []any) (any, error) {
+			if len(params) != 2 {
+				return nil, errors.Errorf("two parameters expected, found %d", len(params))
+			}
+			componentID, ok1 := params[0].(string)
+			configID, ok2 := params[1].(string)
+			if !ok1 || !ok2 {
+				return nil, errors.New("parameters must be strings")
+			}
+			bucket, found := components.GetDefaultBucketByComponentID(keboola.ComponentID(componentID), keboola.ConfigID(configID))
+			if !found {
+				return nil, errors.Errorf(`component "%s" not found or it has no default bucket`, componentID)
+			}
+			return bucket, nil
+		},
+	}
+}
+
+// TableExists Jsonnet function returns true if the table exists in the project, otherwise false.
+func TableExists(tables TablesProvider) *jsonnet.NativeFunction {
+	return &jsonnet.NativeFunction{
+		Name:   `TableExists`,
+		Params: ast.Identifiers{"tableId"},
+		Func: func(params []any) (any, error) {
+			if len(params) != 1 {
+				return nil, errors.Errorf("one parameter expected, found %d", len(params))
+			} else if tableID, ok := params[0].(string); !ok {
+				return nil, errors.New("parameter must be a string")
+			} else if existing, err := tables(); err != nil {
+				return nil, err
+			} else {
+				_, found := existing[tableID]
+				return found, nil
+			}
+		},
+	}
+}
+
+// TableColumns Jsonnet function returns columns of an existing table.
+func TableColumns(tables TablesProvider) *jsonnet.NativeFunction {
+	return &jsonnet.NativeFunction{
+		Name:   `TableColumns`,
+		Params: ast.Identifiers{"tableId"},
+		Func: func(params []any) (any, error) {
+			if len(params) != 1 {
+				return nil, errors.Errorf("one parameter expected, found %d", len(params))
+			} else if tableID, ok := params[0].(string); !ok {
+				return nil, errors.New("parameter must be a string")
+			} else if existing, err := tables(); err != nil {
+				return nil, err
+			} else if columns, found := existing[tableID]; !found {
+				return nil, errors.Errorf(`table "%s" not found`, tableID)
+			} else {
+				out := make([]any, len(columns))
+				for i, column := range columns {
+					out[i] = column
+				}
+				return out, nil
+			}
+		},
+	}
+}
+
+// Hash Jsonnet function returns deterministic SHA256 hash of the value, as a hex string.
+// Objects are hashed with sorted keys, so the result does not depend on the order of the fields.
+func Hash() *jsonnet.NativeFunction {
+	return &jsonnet.NativeFunction{
+		Name:   `Hash`,
+		Params: ast.Identifiers{"value"},
+		Func: func(params []any) (any, error) {
+			if len(params) != 1 {
+				return nil, errors.Errorf("one parameter expected, found %d", len(params))
+			}
+			bytes, err := json.Encode(params[0], false)
+			if err != nil {
+				return nil, errors.Errorf("cannot hash the value: %w", err)
+			}
+			sum := sha256.Sum256(bytes)
+			return hex.EncodeToString(sum[:]), nil
+		},
+	}
+}
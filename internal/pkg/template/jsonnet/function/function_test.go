@@ -58,3 +58,96 @@ func TestSnowflakeWriterComponentID(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigDefaultBucket(t *testing.T) {
+	t.Parallel()
+	components := model.NewComponentsMap(keboola.Components{
+		{
+			ComponentKey: keboola.ComponentKey{ID: "keboola.ex-db-mysql"},
+			Type:         "extractor",
+			Name:         "MySQL",
+			Data:         keboola.ComponentData{DefaultBucket: true, DefaultBucketStage: "in"},
+		},
+		{
+			ComponentKey: keboola.ComponentKey{ID: "keboola.orchestrator"},
+			Type:         "other",
+			Name:         "Orchestrator",
+		},
+	})
+	fn := ConfigDefaultBucket(components)
+
+	bucket, err := fn.Func([]any{"keboola.ex-db-mysql", "123"})
+	require.NoError(t, err)
+	assert.Equal(t, "in.c-keboola-ex-db-mysql-123", bucket)
+
+	_, err = fn.Func([]any{"keboola.orchestrator", "123"})
+	if assert.Error(t, err) {
+		assert.Equal(t, `component "keboola.orchestrator" not found or it has no default bucket`, err.Error())
+	}
+
+	_, err = fn.Func([]any{"keboola.ex-db-mysql"})
+	assert.Error(t, err)
+}
+
+func TestHasProjectFeature(t *testing.T) {
+	t.Parallel()
+	fn := HasProjectFeature(keboola.Features{"foo"}.ToMap())
+
+	v, err := fn.Func([]any{"foo"})
+	require.NoError(t, err)
+	assert.Equal(t, true, v)
+
+	v, err = fn.Func([]any{"bar"})
+	require.NoError(t, err)
+	assert.Equal(t, false, v)
+}
+
+func TestTableFunctions(t *testing.T) {
+	t.Parallel()
+	calls := 0
+	tables := CachedTables(func() (Tables, error) {
+		calls++
+		return Tables{"in.c-bucket.table": {"id", "name"}}, nil
+	})
+
+	v, err := TableExists(tables).Func([]any{"in.c-bucket.table"})
+	require.NoError(t, err)
+	assert.Equal(t, true, v)
+
+	v, err = TableExists(tables).Func([]any{"in.c-bucket.missing"})
+	require.NoError(t, err)
+	assert.Equal(t, false, v)
+
+	v, err = TableColumns(tables).Func([]any{"in.c-bucket.table"})
+	require.NoError(t, err)
+	assert.Equal(t, []any{"id", "name"}, v)
+
+	_, err = TableColumns(tables).Func([]any{"in.c-bucket.missing"})
+	if assert.Error(t, err) {
+		assert.Equal(t, `table "in.c-bucket.missing" not found`, err.Error())
+	}
+
+	// Tables are loaded only once
+	assert.Equal(t, 1, calls)
+
+	// No provider, no tables
+	v, err = TableExists(CachedTables(nil)).Func([]any{"in.c-bucket.table"})
+	require.NoError(t, err)
+	assert.Equal(t, false, v)
+}
+
+func TestHash(t *testing.T) {
+	t.Parallel()
+	fn := Hash()
+
+	v1, err := fn.Func([]any{map[string]any{"a": 1.0, "b": "x"}})
+	require.NoError(t, err)
+	v2, err := fn.Func([]any{map[string]any{"b": "x", "a": 1.0}})
+	require.NoError(t, err)
+	assert.Equal(t, v1, v2)
+	assert.Len(t, v1, 64)
+
+	v3, err := fn.Func([]any{"foo"})
+	require.NoError(t, err)
+	assert.Equal(t, "b2213295d564916f89a6a42455567c87c3f480fcd7a1c15e220f17d7169a790b", v3)
+}
@@ -0,0 +1,293 @@
+// Package archive provides template repository of the "archive" type.
+//
+// The repository is a tar.gz archive, it is downloaded from:
+//   - an HTTPS URL, for example "https://example.com/templates/v1.2.3.tar.gz",
+//   - or an OCI registry, for example "oci://registry.example.com/templates:v1.2.3".
+//
+// The archive is verified by the checksum and/or by the ed25519 signature, at least one of them must be defined in the repository definition.
+// The signature is created from the digest of the archive, for example "sha256:<hex>", so the archive is never fully loaded to the memory.
+// Downloaded archives are cached locally by their digest,
+// so an archive with a pinned checksum can be loaded even without access to the network.
+//
+// Repository implements the same interface as git.RemoteRepository, so it can be updated by the Pull method.
+//
+// nolint: forbidigo
+package archive
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/filesystem"
+	"github.com/keboola/keboola-as-code/internal/pkg/filesystem/aferofs"
+	"github.com/keboola/keboola-as-code/internal/pkg/git"
+	"github.com/keboola/keboola-as-code/internal/pkg/idgenerator"
+	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+const (
+	DefaultTimeout = 2 * time.Minute
+	OCIScheme      = "oci://"
+)
+
+type Repository struct {
+	ref         model.TemplateRepository
+	config      config
+	logger      log.Logger
+	source      source
+	baseDirPath string // base directory for extracted archives
+
+	valuesLock   *sync.RWMutex // sync access to stableDir and stableDigest fields
+	stableDir    *fsWithFreeLock
+	stableDigest string
+
+	pullLock *sync.Mutex // only one pull can run at a time
+}
+
+type config struct {
+	client   *http.Client
+	cacheDir string
+	timeout  time.Duration
+}
+
+type Option func(c *config)
+
+// WithHTTPClient sets HTTP client used to download the archive.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *config) {
+		c.client = client
+	}
+}
+
+// WithCacheDir sets directory where the downloaded archives are cached.
+func WithCacheDir(dir string) Option {
+	return func(c *config) {
+		c.cacheDir = dir
+	}
+}
+
+// WithTimeout sets timeout of the download.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *config) {
+		c.timeout = timeout
+	}
+}
+
+// DefaultCacheDir returns the user cache directory, or the temp directory if it is not available.
+func DefaultCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "keboola-as-code", "templates")
+}
+
+// Fetch downloads, verifies and extracts the archive.
+func Fetch(ctx context.Context, ref model.TemplateRepository, logger log.Logger, opts ...Option) (r *Repository, err error) {
+	// Apply options
+	cfg := config{client: http.DefaultClient, cacheDir: DefaultCacheDir(), timeout: DefaultTimeout}
+	for _, o := range opts {
+		o(&cfg)
+	}
+
+	// Create cache directory
+	if err := os.MkdirAll(cfg.cacheDir, 0o700); err != nil {
+		return nil, errors.Errorf("cannot create cache dir for archive repository: %w", err)
+	}
+
+	// Create base directory
+	baseDirPath, err := os.MkdirTemp("", "kac-archive-repository-")
+	if err != nil {
+		return nil, errors.Errorf("cannot create temp dir for archive repository: %w", err)
+	}
+
+	// Clear everything if fetch fails
+	defer func() {
+		if err != nil {
+			_ = os.RemoveAll(baseDirPath)
+		}
+	}()
+
+	r = &Repository{
+		ref:         ref,
+		config:      cfg,
+		logger:      logger,
+		source:      newSource(ref, cfg),
+		baseDirPath: baseDirPath,
+		valuesLock:  &sync.RWMutex{},
+		pullLock:    &sync.Mutex{},
+	}
+
+	if _, err := r.Pull(ctx); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (r *Repository) String() string {
+	return r.ref.String()
+}
+
+func (r *Repository) Definition() model.TemplateRepository {
+	return r.ref
+}
+
+// URL of the archive.
+func (r *Repository) URL() string {
+	return r.ref.URL
+}
+
+// Ref is not used by the archive repository, the version is part of the URL.
+func (r *Repository) Ref() string {
+	return r.ref.Ref
+}
+
+// CommitHash returns digest of the archive, for example "sha256:...".
+func (r *Repository) CommitHash() string {
+	r.valuesLock.RLock()
+	defer r.valuesLock.RUnlock()
+	return r.stableDigest
+}
+
+// Fs return repository filesystem.
+func (r *Repository) Fs() (filesystem.Fs, git.RepositoryFsUnlockFn) {
+	// Sync access to the "stableDir" field
+	r.valuesLock.RLock()
+	defer r.valuesLock.RUnlock()
+
+	// Postpone free operation when FS is used
+	r.stableDir.freeLock.RLock()
+	return r.stableDir._fs, r.stableDir.freeLock.RUnlock
+}
+
+// Pull downloads the archive again, the repository is changed only if the digest of the archive differs.
+func (r *Repository) Pull(ctx context.Context) (*git.PullResult, error) {
+	r.pullLock.Lock()
+	defer r.pullLock.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, r.config.timeout)
+	defer cancel()
+
+	// Download or load the archive from the cache
+	content, err := r.source.fetch(ctx)
+	if err != nil {
+		return nil, errors.Errorf(`cannot fetch archive repository "%s": %w`, r.ref.URL, err)
+	}
+
+	// Verify checksum and signature
+	if err := verify(r.ref, content); err != nil {
+		return nil, errors.Errorf(`cannot verify archive repository "%s": %w`, r.ref.URL, err)
+	}
+
+	// Update stable dir if digest differs
+	oldDigest := r.CommitHash()
+	result := &git.PullResult{OldHash: oldDigest, NewHash: content.digest, Changed: content.digest != oldDigest}
+	if result.Changed {
+		if err := r.extractToStableDir(content); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func (r *Repository) Free() <-chan struct{} {
+	done := make(chan struct{})
+
+	go func() {
+		// Sync access to the "stableDir" field
+		r.valuesLock.Lock()
+		defer r.valuesLock.Unlock()
+
+		if r.stableDir != nil {
+			<-r.stableDir.free()
+			r.stableDir = nil
+		}
+
+		_ = os.RemoveAll(r.baseDirPath)
+		close(done)
+	}()
+
+	return done
+}
+
+func (r *Repository) extractToStableDir(content *archiveContent) (err error) {
+	// Create stable dir
+	stableDir := filepath.Join(r.baseDirPath, strings.TrimPrefix(content.digest, digestPrefix)[:12]+"-"+idgenerator.Random(8))
+	if err := os.Mkdir(stableDir, 0o700); err != nil {
+		return errors.Errorf("cannot create stable dir for archive repository: %w", err)
+	}
+
+	// Clear stable dir if extraction fails
+	defer func() {
+		if err != nil {
+			_ = os.RemoveAll(stableDir)
+		}
+	}()
+
+	// Extract, the archive is streamed from the cache
+	file, err := os.Open(content.path)
+	if err != nil {
+		return errors.Errorf(`cannot open archive repository "%s": %w`, r.ref.URL, err)
+	}
+	defer file.Close()
+	rootDir, err := extract(file, stableDir)
+	if err != nil {
+		return errors.Errorf(`cannot extract archive repository "%s": %w`, r.ref.URL, err)
+	}
+
+	// Create FS
+	stableDirFs, err := aferofs.NewLocalFs(rootDir, filesystem.WithLogger(r.logger))
+	if err != nil {
+		return errors.Errorf("cannot setup stable fs for archive repository: %w", err)
+	}
+
+	// Sync access to fields
+	r.valuesLock.Lock()
+	defer r.valuesLock.Unlock()
+
+	// Free old stable dir
+	if r.stableDir != nil {
+		r.stableDir.free()
+	}
+
+	// Replace values
+	r.stableDir = newFsWithFreeLock(stableDirFs, stableDir)
+	r.stableDigest = content.digest
+
+	return nil
+}
+
+type _fs = filesystem.Fs
+
+type fsWithFreeLock struct {
+	_fs
+	dir      string
+	freeLock *sync.RWMutex
+}
+
+func newFsWithFreeLock(fs filesystem.Fs, dir string) *fsWithFreeLock {
+	return &fsWithFreeLock{_fs: fs, dir: dir, freeLock: &sync.RWMutex{}}
+}
+
+func (v *fsWithFreeLock) free() <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		// Postpone free operation until the FS is no longer in use
+		v.freeLock.Lock()
+		defer v.freeLock.Unlock()
+
+		// Delete directory
+		_ = os.RemoveAll(v.dir)
+		close(done)
+	}()
+	return done
+}
@@ -0,0 +1,286 @@
+package archive
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/filesystem"
+	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/model"
+)
+
+func TestFetch_HTTPS(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	// Archive is served with ETag and signature
+	var data atomic.Pointer[[]byte]
+	v1 := createArchive(t, "templates/", map[string]string{".keboola/repository.json": "{}", "template1/README.md": "v1"})
+	data.Store(&v1)
+	var downloads atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content := *data.Load()
+		etag := `"` + digestOf(content) + `"`
+		switch r.URL.Path {
+		case "/templates.tar.gz":
+			if r.Header.Get("If-None-Match") == etag {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			downloads.Add(1)
+			w.Header().Set("ETag", etag)
+			_, _ = w.Write(content)
+		case "/templates.tar.gz.sig":
+			_, _ = w.Write([]byte(base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, []byte(digestOf(content))))))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	ref := model.TemplateRepository{
+		Type:      model.RepositoryTypeArchive,
+		Name:      "archive",
+		URL:       server.URL + "/templates.tar.gz",
+		PublicKey: base64.StdEncoding.EncodeToString(publicKey),
+	}
+	repo, err := Fetch(ctx, ref, log.NewNopLogger(), WithCacheDir(t.TempDir()))
+	require.NoError(t, err)
+	defer func() { <-repo.Free() }()
+
+	// The single top-level directory is the root of the repository
+	assert.Equal(t, digestOf(v1), repo.CommitHash())
+	assert.Equal(t, "v1", readFile(t, repo, "template1/README.md"))
+	assert.Equal(t, int32(1), downloads.Load())
+
+	// Archive has not been modified
+	result, err := repo.Pull(ctx)
+	require.NoError(t, err)
+	assert.False(t, result.Changed)
+	assert.Equal(t, int32(1), downloads.Load())
+
+	// Archive has been modified
+	v2 := createArchive(t, "", map[string]string{".keboola/repository.json": "{}", "template1/README.md": "v2"})
+	data.Store(&v2)
+	result, err = repo.Pull(ctx)
+	require.NoError(t, err)
+	assert.True(t, result.Changed)
+	assert.Equal(t, digestOf(v1), result.OldHash)
+	assert.Equal(t, digestOf(v2), result.NewHash)
+	assert.Equal(t, "v2", readFile(t, repo, "template1/README.md"))
+	assert.Equal(t, int32(2), downloads.Load())
+}
+
+func TestFetch_PinnedChecksum_Cache(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	content := createArchive(t, "", map[string]string{".keboola/repository.json": "{}"})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(content)
+	}))
+
+	// Invalid checksum
+	cacheDir := t.TempDir()
+	ref := model.TemplateRepository{Type: model.RepositoryTypeArchive, Name: "archive", URL: server.URL + "/templates.tar.gz", Checksum: "sha256:" + strings.Repeat("0", 64)}
+	_, err := Fetch(ctx, ref, log.NewNopLogger(), WithCacheDir(cacheDir))
+	require.Error(t, err)
+	assert.Equal(t, fmt.Sprintf(`cannot verify archive repository "%s": checksum mismatch, expected "%s", found "%s"`, ref.URL, ref.Checksum, digestOf(content)), err.Error())
+
+	// Valid checksum
+	ref.Checksum = digestOf(content)
+	repo, err := Fetch(ctx, ref, log.NewNopLogger(), WithCacheDir(cacheDir))
+	require.NoError(t, err)
+	<-repo.Free()
+
+	// The archive is loaded from the cache, when the server is not available
+	server.Close()
+	repo, err = Fetch(ctx, ref, log.NewNopLogger(), WithCacheDir(cacheDir))
+	require.NoError(t, err)
+	assert.Equal(t, "{}", readFile(t, repo, ".keboola/repository.json"))
+	<-repo.Free()
+}
+
+func TestFetch_MissingVerification(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	content := createArchive(t, "", map[string]string{".keboola/repository.json": "{}"})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(content)
+	}))
+	defer server.Close()
+
+	ref := model.TemplateRepository{Type: model.RepositoryTypeArchive, Name: "archive", URL: server.URL + "/templates.tar.gz"}
+	_, err := Fetch(ctx, ref, log.NewNopLogger(), WithCacheDir(t.TempDir()))
+	require.Error(t, err)
+	assert.Equal(t, fmt.Sprintf(`cannot verify archive repository "%s": archive must be verified by a checksum or a public key`, ref.URL), err.Error())
+}
+
+func TestFetch_OCI(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	content := createArchive(t, "", map[string]string{".keboola/repository.json": "{}", "template1/README.md": "oci"})
+	digest := digestOf(content)
+	signature := base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, []byte(digestOf(content))))
+
+	// Registry requires an anonymous bearer token
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			assert.Equal(t, "repository:keboola/templates:pull", r.URL.Query().Get("scope"))
+			_, _ = w.Write([]byte(`{"token": "my-token"}`))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer my-token" {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry",scope="repository:keboola/templates:pull"`, server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/v2/keboola/templates/manifests/v1":
+			assert.Equal(t, ociManifestMediaType, r.Header.Get("Accept"))
+			_, _ = fmt.Fprintf(w, `{"layers": [{"mediaType": "application/vnd.oci.image.layer.v1.tar+gzip", "digest": "%s", "annotations": {"%s": "%s"}}]}`, digest, SignatureAnnotation, signature)
+		case "/v2/keboola/templates/blobs/" + digest:
+			_, _ = w.Write(content)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	ref := model.TemplateRepository{
+		Type:      model.RepositoryTypeArchive,
+		Name:      "oci",
+		URL:       "oci://" + strings.TrimPrefix(server.URL, "https://") + "/keboola/templates:v1",
+		PublicKey: base64.StdEncoding.EncodeToString(publicKey),
+	}
+	repo, err := Fetch(ctx, ref, log.NewNopLogger(), WithCacheDir(t.TempDir()), WithHTTPClient(server.Client()))
+	require.NoError(t, err)
+	defer func() { <-repo.Free() }()
+	assert.Equal(t, digest, repo.CommitHash())
+	assert.Equal(t, "oci", readFile(t, repo, "template1/README.md"))
+
+	// Invalid signature
+	otherKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	ref.PublicKey = base64.StdEncoding.EncodeToString(otherKey)
+	_, err = Fetch(ctx, ref, log.NewNopLogger(), WithCacheDir(t.TempDir()), WithHTTPClient(server.Client()))
+	require.Error(t, err)
+	assert.Equal(t, fmt.Sprintf(`cannot verify archive repository "%s": invalid signature`, ref.URL), err.Error())
+}
+
+func TestOCIReference(t *testing.T) {
+	t.Parallel()
+
+	registry, repository, reference, err := ociReference("oci://registry.com:5000/keboola/templates:v1.2.3")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"registry.com:5000", "keboola/templates", "v1.2.3"}, []string{registry, repository, reference})
+
+	registry, repository, reference, err = ociReference("oci://registry.com/templates@sha256:abc")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"registry.com", "templates", "sha256:abc"}, []string{registry, repository, reference})
+
+	registry, repository, reference, err = ociReference("oci://registry.com/templates")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"registry.com", "templates", "latest"}, []string{registry, repository, reference})
+
+	_, _, _, err = ociReference("oci://registry.com")
+	assert.EqualError(t, err, `invalid OCI reference "oci://registry.com", expected "oci://<registry>/<repository>:<tag>"`)
+}
+
+func TestExtract_Invalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := extract(bytes.NewReader(createArchive(t, "", map[string]string{"../evil.txt": "foo"})), t.TempDir())
+	assert.EqualError(t, err, `invalid path "../evil.txt" in the archive`)
+
+	_, err = extract(bytes.NewReader(createArchive(t, "", map[string]string{"foo.txt": "foo"})), t.TempDir())
+	assert.EqualError(t, err, `repository manifest ".keboola/repository.json" not found in the archive`)
+}
+
+func TestExtract_SizeLimit(t *testing.T) {
+	t.Parallel()
+
+	manifest := map[string]string{".keboola/repository.json": "{}"}
+
+	// Within the limit
+	_, err := extractWithLimit(bytes.NewReader(createArchive(t, "", manifest)), t.TempDir(), 10)
+	require.NoError(t, err)
+
+	// Single entry exceeds the limit
+	_, err = extractWithLimit(bytes.NewReader(createArchive(t, "", map[string]string{"big.txt": strings.Repeat("x", 11)})), t.TempDir(), 10)
+	assert.EqualError(t, err, `extracted files are larger than 10 bytes, the limit was exceeded by "big.txt"`)
+
+	// Total size of entries exceeds the limit, each entry is within the limit
+	_, err = extractWithLimit(bytes.NewReader(createOrderedArchive(t, []string{"a.txt", "b.txt", "c.txt"}, "xxxx")), t.TempDir(), 10)
+	assert.EqualError(t, err, `extracted files are larger than 10 bytes, the limit was exceeded by "c.txt"`)
+}
+
+// createOrderedArchive creates an archive with entries in the defined order, all with the same content.
+func createOrderedArchive(t *testing.T, paths []string, content string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for _, path := range paths {
+		require.NoError(t, tarWriter.WriteHeader(&tar.Header{Name: path, Typeflag: tar.TypeReg, Mode: 0o600, Size: int64(len(content))}))
+		_, err := tarWriter.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tarWriter.Close())
+	require.NoError(t, gzipWriter.Close())
+	return buf.Bytes()
+}
+
+func createArchive(t *testing.T, prefix string, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for path, content := range files {
+		require.NoError(t, tarWriter.WriteHeader(&tar.Header{Name: prefix + path, Typeflag: tar.TypeReg, Mode: 0o600, Size: int64(len(content))}))
+		_, err := tarWriter.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tarWriter.Close())
+	require.NoError(t, gzipWriter.Close())
+	return buf.Bytes()
+}
+
+func readFile(t *testing.T, repo *Repository, path string) string {
+	t.Helper()
+	fs, unlockFn := repo.Fs()
+	defer unlockFn()
+	file, err := fs.ReadFile(context.Background(), filesystem.NewFileDef(path))
+	require.NoError(t, err)
+	return file.Content
+}
+
+func digestOf(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,88 @@
+// nolint: forbidigo
+package archive
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/template/repository/manifest"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+// extract the tar.gz archive to the directory.
+// It returns the root directory of the repository, it is the directory itself or a single top-level directory in the archive.
+// Only regular files and directories are extracted, other entries, for example links, are skipped.
+// Extracted files must not exceed MaxArchiveSize in total, so a small compressed archive cannot fill the disk.
+func extract(r io.Reader, dir string) (string, error) {
+	return extractWithLimit(r, dir, MaxArchiveSize)
+}
+
+func extractWithLimit(r io.Reader, dir string, limit int64) (string, error) {
+	gzipReader, err := gzip.NewReader(r)
+	if err != nil {
+		return "", err
+	}
+	defer gzipReader.Close()
+
+	tarReader := tar.NewReader(gzipReader)
+	var total int64
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return "", err
+		}
+
+		// Path must be relative and inside the directory
+		name := filepath.Clean(filepath.FromSlash(header.Name))
+		if filepath.IsAbs(name) || name == ".." || strings.HasPrefix(name, ".."+string(filepath.Separator)) {
+			return "", errors.Errorf(`invalid path "%s" in the archive`, header.Name)
+		}
+		target := filepath.Join(dir, name)
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0o700); err != nil {
+				return "", err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0o700); err != nil {
+				return "", err
+			}
+			file, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+			if err != nil {
+				return "", err
+			}
+			// Read at most one byte over the remaining limit, to detect the exceeded limit
+			written, err := io.Copy(file, io.LimitReader(tarReader, limit-total+1))
+			if closeErr := file.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				return "", err
+			}
+			total += written
+			if total > limit {
+				return "", errors.Errorf(`extracted files are larger than %d bytes, the limit was exceeded by "%s"`, limit, header.Name)
+			}
+		}
+	}
+
+	// Find the repository manifest
+	manifestPath := filepath.FromSlash(manifest.Path())
+	if _, err := os.Stat(filepath.Join(dir, manifestPath)); err == nil {
+		return dir, nil
+	}
+	if entries, err := os.ReadDir(dir); err == nil && len(entries) == 1 && entries[0].IsDir() {
+		root := filepath.Join(dir, entries[0].Name())
+		if _, err := os.Stat(filepath.Join(root, manifestPath)); err == nil {
+			return root, nil
+		}
+	}
+	return "", errors.Errorf(`repository manifest "%s" not found in the archive`, manifest.Path())
+}
@@ -0,0 +1,248 @@
+package archive
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/encoding/json"
+	"github.com/keboola/keboola-as-code/internal/pkg/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+const (
+	// SignatureAnnotation of the OCI manifest or the layer contains base64 encoded ed25519 signature of the archive.
+	SignatureAnnotation  = "com.keboola.templates.signature"
+	ociManifestMediaType = "application/vnd.oci.image.manifest.v1+json"
+	maxOCIManifestSize   = 1024 * 1024
+)
+
+type ociManifest struct {
+	Layers      []ociDescriptor   `json:"layers"`
+	Annotations map[string]string `json:"annotations"`
+}
+
+type ociDescriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Annotations map[string]string `json:"annotations"`
+}
+
+// ociSource downloads the archive from an OCI registry, the archive is the first tar+gzip layer of the artifact.
+// The layer is not downloaded again, if it is already cached.
+type ociSource struct {
+	ref    model.TemplateRepository
+	client *http.Client
+	cache  cache
+	token  string // bearer token, if the registry requires it
+}
+
+// ociReference parses "oci://<registry>/<repository>:<tag>" or "oci://<registry>/<repository>@<digest>".
+func ociReference(str string) (registry, repository, reference string, err error) {
+	registry, path, found := strings.Cut(strings.TrimPrefix(str, OCIScheme), "/")
+	if !found || registry == "" || path == "" {
+		return "", "", "", errors.Errorf(`invalid OCI reference "%s", expected "oci://<registry>/<repository>:<tag>"`, str)
+	}
+	if repository, reference, found = strings.Cut(path, "@"); found {
+		return registry, repository, reference, nil
+	}
+	if i := strings.LastIndex(path, ":"); i > 0 {
+		return registry, path[:i], path[i+1:], nil
+	}
+	return registry, path, "latest", nil
+}
+
+func (s *ociSource) fetch(ctx context.Context) (*archiveContent, error) {
+	// Archive with the pinned checksum can be loaded from the cache without the network access
+	if s.ref.Checksum != "" {
+		if content, found := s.cache.load(s.ref.Checksum); found {
+			return content, nil
+		}
+	}
+
+	registry, repository, reference, err := ociReference(s.ref.URL)
+	if err != nil {
+		return nil, err
+	}
+	baseURL := "https://" + registry + "/v2/" + repository
+
+	// Get manifest
+	body, err := s.get(ctx, baseURL+"/manifests/"+reference, ociManifestMediaType)
+	if err != nil {
+		return nil, err
+	}
+	manifest := &ociManifest{}
+	if err := json.Decode(body, manifest); err != nil {
+		return nil, errors.Errorf(`cannot decode OCI manifest: %w`, err)
+	}
+
+	// Find the archive layer
+	var layer *ociDescriptor
+	for i := range manifest.Layers {
+		if strings.HasSuffix(manifest.Layers[i].MediaType, "tar+gzip") {
+			layer = &manifest.Layers[i]
+			break
+		}
+	}
+	if layer == nil {
+		return nil, errors.Errorf(`no tar+gzip layer found in the OCI manifest "%s"`, s.ref.URL)
+	}
+
+	// Get signature, if it should be verified
+	var signature []byte
+	if s.ref.PublicKey != "" {
+		str := layer.Annotations[SignatureAnnotation]
+		if str == "" {
+			str = manifest.Annotations[SignatureAnnotation]
+		}
+		if str == "" {
+			return nil, errors.Errorf(`signature annotation "%s" not found in the OCI manifest`, SignatureAnnotation)
+		}
+		if signature, err = decodeSignature(str); err != nil {
+			return nil, err
+		}
+	}
+
+	// Use the cached layer or stream it to the cache
+	content, found := s.cache.load(layer.Digest)
+	if !found {
+		body, err := s.open(ctx, baseURL+"/blobs/"+layer.Digest, "")
+		if err != nil {
+			return nil, err
+		}
+		content, err = s.cache.store(body)
+		_ = body.Close()
+		if err != nil {
+			return nil, err
+		}
+		if content.digest != layer.Digest {
+			_ = os.Remove(content.path) // nolint: forbidigo
+			return nil, errors.Errorf(`digest of the downloaded layer "%s" does not match the manifest "%s"`, content.digest, layer.Digest)
+		}
+	}
+
+	// Save the signature to the cache
+	if len(signature) > 0 {
+		content.signature = signature
+		if err := s.cache.saveSignature(content); err != nil {
+			return nil, err
+		}
+	}
+
+	return content, nil
+}
+
+// get sends GET request to the registry and reads the small response body, for example the manifest.
+func (s *ociSource) get(ctx context.Context, url, accept string) ([]byte, error) {
+	body, err := s.open(ctx, url, accept)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(body, maxOCIManifestSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxOCIManifestSize {
+		return nil, errors.Errorf(`response of "%s" is larger than %d bytes`, url, maxOCIManifestSize)
+	}
+	return data, nil
+}
+
+// open sends GET request to the registry and returns the response body, the caller must close it.
+// If the registry requires a bearer token, an anonymous token is obtained, see https://distribution.github.io/distribution/spec/auth/token/.
+func (s *ociSource) open(ctx context.Context, url, accept string) (io.ReadCloser, error) {
+	resp, err := s.do(ctx, url, accept)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+		if err := s.authorize(ctx, challenge); err != nil {
+			return nil, err
+		}
+		if resp, err = s.do(ctx, url, accept); err != nil {
+			return nil, err
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, errors.Errorf(`cannot get "%s": unexpected status code %d`, url, resp.StatusCode)
+	}
+	return resp.Body, nil
+}
+
+func (s *ociSource) do(ctx context.Context, url, accept string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+	return s.client.Do(req)
+}
+
+func (s *ociSource) authorize(ctx context.Context, challenge string) error {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return errors.Errorf(`unsupported OCI registry authentication "%s"`, scheme)
+	}
+
+	// Parse challenge parameters, for example: realm="https://auth.example.com/token",service="registry",scope="repository:foo:pull"
+	values := make(map[string]string)
+	for _, param := range strings.Split(params, ",") {
+		if key, value, found := strings.Cut(strings.TrimSpace(param), "="); found {
+			values[key] = strings.Trim(value, `"`)
+		}
+	}
+	realm, err := url.Parse(values["realm"])
+	if err != nil || values["realm"] == "" {
+		return errors.Errorf(`invalid realm in the OCI registry authentication challenge "%s"`, challenge)
+	}
+	query := realm.Query()
+	for _, key := range []string{"service", "scope"} {
+		if values[key] != "" {
+			query.Set(key, values[key])
+		}
+	}
+	realm.RawQuery = query.Encode()
+
+	// Get anonymous token
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf(`cannot get OCI registry token: unexpected status code %d`, resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1024*1024))
+	if err != nil {
+		return err
+	}
+	token := struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+	if err := json.Decode(body, &token); err != nil {
+		return errors.Errorf(`cannot decode OCI registry token: %w`, err)
+	}
+	s.token = token.Token
+	if s.token == "" {
+		s.token = token.AccessToken
+	}
+	return nil
+}
@@ -0,0 +1,258 @@
+// nolint: forbidigo
+package archive
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+const (
+	// MaxArchiveSize limits size of the downloaded archive.
+	MaxArchiveSize = 512 * 1024 * 1024
+	// SignatureSuffix is appended to the HTTPS URL of the archive to get URL of the signature.
+	SignatureSuffix = ".sig"
+	digestPrefix    = "sha256:"
+)
+
+// archiveContent is the archive stored in the cache, it is never fully loaded to the memory.
+type archiveContent struct {
+	path      string // path to the archive in the cache
+	digest    string // "sha256:<hex>"
+	signature []byte
+}
+
+// source downloads the archive.
+type source interface {
+	fetch(ctx context.Context) (*archiveContent, error)
+}
+
+func newSource(ref model.TemplateRepository, cfg config) source {
+	c := cache{dir: cfg.cacheDir}
+	if strings.HasPrefix(ref.URL, OCIScheme) {
+		return &ociSource{ref: ref, client: cfg.client, cache: c}
+	}
+	return &httpsSource{ref: ref, client: cfg.client, cache: c}
+}
+
+// httpsSource downloads the archive from an HTTPS URL.
+// ETag of the last response is cached, so the archive is not downloaded again, if it has not been modified.
+type httpsSource struct {
+	ref    model.TemplateRepository
+	client *http.Client
+	cache  cache
+}
+
+func (s *httpsSource) fetch(ctx context.Context) (*archiveContent, error) {
+	// Archive with the pinned checksum can be loaded from the cache without the network access
+	if s.ref.Checksum != "" {
+		if content, found := s.cache.load(s.ref.Checksum); found {
+			return content, nil
+		}
+	}
+
+	// Send conditional request, if the archive is cached
+	etag, etagDigest := s.cache.loadETag(s.ref.URL)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.ref.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if etag != "" && s.cache.exists(etagDigest) {
+		req.Header.Set("If-None-Match", etag)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Archive has not been modified
+	if resp.StatusCode == http.StatusNotModified {
+		if content, found := s.cache.load(etagDigest); found {
+			return content, nil
+		}
+		return nil, errors.Errorf(`archive "%s" not found in the cache`, etagDigest)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf(`unexpected status code %d`, resp.StatusCode)
+	}
+
+	// Stream the archive to the cache, the digest is computed on the fly
+	content, err := s.cache.store(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Download signature, if it should be verified
+	if s.ref.PublicKey != "" {
+		if content.signature, err = s.fetchSignature(ctx); err != nil {
+			return nil, err
+		}
+		if err := s.cache.saveSignature(content); err != nil {
+			return nil, err
+		}
+	}
+
+	// Remember ETag of the response
+	if etag := resp.Header.Get("ETag"); etag != "" {
+		if err := s.cache.saveETag(s.ref.URL, etag, content.digest); err != nil {
+			return nil, err
+		}
+	}
+
+	return content, nil
+}
+
+func (s *httpsSource) fetchSignature(ctx context.Context) ([]byte, error) {
+	url := s.ref.URL + SignatureSuffix
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf(`cannot download signature "%s": unexpected status code %d`, url, resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if err != nil {
+		return nil, err
+	}
+	return decodeSignature(string(body))
+}
+
+// cache stores archives by their digest.
+type cache struct {
+	dir string
+}
+
+func (c cache) path(digest, ext string) string {
+	return filepath.Join(c.dir, strings.TrimPrefix(digest, digestPrefix)+ext)
+}
+
+func (c cache) exists(digest string) bool {
+	if digest == "" {
+		return false
+	}
+	_, err := os.Stat(c.path(digest, ".tar.gz"))
+	return err == nil
+}
+
+// load archive from the cache, the digest of the file is checked, so a corrupted file is ignored.
+func (c cache) load(digest string) (*archiveContent, bool) {
+	path := c.path(digest, ".tar.gz")
+	if actual, err := digestOfFile(path); err != nil || actual != digest {
+		return nil, false
+	}
+	content := &archiveContent{path: path, digest: digest}
+	if signature, err := os.ReadFile(c.path(digest, SignatureSuffix)); err == nil {
+		content.signature = signature
+	}
+	return content, true
+}
+
+// store streams the archive to the cache, the digest is computed during the write.
+// The archive is limited by MaxArchiveSize.
+func (c cache) store(r io.Reader) (content *archiveContent, err error) {
+	tmp, err := os.CreateTemp(c.dir, "download-*.tmp")
+	if err != nil {
+		return nil, errors.Errorf(`cannot save archive to the cache: %w`, err)
+	}
+
+	// Remove the temp file if the download fails
+	defer func() {
+		if err != nil {
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	// Read at most one byte over the limit, to detect the exceeded limit
+	hash := sha256.New()
+	written, err := io.Copy(io.MultiWriter(tmp, hash), io.LimitReader(r, MaxArchiveSize+1))
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	if written > MaxArchiveSize {
+		return nil, errors.Errorf(`archive is larger than %d bytes`, MaxArchiveSize)
+	}
+
+	digest := digestPrefix + hex.EncodeToString(hash.Sum(nil))
+	path := c.path(digest, ".tar.gz")
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return nil, errors.Errorf(`cannot save archive to the cache: %w`, err)
+	}
+	return &archiveContent{path: path, digest: digest}, nil
+}
+
+func (c cache) saveSignature(content *archiveContent) error {
+	if err := writeFileAtomic(c.path(content.digest, SignatureSuffix), content.signature); err != nil {
+		return errors.Errorf(`cannot save signature to the cache: %w`, err)
+	}
+	return nil
+}
+
+func (c cache) etagPath(url string) string {
+	hash := sha256.Sum256([]byte(url))
+	return filepath.Join(c.dir, hex.EncodeToString(hash[:])+".etag")
+}
+
+// loadETag returns ETag and digest of the archive last downloaded from the URL.
+func (c cache) loadETag(url string) (etag, digest string) {
+	data, err := os.ReadFile(c.etagPath(url))
+	if err != nil {
+		return "", ""
+	}
+	etag, digest, _ = strings.Cut(string(data), "\n")
+	return etag, digest
+}
+
+func (c cache) saveETag(url, etag, digest string) error {
+	return writeFileAtomic(c.etagPath(url), []byte(etag+"\n"+digest))
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	tmp := fmt.Sprintf("%s.tmp-%d", path, os.Getpid())
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// digestOfFile streams the file through the hash.
+func digestOfFile(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return digestPrefix + hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func decodeSignature(str string) ([]byte, error) {
+	signature, err := base64.StdEncoding.DecodeString(strings.TrimSpace(str))
+	if err != nil {
+		return nil, errors.Errorf(`cannot decode signature: %w`, err)
+	}
+	return signature, nil
+}
@@ -0,0 +1,35 @@
+package archive
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+// verify the archive by the checksum and the ed25519 signature of the digest, at least one of them must be defined.
+func verify(ref model.TemplateRepository, content *archiveContent) error {
+	if ref.Checksum == "" && ref.PublicKey == "" {
+		return errors.New(`archive must be verified by a checksum or a public key`)
+	}
+
+	if ref.Checksum != "" && ref.Checksum != content.digest {
+		return errors.Errorf(`checksum mismatch, expected "%s", found "%s"`, ref.Checksum, content.digest)
+	}
+
+	if ref.PublicKey != "" {
+		key, err := base64.StdEncoding.DecodeString(ref.PublicKey)
+		if err != nil || len(key) != ed25519.PublicKeySize {
+			return errors.New(`public key must be base64 encoded ed25519 public key`)
+		}
+		if len(content.signature) == 0 {
+			return errors.New(`signature not found`)
+		}
+		if !ed25519.Verify(key, []byte(content.digest), content.signature) {
+			return errors.New(`invalid signature`)
+		}
+	}
+
+	return nil
+}
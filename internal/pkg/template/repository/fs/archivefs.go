@@ -0,0 +1,37 @@
+package fs
+
+import (
+	"context"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/filesystem"
+	"github.com/keboola/keboola-as-code/internal/pkg/filesystem/aferofs"
+	"github.com/keboola/keboola-as-code/internal/pkg/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/template/repository/archive"
+)
+
+// archiveFsFor returns template FS loaded from an archive.
+// The archive is cached locally, so it is downloaded again only if it has been modified.
+func archiveFsFor(ctx context.Context, d dependencies, definition model.TemplateRepository) (memoryFs filesystem.Fs, err error) {
+	ctx, span := d.Telemetry().Tracer().Start(ctx, "keboola.go.declarative.templates.repository.fs.archiveFsFor")
+	defer span.End(&err)
+
+	// Download and extract the archive
+	archiveRepository, err := archive.Fetch(ctx, definition, d.Logger())
+	if err != nil {
+		return nil, err
+	}
+
+	// Clear directory at the end. Files will be copied to memory.
+	defer func() {
+		<-archiveRepository.Free()
+	}()
+
+	// Copy to memory FS
+	fs, unlockFn := archiveRepository.Fs()
+	defer unlockFn()
+	memoryFs = aferofs.NewMemoryFs(filesystem.WithLogger(d.Logger()))
+	if err := aferofs.CopyFs2Fs(fs, "", memoryFs, ""); err != nil {
+		return nil, err
+	}
+	return memoryFs, nil
+}
@@ -37,6 +37,8 @@ func For(ctx context.Context, d dependencies, ref model.TemplateRepository, opts
 		return aferofs.NewLocalFs(ref.URL, filesystem.WithLogger(d.Logger()))
 	case model.RepositoryTypeGit:
 		return gitFsFor(ctx, d, ref, opts...)
+	case model.RepositoryTypeArchive:
+		return archiveFsFor(ctx, d, ref)
 	default:
 		panic(errors.Errorf(`unexpected repository type "%s"`, ref.Type))
 	}
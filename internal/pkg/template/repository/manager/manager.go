@@ -8,11 +8,13 @@
 //   - Manager.Repository calls CachedRepository.lock method for every request.
 //   - After the request is finished, it must call provided UnlockFn.
 //   - Manager.Update is called periodically, it calls CachedRepository.update.
-//   - If there has been a change in the underlying git repository, then CachedRepository.update will return an updated copy of the repository.
+//   - If there has been a change in the underlying git repository or archive, then CachedRepository.update will return an updated copy of the repository.
 //   - So there EXIST BOTH a new and an old version at the same time.
 //   - Older requests will finish with the old repository version, new ones will use the new version.
 //   - When all old requests are completed, freeLock is released, so the free method/goroutine is unblocked.
 //   - So the repository and underlying FS are cleaned.
+//   - Manager.UpdateRepository updates a single repository on demand, for example from the refresh endpoint.
+//   - Result of the last checkout/pull of each repository is available by the Manager.RepositoryStatus method.
 package manager
 
 import (
@@ -32,6 +34,7 @@ import (
 	"github.com/keboola/keboola-as-code/internal/pkg/model"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/servicectx"
 	"github.com/keboola/keboola-as-code/internal/pkg/telemetry"
+	"github.com/keboola/keboola-as-code/internal/pkg/template/repository/archive"
 	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
 	checkoutOp "github.com/keboola/keboola-as-code/pkg/lib/operation/repository/checkout"
 	loadRepositoryOp "github.com/keboola/keboola-as-code/pkg/lib/operation/template/repository/load"
@@ -49,9 +52,28 @@ type Manager struct {
 	// This list is individually modified in the Service according to the set project features.
 	defaultRepositories []model.TemplateRepository
 
-	repositories     map[string]*CachedRepository
-	repositoriesInit *singleflight.Group // each template is load only once
-	repositoriesLock *sync.RWMutex       // provides atomic access to the repositories field
+	// Options for repositories of the "archive" type, for example the cache directory.
+	archiveOpts []archive.Option
+
+	repositories       map[string]*CachedRepository
+	repositoriesInit   *singleflight.Group // each template is load only once
+	repositoriesUpdate *singleflight.Group // each repository is updated only once at the same time
+	repositoriesLock   *sync.RWMutex       // provides atomic access to the repositories field
+
+	statuses     map[string]*RepositoryStatus
+	statusesLock *sync.RWMutex // provides atomic access to the statuses field
+}
+
+// RepositoryStatus contains result of the last checkout or pull of a repository.
+type RepositoryStatus struct {
+	// CommitHash of the loaded version, it is empty for a local directory.
+	CommitHash string
+	// LastUpdate is time of the last successful checkout or pull.
+	LastUpdate time.Time
+	// LastError of the last checkout or pull, it is nil if the operation was successful.
+	LastError error
+	// LastErrorAt is time of the LastError.
+	LastErrorAt time.Time
 }
 
 type dependencies interface {
@@ -61,7 +83,16 @@ type dependencies interface {
 	Components() *model.ComponentsMap
 }
 
-func New(ctx context.Context, d dependencies, defaultRepositories []model.TemplateRepository) (*Manager, error) {
+type Option func(m *Manager)
+
+// WithArchiveOptions sets options for repositories of the "archive" type.
+func WithArchiveOptions(opts ...archive.Option) Option {
+	return func(m *Manager) {
+		m.archiveOpts = append(m.archiveOpts, opts...)
+	}
+}
+
+func New(ctx context.Context, d dependencies, defaultRepositories []model.TemplateRepository, opts ...Option) (*Manager, error) {
 	m := &Manager{
 		ctx:                 ctx,
 		deps:                d,
@@ -70,7 +101,13 @@ func New(ctx context.Context, d dependencies, defaultRepositories []model.Templa
 		defaultRepositories: defaultRepositories,
 		repositories:        make(map[string]*CachedRepository),
 		repositoriesInit:    &singleflight.Group{},
+		repositoriesUpdate:  &singleflight.Group{},
 		repositoriesLock:    &sync.RWMutex{},
+		statuses:            make(map[string]*RepositoryStatus),
+		statusesLock:        &sync.RWMutex{},
+	}
+	for _, o := range opts {
+		o(m)
 	}
 
 	// Free all repositories on server shutdown
@@ -111,6 +148,21 @@ func (m *Manager) ManagedRepositories() (out []string) {
 	return out
 }
 
+// ManagedRepositoriesByURL returns all managed references to the git repository, they may differ in the ref.
+func (m *Manager) ManagedRepositoriesByURL(url string) (out []model.TemplateRepository) {
+	m.repositoriesLock.RLock()
+	defer m.repositoriesLock.RUnlock()
+	for _, r := range m.repositories {
+		if def := r.Unwrap().Definition(); def.URL == url {
+			out = append(out, def)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].String() < out[j].String()
+	})
+	return out
+}
+
 func (m *Manager) Repository(ctx context.Context, ref model.TemplateRepository) (*CachedRepository, UnlockFn, error) {
 	cachedRepo, err := m.repository(ctx, ref)
 	if err != nil {
@@ -123,6 +175,18 @@ func (m *Manager) Repository(ctx context.Context, ref model.TemplateRepository)
 	return cachedRepo, unlockFn, nil
 }
 
+// RepositoryStatus returns result of the last checkout or pull of the repository.
+// False is returned if the repository has not been loaded yet.
+func (m *Manager) RepositoryStatus(ref model.TemplateRepository) (RepositoryStatus, bool) {
+	m.statusesLock.RLock()
+	defer m.statusesLock.RUnlock()
+	if status, found := m.statuses[ref.Hash()]; found {
+		return *status, true
+	}
+	return RepositoryStatus{}, false
+}
+
+// Update pulls all repositories in parallel.
 func (m *Manager) Update(ctx context.Context) <-chan error {
 	errorCh := make(chan error, 1)
 	errs := errors.NewMultiError()
@@ -133,42 +197,11 @@ func (m *Manager) Update(ctx context.Context) <-chan error {
 	// Pull repositories in parallel
 	wait := &sync.WaitGroup{}
 	for _, repo := range m.repositories {
-		repoDef := repo.Unwrap().Definition()
-		oldValue := repo
 		wait.Add(1)
 		go func() {
 			defer wait.Done()
-
-			// Update
-			startTime := time.Now()
-			newValue, changed, err := oldValue.update(ctx)
-
-			// Metric
-			elapsedTime := float64(time.Since(startTime)) / float64(time.Millisecond)
-			m.syncMeter.Record(ctx, elapsedTime, metric.WithAttributes(
-				attribute.String("repo.name", repoDef.Name),
-				attribute.String("repo.url", repoDef.URL),
-				attribute.String("repo.ref", repoDef.Ref),
-				attribute.String("error_type", telemetry.ErrorType(err)),
-				attribute.Bool("is_init", false),
-				attribute.Bool("is_success", err == nil),
-				attribute.Bool("is_changed", changed),
-			))
-
-			// Handle error
-			if err != nil {
+			if err := m.update(ctx, repo); err != nil {
 				errs.Append(err)
-				return
-			}
-
-			// Replace value
-			if changed {
-				m.repositoriesLock.Lock()
-				m.repositories[newValue.Hash()] = newValue
-				m.repositoriesLock.Unlock()
-
-				// Free previous value
-				oldValue.free(ctx)
 			}
 		}()
 	}
@@ -185,6 +218,88 @@ func (m *Manager) Update(ctx context.Context) <-chan error {
 	return errorCh
 }
 
+// UpdateRepository pulls the repository immediately, for example when a new template version has been pushed.
+// If the repository has not been loaded yet, it is loaded.
+func (m *Manager) UpdateRepository(ctx context.Context, ref model.TemplateRepository) error {
+	m.repositoriesLock.RLock()
+	repo, found := m.repositories[ref.Hash()]
+	m.repositoriesLock.RUnlock()
+	if !found {
+		_, err := m.repository(ctx, ref)
+		return err
+	}
+	return m.update(ctx, repo)
+}
+
+// update pulls the repository and replaces the cached value, if it has been changed.
+// Parallel updates of the same repository, for example from the cron and the refresh endpoint, are merged.
+func (m *Manager) update(ctx context.Context, oldValue *CachedRepository) error {
+	repoDef := oldValue.Unwrap().Definition()
+	ch := m.repositoriesUpdate.DoChan(repoDef.Hash(), func() (out any, err error) {
+		// Update
+		startTime := time.Now()
+		newValue, changed, err := oldValue.update(ctx)
+
+		// Metric
+		elapsedTime := float64(time.Since(startTime)) / float64(time.Millisecond)
+		m.syncMeter.Record(ctx, elapsedTime, metric.WithAttributes(
+			attribute.String("repo.name", repoDef.Name),
+			attribute.String("repo.url", repoDef.URL),
+			attribute.String("repo.ref", repoDef.Ref),
+			attribute.String("error_type", telemetry.ErrorType(err)),
+			attribute.Bool("is_init", false),
+			attribute.Bool("is_success", err == nil),
+			attribute.Bool("is_changed", changed),
+		))
+
+		// Handle error
+		if err != nil {
+			m.setStatus(repoDef, "", err)
+			return nil, err
+		}
+		m.setStatus(repoDef, newValue.git.CommitHash(), nil)
+
+		// Replace value
+		if changed {
+			m.repositoriesLock.Lock()
+			m.repositories[newValue.Hash()] = newValue
+			m.repositoriesLock.Unlock()
+
+			// Free previous value
+			oldValue.free(ctx)
+		}
+		return nil, nil
+	})
+	return (<-ch).Err
+}
+
+// setStatus stores result of a checkout or pull, the commit hash is kept on error.
+func (m *Manager) setStatus(ref model.TemplateRepository, commitHash string, err error) {
+	m.statusesLock.Lock()
+	defer m.statusesLock.Unlock()
+
+	status, found := m.statuses[ref.Hash()]
+	if !found {
+		status = &RepositoryStatus{}
+		m.statuses[ref.Hash()] = status
+	}
+
+	now := time.Now()
+	if err != nil {
+		status.LastError = err
+		status.LastErrorAt = now
+		return
+	}
+
+	if commitHash == git.CommitHashNotSet {
+		commitHash = ""
+	}
+	status.CommitHash = commitHash
+	status.LastUpdate = now
+	status.LastError = nil
+	status.LastErrorAt = time.Time{}
+}
+
 func (m *Manager) Free(ctx context.Context) {
 	m.repositoriesLock.RLock()
 	defer m.repositoriesLock.RUnlock()
@@ -231,9 +346,17 @@ func (m *Manager) repository(ctx context.Context, ref model.TemplateRepository)
 			))
 		}()
 
+		// Store result of the initial checkout
+		defer func() {
+			if err != nil {
+				m.setStatus(ref, "", err)
+			}
+		}()
+
 		// Load git repository
 		var gitRepo git.Repository
-		if ref.Type == model.RepositoryTypeGit {
+		switch ref.Type {
+		case model.RepositoryTypeGit:
 			// Remote repository
 			startTime := time.Now()
 			m.logger.Infof(ctx, `checking out repository "%s:%s"`, ref.URL, ref.Ref)
@@ -246,7 +369,21 @@ func (m *Manager) repository(ctx context.Context, ref model.TemplateRepository)
 
 			// Checkout done
 			m.logger.WithDuration(time.Since(startTime)).Infof(ctx, `checked out repository "%s"`, gitRepo)
-		} else {
+		case model.RepositoryTypeArchive:
+			// Archive repository
+			startTime := time.Now()
+			m.logger.Infof(ctx, `fetching archive repository "%s"`, ref.URL)
+
+			// Fetch
+			archiveRepo, err := archive.Fetch(ctx, ref, m.logger, m.archiveOpts...)
+			if err != nil {
+				return nil, err
+			}
+			gitRepo = archiveRepo
+
+			// Fetch done
+			m.logger.WithDuration(time.Since(startTime)).Infof(ctx, `fetched archive repository "%s" (%s)`, gitRepo, archiveRepo.CommitHash())
+		default:
 			// Local directory
 			fs, err := aferofs.NewLocalFs(ref.URL, filesystem.WithLogger(m.deps.Logger()))
 			if err != nil {
@@ -267,6 +404,7 @@ func (m *Manager) repository(ctx context.Context, ref model.TemplateRepository)
 
 		// Initialize cached repository, preload all templates
 		r := newCachedRepository(ctx, m.deps, gitRepo, unlockFn, data)
+		m.setStatus(ref, gitRepo.CommitHash(), nil)
 
 		// Cache value
 		m.repositoriesLock.Lock()
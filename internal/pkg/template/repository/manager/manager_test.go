@@ -2,14 +2,24 @@
 package manager_test
 
 import (
+	"archive/tar"
 	"bytes"
+	"compress/gzip"
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
 	"fmt"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -23,6 +33,7 @@ import (
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/dependencies"
 	"github.com/keboola/keboola-as-code/internal/pkg/telemetry"
 	"github.com/keboola/keboola-as-code/internal/pkg/template/repository"
+	"github.com/keboola/keboola-as-code/internal/pkg/template/repository/archive"
 	"github.com/keboola/keboola-as-code/internal/pkg/template/repository/manager"
 )
 
@@ -127,6 +138,14 @@ func TestRepositoryUpdate(t *testing.T) {
 		unlock()
 	}
 
+	// Check status after the checkout
+	status, found := m.RepositoryStatus(repo)
+	require.True(t, found)
+	initHash := status.CommitHash
+	assert.NotEmpty(t, initHash)
+	assert.False(t, status.LastUpdate.IsZero())
+	assert.NoError(t, status.LastError)
+
 	// 1. update - no change
 	assert.NoError(t, <-m.Update(ctx))
 
@@ -134,7 +153,14 @@ func TestRepositoryUpdate(t *testing.T) {
 	runGitCommand(t, tmpDir, "reset", "--hard", "b1")
 
 	// 2. update - change
-	assert.NoError(t, <-m.Update(ctx))
+	assert.NoError(t, m.UpdateRepository(ctx, repo))
+
+	// Check status after the pull
+	status, found = m.RepositoryStatus(repo)
+	require.True(t, found)
+	assert.NotEmpty(t, status.CommitHash)
+	assert.NotEqual(t, initHash, status.CommitHash)
+	assert.NoError(t, status.LastError)
 
 	// Check FS
 	repoInst, unlock, err = m.Repository(ctx, repo)
@@ -227,6 +253,67 @@ func TestRepositoryUpdate(t *testing.T) {
 	)
 }
 
+func TestRepositoryUpdate_Archive(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	// Serve the repository as a tar.gz archive, with the signature of the archive digest
+	var content atomic.Pointer[[]byte]
+	v1 := createArchive(t, filepath.Join("test", "repository"), nil)
+	content.Store(&v1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, ".sig") {
+			digest := fmt.Sprintf("sha256:%x", sha256.Sum256(*content.Load()))
+			_, _ = w.Write([]byte(base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, []byte(digest)))))
+			return
+		}
+		_, _ = w.Write(*content.Load())
+	}))
+	defer server.Close()
+	repo := model.TemplateRepository{
+		Type:      model.RepositoryTypeArchive,
+		Name:      repository.DefaultTemplateRepositoryName,
+		URL:       server.URL + "/templates.tar.gz",
+		PublicKey: base64.StdEncoding.EncodeToString(publicKey),
+	}
+
+	// Create manager
+	d := dependencies.NewMocked(t, ctx)
+	m, err := manager.New(ctx, d, []model.TemplateRepository{repo}, manager.WithArchiveOptions(archive.WithCacheDir(t.TempDir())))
+	require.NoError(t, err)
+
+	// Check FS
+	repoInst, unlock, err := m.Repository(ctx, repo)
+	if assert.NoError(t, err) {
+		assert.True(t, repoInst.Fs().Exists(ctx, "template1"))
+		assert.False(t, repoInst.Fs().Exists(ctx, "new-file.txt"))
+		unlock()
+	}
+
+	// 1. update - no change
+	assert.NoError(t, <-m.Update(ctx))
+
+	// Modify archive
+	v2 := createArchive(t, filepath.Join("test", "repository"), map[string]string{"new-file.txt": "foo"})
+	content.Store(&v2)
+
+	// 2. update - change
+	assert.NoError(t, <-m.Update(ctx))
+
+	// Check FS
+	repoInst, unlock, err = m.Repository(ctx, repo)
+	if assert.NoError(t, err) {
+		assert.True(t, repoInst.Fs().Exists(ctx, "template1"))
+		assert.True(t, repoInst.Fs().Exists(ctx, "new-file.txt"))
+		unlock()
+	}
+}
+
 func TestDefaultRepositories(t *testing.T) {
 	t.Parallel()
 	if runtime.GOOS == "windows" {
@@ -278,3 +365,43 @@ func runGitCommand(t *testing.T, dir string, args ...string) {
 	cmd.Stderr = &stderr
 	assert.NoError(t, cmd.Run(), "STDOUT:\n"+stdout.String()+"\n\nSTDERR:\n"+stderr.String())
 }
+
+// createArchive creates tar.gz archive from the directory, the .gittest directory is skipped.
+func createArchive(t *testing.T, dir string, extraFiles map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+	writeFile := func(path string, content []byte) {
+		require.NoError(t, tarWriter.WriteHeader(&tar.Header{Name: filepath.ToSlash(path), Typeflag: tar.TypeReg, Mode: 0o600, Size: int64(len(content))}))
+		_, err := tarWriter.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if entry.Name() == ".gittest" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		writeFile(relPath, content)
+		return nil
+	}))
+	for path, content := range extraFiles {
+		writeFile(path, []byte(content))
+	}
+	require.NoError(t, tarWriter.Close())
+	require.NoError(t, gzipWriter.Close())
+	return buf.Bytes()
+}
@@ -24,7 +24,7 @@ import (
 type CachedRepository struct {
 	d dependencies
 
-	git  git.Repository // *git.LocalRepository (type=dir) or *git.RemoteRepository (type=git)
+	git  git.Repository // *git.LocalRepository (type=dir), *git.RemoteRepository (type=git) or *archive.Repository (type=archive)
 	repo *repository.Repository
 
 	templates     map[string]*template.Template
@@ -133,8 +133,8 @@ func (r *CachedRepository) Template(ctx context.Context, reference model.Templat
 
 // update returns an updated copy of the repository if the repository has been changed.
 func (r *CachedRepository) update(ctx context.Context) (*CachedRepository, bool, error) {
-	// Only RemoteRepository can be updated
-	if repo, ok := r.git.(*git.RemoteRepository); ok {
+	// Only RemoteRepository and archive Repository can be updated
+	if repo, ok := r.git.(pullOp.Repository); ok {
 		// Log start
 		startTime := time.Now()
 		r.d.Logger().Infof(ctx, `repository "%s" update started`, r.URLAndRef())
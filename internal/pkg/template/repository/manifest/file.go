@@ -117,6 +117,24 @@ func (f *file) validate(ctx context.Context) error {
 		}
 	}
 
+	// Validate required templates
+	templates := make(map[string]bool)
+	for _, t := range f.Templates {
+		templates[t.ID] = true
+	}
+	for i, t := range f.Templates {
+		for j, r := range t.Requirements.Templates {
+			if _, err := r.Constraint(); err != nil {
+				errs.Append(errors.Errorf(`"templates[%d].requirements.templates[%d].version": %w`, i, j, err))
+			}
+			if r.ID == t.ID {
+				errs.Append(errors.Errorf(`"templates[%d].requirements.templates[%d].id": template "%s" cannot require itself`, i, j, r.ID))
+			} else if r.ID != "" && !templates[r.ID] {
+				errs.Append(errors.Errorf(`"templates[%d].requirements.templates[%d].id": template "%s" not found`, i, j, r.ID))
+			}
+		}
+	}
+
 	if errs.Len() > 0 {
 		return errors.PrefixError(errs, "repository manifest is not valid")
 	}
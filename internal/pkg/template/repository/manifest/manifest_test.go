@@ -205,6 +205,61 @@ repository manifest is not valid:
 	assert.Equal(t, strings.TrimSpace(expected), err.Error())
 }
 
+func TestManifestContentValidateRequiredTemplates(t *testing.T) {
+	t.Parallel()
+	manifestContent := &file{
+		Version: 2,
+		Author: Author{
+			Name: "Author",
+			URL:  "https://example.com",
+		},
+		Templates: []TemplateRecord{
+			{
+				ID:          "template-1",
+				Name:        `Template 1`,
+				Description: `My Template 1`,
+				Path:        "template-1",
+				Requirements: Requirements{
+					Templates: []TemplateRequirement{
+						{ID: "template-2", Version: "^1.0"},
+						{ID: "template-1", Version: "^1.0"},
+						{ID: "missing", Version: "foo"},
+					},
+				},
+				Versions: []VersionRecord{
+					{
+						Version:     version(`1.2.3`),
+						Description: `SemVersion 1`,
+						Path:        "v1",
+					},
+				},
+			},
+			{
+				ID:          "template-2",
+				Name:        `Template 2`,
+				Description: `My Template 2`,
+				Path:        "template-2",
+				Versions: []VersionRecord{
+					{
+						Version:     version(`1.0.0`),
+						Description: `SemVersion 1`,
+						Path:        "v1",
+					},
+				},
+			},
+		},
+	}
+	err := manifestContent.validate(context.Background())
+	assert.Error(t, err)
+	expected := `
+repository manifest is not valid:
+- "templates[0].requirements.templates[1].id": template "template-1" cannot require itself
+- "templates[0].requirements.templates[2].version": invalid version range "foo" of the required template "missing"
+- "templates[0].requirements.templates[2].id": template "missing" not found
+`
+	assert.Equal(t, strings.TrimSpace(expected), err.Error())
+}
+
 func TestManifestBadRecordSemanticVersion(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
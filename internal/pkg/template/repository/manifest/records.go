@@ -6,6 +6,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/Masterminds/semver"
 	"github.com/keboola/go-client/pkg/keboola"
 
 	"github.com/keboola/keboola-as-code/internal/pkg/model"
@@ -32,9 +33,36 @@ type VersionRecord struct {
 }
 
 type Requirements struct {
-	Backends   []string `json:"backends"`
-	Components []string `json:"components"`
-	Features   []string `json:"features"`
+	Backends   []string              `json:"backends"`
+	Components []string              `json:"components"`
+	Features   []string              `json:"features"`
+	Templates  []TemplateRequirement `json:"templates,omitempty" validate:"dive"`
+}
+
+// TemplateRequirement defines a template from the same repository, that must be installed before the template.
+// Inputs maps the template input ID to the prerequisite input ID, the value is passed from the prerequisite.
+type TemplateRequirement struct {
+	ID      string            `json:"id" validate:"required,alphanumdash,min=1,max=40"`
+	Version string            `json:"version" validate:"required"`
+	Inputs  map[string]string `json:"inputs,omitempty"`
+}
+
+// Constraint parses the version range, for example "^1.2" or ">= 1.0, < 2.0".
+func (v TemplateRequirement) Constraint() (*semver.Constraints, error) {
+	c, err := semver.NewConstraint(v.Version)
+	if err != nil {
+		return nil, errors.Errorf(`invalid version range "%s" of the required template "%s"`, v.Version, v.ID)
+	}
+	return c, nil
+}
+
+// Match checks if the version satisfies the version range.
+func (v TemplateRequirement) Match(version model.SemVersion) bool {
+	c, err := v.Constraint()
+	if err != nil {
+		return false
+	}
+	return c.Check(version.Value())
 }
 
 func (v *TemplateRecord) AllVersions() (out []VersionRecord) {
@@ -112,6 +140,16 @@ func (v *TemplateRecord) GetVersionOrErr(wantedStr string) (VersionRecord, error
 	return version, nil
 }
 
+// LatestVersionInRange returns the latest version which satisfies the version range of the requirement.
+func (v *TemplateRecord) LatestVersionInRange(requirement TemplateRequirement) (VersionRecord, bool) {
+	for _, version := range v.AllVersions() {
+		if requirement.Match(version.Version) {
+			return version, true
+		}
+	}
+	return VersionRecord{}, false
+}
+
 func (v *TemplateRecord) GetClosestVersion(wanted model.SemVersion) (VersionRecord, bool) {
 	if version, found := v.GetVersion(wanted); found {
 		return version, true
@@ -372,3 +372,30 @@ func TestTemplateRecord_HasComponent(t *testing.T) {
 		})
 	}
 }
+
+func TestTemplateRecord_LatestVersionInRange(t *testing.T) {
+	t.Parallel()
+	template := TemplateRecord{
+		ID: "template-1",
+		Versions: []VersionRecord{
+			{Version: version(`0.9.0`)},
+			{Version: version(`1.0.0`)},
+			{Version: version(`1.2.3`)},
+			{Version: version(`2.0.0`)},
+		},
+	}
+
+	v, found := template.LatestVersionInRange(TemplateRequirement{ID: "template-1", Version: "^1.0"})
+	assert.True(t, found)
+	assert.Equal(t, "1.2.3", v.Version.String())
+
+	v, found = template.LatestVersionInRange(TemplateRequirement{ID: "template-1", Version: ">= 0.9, < 1.1"})
+	assert.True(t, found)
+	assert.Equal(t, "1.0.0", v.Version.String())
+
+	_, found = template.LatestVersionInRange(TemplateRequirement{ID: "template-1", Version: "^3"})
+	assert.False(t, found)
+
+	_, err := TemplateRequirement{ID: "template-1", Version: "foo"}.Constraint()
+	assert.EqualError(t, err, `invalid version range "foo" of the required template "template-1"`)
+}
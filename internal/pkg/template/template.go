@@ -29,6 +29,7 @@ import (
 )
 
 const (
+	EnvironmentFile      = "environment.json"
 	ExpectedOutDirectory = "expected-out"
 	IDRegexp             = `^[a-zA-Z0-9\-]+$`
 	InputsFile           = "inputs.json"
@@ -348,6 +349,9 @@ func (t *Template) LoadState(templateCtx Context, options loadState.Options, d d
 		LocalFilter:  &localFilter,
 		RemoteFilter: &remoteFilter,
 	}
+	if v, ok := templateCtx.(ContextWithRemoteObjects); ok {
+		loadOptions.RemoteObjects = v.RemoteObjects()
+	}
 
 	// Evaluate manifest
 	container, err := t.evaluate(templateCtx)
@@ -472,6 +476,24 @@ func (t *Test) Inputs(ctx context.Context, provider testhelper.EnvProvider, repl
 	return inputs, nil
 }
 
+// Environment loads the optional mock of the project environment, nil is returned if the test does not define it.
+func (t *Test) Environment(ctx context.Context) (*TestEnvironment, error) {
+	if !t.fs.IsFile(ctx, EnvironmentFile) {
+		return nil, nil
+	}
+
+	file, err := t.fs.ReadFile(ctx, filesystem.NewFileDef(EnvironmentFile).SetDescription("template test environment"))
+	if err != nil {
+		return nil, err
+	}
+
+	out := &TestEnvironment{}
+	if err := json.DecodeString(file.Content, out); err != nil {
+		return nil, errors.Errorf(`cannot decode test environment file "%s": %w`, EnvironmentFile, err)
+	}
+	return out, nil
+}
+
 func (t *CreatedTest) saveInputs(ctx context.Context, inputsValues InputsValues) error {
 	res := make(map[string]any)
 	for k, v := range inputsValues.ToMap() {
@@ -10,6 +10,7 @@ import (
 	"github.com/keboola/keboola-as-code/internal/pkg/filesystem/aferofs"
 	"github.com/keboola/keboola-as-code/internal/pkg/log"
 	"github.com/keboola/keboola-as-code/internal/pkg/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/template/jsonnet/function"
 	"github.com/keboola/keboola-as-code/internal/pkg/template/repository"
 )
 
@@ -114,3 +115,37 @@ func TestTemplate_TestInputs(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, map[string]any{"foo": "bar"}, res)
 }
+
+func TestTemplate_TestEnvironment(t *testing.T) {
+	t.Parallel()
+
+	logger := log.NewDebugLogger()
+	fs := aferofs.NewMemoryFs(filesystem.WithLogger(logger))
+	ctx := context.Background()
+
+	assert.NoError(t, fs.Mkdir(ctx, "tests/one"))
+	assert.NoError(t, fs.Mkdir(ctx, "tests/two"))
+	assert.NoError(t, fs.WriteFile(ctx, filesystem.NewRawFile("tests/one/environment.json", `{"features":["foo"],"tables":{"in.c-bucket.table":["id"]}}`)))
+
+	tmpl := initTemplate(t, fs)
+
+	// Environment is mocked
+	test, err := tmpl.Test(ctx, "one")
+	assert.NoError(t, err)
+	mock, err := test.Environment(ctx)
+	assert.NoError(t, err)
+	env := mock.Apply(function.Environment{StackHost: "connection.keboola.com"})
+	assert.Equal(t, "connection.keboola.com", env.StackHost)
+	assert.True(t, env.Features.Has("foo"))
+	tables, err := env.Tables()
+	assert.NoError(t, err)
+	assert.Equal(t, function.Tables{"in.c-bucket.table": {"id"}}, tables)
+
+	// Environment is not mocked
+	test, err = tmpl.Test(ctx, "two")
+	assert.NoError(t, err)
+	mock, err = test.Environment(ctx)
+	assert.NoError(t, err)
+	assert.Nil(t, mock)
+	assert.Equal(t, function.Environment{StackHost: "connection.keboola.com"}, mock.Apply(function.Environment{StackHost: "connection.keboola.com"}))
+}
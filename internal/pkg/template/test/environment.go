@@ -0,0 +1,18 @@
+package test
+
+import (
+	"context"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/template"
+	"github.com/keboola/keboola-as-code/internal/pkg/template/jsonnet/function"
+)
+
+// MockEnvironment replaces values of the project environment by values mocked in the test, if any.
+func MockEnvironment(ctx context.Context, test *template.Test, env function.Environment) (*function.Environment, error) {
+	mock, err := test.Environment(ctx)
+	if err != nil {
+		return nil, err
+	}
+	env = mock.Apply(env)
+	return &env, nil
+}
@@ -0,0 +1,510 @@
+// Package fakestorage provides an in-process fake of the Storage API.
+//
+// It is used to run template tests offline, without a test project.
+// The fake keeps one default branch, configurations, rows and their metadata in memory.
+// The Encryption API and the Scheduler API are faked too, so the project can be pushed.
+// Jobs cannot be run.
+package fakestorage
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/keboola/go-client/pkg/client"
+	"github.com/keboola/go-client/pkg/keboola"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/encoding/json"
+)
+
+const (
+	ProjectID       = 1
+	DefaultBranchID = 1
+	Token           = "offline-token" // nolint:gosec // it is not a secret
+	encryptedPrefix = "KBC::ProjectSecure::"
+)
+
+// API is the fake Storage API, it implements http.RoundTripper.
+type API struct {
+	lock           sync.Mutex
+	host           string
+	mux            *http.ServeMux
+	components     keboola.Components
+	nextID         int
+	branch         *keboola.Branch
+	branchMetadata keboola.MetadataDetails
+	configs        map[keboola.ConfigKey]*keboola.ConfigWithRows
+	configMetadata map[keboola.ConfigKey]keboola.MetadataDetails
+}
+
+// New creates the fake Storage API for the host.
+// The components are returned by the index request.
+func New(host string, components keboola.Components) *API {
+	host = strings.TrimPrefix(strings.TrimPrefix(host, "https://"), "http://")
+	a := &API{
+		host:           host,
+		mux:            http.NewServeMux(),
+		components:     components,
+		nextID:         1000,
+		branch:         &keboola.Branch{BranchKey: keboola.BranchKey{ID: DefaultBranchID}, Name: "Main", IsDefault: true},
+		branchMetadata: make(keboola.MetadataDetails, 0),
+		configs:        make(map[keboola.ConfigKey]*keboola.ConfigWithRows),
+		configMetadata: make(map[keboola.ConfigKey]keboola.MetadataDetails),
+	}
+
+	storage := host + "/v2/storage/"
+	configs := storage + "branch/{branchId}/components/{componentId}/configs"
+	a.mux.HandleFunc("GET "+storage+"{$}", a.index)
+	a.mux.HandleFunc("GET "+storage+"tokens/verify", a.verifyToken)
+	a.mux.HandleFunc("POST "+storage+"tickets", a.generateID)
+	a.mux.HandleFunc("GET "+storage+"dev-branches", a.listBranches)
+	a.mux.HandleFunc("GET "+storage+"dev-branches/{branchId}", a.getBranch)
+	a.mux.HandleFunc("GET "+storage+"branch/{branchId}/metadata", a.listBranchMetadata)
+	a.mux.HandleFunc("POST "+storage+"branch/{branchId}/metadata", a.appendBranchMetadata)
+	a.mux.HandleFunc("DELETE "+storage+"branch/{branchId}/metadata/{metadataId}", a.deleteBranchMetadata)
+	a.mux.HandleFunc("GET "+storage+"branch/{branchId}/tables", a.listTables)
+	a.mux.HandleFunc("GET "+storage+"branch/{branchId}/components", a.listConfigsAndRows)
+	a.mux.HandleFunc("GET "+storage+"branch/{branchId}/search/component-configurations", a.listConfigMetadata)
+	a.mux.HandleFunc("POST "+configs, a.createConfig)
+	a.mux.HandleFunc("GET "+configs+"/{configId}", a.getConfig)
+	a.mux.HandleFunc("PUT "+configs+"/{configId}", a.updateConfig)
+	a.mux.HandleFunc("DELETE "+configs+"/{configId}", a.deleteConfig)
+	a.mux.HandleFunc("POST "+configs+"/{configId}/metadata", a.appendConfigMetadata)
+	a.mux.HandleFunc("DELETE "+configs+"/{configId}/metadata/{metadataId}", a.deleteConfigMetadata)
+	a.mux.HandleFunc("POST "+configs+"/{configId}/rows", a.createRow)
+	a.mux.HandleFunc("PUT "+configs+"/{configId}/rows/{rowId}", a.updateRow)
+	a.mux.HandleFunc("DELETE "+configs+"/{configId}/rows/{rowId}", a.deleteRow)
+	a.mux.HandleFunc("POST encryption."+host+"/encrypt", a.encrypt)
+	a.mux.HandleFunc("GET scheduler."+host+"/schedules", a.listSchedules)
+	a.mux.HandleFunc("POST scheduler."+host+"/schedules", a.activateSchedule)
+	a.mux.HandleFunc("DELETE scheduler."+host+"/schedules/{scheduleId}", a.noContent)
+	a.mux.HandleFunc("DELETE scheduler."+host+"/configurations/{configId}", a.noContent)
+	return a
+}
+
+// Host of the fake Storage API.
+func (a *API) Host() string {
+	return a.host
+}
+
+// Client returns HTTP client which sends all requests to the fake.
+func (a *API) Client() client.Client {
+	return client.New().WithTransport(a).WithRetry(client.TestingRetry())
+}
+
+// RoundTrip handles the request in-process.
+func (a *API) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		defer req.Body.Close()
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	// The mux matches the host from the Host field, it is empty in a client request
+	serverReq := req.Clone(req.Context())
+	serverReq.Host = req.URL.Host
+
+	recorder := httptest.NewRecorder()
+	a.mux.ServeHTTP(recorder, serverReq)
+	resp := recorder.Result()
+	resp.Request = req
+	return resp, nil
+}
+
+func (a *API) index(w http.ResponseWriter, req *http.Request) {
+	index := &keboola.IndexComponents{
+		Index: keboola.Index{
+			Services: keboola.Services{
+				{ID: "encryption", URL: keboola.ServiceURL("https://encryption." + a.host)},
+				{ID: "scheduler", URL: keboola.ServiceURL("https://scheduler." + a.host)},
+			},
+			Features: keboola.Features{},
+		},
+		Components: a.components,
+	}
+	if req.URL.Query().Get("exclude") == "components" {
+		index.Components = keboola.Components{}
+	}
+	writeJSON(w, http.StatusOK, index)
+}
+
+func (a *API) verifyToken(w http.ResponseWriter, req *http.Request) {
+	if req.Header.Get("X-StorageApi-Token") != Token {
+		writeError(w, http.StatusUnauthorized, "storage.tokenInvalid", "Invalid access token")
+		return
+	}
+	writeJSON(w, http.StatusOK, &keboola.Token{
+		ID:          "1",
+		Description: "offline",
+		IsMaster:    true,
+		Owner: keboola.TokenOwner{
+			ID:             ProjectID,
+			Name:           "Offline Project",
+			Features:       keboola.Features{},
+			HasSnowflake:   true,
+			DefaultBackend: "snowflake",
+		},
+	})
+}
+
+func (a *API) generateID(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusCreated, &keboola.Ticket{ID: a.newID()})
+}
+
+func (a *API) listBranches(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, []*keboola.Branch{a.branch})
+}
+
+func (a *API) getBranch(w http.ResponseWriter, req *http.Request) {
+	if !a.checkBranch(w, req) {
+		return
+	}
+	writeJSON(w, http.StatusOK, a.branch)
+}
+
+// listTables returns no tables, the fake project has no storage.
+func (a *API) listTables(w http.ResponseWriter, req *http.Request) {
+	if !a.checkBranch(w, req) {
+		return
+	}
+	writeJSON(w, http.StatusOK, []*keboola.Table{})
+}
+
+func (a *API) listBranchMetadata(w http.ResponseWriter, req *http.Request) {
+	if !a.checkBranch(w, req) {
+		return
+	}
+	writeJSON(w, http.StatusOK, a.branchMetadata)
+}
+
+func (a *API) appendBranchMetadata(w http.ResponseWriter, req *http.Request) {
+	if !a.checkBranch(w, req) {
+		return
+	}
+	payload := keboola.MetadataPayload{}
+	if !readJSON(w, req, &payload) {
+		return
+	}
+	a.branchMetadata = a.appendMetadata(a.branchMetadata, payload)
+	writeJSON(w, http.StatusCreated, a.branchMetadata)
+}
+
+func (a *API) deleteBranchMetadata(w http.ResponseWriter, req *http.Request) {
+	if !a.checkBranch(w, req) {
+		return
+	}
+	a.branchMetadata = deleteMetadata(a.branchMetadata, req.PathValue("metadataId"))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *API) listConfigsAndRows(w http.ResponseWriter, req *http.Request) {
+	if !a.checkBranch(w, req) {
+		return
+	}
+
+	byComponent := make(map[keboola.ComponentID]*keboola.ComponentWithConfigs)
+	for _, config := range a.sortedConfigs() {
+		item, found := byComponent[config.ComponentID]
+		if !found {
+			item = &keboola.ComponentWithConfigs{BranchID: a.branch.ID, Component: a.component(config.ComponentID)}
+			byComponent[config.ComponentID] = item
+		}
+		item.Configs = append(item.Configs, config)
+	}
+
+	out := make([]*keboola.ComponentWithConfigs, 0, len(byComponent))
+	for _, item := range byComponent {
+		out = append(out, item)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].ID < out[j].ID
+	})
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (a *API) listConfigMetadata(w http.ResponseWriter, req *http.Request) {
+	if !a.checkBranch(w, req) {
+		return
+	}
+	out := make(keboola.ConfigsMetadata, 0)
+	for _, config := range a.sortedConfigs() {
+		if metadata := a.configMetadata[config.ConfigKey]; len(metadata) > 0 {
+			out = append(out, &keboola.ConfigMetadataItem{ComponentID: config.ComponentID, ConfigID: config.ID, Metadata: metadata})
+		}
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (a *API) createConfig(w http.ResponseWriter, req *http.Request) {
+	if !a.checkBranch(w, req) {
+		return
+	}
+
+	body := &configBody{}
+	if !readJSON(w, req, body) {
+		return
+	}
+
+	key := keboola.ConfigKey{BranchID: a.branch.ID, ComponentID: keboola.ComponentID(req.PathValue("componentId")), ID: keboola.ConfigID(body.ID)}
+	if key.ID == "" {
+		key.ID = keboola.ConfigID(a.newID())
+	}
+	if _, found := a.configs[key]; found {
+		writeError(w, http.StatusBadRequest, "configurationAlreadyExists", fmt.Sprintf(`Configuration %s already exists.`, key.ID))
+		return
+	}
+
+	config := &keboola.ConfigWithRows{Config: &keboola.Config{ConfigKey: key, Version: 1}, Rows: make([]*keboola.ConfigRow, 0)}
+	body.applyTo(config.Config)
+	a.configs[key] = config
+	writeJSON(w, http.StatusCreated, config.Config)
+}
+
+func (a *API) getConfig(w http.ResponseWriter, req *http.Request) {
+	if config, ok := a.findConfig(w, req); ok {
+		writeJSON(w, http.StatusOK, config.Config)
+	}
+}
+
+func (a *API) updateConfig(w http.ResponseWriter, req *http.Request) {
+	config, ok := a.findConfig(w, req)
+	if !ok {
+		return
+	}
+	body := &configBody{}
+	if !readJSON(w, req, body) {
+		return
+	}
+	body.applyTo(config.Config)
+	config.Version++
+	writeJSON(w, http.StatusOK, config.Config)
+}
+
+func (a *API) deleteConfig(w http.ResponseWriter, req *http.Request) {
+	config, ok := a.findConfig(w, req)
+	if !ok {
+		return
+	}
+	delete(a.configs, config.ConfigKey)
+	delete(a.configMetadata, config.ConfigKey)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *API) appendConfigMetadata(w http.ResponseWriter, req *http.Request) {
+	config, ok := a.findConfig(w, req)
+	if !ok {
+		return
+	}
+	payload := keboola.MetadataPayload{}
+	if !readJSON(w, req, &payload) {
+		return
+	}
+	a.configMetadata[config.ConfigKey] = a.appendMetadata(a.configMetadata[config.ConfigKey], payload)
+	writeJSON(w, http.StatusCreated, a.configMetadata[config.ConfigKey])
+}
+
+func (a *API) deleteConfigMetadata(w http.ResponseWriter, req *http.Request) {
+	config, ok := a.findConfig(w, req)
+	if !ok {
+		return
+	}
+	a.configMetadata[config.ConfigKey] = deleteMetadata(a.configMetadata[config.ConfigKey], req.PathValue("metadataId"))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *API) createRow(w http.ResponseWriter, req *http.Request) {
+	config, ok := a.findConfig(w, req)
+	if !ok {
+		return
+	}
+
+	body := &rowBody{}
+	if !readJSON(w, req, body) {
+		return
+	}
+
+	id := keboola.RowID(body.ID)
+	if id == "" {
+		id = keboola.RowID(a.newID())
+	}
+	for _, row := range config.Rows {
+		if row.ID == id {
+			writeError(w, http.StatusBadRequest, "configurationRowAlreadyExists", fmt.Sprintf(`Row %s already exists.`, id))
+			return
+		}
+	}
+
+	row := &keboola.ConfigRow{ConfigRowKey: keboola.ConfigRowKey{BranchID: config.BranchID, ComponentID: config.ComponentID, ConfigID: config.ID, ID: id}, Version: 1}
+	body.applyTo(row)
+	config.Rows = append(config.Rows, row)
+	config.SortRows()
+	writeJSON(w, http.StatusCreated, row)
+}
+
+func (a *API) updateRow(w http.ResponseWriter, req *http.Request) {
+	config, ok := a.findConfig(w, req)
+	if !ok {
+		return
+	}
+	body := &rowBody{}
+	if !readJSON(w, req, body) {
+		return
+	}
+	for _, row := range config.Rows {
+		if string(row.ID) == req.PathValue("rowId") {
+			body.applyTo(row)
+			row.Version++
+			config.SortRows()
+			writeJSON(w, http.StatusOK, row)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "notFound", fmt.Sprintf(`Row %s not found.`, req.PathValue("rowId")))
+}
+
+func (a *API) deleteRow(w http.ResponseWriter, req *http.Request) {
+	config, ok := a.findConfig(w, req)
+	if !ok {
+		return
+	}
+	for i, row := range config.Rows {
+		if string(row.ID) == req.PathValue("rowId") {
+			config.Rows = append(config.Rows[:i], config.Rows[i+1:]...)
+			break
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// encrypt marks values as encrypted, the values are not really encrypted.
+func (a *API) encrypt(w http.ResponseWriter, req *http.Request) {
+	data := make(map[string]string)
+	if !readJSON(w, req, &data) {
+		return
+	}
+	for k, v := range data {
+		if !strings.HasPrefix(v, "KBC::") {
+			data[k] = encryptedPrefix + v
+		}
+	}
+	writeJSON(w, http.StatusOK, data)
+}
+
+func (a *API) listSchedules(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, []*keboola.Schedule{})
+}
+
+func (a *API) activateSchedule(w http.ResponseWriter, req *http.Request) {
+	body := make(map[string]string)
+	if !readJSON(w, req, &body) {
+		return
+	}
+	writeJSON(w, http.StatusCreated, &keboola.Schedule{
+		ScheduleKey: keboola.ScheduleKey{ID: keboola.ScheduleID(a.newID())},
+		ConfigID:    keboola.ConfigID(body["configurationId"]),
+	})
+}
+
+func (a *API) noContent(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *API) newID() string {
+	a.nextID++
+	return strconv.Itoa(a.nextID)
+}
+
+func (a *API) checkBranch(w http.ResponseWriter, req *http.Request) bool {
+	if req.PathValue("branchId") != a.branch.ID.String() {
+		writeError(w, http.StatusNotFound, "notFound", fmt.Sprintf(`Branch %s not found.`, req.PathValue("branchId")))
+		return false
+	}
+	return true
+}
+
+func (a *API) findConfig(w http.ResponseWriter, req *http.Request) (*keboola.ConfigWithRows, bool) {
+	if !a.checkBranch(w, req) {
+		return nil, false
+	}
+	key := keboola.ConfigKey{BranchID: a.branch.ID, ComponentID: keboola.ComponentID(req.PathValue("componentId")), ID: keboola.ConfigID(req.PathValue("configId"))}
+	config, found := a.configs[key]
+	if !found {
+		writeError(w, http.StatusNotFound, "notFound", fmt.Sprintf(`Configuration %s not found.`, key.ID))
+		return nil, false
+	}
+	return config, true
+}
+
+func (a *API) sortedConfigs() []*keboola.ConfigWithRows {
+	out := make([]*keboola.ConfigWithRows, 0, len(a.configs))
+	for _, config := range a.configs {
+		out = append(out, config)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].ComponentID+"/"+keboola.ComponentID(out[i].ID) < out[j].ComponentID+"/"+keboola.ComponentID(out[j].ID)
+	})
+	return out
+}
+
+func (a *API) component(id keboola.ComponentID) keboola.Component {
+	for _, c := range a.components {
+		if c.ID == id {
+			return *c
+		}
+	}
+	return keboola.Component{ComponentKey: keboola.ComponentKey{ID: id}, Type: "other", Name: id.String()}
+}
+
+func (a *API) appendMetadata(existing keboola.MetadataDetails, payload keboola.MetadataPayload) keboola.MetadataDetails {
+	for _, kv := range payload.Metadata {
+		updated := false
+		for i := range existing {
+			if existing[i].Key == kv.Key {
+				existing[i].Value = kv.Value
+				updated = true
+			}
+		}
+		if !updated {
+			existing = append(existing, keboola.MetadataDetail{ID: a.newID(), Key: kv.Key, Value: kv.Value, Provider: "user"})
+		}
+	}
+	return existing
+}
+
+func deleteMetadata(existing keboola.MetadataDetails, id string) keboola.MetadataDetails {
+	out := make(keboola.MetadataDetails, 0, len(existing))
+	for _, item := range existing {
+		if item.ID != id {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+func readJSON(w http.ResponseWriter, req *http.Request, target any) bool {
+	data, err := io.ReadAll(req.Body)
+	if err == nil {
+		err = json.Decode(data, target)
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "validation.invalidBody", err.Error())
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(json.MustEncode(body, false))
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, map[string]any{"error": message, "code": code, "status": "error"})
+}
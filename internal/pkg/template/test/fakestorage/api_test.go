@@ -0,0 +1,65 @@
+package fakestorage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/keboola/go-client/pkg/keboola"
+	"github.com/keboola/go-utils/pkg/orderedmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPI_ConfigsAndRows(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	components := keboola.Components{{ComponentKey: keboola.ComponentKey{ID: "ex-generic-v2"}, Type: "extractor", Name: "Generic"}}
+	fake := New("https://connection.keboola.local", components)
+	httpClient := fake.Client()
+	api, err := keboola.NewAuthorizedAPI(ctx, "https://"+fake.Host(), Token, keboola.WithClient(&httpClient))
+	require.NoError(t, err)
+
+	// Token
+	token, err := api.VerifyTokenRequest(Token).Send(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, ProjectID, token.ProjectID())
+
+	// Create config with a row
+	branch := keboola.BranchKey{ID: DefaultBranchID}
+	content := orderedmap.New()
+	content.Set("foo", "bar")
+	config := &keboola.ConfigWithRows{
+		Config: &keboola.Config{ConfigKey: keboola.ConfigKey{BranchID: branch.ID, ComponentID: "ex-generic-v2"}, Name: "My Config", Content: content},
+		Rows:   []*keboola.ConfigRow{{Name: "My Row", Content: orderedmap.New()}},
+	}
+	_, err = api.CreateConfigRequest(config).Send(ctx)
+	require.NoError(t, err)
+	assert.NotEmpty(t, config.ID)
+	assert.NotEmpty(t, config.Rows[0].ID)
+
+	// Update config
+	config.Name = "Updated"
+	_, err = api.UpdateConfigRequest(config.Config, []string{"name"}).Send(ctx)
+	require.NoError(t, err)
+
+	// List
+	result, err := api.ListConfigsAndRowsFrom(branch).Send(ctx)
+	require.NoError(t, err)
+	require.Len(t, *result, 1)
+	component := (*result)[0]
+	assert.Equal(t, keboola.ComponentID("ex-generic-v2"), component.ID)
+	require.Len(t, component.Configs, 1)
+	assert.Equal(t, "Updated", component.Configs[0].Name)
+	v, _ := component.Configs[0].Content.Get("foo")
+	assert.Equal(t, "bar", v)
+	require.Len(t, component.Configs[0].Rows, 1)
+	assert.Equal(t, "My Row", component.Configs[0].Rows[0].Name)
+
+	// Delete
+	_, err = api.DeleteConfigRequest(config.ConfigKey).Send(ctx)
+	require.NoError(t, err)
+	result, err = api.ListConfigsAndRowsFrom(branch).Send(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, *result)
+}
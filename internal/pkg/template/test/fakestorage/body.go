@@ -0,0 +1,68 @@
+package fakestorage
+
+import (
+	"github.com/keboola/go-client/pkg/keboola"
+	"github.com/keboola/go-utils/pkg/orderedmap"
+)
+
+// configBody is a create or update request, update request contains only changed fields.
+type configBody struct {
+	ID                string                 `json:"configurationId"`
+	Name              *string                `json:"name"`
+	Description       *string                `json:"description"`
+	ChangeDescription *string                `json:"changeDescription"`
+	IsDisabled        *bool                  `json:"isDisabled"`
+	Content           *orderedmap.OrderedMap `json:"configuration"`
+}
+
+// rowBody is a create or update request, update request contains only changed fields.
+type rowBody struct {
+	ID                string                 `json:"rowId"`
+	Name              *string                `json:"name"`
+	Description       *string                `json:"description"`
+	ChangeDescription *string                `json:"changeDescription"`
+	IsDisabled        *bool                  `json:"isDisabled"`
+	Content           *orderedmap.OrderedMap `json:"configuration"`
+}
+
+func (b *configBody) applyTo(config *keboola.Config) {
+	if b.Name != nil {
+		config.Name = *b.Name
+	}
+	if b.Description != nil {
+		config.Description = *b.Description
+	}
+	if b.ChangeDescription != nil {
+		config.ChangeDescription = *b.ChangeDescription
+	}
+	if b.IsDisabled != nil {
+		config.IsDisabled = *b.IsDisabled
+	}
+	if b.Content != nil {
+		config.Content = b.Content
+	}
+	if config.Content == nil {
+		config.Content = orderedmap.New()
+	}
+}
+
+func (b *rowBody) applyTo(row *keboola.ConfigRow) {
+	if b.Name != nil {
+		row.Name = *b.Name
+	}
+	if b.Description != nil {
+		row.Description = *b.Description
+	}
+	if b.ChangeDescription != nil {
+		row.ChangeDescription = *b.ChangeDescription
+	}
+	if b.IsDisabled != nil {
+		row.IsDisabled = *b.IsDisabled
+	}
+	if b.Content != nil {
+		row.Content = b.Content
+	}
+	if row.Content == nil {
+		row.Content = orderedmap.New()
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/benbjohnson/clock"
 	"github.com/keboola/go-client/pkg/client"
+	"github.com/keboola/go-client/pkg/keboola"
 
 	"github.com/keboola/keboola-as-code/internal/pkg/env"
 	"github.com/keboola/keboola-as-code/internal/pkg/filesystem"
@@ -18,6 +19,7 @@ import (
 	dependenciesPkg "github.com/keboola/keboola-as-code/internal/pkg/service/common/dependencies"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/servicectx"
 	"github.com/keboola/keboola-as-code/internal/pkg/telemetry"
+	"github.com/keboola/keboola-as-code/internal/pkg/template/test/fakestorage"
 	"github.com/keboola/keboola-as-code/internal/pkg/utils/testproject"
 	loadState "github.com/keboola/keboola-as-code/pkg/lib/operation/state/load"
 )
@@ -59,7 +61,7 @@ func PrepareProject(
 		return nil, nil, nil, nil, err
 	}
 
-	testDeps, err := newTestDependencies(ctx, logger, tel, stdout, stderr, proc, testPrj.StorageAPIHost(), testPrj.StorageAPIToken().Token)
+	testDeps, err := newTestDependencies(ctx, logger, tel, stdout, stderr, proc, client.NewTestClient(), testPrj.StorageAPIHost(), testPrj.StorageAPIToken().Token)
 	if err != nil {
 		return nil, nil, nil, nil, err
 	}
@@ -84,7 +86,7 @@ func PrepareProject(
 	}
 
 	// Load fixture with minimal project
-	prjFS, err := createEmptyBranch(ctx, testPrj, branchID)
+	prjFS, err := createEmptyBranch(ctx, testPrj.ID(), testPrj.StorageAPIHost(), branchID)
 	if err != nil {
 		unlockFn()
 		return nil, nil, nil, nil, err
@@ -113,6 +115,52 @@ func PrepareProject(
 	return prjState, testPrj, testDeps, unlockFn, nil
 }
 
+// PrepareOfflineProject is an alternative to PrepareProject, the project is backed by the in-process fake Storage API.
+// No test project and no credentials are needed. Components are served from the provided list.
+func PrepareOfflineProject(
+	ctx context.Context,
+	logger log.Logger,
+	tel telemetry.Telemetry,
+	stdout io.Writer,
+	stderr io.Writer,
+	proc *servicectx.Process,
+	apiHost string,
+	components keboola.Components,
+	remote bool,
+) (*project.State, *fakestorage.API, *Dependencies, error) {
+	api := fakestorage.New(apiHost, components)
+	testDeps, err := newTestDependencies(ctx, logger, tel, stdout, stderr, proc, api.Client(), api.Host(), fakestorage.Token)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// Load fixture with minimal project
+	prjFS, err := createEmptyBranch(ctx, fakestorage.ProjectID, api.Host(), fakestorage.DefaultBranchID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// Load project state
+	prj, err := project.New(ctx, log.NewNopLogger(), prjFS, env.Empty(), true)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var loadOptions loadState.Options
+	if remote {
+		loadOptions = loadState.Options{LoadRemoteState: true}
+	} else {
+		loadOptions = loadState.LocalOperationOptions()
+	}
+
+	prjState, err := prj.LoadState(loadOptions, testDeps)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return prjState, api, testDeps, nil
+}
+
 func newTestDependencies(
 	ctx context.Context,
 	logger log.Logger,
@@ -120,10 +168,11 @@ func newTestDependencies(
 	stdout io.Writer,
 	stderr io.Writer,
 	proc *servicectx.Process,
+	httpClient client.Client,
 	apiHost,
 	apiToken string,
 ) (*Dependencies, error) {
-	baseDeps := dependenciesPkg.NewBaseScope(ctx, logger, tel, stdout, stderr, clock.New(), proc, client.NewTestClient())
+	baseDeps := dependenciesPkg.NewBaseScope(ctx, logger, tel, stdout, stderr, clock.New(), proc, httpClient)
 	publicDeps, err := dependenciesPkg.NewPublicScope(ctx, baseDeps, apiHost, dependenciesPkg.WithPreloadComponents(true))
 	if err != nil {
 		return nil, err
@@ -149,9 +198,9 @@ func newTestDependencies(
 // .keboola/manifest.json
 // main/description
 // main/meta.json.
-func createEmptyBranch(ctx context.Context, prj *testproject.Project, branchID int) (filesystem.Fs, error) {
+func createEmptyBranch(ctx context.Context, projectID int, apiHost string, branchID int) (filesystem.Fs, error) {
 	prjFS := aferofs.NewMemoryFs()
-	err := prjFS.WriteFile(ctx, filesystem.NewRawFile(".keboola/manifest.json", getManifest(prj, branchID)))
+	err := prjFS.WriteFile(ctx, filesystem.NewRawFile(".keboola/manifest.json", getManifest(projectID, apiHost, branchID)))
 	if err != nil {
 		return nil, err
 	}
@@ -168,7 +217,7 @@ func createEmptyBranch(ctx context.Context, prj *testproject.Project, branchID i
 	return prjFS, nil
 }
 
-func getManifest(prj *testproject.Project, branchID int) string {
+func getManifest(projectID int, apiHost string, branchID int) string {
 	return fmt.Sprintf(`{
   "version": 2,
   "project": {
@@ -203,5 +252,5 @@ func getManifest(prj *testproject.Project, branchID int) string {
   ],
   "configurations": []
 }
-`, prj.ID(), prj.StorageAPIHost(), branchID)
+`, projectID, apiHost, branchID)
 }
@@ -0,0 +1,46 @@
+package template
+
+import (
+	"github.com/keboola/go-client/pkg/keboola"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/template/jsonnet/function"
+)
+
+// TestEnvironment mocks the project environment used by Jsonnet functions in a template test.
+// It is loaded from the optional EnvironmentFile in the test directory, only defined fields are mocked.
+//
+// Example:
+//
+//	{
+//	  "stackHost": "connection.keboola.com",
+//	  "features": ["my-feature"],
+//	  "tables": {"in.c-bucket.table": ["id", "name"]}
+//	}
+type TestEnvironment struct {
+	StackHost *string             `json:"stackHost,omitempty"`
+	Features  []string            `json:"features,omitempty"`
+	Tables    map[string][]string `json:"tables,omitempty"`
+}
+
+// Apply the mocked values to the environment.
+func (e *TestEnvironment) Apply(env function.Environment) function.Environment {
+	if e == nil {
+		return env
+	}
+	if e.StackHost != nil {
+		env.StackHost = *e.StackHost
+	}
+	if e.Features != nil {
+		env.Features = keboola.Features(e.Features).ToMap()
+	}
+	if e.Tables != nil {
+		tables := make(function.Tables, len(e.Tables))
+		for id, columns := range e.Tables {
+			tables[id] = columns
+		}
+		env.Tables = func() (function.Tables, error) {
+			return tables, nil
+		}
+	}
+	return env
+}
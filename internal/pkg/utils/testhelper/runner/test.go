@@ -176,8 +176,12 @@ func (t *Test) Run(opts ...Options) {
 	}
 
 	if c.addEnvVarsFromFile {
-		// Load additional env vars from the test file.
-		t.addEnvVarsFromFile()
+		// Load additional env vars from the test file, they are passed to the CLI binary and to the API server.
+		envsFromFile := t.addEnvVarsFromFile()
+		if c.apiServerConfig.envs != nil {
+			c.apiServerConfig.envs = c.apiServerConfig.envs.Clone()
+			c.apiServerConfig.envs.Merge(envsFromFile, true)
+		}
 	}
 
 	// Replace all %%ENV_VAR%% in all files of the working directory.
@@ -222,7 +226,8 @@ func (t *Test) initProjectState() {
 	}
 }
 
-func (t *Test) addEnvVarsFromFile() {
+func (t *Test) addEnvVarsFromFile() *env.Map {
+	envsFromFile := env.Empty()
 	if t.testDirFS.Exists(t.ctx, envFileName) {
 		envFile, err := t.testDirFS.ReadFile(t.ctx, filesystem.NewFileDef(envFileName))
 		if err != nil {
@@ -233,7 +238,7 @@ func (t *Test) addEnvVarsFromFile() {
 		envFileContent := testhelper.MustReplaceEnvsString(envFile.Content, t.envProvider)
 
 		// Parse "env" file
-		envsFromFile, err := env.LoadEnvString(envFileContent)
+		envsFromFile, err = env.LoadEnvString(envFileContent)
 		if err != nil {
 			t.t.Fatalf(`Cannot load "%s" file: %s`, envFileName, err)
 		}
@@ -241,6 +246,7 @@ func (t *Test) addEnvVarsFromFile() {
 		// Merge
 		t.env.Merge(envsFromFile, true)
 	}
+	return envsFromFile
 }
 
 func (t *Test) runCLIBinary(path string) {
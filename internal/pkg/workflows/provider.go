@@ -0,0 +1,178 @@
+package workflows
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/filesystem"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+const (
+	ProviderGitHub    = "github"
+	ProviderGitLab    = "gitlab"
+	ProviderBitbucket = "bitbucket"
+	ProviderAzure     = "azure"
+	DefaultProvider   = ProviderGitHub
+)
+
+// provider defines templates and secret-handling instructions of a CI system.
+type provider struct {
+	name  string
+	files func(o *Options) []file
+	notes func(o *Options) []string
+}
+
+// file maps an embedded template to the generated file.
+type file struct {
+	template string
+	target   string
+}
+
+// Providers returns names of all supported CI providers.
+func Providers() []string {
+	return []string{ProviderGitHub, ProviderGitLab, ProviderBitbucket, ProviderAzure}
+}
+
+// ProviderTitle returns human-readable name of the CI provider.
+func ProviderTitle(name string) string {
+	switch name {
+	case ProviderGitHub:
+		return "GitHub Actions"
+	case ProviderGitLab:
+		return "GitLab CI"
+	case ProviderBitbucket:
+		return "Bitbucket Pipelines"
+	case ProviderAzure:
+		return "Azure Pipelines"
+	default:
+		return name
+	}
+}
+
+// ValidateProvider checks that the CI provider is supported.
+func ValidateProvider(name string) error {
+	_, err := providerFor(name)
+	return err
+}
+
+func providerFor(name string) (*provider, error) {
+	switch name {
+	case ProviderGitHub, "":
+		return githubProvider(), nil
+	case ProviderGitLab:
+		return gitlabProvider(), nil
+	case ProviderBitbucket:
+		return bitbucketProvider(), nil
+	case ProviderAzure:
+		return azureProvider(), nil
+	default:
+		return nil, errors.Errorf(`CI provider "%s" is not supported, please use one of: %s`, name, strings.Join(Providers(), ", "))
+	}
+}
+
+func githubProvider() *provider {
+	return &provider{
+		name: ProviderGitHub,
+		files: func(o *Options) (out []file) {
+			workflowsDir := filesystem.Join(".github", "workflows")
+			installActDir := filesystem.Join(".github", "actions", "install")
+			out = append(out, file{template: `template/github/install.yml.tmpl`, target: filesystem.Join(installActDir, `action.yml`)})
+			if o.Validate {
+				out = append(out, file{template: `template/github/validate.yml.tmpl`, target: filesystem.Join(workflowsDir, `validate.yml`)})
+			}
+			if o.Push {
+				out = append(out, file{template: `template/github/push.yml.tmpl`, target: filesystem.Join(workflowsDir, `push.yml`)})
+			}
+			if o.Pull {
+				out = append(out, file{template: `template/github/pull.yml.tmpl`, target: filesystem.Join(workflowsDir, `pull.yml`)})
+			}
+			return out
+		},
+		notes: func(o *Options) []string {
+			return []string{
+				"Please set the secret KBC_STORAGE_API_TOKEN in the GitHub settings.",
+				"See: https://docs.github.com/en/actions/reference/encrypted-secrets",
+			}
+		},
+	}
+}
+
+func gitlabProvider() *provider {
+	return &provider{
+		name: ProviderGitLab,
+		files: func(o *Options) (out []file) {
+			ciDir := filesystem.Join(".gitlab", "ci")
+			out = append(out, file{template: `template/gitlab/gitlab-ci.yml.tmpl`, target: `.gitlab-ci.yml`})
+			out = append(out, file{template: `template/gitlab/install.yml.tmpl`, target: filesystem.Join(ciDir, `install.yml`)})
+			if o.Validate {
+				out = append(out, file{template: `template/gitlab/validate.yml.tmpl`, target: filesystem.Join(ciDir, `validate.yml`)})
+			}
+			if o.Push {
+				out = append(out, file{template: `template/gitlab/push.yml.tmpl`, target: filesystem.Join(ciDir, `push.yml`)})
+			}
+			if o.Pull {
+				out = append(out, file{template: `template/gitlab/pull.yml.tmpl`, target: filesystem.Join(ciDir, `pull.yml`)})
+			}
+			return out
+		},
+		notes: func(o *Options) (out []string) {
+			out = append(out, "Please set the masked CI/CD variable KBC_STORAGE_API_TOKEN in the GitLab project settings.")
+			if o.Pull {
+				out = append(out, "Please set the masked CI/CD variable KBC_GIT_PUSH_TOKEN to a project access token with the \"write_repository\" scope.")
+				out = append(out, fmt.Sprintf(`Please create a pipeline schedule for the "%s" branch to run the automatic pull.`, o.MainBranch))
+			}
+			out = append(out, "See: https://docs.gitlab.com/ee/ci/variables/")
+			return out
+		},
+	}
+}
+
+func bitbucketProvider() *provider {
+	return &provider{
+		name: ProviderBitbucket,
+		files: func(o *Options) []file {
+			return []file{
+				{template: `template/bitbucket/bitbucket-pipelines.yml.tmpl`, target: `bitbucket-pipelines.yml`},
+				{template: `template/bitbucket/install.sh.tmpl`, target: filesystem.Join(".bitbucket", `install.sh`)},
+			}
+		},
+		notes: func(o *Options) (out []string) {
+			out = append(out, "Please set the secured repository variable KBC_STORAGE_API_TOKEN in the Bitbucket repository settings.")
+			if o.Pull {
+				out = append(out, fmt.Sprintf(`Please create a schedule of the "kbc-pull" custom pipeline for the "%s" branch.`, o.MainBranch))
+			}
+			out = append(out, "See: https://support.atlassian.com/bitbucket-cloud/docs/variables-and-secrets/")
+			return out
+		},
+	}
+}
+
+func azureProvider() *provider {
+	return &provider{
+		name: ProviderAzure,
+		files: func(o *Options) (out []file) {
+			pipelinesDir := ".azure-pipelines"
+			out = append(out, file{template: `template/azure/install.yml.tmpl`, target: filesystem.Join(pipelinesDir, `install.yml`)})
+			if o.Validate {
+				out = append(out, file{template: `template/azure/validate.yml.tmpl`, target: filesystem.Join(pipelinesDir, `validate.yml`)})
+			}
+			if o.Push {
+				out = append(out, file{template: `template/azure/push.yml.tmpl`, target: filesystem.Join(pipelinesDir, `push.yml`)})
+			}
+			if o.Pull {
+				out = append(out, file{template: `template/azure/pull.yml.tmpl`, target: filesystem.Join(pipelinesDir, `pull.yml`)})
+			}
+			return out
+		},
+		notes: func(o *Options) (out []string) {
+			out = append(out, "Please create a pipeline in Azure DevOps for each generated pipeline in the \".azure-pipelines\" directory, except \"install.yml\".")
+			out = append(out, "Please set the secret variable KBC_STORAGE_API_TOKEN in each pipeline.")
+			if o.Pull {
+				out = append(out, "Please allow the \"Contribute\" permission of the build service to the repository, it is required by the automatic pull.")
+			}
+			out = append(out, "See: https://learn.microsoft.com/en-us/azure/devops/pipelines/process/set-secret-variables")
+			return out
+		},
+	}
+}
@@ -18,9 +18,10 @@ import (
 var templates embed.FS
 
 type Options struct {
-	Validate   bool // validate all branches
-	Push       bool // push to Keboola Connection state in the main branch
-	Pull       bool // periodical pull new changes to the main branch
+	Provider   string // CI provider, see Providers, GitHub Actions by default
+	Validate   bool   // validate all branches
+	Push       bool   // push to Keboola Connection state in the main branch
+	Pull       bool   // periodical pull new changes to the main branch
 	MainBranch string
 }
 
@@ -29,14 +30,20 @@ func (o *Options) Enabled() bool {
 }
 
 type generator struct {
-	fs      filesystem.Fs
-	options *Options
-	logger  log.Logger
-	errors  errors.MultiError
+	fs       filesystem.Fs
+	options  *Options
+	provider *provider
+	logger   log.Logger
+	errors   errors.MultiError
 }
 
 func GenerateFiles(ctx context.Context, logger log.Logger, fs filesystem.Fs, options *Options) error {
-	g := &generator{fs: fs, options: options, logger: logger, errors: errors.NewMultiError()}
+	p, err := providerFor(options.Provider)
+	if err != nil {
+		return err
+	}
+
+	g := &generator{fs: fs, options: options, provider: p, logger: logger, errors: errors.NewMultiError()}
 	return g.generateFiles(ctx)
 }
 
@@ -47,29 +54,13 @@ func (g *generator) generateFiles(ctx context.Context) error {
 		return nil
 	}
 
-	// Common files
 	g.logger.Info(ctx, "")
 	g.logger.Info(ctx, `Generating CI workflows ...`)
-	workflowsDir := filesystem.Join(".github", "workflows")
-	actionsDir := filesystem.Join(".github", "actions")
-	installActDir := filesystem.Join(actionsDir, "install")
-	g.handleError(g.fs.Mkdir(ctx, workflowsDir))
-	g.handleError(g.fs.Mkdir(ctx, installActDir))
-	g.renderTemplate(ctx, `template/install.yml.tmpl`, filesystem.Join(installActDir, `action.yml`))
-
-	// Validate operation
-	if g.options.Validate {
-		g.renderTemplate(ctx, `template/validate.yml.tmpl`, filesystem.Join(workflowsDir, `validate.yml`))
-	}
-
-	// Push operation
-	if g.options.Push {
-		g.renderTemplate(ctx, `template/push.yml.tmpl`, filesystem.Join(workflowsDir, `push.yml`))
-	}
-
-	// Pull operation
-	if g.options.Pull {
-		g.renderTemplate(ctx, `template/pull.yml.tmpl`, filesystem.Join(workflowsDir, `pull.yml`))
+	for _, f := range g.provider.files(g.options) {
+		if dir := filesystem.Dir(f.target); dir != "." {
+			g.handleError(g.fs.Mkdir(ctx, dir))
+		}
+		g.renderTemplate(ctx, f.template, f.target)
 	}
 
 	if g.errors.Len() > 0 {
@@ -80,8 +71,9 @@ func (g *generator) generateFiles(ctx context.Context) error {
 	g.logger.Info(ctx, "CI workflows have been generated.")
 	g.logger.Info(ctx, "Feel free to modify them.")
 	g.logger.Info(ctx, "")
-	g.logger.Info(ctx, "Please set the secret KBC_STORAGE_API_TOKEN in the GitHub settings.")
-	g.logger.Info(ctx, "See: https://docs.github.com/en/actions/reference/encrypted-secrets")
+	for _, note := range g.provider.notes(g.options) {
+		g.logger.Info(ctx, note)
+	}
 	return nil
 }
 
@@ -0,0 +1,123 @@
+package workflows
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/filesystem"
+	"github.com/keboola/keboola-as-code/internal/pkg/filesystem/aferofs"
+	"github.com/keboola/keboola-as-code/internal/pkg/log"
+)
+
+func TestGenerateFiles_Providers(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		provider string
+		files    []string
+		notes    []string
+	}{
+		{
+			provider: ProviderGitHub,
+			files: []string{
+				".github/actions/install/action.yml",
+				".github/workflows/validate.yml",
+				".github/workflows/push.yml",
+				".github/workflows/pull.yml",
+			},
+			notes: []string{"Please set the secret KBC_STORAGE_API_TOKEN in the GitHub settings."},
+		},
+		{
+			provider: ProviderGitLab,
+			files: []string{
+				".gitlab-ci.yml",
+				".gitlab/ci/install.yml",
+				".gitlab/ci/validate.yml",
+				".gitlab/ci/push.yml",
+				".gitlab/ci/pull.yml",
+			},
+			notes: []string{"KBC_STORAGE_API_TOKEN", "KBC_GIT_PUSH_TOKEN", `pipeline schedule for the "main" branch`},
+		},
+		{
+			provider: ProviderBitbucket,
+			files: []string{
+				"bitbucket-pipelines.yml",
+				".bitbucket/install.sh",
+			},
+			notes: []string{"KBC_STORAGE_API_TOKEN", `"kbc-pull" custom pipeline for the "main" branch`},
+		},
+		{
+			provider: ProviderAzure,
+			files: []string{
+				".azure-pipelines/install.yml",
+				".azure-pipelines/validate.yml",
+				".azure-pipelines/push.yml",
+				".azure-pipelines/pull.yml",
+			},
+			notes: []string{"KBC_STORAGE_API_TOKEN", `"Contribute" permission`},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.provider, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			logger := log.NewDebugLogger()
+			fs := aferofs.NewMemoryFs()
+			opts := &Options{Provider: tc.provider, Validate: true, Push: true, Pull: true, MainBranch: "main"}
+			require.NoError(t, GenerateFiles(ctx, logger, fs, opts))
+
+			logs := logger.AllMessagesTxt()
+			for _, path := range tc.files {
+				assert.True(t, fs.IsFile(ctx, path), path)
+				assert.Contains(t, logs, `Created file "`+path+`".`)
+			}
+			for _, note := range tc.notes {
+				assert.Contains(t, logs, note)
+			}
+		})
+	}
+}
+
+func TestGenerateFiles_GitLab_MainBranch(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	fs := aferofs.NewMemoryFs()
+	opts := &Options{Provider: ProviderGitLab, Validate: true, Push: true, Pull: false, MainBranch: "master"}
+	require.NoError(t, GenerateFiles(ctx, log.NewNopLogger(), fs, opts))
+
+	// Pull workflow is not generated and not included
+	assert.False(t, fs.IsFile(ctx, ".gitlab/ci/pull.yml"))
+	root, err := fs.ReadFile(ctx, filesystem.NewFileDef(".gitlab-ci.yml"))
+	require.NoError(t, err)
+	assert.Equal(t, `stages:
+  - validate
+  - push
+include:
+  - local: .gitlab/ci/install.yml
+  - local: .gitlab/ci/validate.yml
+  - local: .gitlab/ci/push.yml
+`, root.Content)
+
+	// The main branch is validated by the push job
+	validate, err := fs.ReadFile(ctx, filesystem.NewFileDef(".gitlab/ci/validate.yml"))
+	require.NoError(t, err)
+	assert.Contains(t, validate.Content, `- if: $CI_COMMIT_BRANCH == "master"`)
+}
+
+func TestGenerateFiles_UnknownProvider(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	fs := aferofs.NewMemoryFs()
+	opts := &Options{Provider: "jenkins", Validate: true, MainBranch: "main"}
+	err := GenerateFiles(ctx, log.NewNopLogger(), fs, opts)
+	if assert.Error(t, err) {
+		assert.Equal(t, `CI provider "jenkins" is not supported, please use one of: github, gitlab, bitbucket, azure`, err.Error())
+	}
+}
@@ -0,0 +1,51 @@
+package graph
+
+import (
+	"context"
+	"io"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/lineage"
+	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/project"
+	"github.com/keboola/keboola-as-code/internal/pkg/telemetry"
+)
+
+type Options struct {
+	Branch    model.BranchKey
+	Format    lineage.Format
+	Focus     string            // optional, node ID or table ID, only the node and nodes connected in the direction are exported
+	Direction lineage.Direction // direction of the focus
+}
+
+type dependencies interface {
+	Logger() log.Logger
+	Telemetry() telemetry.Telemetry
+	Stdout() io.Writer
+}
+
+func Run(ctx context.Context, projectState *project.State, o Options, d dependencies) (err error) {
+	ctx, span := d.Telemetry().Tracer().Start(ctx, "keboola.go.operation.project.local.graph")
+	defer span.End(&err)
+
+	// Build graph
+	graph := lineage.Build(projectState.State(), o.Branch)
+	if o.Focus != "" {
+		graph, err = graph.Focus(o.Focus, o.Direction)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Export
+	out, err := graph.Export(o.Format)
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(d.Stdout(), out); err != nil {
+		return err
+	}
+
+	d.Logger().Debugf(ctx, `Exported lineage graph with %d nodes and %d edges.`, len(graph.Nodes()), len(graph.Edges()))
+	return nil
+}
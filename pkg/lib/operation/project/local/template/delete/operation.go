@@ -3,6 +3,9 @@ package delete_template
 import (
 	"context"
 	"io"
+	"strings"
+
+	"github.com/keboola/go-client/pkg/keboola"
 
 	"github.com/keboola/keboola-as-code/internal/pkg/log"
 	"github.com/keboola/keboola-as-code/internal/pkg/model"
@@ -14,9 +17,10 @@ import (
 )
 
 type Options struct {
-	Branch   model.BranchKey
-	DryRun   bool
-	Instance string
+	Branch      model.BranchKey
+	DryRun      bool
+	Instance    string
+	KeepConfigs []model.ConfigKey // configs converted to regular configs instead of deletion
 }
 
 type dependencies interface {
@@ -32,7 +36,7 @@ func Run(ctx context.Context, projectState *project.State, o Options, d dependen
 	logger := d.Logger()
 
 	// Get plan
-	plan, err := deleteTemplate.NewPlan(projectState.State(), o.Branch, o.Instance)
+	plan, err := Plan(projectState, o)
 	if err != nil {
 		return err
 	}
@@ -60,3 +64,27 @@ func Run(ctx context.Context, projectState *project.State, o Options, d dependen
 
 	return nil
 }
+
+// Plan returns the delete plan without invoking it, so it can be previewed.
+func Plan(projectState *project.State, o Options) (*deleteTemplate.Plan, error) {
+	return deleteTemplate.NewPlan(projectState.State(), o.Branch, o.Instance, o.KeepConfigs)
+}
+
+// ParseConfigKeys parses configs in the "[componentId]:[configId]" format.
+func ParseConfigKeys(branch model.BranchKey, values []string) ([]model.ConfigKey, error) {
+	var out []model.ConfigKey
+	errs := errors.NewMultiError()
+	for _, item := range values {
+		item = strings.TrimSpace(item)
+		if len(item) == 0 {
+			continue
+		}
+		componentID, configID, found := strings.Cut(item, ":")
+		if !found || componentID == "" || configID == "" || strings.Contains(configID, ":") {
+			errs.Append(errors.Errorf(`cannot parse "%s", must be in "[componentId]:[configId]" format`, item))
+			continue
+		}
+		out = append(out, model.ConfigKey{BranchID: branch.ID, ComponentID: keboola.ComponentID(componentID), ID: keboola.ConfigID(configID)})
+	}
+	return out, errs.ErrorOrNil()
+}
@@ -0,0 +1,335 @@
+package prerequisites
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/keboola/go-client/pkg/keboola"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/project"
+	"github.com/keboola/keboola-as-code/internal/pkg/telemetry"
+	"github.com/keboola/keboola-as-code/internal/pkg/template"
+	"github.com/keboola/keboola-as-code/internal/pkg/template/context/upgrade"
+	"github.com/keboola/keboola-as-code/internal/pkg/template/input"
+	"github.com/keboola/keboola-as-code/internal/pkg/template/repository"
+	"github.com/keboola/keboola-as-code/internal/pkg/template/repository/manifest"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+	upgradeTemplate "github.com/keboola/keboola-as-code/pkg/lib/operation/project/local/template/upgrade"
+	useTemplate "github.com/keboola/keboola-as-code/pkg/lib/operation/project/local/template/use"
+)
+
+type Action string
+
+const (
+	// ActionNone - a matching instance is already installed.
+	ActionNone = Action("none")
+	// ActionInstall - the template is not installed, a new instance will be created.
+	ActionInstall = Action("install")
+	// ActionUpgrade - the installed instance doesn't match the version range, it will be upgraded.
+	ActionUpgrade = Action("upgrade")
+)
+
+// Prerequisite is a template required by another template, see manifest.TemplateRequirement.
+type Prerequisite struct {
+	Template *template.Template
+	Instance *model.TemplateInstance // nil, if the template is not installed in the branch
+	Action   Action
+	Inputs   template.InputsValues // values used to install/upgrade the instance, or exported from the existing instance
+}
+
+// Prerequisites are ordered, each prerequisite is placed after its own prerequisites.
+type Prerequisites []*Prerequisite
+
+// InputsFn returns inputs values for a prerequisite that will be installed or upgraded.
+// The groups contain values of the existing instance as default values, if any.
+type InputsFn func(ctx context.Context, tmpl *template.Template, groups input.StepsGroupsExt) (template.InputsValues, error)
+
+type dependencies interface {
+	Components() *model.ComponentsMap
+	KeboolaProjectAPI() *keboola.AuthorizedAPI
+	Logger() log.Logger
+	ObjectIDGeneratorFactory() func(ctx context.Context) *keboola.TicketProvider
+	ProjectID() keboola.ProjectID
+	ProjectBackends() []string
+	ProjectFeatures() keboola.FeaturesMap
+	StorageAPIHost() string
+	StorageAPITokenID() string
+	Stdout() io.Writer
+	Telemetry() telemetry.Telemetry
+	Template(ctx context.Context, reference model.TemplateRef) (*template.Template, error)
+	TemplateRepository(ctx context.Context, reference model.TemplateRepository) (*repository.Repository, error)
+}
+
+type resolver struct {
+	projectState *project.State
+	branch       model.BranchKey
+	instances    model.TemplatesInstances
+	inputsFn     InputsFn
+	deps         dependencies
+	repo         *repository.Repository
+	resolved     map[string]*Prerequisite
+	out          Prerequisites
+}
+
+// Resolve prerequisites of the template, recursively.
+// A prerequisite is installed if it is missing in the branch, or upgraded if the installed version doesn't match the version range.
+func Resolve(ctx context.Context, projectState *project.State, branch model.BranchKey, tmpl *template.Template, inputsFn InputsFn, d dependencies) (result Prerequisites, err error) {
+	ctx, span := d.Telemetry().Tracer().Start(ctx, "keboola.go.operation.project.local.template.prerequisites.resolve")
+	defer span.End(&err)
+
+	requirements := tmpl.TemplateRecord().Requirements.Templates
+	if len(requirements) == 0 {
+		return nil, nil
+	}
+
+	// Get existing instances
+	branchState, ok := projectState.GetOrNil(branch).(*model.BranchState)
+	if !ok {
+		return nil, errors.Errorf(`branch "%d" not found`, branch.ID)
+	}
+	instances, err := branchState.Local.Metadata.TemplatesInstances()
+	if err != nil {
+		return nil, err
+	}
+
+	// Prerequisites are loaded from the same repository
+	repo, err := d.TemplateRepository(ctx, tmpl.Repository())
+	if err != nil {
+		return nil, err
+	}
+
+	r := &resolver{
+		projectState: projectState,
+		branch:       branch,
+		instances:    instances,
+		inputsFn:     inputsFn,
+		deps:         d,
+		repo:         repo,
+		resolved:     make(map[string]*Prerequisite),
+	}
+	if err := r.resolveAll(ctx, tmpl.TemplateRecord(), []string{tmpl.TemplateID()}); err != nil {
+		return nil, err
+	}
+
+	// Mapped inputs must exist in the direct prerequisites
+	if _, err := r.out.InputsFor(requirements); err != nil {
+		return nil, err
+	}
+
+	return r.out, nil
+}
+
+func (r *resolver) resolveAll(ctx context.Context, record manifest.TemplateRecord, path []string) error {
+	for _, requirement := range record.Requirements.Templates {
+		// Check circular dependency
+		for _, id := range path {
+			if id == requirement.ID {
+				return errors.Errorf(`template "%s" has a circular dependency: %s -> %s`, path[0], strings.Join(path, " -> "), requirement.ID)
+			}
+		}
+
+		// The template may be required multiple times, the resolved version must match all ranges
+		if p, found := r.resolved[requirement.ID]; found {
+			if !requirement.Match(p.Template.VersionRecord().Version) {
+				return errors.Errorf(`template "%s" requires "%s" version "%s", but version "%s" is required by another template`, record.ID, requirement.ID, requirement.Version, p.Template.VersionRecord().Version.String())
+			}
+			continue
+		}
+
+		// Get template record
+		prerequisiteRecord, found := r.repo.RecordByID(requirement.ID)
+		if !found {
+			return errors.Errorf(`template "%s" required by "%s" not found in the repository "%s"`, requirement.ID, record.ID, r.repo.Definition().Name)
+		}
+
+		// Resolve nested prerequisites first
+		if err := r.resolveAll(ctx, prerequisiteRecord, append(path, requirement.ID)); err != nil {
+			return err
+		}
+
+		p, err := r.resolve(ctx, prerequisiteRecord, requirement)
+		if err != nil {
+			return err
+		}
+		r.resolved[requirement.ID] = p
+		r.out = append(r.out, p)
+	}
+	return nil
+}
+
+func (r *resolver) resolve(ctx context.Context, record manifest.TemplateRecord, requirement manifest.TemplateRequirement) (*Prerequisite, error) {
+	// Find an existing instance, prefer an instance with a matching version
+	var instance *model.TemplateInstance
+	for i := range r.instances {
+		item := &r.instances[i]
+		if item.TemplateID != record.ID || item.RepositoryName != r.repo.Definition().Name {
+			continue
+		}
+		if v, err := model.NewSemVersion(item.Version); err == nil && requirement.Match(v) {
+			instance = item
+			break
+		}
+		if instance == nil {
+			instance = item
+		}
+	}
+
+	// Get version
+	var version string
+	if instance != nil && r.matchInstance(instance, requirement) {
+		version = instance.Version
+	} else if v, found := record.LatestVersionInRange(requirement); found {
+		version = v.Version.Original()
+	} else {
+		return nil, errors.Errorf(`no version of the template "%s" matches the range "%s"`, record.ID, requirement.Version)
+	}
+
+	// Load template
+	tmpl, err := r.deps.Template(ctx, model.NewTemplateRef(r.repo.Definition(), record.ID, version))
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Prerequisite{Template: tmpl, Instance: instance}
+	switch {
+	case instance == nil:
+		p.Action = ActionInstall
+		groups := tmpl.Inputs().ToExtended()
+		if p.Inputs, err = r.inputsFn(ctx, tmpl, groups); err != nil {
+			return nil, errors.PrefixErrorf(err, `cannot install the required template "%s"`, tmpl.FullName())
+		}
+	case r.matchInstance(instance, requirement):
+		p.Action = ActionNone
+		groups := upgrade.ExportInputsValues(ctx, r.deps.Logger().Debugf, r.projectState.State(), r.branch, instance.InstanceID, tmpl.Inputs())
+		if p.Inputs, err = ExportedInputs(ctx, groups); err != nil {
+			return nil, err
+		}
+	default:
+		p.Action = ActionUpgrade
+		groups := upgrade.ExportInputsValues(ctx, r.deps.Logger().Debugf, r.projectState.State(), r.branch, instance.InstanceID, tmpl.Inputs())
+		if p.Inputs, err = r.inputsFn(ctx, tmpl, groups); err != nil {
+			return nil, errors.PrefixErrorf(err, `cannot upgrade the required template "%s"`, tmpl.FullName())
+		}
+	}
+
+	return p, nil
+}
+
+func (r *resolver) matchInstance(instance *model.TemplateInstance, requirement manifest.TemplateRequirement) bool {
+	v, err := model.NewSemVersion(instance.Version)
+	return err == nil && requirement.Match(v)
+}
+
+// Get returns prerequisite by the template ID.
+func (v Prerequisites) Get(templateID string) (*Prerequisite, bool) {
+	for _, p := range v {
+		if p.Template.TemplateID() == templateID {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+// InputsFor returns values passed from the prerequisites to the template inputs, according to the requirements.
+func (v Prerequisites) InputsFor(requirements []manifest.TemplateRequirement) (map[string]any, error) {
+	out := make(map[string]any)
+	errs := errors.NewMultiError()
+	for _, requirement := range requirements {
+		p, found := v.Get(requirement.ID)
+		if !found {
+			errs.Append(errors.Errorf(`required template "%s" not found`, requirement.ID))
+			continue
+		}
+		values := p.Inputs.ToMap()
+		for inputID, prerequisiteInputID := range requirement.Inputs {
+			value, found := values[prerequisiteInputID]
+			if !found {
+				errs.Append(errors.Errorf(`input "%s" not found in the required template "%s"`, prerequisiteInputID, requirement.ID))
+				continue
+			}
+			out[inputID] = value.Value
+		}
+	}
+	return out, errs.ErrorOrNil()
+}
+
+// Install installs or upgrades the prerequisites in the order, matching instances are skipped.
+func Install(ctx context.Context, projectState *project.State, branch model.BranchKey, prerequisites Prerequisites, d dependencies) (warnings []string, err error) {
+	ctx, span := d.Telemetry().Tracer().Start(ctx, "keboola.go.operation.project.local.template.prerequisites.install")
+	defer span.End(&err)
+
+	for _, p := range prerequisites {
+		var result *useTemplate.Result
+		switch p.Action {
+		case ActionInstall:
+			result, err = useTemplate.Run(ctx, projectState, p.Template, useTemplate.Options{
+				InstanceName: p.Template.TemplateRecord().Name,
+				TargetBranch: branch,
+				Inputs:       p.Inputs,
+			}, d)
+			if err != nil {
+				return nil, errors.PrefixErrorf(err, `cannot install the required template "%s"`, p.Template.FullName())
+			}
+			d.Logger().Infof(ctx, `Required template "%s" installed, instance ID "%s".`, p.Template.FullName(), result.InstanceID)
+		case ActionUpgrade:
+			result, err = upgradeTemplate.Run(ctx, projectState, p.Template, upgradeTemplate.Options{
+				Branch:   branch,
+				Instance: *p.Instance,
+				Inputs:   p.Inputs,
+			}, d)
+			if err != nil {
+				return nil, errors.PrefixErrorf(err, `cannot upgrade the required template "%s"`, p.Template.FullName())
+			}
+			d.Logger().Infof(ctx, `Required template "%s" upgraded, instance ID "%s".`, p.Template.FullName(), result.InstanceID)
+		default:
+			continue
+		}
+		warnings = append(warnings, result.Warnings...)
+	}
+	return warnings, nil
+}
+
+// DefaultInputs returns default values of the inputs, it can be used as InputsFn, if there is no user interaction.
+func DefaultInputs(ctx context.Context, tmpl *template.Template, groups input.StepsGroupsExt) (template.InputsValues, error) {
+	values, err := inputsValues(ctx, groups, true)
+	if err != nil {
+		return nil, errors.NewNestedError(err, errors.Errorf(`please install the template "%s" first`, tmpl.FullName()))
+	}
+	return values, nil
+}
+
+// ExportedInputs converts values exported from an existing instance, the values are not validated.
+func ExportedInputs(ctx context.Context, groups input.StepsGroupsExt) (template.InputsValues, error) {
+	return inputsValues(ctx, groups, false)
+}
+
+func inputsValues(ctx context.Context, groups input.StepsGroupsExt, validate bool) (template.InputsValues, error) {
+	out := make(template.InputsValues, 0)
+	valuesMap := make(map[string]any)
+	errs := errors.NewMultiError()
+	_ = groups.VisitInputs(func(group *input.StepsGroupExt, step *input.StepExt, inputDef *input.Input) error {
+		available, err := inputDef.Available(valuesMap)
+		if err != nil {
+			errs.Append(err)
+			return nil
+		}
+
+		value := template.InputValue{ID: inputDef.ID, Value: inputDef.Empty(), Skipped: true}
+		if available {
+			value, err = template.ParseInputValue(ctx, inputDef.DefaultOrEmpty(), inputDef, validate)
+			if err != nil {
+				errs.AppendWithPrefixf(err, `input "%s"`, inputDef.ID)
+				return nil
+			}
+			value.Skipped = false
+		}
+
+		valuesMap[inputDef.ID] = value.Value
+		out = append(out, value)
+		return nil
+	})
+	return out, errs.ErrorOrNil()
+}
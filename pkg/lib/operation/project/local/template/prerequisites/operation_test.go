@@ -0,0 +1,46 @@
+package prerequisites
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/template"
+	"github.com/keboola/keboola-as-code/internal/pkg/template/input"
+)
+
+func TestInputsValues(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	groups := template.StepsGroups{
+		{
+			Required: input.RequiredAll,
+			Steps: template.Steps{
+				{
+					Inputs: template.Inputs{
+						{ID: "foo", Name: "Foo", Type: input.TypeString, Kind: input.KindInput, Default: "default", Rules: "required"},
+						{ID: "bar", Name: "Bar", Type: input.TypeString, Kind: input.KindInput, If: "foo == 'other'"},
+						{ID: "baz", Name: "Baz", Type: input.TypeString, Kind: input.KindInput, Rules: "required"},
+					},
+				},
+			},
+		},
+	}
+
+	// Exported values are not validated
+	values, err := ExportedInputs(ctx, groups.ToExtended())
+	require.NoError(t, err)
+	assert.Equal(t, template.InputsValues{
+		{ID: "foo", Value: "default"},
+		{ID: "bar", Value: "", Skipped: true},
+		{ID: "baz", Value: ""},
+	}, values)
+
+	// Default values must be valid
+	_, err = inputsValues(ctx, groups.ToExtended(), true)
+	require.Error(t, err)
+	assert.Equal(t, "input \"baz\":\n- invalid template input: Baz is a required field", err.Error())
+}
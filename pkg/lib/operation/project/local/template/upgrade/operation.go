@@ -12,6 +12,7 @@ import (
 	"github.com/keboola/keboola-as-code/internal/pkg/telemetry"
 	"github.com/keboola/keboola-as-code/internal/pkg/template"
 	"github.com/keboola/keboola-as-code/internal/pkg/template/context/upgrade"
+	"github.com/keboola/keboola-as-code/internal/pkg/template/jsonnet/function"
 	"github.com/keboola/keboola-as-code/pkg/lib/operation/project/local/template/use"
 )
 
@@ -21,6 +22,8 @@ type Options struct {
 	Inputs                template.InputsValues
 	SkipEncrypt           bool
 	SkipSecretsValidation bool
+	SkipStorageValidation bool
+	Environment           *function.Environment // optional, overrides the project environment, for example in template tests
 }
 
 type dependencies interface {
@@ -30,6 +33,7 @@ type dependencies interface {
 	ObjectIDGeneratorFactory() func(ctx context.Context) *keboola.TicketProvider
 	ProjectID() keboola.ProjectID
 	ProjectBackends() []string
+	ProjectFeatures() keboola.FeaturesMap
 	StorageAPIHost() string
 	StorageAPITokenID() string
 	Telemetry() telemetry.Telemetry
@@ -43,8 +47,14 @@ func Run(ctx context.Context, projectState *project.State, tmpl *template.Templa
 	// Create tickets provider, to generate new IDs, if needed
 	tickets := d.ObjectIDGeneratorFactory()(ctx)
 
+	// Get the project environment for Jsonnet functions
+	env := use.ProjectEnvironment(ctx, d, o.Branch)
+	if o.Environment != nil {
+		env = *o.Environment
+	}
+
 	// Prepare template
-	tmplCtx := upgrade.NewContext(ctx, tmpl.Reference(), tmpl.ObjectsRoot(), o.Instance.InstanceID, o.Branch, o.Inputs, tmpl.Inputs().InputsMap(), tickets, d.Components(), projectState.State(), d.ProjectBackends())
+	tmplCtx := upgrade.NewContext(ctx, tmpl.Reference(), tmpl.ObjectsRoot(), o.Instance.InstanceID, o.Branch, o.Inputs, tmpl.Inputs().InputsMap(), tickets, d.Components(), projectState.State(), d.ProjectBackends(), env)
 	plan, err := use.PrepareTemplate(ctx, d, use.ExtendedOptions{
 		TargetBranch:          o.Branch,
 		Inputs:                o.Inputs,
@@ -56,6 +66,7 @@ func Run(ctx context.Context, projectState *project.State, tmpl *template.Templa
 		Upgrade:               true,
 		SkipEncrypt:           o.SkipEncrypt,
 		SkipSecretsValidation: o.SkipSecretsValidation,
+		SkipStorageValidation: o.SkipStorageValidation,
 	})
 	if err != nil {
 		return nil, err
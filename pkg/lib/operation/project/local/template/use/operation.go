@@ -20,6 +20,8 @@ import (
 	"github.com/keboola/keboola-as-code/internal/pkg/telemetry"
 	"github.com/keboola/keboola-as-code/internal/pkg/template"
 	"github.com/keboola/keboola-as-code/internal/pkg/template/context/use"
+	"github.com/keboola/keboola-as-code/internal/pkg/template/input"
+	"github.com/keboola/keboola-as-code/internal/pkg/template/jsonnet/function"
 	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
 	"github.com/keboola/keboola-as-code/pkg/lib/operation/project/local/encrypt"
 	saveProjectManifest "github.com/keboola/keboola-as-code/pkg/lib/operation/project/local/manifest/save"
@@ -35,6 +37,8 @@ type Options struct {
 	InstanceID            string
 	SkipEncrypt           bool
 	SkipSecretsValidation bool
+	SkipStorageValidation bool                  // skip check that tables, buckets and workspaces from the inputs exist
+	Environment           *function.Environment // optional, overrides the project environment, for example in template tests
 }
 
 type dependencies interface {
@@ -46,6 +50,7 @@ type dependencies interface {
 	ObjectIDGeneratorFactory() func(ctx context.Context) *keboola.TicketProvider
 	ProjectID() keboola.ProjectID
 	ProjectBackends() []string
+	ProjectFeatures() keboola.FeaturesMap
 	Telemetry() telemetry.Telemetry
 	Stdout() io.Writer
 }
@@ -71,8 +76,14 @@ func Run(ctx context.Context, projectState *project.State, tmpl *template.Templa
 		o.InstanceID = idgenerator.TemplateInstanceID()
 	}
 
+	// Get the project environment for Jsonnet functions
+	env := ProjectEnvironment(ctx, d, o.TargetBranch)
+	if o.Environment != nil {
+		env = *o.Environment
+	}
+
 	// Prepare template
-	tmplCtx := use.NewContext(ctx, tmpl.Reference(), tmpl.ObjectsRoot(), o.InstanceID, o.TargetBranch, o.Inputs, tmpl.Inputs().InputsMap(), tickets, d.Components(), projectState.State(), d.ProjectBackends())
+	tmplCtx := use.NewContext(ctx, tmpl.Reference(), tmpl.ObjectsRoot(), o.InstanceID, o.TargetBranch, o.Inputs, tmpl.Inputs().InputsMap(), tickets, d.Components(), projectState.State(), d.ProjectBackends(), env)
 	plan, err := PrepareTemplate(ctx, d, ExtendedOptions{
 		TargetBranch:          o.TargetBranch,
 		Inputs:                o.Inputs,
@@ -84,6 +95,7 @@ func Run(ctx context.Context, projectState *project.State, tmpl *template.Templa
 		Upgrade:               false,
 		SkipEncrypt:           o.SkipEncrypt,
 		SkipSecretsValidation: o.SkipSecretsValidation,
+		SkipStorageValidation: o.SkipStorageValidation,
 	})
 	if err != nil {
 		return nil, err
@@ -92,6 +104,26 @@ func Run(ctx context.Context, projectState *project.State, tmpl *template.Templa
 	return plan.Invoke(ctx)
 }
 
+// ProjectEnvironment returns information about the project for Jsonnet functions.
+// Tables are loaded lazily, only if a table function is used in the template.
+func ProjectEnvironment(ctx context.Context, d dependencies, branch model.BranchKey) function.Environment {
+	return function.Environment{
+		StackHost: d.StorageAPIHost(),
+		Features:  d.ProjectFeatures(),
+		Tables: func() (function.Tables, error) {
+			tables, err := d.KeboolaProjectAPI().ListTablesRequest(branch.ID, keboola.WithColumns()).Send(ctx)
+			if err != nil {
+				return nil, errors.Errorf("cannot load tables: %w", err)
+			}
+			out := make(function.Tables, len(*tables))
+			for _, table := range *tables {
+				out[table.TableID.String()] = table.Columns
+			}
+			return out, nil
+		},
+	}
+}
+
 type ExtendedOptions struct {
 	TargetBranch          model.BranchKey
 	Inputs                template.InputsValues
@@ -103,6 +135,7 @@ type ExtendedOptions struct {
 	Upgrade               bool
 	SkipEncrypt           bool
 	SkipSecretsValidation bool
+	SkipStorageValidation bool
 }
 
 type TemplatePlan struct {
@@ -130,6 +163,13 @@ func PrepareTemplate(ctx context.Context, d dependencies, o ExtendedOptions) (pl
 		saveOp:   manager.NewUnitOfWork(ctx),
 	}
 
+	// Values of the storage inputs must reference existing objects
+	if !o.SkipStorageValidation {
+		if err := validateStorageInputs(ctx, d, o); err != nil {
+			return nil, err
+		}
+	}
+
 	// Load template state
 	plan.templateState, err = o.Template.LoadState(o.TemplateCtx, LoadTemplateOptions(), d)
 	if err != nil {
@@ -299,6 +339,30 @@ func (p *TemplatePlan) Invoke(ctx context.Context) (*Result, error) {
 	return result, nil
 }
 
+// validateStorageInputs checks that tables, buckets and workspaces from the inputs exist in the target branch.
+func validateStorageInputs(ctx context.Context, d dependencies, o ExtendedOptions) error {
+	inputs := o.Template.Inputs().InputsMap()
+	kinds := input.StorageKindsOf(inputs)
+	if len(kinds) == 0 {
+		return nil
+	}
+
+	storage, err := input.LoadStorageObjects(ctx, d.KeboolaProjectAPI(), o.TargetBranch.ID, kinds)
+	if err != nil {
+		return err
+	}
+
+	errs := errors.NewMultiError()
+	for _, value := range o.Inputs {
+		if inputDef, found := inputs[value.ID]; found && !value.Skipped {
+			if err := storage.ValidateValue(inputDef, value.Value); err != nil {
+				errs.Append(err)
+			}
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
 func mergeSharedCodes(object model.ObjectState, plan *TemplatePlan, sharedCodes map[keboola.ComponentID]*model.ConfigState) (skip bool) {
 	switch v := object.(type) {
 	case *model.ConfigState:
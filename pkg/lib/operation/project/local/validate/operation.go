@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"github.com/keboola/keboola-as-code/internal/pkg/encoding/json/schema"
+	"github.com/keboola/keboola-as-code/internal/pkg/filesystem"
+	"github.com/keboola/keboola-as-code/internal/pkg/lint"
 	"github.com/keboola/keboola-as-code/internal/pkg/log"
 	"github.com/keboola/keboola-as-code/internal/pkg/plan/encrypt"
 	"github.com/keboola/keboola-as-code/internal/pkg/project"
@@ -14,6 +16,8 @@ import (
 type Options struct {
 	ValidateSecrets    bool
 	ValidateJSONSchema bool
+	Lint               bool   // run lint rules, configured by the ".keboola/lint.json" file
+	SARIFOutput        string // optional, lint results are written to the file in the SARIF format, the path is relative to the working dir
 }
 
 type dependencies interface {
@@ -43,6 +47,13 @@ func Run(ctx context.Context, projectState *project.State, o Options, d dependen
 		}
 	}
 
+	// Run lint rules
+	if o.Lint {
+		if err := runLint(ctx, projectState, o, d); err != nil {
+			errs.Append(err)
+		}
+	}
+
 	// Process errors
 	if err := errs.ErrorOrNil(); err != nil {
 		return errors.PrefixError(err, "validation failed")
@@ -51,3 +62,55 @@ func Run(ctx context.Context, projectState *project.State, o Options, d dependen
 	logger.Debug(ctx, "Validation done.")
 	return nil
 }
+
+func runLint(ctx context.Context, projectState *project.State, o Options, d dependencies) error {
+	logger := d.Logger()
+
+	// Load rules config
+	config, err := lint.LoadConfig(ctx, projectState.Fs())
+	if err != nil {
+		return err
+	}
+	engine, err := lint.NewEngine(config, lint.DefaultRules()...)
+	if err != nil {
+		return err
+	}
+
+	// Run rules
+	issues := engine.Run(ctx, projectState.State())
+	for _, issue := range issues {
+		switch issue.Severity {
+		case lint.SeverityError:
+			// Errors are returned below
+		case lint.SeverityWarning:
+			logger.Warn(ctx, issue.String())
+		default:
+			logger.Info(ctx, issue.String())
+		}
+	}
+
+	// Write SARIF report
+	if o.SARIFOutput != "" {
+		content, err := engine.SARIF(issues)
+		if err != nil {
+			return err
+		}
+		// The path is relative to the working directory, as other paths from the command line
+		fs := projectState.Fs()
+		if err := fs.WriteFile(ctx, filesystem.NewRawFile(filesystem.Join(fs.WorkingDir(), o.SARIFOutput), content)); err != nil {
+			return err
+		}
+		logger.Infof(ctx, `SARIF report written to "%s".`, o.SARIFOutput)
+	}
+
+	// Convert error issues to errors
+	errs := errors.NewMultiError()
+	for _, issue := range issues.Errors() {
+		errs.Append(errors.New(issue.String()))
+	}
+	if err := errs.ErrorOrNil(); err != nil {
+		return errors.PrefixError(err, "lint failed")
+	}
+
+	return nil
+}
@@ -10,9 +10,10 @@ import (
 )
 
 type Options struct {
-	Validate   bool // validate all branches
-	Push       bool // push to the project on change in the main branch
-	Pull       bool // periodical pull new changes to the main branch
+	Provider   string // CI provider, see workflows.Providers
+	Validate   bool   // validate all branches
+	Push       bool   // push to the project on change in the main branch
+	Pull       bool   // periodical pull new changes to the main branch
 	MainBranch string
 }
 
@@ -34,6 +35,7 @@ func Run(ctx context.Context, fs filesystem.Fs, o Options, d dependencies) (err
 	}
 
 	return workflows.GenerateFiles(ctx, d.Logger(), fs, &workflows.Options{
+		Provider:   o.Provider,
 		Validate:   o.Validate,
 		Push:       o.Push,
 		Pull:       o.Pull,
@@ -5,6 +5,7 @@ import (
 
 	"github.com/keboola/keboola-as-code/internal/pkg/diff"
 	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/model"
 	"github.com/keboola/keboola-as-code/internal/pkg/project"
 	"github.com/keboola/keboola-as-code/internal/pkg/telemetry"
 	createDiff "github.com/keboola/keboola-as-code/pkg/lib/operation/project/sync/diff/create"
@@ -13,6 +14,7 @@ import (
 type Options struct {
 	PrintDetails      bool
 	LogUntrackedPaths bool
+	Object            model.Key // optional, limits the diff to the object and its children
 }
 
 type dependencies interface {
@@ -31,6 +33,9 @@ func Run(ctx context.Context, projectState *project.State, o Options, d dependen
 	if err != nil {
 		return nil, err
 	}
+	if o.Object != nil {
+		results = results.Subtree(o.Object)
+	}
 
 	// Log untracked paths
 	if o.LogUntrackedPaths {
@@ -8,6 +8,7 @@ import (
 
 	"github.com/keboola/keboola-as-code/internal/pkg/diff"
 	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/model"
 	"github.com/keboola/keboola-as-code/internal/pkg/plan/pull"
 	"github.com/keboola/keboola-as-code/internal/pkg/project"
 	"github.com/keboola/keboola-as-code/internal/pkg/project/cachefile"
@@ -23,6 +24,7 @@ import (
 type Options struct {
 	DryRun            bool
 	LogUntrackedPaths bool
+	Object            model.Key // optional, limits the operation to the object and its children
 }
 
 type dependencies interface {
@@ -54,6 +56,9 @@ func Run(ctx context.Context, projectState *project.State, o Options, d dependen
 	if err != nil {
 		return err
 	}
+	if o.Object != nil {
+		results = results.Subtree(o.Object)
+	}
 
 	// Get plan
 	plan, err := pull.NewPlan(results)
@@ -8,6 +8,7 @@ import (
 
 	"github.com/keboola/keboola-as-code/internal/pkg/diff"
 	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/model"
 	"github.com/keboola/keboola-as-code/internal/pkg/plan/push"
 	"github.com/keboola/keboola-as-code/internal/pkg/project"
 	"github.com/keboola/keboola-as-code/internal/pkg/telemetry"
@@ -22,6 +23,7 @@ type Options struct {
 	SkipValidation    bool
 	AllowRemoteDelete bool
 	LogUntrackedPaths bool
+	Object            model.Key // optional, limits the operation to the object and its children
 	ChangeDescription string
 }
 
@@ -70,6 +72,9 @@ func Run(ctx context.Context, projectState *project.State, o Options, d dependen
 	if err != nil {
 		return err
 	}
+	if o.Object != nil {
+		results = results.Subtree(o.Object)
+	}
 
 	// Get plan
 	plan, err := push.NewPlan(results, projectState.ProjectManifest().AllowTargetENV())
@@ -18,7 +18,15 @@ type dependencies interface {
 	Telemetry() telemetry.Telemetry
 }
 
-func Run(ctx context.Context, repo *git.RemoteRepository, d dependencies) (result *git.PullResult, err error) {
+// Repository which can be updated, *git.RemoteRepository or *archive.Repository.
+type Repository interface {
+	String() string
+	URL() string
+	Ref() string
+	Pull(ctx context.Context) (*git.PullResult, error)
+}
+
+func Run(ctx context.Context, repo Repository, d dependencies) (result *git.PullResult, err error) {
 	ctx, span := d.Telemetry().Tracer().Start(ctx, "keboola.go.operation.repository.pull")
 	defer span.End(&err)
 
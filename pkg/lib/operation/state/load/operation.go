@@ -63,8 +63,9 @@ type Options struct {
 
 type OptionsWithFilter struct {
 	Options
-	LocalFilter  *model.ObjectsFilter
-	RemoteFilter *model.ObjectsFilter
+	LocalFilter   *model.ObjectsFilter
+	RemoteFilter  *model.ObjectsFilter
+	RemoteObjects []model.Object // if set, the remote state is loaded from the objects instead of the API
 }
 
 func InitOptions(pull bool) Options {
@@ -143,6 +144,7 @@ func Run(ctx context.Context, container state.ObjectsContainer, o OptionsWithFil
 		IgnoreNotFoundErr: o.IgnoreNotFoundErr,
 		LocalFilter:       o.LocalFilter,
 		RemoteFilter:      o.RemoteFilter,
+		RemoteObjects:     o.RemoteObjects,
 	}
 
 	// Create state
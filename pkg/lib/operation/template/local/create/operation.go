@@ -31,6 +31,8 @@ type Options struct {
 	Configs      []create.ConfigDef
 	StepsGroups  template.StepsGroups
 	Components   []string
+	// SourceObjects of a local project, if set, the objects are not loaded from the remote project.
+	SourceObjects []model.Object
 }
 
 type dependencies interface {
@@ -80,7 +82,12 @@ func Run(ctx context.Context, o Options, d dependencies) (err error) {
 	}
 
 	// Template context
-	templateCtx := create.NewContext(ctx, o.SourceBranch, o.Configs)
+	var templateCtx *create.Context
+	if o.SourceObjects != nil {
+		templateCtx = create.NewContextFromObjects(ctx, o.SourceBranch, o.Configs, o.SourceObjects)
+	} else {
+		templateCtx = create.NewContext(ctx, o.SourceBranch, o.Configs)
+	}
 
 	// Template definition
 	templateDef := model.NewTemplateRef(repo.Definition(), o.ID, versionRecord.Version.String())
@@ -0,0 +1,281 @@
+// Package diff compares two versions of a template.
+//
+// Both versions are rendered with the same test inputs into an in-memory project, backed by the fake Storage API.
+// The old version is applied first, then the instance is upgraded to the new version, like "kbc local template upgrade".
+// The old objects are used as the remote state and the new objects as the local state, so the diff package can be used.
+package diff
+
+import (
+	"context"
+	"io"
+
+	"github.com/keboola/go-client/pkg/keboola"
+	"github.com/keboola/go-utils/pkg/deepcopy"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/diff"
+	"github.com/keboola/keboola-as-code/internal/pkg/encoding/json"
+	"github.com/keboola/keboola-as-code/internal/pkg/filesystem"
+	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/project"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/servicectx"
+	"github.com/keboola/keboola-as-code/internal/pkg/telemetry"
+	"github.com/keboola/keboola-as-code/internal/pkg/template"
+	tmplTest "github.com/keboola/keboola-as-code/internal/pkg/template/test"
+	"github.com/keboola/keboola-as-code/internal/pkg/template/test/fakestorage"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+	upgradeTemplate "github.com/keboola/keboola-as-code/pkg/lib/operation/project/local/template/upgrade"
+	useTemplate "github.com/keboola/keboola-as-code/pkg/lib/operation/project/local/template/use"
+)
+
+const (
+	ActionChanged = "changed"
+	ActionAdded   = "added"
+	ActionRemoved = "removed"
+)
+
+type Options struct {
+	TestName     string // name of the test with inputs, if empty, the first test present in both versions is used
+	PrintDetails bool   // print changed lines, not only changed fields
+	OutputJSON   string // optional path to the JSON changelog
+}
+
+type dependencies interface {
+	Components() *model.ComponentsMap
+	Fs() filesystem.Fs
+	Logger() log.Logger
+	Process() *servicectx.Process
+	StorageAPIHost() string
+	Telemetry() telemetry.Telemetry
+	Stderr() io.Writer
+}
+
+// Changelog describes what an upgrade from the old to the new version does with an instance.
+type Changelog struct {
+	TemplateID string            `json:"templateId"`
+	From       string            `json:"from"`
+	To         string            `json:"to"`
+	Test       string            `json:"test"`
+	Changes    []ChangelogObject `json:"changes"`
+}
+
+type ChangelogObject struct {
+	Kind          string           `json:"kind"`
+	Path          string           `json:"path"`
+	Action        string           `json:"action"`
+	ChangedFields []ChangelogField `json:"changedFields,omitempty"`
+}
+
+type ChangelogField struct {
+	Field string `json:"field"`
+	Paths string `json:"paths,omitempty"`
+	Diff  string `json:"diff"`
+}
+
+func Run(ctx context.Context, oldTmpl, newTmpl *template.Template, o Options, d dependencies) (changelog *Changelog, err error) {
+	ctx, span := d.Telemetry().Tracer().Start(ctx, "keboola.go.operation.template.local.diff")
+	defer span.End(&err)
+
+	logger := d.Logger()
+
+	// Find tests with the same name in both versions
+	oldTest, newTest, err := findTests(ctx, oldTmpl, newTmpl, o.TestName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read inputs and replace env vars, each version has own inputs definitions
+	oldInputs, err := tmplTest.ReadInputValues(ctx, oldTmpl, oldTest)
+	if err != nil {
+		return nil, errors.PrefixErrorf(err, `cannot read inputs of the test "%s" from version "%s"`, oldTest.Name(), oldTmpl.Version())
+	}
+	newInputs, err := tmplTest.ReadInputValues(ctx, newTmpl, newTest)
+	if err != nil {
+		return nil, errors.PrefixErrorf(err, `cannot read inputs of the test "%s" from version "%s"`, newTest.Name(), newTmpl.Version())
+	}
+
+	// Prepare in-memory project, output of the use/upgrade operations is not printed
+	prjState, _, testDeps, err := tmplTest.PrepareOfflineProject(ctx, log.NewNopLogger(), d.Telemetry(), io.Discard, d.Stderr(), d.Process(), d.StorageAPIHost(), d.Components().All(), false)
+	if err != nil {
+		return nil, err
+	}
+	branchKey := model.BranchKey{ID: keboola.BranchID(fakestorage.DefaultBranchID)}
+
+	// Mock project environment for Jsonnet functions, if the tests define it
+	env := useTemplate.ProjectEnvironment(ctx, testDeps, branchKey)
+	oldEnv, err := tmplTest.MockEnvironment(ctx, oldTest, env)
+	if err != nil {
+		return nil, err
+	}
+	newEnv, err := tmplTest.MockEnvironment(ctx, newTest, env)
+	if err != nil {
+		return nil, err
+	}
+
+	// Render the old version
+	useResult, err := useTemplate.Run(ctx, prjState, oldTmpl, useTemplate.Options{
+		InstanceName:          "diff",
+		TargetBranch:          branchKey,
+		Inputs:                oldInputs,
+		InstanceID:            template.InstanceIDForTest,
+		SkipEncrypt:           true,
+		SkipSecretsValidation: true,
+		SkipStorageValidation: true,
+		Environment:           oldEnv,
+	}, testDeps)
+	if err != nil {
+		return nil, errors.PrefixErrorf(err, `cannot render version "%s"`, oldTmpl.Version())
+	}
+
+	// The old version is the "remote" state
+	for _, objectState := range prjState.All() {
+		if objectState.HasLocalState() {
+			objectState.SetRemoteState(deepcopy.Copy(objectState.LocalState()).(model.Object))
+		}
+	}
+
+	// Upgrade the instance to the new version
+	instance, err := findInstance(prjState, branchKey, useResult.InstanceID)
+	if err != nil {
+		return nil, err
+	}
+	_, err = upgradeTemplate.Run(ctx, prjState, newTmpl, upgradeTemplate.Options{
+		Branch:                branchKey,
+		Instance:              *instance,
+		Inputs:                newInputs,
+		SkipEncrypt:           true,
+		SkipSecretsValidation: true,
+		SkipStorageValidation: true,
+		Environment:           newEnv,
+	}, testDeps)
+	if err != nil {
+		return nil, errors.PrefixErrorf(err, `cannot render version "%s"`, newTmpl.Version())
+	}
+
+	// Diff, the branch is not part of the template
+	results, err := diff.NewDiffer(prjState).Diff()
+	if err != nil {
+		return nil, err
+	}
+	results = withoutBranches(results)
+
+	// Print diff
+	if results.Equal {
+		logger.Infof(ctx, `No difference between versions "%s" and "%s".`, oldTmpl.Version(), newTmpl.Version())
+	} else {
+		logger.Info(ctx, diff.ChangeMark+" changed")
+		logger.Infof(ctx, `%s only in "%s"`, diff.OnlyInRemoteMark, oldTmpl.Version())
+		logger.Infof(ctx, `%s only in "%s"`, diff.OnlyInLocalMark, newTmpl.Version())
+		logger.Info(ctx, "")
+		logger.Info(ctx, "Diff:")
+		for _, line := range results.Format(o.PrintDetails) {
+			logger.Info(ctx, line)
+		}
+	}
+
+	changelog = newChangelog(oldTmpl, newTmpl, oldTest.Name(), results)
+
+	// Write JSON changelog
+	if o.OutputJSON != "" {
+		content, err := json.EncodeString(changelog, true)
+		if err != nil {
+			return nil, errors.PrefixError(err, "cannot encode changelog")
+		}
+		if err := d.Fs().WriteFile(ctx, filesystem.NewRawFile(o.OutputJSON, content)); err != nil {
+			return nil, err
+		}
+		logger.Infof(ctx, `Changelog written to "%s".`, o.OutputJSON)
+	}
+
+	return changelog, nil
+}
+
+func findTests(ctx context.Context, oldTmpl, newTmpl *template.Template, testName string) (*template.Test, *template.Test, error) {
+	if testName != "" {
+		oldTest, err := oldTmpl.Test(ctx, testName)
+		if err != nil {
+			return nil, nil, err
+		}
+		newTest, err := newTmpl.Test(ctx, testName)
+		if err != nil {
+			return nil, nil, err
+		}
+		return oldTest, newTest, nil
+	}
+
+	oldTests, err := oldTmpl.Tests(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	newTests, err := newTmpl.Tests(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, oldTest := range oldTests {
+		for _, newTest := range newTests {
+			if oldTest.Name() == newTest.Name() {
+				return oldTest, newTest, nil
+			}
+		}
+	}
+	return nil, nil, errors.Errorf(`versions "%s" and "%s" of the template "%s" have no common test, please create a test with the same name in both versions`, oldTmpl.Version(), newTmpl.Version(), newTmpl.TemplateID())
+}
+
+func findInstance(prjState *project.State, branchKey model.BranchKey, instanceID string) (*model.TemplateInstance, error) {
+	branch, found := prjState.GetOrNil(branchKey).(*model.BranchState)
+	if !found {
+		return nil, errors.Errorf(`branch "%d" not found`, branchKey.ID)
+	}
+	instance, found, err := branch.Local.Metadata.TemplateInstance(instanceID)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errors.Errorf(`template instance "%s" not found in branch metadata`, instanceID)
+	}
+	return instance, nil
+}
+
+func withoutBranches(results *diff.Results) *diff.Results {
+	out := &diff.Results{Equal: true, Objects: results.Objects}
+	for _, result := range results.Results {
+		if result.Kind().IsBranch() {
+			continue
+		}
+		out.Results = append(out.Results, result)
+		if result.State != diff.ResultEqual {
+			out.Equal = false
+		}
+	}
+	return out
+}
+
+func newChangelog(oldTmpl, newTmpl *template.Template, testName string, results *diff.Results) *Changelog {
+	out := &Changelog{
+		TemplateID: newTmpl.TemplateID(),
+		From:       oldTmpl.Version(),
+		To:         newTmpl.Version(),
+		Test:       testName,
+		Changes:    make([]ChangelogObject, 0),
+	}
+	for _, result := range results.Results {
+		var action string
+		switch result.State {
+		case diff.ResultNotEqual:
+			action = ActionChanged
+		case diff.ResultOnlyInLocal:
+			action = ActionAdded
+		case diff.ResultOnlyInRemote:
+			action = ActionRemoved
+		default:
+			continue
+		}
+
+		object := ChangelogObject{Kind: result.Kind().Name, Path: result.Path(), Action: action}
+		for _, field := range result.ChangedFields.All() {
+			object.ChangedFields = append(object.ChangedFields, ChangelogField{Field: field.Name(), Paths: field.Paths(), Diff: field.Diff()})
+		}
+		out.Changes = append(out.Changes, object)
+	}
+	return out
+}
@@ -0,0 +1,111 @@
+package diff
+
+import (
+	"context"
+	"testing"
+
+	"github.com/keboola/go-client/pkg/keboola"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/diff"
+	"github.com/keboola/keboola-as-code/internal/pkg/env"
+	fixtures "github.com/keboola/keboola-as-code/internal/pkg/fixtures/local"
+	"github.com/keboola/keboola-as-code/internal/pkg/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/template"
+	"github.com/keboola/keboola-as-code/internal/pkg/template/repository"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/testapi"
+)
+
+func TestWithoutBranches(t *testing.T) {
+	t.Parallel()
+
+	branch := &diff.Result{ObjectState: branchState(), State: diff.ResultNotEqual, ChangedFields: model.NewChangedFields("metadata")}
+	config := &diff.Result{ObjectState: configState("api"), State: diff.ResultEqual}
+
+	// Only the branch is changed, so the results are equal
+	results := withoutBranches(&diff.Results{Equal: false, Results: []*diff.Result{branch, config}})
+	assert.True(t, results.Equal)
+	assert.Equal(t, []*diff.Result{config}, results.Results)
+
+	// A config is changed
+	changed := &diff.Result{ObjectState: configState("api"), State: diff.ResultOnlyInLocal}
+	results = withoutBranches(&diff.Results{Equal: false, Results: []*diff.Result{branch, changed}})
+	assert.False(t, results.Equal)
+	assert.Equal(t, []*diff.Result{changed}, results.Results)
+}
+
+func TestNewChangelog(t *testing.T) {
+	t.Parallel()
+
+	changedFields := model.NewChangedFields()
+	changedFields.Add("configuration").AddPath("parameters.api.retries").SetDiff("+ parameters.api.retries:\n+   3")
+	changedFields.Add("name").SetDiff("- old\n+ new")
+
+	results := &diff.Results{Results: []*diff.Result{
+		{ObjectState: configState("added"), State: diff.ResultOnlyInLocal},
+		{ObjectState: configState("api"), State: diff.ResultNotEqual, ChangedFields: changedFields},
+		{ObjectState: configState("equal"), State: diff.ResultEqual},
+		{ObjectState: configState("removed"), State: diff.ResultOnlyInRemote},
+	}}
+
+	changelog := newChangelog(testTemplate(t, "1.0.0"), testTemplate(t, "2.0.0"), "one", results)
+	assert.Equal(t, &Changelog{
+		TemplateID: "my-template",
+		From:       "1.0.0",
+		To:         "2.0.0",
+		Test:       "one",
+		Changes: []ChangelogObject{
+			{Kind: "config", Path: "main/extractor/ex-generic-v2/added", Action: ActionAdded},
+			{
+				Kind:   "config",
+				Path:   "main/extractor/ex-generic-v2/api",
+				Action: ActionChanged,
+				ChangedFields: []ChangelogField{
+					{Field: "configuration", Paths: "parameters.api.retries", Diff: "+ parameters.api.retries:\n+   3"},
+					{Field: "name", Diff: "- old\n+ new"},
+				},
+			},
+			{Kind: "config", Path: "main/extractor/ex-generic-v2/removed", Action: ActionRemoved},
+		},
+	}, changelog)
+
+	// No change
+	changelog = newChangelog(testTemplate(t, "1.0.0"), testTemplate(t, "1.0.0"), "one", &diff.Results{Equal: true})
+	assert.Empty(t, changelog.Changes)
+	assert.NotNil(t, changelog.Changes)
+}
+
+func branchState() *model.BranchState {
+	key := model.BranchKey{ID: 123}
+	return &model.BranchState{
+		BranchManifest: &model.BranchManifest{BranchKey: key, Paths: model.Paths{AbsPath: model.NewAbsPath("", "main")}},
+		Local:          &model.Branch{BranchKey: key, Name: "Main"},
+	}
+}
+
+func configState(name string) *model.ConfigState {
+	key := model.ConfigKey{BranchID: 123, ComponentID: "ex-generic-v2", ID: keboola.ConfigID("my-" + name)}
+	return &model.ConfigState{
+		ConfigManifest: &model.ConfigManifest{ConfigKey: key, Paths: model.Paths{AbsPath: model.NewAbsPath("main", "extractor/ex-generic-v2/"+name)}},
+		Local:          &model.Config{ConfigKey: key, Name: name},
+	}
+}
+
+func testTemplate(t *testing.T, versionStr string) *template.Template {
+	t.Helper()
+	ctx := context.Background()
+
+	fs, err := fixtures.LoadFS(ctx, "template-simple", env.Empty())
+	require.NoError(t, err)
+
+	version, err := model.NewSemVersion(versionStr)
+	require.NoError(t, err)
+	tmplRef := model.NewTemplateRef(model.TemplateRepository{Name: "keboola"}, "my-template", version.String())
+	versionRec := repository.VersionRecord{Version: version, Stable: true, Path: "v1"}
+	tmplRec := repository.TemplateRecord{ID: tmplRef.TemplateID(), Name: "My Template", Path: "my-template", Versions: []repository.VersionRecord{versionRec}}
+
+	tmpl, err := template.New(ctx, tmplRef, tmplRec, versionRec, fs, fs, "", testapi.MockedComponentsMap())
+	require.NoError(t, err)
+	return tmpl
+}
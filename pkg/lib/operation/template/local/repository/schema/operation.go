@@ -0,0 +1,47 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/encoding/json"
+	"github.com/keboola/keboola-as-code/internal/pkg/filesystem"
+	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/telemetry"
+	"github.com/keboola/keboola-as-code/internal/pkg/template"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+type Options struct {
+	OutputFile string // optional path to the output file, if empty, the schema is printed to stdout
+}
+
+type dependencies interface {
+	Fs() filesystem.Fs
+	Logger() log.Logger
+	Telemetry() telemetry.Telemetry
+	Stdout() io.Writer
+}
+
+// Run exports inputs of the template version as a JSON Schema.
+func Run(ctx context.Context, tmpl *template.Template, o Options, d dependencies) (err error) {
+	ctx, span := d.Telemetry().Tracer().Start(ctx, "keboola.go.operation.template.local.repository.schema")
+	defer span.End(&err)
+
+	content, err := json.EncodeString(tmpl.Inputs().JSONSchema(), true)
+	if err != nil {
+		return errors.PrefixError(err, "cannot encode JSON Schema")
+	}
+
+	if o.OutputFile == "" {
+		_, err = fmt.Fprint(d.Stdout(), content)
+		return err
+	}
+
+	if err := d.Fs().WriteFile(ctx, filesystem.NewRawFile(o.OutputFile, content)); err != nil {
+		return err
+	}
+	d.Logger().Infof(ctx, `JSON Schema written to "%s".`, o.OutputFile)
+	return nil
+}
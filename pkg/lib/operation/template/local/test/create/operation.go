@@ -56,6 +56,7 @@ func Run(ctx context.Context, tmpl *template.Template, o Options, d dependencies
 		InstanceID:            template.InstanceIDForTest,
 		SkipEncrypt:           true,
 		SkipSecretsValidation: true,
+		SkipStorageValidation: true,
 	}
 	opResult, err := useTemplate.Run(ctx, prjState, tmpl, tmplOpts, testDeps)
 	if err != nil {
@@ -19,6 +19,7 @@ import (
 	"github.com/keboola/keboola-as-code/internal/pkg/telemetry"
 	"github.com/keboola/keboola-as-code/internal/pkg/template"
 	tmplTest "github.com/keboola/keboola-as-code/internal/pkg/template/test"
+	"github.com/keboola/keboola-as-code/internal/pkg/template/test/fakestorage"
 	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
 	"github.com/keboola/keboola-as-code/internal/pkg/utils/testhelper"
 	"github.com/keboola/keboola-as-code/internal/pkg/utils/testhelper/storageenvmock"
@@ -29,18 +30,31 @@ import (
 type Options struct {
 	LocalOnly  bool   // run local tests only
 	RemoteOnly bool   // run remote tests only
+	Offline    bool   // use the in-process fake Storage API instead of a test project, jobs are not run
 	TestName   string // run only selected test
 	Verbose    bool   // verbose output
 }
 
 type dependencies interface {
+	Components() *model.ComponentsMap
 	Process() *servicectx.Process
 	Logger() log.Logger
+	StorageAPIHost() string
 	Telemetry() telemetry.Telemetry
 	Stdout() io.Writer
 	Stderr() io.Writer
 }
 
+// testProject is a project for one test run, a real test project or the fake Storage API in the offline mode.
+type testProject struct {
+	state    *project.State
+	deps     *tmplTest.Dependencies
+	id       int
+	host     string
+	api      *keboola.AuthorizedAPI // nil in the offline mode
+	unlockFn func()
+}
+
 func Run(ctx context.Context, tmpl *template.Template, o Options, d dependencies) (err error) {
 	ctx, span := d.Telemetry().Tracer().Start(ctx, "keboola.go.operation.template.test.run")
 	defer span.End(&err)
@@ -72,7 +86,7 @@ func Run(ctx context.Context, tmpl *template.Template, o Options, d dependencies
 			if o.Verbose {
 				d.Logger().Infof(ctx, `%s %s local running`, tmpl.FullName(), test.Name())
 			}
-			if err := runLocalTest(ctx, test, tmpl, o.Verbose, d); err != nil {
+			if err := runLocalTest(ctx, test, tmpl, o, d); err != nil {
 				d.Logger().Errorf(ctx, `FAIL %s %s local`, tmpl.FullName(), test.Name())
 				errs.AppendWithPrefixf(err, `running local test "%s" for template "%s" failed`, test.Name(), tmpl.TemplateID())
 			} else {
@@ -84,7 +98,7 @@ func Run(ctx context.Context, tmpl *template.Template, o Options, d dependencies
 			if o.Verbose {
 				d.Logger().Infof(ctx, `%s %s remote running`, tmpl.FullName(), test.Name())
 			}
-			if err := runRemoteTest(ctx, test, tmpl, o.Verbose, d); err != nil {
+			if err := runRemoteTest(ctx, test, tmpl, o, d); err != nil {
 				d.Logger().Errorf(ctx, `FAIL %s %s remote`, tmpl.FullName(), test.Name())
 				errs.AppendWithPrefixf(err, `running remote test "%s" for template "%s" failed`, test.Name(), tmpl.TemplateID())
 			} else {
@@ -96,21 +110,15 @@ func Run(ctx context.Context, tmpl *template.Template, o Options, d dependencies
 	return errs.ErrorOrNil()
 }
 
-func runLocalTest(ctx context.Context, test *template.Test, tmpl *template.Template, verbose bool, d dependencies) error {
+func runLocalTest(ctx context.Context, test *template.Test, tmpl *template.Template, o Options, d dependencies) error {
 	branchID := 1
 
-	var logger log.Logger
-	if verbose {
-		logger = d.Logger()
-	} else {
-		logger = log.NewNopLogger()
-	}
-
-	prjState, testPrj, testDeps, unlockFn, err := tmplTest.PrepareProject(ctx, logger, d.Telemetry(), tmpl.ProjectsFilePath(), d.Stdout(), d.Stderr(), d.Process(), branchID, false)
+	testPrj, err := prepareProject(ctx, tmpl, o, d, branchID, false)
 	if err != nil {
 		return err
 	}
-	defer unlockFn()
+	defer testPrj.unlockFn()
+	prjState, testDeps := testPrj.state, testPrj.deps
 	d.Logger().Debugf(ctx, `Working directory set up.`)
 
 	// Read inputs and replace env vars
@@ -120,13 +128,22 @@ func runLocalTest(ctx context.Context, test *template.Test, tmpl *template.Templ
 	}
 	d.Logger().Debugf(ctx, `Inputs prepared.`)
 
+	// Mock project environment for Jsonnet functions, if the test defines it
+	branchKey := model.BranchKey{ID: keboola.BranchID(branchID)}
+	prjEnv, err := tmplTest.MockEnvironment(ctx, test, useTemplate.ProjectEnvironment(ctx, testDeps, branchKey))
+	if err != nil {
+		return err
+	}
+
 	// Use template
 	tmplOpts := useTemplate.Options{
-		InstanceName: "test",
-		TargetBranch: model.BranchKey{ID: keboola.BranchID(branchID)},
-		Inputs:       inputValues,
-		InstanceID:   template.InstanceIDForTest,
-		SkipEncrypt:  true,
+		InstanceName:          "test",
+		TargetBranch:          branchKey,
+		Inputs:                inputValues,
+		InstanceID:            template.InstanceIDForTest,
+		SkipEncrypt:           true,
+		SkipStorageValidation: true,
+		Environment:           prjEnv,
 	}
 	_, err = useTemplate.Run(ctx, prjState, tmpl, tmplOpts, testDeps)
 	if err != nil {
@@ -139,8 +156,8 @@ func runLocalTest(ctx context.Context, test *template.Test, tmpl *template.Templ
 		return err
 	}
 	replaceEnvs := env.Empty()
-	replaceEnvs.Set("STORAGE_API_HOST", testPrj.StorageAPIHost())
-	replaceEnvs.Set("PROJECT_ID", strconv.Itoa(testPrj.ID()))
+	replaceEnvs.Set("STORAGE_API_HOST", testPrj.host)
+	replaceEnvs.Set("PROJECT_ID", strconv.Itoa(testPrj.id))
 	replaceEnvs.Set("MAIN_BRANCH_ID", strconv.Itoa(branchID))
 	envProvider := storageenvmock.CreateStorageEnvMockTicketProvider(ctx, replaceEnvs)
 	err = testhelper.ReplaceEnvsDir(ctx, prjState.Fs(), `/`, envProvider)
@@ -165,19 +182,13 @@ func runLocalTest(ctx context.Context, test *template.Test, tmpl *template.Templ
 	return testhelper.DirectoryContentsSame(ctx, expectedDirFs, `/`, prjState.Fs(), `/`)
 }
 
-func runRemoteTest(ctx context.Context, test *template.Test, tmpl *template.Template, verbose bool, d dependencies) error {
-	var logger log.Logger
-	if verbose {
-		logger = d.Logger()
-	} else {
-		logger = log.NewNopLogger()
-	}
-
-	prjState, testPrj, testDeps, unlockFn, err := tmplTest.PrepareProject(ctx, logger, d.Telemetry(), tmpl.ProjectsFilePath(), d.Stdout(), d.Stderr(), d.Process(), 0, true)
+func runRemoteTest(ctx context.Context, test *template.Test, tmpl *template.Template, o Options, d dependencies) error {
+	testPrj, err := prepareProject(ctx, tmpl, o, d, 0, true)
 	if err != nil {
 		return err
 	}
-	defer unlockFn()
+	defer testPrj.unlockFn()
+	prjState, testDeps := testPrj.state, testPrj.deps
 	d.Logger().Debugf(ctx, `Working directory set up.`)
 
 	branchKey := prjState.MainBranch().BranchKey
@@ -194,11 +205,18 @@ func runRemoteTest(ctx context.Context, test *template.Test, tmpl *template.Temp
 		objectState.SetLocalState(deepcopy.Copy(objectState.RemoteState()).(model.Object))
 	}
 
+	// Mock project environment for Jsonnet functions, if the test defines it
+	prjEnv, err := tmplTest.MockEnvironment(ctx, test, useTemplate.ProjectEnvironment(ctx, testDeps, branchKey))
+	if err != nil {
+		return err
+	}
+
 	// Use template
 	tmplOpts := useTemplate.Options{
 		InstanceName: "test",
 		TargetBranch: branchKey,
 		Inputs:       inputValues,
+		Environment:  prjEnv,
 	}
 	opResult, err := useTemplate.Run(ctx, prjState, tmpl, tmplOpts, testDeps)
 	if err != nil {
@@ -211,8 +229,8 @@ func runRemoteTest(ctx context.Context, test *template.Test, tmpl *template.Temp
 		return err
 	}
 	replaceEnvs := env.Empty()
-	replaceEnvs.Set("STORAGE_API_HOST", testPrj.StorageAPIHost())
-	replaceEnvs.Set("PROJECT_ID", strconv.Itoa(testPrj.ID()))
+	replaceEnvs.Set("STORAGE_API_HOST", testPrj.host)
+	replaceEnvs.Set("PROJECT_ID", strconv.Itoa(testPrj.id))
 	replaceEnvs.Set("MAIN_BRANCH_ID", prjState.MainBranch().ID.String())
 	envProvider := storageenvmock.CreateStorageEnvMockTicketProvider(ctx, replaceEnvs)
 	err = testhelper.ReplaceEnvsDir(ctx, prjState.Fs(), `/`, envProvider)
@@ -258,8 +276,14 @@ func runRemoteTest(ctx context.Context, test *template.Test, tmpl *template.Temp
 		return err
 	}
 
+	// Jobs cannot be run in the offline mode
+	if testPrj.api == nil {
+		d.Logger().Infof(ctx, `Offline mode, the job of the main config "%s:%s" is not run.`, tmplInst.MainConfig.ComponentID, tmplInst.MainConfig.ConfigID)
+		return nil
+	}
+
 	// Run the mainConfig job
-	api := testPrj.ProjectAPI()
+	api := testPrj.api
 	job, err := api.NewCreateJobRequest(tmplInst.MainConfig.ComponentID).WithConfig(tmplInst.MainConfig.ConfigID).Send(ctx)
 	if err != nil {
 		return err
@@ -270,6 +294,30 @@ func runRemoteTest(ctx context.Context, test *template.Test, tmpl *template.Temp
 	return api.WaitForQueueJob(timeoutCtx, job.ID)
 }
 
+// prepareProject gets a test project from the projects file or, in the offline mode, creates the fake Storage API.
+func prepareProject(ctx context.Context, tmpl *template.Template, o Options, d dependencies, branchID int, remote bool) (*testProject, error) {
+	var logger log.Logger
+	if o.Verbose {
+		logger = d.Logger()
+	} else {
+		logger = log.NewNopLogger()
+	}
+
+	if o.Offline {
+		prjState, api, testDeps, err := tmplTest.PrepareOfflineProject(ctx, logger, d.Telemetry(), d.Stdout(), d.Stderr(), d.Process(), d.StorageAPIHost(), d.Components().All(), remote)
+		if err != nil {
+			return nil, err
+		}
+		return &testProject{state: prjState, deps: testDeps, id: fakestorage.ProjectID, host: api.Host(), unlockFn: func() {}}, nil
+	}
+
+	prjState, testPrj, testDeps, unlockFn, err := tmplTest.PrepareProject(ctx, logger, d.Telemetry(), tmpl.ProjectsFilePath(), d.Stdout(), d.Stderr(), d.Process(), branchID, remote)
+	if err != nil {
+		return nil, err
+	}
+	return &testProject{state: prjState, deps: testDeps, id: testPrj.ID(), host: testPrj.StorageAPIHost(), api: testPrj.ProjectAPI(), unlockFn: unlockFn}, nil
+}
+
 func reloadPrjState(ctx context.Context, prjState *project.State) error {
 	ok, localErr, remoteErr := prjState.Load(ctx, state.LoadOptions{LoadRemoteState: true})
 	if remoteErr != nil {
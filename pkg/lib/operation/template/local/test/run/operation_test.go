@@ -0,0 +1,73 @@
+package run
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/env"
+	fixtures "github.com/keboola/keboola-as-code/internal/pkg/fixtures/local"
+	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/model"
+	dependenciesPkg "github.com/keboola/keboola-as-code/internal/pkg/service/common/dependencies"
+	"github.com/keboola/keboola-as-code/internal/pkg/template"
+	"github.com/keboola/keboola-as-code/internal/pkg/template/repository"
+)
+
+func TestRun_Offline(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	logger := log.NewDebugLogger()
+	d := dependenciesPkg.NewMocked(t, ctx, dependenciesPkg.WithDebugLogger(logger), dependenciesPkg.WithStdout(io.Discard))
+
+	// No request is sent to the Storage API, the test project is backed by the fake Storage API
+	tmpl := loadTestTemplate(t, d.Components())
+	callsBefore := d.MockedHTTPTransport().GetTotalCallCount()
+	require.NoError(t, Run(ctx, tmpl, Options{Offline: true}, d))
+	assert.Equal(t, callsBefore, d.MockedHTTPTransport().GetTotalCallCount())
+
+	// Both tests passed, the job of the main config is not run
+	logs := logger.AllMessages()
+	assert.Contains(t, logs, `PASS keboola/my-template/0.0.1 one local`)
+	assert.Contains(t, logs, `PASS keboola/my-template/0.0.1 one remote`)
+	assert.Contains(t, logs, `Offline mode, the job of the main config \"ex-generic-v2:1001\" is not run.`)
+}
+
+func TestRun_Offline_Fail(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	d := dependenciesPkg.NewMocked(t, ctx, dependenciesPkg.WithStdout(io.Discard))
+
+	// Modify the expected output, so the test fails
+	tmpl := loadTestTemplate(t, d.Components())
+	fs := tmpl.Fs()
+	require.NoError(t, fs.Remove(ctx, "tests/one/expected-out/main/description.md"))
+
+	err := Run(ctx, tmpl, Options{Offline: true, LocalOnly: true}, d)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `running local test "one" for template "my-template" failed`)
+	}
+}
+
+func loadTestTemplate(t *testing.T, components *model.ComponentsMap) *template.Template {
+	t.Helper()
+	ctx := context.Background()
+
+	fs, err := fixtures.LoadFS(ctx, "template-with-test", env.Empty())
+	require.NoError(t, err)
+
+	version, err := model.NewSemVersion("0.0.1")
+	require.NoError(t, err)
+	tmplRef := model.NewTemplateRef(model.TemplateRepository{Name: "keboola"}, "my-template", version.String())
+	versionRec := repository.VersionRecord{Version: version, Stable: true, Path: "v0"}
+	tmplRec := repository.TemplateRecord{ID: tmplRef.TemplateID(), Name: "My Template", Path: "my-template", Versions: []repository.VersionRecord{versionRec}}
+
+	tmpl, err := template.New(ctx, tmplRef, tmplRec, versionRec, fs, fs, "", components)
+	require.NoError(t, err)
+	return tmpl
+}
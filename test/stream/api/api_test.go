@@ -76,6 +76,7 @@ func TestStreamApiE2E(t *testing.T) {
 			// Run the test
 			test.Run(
 				runner.WithInitProjectState(),
+				runner.WithAddEnvVarsFromFile(),
 				runner.WithRunAPIServerAndRequests(
 					binaryPath,
 					[]string{"api", "storage-writer"}, // start api component and storage-writer to detect the volume
@@ -49,14 +49,15 @@ func TestTemplatesApiE2E(t *testing.T) {
 			etcdClient := etcdhelper.ClientForTest(t, etcdCfg)
 
 			addEnvs := env.FromMap(map[string]string{
-				"TEMPLATES_DATADOG_ENABLED":  "false",
-				"TEMPLATES_NODE_ID":          "test-node",
-				"TEMPLATES_STORAGE_API_HOST": test.TestProject().StorageAPIHost(),
-				"TEMPLATES_ETCD_NAMESPACE":   etcdCfg.Namespace,
-				"TEMPLATES_ETCD_ENDPOINT":    etcdCfg.Endpoint,
-				"TEMPLATES_ETCD_USERNAME":    etcdCfg.Username,
-				"TEMPLATES_ETCD_PASSWORD":    etcdCfg.Password,
-				"TEMPLATES_API_PUBLIC_URL":   "https://templates.keboola.local",
+				"TEMPLATES_DATADOG_ENABLED":               "false",
+				"TEMPLATES_NODE_ID":                       "test-node",
+				"TEMPLATES_STORAGE_API_HOST":              test.TestProject().StorageAPIHost(),
+				"TEMPLATES_ETCD_NAMESPACE":                etcdCfg.Namespace,
+				"TEMPLATES_ETCD_ENDPOINT":                 etcdCfg.Endpoint,
+				"TEMPLATES_ETCD_USERNAME":                 etcdCfg.Username,
+				"TEMPLATES_ETCD_PASSWORD":                 etcdCfg.Password,
+				"TEMPLATES_API_PUBLIC_URL":                "https://templates.keboola.local",
+				"TEMPLATES_API_REPOSITORY_WEBHOOK_SECRET": "my-webhook-secret",
 			})
 
 			requestDecoratorFn := func(request *runner.APIRequestDef) {
@@ -81,6 +82,7 @@ func TestTemplatesApiE2E(t *testing.T) {
 			// Run the test
 			test.Run(
 				runner.WithInitProjectState(),
+				runner.WithAddEnvVarsFromFile(),
 				runner.WithRunAPIServerAndRequests(
 					binaryPath,
 					addArgs,